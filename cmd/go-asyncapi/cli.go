@@ -7,12 +7,11 @@ import (
 	"log/slog"
 	"os"
 	"path"
-	"time"
 
 	stdLog "log"
 
-	"github.com/bdragon300/go-asyncapi/assets"
 	"github.com/bdragon300/go-asyncapi/internal/log"
+	"github.com/bdragon300/go-asyncapi/internal/pipeline"
 	"github.com/bdragon300/go-asyncapi/internal/types"
 
 	chlog "github.com/charmbracelet/log"
@@ -20,23 +19,19 @@ import (
 	"github.com/alexflint/go-arg"
 )
 
-const (
-	defaultConfigFileName                   = "default_config.yaml"
-	defaultMainTemplateName                 = "main.tmpl"
-	defaultSubprocessLocatorShutdownTimeout = 3 * time.Second
-)
-
 var ErrWrongCliArgs = errors.New("cli args")
 
 type cli struct {
-	CodeCmd             *CodeCmd    `arg:"subcommand:code" help:"Generate the code"`
-	ClientCmd           *ClientCmd  `arg:"subcommand:client" help:"Build the client executable (requires Go toolchain installed)"`
-	InfraCmd            *InfraCmd   `arg:"subcommand:infra" help:"Generate the infrastructure setup files"`
-	DiagramCmd          *DiagramCmd `arg:"subcommand:diagram" help:"Generate the architecture diagram"`
-	UICmd               *UICmd      `arg:"subcommand:ui" help:"Generate and optionally serve the documentation"`
-	ListImplementations *struct{}   `arg:"subcommand:list-implementations" help:"Show all available protocol implementations"`
-	Verbose             int         `arg:"-v" help:"Verbose output: 1 (debug), 2 (trace)" placeholder:"LEVEL"`
-	Quiet               bool        `help:"Suppress the logging output"`
+	CodeCmd             *CodeCmd     `arg:"subcommand:code" help:"Generate the code"`
+	ClientCmd           *ClientCmd   `arg:"subcommand:client" help:"Build the client executable (requires Go toolchain installed)"`
+	InfraCmd            *InfraCmd    `arg:"subcommand:infra" help:"Generate the infrastructure setup files"`
+	DiagramCmd          *DiagramCmd  `arg:"subcommand:diagram" help:"Generate the architecture diagram"`
+	UICmd               *UICmd       `arg:"subcommand:ui" help:"Generate and optionally serve the documentation"`
+	DiffCmd             *DiffCmd     `arg:"subcommand:diff" help:"Compare two document versions and detect the breaking changes"`
+	ValidateCmd         *ValidateCmd `arg:"subcommand:validate" help:"Check the document for errors and common mistakes"`
+	ListImplementations *struct{}    `arg:"subcommand:list-implementations" help:"Show all available protocol implementations"`
+	Verbose             int          `arg:"-v" help:"Verbose output: 1 (debug), 2 (trace)" placeholder:"LEVEL"`
+	Quiet               bool         `help:"Suppress the logging output"`
 
 	ConfigFile string `arg:"-c,--config-file" help:"YAML configuration file path" placeholder:"FILE"`
 }
@@ -96,6 +91,10 @@ func main() {
 		err = cliDiagram(cliArgs.DiagramCmd, mergedConfig)
 	case cliArgs.UICmd != nil:
 		err = cliUI(cliArgs.UICmd, mergedConfig)
+	case cliArgs.DiffCmd != nil:
+		err = cliDiff(cliArgs.DiffCmd, mergedConfig)
+	case cliArgs.ValidateCmd != nil:
+		err = cliValidate(cliArgs.ValidateCmd, mergedConfig)
 	default:
 		cliParser.Fail("No subcommand specified. Try --help for more information")
 		os.Exit(1)
@@ -117,11 +116,11 @@ func main() {
 	chlog.Info("Done")
 }
 
-func loadFullConfig(cliArgs cli) (toolConfig, error) {
+func loadFullConfig(cliArgs cli) (pipeline.Config, error) {
 	logger := log.GetLogger("")
-	builtinConfig, err := loadConfig(assets.AssetFS, defaultConfigFileName)
+	builtinConfig, err := pipeline.DefaultConfig()
 	if err != nil {
-		return toolConfig{}, fmt.Errorf("load built-in config, this is a bug: %w", err)
+		return pipeline.Config{}, fmt.Errorf("load built-in config, this is a bug: %w", err)
 	}
 
 	fileName := cliArgs.ConfigFile
@@ -133,15 +132,15 @@ func loadFullConfig(cliArgs cli) (toolConfig, error) {
 		}
 	}
 
-	var userConfig toolConfig
+	var userConfig pipeline.Config
 	if fileName != "" {
 		logger.Debug("Loading user config", "file", fileName)
-		if userConfig, err = loadConfig(os.DirFS(path.Dir(fileName)), path.Base(fileName)); err != nil {
-			return toolConfig{}, fmt.Errorf("load config file %q: %w", fileName, err)
+		if userConfig, err = pipeline.LoadConfig(os.DirFS(path.Dir(fileName)), path.Base(fileName)); err != nil {
+			return pipeline.Config{}, fmt.Errorf("load config file %q: %w", fileName, err)
 		}
 	} else {
 		logger.Debug("No user config, using only built-in defaults")
 	}
 
-	return mergeConfig(builtinConfig, userConfig), err
+	return pipeline.MergeConfig(builtinConfig, userConfig), err
 }
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/bdragon300/go-asyncapi/internal/log"
+	"github.com/bdragon300/go-asyncapi/internal/pipeline"
 	chlog "github.com/charmbracelet/log"
 	"github.com/samber/lo"
 )
@@ -43,7 +44,7 @@ type ClientCmd struct {
 	LocatorCommand  string        `arg:"--locator-command" help:"Custom locator command to use instead of built-in locator" placeholder:"COMMAND"`
 }
 
-func cliClient(cmd *ClientCmd, globalConfig toolConfig) error {
+func cliClient(cmd *ClientCmd, globalConfig pipeline.Config) error {
 	logger := log.GetLogger("")
 	cmdConfig := cliClientMergeConfig(globalConfig, cmd)
 
@@ -152,7 +153,7 @@ func runGoBuild(sourceFile, outputFile string) error {
 	return nil
 }
 
-func cliClientMergeConfig(globalConfig toolConfig, cmd *ClientCmd) toolConfig {
+func cliClientMergeConfig(globalConfig pipeline.Config, cmd *ClientCmd) pipeline.Config {
 	res := globalConfig
 
 	res.TemplatesDir = coalesce(cmd.TemplateDir, globalConfig.TemplatesDir)
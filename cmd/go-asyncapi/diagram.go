@@ -1,42 +1,18 @@
 package main
 
 import (
-	"bytes"
-	"context"
-	"errors"
 	"fmt"
-	"io/fs"
-	"log/slog"
-	"os"
-	"os/exec"
-	"path"
 	"slices"
 	"strings"
 	"time"
 
 	"github.com/bdragon300/go-asyncapi/internal/common"
-	"github.com/bdragon300/go-asyncapi/internal/compiler"
 	"github.com/bdragon300/go-asyncapi/internal/compiler/compile"
 	"github.com/bdragon300/go-asyncapi/internal/jsonpointer"
 	"github.com/bdragon300/go-asyncapi/internal/log"
-	"github.com/bdragon300/go-asyncapi/internal/renderer"
-	"github.com/bdragon300/go-asyncapi/internal/selector"
-	"github.com/bdragon300/go-asyncapi/internal/tmpl"
-	"github.com/bdragon300/go-asyncapi/internal/tmpl/manager"
-	"github.com/bdragon300/go-asyncapi/internal/types"
+	"github.com/bdragon300/go-asyncapi/internal/pipeline"
 	"github.com/bdragon300/go-asyncapi/internal/writer"
-	"github.com/bdragon300/go-asyncapi/templates/diagram"
 	"github.com/samber/lo"
-	"oss.terrastruct.com/d2/d2format"
-	"oss.terrastruct.com/d2/d2graph"
-	"oss.terrastruct.com/d2/d2lib"
-	"oss.terrastruct.com/d2/d2plugin"
-	"oss.terrastruct.com/d2/d2renderers/d2svg"
-	"oss.terrastruct.com/d2/d2target"
-	"oss.terrastruct.com/d2/d2themes"
-	"oss.terrastruct.com/d2/d2themes/d2themescatalog"
-	d2log "oss.terrastruct.com/d2/lib/log"
-	"oss.terrastruct.com/d2/lib/textmeasure"
 )
 
 type DiagramCmd struct {
@@ -90,7 +66,7 @@ type DiagramCmdD2DagreOptions struct {
 	EdgeSep *int64 `arg:"--d2-dagre-edge-sep" help:"Dagre engine: number of pixels that separate edges" placeholder:"PIXELS"`
 }
 
-func cliDiagram(cmd *DiagramCmd, globalConfig toolConfig) error {
+func cliDiagram(cmd *DiagramCmd, globalConfig pipeline.Config) error {
 	logger := log.GetLogger("")
 	cmdConfig, err := cliDiagramMergeConfig(globalConfig, cmd)
 	if err != nil {
@@ -100,7 +76,7 @@ func cliDiagram(cmd *DiagramCmd, globalConfig toolConfig) error {
 	//
 	// Compilation & linking
 	//
-	fileLocator := getLocator(cmdConfig)
+	fileLocator := pipeline.NewLocator(cmdConfig.Locator)
 	docURL, err := jsonpointer.Parse(cmd.Document)
 	if err != nil {
 		return fmt.Errorf("parse URL: %w", err)
@@ -110,7 +86,7 @@ func cliDiagram(cmd *DiagramCmd, globalConfig toolConfig) error {
 		GeneratePublishers:  true,
 		GenerateSubscribers: true,
 	}
-	documents, err := runCompilationAndLinking(fileLocator, docURL, compileOpts)
+	documents, err := pipeline.CompileAndLink(fileLocator, docURL, compileOpts)
 	if err != nil {
 		return fmt.Errorf("compilation: %w", err)
 	}
@@ -118,67 +94,9 @@ func cliDiagram(cmd *DiagramCmd, globalConfig toolConfig) error {
 	//
 	// Rendering
 	//
-	logger.Debug("Run objects rendering")
-	renderManager := manager.NewTemplateRenderManager(common.RenderOpts{})
-	templateDirs := []fs.FS{diagram.TemplateFS}
-	if cmdConfig.TemplatesDir != "" {
-		logger.Debug("Custom templates location", "directory", cmdConfig.TemplatesDir)
-		templateDirs = append(templateDirs, os.DirFS(cmdConfig.TemplatesDir))
-	}
-	tplLoader := tmpl.NewTemplateLoader(defaultMainTemplateName, templateDirs...)
-	logger.Trace("Parse templates", "dirs", templateDirs)
-	renderManager.TemplateLoader = tplLoader
-	if err = tplLoader.ParseRecursive(renderManager); err != nil {
-		return fmt.Errorf("parse templates: %w", err)
-	}
-
-	diagramConfig := getDiagramConfig(cmdConfig.Diagram)
-	fileExtension := "." + strings.ToLower(string(cmdConfig.Diagram.Format))
-	if cmdConfig.Diagram.MultipleFiles {
-		visibleArtifactsByDoc := lo.MapValues(documents, func(d *compiler.Document, _ string) []common.Artifact {
-			return lo.Filter(selector.GatherArtifacts(d), func(a common.Artifact, _ int) bool {
-				return a.Visible()
-			})
-		})
-		logger.Debug("Render multiple diagram files", "count", len(visibleArtifactsByDoc))
-		if err = renderer.RenderDiagramMultipleFiles(visibleArtifactsByDoc, fileExtension, diagramConfig, renderManager); err != nil {
-			return fmt.Errorf("render diagrams: %w", err)
-		}
-	} else {
-		allArtifacts := selector.GatherArtifacts(lo.Values(documents)...)
-		visibleArtifacts := lo.Filter(allArtifacts, func(a common.Artifact, _ int) bool {
-			return a.Visible()
-		})
-		logger.Debug("Rendering artifacts", "file", cmdConfig.Diagram.OutputFile, "allArtifacts", len(allArtifacts), "visibleArtifacts", len(visibleArtifacts))
-		fileName := cmdConfig.Diagram.OutputFile
-		if fileName == "" {
-			fileName = strings.TrimSuffix(path.Base(cmd.Document), path.Ext(cmd.Document)) + fileExtension
-		}
-		if err = renderer.RenderDiagramOneFile(visibleArtifacts, fileName, diagramConfig, renderManager); err != nil {
-			return fmt.Errorf("render diagram: %w", err)
-		}
-	}
-
-	//
-	// Finishing d2 rendering
-	//
-	logger.Debug("Finish the files rendering")
-	buffers, err := renderer.FinishFiles(renderManager)
+	buffers, err := pipeline.GenerateDiagram(cmdConfig, documents, cmd.Document)
 	if err != nil {
-		return fmt.Errorf("finish files: %w", err)
-	}
-	logger.Debug("Rendering finishing complete")
-
-	//
-	// Converting & formatting
-	//
-	needPostprocessing := cmdConfig.Diagram.Format != common.DiagramOutputFormatD2 || !cmdConfig.Diagram.DisableFormatting
-	if needPostprocessing {
-		logger.Debug("Run postprocessing", "files", len(buffers))
-		if buffers, err = postprocessD2Files(buffers, cmdConfig); err != nil {
-			return fmt.Errorf("postprocessing: %w", err)
-		}
-		logger.Debug("Postprocessing complete", "filesCount", len(buffers))
+		return err
 	}
 
 	//
@@ -192,44 +110,7 @@ func cliDiagram(cmd *DiagramCmd, globalConfig toolConfig) error {
 	return nil
 }
 
-func postprocessD2Files(buffers map[string]*bytes.Buffer, cmdConfig toolConfig) (map[string]*bytes.Buffer, error) {
-	for fileName, srcBuf := range buffers {
-		log.GetLogger("").Debug("Compiling d2 file", "file", fileName, "size", srcBuf.Len(), "opts", cmdConfig.Diagram.D2)
-		diagramObj, graphObj, err := compileD2(srcBuf.Bytes(), cmdConfig.Diagram.D2)
-		if err != nil {
-			return nil, types.MultilineError{
-				Err:     fmt.Errorf("compile d2: %w", err),
-				Content: srcBuf.Bytes(),
-			}
-		}
-
-		switch {
-		case cmdConfig.Diagram.Format == common.DiagramOutputFormatSVG:
-			log.GetLogger(log.LoggerPrefixRendering).Debug("-> Converting D2 diagram to SVG", "file", fileName)
-			renderOpts, err := getD2RenderOpts(cmdConfig.Diagram.D2)
-			if err != nil {
-				return nil, fmt.Errorf("d2 render options: %w", err)
-			}
-			newBuf, err := d2svg.Render(diagramObj, &renderOpts)
-			if err != nil {
-				return nil, types.MultilineError{
-					Err:     fmt.Errorf("render svg: %w", err),
-					Content: srcBuf.Bytes(),
-				}
-			}
-			buffers[fileName].Reset()
-			buffers[fileName].Write(newBuf)
-
-		case !cmdConfig.Diagram.DisableFormatting:
-			log.GetLogger(log.LoggerPrefixFormatting).Debug("-> Formatting D2 file", "file", fileName)
-			newBuf := []byte(d2format.Format(graphObj.BaseAST))
-			buffers[fileName] = bytes.NewBuffer(newBuf)
-		}
-	}
-	return buffers, nil
-}
-
-func cliDiagramMergeConfig(globalConfig toolConfig, cmd *DiagramCmd) (toolConfig, error) {
+func cliDiagramMergeConfig(globalConfig pipeline.Config, cmd *DiagramCmd) (pipeline.Config, error) {
 	res := globalConfig
 
 	formats := []common.DiagramOutputFormat{
@@ -254,13 +135,13 @@ func cliDiagramMergeConfig(globalConfig toolConfig, cmd *DiagramCmd) (toolConfig
 	}
 	res.Diagram.DocumentBorders = coalesce(cmd.DocumentBorders, globalConfig.Diagram.DocumentBorders)
 
-	engines := []d2DiagramEngine{D2DiagramEngineELK, D2DiagramEngineDagre}
-	res.Diagram.D2.Engine = coalesce(d2DiagramEngine(cmd.Engine), globalConfig.Diagram.D2.Engine)
+	engines := []pipeline.D2DiagramEngine{pipeline.D2DiagramEngineELK, pipeline.D2DiagramEngineDagre}
+	res.Diagram.D2.Engine = coalesce(pipeline.D2DiagramEngine(cmd.Engine), globalConfig.Diagram.D2.Engine)
 	if !slices.Contains(engines, res.Diagram.D2.Engine) {
 		return res, fmt.Errorf(
 			"unknown D2 diagram engine %q, possible values: %s",
 			cmd.Engine,
-			strings.Join(lo.Map(engines, func(e d2DiagramEngine, _ int) string { return string(e) }), ", "),
+			strings.Join(lo.Map(engines, func(e pipeline.D2DiagramEngine, _ int) string { return string(e) }), ", "),
 		)
 	}
 	directions := []common.D2DiagramDirection{
@@ -306,101 +187,3 @@ func cliDiagramMergeConfig(globalConfig toolConfig, cmd *DiagramCmd) (toolConfig
 
 	return res, nil
 }
-
-func getDiagramConfig(conf toolConfigDiagram) common.DiagramRenderOpts {
-	return common.DiagramRenderOpts{
-		ShowChannels:        !conf.ServersCentric,
-		ShowServers:         !conf.ChannelsCentric,
-		ShowDocumentBorders: conf.DocumentBorders,
-		D2DiagramDirection:  conf.D2.Direction,
-	}
-}
-
-func compileD2(contents []byte, d2opts toolConfigDiagramD2Opts) (*d2target.Diagram, *d2graph.Graph, error) {
-	engine := string(d2opts.Engine)
-
-	ctx := d2log.With(context.Background(), slog.Default())
-	ruler, err := textmeasure.NewRuler()
-	if err != nil {
-		return nil, nil, fmt.Errorf("create d2 text ruler: %w", err)
-	}
-	plugins, err := d2plugin.ListPlugins(ctx)
-	if err != nil {
-		return nil, nil, fmt.Errorf("list plugins: %w", err)
-	}
-	compileOpts := &d2lib.CompileOptions{
-		Layout:         lo.ToPtr(engine),
-		LayoutResolver: layoutResolver(ctx, plugins, d2opts),
-		Ruler:          ruler,
-	}
-	renderOpts, err := getD2RenderOpts(d2opts)
-	if err != nil {
-		return nil, nil, fmt.Errorf("d2 render options: %w", err)
-	}
-
-	diagramObj, graphObj, err := d2lib.Compile(ctx, string(contents), compileOpts, &renderOpts)
-	if err != nil {
-		return nil, nil, fmt.Errorf("d2 compile: %w", err)
-	}
-	return diagramObj, graphObj, nil
-}
-
-func layoutResolver(ctx context.Context, plugins []d2plugin.Plugin, d2opts toolConfigDiagramD2Opts) func(engine string) (d2graph.LayoutGraph, error) {
-	cached := make(map[string]d2graph.LayoutGraph)
-	return func(engine string) (d2graph.LayoutGraph, error) {
-		if c, ok := cached[engine]; ok {
-			return c, nil
-		}
-
-		plugin, err := d2plugin.FindPlugin(ctx, plugins, engine)
-		if err != nil {
-			if errors.Is(err, exec.ErrNotFound) {
-				return nil, fmt.Errorf("layout engine %q not found", engine)
-			}
-			return nil, err
-		}
-
-		var engineOpts []byte
-		switch d2opts.Engine {
-		case D2DiagramEngineELK:
-			if engineOpts, err = d2opts.ELK.ToD2PluginOpts(); err != nil {
-				return nil, fmt.Errorf("to %s options: %w", engine, err)
-			}
-		case D2DiagramEngineDagre:
-			if engineOpts, err = d2opts.Dagre.ToD2PluginOpts(); err != nil {
-				return nil, fmt.Errorf("to %s options: %w", engine, err)
-			}
-		default:
-			return nil, fmt.Errorf("unknown D2 engine: %q", d2opts.Engine)
-		}
-		if err = plugin.HydrateOpts(engineOpts); err != nil {
-			return nil, fmt.Errorf("hydrate %q engine options: %w", engine, err)
-		}
-
-		cached[engine] = plugin.Layout
-		return plugin.Layout, nil
-	}
-}
-
-func getD2RenderOpts(d2opts toolConfigDiagramD2Opts) (d2svg.RenderOpts, error) {
-	if d2opts.DarkThemeID != nil {
-		match := d2themescatalog.Find(*d2opts.DarkThemeID)
-		if match == (d2themes.Theme{}) {
-			return d2svg.RenderOpts{}, fmt.Errorf("dark theme not found. The available options are:\n%s", d2themescatalog.CLIString())
-		}
-	}
-	if d2opts.ThemeID != nil {
-		match := d2themescatalog.Find(*d2opts.ThemeID)
-		if match == (d2themes.Theme{}) {
-			return d2svg.RenderOpts{}, fmt.Errorf("theme not found. The available options are:\n%s", d2themescatalog.CLIString())
-		}
-	}
-	return d2svg.RenderOpts{
-		Pad:         d2opts.Pad,
-		Sketch:      d2opts.Sketch,
-		Center:      d2opts.Center,
-		ThemeID:     coalesce(d2opts.ThemeID, d2opts.DarkThemeID),
-		DarkThemeID: d2opts.DarkThemeID,
-		Scale:       d2opts.Scale,
-	}, nil
-}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bdragon300/go-asyncapi/internal/compiler/compile"
+	"github.com/bdragon300/go-asyncapi/internal/differ"
+	"github.com/bdragon300/go-asyncapi/internal/jsonpointer"
+	"github.com/bdragon300/go-asyncapi/internal/log"
+	"github.com/bdragon300/go-asyncapi/internal/pipeline"
+	"github.com/samber/lo"
+)
+
+const (
+	diffFormatText = "text"
+	diffFormatJSON = "json"
+)
+
+type DiffCmd struct {
+	OldDocument string `arg:"required,positional" help:"Old AsyncAPI document file or url" placeholder:"OLD_FILE"`
+	NewDocument string `arg:"required,positional" help:"New AsyncAPI document file or url" placeholder:"NEW_FILE"`
+	Format      string `arg:"-f,--format" help:"Output format. Possible values: text, json" default:"text" placeholder:"FORMAT"`
+	OutputFile  string `arg:"-o,--output" help:"Output file path. By default, the report is written to stdout" placeholder:"FILE"`
+
+	AllowRemoteRefs bool          `arg:"--allow-remote-refs" help:"Allow locator to fetch the files from remote $ref URLs"`
+	LocatorRootDir  string        `arg:"--locator-root-dir" help:"Root directory to search the documents" placeholder:"PATH"`
+	LocatorTimeout  time.Duration `arg:"--locator-timeout" help:"Timeout for locator to read a document. Format: 30s, 2m, etc." placeholder:"DURATION"`
+	LocatorCommand  string        `arg:"--locator-command" help:"Custom locator command to use instead of built-in locator" placeholder:"COMMAND"`
+}
+
+func cliDiff(cmd *DiffCmd, globalConfig pipeline.Config) error {
+	logger := log.GetLogger("")
+	cmdConfig := cliDiffMergeConfig(globalConfig, cmd)
+	formats := []string{diffFormatText, diffFormatJSON}
+	if !slices.Contains(formats, cmd.Format) {
+		return fmt.Errorf("%w: unknown format %q, possible values: %s", ErrWrongCliArgs, cmd.Format, strings.Join(formats, ", "))
+	}
+
+	//
+	// Compilation & linking
+	//
+	fileLocator := pipeline.NewLocator(cmdConfig.Locator)
+	compileOpts := compile.CompilationOpts{
+		AllowRemoteRefs:     cmdConfig.Locator.AllowRemoteReferences,
+		GeneratePublishers:  true,
+		GenerateSubscribers: true,
+	}
+	oldURL, err := jsonpointer.Parse(cmd.OldDocument)
+	if err != nil {
+		return fmt.Errorf("parse URL: %w", err)
+	}
+	newURL, err := jsonpointer.Parse(cmd.NewDocument)
+	if err != nil {
+		return fmt.Errorf("parse URL: %w", err)
+	}
+	logger.Debug("Compile the old document", "url", oldURL)
+	oldDocuments, err := pipeline.CompileAndLink(fileLocator, oldURL, compileOpts)
+	if err != nil {
+		return fmt.Errorf("old document compilation: %w", err)
+	}
+	logger.Debug("Compile the new document", "url", newURL)
+	newDocuments, err := pipeline.CompileAndLink(fileLocator, newURL, compileOpts)
+	if err != nil {
+		return fmt.Errorf("new document compilation: %w", err)
+	}
+
+	//
+	// Comparing
+	//
+	logger.Debug("Run comparing")
+	changes := differ.Compare(oldDocuments, newDocuments)
+	logger.Debug("Comparing complete", "changes", len(changes))
+
+	var out io.Writer = os.Stdout
+	if cmd.OutputFile != "" {
+		f, err := os.Create(cmd.OutputFile)
+		if err != nil {
+			return fmt.Errorf("create output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+	switch cmd.Format {
+	case diffFormatJSON:
+		err = differ.WriteJSON(out, changes)
+	default:
+		err = differ.WriteText(out, changes)
+	}
+	if err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+
+	if differ.HasBreaking(changes) {
+		return fmt.Errorf("found %d breaking change(s)", lo.CountBy(changes, func(c differ.Change) bool { return c.Breaking }))
+	}
+	return nil
+}
+
+func cliDiffMergeConfig(globalConfig pipeline.Config, cmd *DiffCmd) pipeline.Config {
+	res := globalConfig
+
+	res.Locator.AllowRemoteReferences = coalesce(cmd.AllowRemoteRefs, res.Locator.AllowRemoteReferences)
+	res.Locator.RootDirectory = coalesce(cmd.LocatorRootDir, res.Locator.RootDirectory)
+	res.Locator.Timeout = coalesce(cmd.LocatorTimeout, res.Locator.Timeout)
+	res.Locator.Command = coalesce(cmd.LocatorCommand, res.Locator.Command)
+
+	return res
+}
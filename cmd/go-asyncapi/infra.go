@@ -3,28 +3,25 @@ package main
 import (
 	"fmt"
 	"io"
-	"io/fs"
+	"maps"
 	"os"
+	"path"
+	"slices"
 	"time"
 
 	"github.com/bdragon300/go-asyncapi/internal/compiler/compile"
-	"github.com/bdragon300/go-asyncapi/internal/selector"
+	"github.com/bdragon300/go-asyncapi/internal/pipeline"
 
-	"github.com/bdragon300/go-asyncapi/internal/common"
 	"github.com/bdragon300/go-asyncapi/internal/jsonpointer"
 	"github.com/bdragon300/go-asyncapi/internal/log"
-	"github.com/bdragon300/go-asyncapi/internal/renderer"
-	"github.com/bdragon300/go-asyncapi/internal/tmpl"
-	"github.com/bdragon300/go-asyncapi/internal/tmpl/manager"
-	"github.com/bdragon300/go-asyncapi/templates/infra"
 	"github.com/samber/lo"
 )
 
 type InfraCmd struct {
 	Document string `arg:"required,positional" help:"AsyncAPI document file or url" placeholder:"FILE"`
 
-	Engine     string `arg:"-e,--engine" help:"Target infra engine" placeholder:"NAME"`
-	OutputFile string `arg:"-o,--output" help:"Output file path or '-' to print to stdout. If omitted, the file name depends on selected engine" placeholder:"FILE"`
+	Engine     string `arg:"-e,--engine" help:"Target infra engine. Possible values: docker, kubernetes, helm" placeholder:"NAME"`
+	OutputFile string `arg:"-o,--output" help:"Output file path (chart directory for helm) or '-' to print to stdout. If omitted, the file name depends on selected engine" placeholder:"FILE"`
 
 	TemplateDir     string        `arg:"-T,--template-dir" help:"User templates directory" placeholder:"DIR"`
 	AllowRemoteRefs bool          `arg:"--allow-remote-refs" help:"Allow locator to fetch the files from remote $ref URLs"`
@@ -33,7 +30,7 @@ type InfraCmd struct {
 	LocatorCommand  string        `arg:"--locator-command" help:"Custom locator command to use instead of built-in locator" placeholder:"COMMAND"`
 }
 
-func cliInfra(cmd *InfraCmd, globalConfig toolConfig) error {
+func cliInfra(cmd *InfraCmd, globalConfig pipeline.Config) error {
 	logger := log.GetLogger("")
 	cmdConfig, err := cliInfraMergeConfig(globalConfig, cmd)
 	if err != nil {
@@ -43,7 +40,7 @@ func cliInfra(cmd *InfraCmd, globalConfig toolConfig) error {
 	//
 	// Compilation & linking
 	//
-	fileLocator := getLocator(cmdConfig)
+	fileLocator := pipeline.NewLocator(cmdConfig.Locator)
 	docURL, err := jsonpointer.Parse(cmd.Document)
 	if err != nil {
 		return fmt.Errorf("parse URL: %w", err)
@@ -53,7 +50,7 @@ func cliInfra(cmd *InfraCmd, globalConfig toolConfig) error {
 		GeneratePublishers:  true,
 		GenerateSubscribers: true,
 	}
-	documents, err := runCompilationAndLinking(fileLocator, docURL, compileOpts)
+	documents, err := pipeline.CompileAndLink(fileLocator, docURL, compileOpts)
 	if err != nil {
 		return fmt.Errorf("compilation: %w", err)
 	}
@@ -61,51 +58,32 @@ func cliInfra(cmd *InfraCmd, globalConfig toolConfig) error {
 	//
 	// Rendering
 	//
-	activeProtocols := collectActiveServersProtocols(documents)
-	logger.Debug("Collected active servers protocols", "value", activeProtocols)
-
-	// TODO: refactor RenderOpts -- it almost not needed here, it's related to codegen.
-	//       Also consider to include add InfraServerOpts (replace RenderOpts to interface in manager?)
-	renderOpts, err := getRenderOpts(cmdConfig, cmdConfig.Code.TargetDir, false)
+	buffers, err := pipeline.GenerateInfra(cmdConfig, documents)
 	if err != nil {
-		return fmt.Errorf("%w: %w", ErrWrongCliArgs, err)
-	}
-	renderManager := manager.NewTemplateRenderManager(renderOpts)
-
-	// Document objects
-	logger.Debug("Run objects rendering")
-	templateDirs := []fs.FS{infra.TemplateFS}
-	if cmdConfig.TemplatesDir != "" {
-		logger.Debug("Custom templates location", "directory", cmdConfig.TemplatesDir)
-		templateDirs = append(templateDirs, os.DirFS(cmdConfig.TemplatesDir))
-	}
-	tplLoader := tmpl.NewTemplateLoader(defaultMainTemplateName, templateDirs...)
-	logger.Trace("Parse templates", "dirs", templateDirs)
-	renderManager.TemplateLoader = tplLoader
-	if err = tplLoader.ParseRecursive(renderManager); err != nil {
-		return fmt.Errorf("parse templates: %w", err)
+		return err
 	}
-	allArtifacts := selector.GatherArtifacts(lo.Values(documents)...)
-	visibleArtifacts := lo.Filter(allArtifacts, func(a common.Artifact, _ int) bool {
-		return a.Visible()
-	})
-	logger.Debug("Rendering the artifacts", "allArtifacts", len(allArtifacts), "visibleArtifacts", len(visibleArtifacts))
+	fileNames := slices.Sorted(maps.Keys(buffers))
 
-	serverConfig := getInfraServerConfig(cmdConfig.Infra.ServerOpts)
-
-	if err = renderer.RenderInfra(visibleArtifacts, activeProtocols, cmdConfig.Infra.OutputFile, serverConfig, renderManager); err != nil {
-		return fmt.Errorf("render infra: %w", err)
-	}
-
-	states := renderManager.CommittedStates()
-	outBuf := states[cmdConfig.Infra.OutputFile].Buffer
 	if cmdConfig.Infra.OutputFile == "-" {
 		logger.Info("Output file to stdout")
-		lo.Must(os.Stdout.ReadFrom(outBuf))
+		for _, fileName := range fileNames {
+			if len(fileNames) > 1 {
+				lo.Must(fmt.Fprintf(os.Stdout, "# Source: %s\n", fileName))
+			}
+			lo.Must(os.Stdout.ReadFrom(buffers[fileName]))
+		}
 		return nil
 	}
 
-	return writeToFile(cmdConfig.Infra.OutputFile, outBuf)
+	for _, fileName := range fileNames {
+		if err = os.MkdirAll(path.Dir(fileName), 0o755); err != nil {
+			return fmt.Errorf("create directory: %w", err)
+		}
+		if err = writeToFile(fileName, buffers[fileName]); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func writeToFile(fileName string, buf io.Reader) error {
@@ -127,7 +105,7 @@ func writeToFile(fileName string, buf io.Reader) error {
 	return nil
 }
 
-func cliInfraMergeConfig(globalConfig toolConfig, cmd *InfraCmd) (toolConfig, error) {
+func cliInfraMergeConfig(globalConfig pipeline.Config, cmd *InfraCmd) (pipeline.Config, error) {
 	res := globalConfig
 
 	res.TemplatesDir = coalesce(cmd.TemplateDir, globalConfig.TemplatesDir)
@@ -135,10 +113,14 @@ func cliInfraMergeConfig(globalConfig toolConfig, cmd *InfraCmd) (toolConfig, er
 	res.Infra.Engine = coalesce(cmd.Engine, globalConfig.Infra.Engine)
 	var outputFile string
 	switch res.Infra.Engine {
-	case "docker":
+	case pipeline.InfraEngineDocker:
 		outputFile = "./docker-compose.yaml"
+	case pipeline.InfraEngineKubernetes:
+		outputFile = "./kubernetes.yaml"
+	case pipeline.InfraEngineHelm:
+		outputFile = "./chart"
 	default:
-		return res, fmt.Errorf("unknown engine: %s", cmd.Engine)
+		return res, fmt.Errorf("unknown engine: %s", res.Infra.Engine)
 	}
 	res.Infra.OutputFile = coalesce(cmd.OutputFile, outputFile)
 
@@ -149,37 +131,3 @@ func cliInfraMergeConfig(globalConfig toolConfig, cmd *InfraCmd) (toolConfig, er
 
 	return res, nil
 }
-
-func getInfraServerConfig(opts []toolConfigInfraServerOpt) []common.InfraServerOpts {
-	res := make([]common.InfraServerOpts, 0)
-
-	for _, opt := range opts {
-		switch opt.Variables.Selector {
-		case 0:
-			var varGroups [][]common.InfraServerVariableOpts
-			for k, v := range opt.Variables.V0.Entries() {
-				varGroups = append(varGroups, []common.InfraServerVariableOpts{
-					{Name: k, Value: v},
-				})
-			}
-			res = append(res, common.InfraServerOpts{
-				ServerName:     opt.ServerName,
-				VariableGroups: varGroups,
-			})
-		case 1:
-			var varGroups [][]common.InfraServerVariableOpts
-			for _, g := range opt.Variables.V1 {
-				for k, v := range g.Entries() {
-					varGroups = append(varGroups, []common.InfraServerVariableOpts{
-						{Name: k, Value: v},
-					})
-				}
-			}
-			res = append(res, common.InfraServerOpts{
-				ServerName:     opt.ServerName,
-				VariableGroups: varGroups,
-			})
-		}
-	}
-	return res
-}
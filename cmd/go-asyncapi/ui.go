@@ -16,6 +16,7 @@ import (
 	"github.com/bdragon300/go-asyncapi/internal/compiler"
 	"github.com/bdragon300/go-asyncapi/internal/jsonpointer"
 	"github.com/bdragon300/go-asyncapi/internal/log"
+	"github.com/bdragon300/go-asyncapi/internal/pipeline"
 	"github.com/bdragon300/go-asyncapi/internal/renderer"
 	"github.com/bdragon300/go-asyncapi/internal/tmpl"
 	"github.com/bdragon300/go-asyncapi/internal/tmpl/manager"
@@ -46,7 +47,7 @@ type UICmd struct {
 	TemplatesDir string `arg:"-T,--templates-dir" help:"User templates directory" placeholder:"DIR"`
 }
 
-func cliUI(cmd *UICmd, globalConfig toolConfig) error {
+func cliUI(cmd *UICmd, globalConfig pipeline.Config) error {
 	logger := log.GetLogger("")
 	cmdConfig, err := cliUIMergeConfig(globalConfig, cmd)
 	if err != nil {
@@ -57,7 +58,7 @@ func cliUI(cmd *UICmd, globalConfig toolConfig) error {
 		return fmt.Errorf("ui bundle directory is set but bundling is disabled. Use --bundle flag to enable bundling")
 	}
 
-	locator := getLocator(cmdConfig)
+	locator := pipeline.NewLocator(cmdConfig.Locator)
 	docURL, err := jsonpointer.Parse(cmd.Document)
 	if err != nil {
 		return fmt.Errorf("parse URL: %w", err)
@@ -86,7 +87,7 @@ func cliUI(cmd *UICmd, globalConfig toolConfig) error {
 		logger.Debug("Custom templates location", "directory", cmdConfig.TemplatesDir)
 		templateDirs = append(templateDirs, os.DirFS(cmdConfig.TemplatesDir))
 	}
-	tplLoader := tmpl.NewTemplateLoader(defaultMainTemplateName, templateDirs...)
+	tplLoader := tmpl.NewTemplateLoader(pipeline.DefaultMainTemplateName, templateDirs...)
 	logger.Trace("Parse templates", "dirs", templateDirs)
 	renderManager.TemplateLoader = tplLoader
 	if err = tplLoader.ParseRecursive(renderManager); err != nil {
@@ -316,7 +317,7 @@ func serveUI(address, listenPath string, files map[string]servingUIContent) erro
 	return nil
 }
 
-func cliUIMergeConfig(globalConfig toolConfig, cmd *UICmd) (toolConfig, error) {
+func cliUIMergeConfig(globalConfig pipeline.Config, cmd *UICmd) (pipeline.Config, error) {
 	res := globalConfig
 
 	res.TemplatesDir = coalesce(cmd.TemplatesDir, globalConfig.TemplatesDir)
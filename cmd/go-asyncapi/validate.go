@@ -0,0 +1,183 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bdragon300/go-asyncapi/internal/compiler"
+	"github.com/bdragon300/go-asyncapi/internal/compiler/compile"
+	"github.com/bdragon300/go-asyncapi/internal/jsonpointer"
+	"github.com/bdragon300/go-asyncapi/internal/linker"
+	"github.com/bdragon300/go-asyncapi/internal/lint"
+	"github.com/bdragon300/go-asyncapi/internal/log"
+	"github.com/bdragon300/go-asyncapi/internal/pipeline"
+	"github.com/bdragon300/go-asyncapi/internal/types"
+	"github.com/samber/lo"
+)
+
+const (
+	validateFormatText  = "text"
+	validateFormatJSON  = "json"
+	validateFormatSARIF = "sarif"
+)
+
+type ValidateCmd struct {
+	Document   string `arg:"required,positional" help:"AsyncAPI document file or url" placeholder:"FILE"`
+	Format     string `arg:"-f,--format" help:"Output format. Possible values: text, json, sarif" default:"text" placeholder:"FORMAT"`
+	OutputFile string `arg:"-o,--output" help:"Output file path. By default, the report is written to stdout" placeholder:"FILE"`
+
+	TemplateDir     string        `arg:"-T,--template-dir" help:"User templates directory, used to check the content type encoders" placeholder:"DIR"`
+	AllowRemoteRefs bool          `arg:"--allow-remote-refs" help:"Allow locator to fetch the files from remote $ref URLs"`
+	LocatorRootDir  string        `arg:"--locator-root-dir" help:"Root directory to search the documents" placeholder:"PATH"`
+	LocatorTimeout  time.Duration `arg:"--locator-timeout" help:"Timeout for locator to read a document. Format: 30s, 2m, etc." placeholder:"DURATION"`
+	LocatorCommand  string        `arg:"--locator-command" help:"Custom locator command to use instead of built-in locator" placeholder:"COMMAND"`
+}
+
+func cliValidate(cmd *ValidateCmd, globalConfig pipeline.Config) error {
+	logger := log.GetLogger("")
+	cmdConfig := cliValidateMergeConfig(globalConfig, cmd)
+	formats := []string{validateFormatText, validateFormatJSON, validateFormatSARIF}
+	if !slices.Contains(formats, cmd.Format) {
+		return fmt.Errorf("%w: unknown format %q, possible values: %s", ErrWrongCliArgs, cmd.Format, strings.Join(formats, ", "))
+	}
+
+	fileLocator := pipeline.NewLocator(cmdConfig.Locator)
+	docURL, err := jsonpointer.Parse(cmd.Document)
+	if err != nil {
+		return fmt.Errorf("parse URL: %w", err)
+	}
+	compileOpts := compile.CompilationOpts{
+		AllowRemoteRefs:     cmdConfig.Locator.AllowRemoteReferences,
+		GeneratePublishers:  true,
+		GenerateSubscribers: true,
+	}
+	findings, err := runValidation(fileLocator, docURL, compileOpts, cmdConfig)
+	if err != nil {
+		return err
+	}
+
+	//
+	// Reporting
+	//
+	lint.ResolvePositions(findings, docURL.Location(), func(location string) ([]byte, error) {
+		u, err := jsonpointer.Parse(location)
+		if err != nil {
+			return nil, err
+		}
+		data, _, err := compiler.ReadDocument(u, fileLocator, logger)
+		return data, err
+	})
+
+	var out io.Writer = os.Stdout
+	if cmd.OutputFile != "" {
+		f, err := os.Create(cmd.OutputFile)
+		if err != nil {
+			return fmt.Errorf("create output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+	switch cmd.Format {
+	case validateFormatJSON:
+		err = lint.WriteJSON(out, findings)
+	case validateFormatSARIF:
+		err = lint.WriteSARIF(out, findings, docURL.Location(), toolVersion())
+	default:
+		err = lint.WriteText(out, findings)
+	}
+	if err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+
+	if lint.HasErrors(findings) {
+		return fmt.Errorf("document has %d error(s)", lo.CountBy(findings, func(f lint.Finding) bool {
+			return f.Severity == lint.SeverityError
+		}))
+	}
+	return nil
+}
+
+// runValidation runs the compilation and linking, and checks the result. Compilation and linking errors are returned
+// as findings, not as error.
+func runValidation(
+	fileLocator pipeline.DocumentLocator,
+	docURL *jsonpointer.JSONPointer,
+	compileOpts compile.CompilationOpts,
+	cmdConfig pipeline.Config,
+) ([]lint.Finding, error) {
+	logger := log.GetLogger("")
+
+	logger.Debug("Run compilation")
+	compileContext := compile.NewCompileContext(compileOpts)
+	documents, err := pipeline.Compile(docURL, compileContext, fileLocator)
+	if err != nil {
+		pointer := docURL.String()
+		var ce types.CompileError
+		if errors.As(err, &ce) && ce.Path != "" {
+			pointer = ce.Path
+		}
+		return []lint.Finding{{
+			Rule:     lint.RuleCompilation,
+			Severity: lint.SeverityError,
+			Message:  err.Error(),
+			Pointer:  pointer,
+		}}, nil
+	}
+	logger.Debug("Compilation complete", "files", len(documents))
+
+	logger.Debug("Run linking")
+	objSources := lo.MapValues(documents, func(value *compiler.Document, _ string) linker.ObjectSource { return value })
+	if err = pipeline.Link(objSources); err != nil {
+		findings := lint.UnresolvedRefs(documents)
+		if len(findings) == 0 {
+			findings = append(findings, lint.Finding{
+				Rule:     lint.RuleCompilation,
+				Severity: lint.SeverityError,
+				Message:  fmt.Sprintf("linking: %v", err),
+				Pointer:  docURL.String(),
+			})
+		}
+		return findings, nil
+	}
+	logger.Debug("Linking complete")
+
+	logger.Debug("Run checks")
+	tplLoader, err := pipeline.CodeTemplateLoader(cmdConfig)
+	if err != nil {
+		return nil, err
+	}
+	findings := lint.Check(documents, lint.Options{
+		RootDocument: docURL.Location(),
+		HasTemplate: func(name string) bool {
+			_, err := tplLoader.LoadTemplate(name)
+			return err == nil
+		},
+	})
+	logger.Debug("Checks complete", "findings", len(findings))
+	return findings, nil
+}
+
+func toolVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Main.Version
+	}
+	return ""
+}
+
+func cliValidateMergeConfig(globalConfig pipeline.Config, cmd *ValidateCmd) pipeline.Config {
+	res := globalConfig
+
+	res.TemplatesDir = coalesce(cmd.TemplateDir, res.TemplatesDir)
+	res.Locator.AllowRemoteReferences = coalesce(cmd.AllowRemoteRefs, res.Locator.AllowRemoteReferences)
+	res.Locator.RootDirectory = coalesce(cmd.LocatorRootDir, res.Locator.RootDirectory)
+	res.Locator.Timeout = coalesce(cmd.LocatorTimeout, res.Locator.Timeout)
+	res.Locator.Command = coalesce(cmd.LocatorCommand, res.Locator.Command)
+
+	return res
+}
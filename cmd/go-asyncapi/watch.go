@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/bdragon300/go-asyncapi/internal/jsonpointer"
+	"github.com/bdragon300/go-asyncapi/internal/log"
+	"github.com/bdragon300/go-asyncapi/internal/pipeline"
+	"github.com/bdragon300/go-asyncapi/internal/writer"
+	"github.com/fsnotify/fsnotify"
+	"github.com/samber/lo"
+)
+
+// watchDebounceInterval is the time to wait for the next filesystem event before the regeneration. Editors typically
+// produce several events on file save, so we wait for them all.
+const watchDebounceInterval = 200 * time.Millisecond
+
+// watchCode generates the code and then regenerates it every time the root document, the documents it refers to
+// or the custom templates change. Only the files which contents are changed are rewritten, the files that
+// are not generated anymore are removed. Generation and writing errors are logged, but do not stop watching.
+//
+// Only the local files are watched, the remote documents are compiled on every regeneration, but their changes do not
+// trigger it.
+func watchCode(cmd *CodeCmd, cmdConfig pipeline.Config) error {
+	logger := log.GetLogger("")
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("create watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	rootDocumentURL, err := jsonpointer.Parse(cmd.Document)
+	if err != nil {
+		return fmt.Errorf("parse URL: %w", err)
+	}
+	watchedFiles := make(map[string]struct{})
+	if rootDocumentURL.URI == nil {
+		watchedFiles[absPath(rootDocumentURL.FSPath)] = struct{}{}
+	}
+	templatesDir := lo.Ternary(cmdConfig.TemplatesDir != "", absPath(cmdConfig.TemplatesDir), "")
+	watchedDirs := make(map[string]struct{})
+	var generatedFiles []string
+
+	for {
+		logger.Info("Generate the code")
+		files, documents, err := generateCode(cmd, cmdConfig)
+		if err != nil {
+			logger.Error("Code generation failed", "error", err)
+		} else {
+			generatedFiles = writeGeneratedFiles(files, cmdConfig.Code.TargetDir, generatedFiles)
+		}
+
+		// The compiler may have found the new referenced documents, watch them as well. On compilation error, the documents
+		// list may be incomplete, so we keep watching the files from previous runs.
+		for _, doc := range documents {
+			if u := doc.DocumentURL(); u.URI == nil {
+				watchedFiles[absPath(u.FSPath)] = struct{}{}
+			}
+		}
+		if err = updateWatcher(watcher, watchedFiles, templatesDir, watchedDirs); err != nil {
+			return err
+		}
+		logger.Info("Watching for changes, press Ctrl+C to stop", "files", len(watchedFiles), "templatesDir", templatesDir)
+
+		if !waitForChanges(ctx, watcher, watchedFiles, templatesDir) {
+			logger.Info("Stop watching")
+			return nil
+		}
+	}
+}
+
+// writeGeneratedFiles writes the changed files to targetDir and removes the files generated on previous run, but not
+// generated now. Returns the names of generated files to pass on the next run. Errors are logged, so they don't stop
+// watching. On error, the files from both runs are returned, so the stale files are removed on the next successful run.
+func writeGeneratedFiles(files map[string]*bytes.Buffer, targetDir string, generatedFiles []string) []string {
+	logger := log.GetLogger("")
+
+	logger.Debug("Run writing")
+	newGeneratedFiles := slices.Sorted(maps.Keys(files))
+	written, err := writer.WriteChangedBuffersToFiles(files, targetDir)
+	if err != nil {
+		logger.Error("Writing failed", "error", err)
+		return lo.Union(generatedFiles, newGeneratedFiles)
+	}
+	if err = writer.RemoveFiles(lo.Without(generatedFiles, newGeneratedFiles...), targetDir); err != nil {
+		logger.Error("Removing stale files failed", "error", err)
+		return lo.Union(generatedFiles, newGeneratedFiles)
+	}
+	logger.Info("Code generation finished", "written", len(written), "unchanged", len(files)-len(written))
+	return newGeneratedFiles
+}
+
+// updateWatcher adds the directories of watched files and the templates directory with all its subdirectories to
+// the watcher. We watch the directories, not files, because many editors save the file by replacing it, that drops
+// the watch on the file.
+func updateWatcher(watcher *fsnotify.Watcher, watchedFiles map[string]struct{}, templatesDir string, watchedDirs map[string]struct{}) error {
+	logger := log.GetLogger("")
+
+	dirs := lo.Map(lo.Keys(watchedFiles), func(item string, _ int) string { return filepath.Dir(item) })
+	if templatesDir != "" {
+		err := filepath.WalkDir(templatesDir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				dirs = append(dirs, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("walk templates directory: %w", err)
+		}
+	}
+
+	for _, dir := range lo.Uniq(dirs) {
+		if _, ok := watchedDirs[dir]; ok {
+			continue
+		}
+		logger.Debug("Watch directory", "path", dir)
+		if err := watcher.Add(dir); err != nil {
+			return fmt.Errorf("watch directory %q: %w", dir, err)
+		}
+		watchedDirs[dir] = struct{}{}
+	}
+	return nil
+}
+
+// waitForChanges blocks until any of watched files or any file in templates directory changes. Returns false if
+// ctx is done.
+func waitForChanges(ctx context.Context, watcher *fsnotify.Watcher, watchedFiles map[string]struct{}, templatesDir string) bool {
+	logger := log.GetLogger("")
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-debounce:
+			return true
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return false
+			}
+			logger.Warn("Watcher error", "error", err)
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return false
+			}
+			if ev.Has(fsnotify.Chmod) && !ev.Has(fsnotify.Write) {
+				continue
+			}
+			name := absPath(ev.Name)
+			_, isWatchedFile := watchedFiles[name]
+			isTemplate := templatesDir != "" && strings.HasPrefix(name, templatesDir+string(filepath.Separator))
+			if !isWatchedFile && !isTemplate {
+				continue
+			}
+			logger.Debug("File changed", "path", ev.Name, "op", ev.Op)
+			debounce = time.After(watchDebounceInterval)
+		}
+	}
+}
+
+func absPath(p string) string {
+	if res, err := filepath.Abs(p); err == nil {
+		return res
+	}
+	return filepath.Clean(p)
+}
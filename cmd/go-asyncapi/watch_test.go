@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestWriteGeneratedFiles(t *testing.T) {
+	targetDir := t.TempDir()
+	files := map[string]*bytes.Buffer{
+		"a.go":     bytes.NewBufferString("package a\n"),
+		"sub/b.go": bytes.NewBufferString("package sub\n"),
+	}
+	generated := writeGeneratedFiles(files, targetDir, nil)
+	if want := []string{"a.go", "sub/b.go"}; !slices.Equal(generated, want) {
+		t.Fatalf("writeGeneratedFiles() = %v, want %v", generated, want)
+	}
+
+	// The file not generated anymore is removed
+	delete(files, "sub/b.go")
+	generated = writeGeneratedFiles(files, targetDir, generated)
+	if want := []string{"a.go"}; !slices.Equal(generated, want) {
+		t.Errorf("writeGeneratedFiles() = %v, want %v", generated, want)
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, "sub", "b.go")); !os.IsNotExist(err) {
+		t.Errorf("stale file is not removed, stat error = %v", err)
+	}
+}
+
+func TestWriteGeneratedFilesError(t *testing.T) {
+	targetDir := t.TempDir()
+	// File in place of directory
+	if err := os.WriteFile(filepath.Join(targetDir, "sub"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]*bytes.Buffer{"sub/b.go": bytes.NewBufferString("package sub\n")}
+
+	// Error does not stop watching, the files from previous run are kept to be removed later
+	generated := writeGeneratedFiles(files, targetDir, []string{"old.go"})
+	if want := []string{"old.go", "sub/b.go"}; !slices.Equal(generated, want) {
+		t.Errorf("writeGeneratedFiles() = %v, want %v", generated, want)
+	}
+}
+
+func TestUpdateWatcher(t *testing.T) {
+	dir := t.TempDir()
+	docsDir := filepath.Join(dir, "docs")
+	templatesDir := filepath.Join(dir, "templates")
+	for _, d := range []string{docsDir, filepath.Join(templatesDir, "code", "proto")} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	watcher := newTestWatcher(t)
+	watchedFiles := map[string]struct{}{
+		filepath.Join(dir, "asyncapi.yaml"):    {},
+		filepath.Join(docsDir, "common.yaml"):  {},
+		filepath.Join(docsDir, "schemas.yaml"): {},
+	}
+	watchedDirs := make(map[string]struct{})
+
+	if err := updateWatcher(watcher, watchedFiles, templatesDir, watchedDirs); err != nil {
+		t.Fatalf("updateWatcher() error = %v", err)
+	}
+	want := []string{dir, docsDir, templatesDir, filepath.Join(templatesDir, "code"), filepath.Join(templatesDir, "code", "proto")}
+	slices.Sort(want)
+	got := watcher.WatchList()
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Errorf("watched directories = %v, want %v", got, want)
+	}
+
+	// Directories are added only once
+	watcher.Remove(dir)
+	if err := updateWatcher(watcher, watchedFiles, templatesDir, watchedDirs); err != nil {
+		t.Fatalf("updateWatcher() error = %v", err)
+	}
+	if len(watcher.WatchList()) != len(want)-1 {
+		t.Errorf("watched directories = %v, want %d directories", watcher.WatchList(), len(want)-1)
+	}
+}
+
+func TestWaitForChanges(t *testing.T) {
+	tests := []struct {
+		name string
+		// file is the file to write to, relative to the temporary directory
+		file string
+		want bool
+	}{
+		{name: "watched file", file: "asyncapi.yaml", want: true},
+		{name: "template", file: "templates/code/main.tmpl", want: true},
+		{name: "other file", file: "other.yaml", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			templatesDir := filepath.Join(dir, "templates")
+			if err := os.MkdirAll(filepath.Join(templatesDir, "code"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			watcher := newTestWatcher(t)
+			watchedFiles := map[string]struct{}{filepath.Join(dir, "asyncapi.yaml"): {}}
+			if err := updateWatcher(watcher, watchedFiles, templatesDir, make(map[string]struct{})); err != nil {
+				t.Fatal(err)
+			}
+
+			// Timeout means that no change is detected
+			ctx, cancel := context.WithTimeout(context.Background(), watchDebounceInterval*5)
+			defer cancel()
+			if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(tt.file)), []byte("changed"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if got := waitForChanges(ctx, watcher, watchedFiles, templatesDir); got != tt.want {
+				t.Errorf("waitForChanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWaitForChangesDebounce(t *testing.T) {
+	dir := t.TempDir()
+	watcher := newTestWatcher(t)
+	file := filepath.Join(dir, "asyncapi.yaml")
+	watchedFiles := map[string]struct{}{file: {}}
+	if err := updateWatcher(watcher, watchedFiles, "", make(map[string]struct{})); err != nil {
+		t.Fatal(err)
+	}
+
+	// Editors produce several events on save, they must trigger one regeneration after the last event
+	start := time.Now()
+	go func() {
+		for range 3 {
+			_ = os.WriteFile(file, []byte("changed"), 0o644)
+			time.Sleep(watchDebounceInterval / 2)
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if !waitForChanges(ctx, watcher, watchedFiles, "") {
+		t.Fatal("waitForChanges() = false, want true")
+	}
+	if elapsed := time.Since(start); elapsed < watchDebounceInterval*2 {
+		t.Errorf("waitForChanges() returned after %v, want after the last event and debounce interval", elapsed)
+	}
+}
+
+func newTestWatcher(t *testing.T) *fsnotify.Watcher {
+	t.Helper()
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { watcher.Close() })
+	return watcher
+}
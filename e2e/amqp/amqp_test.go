@@ -0,0 +1,315 @@
+package amqp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bdragon300/go-asyncapi/e2e/amqp/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/amqp/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/amqp/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/run"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+var errHandle = errors.New("handle failed")
+
+func TestRetryQuorumQueue(t *testing.T) {
+	queue := newFakeQueue(true, true)
+	queue.push("t1")
+	ch := channels.NewTasksAMQP(nil, queue, run.WithRetry(run.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}))
+
+	var attempts int
+	err := subscribe(t, ch, func(ctx context.Context, message messages.TaskReceiver) error {
+		attempts++
+		if attempts == 3 {
+			defer queue.close()
+		}
+		return errHandle
+	})
+	if err != nil {
+		t.Fatalf("SubscribeTask() error = %v", err)
+	}
+
+	// Every attempt is a separate delivery, the broker requeues the message on nack and increments x-delivery-count
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+	want := []string{"t1 nack requeue", "t1 nack requeue", "t1 nack"}
+	if got := queue.settlements(); !slices.Equal(got, want) {
+		t.Errorf("settlements = %v, want %v", got, want)
+	}
+}
+
+func TestRetryDeadLetterHandler(t *testing.T) {
+	queue := newFakeQueue(true, true)
+	queue.push("t1")
+	var deadLetters []string
+	deadLetter := func(_ context.Context, envelope amqp.EnvelopeReader, payload []byte, err error) error {
+		defer queue.close()
+		if _, ok := envelope.(*amqp.EnvelopeIn); !ok {
+			t.Errorf("dead-letter envelope type = %T, want *amqp.EnvelopeIn", envelope)
+		}
+		if !errors.Is(err, errHandle) {
+			t.Errorf("dead-letter error = %v, want %v", err, errHandle)
+		}
+		deadLetters = append(deadLetters, string(payload))
+		return nil
+	}
+	ch := channels.NewTasksAMQP(
+		nil,
+		queue,
+		run.WithRetry(run.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+		run.WithDeadLetter(deadLetter),
+	)
+
+	err := subscribe(t, ch, func(context.Context, messages.TaskReceiver) error {
+		return errHandle
+	})
+	if err != nil {
+		t.Fatalf("SubscribeTask() error = %v", err)
+	}
+
+	// The message handled by dead-letter handler is acknowledged
+	if want := []string{"t1 nack requeue", "t1 ack"}; !slices.Equal(queue.settlements(), want) {
+		t.Errorf("settlements = %v, want %v", queue.settlements(), want)
+	}
+	if want := []string{`{"id":"t1"}`}; !slices.Equal(deadLetters, want) {
+		t.Errorf("dead letters = %v, want %v", deadLetters, want)
+	}
+}
+
+func TestRetryClassicQueue(t *testing.T) {
+	// Classic queues don't set x-delivery-count, so the message is retried in-process
+	queue := newFakeQueue(true, false)
+	queue.push("t1")
+	queue.push("t2")
+	ch := channels.NewTasksAMQP(nil, queue, run.WithRetry(run.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}))
+
+	var got []string
+	err := subscribe(t, ch, func(_ context.Context, message messages.TaskReceiver) error {
+		got = append(got, message.Payload().ID)
+		if message.Payload().ID == "t2" {
+			defer queue.close()
+			return nil
+		}
+		return errHandle
+	})
+	if err != nil {
+		t.Fatalf("SubscribeTask() error = %v", err)
+	}
+
+	if want := []string{"t1", "t1", "t1", "t2"}; !slices.Equal(got, want) {
+		t.Errorf("handled = %v, want %v", got, want)
+	}
+	if want := []string{"t1 nack", "t2 ack"}; !slices.Equal(queue.settlements(), want) {
+		t.Errorf("settlements = %v, want %v", queue.settlements(), want)
+	}
+}
+
+func TestRetryAutoAck(t *testing.T) {
+	// With automatic acknowledgement the message can't be rejected, so it's dropped after the last attempt
+	queue := newFakeQueue(false, true)
+	queue.push("t1")
+	queue.push("t2")
+	ch := channels.NewTasksAMQP(nil, queue, run.WithRetry(run.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}))
+
+	var got []string
+	err := subscribe(t, ch, func(_ context.Context, message messages.TaskReceiver) error {
+		got = append(got, message.Payload().ID)
+		if message.Payload().ID == "t2" {
+			defer queue.close()
+		}
+		return errHandle
+	})
+	if err != nil {
+		t.Fatalf("SubscribeTask() error = %v", err)
+	}
+
+	if want := []string{"t1", "t1", "t2", "t2"}; !slices.Equal(got, want) {
+		t.Errorf("handled = %v, want %v", got, want)
+	}
+	if len(queue.settlements()) != 0 {
+		t.Errorf("settlements = %v, want none", queue.settlements())
+	}
+}
+
+func TestUnwrapEnvelope(t *testing.T) {
+	queue := newFakeQueue(true, true)
+	queue.push("t1")
+	var redelivered []bool
+	middleware := func(next run.SubscribeHandler[amqp.EnvelopeReader]) run.SubscribeHandler[amqp.EnvelopeReader] {
+		return func(ctx context.Context, envelope amqp.EnvelopeReader, message any) error {
+			w, ok := envelope.(interface{ Unwrap() amqp.EnvelopeReader })
+			if !ok {
+				t.Fatalf("envelope %T has no Unwrap method", envelope)
+			}
+			redelivered = append(redelivered, w.Unwrap().(*amqp.EnvelopeIn).Redelivered)
+			return next(ctx, envelope, message)
+		}
+	}
+	ch := channels.NewTasksAMQP(
+		nil,
+		queue,
+		run.WithRetry(run.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+		run.WithSubscribeMiddleware(middleware),
+	)
+
+	var attempts int
+	err := subscribe(t, ch, func(_ context.Context, message messages.TaskReceiver) error {
+		if message.Payload().ID != "t1" {
+			t.Errorf("payload id = %q, want %q", message.Payload().ID, "t1")
+		}
+		if attempts++; attempts == 2 {
+			defer queue.close()
+			return nil
+		}
+		return errHandle
+	})
+	if err != nil {
+		t.Fatalf("SubscribeTask() error = %v", err)
+	}
+
+	if want := []bool{false, true}; !slices.Equal(redelivered, want) {
+		t.Errorf("redelivered = %v, want %v", redelivered, want)
+	}
+}
+
+func subscribe(t *testing.T, ch *channels.TasksAMQP, cb func(context.Context, messages.TaskReceiver) error) error {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	err := ch.SubscribeTask(ctx, cb)
+	if ctx.Err() != nil {
+		t.Fatal("timeout waiting for the messages")
+	}
+	return err
+}
+
+// fakeQueue is the amqp.Subscriber that delivers the messages the same way as amqp.SubscribeChannel does, and
+// records their acknowledgements. The requeued messages are delivered again, with x-delivery-count header
+// incremented like quorum queues do.
+type fakeQueue struct {
+	manualAck     bool
+	deliveryCount bool
+
+	mu       sync.Mutex
+	pending  chan amqp091.Delivery
+	nextTag  uint64
+	bodies   map[uint64]amqp091.Delivery
+	settled  []string
+	isClosed bool
+}
+
+func newFakeQueue(manualAck, deliveryCount bool) *fakeQueue {
+	return &fakeQueue{
+		manualAck:     manualAck,
+		deliveryCount: deliveryCount,
+		pending:       make(chan amqp091.Delivery, 10),
+		bodies:        make(map[uint64]amqp091.Delivery),
+	}
+}
+
+func (q *fakeQueue) push(id string) {
+	b, _ := json.Marshal(map[string]string{"id": id})
+	d := amqp091.Delivery{Body: b, Headers: amqp091.Table{}}
+	if q.deliveryCount {
+		d.Headers["x-delivery-count"] = int64(0)
+	}
+	q.deliver(d)
+}
+
+func (q *fakeQueue) deliver(d amqp091.Delivery) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.isClosed {
+		return
+	}
+	q.nextTag++
+	d.DeliveryTag = q.nextTag
+	d.Acknowledger = q
+	q.bodies[d.DeliveryTag] = d
+	q.pending <- d
+}
+
+func (q *fakeQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.isClosed {
+		q.isClosed = true
+		close(q.pending)
+	}
+}
+
+func (q *fakeQueue) settlements() []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return slices.Clone(q.settled)
+}
+
+func (q *fakeQueue) Receive(ctx context.Context, cb func(envelope amqp.EnvelopeReader)) error {
+	run.NotifySubscribeReady(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case d, ok := <-q.pending:
+			if !ok {
+				return nil
+			}
+			evlp := amqp.NewEnvelopeIn(&d, bytes.NewReader(d.Body))
+			evlp.ManualAck = q.manualAck
+			cb(evlp)
+			if q.manualAck && !evlp.Settled() {
+				if err := d.Ack(false); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+func (q *fakeQueue) Close() error {
+	return nil
+}
+
+func (q *fakeQueue) Ack(tag uint64, _ bool) error {
+	q.settle(tag, "ack")
+	return nil
+}
+
+func (q *fakeQueue) Nack(tag uint64, _ bool, requeue bool) error {
+	action := "nack"
+	if requeue {
+		action = "nack requeue"
+	}
+	d := q.settle(tag, action)
+	if requeue {
+		d.Redelivered = true
+		if v, ok := d.Headers["x-delivery-count"].(int64); ok {
+			d.Headers = amqp091.Table{"x-delivery-count": v + 1}
+		}
+		go q.deliver(d)
+	}
+	return nil
+}
+
+func (q *fakeQueue) Reject(tag uint64, requeue bool) error {
+	return q.Nack(tag, false, requeue)
+}
+
+func (q *fakeQueue) settle(tag uint64, action string) amqp091.Delivery {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	d := q.bodies[tag]
+	delete(q.bodies, tag)
+	var m map[string]string
+	_ = json.Unmarshal(d.Body, &m)
+	q.settled = append(q.settled, m["id"]+" "+action)
+	return d
+}
@@ -0,0 +1,277 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package channels
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/amqp/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/amqp/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+func TasksAddress() run.ParamString {
+	return run.ParamString{
+		Expr: "tasks",
+	}
+}
+
+type TasksBindings struct{}
+
+func (c TasksBindings) AMQP() amqp.ChannelBindings {
+	return amqp.ChannelBindings{
+		Is: amqp.ChannelTypeQueue,
+
+		QueueConfiguration: amqp.QueueConfiguration{
+			Name:    "tasks",
+			Durable: run.ToPtr(true),
+		},
+	}
+}
+
+func NewTasksAMQP(
+
+	publisher amqp.Publisher,
+	subscriber amqp.Subscriber,
+	opts ...run.MiddlewareOption,
+) *TasksAMQP {
+	res := TasksAMQP{
+		address: TasksAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+
+	bindings := TasksBindings{}.AMQP()
+	switch bindings.Is {
+	case amqp.ChannelTypeQueue:
+		res.queue = res.address.String()
+	default:
+		res.routingKey = res.address.String()
+	}
+	if bindings.ExchangeConfiguration.Name != nil {
+		res.exchange = *bindings.ExchangeConfiguration.Name
+	}
+	if bindings.QueueConfiguration.Name != "" {
+		res.queue = bindings.QueueConfiguration.Name
+	}
+	return &res
+}
+
+type TasksServerAMQP interface {
+	OpenTasksAMQP(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*TasksAMQP, error)
+	Producer() amqp.Producer
+	Consumer() amqp.Consumer
+}
+
+func OpenTasksAMQP(
+	ctx context.Context,
+	server TasksServerAMQP,
+
+	opBindings *amqp.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*TasksAMQP, error) {
+	var err error
+	chBindings := TasksBindings{}.AMQP()
+	address, err := TasksAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher amqp.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			&chBindings,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber amqp.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			&chBindings,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewTasksAMQP(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type TasksAMQP struct {
+	address     run.ParamString
+	publisher   amqp.Publisher
+	subscriber  amqp.Subscriber
+	middlewares run.Middlewares
+	exchange    string
+	queue       string
+	routingKey  string
+}
+
+func (c TasksAMQP) Exchange() string {
+	return c.exchange
+}
+
+func (c TasksAMQP) Queue() string {
+	return c.queue
+}
+
+func (c TasksAMQP) RoutingKey() string {
+	return c.routingKey
+}
+
+func (c TasksAMQP) Address() run.ParamString {
+	return c.address
+}
+func (c TasksAMQP) Bindings() amqp.ChannelBindings {
+	return TasksBindings{}.AMQP()
+}
+
+func (c TasksAMQP) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type TasksEnvelopeMarshalerAMQP interface {
+	MarshalTasksAMQP(envelope amqp.EnvelopeWriter) error
+}
+
+func (c TasksAMQP) SealTask(
+	envelope amqp.EnvelopeWriter,
+	message TasksEnvelopeMarshalerAMQP,
+) error {
+	if err := message.MarshalTasksAMQP(envelope); err != nil {
+		return err
+	}
+
+	envelope.SetRoutingKey(c.RoutingKey())
+	return nil
+}
+
+func (c TasksAMQP) PublishTask(
+	ctx context.Context,
+
+	message TasksEnvelopeMarshalerAMQP,
+) error {
+	envelope := amqp.NewEnvelopeOut(nil)
+	if err := c.SealTask(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c TasksAMQP) PublishEnvelope(ctx context.Context, envelope amqp.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope amqp.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c TasksAMQP) Publisher() amqp.Publisher {
+	return c.publisher
+}
+
+func (c TasksAMQP) Publish(ctx context.Context, envelopes ...amqp.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type TasksEnvelopeUnmarshalerAMQP interface {
+	UnmarshalTasksAMQP(envelope amqp.EnvelopeReader) error
+}
+
+func (c TasksAMQP) UnsealTask(
+	envelope amqp.EnvelopeReader,
+	message TasksEnvelopeUnmarshalerAMQP,
+) error {
+	return message.UnmarshalTasksAMQP(envelope)
+}
+
+// SubscribeTask receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c TasksAMQP) SubscribeTask(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.TaskReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope amqp.EnvelopeReader, message any) error {
+		m := message.(*messages.TaskIn)
+		if err2 := c.UnsealTask(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope amqp.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) amqp.EnvelopeReader {
+				return &tasksAMQPBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope amqp.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.TaskIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// tasksAMQPBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type tasksAMQPBufferedEnvelope struct {
+	amqp.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *tasksAMQPBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *tasksAMQPBufferedEnvelope) Unwrap() amqp.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c TasksAMQP) Subscriber() amqp.Subscriber {
+	return c.subscriber
+}
+
+func (c TasksAMQP) Subscribe(ctx context.Context, cb func(envelope amqp.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
@@ -0,0 +1,119 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package messages
+
+import (
+	"encoding/json"
+	"github.com/bdragon300/go-asyncapi/e2e/amqp/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+)
+
+type TaskSender interface {
+	SetPayload(payload struct {
+		ID string `json:"id"`
+	}) *TaskOut
+	SetHeaders(headers map[string]any) *TaskOut
+}
+
+// TaskOut-- (Outbound Message)
+type TaskOut struct {
+	Payload struct {
+		ID string `json:"id"`
+	}
+	Headers map[string]any
+}
+
+// Validate checks the TaskOut value against the constraints from the jsonschema definition.
+func (v TaskOut) Validate() error {
+	return nil
+}
+
+func (m *TaskOut) SetPayload(payload struct {
+	ID string `json:"id"`
+}) *TaskOut {
+	m.Payload = payload
+	return m
+}
+
+func (m *TaskOut) SetHeaders(headers map[string]any) *TaskOut {
+	m.Headers = headers
+	return m
+}
+
+type TaskReceiver interface {
+	Payload() struct {
+		ID string `json:"id"`
+	}
+	Headers() map[string]any
+}
+
+// TaskIn-- (Inbound Message)
+type TaskIn struct {
+	payload struct {
+		ID string `json:"id"`
+	}
+	headers map[string]any
+}
+
+// Validate checks the TaskIn value against the constraints from the jsonschema definition.
+func (v TaskIn) Validate() error {
+	return nil
+}
+
+func (m *TaskIn) Payload() struct {
+	ID string `json:"id"`
+} {
+	return m.payload
+}
+
+func (m *TaskIn) Headers() map[string]any {
+	return m.headers
+}
+
+func (m *TaskOut) MarshalTasksAMQP(envelope amqp.EnvelopeWriter) error {
+	return m.MarshalEnvelopeAMQP(envelope)
+}
+
+func (m *TaskOut) MarshalEnvelopeAMQP(envelope amqp.EnvelopeWriter) error {
+	if err := m.MarshalAMQP(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers(m.Headers))
+	return nil
+}
+
+func (m *TaskOut) MarshalAMQP(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *TaskIn) UnmarshalTasksAMQP(envelope amqp.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeAMQP(envelope)
+}
+
+func (m *TaskIn) UnmarshalEnvelopeAMQP(envelope amqp.EnvelopeReader) error {
+	if err := m.UnmarshalAMQP(envelope); err != nil {
+		return err
+	}
+	m.headers = map[string]any(envelope.Headers())
+	return nil
+}
+
+func (m *TaskIn) UnmarshalAMQP(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/amqp/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/amqp/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/amqp/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+type ReceiveTaskBindings struct{}
+
+func (c ReceiveTaskBindings) AMQP() amqp.OperationBindings {
+	return amqp.OperationBindings{
+
+		Ack: true,
+	}
+}
+
+type ReceiveTaskServerAMQP interface {
+	OpenTasksAMQP(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.TasksAMQP, error)
+	OpenReceiveTaskAMQP(context.Context, ...run.MiddlewareOption) (*ReceiveTaskAMQP, error)
+	Producer() amqp.Producer
+	Consumer() amqp.Consumer
+}
+
+func OpenReceiveTaskAMQP(
+	ctx context.Context,
+	server ReceiveTaskServerAMQP,
+
+	opts ...run.MiddlewareOption,
+) (*ReceiveTaskAMQP, error) {
+	opBindings := ReceiveTaskBindings{}.AMQP()
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "tasks",
+			Operation: "receiveTask",
+			Protocol:  "amqp",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, receiveTaskAMQPMetrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenTasksAMQP(
+		run.WithOperationName(ctx, "receiveTask"),
+		server,
+
+		&opBindings,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReceiveTaskAMQP{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// receiveTaskAMQPEnvelopeReader counts the payload bytes read from the envelope.
+type receiveTaskAMQPEnvelopeReader struct {
+	amqp.EnvelopeReader
+	size int
+}
+
+func (e *receiveTaskAMQPEnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// receiveTaskAMQPMetrics returns the middleware that reports the received messages metrics.
+func receiveTaskAMQPMetrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[amqp.EnvelopeReader]) run.SubscribeHandler[amqp.EnvelopeReader] {
+		return func(ctx context.Context, envelope amqp.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.TaskIn:
+				labels.Message = "task"
+			}
+			counter := &receiveTaskAMQPEnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type ReceiveTaskChannelAMQP interface {
+	Close() error
+
+	SealTask(amqp.EnvelopeWriter, channels.TasksEnvelopeMarshalerAMQP) error
+	PublishTask(context.Context, channels.TasksEnvelopeMarshalerAMQP) error
+
+	UnsealTask(amqp.EnvelopeReader, channels.TasksEnvelopeUnmarshalerAMQP) error
+	SubscribeTask(context.Context, func(context.Context, messages.TaskReceiver) error) error
+}
+
+type ReceiveTaskAMQP struct {
+	Channel      ReceiveTaskChannelAMQP
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c ReceiveTaskAMQP) Close() error {
+	return c.Channel.Close()
+}
+func (c ReceiveTaskAMQP) Bindings() amqp.OperationBindings {
+	return ReceiveTaskBindings{}.AMQP()
+}
+
+func (o ReceiveTaskAMQP) UnsealTask(
+	envelope amqp.EnvelopeReader,
+	message channels.TasksEnvelopeUnmarshalerAMQP,
+) error {
+	return o.Channel.UnsealTask(envelope, message)
+}
+
+func (o ReceiveTaskAMQP) SubscribeTask(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.TaskReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeTask(ctx, cb)
+}
@@ -0,0 +1,123 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/amqp/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/amqp/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/amqp/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+type ReceiveTaskAutoAckServerAMQP interface {
+	OpenTasksAMQP(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.TasksAMQP, error)
+	OpenReceiveTaskAutoAckAMQP(context.Context, ...run.MiddlewareOption) (*ReceiveTaskAutoAckAMQP, error)
+	Producer() amqp.Producer
+	Consumer() amqp.Consumer
+}
+
+func OpenReceiveTaskAutoAckAMQP(
+	ctx context.Context,
+	server ReceiveTaskAutoAckServerAMQP,
+
+	opts ...run.MiddlewareOption,
+) (*ReceiveTaskAutoAckAMQP, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "tasks",
+			Operation: "receiveTaskAutoAck",
+			Protocol:  "amqp",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, receiveTaskAutoAckAMQPMetrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenTasksAMQP(
+		run.WithOperationName(ctx, "receiveTaskAutoAck"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReceiveTaskAutoAckAMQP{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// receiveTaskAutoAckAMQPEnvelopeReader counts the payload bytes read from the envelope.
+type receiveTaskAutoAckAMQPEnvelopeReader struct {
+	amqp.EnvelopeReader
+	size int
+}
+
+func (e *receiveTaskAutoAckAMQPEnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// receiveTaskAutoAckAMQPMetrics returns the middleware that reports the received messages metrics.
+func receiveTaskAutoAckAMQPMetrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[amqp.EnvelopeReader]) run.SubscribeHandler[amqp.EnvelopeReader] {
+		return func(ctx context.Context, envelope amqp.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.TaskIn:
+				labels.Message = "task"
+			}
+			counter := &receiveTaskAutoAckAMQPEnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type ReceiveTaskAutoAckChannelAMQP interface {
+	Close() error
+
+	SealTask(amqp.EnvelopeWriter, channels.TasksEnvelopeMarshalerAMQP) error
+	PublishTask(context.Context, channels.TasksEnvelopeMarshalerAMQP) error
+
+	UnsealTask(amqp.EnvelopeReader, channels.TasksEnvelopeUnmarshalerAMQP) error
+	SubscribeTask(context.Context, func(context.Context, messages.TaskReceiver) error) error
+}
+
+type ReceiveTaskAutoAckAMQP struct {
+	Channel      ReceiveTaskAutoAckChannelAMQP
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c ReceiveTaskAutoAckAMQP) Close() error {
+	return c.Channel.Close()
+}
+
+func (o ReceiveTaskAutoAckAMQP) UnsealTask(
+	envelope amqp.EnvelopeReader,
+	message channels.TasksEnvelopeUnmarshalerAMQP,
+) error {
+	return o.Channel.UnsealTask(envelope, message)
+}
+
+func (o ReceiveTaskAutoAckAMQP) SubscribeTask(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.TaskReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeTask(ctx, cb)
+}
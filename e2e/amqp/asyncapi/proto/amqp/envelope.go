@@ -0,0 +1,160 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package amqp
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"io"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+)
+
+func NewEnvelopeOut(buf []byte) *EnvelopeOut {
+	return &EnvelopeOut{
+		Publishing: &amqp091.Publishing{Body: buf},
+	}
+}
+
+type EnvelopeOut struct {
+	*amqp091.Publishing
+	routingKey string
+}
+
+func (e *EnvelopeOut) Write(p []byte) (n int, err error) {
+	e.Body = append(e.Body, p...)
+	return len(p), nil
+}
+
+func (e *EnvelopeOut) ResetPayload() {
+	e.Body = e.Body[:0]
+}
+
+func (e *EnvelopeOut) SetHeaders(headers run.Headers) {
+	if e.Publishing.Headers == nil {
+		e.Publishing.Headers = make(amqp091.Table, len(headers))
+	}
+	for k, v := range headers {
+		e.Publishing.Headers[k] = v
+	}
+}
+
+func (e *EnvelopeOut) SetContentType(contentType string) {
+	e.ContentType = contentType
+}
+
+func (e *EnvelopeOut) SetBindings(bindings MessageBindings) {
+	e.Publishing.ContentEncoding = bindings.ContentEncoding
+	e.Type = bindings.MessageType
+}
+
+func (e *EnvelopeOut) SetRoutingKey(routingKey string) {
+	e.routingKey = routingKey
+}
+
+func (e *EnvelopeOut) SetReplyTo(replyTo string) {
+	e.Publishing.ReplyTo = replyTo
+}
+
+func (e *EnvelopeOut) AsAMQP091Record() *amqp091.Publishing {
+	return e.Publishing
+}
+
+// MessageID returns the message-id message property.
+func (e *EnvelopeOut) MessageID() string {
+	return e.Publishing.MessageId
+}
+
+func (e *EnvelopeOut) RoutingKey() string {
+	return e.routingKey
+}
+
+func NewEnvelopeIn(delivery *amqp091.Delivery, rd io.Reader) *EnvelopeIn {
+	return &EnvelopeIn{
+		Delivery: delivery,
+		reader:   rd,
+	}
+}
+
+type EnvelopeIn struct {
+	*amqp091.Delivery
+	// ManualAck is true if the consumer acknowledges the messages manually, i.e. the ack operation binding is set.
+	// Native redelivery and dead-lettering are used only in this mode.
+	ManualAck bool
+
+	reader  io.Reader
+	settled bool
+}
+
+func (e EnvelopeIn) Read(p []byte) (n int, err error) {
+	return e.reader.Read(p)
+}
+
+func (e EnvelopeIn) Headers() run.Headers {
+	return map[string]any(e.Delivery.Headers)
+}
+
+// MessageID returns the message-id message property.
+func (e EnvelopeIn) MessageID() string {
+	return e.Delivery.MessageId
+}
+
+func (e EnvelopeIn) ReplyTo() string {
+	return e.Delivery.ReplyTo
+}
+
+func (e *EnvelopeIn) Ack() error {
+	e.settled = true
+	return e.Delivery.Ack(false)
+}
+
+func (e *EnvelopeIn) Nack(requeue bool) error {
+	e.settled = true
+	return e.Delivery.Nack(false, requeue)
+}
+
+func (e *EnvelopeIn) Reject(requeue bool) error {
+	e.settled = true
+	return e.Delivery.Reject(requeue)
+}
+
+// Settled returns true if the message has been acknowledged or rejected by Ack, Nack or Reject.
+func (e *EnvelopeIn) Settled() bool {
+	return e.settled
+}
+
+// DeliveryAttempt returns the delivery attempt number based on x-delivery-count header, that is set by quorum
+// queues. Returns false if the header is not set or the consumer acknowledges the messages automatically.
+func (e *EnvelopeIn) DeliveryAttempt() (int, bool) {
+	if !e.ManualAck {
+		return 0, false
+	}
+	switch v := e.Delivery.Headers["x-delivery-count"].(type) {
+	case int64:
+		return int(v) + 1, true
+	case int32:
+		return int(v) + 1, true
+	case int:
+		return v + 1, true
+	}
+	return 0, false
+}
+
+// Redeliver requeues the message after the delay. AMQP doesn't support the delayed requeue, so the delay is
+// waited before nack.
+func (e *EnvelopeIn) Redeliver(delay time.Duration) error {
+	time.Sleep(delay)
+	return e.Nack(true)
+}
+
+// DeadLetter rejects the message without requeue, so the broker routes it to the queue's dead-letter exchange
+// if any. Does nothing if the consumer acknowledges the messages automatically.
+func (e *EnvelopeIn) DeadLetter() error {
+	if !e.ManualAck {
+		return nil
+	}
+	return e.Nack(false)
+}
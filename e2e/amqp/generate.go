@@ -0,0 +1,4 @@
+// Package amqp checks the retry and dead-letter mapping of AMQP implementation with the fake deliveries.
+package amqp
+
+//go:generate go -C ../.. run ./cmd/go-asyncapi code -t e2e/amqp/asyncapi -M github.com/bdragon300/go-asyncapi/e2e/amqp/asyncapi e2e/amqp/asyncapi.yaml
@@ -0,0 +1,246 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package channels
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/proto/sns"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+func NotificationsAddress() run.ParamString {
+	return run.ParamString{
+		Expr: "notifications.fifo",
+	}
+}
+
+type NotificationsBindings struct{}
+
+func (c NotificationsBindings) SNS() sns.ChannelBindings {
+	return sns.ChannelBindings{
+
+		Ordering: sns.Ordering{
+			Type: sns.OrderingTypeFIFO,
+		},
+	}
+}
+
+func NewNotificationsSNS(
+
+	publisher sns.Publisher,
+	subscriber sns.Subscriber,
+	opts ...run.MiddlewareOption,
+) *NotificationsSNS {
+	res := NotificationsSNS{
+		address: NotificationsAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+	return &res
+}
+
+type NotificationsServerSNS interface {
+	OpenNotificationsSNS(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*NotificationsSNS, error)
+	Producer() sns.Producer
+	Consumer() sns.Consumer
+}
+
+func OpenNotificationsSNS(
+	ctx context.Context,
+	server NotificationsServerSNS,
+
+	opBindings *sns.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*NotificationsSNS, error) {
+	var err error
+	chBindings := NotificationsBindings{}.SNS()
+	address, err := NotificationsAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher sns.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			&chBindings,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber sns.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			&chBindings,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewNotificationsSNS(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type NotificationsSNS struct {
+	address     run.ParamString
+	publisher   sns.Publisher
+	subscriber  sns.Subscriber
+	middlewares run.Middlewares
+}
+
+func (c NotificationsSNS) Address() run.ParamString {
+	return c.address
+}
+func (c NotificationsSNS) Bindings() sns.ChannelBindings {
+	return NotificationsBindings{}.SNS()
+}
+
+func (c NotificationsSNS) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type NotificationsEnvelopeMarshalerSNS interface {
+	MarshalNotificationsSNS(envelope sns.EnvelopeWriter) error
+}
+
+func (c NotificationsSNS) SealOrder(
+	envelope sns.EnvelopeWriter,
+	message NotificationsEnvelopeMarshalerSNS,
+) error {
+	if err := message.MarshalNotificationsSNS(envelope); err != nil {
+		return err
+	}
+
+	envelope.SetBindings(messages.OrderBindings{}.SNS())
+	return nil
+}
+
+func (c NotificationsSNS) PublishOrder(
+	ctx context.Context,
+
+	message NotificationsEnvelopeMarshalerSNS,
+) error {
+	envelope := sns.NewEnvelopeOut(nil)
+	if err := c.SealOrder(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c NotificationsSNS) PublishEnvelope(ctx context.Context, envelope sns.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope sns.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c NotificationsSNS) Publisher() sns.Publisher {
+	return c.publisher
+}
+
+func (c NotificationsSNS) Publish(ctx context.Context, envelopes ...sns.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type NotificationsEnvelopeUnmarshalerSNS interface {
+	UnmarshalNotificationsSNS(envelope sns.EnvelopeReader) error
+}
+
+func (c NotificationsSNS) UnsealOrder(
+	envelope sns.EnvelopeReader,
+	message NotificationsEnvelopeUnmarshalerSNS,
+) error {
+	return message.UnmarshalNotificationsSNS(envelope)
+}
+
+// SubscribeOrder receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c NotificationsSNS) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope sns.EnvelopeReader, message any) error {
+		m := message.(*messages.OrderIn)
+		if err2 := c.UnsealOrder(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope sns.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) sns.EnvelopeReader {
+				return &notificationsSNSBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope sns.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.OrderIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// notificationsSNSBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type notificationsSNSBufferedEnvelope struct {
+	sns.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *notificationsSNSBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *notificationsSNSBufferedEnvelope) Unwrap() sns.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c NotificationsSNS) Subscriber() sns.Subscriber {
+	return c.subscriber
+}
+
+func (c NotificationsSNS) Subscribe(ctx context.Context, cb func(envelope sns.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
@@ -0,0 +1,249 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package channels
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/proto/sqs"
+	"github.com/bdragon300/go-asyncapi/run"
+	"time"
+)
+
+func OrdersAddress() run.ParamString {
+	return run.ParamString{
+		Expr: "orders",
+	}
+}
+
+type OrdersBindings struct{}
+
+func (c OrdersBindings) SQS() sqs.ChannelBindings {
+	return sqs.ChannelBindings{
+		Queue: sqs.Queue{
+			Name:      "orders.fifo",
+			FIFOQueue: true,
+
+			ReceiveMessageWaitTime: time.Duration(5 * time.Second),
+		},
+	}
+}
+
+func NewOrdersSQS(
+
+	publisher sqs.Publisher,
+	subscriber sqs.Subscriber,
+	opts ...run.MiddlewareOption,
+) *OrdersSQS {
+	res := OrdersSQS{
+		address: OrdersAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+	return &res
+}
+
+type OrdersServerSQS interface {
+	OpenOrdersSQS(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*OrdersSQS, error)
+	Producer() sqs.Producer
+	Consumer() sqs.Consumer
+}
+
+func OpenOrdersSQS(
+	ctx context.Context,
+	server OrdersServerSQS,
+
+	opBindings *sqs.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*OrdersSQS, error) {
+	var err error
+	chBindings := OrdersBindings{}.SQS()
+	address, err := OrdersAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher sqs.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			&chBindings,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber sqs.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			&chBindings,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewOrdersSQS(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type OrdersSQS struct {
+	address     run.ParamString
+	publisher   sqs.Publisher
+	subscriber  sqs.Subscriber
+	middlewares run.Middlewares
+}
+
+func (c OrdersSQS) Address() run.ParamString {
+	return c.address
+}
+func (c OrdersSQS) Bindings() sqs.ChannelBindings {
+	return OrdersBindings{}.SQS()
+}
+
+func (c OrdersSQS) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type OrdersEnvelopeMarshalerSQS interface {
+	MarshalOrdersSQS(envelope sqs.EnvelopeWriter) error
+}
+
+func (c OrdersSQS) SealOrder(
+	envelope sqs.EnvelopeWriter,
+	message OrdersEnvelopeMarshalerSQS,
+) error {
+	if err := message.MarshalOrdersSQS(envelope); err != nil {
+		return err
+	}
+
+	envelope.SetBindings(messages.OrderBindings{}.SQS())
+	return nil
+}
+
+func (c OrdersSQS) PublishOrder(
+	ctx context.Context,
+
+	message OrdersEnvelopeMarshalerSQS,
+) error {
+	envelope := sqs.NewEnvelopeOut(nil)
+	if err := c.SealOrder(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c OrdersSQS) PublishEnvelope(ctx context.Context, envelope sqs.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope sqs.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c OrdersSQS) Publisher() sqs.Publisher {
+	return c.publisher
+}
+
+func (c OrdersSQS) Publish(ctx context.Context, envelopes ...sqs.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type OrdersEnvelopeUnmarshalerSQS interface {
+	UnmarshalOrdersSQS(envelope sqs.EnvelopeReader) error
+}
+
+func (c OrdersSQS) UnsealOrder(
+	envelope sqs.EnvelopeReader,
+	message OrdersEnvelopeUnmarshalerSQS,
+) error {
+	return message.UnmarshalOrdersSQS(envelope)
+}
+
+// SubscribeOrder receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c OrdersSQS) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope sqs.EnvelopeReader, message any) error {
+		m := message.(*messages.OrderIn)
+		if err2 := c.UnsealOrder(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope sqs.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) sqs.EnvelopeReader {
+				return &ordersSQSBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope sqs.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.OrderIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// ordersSQSBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type ordersSQSBufferedEnvelope struct {
+	sqs.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *ordersSQSBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *ordersSQSBufferedEnvelope) Unwrap() sqs.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c OrdersSQS) Subscriber() sqs.Subscriber {
+	return c.subscriber
+}
+
+func (c OrdersSQS) Subscribe(ctx context.Context, cb func(envelope sqs.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
@@ -0,0 +1,230 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package channels
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/proto/sqs"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+func TasksAddress() run.ParamString {
+	return run.ParamString{
+		Expr: "tasks",
+	}
+}
+
+func NewTasksSQS(
+
+	publisher sqs.Publisher,
+	subscriber sqs.Subscriber,
+	opts ...run.MiddlewareOption,
+) *TasksSQS {
+	res := TasksSQS{
+		address: TasksAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+	return &res
+}
+
+type TasksServerSQS interface {
+	OpenTasksSQS(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*TasksSQS, error)
+	Producer() sqs.Producer
+	Consumer() sqs.Consumer
+}
+
+func OpenTasksSQS(
+	ctx context.Context,
+	server TasksServerSQS,
+
+	opBindings *sqs.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*TasksSQS, error) {
+	var err error
+	address, err := TasksAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher sqs.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber sqs.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewTasksSQS(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type TasksSQS struct {
+	address     run.ParamString
+	publisher   sqs.Publisher
+	subscriber  sqs.Subscriber
+	middlewares run.Middlewares
+}
+
+func (c TasksSQS) Address() run.ParamString {
+	return c.address
+}
+
+func (c TasksSQS) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type TasksEnvelopeMarshalerSQS interface {
+	MarshalTasksSQS(envelope sqs.EnvelopeWriter) error
+}
+
+func (c TasksSQS) SealTask(
+	envelope sqs.EnvelopeWriter,
+	message TasksEnvelopeMarshalerSQS,
+) error {
+	if err := message.MarshalTasksSQS(envelope); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c TasksSQS) PublishTask(
+	ctx context.Context,
+
+	message TasksEnvelopeMarshalerSQS,
+) error {
+	envelope := sqs.NewEnvelopeOut(nil)
+	if err := c.SealTask(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c TasksSQS) PublishEnvelope(ctx context.Context, envelope sqs.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope sqs.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c TasksSQS) Publisher() sqs.Publisher {
+	return c.publisher
+}
+
+func (c TasksSQS) Publish(ctx context.Context, envelopes ...sqs.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type TasksEnvelopeUnmarshalerSQS interface {
+	UnmarshalTasksSQS(envelope sqs.EnvelopeReader) error
+}
+
+func (c TasksSQS) UnsealTask(
+	envelope sqs.EnvelopeReader,
+	message TasksEnvelopeUnmarshalerSQS,
+) error {
+	return message.UnmarshalTasksSQS(envelope)
+}
+
+// SubscribeTask receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c TasksSQS) SubscribeTask(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.TaskReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope sqs.EnvelopeReader, message any) error {
+		m := message.(*messages.TaskIn)
+		if err2 := c.UnsealTask(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope sqs.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) sqs.EnvelopeReader {
+				return &tasksSQSBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope sqs.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.TaskIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// tasksSQSBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type tasksSQSBufferedEnvelope struct {
+	sqs.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *tasksSQSBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *tasksSQSBufferedEnvelope) Unwrap() sqs.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c TasksSQS) Subscriber() sqs.Subscriber {
+	return c.subscriber
+}
+
+func (c TasksSQS) Subscribe(ctx context.Context, cb func(envelope sqs.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
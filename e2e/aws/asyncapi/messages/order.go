@@ -0,0 +1,200 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/proto/sns"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/proto/sqs"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+)
+
+type OrderBindings struct{}
+
+func (c OrderBindings) SQS() sqs.MessageBindings {
+	return sqs.MessageBindings{
+		MessageGroupID: "orders",
+	}
+}
+func (c OrderBindings) SNS() sns.MessageBindings {
+	return sns.MessageBindings{
+		MessageGroupID: "orders",
+	}
+}
+
+type OrderSender interface {
+	SetPayload(payload schemas.Order) *OrderOut
+	SetHeaders(headers map[string]any) *OrderOut
+}
+
+// OrderOut-- (Outbound Message)
+type OrderOut struct {
+	Payload schemas.Order
+	Headers map[string]any
+}
+
+// Validate checks the OrderOut value against the constraints from the jsonschema definition.
+func (v OrderOut) Validate() error {
+	if err := v.Payload.Validate(); err != nil {
+		return fmt.Errorf("Payload: %w", err)
+	}
+	return nil
+}
+
+func (m *OrderOut) SetPayload(payload schemas.Order) *OrderOut {
+	m.Payload = payload
+	return m
+}
+
+func (m *OrderOut) SetHeaders(headers map[string]any) *OrderOut {
+	m.Headers = headers
+	return m
+}
+
+type OrderReceiver interface {
+	Payload() schemas.Order
+	Headers() map[string]any
+}
+
+// OrderIn-- (Inbound Message)
+type OrderIn struct {
+	payload schemas.Order
+	headers map[string]any
+}
+
+// Validate checks the OrderIn value against the constraints from the jsonschema definition.
+func (v OrderIn) Validate() error {
+	if err := v.payload.Validate(); err != nil {
+		return fmt.Errorf("payload: %w", err)
+	}
+	return nil
+}
+
+func (m *OrderIn) Payload() schemas.Order {
+	return m.payload
+}
+
+func (m *OrderIn) Headers() map[string]any {
+	return m.headers
+}
+
+func (m *OrderOut) MarshalNotificationsSNS(envelope sns.EnvelopeWriter) error {
+	return m.MarshalEnvelopeSNS(envelope)
+}
+func (m *OrderOut) MarshalOrdersSNS(envelope sns.EnvelopeWriter) error {
+	return m.MarshalEnvelopeSNS(envelope)
+}
+
+func (m *OrderOut) MarshalEnvelopeSNS(envelope sns.EnvelopeWriter) error {
+	if err := m.MarshalSNS(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers(m.Headers))
+	return nil
+}
+
+func (m *OrderOut) MarshalSNS(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+func (c OrderOut) BindingsSNS() sns.MessageBindings {
+	return OrderBindings{}.SNS()
+}
+
+func (m *OrderIn) UnmarshalNotificationsSNS(envelope sns.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeSNS(envelope)
+}
+func (m *OrderIn) UnmarshalOrdersSNS(envelope sns.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeSNS(envelope)
+}
+
+func (m *OrderIn) UnmarshalEnvelopeSNS(envelope sns.EnvelopeReader) error {
+	if err := m.UnmarshalSNS(envelope); err != nil {
+		return err
+	}
+	m.headers = map[string]any(envelope.Headers())
+	return nil
+}
+
+func (m *OrderIn) UnmarshalSNS(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
+func (c OrderIn) BindingsSNS() sns.MessageBindings {
+	return OrderBindings{}.SNS()
+}
+
+func (m *OrderOut) MarshalNotificationsSQS(envelope sqs.EnvelopeWriter) error {
+	return m.MarshalEnvelopeSQS(envelope)
+}
+func (m *OrderOut) MarshalOrdersSQS(envelope sqs.EnvelopeWriter) error {
+	return m.MarshalEnvelopeSQS(envelope)
+}
+
+func (m *OrderOut) MarshalEnvelopeSQS(envelope sqs.EnvelopeWriter) error {
+	if err := m.MarshalSQS(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers(m.Headers))
+	return nil
+}
+
+func (m *OrderOut) MarshalSQS(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+func (c OrderOut) BindingsSQS() sqs.MessageBindings {
+	return OrderBindings{}.SQS()
+}
+
+func (m *OrderIn) UnmarshalNotificationsSQS(envelope sqs.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeSQS(envelope)
+}
+func (m *OrderIn) UnmarshalOrdersSQS(envelope sqs.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeSQS(envelope)
+}
+
+func (m *OrderIn) UnmarshalEnvelopeSQS(envelope sqs.EnvelopeReader) error {
+	if err := m.UnmarshalSQS(envelope); err != nil {
+		return err
+	}
+	m.headers = map[string]any(envelope.Headers())
+	return nil
+}
+
+func (m *OrderIn) UnmarshalSQS(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
+func (c OrderIn) BindingsSQS() sqs.MessageBindings {
+	return OrderBindings{}.SQS()
+}
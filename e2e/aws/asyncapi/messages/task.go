@@ -0,0 +1,115 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/proto/sqs"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+)
+
+type TaskSender interface {
+	SetPayload(payload schemas.Order) *TaskOut
+	SetHeaders(headers map[string]any) *TaskOut
+}
+
+// TaskOut-- (Outbound Message)
+type TaskOut struct {
+	Payload schemas.Order
+	Headers map[string]any
+}
+
+// Validate checks the TaskOut value against the constraints from the jsonschema definition.
+func (v TaskOut) Validate() error {
+	if err := v.Payload.Validate(); err != nil {
+		return fmt.Errorf("Payload: %w", err)
+	}
+	return nil
+}
+
+func (m *TaskOut) SetPayload(payload schemas.Order) *TaskOut {
+	m.Payload = payload
+	return m
+}
+
+func (m *TaskOut) SetHeaders(headers map[string]any) *TaskOut {
+	m.Headers = headers
+	return m
+}
+
+type TaskReceiver interface {
+	Payload() schemas.Order
+	Headers() map[string]any
+}
+
+// TaskIn-- (Inbound Message)
+type TaskIn struct {
+	payload schemas.Order
+	headers map[string]any
+}
+
+// Validate checks the TaskIn value against the constraints from the jsonschema definition.
+func (v TaskIn) Validate() error {
+	if err := v.payload.Validate(); err != nil {
+		return fmt.Errorf("payload: %w", err)
+	}
+	return nil
+}
+
+func (m *TaskIn) Payload() schemas.Order {
+	return m.payload
+}
+
+func (m *TaskIn) Headers() map[string]any {
+	return m.headers
+}
+
+func (m *TaskOut) MarshalTasksSQS(envelope sqs.EnvelopeWriter) error {
+	return m.MarshalEnvelopeSQS(envelope)
+}
+
+func (m *TaskOut) MarshalEnvelopeSQS(envelope sqs.EnvelopeWriter) error {
+	if err := m.MarshalSQS(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers(m.Headers))
+	return nil
+}
+
+func (m *TaskOut) MarshalSQS(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *TaskIn) UnmarshalTasksSQS(envelope sqs.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeSQS(envelope)
+}
+
+func (m *TaskIn) UnmarshalEnvelopeSQS(envelope sqs.EnvelopeReader) error {
+	if err := m.UnmarshalSQS(envelope); err != nil {
+		return err
+	}
+	m.headers = map[string]any(envelope.Headers())
+	return nil
+}
+
+func (m *TaskIn) UnmarshalSQS(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
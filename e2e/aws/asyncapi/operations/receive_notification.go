@@ -0,0 +1,123 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/proto/sns"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+type ReceiveNotificationServerSNS interface {
+	OpenNotificationsSNS(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.NotificationsSNS, error)
+	OpenReceiveNotificationSNS(context.Context, ...run.MiddlewareOption) (*ReceiveNotificationSNS, error)
+	Producer() sns.Producer
+	Consumer() sns.Consumer
+}
+
+func OpenReceiveNotificationSNS(
+	ctx context.Context,
+	server ReceiveNotificationServerSNS,
+
+	opts ...run.MiddlewareOption,
+) (*ReceiveNotificationSNS, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "notifications",
+			Operation: "receiveNotification",
+			Protocol:  "sns",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, receiveNotificationSNSMetrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenNotificationsSNS(
+		run.WithOperationName(ctx, "receiveNotification"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReceiveNotificationSNS{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// receiveNotificationSNSEnvelopeReader counts the payload bytes read from the envelope.
+type receiveNotificationSNSEnvelopeReader struct {
+	sns.EnvelopeReader
+	size int
+}
+
+func (e *receiveNotificationSNSEnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// receiveNotificationSNSMetrics returns the middleware that reports the received messages metrics.
+func receiveNotificationSNSMetrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[sns.EnvelopeReader]) run.SubscribeHandler[sns.EnvelopeReader] {
+		return func(ctx context.Context, envelope sns.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.OrderIn:
+				labels.Message = "order"
+			}
+			counter := &receiveNotificationSNSEnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type ReceiveNotificationChannelSNS interface {
+	Close() error
+
+	SealOrder(sns.EnvelopeWriter, channels.NotificationsEnvelopeMarshalerSNS) error
+	PublishOrder(context.Context, channels.NotificationsEnvelopeMarshalerSNS) error
+
+	UnsealOrder(sns.EnvelopeReader, channels.NotificationsEnvelopeUnmarshalerSNS) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+}
+
+type ReceiveNotificationSNS struct {
+	Channel      ReceiveNotificationChannelSNS
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c ReceiveNotificationSNS) Close() error {
+	return c.Channel.Close()
+}
+
+func (o ReceiveNotificationSNS) UnsealOrder(
+	envelope sns.EnvelopeReader,
+	message channels.NotificationsEnvelopeUnmarshalerSNS,
+) error {
+	return o.Channel.UnsealOrder(envelope, message)
+}
+
+func (o ReceiveNotificationSNS) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeOrder(ctx, cb)
+}
@@ -0,0 +1,123 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/proto/sqs"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+type ReceiveOrderServerSQS interface {
+	OpenOrdersSQS(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersSQS, error)
+	OpenReceiveOrderSQS(context.Context, ...run.MiddlewareOption) (*ReceiveOrderSQS, error)
+	Producer() sqs.Producer
+	Consumer() sqs.Consumer
+}
+
+func OpenReceiveOrderSQS(
+	ctx context.Context,
+	server ReceiveOrderServerSQS,
+
+	opts ...run.MiddlewareOption,
+) (*ReceiveOrderSQS, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "receiveOrder",
+			Protocol:  "sqs",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, receiveOrderSQSMetrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenOrdersSQS(
+		run.WithOperationName(ctx, "receiveOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReceiveOrderSQS{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// receiveOrderSQSEnvelopeReader counts the payload bytes read from the envelope.
+type receiveOrderSQSEnvelopeReader struct {
+	sqs.EnvelopeReader
+	size int
+}
+
+func (e *receiveOrderSQSEnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// receiveOrderSQSMetrics returns the middleware that reports the received messages metrics.
+func receiveOrderSQSMetrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[sqs.EnvelopeReader]) run.SubscribeHandler[sqs.EnvelopeReader] {
+		return func(ctx context.Context, envelope sqs.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.OrderIn:
+				labels.Message = "order"
+			}
+			counter := &receiveOrderSQSEnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type ReceiveOrderChannelSQS interface {
+	Close() error
+
+	SealOrder(sqs.EnvelopeWriter, channels.OrdersEnvelopeMarshalerSQS) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerSQS) error
+
+	UnsealOrder(sqs.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerSQS) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+}
+
+type ReceiveOrderSQS struct {
+	Channel      ReceiveOrderChannelSQS
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c ReceiveOrderSQS) Close() error {
+	return c.Channel.Close()
+}
+
+func (o ReceiveOrderSQS) UnsealOrder(
+	envelope sqs.EnvelopeReader,
+	message channels.OrdersEnvelopeUnmarshalerSQS,
+) error {
+	return o.Channel.UnsealOrder(envelope, message)
+}
+
+func (o ReceiveOrderSQS) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeOrder(ctx, cb)
+}
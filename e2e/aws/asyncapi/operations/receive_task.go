@@ -0,0 +1,123 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/proto/sqs"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+type ReceiveTaskServerSQS interface {
+	OpenTasksSQS(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.TasksSQS, error)
+	OpenReceiveTaskSQS(context.Context, ...run.MiddlewareOption) (*ReceiveTaskSQS, error)
+	Producer() sqs.Producer
+	Consumer() sqs.Consumer
+}
+
+func OpenReceiveTaskSQS(
+	ctx context.Context,
+	server ReceiveTaskServerSQS,
+
+	opts ...run.MiddlewareOption,
+) (*ReceiveTaskSQS, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "tasks",
+			Operation: "receiveTask",
+			Protocol:  "sqs",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, receiveTaskSQSMetrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenTasksSQS(
+		run.WithOperationName(ctx, "receiveTask"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReceiveTaskSQS{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// receiveTaskSQSEnvelopeReader counts the payload bytes read from the envelope.
+type receiveTaskSQSEnvelopeReader struct {
+	sqs.EnvelopeReader
+	size int
+}
+
+func (e *receiveTaskSQSEnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// receiveTaskSQSMetrics returns the middleware that reports the received messages metrics.
+func receiveTaskSQSMetrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[sqs.EnvelopeReader]) run.SubscribeHandler[sqs.EnvelopeReader] {
+		return func(ctx context.Context, envelope sqs.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.TaskIn:
+				labels.Message = "task"
+			}
+			counter := &receiveTaskSQSEnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type ReceiveTaskChannelSQS interface {
+	Close() error
+
+	SealTask(sqs.EnvelopeWriter, channels.TasksEnvelopeMarshalerSQS) error
+	PublishTask(context.Context, channels.TasksEnvelopeMarshalerSQS) error
+
+	UnsealTask(sqs.EnvelopeReader, channels.TasksEnvelopeUnmarshalerSQS) error
+	SubscribeTask(context.Context, func(context.Context, messages.TaskReceiver) error) error
+}
+
+type ReceiveTaskSQS struct {
+	Channel      ReceiveTaskChannelSQS
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c ReceiveTaskSQS) Close() error {
+	return c.Channel.Close()
+}
+
+func (o ReceiveTaskSQS) UnsealTask(
+	envelope sqs.EnvelopeReader,
+	message channels.TasksEnvelopeUnmarshalerSQS,
+) error {
+	return o.Channel.UnsealTask(envelope, message)
+}
+
+func (o ReceiveTaskSQS) SubscribeTask(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.TaskReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeTask(ctx, cb)
+}
@@ -0,0 +1,120 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/proto/sns"
+	"github.com/bdragon300/go-asyncapi/run"
+	"time"
+)
+
+type SendNotificationServerSNS interface {
+	OpenNotificationsSNS(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.NotificationsSNS, error)
+	OpenSendNotificationSNS(context.Context, ...run.MiddlewareOption) (*SendNotificationSNS, error)
+	Producer() sns.Producer
+	Consumer() sns.Consumer
+}
+
+func OpenSendNotificationSNS(
+	ctx context.Context,
+	server SendNotificationServerSNS,
+
+	opts ...run.MiddlewareOption,
+) (*SendNotificationSNS, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "notifications",
+			Operation: "sendNotification",
+			Protocol:  "sns",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenNotificationsSNS(
+		run.WithOperationName(ctx, "sendNotification"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendNotificationSNS{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// sendNotificationSNSEnvelopeWriter counts the payload bytes written to the envelope.
+type sendNotificationSNSEnvelopeWriter struct {
+	sns.EnvelopeWriter
+	size int
+}
+
+func (e *sendNotificationSNSEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type SendNotificationChannelSNS interface {
+	Close() error
+
+	SealOrder(sns.EnvelopeWriter, channels.NotificationsEnvelopeMarshalerSNS) error
+	PublishOrder(context.Context, channels.NotificationsEnvelopeMarshalerSNS) error
+
+	UnsealOrder(sns.EnvelopeReader, channels.NotificationsEnvelopeUnmarshalerSNS) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+	PublishEnvelope(context.Context, sns.EnvelopeWriter, any) error
+}
+
+type SendNotificationSNS struct {
+	Channel      SendNotificationChannelSNS
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c SendNotificationSNS) Close() error {
+	return c.Channel.Close()
+}
+
+func (o SendNotificationSNS) SealOrder(
+	envelope sns.EnvelopeWriter,
+	message channels.NotificationsEnvelopeMarshalerSNS,
+) error {
+	return o.Channel.SealOrder(envelope, message)
+}
+
+func (o SendNotificationSNS) PublishOrder(
+	ctx context.Context,
+
+	message channels.NotificationsEnvelopeMarshalerSNS,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishOrder(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "order"
+	envelope := sns.NewEnvelopeOut(nil)
+	counter := &sendNotificationSNSEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealOrder(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
@@ -0,0 +1,120 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/proto/sqs"
+	"github.com/bdragon300/go-asyncapi/run"
+	"time"
+)
+
+type SendOrderServerSQS interface {
+	OpenOrdersSQS(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersSQS, error)
+	OpenSendOrderSQS(context.Context, ...run.MiddlewareOption) (*SendOrderSQS, error)
+	Producer() sqs.Producer
+	Consumer() sqs.Consumer
+}
+
+func OpenSendOrderSQS(
+	ctx context.Context,
+	server SendOrderServerSQS,
+
+	opts ...run.MiddlewareOption,
+) (*SendOrderSQS, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "sendOrder",
+			Protocol:  "sqs",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenOrdersSQS(
+		run.WithOperationName(ctx, "sendOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendOrderSQS{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// sendOrderSQSEnvelopeWriter counts the payload bytes written to the envelope.
+type sendOrderSQSEnvelopeWriter struct {
+	sqs.EnvelopeWriter
+	size int
+}
+
+func (e *sendOrderSQSEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type SendOrderChannelSQS interface {
+	Close() error
+
+	SealOrder(sqs.EnvelopeWriter, channels.OrdersEnvelopeMarshalerSQS) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerSQS) error
+
+	UnsealOrder(sqs.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerSQS) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+	PublishEnvelope(context.Context, sqs.EnvelopeWriter, any) error
+}
+
+type SendOrderSQS struct {
+	Channel      SendOrderChannelSQS
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c SendOrderSQS) Close() error {
+	return c.Channel.Close()
+}
+
+func (o SendOrderSQS) SealOrder(
+	envelope sqs.EnvelopeWriter,
+	message channels.OrdersEnvelopeMarshalerSQS,
+) error {
+	return o.Channel.SealOrder(envelope, message)
+}
+
+func (o SendOrderSQS) PublishOrder(
+	ctx context.Context,
+
+	message channels.OrdersEnvelopeMarshalerSQS,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishOrder(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "order"
+	envelope := sqs.NewEnvelopeOut(nil)
+	counter := &sendOrderSQSEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealOrder(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
@@ -0,0 +1,120 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/proto/sqs"
+	"github.com/bdragon300/go-asyncapi/run"
+	"time"
+)
+
+type SendTaskServerSQS interface {
+	OpenTasksSQS(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.TasksSQS, error)
+	OpenSendTaskSQS(context.Context, ...run.MiddlewareOption) (*SendTaskSQS, error)
+	Producer() sqs.Producer
+	Consumer() sqs.Consumer
+}
+
+func OpenSendTaskSQS(
+	ctx context.Context,
+	server SendTaskServerSQS,
+
+	opts ...run.MiddlewareOption,
+) (*SendTaskSQS, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "tasks",
+			Operation: "sendTask",
+			Protocol:  "sqs",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenTasksSQS(
+		run.WithOperationName(ctx, "sendTask"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendTaskSQS{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// sendTaskSQSEnvelopeWriter counts the payload bytes written to the envelope.
+type sendTaskSQSEnvelopeWriter struct {
+	sqs.EnvelopeWriter
+	size int
+}
+
+func (e *sendTaskSQSEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type SendTaskChannelSQS interface {
+	Close() error
+
+	SealTask(sqs.EnvelopeWriter, channels.TasksEnvelopeMarshalerSQS) error
+	PublishTask(context.Context, channels.TasksEnvelopeMarshalerSQS) error
+
+	UnsealTask(sqs.EnvelopeReader, channels.TasksEnvelopeUnmarshalerSQS) error
+	SubscribeTask(context.Context, func(context.Context, messages.TaskReceiver) error) error
+	PublishEnvelope(context.Context, sqs.EnvelopeWriter, any) error
+}
+
+type SendTaskSQS struct {
+	Channel      SendTaskChannelSQS
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c SendTaskSQS) Close() error {
+	return c.Channel.Close()
+}
+
+func (o SendTaskSQS) SealTask(
+	envelope sqs.EnvelopeWriter,
+	message channels.TasksEnvelopeMarshalerSQS,
+) error {
+	return o.Channel.SealTask(envelope, message)
+}
+
+func (o SendTaskSQS) PublishTask(
+	ctx context.Context,
+
+	message channels.TasksEnvelopeMarshalerSQS,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishTask(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "task"
+	envelope := sqs.NewEnvelopeOut(nil)
+	counter := &sendTaskSQSEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealTask(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
@@ -0,0 +1,346 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package sns
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snsTypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+const (
+	// DefaultReceiveWaitTime is the long polling wait time of the subscription queue.
+	DefaultReceiveWaitTime = 20 * time.Second
+	// DefaultSubscriptionQueueSuffix is added to the topic name to make the default subscription queue name.
+	DefaultSubscriptionQueueSuffix = "-go-asyncapi"
+	// localRegion is used for local endpoints such as LocalStack, if the region is not configured.
+	localRegion = "us-east-1"
+	fifoSuffix  = ".fifo"
+)
+
+var awsHostRe = regexp.MustCompile(`^[a-z0-9-]+\.([a-z0-9-]+)\.amazonaws\.com(\.cn)?$`)
+
+// ClientOption modifies the AWS SDK config options before loading the config.
+type ClientOption = func(opts *config.LoadOptions) error
+
+// NewClient creates the SNS client and SQS client, which is used to receive the messages. The AWS config is loaded
+// from the environment, shared config files, etc. as usual for AWS SDK.
+//
+// If the server host is the AWS host, e.g. "sns.us-east-1.amazonaws.com", the region is taken from it. Otherwise,
+// the server is considered as the local endpoint such as LocalStack, so the client connects to it by plain HTTP
+// with static dummy credentials.
+func NewClient(ctx context.Context, serverURL string, security run.AnySecurityScheme, extraOpts ...ClientOption) (*Client, error) {
+	opts, err := loadOptions(serverURL, security)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, append(opts, extraOpts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("load aws config: %w", err)
+	}
+	if cfg.Region == "" && cfg.BaseEndpoint != nil {
+		cfg.Region = localRegion
+	}
+	return &Client{Client: sns.NewFromConfig(cfg), SQS: sqs.NewFromConfig(cfg)}, nil
+}
+
+type Client struct {
+	*sns.Client
+	// SQS is used to receive the topic messages from SQS queue subscribed to the topic.
+	SQS *sqs.Client
+	// DisableTopicCreation disables creating the topic. In this case, the topic ARN is looked up by the topic name.
+	DisableTopicCreation bool
+	// SubscriptionQueueName returns the name of SQS queue, that is subscribed to the topic to receive messages.
+	// If nil, DefaultSubscriptionQueueName is used.
+	SubscriptionQueueName func(topic string, opBindings *OperationBindings) string
+}
+
+func (c *Client) Publisher(ctx context.Context, address string, chb *ChannelBindings, opb *OperationBindings, security run.AnySecurityScheme) (Publisher, error) {
+	if security != nil {
+		return nil, fmt.Errorf("operation security schemes are not supported")
+	}
+
+	topicARN, err := c.TopicARN(ctx, address, chb, opb)
+	if err != nil {
+		return nil, err
+	}
+	return &PublishChannel{Client: c.Client, TopicARN: topicARN}, nil
+}
+
+// Subscriber subscribes the SQS queue to the topic with raw message delivery and returns the subscriber that
+// receives the messages from this queue. The queue is created if it does not exist.
+func (c *Client) Subscriber(ctx context.Context, address string, chb *ChannelBindings, opb *OperationBindings, security run.AnySecurityScheme) (Subscriber, error) {
+	if security != nil {
+		return nil, fmt.Errorf("operation security schemes are not supported")
+	}
+
+	topicARN, err := c.TopicARN(ctx, address, chb, opb)
+	if err != nil {
+		return nil, err
+	}
+	topic := topicARN[strings.LastIndex(topicARN, ":")+1:]
+	queueName := DefaultSubscriptionQueueName(topic, opb)
+	if c.SubscriptionQueueName != nil {
+		queueName = c.SubscriptionQueueName(topic, opb)
+	}
+	queueURL, queueARN, err := c.ensureQueue(ctx, queueName, topicARN)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := map[string]string{"RawMessageDelivery": "true"}
+	if consumer, ok := sqsConsumer(opb); ok {
+		if consumer.FilterPolicy != "" {
+			attrs["FilterPolicy"] = consumer.FilterPolicy
+		}
+		if consumer.FilterPolicyScope != "" {
+			attrs["FilterPolicyScope"] = string(consumer.FilterPolicyScope)
+		}
+		if dlq := consumer.RedrivePolicy.DeadLetterQueue; dlq.ARN != "" || dlq.Name != "" {
+			dlqARN, err := c.queueARN(ctx, dlq)
+			if err != nil {
+				return nil, err
+			}
+			b, err := json.Marshal(map[string]string{"deadLetterTargetArn": dlqARN})
+			if err != nil {
+				return nil, err
+			}
+			attrs["RedrivePolicy"] = string(b)
+		}
+	}
+	_, err = c.Subscribe(ctx, &sns.SubscribeInput{
+		TopicArn:              aws.String(topicARN),
+		Protocol:              aws.String("sqs"),
+		Endpoint:              aws.String(queueARN),
+		Attributes:            attrs,
+		ReturnSubscriptionArn: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("subscribe queue %q to topic %q: %w", queueName, topicARN, err)
+	}
+
+	ctx2, cancel := context.WithCancel(context.Background())
+	return &SubscribeChannel{
+		Client:   c.SQS,
+		QueueURL: queueURL,
+		WaitTime: DefaultReceiveWaitTime,
+		ctx:      ctx2,
+		cancel:   cancel,
+	}, nil
+}
+
+// TopicARN returns the ARN of the channel topic. The topic is taken from the operation bindings, the channel
+// bindings or the channel address, in this order. If the topic is set by name, it is created according to the
+// channel bindings (that returns ARN of existing topic), unless DisableTopicCreation is set.
+func (c *Client) TopicARN(ctx context.Context, address string, chb *ChannelBindings, opb *OperationBindings) (string, error) {
+	name := address
+	switch {
+	case opb != nil && opb.Topic.ARN != "":
+		return opb.Topic.ARN, nil
+	case opb != nil && opb.Topic.Name != "":
+		name = opb.Topic.Name
+	case chb != nil && chb.Name != "":
+		name = chb.Name
+	}
+	if strings.HasPrefix(name, "arn:") {
+		return name, nil
+	}
+
+	if c.DisableTopicCreation {
+		return c.findTopic(ctx, name)
+	}
+	attrs := make(map[string]string)
+	var tags []snsTypes.Tag
+	if chb != nil {
+		if chb.Ordering.Type == OrderingTypeFIFO {
+			attrs["FifoTopic"] = "true"
+			attrs["ContentBasedDeduplication"] = strconv.FormatBool(chb.Ordering.ContentBasedDeduplication)
+		}
+		if chb.Policy != "" {
+			attrs["Policy"] = chb.Policy
+		}
+		for k, v := range chb.Tags {
+			tags = append(tags, snsTypes.Tag{Key: aws.String(k), Value: aws.String(v)})
+		}
+	}
+	res, err := c.CreateTopic(ctx, &sns.CreateTopicInput{Name: aws.String(name), Attributes: attrs, Tags: tags})
+	if err != nil {
+		return "", fmt.Errorf("create topic %q: %w", name, err)
+	}
+	return aws.ToString(res.TopicArn), nil
+}
+
+func (c *Client) findTopic(ctx context.Context, name string) (string, error) {
+	pages := sns.NewListTopicsPaginator(c.Client, &sns.ListTopicsInput{})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return "", fmt.Errorf("list topics: %w", err)
+		}
+		for _, t := range page.Topics {
+			if arn := aws.ToString(t.TopicArn); strings.HasSuffix(arn, ":"+name) {
+				return arn, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("topic %q not found", name)
+}
+
+// ensureQueue returns the URL and ARN of the subscription queue. If the queue does not exist, it is created with
+// the access policy that allows the topic to send messages to it.
+func (c *Client) ensureQueue(ctx context.Context, name, topicARN string) (string, string, error) {
+	res, err := c.SQS.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{QueueName: aws.String(name)})
+	var notExist *types.QueueDoesNotExist
+	switch {
+	case err == nil:
+		queueURL := aws.ToString(res.QueueUrl)
+		queueARN, err := c.queueAttribute(ctx, queueURL, types.QueueAttributeNameQueueArn)
+		return queueURL, queueARN, err
+	case !errors.As(err, &notExist):
+		return "", "", fmt.Errorf("get url of queue %q: %w", name, err)
+	}
+
+	attrs := make(map[string]string)
+	if strings.HasSuffix(name, fifoSuffix) {
+		attrs[string(types.QueueAttributeNameFifoQueue)] = "true"
+	}
+	created, err := c.SQS.CreateQueue(ctx, &sqs.CreateQueueInput{QueueName: aws.String(name), Attributes: attrs})
+	if err != nil {
+		return "", "", fmt.Errorf("create queue %q: %w", name, err)
+	}
+	queueURL := aws.ToString(created.QueueUrl)
+	queueARN, err := c.queueAttribute(ctx, queueURL, types.QueueAttributeNameQueueArn)
+	if err != nil {
+		return "", "", err
+	}
+	return queueURL, queueARN, c.allowTopic(ctx, queueURL, queueARN, topicARN)
+}
+
+// allowTopic sets the queue access policy that allows the topic to send messages to the queue.
+func (c *Client) allowTopic(ctx context.Context, queueURL, queueARN, topicARN string) error {
+	policy, err := json.Marshal(map[string]any{
+		"Version": "2012-10-17",
+		"Statement": []any{map[string]any{
+			"Effect":    "Allow",
+			"Principal": map[string]string{"Service": "sns.amazonaws.com"},
+			"Action":    "sqs:SendMessage",
+			"Resource":  queueARN,
+			"Condition": map[string]any{"ArnEquals": map[string]string{"aws:SourceArn": topicARN}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.SQS.SetQueueAttributes(ctx, &sqs.SetQueueAttributesInput{
+		QueueUrl:   aws.String(queueURL),
+		Attributes: map[string]string{string(types.QueueAttributeNamePolicy): string(policy)},
+	})
+	if err != nil {
+		return fmt.Errorf("set policy of queue %q: %w", queueURL, err)
+	}
+	return nil
+}
+
+func (c *Client) queueARN(ctx context.Context, id Identifier) (string, error) {
+	if id.ARN != "" {
+		return id.ARN, nil
+	}
+	res, err := c.SQS.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{QueueName: aws.String(id.Name)})
+	if err != nil {
+		return "", fmt.Errorf("get url of queue %q: %w", id.Name, err)
+	}
+	return c.queueAttribute(ctx, aws.ToString(res.QueueUrl), types.QueueAttributeNameQueueArn)
+}
+
+func (c *Client) queueAttribute(ctx context.Context, queueURL string, name types.QueueAttributeName) (string, error) {
+	res, err := c.SQS.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(queueURL),
+		AttributeNames: []types.QueueAttributeName{name},
+	})
+	if err != nil {
+		return "", fmt.Errorf("get attribute %s of queue %q: %w", name, queueURL, err)
+	}
+	return res.Attributes[string(name)], nil
+}
+
+// DefaultSubscriptionQueueName returns the queue name of the first "sqs" consumer in the operation bindings. If
+// there is no such consumer, returns the topic name with DefaultSubscriptionQueueSuffix.
+func DefaultSubscriptionQueueName(topic string, opBindings *OperationBindings) string {
+	if consumer, ok := sqsConsumer(opBindings); ok {
+		switch ep := consumer.Endpoint; {
+		case ep.Name != "":
+			return ep.Name
+		case ep.ARN != "":
+			return ep.ARN[strings.LastIndex(ep.ARN, ":")+1:]
+		case ep.URL != "":
+			return path.Base(ep.URL)
+		}
+	}
+	if strings.HasSuffix(topic, fifoSuffix) {
+		return strings.TrimSuffix(topic, fifoSuffix) + DefaultSubscriptionQueueSuffix + fifoSuffix
+	}
+	return topic + DefaultSubscriptionQueueSuffix
+}
+
+func sqsConsumer(opBindings *OperationBindings) (TopicConsumer, bool) {
+	if opBindings == nil {
+		return TopicConsumer{}, false
+	}
+	for _, c := range opBindings.Consumers {
+		if c.Protocol == "sqs" {
+			return c, true
+		}
+	}
+	return TopicConsumer{}, false
+}
+
+func loadOptions(serverURL string, security run.AnySecurityScheme) ([]ClientOption, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse server url: %w", err)
+	}
+
+	var res []ClientOption
+	if m := awsHostRe.FindStringSubmatch(u.Hostname()); m != nil {
+		res = append(res, config.WithRegion(m[1]))
+	} else if u.Host != "" {
+		scheme := u.Scheme
+		if scheme != "https" {
+			scheme = "http"
+		}
+		res = append(res,
+			config.WithBaseEndpoint(scheme+"://"+u.Host),
+			config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider("test", "test", "")),
+		)
+	}
+
+	if security != nil {
+		switch v := security.(type) {
+		case run.UserPasswordSecurity:
+			accessKeyID, secretAccessKey := v.UserPassword()
+			res = append(res, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, "")))
+		default:
+			return nil, errors.New("unsupported security scheme: " + security.AuthType())
+		}
+	}
+	return res, nil
+}
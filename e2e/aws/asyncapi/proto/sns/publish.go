@@ -0,0 +1,34 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package sns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+)
+
+type PublishChannel struct {
+	Client   *sns.Client
+	TopicARN string
+}
+
+// Send publishes the envelopes to the topic one by one.
+func (p PublishChannel) Send(ctx context.Context, envelopes ...EnvelopeWriter) error {
+	for i, envelope := range envelopes {
+		e := envelope.(*EnvelopeOut)
+		input := *e.PublishInput
+		input.TopicArn = aws.String(p.TopicARN)
+		input.Message = aws.String(string(e.payload))
+		if _, err := p.Client.Publish(ctx, &input); err != nil {
+			return fmt.Errorf("envelope #%d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (p PublishChannel) Close() error {
+	return nil
+}
@@ -0,0 +1,88 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package sns
+
+type (
+	ServerBindings struct{}
+
+	ChannelBindings struct {
+		// Name of the topic. If empty, the channel address is used. For FIFO topic, it must end with ".fifo" suffix.
+		Name     string
+		Ordering Ordering
+		// Policy is the topic access policy in JSON.
+		Policy string
+		Tags   map[string]string
+	}
+
+	OperationBindings struct {
+		// Topic overrides the topic from channel.
+		Topic          Identifier
+		Consumers      []TopicConsumer
+		DeliveryPolicy DeliveryPolicy
+	}
+
+	MessageBindings struct {
+		// MessageGroupID is the message group ID for FIFO topics.
+		MessageGroupID string
+		// MessageDeduplicationID is the message deduplication ID for FIFO topics.
+		MessageDeduplicationID string
+	}
+)
+
+type Ordering struct {
+	Type                      OrderingType
+	ContentBasedDeduplication bool
+}
+
+type OrderingType string
+
+const (
+	OrderingTypeStandard OrderingType = "standard"
+	OrderingTypeFIFO     OrderingType = "FIFO"
+)
+
+// Identifier identifies the topic or the consumer endpoint. Only one field is expected to be set.
+type Identifier struct {
+	URL   string
+	Email string
+	Phone string
+	ARN   string
+	Name  string
+}
+
+type TopicConsumer struct {
+	// Protocol is the subscription protocol, such as "sqs", "http", "https", "email", "lambda", etc.
+	Protocol string
+	Endpoint Identifier
+	// FilterPolicy is the subscription filter policy in JSON.
+	FilterPolicy       string
+	FilterPolicyScope  FilterPolicyScope
+	RawMessageDelivery bool
+	RedrivePolicy      RedrivePolicy
+	DeliveryPolicy     DeliveryPolicy
+	DisplayName        string
+}
+
+type FilterPolicyScope string
+
+const (
+	FilterPolicyScopeMessageAttributes FilterPolicyScope = "MessageAttributes"
+	FilterPolicyScopeMessageBody       FilterPolicyScope = "MessageBody"
+)
+
+type RedrivePolicy struct {
+	DeadLetterQueue Identifier
+	MaxReceiveCount int
+}
+
+// DeliveryPolicy is the delivery policy for HTTP(S) subscriptions.
+type DeliveryPolicy struct {
+	MinDelayTarget       int
+	MaxDelayTarget       int
+	NumRetries           int
+	NumNoDelayRetries    int
+	NumMinDelayRetries   int
+	NumMaxDelayRetries   int
+	BackoffFunction      string
+	MaxReceivesPerSecond int
+}
@@ -0,0 +1,77 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package sqs
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+func NewEnvelopeOut(buf []byte) *EnvelopeOut {
+	return &EnvelopeOut{
+		SendMessageInput: &sqs.SendMessageInput{},
+		payload:          buf,
+	}
+}
+
+type EnvelopeOut struct {
+	*sqs.SendMessageInput
+	payload         []byte
+	messageBindings MessageBindings
+}
+
+func (e *EnvelopeOut) Write(p []byte) (n int, err error) {
+	e.payload = append(e.payload, p...)
+	return len(p), nil
+}
+
+func (e *EnvelopeOut) ResetPayload() {
+	e.payload = e.payload[:0]
+}
+
+func (e *EnvelopeOut) SetHeaders(headers run.Headers) {
+	for k, v := range headers.ToByteValues() {
+		e.setAttribute(k, string(v))
+	}
+}
+
+func (e *EnvelopeOut) SetContentType(contentType string) {
+	e.setAttribute("Content-Type", contentType)
+}
+
+// SetBindings sets the message bindings. The message group ID and deduplication ID from bindings are set to the
+// message if they are not set yet.
+func (e *EnvelopeOut) SetBindings(bindings MessageBindings) {
+	e.messageBindings = bindings
+	if e.MessageGroupId == nil && bindings.MessageGroupID != "" {
+		e.SetMessageGroupID(bindings.MessageGroupID)
+	}
+	if e.MessageDeduplicationId == nil && bindings.MessageDeduplicationID != "" {
+		e.SetMessageDeduplicationID(bindings.MessageDeduplicationID)
+	}
+}
+
+// SetMessageGroupID sets the message group ID, that is required for FIFO queues.
+func (e *EnvelopeOut) SetMessageGroupID(id string) {
+	e.MessageGroupId = aws.String(id)
+}
+
+// SetMessageDeduplicationID sets the message deduplication ID for FIFO queues.
+func (e *EnvelopeOut) SetMessageDeduplicationID(id string) {
+	e.MessageDeduplicationId = aws.String(id)
+}
+
+func (e *EnvelopeOut) setAttribute(name, value string) {
+	if e.MessageAttributes == nil {
+		e.MessageAttributes = make(map[string]types.MessageAttributeValue)
+	}
+	e.MessageAttributes[name] = types.MessageAttributeValue{
+		DataType:    aws.String("String"),
+		StringValue: aws.String(value),
+	}
+}
@@ -0,0 +1,13 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package schemas
+
+type Order struct {
+	ID     string `json:"id"`
+	Amount int    `json:"amount"`
+}
+
+// Validate checks the Order value against the constraints from the jsonschema definition.
+func (v Order) Validate() error {
+	return nil
+}
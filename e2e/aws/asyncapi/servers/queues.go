@@ -0,0 +1,161 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package servers
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/proto/sqs"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+	"net/url"
+)
+
+func QueuesURL() (*url.URL, error) {
+	return &url.URL{Scheme: "sqs", Host: "localhost:4566", Path: ""}, nil
+}
+
+func NewQueues(producer sqs.Producer, consumer sqs.Consumer) *Queues {
+	return &Queues{
+		producer: producer,
+		consumer: consumer,
+	}
+}
+
+type QueuesClosable struct {
+	Queues
+}
+
+func (c QueuesClosable) Close() error {
+	var err error
+	if v, ok := any(c.producer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	if v, ok := any(c.consumer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	return err
+}
+
+func ConnectQueuesBidi(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...sqs.ClientOption,
+) (*QueuesClosable, error) {
+	client, err := sqs.NewClient(ctx, url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	producer, consumer := client, client
+	return &QueuesClosable{
+		Queues{producer: producer, consumer: consumer},
+	}, nil
+}
+
+func ConnectQueuesProducer(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...sqs.ClientOption,
+) (*QueuesClosable, error) {
+	producer, err := sqs.NewClient(ctx, url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &QueuesClosable{
+		Queues{producer: producer},
+	}, nil
+}
+
+func ConnectQueuesConsumer(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...sqs.ClientOption,
+) (*QueuesClosable, error) {
+	consumer, err := sqs.NewClient(ctx, url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &QueuesClosable{
+		Queues{consumer: consumer},
+	}, nil
+}
+
+type Queues struct {
+	producer sqs.Producer
+	consumer sqs.Consumer
+}
+
+func (s Queues) Name() string {
+	return "Queues"
+}
+
+func (s Queues) Producer() sqs.Producer {
+	return s.producer
+}
+
+func (s Queues) Consumer() sqs.Consumer {
+	return s.consumer
+}
+
+func (s Queues) OpenOrdersSQS(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.OrdersSQS, error) {
+	return channels.OpenOrdersSQS(
+		ctx, s, nil, security, opts...,
+	)
+}
+func (s Queues) OpenTasksSQS(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.TasksSQS, error) {
+	return channels.OpenTasksSQS(
+		ctx, s, nil, security, opts...,
+	)
+}
+
+func (s Queues) OpenReceiveOrderSQS(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.ReceiveOrderSQS, error) {
+	return operations.OpenReceiveOrderSQS(
+		ctx, s, opts...,
+	)
+}
+func (s Queues) OpenSendOrderSQS(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.SendOrderSQS, error) {
+	return operations.OpenSendOrderSQS(
+		ctx, s, opts...,
+	)
+}
+func (s Queues) OpenReceiveTaskSQS(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.ReceiveTaskSQS, error) {
+	return operations.OpenReceiveTaskSQS(
+		ctx, s, opts...,
+	)
+}
+func (s Queues) OpenSendTaskSQS(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.SendTaskSQS, error) {
+	return operations.OpenSendTaskSQS(
+		ctx, s, opts...,
+	)
+}
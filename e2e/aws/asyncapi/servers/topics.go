@@ -0,0 +1,133 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package servers
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/proto/sns"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+	"net/url"
+)
+
+func TopicsURL() (*url.URL, error) {
+	return &url.URL{Scheme: "sns", Host: "localhost:4566", Path: ""}, nil
+}
+
+func NewTopics(producer sns.Producer, consumer sns.Consumer) *Topics {
+	return &Topics{
+		producer: producer,
+		consumer: consumer,
+	}
+}
+
+type TopicsClosable struct {
+	Topics
+}
+
+func (c TopicsClosable) Close() error {
+	var err error
+	if v, ok := any(c.producer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	if v, ok := any(c.consumer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	return err
+}
+
+func ConnectTopicsBidi(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...sns.ClientOption,
+) (*TopicsClosable, error) {
+	client, err := sns.NewClient(ctx, url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	producer, consumer := client, client
+	return &TopicsClosable{
+		Topics{producer: producer, consumer: consumer},
+	}, nil
+}
+
+func ConnectTopicsProducer(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...sns.ClientOption,
+) (*TopicsClosable, error) {
+	producer, err := sns.NewClient(ctx, url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &TopicsClosable{
+		Topics{producer: producer},
+	}, nil
+}
+
+func ConnectTopicsConsumer(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...sns.ClientOption,
+) (*TopicsClosable, error) {
+	consumer, err := sns.NewClient(ctx, url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &TopicsClosable{
+		Topics{consumer: consumer},
+	}, nil
+}
+
+type Topics struct {
+	producer sns.Producer
+	consumer sns.Consumer
+}
+
+func (s Topics) Name() string {
+	return "Topics"
+}
+
+func (s Topics) Producer() sns.Producer {
+	return s.producer
+}
+
+func (s Topics) Consumer() sns.Consumer {
+	return s.consumer
+}
+
+func (s Topics) OpenNotificationsSNS(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.NotificationsSNS, error) {
+	return channels.OpenNotificationsSNS(
+		ctx, s, nil, security, opts...,
+	)
+}
+
+func (s Topics) OpenReceiveNotificationSNS(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.ReceiveNotificationSNS, error) {
+	return operations.OpenReceiveNotificationSNS(
+		ctx, s, opts...,
+	)
+}
+func (s Topics) OpenSendNotificationSNS(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.SendNotificationSNS, error) {
+	return operations.OpenSendNotificationSNS(
+		ctx, s, opts...,
+	)
+}
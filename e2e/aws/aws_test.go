@@ -0,0 +1,706 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/proto/sqs"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi/servers"
+)
+
+func TestQueueRoundTrip(t *testing.T) {
+	ctx, fake, u := startServer(t)
+	conn, err := servers.ConnectQueuesBidi(ctx, u)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer conn.Close()
+
+	recvOp, err := operations.OpenReceiveOrderSQS(ctx, conn)
+	if err != nil {
+		t.Fatalf("open receive operation: %v", err)
+	}
+	defer recvOp.Close()
+	sendOp, err := operations.OpenSendOrderSQS(ctx, conn)
+	if err != nil {
+		t.Fatalf("open send operation: %v", err)
+	}
+	defer sendOp.Close()
+
+	for i := 1; i <= 3; i++ {
+		msg := new(messages.OrderOut).SetPayload(schemas.Order{ID: "o" + strconv.Itoa(i), Amount: i})
+		msg.SetHeaders(map[string]any{"trace": "abc"})
+		if err = sendOp.PublishOrder(ctx, msg); err != nil {
+			t.Fatalf("PublishOrder() error = %v", err)
+		}
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var got []int
+	err = recvOp.SubscribeOrder(subCtx, func(_ context.Context, message messages.OrderReceiver) error {
+		got = append(got, message.Payload().Amount)
+		for k, want := range map[string]string{"trace": "abc", "Content-Type": "application/json"} {
+			if v := message.Headers()[k]; v != want {
+				t.Errorf("header %q = %v, want %q", k, v, want)
+			}
+		}
+		if len(got) == 3 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("SubscribeOrder() error = %v, want context.Canceled", err)
+	}
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("received orders = %v, want [1 2 3]", got)
+	}
+
+	// Queue is created according to the queue binding
+	q := fake.queue("orders.fifo")
+	if q == nil {
+		t.Fatalf("queue orders.fifo is not created, queues: %v", fake.queueNames())
+	}
+	if q.attribute("FifoQueue") != "true" || q.attribute("ReceiveMessageWaitTimeSeconds") != "5" {
+		t.Errorf("queue attributes = %v, want FifoQueue and ReceiveMessageWaitTimeSeconds", q.attributes)
+	}
+	// Message group ID is taken from the message bindings
+	for _, m := range q.sent() {
+		if m.groupID != "orders" {
+			t.Errorf("message %q group ID = %q, want %q", m.body, m.groupID, "orders")
+		}
+	}
+	// Long polling wait time is taken from the queue binding, messages are deleted after the callback returns
+	if waits := q.receiveWaits(); len(waits) == 0 || slices.ContainsFunc(waits, func(w int) bool { return w != 5 }) {
+		t.Errorf("receive wait times = %v, want all 5", waits)
+	}
+	if deleted, left := q.counts(); deleted != 3 || left != 0 {
+		t.Errorf("deleted = %d, left in queue = %d, want 3, 0", deleted, left)
+	}
+}
+
+func TestQueueDeduplication(t *testing.T) {
+	ctx, fake, u := startServer(t)
+	conn, err := servers.ConnectQueuesProducer(ctx, u)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer conn.Close()
+
+	ch, err := channels.OpenOrdersSQS(ctx, conn, nil, nil)
+	if err != nil {
+		t.Fatalf("open channel: %v", err)
+	}
+	defer ch.Close()
+
+	// IDs set in the envelope take precedence over the ones from message bindings
+	for range 2 {
+		msg := new(messages.OrderOut).SetPayload(schemas.Order{ID: "o1"})
+		envelope := sqs.NewEnvelopeOut(nil)
+		envelope.SetMessageGroupID("custom")
+		envelope.SetMessageDeduplicationID("o1")
+		if err = ch.SealOrder(envelope, msg); err != nil {
+			t.Fatalf("SealOrder() error = %v", err)
+		}
+		if err = ch.PublishEnvelope(ctx, envelope, msg); err != nil {
+			t.Fatalf("PublishEnvelope() error = %v", err)
+		}
+	}
+
+	q := fake.queue("orders.fifo")
+	sent := q.sent()
+	if len(sent) != 1 {
+		t.Fatalf("messages in queue = %d, want 1, since the second one is a duplicate", len(sent))
+	}
+	if sent[0].groupID != "custom" || sent[0].deduplicationID != "o1" {
+		t.Errorf("group ID = %q, deduplication ID = %q, want %q, %q", sent[0].groupID, sent[0].deduplicationID, "custom", "o1")
+	}
+}
+
+func TestQueueNack(t *testing.T) {
+	ctx, fake, u := startServer(t)
+	conn, err := servers.ConnectQueuesBidi(ctx, u)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer conn.Close()
+
+	ch, err := channels.OpenTasksSQS(ctx, conn, nil, nil)
+	if err != nil {
+		t.Fatalf("open channel: %v", err)
+	}
+	defer ch.Close()
+	if err = ch.PublishTask(ctx, new(messages.TaskOut).SetPayload(schemas.Order{ID: "t1"})); err != nil {
+		t.Fatalf("PublishTask() error = %v", err)
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var receiveCounts []string
+	err = ch.Subscribe(subCtx, func(envelope sqs.EnvelopeReader) {
+		var m messages.TaskIn
+		if err := ch.UnsealTask(envelope, &m); err != nil {
+			t.Errorf("UnsealTask() error = %v", err)
+		}
+		if m.Payload().ID != "t1" {
+			t.Errorf("payload ID = %q, want %q", m.Payload().ID, "t1")
+		}
+		e := envelope.(*sqs.EnvelopeIn)
+		receiveCounts = append(receiveCounts, e.Attributes["ApproximateReceiveCount"])
+		// Nacked message becomes visible again and is redelivered. Then it's acknowledged manually, so it's not
+		// deleted for the second time after the callback returns.
+		if len(receiveCounts) == 1 {
+			if err := e.Nack(ctx); err != nil {
+				t.Errorf("Nack() error = %v", err)
+			}
+			return
+		}
+		if err := e.Ack(ctx); err != nil {
+			t.Errorf("Ack() error = %v", err)
+		}
+		cancel()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Subscribe() error = %v, want context.Canceled", err)
+	}
+
+	q := fake.queue("tasks")
+	if !slices.Equal(receiveCounts, []string{"1", "2"}) {
+		t.Errorf("receive counts = %v, want [1 2]", receiveCounts)
+	}
+	if vt := q.visibilityTimeouts(); !slices.Equal(vt, []int{0}) {
+		t.Errorf("visibility timeout changes = %v, want [0]", vt)
+	}
+	if deleted, left := q.counts(); deleted != 1 || left != 0 {
+		t.Errorf("deleted = %d, left in queue = %d, want 1, 0", deleted, left)
+	}
+	// Default long polling wait time
+	if waits := q.receiveWaits(); len(waits) == 0 || waits[0] != 20 {
+		t.Errorf("receive wait times = %v, want 20", waits)
+	}
+}
+
+func TestTopicRoundTrip(t *testing.T) {
+	ctx, fake, u := startServer(t)
+	conn, err := servers.ConnectTopicsBidi(ctx, u)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer conn.Close()
+
+	recvOp, err := operations.OpenReceiveNotificationSNS(ctx, conn)
+	if err != nil {
+		t.Fatalf("open receive operation: %v", err)
+	}
+	defer recvOp.Close()
+	sendOp, err := operations.OpenSendNotificationSNS(ctx, conn)
+	if err != nil {
+		t.Fatalf("open send operation: %v", err)
+	}
+	defer sendOp.Close()
+
+	for i := 1; i <= 2; i++ {
+		msg := new(messages.OrderOut).SetPayload(schemas.Order{ID: "n" + strconv.Itoa(i), Amount: i})
+		if err = sendOp.PublishOrder(ctx, msg); err != nil {
+			t.Fatalf("PublishOrder() error = %v", err)
+		}
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var got []int
+	err = recvOp.SubscribeOrder(subCtx, func(_ context.Context, message messages.OrderReceiver) error {
+		got = append(got, message.Payload().Amount)
+		if v := message.Headers()["Content-Type"]; v != "application/json" {
+			t.Errorf("Content-Type header = %v, want application/json", v)
+		}
+		if len(got) == 2 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("SubscribeOrder() error = %v, want context.Canceled", err)
+	}
+	if !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("received orders = %v, want [1 2]", got)
+	}
+
+	// FIFO topic is created according to the channel bindings, the subscription queue is subscribed with raw
+	// message delivery. Message group ID is taken from the message bindings.
+	topic := fake.topic("notifications.fifo")
+	if topic == nil || topic.attributes["FifoTopic"] != "true" {
+		t.Fatalf("topic = %+v, want FIFO topic notifications.fifo", topic)
+	}
+	q := fake.queue("notifications-go-asyncapi.fifo")
+	if q == nil {
+		t.Fatalf("subscription queue is not created, queues: %v", fake.queueNames())
+	}
+	if len(topic.subscriptions) != 1 || topic.subscriptions[q.arn]["RawMessageDelivery"] != "true" {
+		t.Errorf("subscriptions = %v, want raw delivery to %s", topic.subscriptions, q.arn)
+	}
+	if q.attribute("FifoQueue") != "true" || !strings.Contains(q.attribute("Policy"), topic.arn) {
+		t.Errorf("queue attributes = %v, want FIFO queue with policy allowing the topic", q.attributes)
+	}
+	for _, m := range q.sent() {
+		if m.groupID != "orders" {
+			t.Errorf("message %q group ID = %q, want %q", m.body, m.groupID, "orders")
+		}
+	}
+	if deleted, left := q.counts(); deleted != 2 || left != 0 {
+		t.Errorf("deleted = %d, left in queue = %d, want 2, 0", deleted, left)
+	}
+}
+
+// startServer starts the fake AWS endpoint and returns its URL, that the generated clients consider as the local
+// endpoint such as LocalStack.
+func startServer(t *testing.T) (context.Context, *fakeAWS, *url.URL) {
+	t.Helper()
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_CONFIG_FILE", "/dev/null")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/dev/null")
+
+	fake := &fakeAWS{queues: make(map[string]*fakeQueue), topics: make(map[string]*fakeTopic)}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	fake.url = srv.URL
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return t.Context(), fake, u
+}
+
+const fakeAccountID = "000000000000"
+
+// fakeAWS is the fake AWS endpoint, that serves the SQS JSON protocol and SNS query protocol requests used by
+// the generated code. Messages published to the topic are delivered to the subscribed queues as raw messages.
+type fakeAWS struct {
+	url string
+
+	mu      sync.Mutex
+	queues  map[string]*fakeQueue
+	topics  map[string]*fakeTopic
+	counter atomic.Int64
+}
+
+func (f *fakeAWS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if target := r.Header.Get("X-Amz-Target"); strings.HasPrefix(target, "AmazonSQS.") {
+		f.serveSQS(w, r, strings.TrimPrefix(target, "AmazonSQS."))
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.serveSNS(w, r.PostForm)
+}
+
+func (f *fakeAWS) queue(name string) *fakeQueue {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.queues[name]
+}
+
+func (f *fakeAWS) queueNames() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var res []string
+	for k := range f.queues {
+		res = append(res, k)
+	}
+	return res
+}
+
+func (f *fakeAWS) topic(name string) *fakeTopic {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.topics[name]
+}
+
+func (f *fakeAWS) nextID() string {
+	return strconv.FormatInt(f.counter.Add(1), 10)
+}
+
+type sqsRequest struct {
+	QueueName              string
+	QueueUrl               string
+	Attributes             map[string]string
+	MessageBody            string
+	MessageAttributes      map[string]sqsAttributeValue
+	MessageGroupId         string
+	MessageDeduplicationId string
+	MaxNumberOfMessages    int
+	WaitTimeSeconds        int
+	ReceiptHandle          string
+	VisibilityTimeout      int
+}
+
+type sqsAttributeValue struct {
+	DataType    string
+	StringValue string `json:",omitempty"`
+}
+
+type sqsMessage struct {
+	MessageId         string
+	ReceiptHandle     string
+	Body              string
+	Attributes        map[string]string
+	MessageAttributes map[string]sqsAttributeValue
+}
+
+func (f *fakeAWS) serveSQS(w http.ResponseWriter, r *http.Request, op string) {
+	var req sqsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	q := f.queues[req.QueueName]
+	if req.QueueUrl != "" {
+		q = f.queues[req.QueueUrl[strings.LastIndex(req.QueueUrl, "/")+1:]]
+	}
+	f.mu.Unlock()
+	if q == nil && op != "CreateQueue" {
+		writeSQSError(w, "QueueDoesNotExist", "The specified queue does not exist")
+		return
+	}
+
+	var res any
+	switch op {
+	case "GetQueueUrl":
+		res = map[string]string{"QueueUrl": q.url}
+	case "CreateQueue":
+		f.mu.Lock()
+		if q = f.queues[req.QueueName]; q == nil {
+			q = &fakeQueue{
+				name:       req.QueueName,
+				url:        f.url + "/" + fakeAccountID + "/" + req.QueueName,
+				arn:        "arn:aws:sqs:us-east-1:" + fakeAccountID + ":" + req.QueueName,
+				attributes: req.Attributes,
+				notify:     make(chan struct{}),
+				dedupIDs:   make(map[string]bool),
+			}
+			f.queues[req.QueueName] = q
+		}
+		f.mu.Unlock()
+		res = map[string]string{"QueueUrl": q.url}
+	case "GetQueueAttributes":
+		res = map[string]any{"Attributes": map[string]string{"QueueArn": q.arn}}
+	case "SetQueueAttributes":
+		q.setAttributes(req.Attributes)
+		res = struct{}{}
+	case "SendMessage":
+		if q.attribute("FifoQueue") == "true" && req.MessageGroupId == "" {
+			writeSQSError(w, "MissingParameter", "The request must contain the parameter MessageGroupId")
+			return
+		}
+		id := f.nextID()
+		q.send(&fakeMessage{
+			id:              id,
+			body:            req.MessageBody,
+			attributes:      req.MessageAttributes,
+			groupID:         req.MessageGroupId,
+			deduplicationID: req.MessageDeduplicationId,
+		})
+		res = map[string]string{"MessageId": id}
+	case "ReceiveMessage":
+		msgs := q.receive(r.Context(), req.MaxNumberOfMessages, req.WaitTimeSeconds, f.nextID)
+		res = map[string]any{"Messages": msgs}
+	case "DeleteMessage":
+		q.delete(req.ReceiptHandle)
+		res = struct{}{}
+	case "ChangeMessageVisibility":
+		q.changeVisibility(req.ReceiptHandle, req.VisibilityTimeout)
+		res = struct{}{}
+	default:
+		http.Error(w, "unsupported operation "+op, http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func writeSQSError(w http.ResponseWriter, code, message string) {
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]string{"__type": "com.amazonaws.sqs#" + code, "message": message})
+}
+
+func (f *fakeAWS) serveSNS(w http.ResponseWriter, form url.Values) {
+	action := form.Get("Action")
+	var field, value string
+	switch action {
+	case "CreateTopic":
+		name := form.Get("Name")
+		f.mu.Lock()
+		t := f.topics[name]
+		if t == nil {
+			t = &fakeTopic{
+				arn:           "arn:aws:sns:us-east-1:" + fakeAccountID + ":" + name,
+				attributes:    queryMap(form, "Attributes", "key", "value"),
+				subscriptions: make(map[string]map[string]string),
+			}
+			f.topics[name] = t
+		}
+		f.mu.Unlock()
+		field, value = "TopicArn", t.arn
+	case "Subscribe":
+		t := f.topic(form.Get("TopicArn")[strings.LastIndex(form.Get("TopicArn"), ":")+1:])
+		if t == nil {
+			http.Error(w, "topic does not exist", http.StatusNotFound)
+			return
+		}
+		// Subscribing the same endpoint again returns the existing subscription
+		f.mu.Lock()
+		t.subscriptions[form.Get("Endpoint")] = queryMap(form, "Attributes", "key", "value")
+		f.mu.Unlock()
+		field, value = "SubscriptionArn", t.arn+":"+form.Get("Endpoint")
+	case "Publish":
+		t := f.topic(form.Get("TopicArn")[strings.LastIndex(form.Get("TopicArn"), ":")+1:])
+		if t == nil {
+			http.Error(w, "topic does not exist", http.StatusNotFound)
+			return
+		}
+		if t.attributes["FifoTopic"] == "true" && form.Get("MessageGroupId") == "" {
+			http.Error(w, "The request must contain the parameter MessageGroupId", http.StatusBadRequest)
+			return
+		}
+		attrs := make(map[string]sqsAttributeValue)
+		for k, v := range queryMap(form, "MessageAttributes", "Name", "Value.StringValue") {
+			attrs[k] = sqsAttributeValue{DataType: "String", StringValue: v}
+		}
+		id := f.nextID()
+		f.mu.Lock()
+		for queueARN := range t.subscriptions {
+			for _, q := range f.queues {
+				if q.arn == queueARN {
+					q.send(&fakeMessage{
+						id:              id,
+						body:            form.Get("Message"),
+						attributes:      attrs,
+						groupID:         form.Get("MessageGroupId"),
+						deduplicationID: form.Get("MessageDeduplicationId"),
+					})
+				}
+			}
+		}
+		f.mu.Unlock()
+		field, value = "MessageId", id
+	default:
+		http.Error(w, "unsupported action "+action, http.StatusBadRequest)
+		return
+	}
+
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(value))
+	w.Header().Set("Content-Type", "text/xml")
+	_, _ = fmt.Fprintf(w, "<%[1]sResponse><%[1]sResult><%[2]s>%[3]s</%[2]s></%[1]sResult></%[1]sResponse>", action, field, b.String())
+}
+
+// queryMap returns the map encoded in AWS query protocol as prefix.entry.N.keyName and prefix.entry.N.valueName.
+func queryMap(form url.Values, prefix, keyName, valueName string) map[string]string {
+	res := make(map[string]string)
+	for i := 1; ; i++ {
+		entry := fmt.Sprintf("%s.entry.%d.", prefix, i)
+		if !form.Has(entry + keyName) {
+			return res
+		}
+		res[form.Get(entry+keyName)] = form.Get(entry + valueName)
+	}
+}
+
+type fakeTopic struct {
+	arn        string
+	attributes map[string]string
+	// subscriptions are attributes of subscriptions by endpoint queue ARN
+	subscriptions map[string]map[string]string
+}
+
+type fakeMessage struct {
+	id              string
+	body            string
+	attributes      map[string]sqsAttributeValue
+	groupID         string
+	deduplicationID string
+
+	receiptHandle string
+	inFlight      bool
+	receiveCount  int
+	deleted       bool
+}
+
+type fakeQueue struct {
+	name string
+	url  string
+	arn  string
+
+	mu         sync.Mutex
+	attributes map[string]string
+	messages   []*fakeMessage
+	dedupIDs   map[string]bool
+	// notify is closed and replaced when a message becomes visible
+	notify chan struct{}
+	waits  []int
+	// visibility are the visibility timeouts set by ChangeMessageVisibility
+	visibility []int
+}
+
+func (q *fakeQueue) setAttributes(attrs map[string]string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.attributes == nil {
+		q.attributes = make(map[string]string)
+	}
+	for k, v := range attrs {
+		q.attributes[k] = v
+	}
+}
+
+func (q *fakeQueue) attribute(name string) string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.attributes[name]
+}
+
+func (q *fakeQueue) send(m *fakeMessage) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if m.deduplicationID != "" {
+		if q.dedupIDs[m.deduplicationID] {
+			return
+		}
+		q.dedupIDs[m.deduplicationID] = true
+	}
+	q.messages = append(q.messages, m)
+	q.wakeUp()
+}
+
+// receive returns up to maxCount visible messages, waiting for them at most waitSeconds.
+func (q *fakeQueue) receive(ctx context.Context, maxCount, waitSeconds int, nextID func() string) []sqsMessage {
+	timer := time.NewTimer(time.Duration(waitSeconds) * time.Second)
+	defer timer.Stop()
+
+	q.mu.Lock()
+	q.waits = append(q.waits, waitSeconds)
+	q.mu.Unlock()
+	for {
+		q.mu.Lock()
+		var res []sqsMessage
+		for _, m := range q.messages {
+			if m.deleted || m.inFlight || len(res) >= maxCount {
+				continue
+			}
+			m.inFlight = true
+			m.receiveCount++
+			m.receiptHandle = nextID()
+			res = append(res, sqsMessage{
+				MessageId:     m.id,
+				ReceiptHandle: m.receiptHandle,
+				Body:          m.body,
+				Attributes: map[string]string{
+					"ApproximateReceiveCount": strconv.Itoa(m.receiveCount),
+					"MessageGroupId":          m.groupID,
+					"MessageDeduplicationId":  m.deduplicationID,
+				},
+				MessageAttributes: m.attributes,
+			})
+		}
+		notify := q.notify
+		q.mu.Unlock()
+		if len(res) > 0 {
+			return res
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+			return nil
+		case <-notify:
+		}
+	}
+}
+
+func (q *fakeQueue) delete(receiptHandle string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, m := range q.messages {
+		if m.receiptHandle == receiptHandle {
+			m.deleted = true
+		}
+	}
+}
+
+func (q *fakeQueue) changeVisibility(receiptHandle string, timeout int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.visibility = append(q.visibility, timeout)
+	for _, m := range q.messages {
+		if m.receiptHandle == receiptHandle && timeout == 0 {
+			m.inFlight = false
+			q.wakeUp()
+		}
+	}
+}
+
+func (q *fakeQueue) wakeUp() {
+	close(q.notify)
+	q.notify = make(chan struct{})
+}
+
+// sent returns all messages that were sent to the queue.
+func (q *fakeQueue) sent() []fakeMessage {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var res []fakeMessage
+	for _, m := range q.messages {
+		res = append(res, *m)
+	}
+	return res
+}
+
+// counts returns the number of deleted messages and the number of messages left in the queue.
+func (q *fakeQueue) counts() (deleted, left int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, m := range q.messages {
+		if m.deleted {
+			deleted++
+		} else {
+			left++
+		}
+	}
+	return
+}
+
+func (q *fakeQueue) receiveWaits() []int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return slices.Clone(q.waits)
+}
+
+func (q *fakeQueue) visibilityTimeouts() []int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return slices.Clone(q.visibility)
+}
@@ -0,0 +1,4 @@
+// Package aws checks the AWS SQS and SNS implementations against the fake AWS endpoint.
+package aws
+
+//go:generate go -C ../.. run ./cmd/go-asyncapi code -t e2e/aws/asyncapi -M github.com/bdragon300/go-asyncapi/e2e/aws/asyncapi e2e/aws/asyncapi.yaml
@@ -0,0 +1,1332 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package channels
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/googlepubsub"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/mqtt5"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/nats"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/sqs"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+func OrdersAddress() run.ParamString {
+	return run.ParamString{
+		Expr: "orders",
+	}
+}
+
+func NewOrdersAMQP(
+
+	publisher amqp.Publisher,
+	subscriber amqp.Subscriber,
+	opts ...run.MiddlewareOption,
+) *OrdersAMQP {
+	res := OrdersAMQP{
+		address: OrdersAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+
+	res.routingKey = res.address.String()
+	return &res
+}
+
+type OrdersServerAMQP interface {
+	OpenOrdersAMQP(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*OrdersAMQP, error)
+	Producer() amqp.Producer
+	Consumer() amqp.Consumer
+}
+
+func OpenOrdersAMQP(
+	ctx context.Context,
+	server OrdersServerAMQP,
+
+	opBindings *amqp.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*OrdersAMQP, error) {
+	var err error
+	address, err := OrdersAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher amqp.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber amqp.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewOrdersAMQP(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type OrdersAMQP struct {
+	address     run.ParamString
+	publisher   amqp.Publisher
+	subscriber  amqp.Subscriber
+	middlewares run.Middlewares
+	exchange    string
+	queue       string
+	routingKey  string
+}
+
+func (c OrdersAMQP) Exchange() string {
+	return c.exchange
+}
+
+func (c OrdersAMQP) Queue() string {
+	return c.queue
+}
+
+func (c OrdersAMQP) RoutingKey() string {
+	return c.routingKey
+}
+
+func (c OrdersAMQP) Address() run.ParamString {
+	return c.address
+}
+
+func (c OrdersAMQP) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type OrdersEnvelopeMarshalerAMQP interface {
+	MarshalOrdersAMQP(envelope amqp.EnvelopeWriter) error
+}
+
+func (c OrdersAMQP) SealOrder(
+	envelope amqp.EnvelopeWriter,
+	message OrdersEnvelopeMarshalerAMQP,
+) error {
+	if err := message.MarshalOrdersAMQP(envelope); err != nil {
+		return err
+	}
+
+	envelope.SetRoutingKey(c.RoutingKey())
+	return nil
+}
+
+func (c OrdersAMQP) PublishOrder(
+	ctx context.Context,
+
+	message OrdersEnvelopeMarshalerAMQP,
+) error {
+	envelope := amqp.NewEnvelopeOut(nil)
+	if err := c.SealOrder(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c OrdersAMQP) PublishEnvelope(ctx context.Context, envelope amqp.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope amqp.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c OrdersAMQP) Publisher() amqp.Publisher {
+	return c.publisher
+}
+
+func (c OrdersAMQP) Publish(ctx context.Context, envelopes ...amqp.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type OrdersEnvelopeUnmarshalerAMQP interface {
+	UnmarshalOrdersAMQP(envelope amqp.EnvelopeReader) error
+}
+
+func (c OrdersAMQP) UnsealOrder(
+	envelope amqp.EnvelopeReader,
+	message OrdersEnvelopeUnmarshalerAMQP,
+) error {
+	return message.UnmarshalOrdersAMQP(envelope)
+}
+
+// SubscribeOrder receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c OrdersAMQP) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope amqp.EnvelopeReader, message any) error {
+		m := message.(*messages.OrderIn)
+		if err2 := c.UnsealOrder(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope amqp.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) amqp.EnvelopeReader {
+				return &ordersAMQPBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope amqp.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.OrderIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// ordersAMQPBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type ordersAMQPBufferedEnvelope struct {
+	amqp.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *ordersAMQPBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *ordersAMQPBufferedEnvelope) Unwrap() amqp.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c OrdersAMQP) Subscriber() amqp.Subscriber {
+	return c.subscriber
+}
+
+func (c OrdersAMQP) Subscribe(ctx context.Context, cb func(envelope amqp.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
+
+func NewOrdersGooglepubsub(
+
+	publisher googlepubsub.Publisher,
+	subscriber googlepubsub.Subscriber,
+	opts ...run.MiddlewareOption,
+) *OrdersGooglepubsub {
+	res := OrdersGooglepubsub{
+		address: OrdersAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+	return &res
+}
+
+type OrdersServerGooglepubsub interface {
+	OpenOrdersGooglepubsub(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*OrdersGooglepubsub, error)
+	Producer() googlepubsub.Producer
+	Consumer() googlepubsub.Consumer
+}
+
+func OpenOrdersGooglepubsub(
+	ctx context.Context,
+	server OrdersServerGooglepubsub,
+
+	opBindings *googlepubsub.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*OrdersGooglepubsub, error) {
+	var err error
+	address, err := OrdersAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher googlepubsub.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber googlepubsub.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewOrdersGooglepubsub(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type OrdersGooglepubsub struct {
+	address     run.ParamString
+	publisher   googlepubsub.Publisher
+	subscriber  googlepubsub.Subscriber
+	middlewares run.Middlewares
+}
+
+func (c OrdersGooglepubsub) Address() run.ParamString {
+	return c.address
+}
+
+func (c OrdersGooglepubsub) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type OrdersEnvelopeMarshalerGooglepubsub interface {
+	MarshalOrdersGooglepubsub(envelope googlepubsub.EnvelopeWriter) error
+}
+
+func (c OrdersGooglepubsub) SealOrder(
+	envelope googlepubsub.EnvelopeWriter,
+	message OrdersEnvelopeMarshalerGooglepubsub,
+) error {
+	if err := message.MarshalOrdersGooglepubsub(envelope); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c OrdersGooglepubsub) PublishOrder(
+	ctx context.Context,
+
+	message OrdersEnvelopeMarshalerGooglepubsub,
+) error {
+	envelope := googlepubsub.NewEnvelopeOut(nil)
+	if err := c.SealOrder(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c OrdersGooglepubsub) PublishEnvelope(ctx context.Context, envelope googlepubsub.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope googlepubsub.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c OrdersGooglepubsub) Publisher() googlepubsub.Publisher {
+	return c.publisher
+}
+
+func (c OrdersGooglepubsub) Publish(ctx context.Context, envelopes ...googlepubsub.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type OrdersEnvelopeUnmarshalerGooglepubsub interface {
+	UnmarshalOrdersGooglepubsub(envelope googlepubsub.EnvelopeReader) error
+}
+
+func (c OrdersGooglepubsub) UnsealOrder(
+	envelope googlepubsub.EnvelopeReader,
+	message OrdersEnvelopeUnmarshalerGooglepubsub,
+) error {
+	return message.UnmarshalOrdersGooglepubsub(envelope)
+}
+
+// SubscribeOrder receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c OrdersGooglepubsub) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope googlepubsub.EnvelopeReader, message any) error {
+		m := message.(*messages.OrderIn)
+		if err2 := c.UnsealOrder(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope googlepubsub.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) googlepubsub.EnvelopeReader {
+				return &ordersGooglepubsubBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope googlepubsub.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.OrderIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// ordersGooglepubsubBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type ordersGooglepubsubBufferedEnvelope struct {
+	googlepubsub.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *ordersGooglepubsubBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *ordersGooglepubsubBufferedEnvelope) Unwrap() googlepubsub.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c OrdersGooglepubsub) Subscriber() googlepubsub.Subscriber {
+	return c.subscriber
+}
+
+func (c OrdersGooglepubsub) Subscribe(ctx context.Context, cb func(envelope googlepubsub.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
+
+func NewOrdersKafka(
+
+	publisher kafka.Publisher,
+	subscriber kafka.Subscriber,
+	opts ...run.MiddlewareOption,
+) *OrdersKafka {
+	res := OrdersKafka{
+		address: OrdersAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+
+	res.topic = res.address.String()
+	return &res
+}
+
+type OrdersServerKafka interface {
+	OpenOrdersKafka(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*OrdersKafka, error)
+	Producer() kafka.Producer
+	Consumer() kafka.Consumer
+}
+
+func OpenOrdersKafka(
+	ctx context.Context,
+	server OrdersServerKafka,
+
+	opBindings *kafka.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*OrdersKafka, error) {
+	var err error
+	address, err := OrdersAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher kafka.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber kafka.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewOrdersKafka(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type OrdersKafka struct {
+	address     run.ParamString
+	publisher   kafka.Publisher
+	subscriber  kafka.Subscriber
+	middlewares run.Middlewares
+	topic       string
+}
+
+func (c OrdersKafka) Topic() string {
+	return c.topic
+}
+
+func (c OrdersKafka) Address() run.ParamString {
+	return c.address
+}
+
+func (c OrdersKafka) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type OrdersEnvelopeMarshalerKafka interface {
+	MarshalOrdersKafka(envelope kafka.EnvelopeWriter) error
+}
+
+func (c OrdersKafka) SealOrder(
+	envelope kafka.EnvelopeWriter,
+	message OrdersEnvelopeMarshalerKafka,
+) error {
+	if err := message.MarshalOrdersKafka(envelope); err != nil {
+		return err
+	}
+
+	envelope.SetTopic(c.Topic())
+	return nil
+}
+
+func (c OrdersKafka) PublishOrder(
+	ctx context.Context,
+
+	message OrdersEnvelopeMarshalerKafka,
+) error {
+	envelope := kafka.NewEnvelopeOut(nil)
+	if err := c.SealOrder(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c OrdersKafka) PublishEnvelope(ctx context.Context, envelope kafka.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope kafka.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c OrdersKafka) Publisher() kafka.Publisher {
+	return c.publisher
+}
+
+func (c OrdersKafka) Publish(ctx context.Context, envelopes ...kafka.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type OrdersEnvelopeUnmarshalerKafka interface {
+	UnmarshalOrdersKafka(envelope kafka.EnvelopeReader) error
+}
+
+func (c OrdersKafka) UnsealOrder(
+	envelope kafka.EnvelopeReader,
+	message OrdersEnvelopeUnmarshalerKafka,
+) error {
+	if err := envelope.VerifyBindings(kafka.MessageBindings{}); err != nil {
+		return err
+	}
+	return message.UnmarshalOrdersKafka(envelope)
+}
+
+// SubscribeOrder receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c OrdersKafka) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope kafka.EnvelopeReader, message any) error {
+		m := message.(*messages.OrderIn)
+		if err2 := c.UnsealOrder(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope kafka.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) kafka.EnvelopeReader {
+				return &ordersKafkaBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope kafka.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.OrderIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// ordersKafkaBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type ordersKafkaBufferedEnvelope struct {
+	kafka.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *ordersKafkaBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *ordersKafkaBufferedEnvelope) Unwrap() kafka.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c OrdersKafka) Subscriber() kafka.Subscriber {
+	return c.subscriber
+}
+
+func (c OrdersKafka) Subscribe(ctx context.Context, cb func(envelope kafka.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
+
+func NewOrdersMqtt5(
+
+	publisher mqtt5.Publisher,
+	subscriber mqtt5.Subscriber,
+	opts ...run.MiddlewareOption,
+) *OrdersMqtt5 {
+	res := OrdersMqtt5{
+		address: OrdersAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+	return &res
+}
+
+type OrdersServerMqtt5 interface {
+	OpenOrdersMqtt5(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*OrdersMqtt5, error)
+	Producer() mqtt5.Producer
+	Consumer() mqtt5.Consumer
+}
+
+func OpenOrdersMqtt5(
+	ctx context.Context,
+	server OrdersServerMqtt5,
+
+	opBindings *mqtt5.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*OrdersMqtt5, error) {
+	var err error
+	address, err := OrdersAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher mqtt5.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber mqtt5.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewOrdersMqtt5(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type OrdersMqtt5 struct {
+	address     run.ParamString
+	publisher   mqtt5.Publisher
+	subscriber  mqtt5.Subscriber
+	middlewares run.Middlewares
+	topic       string
+}
+
+func (c OrdersMqtt5) Topic() string {
+	return c.topic
+}
+
+func (c OrdersMqtt5) Address() run.ParamString {
+	return c.address
+}
+
+func (c OrdersMqtt5) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type OrdersEnvelopeMarshalerMqtt5 interface {
+	MarshalOrdersMqtt5(envelope mqtt5.EnvelopeWriter) error
+}
+
+func (c OrdersMqtt5) SealOrder(
+	envelope mqtt5.EnvelopeWriter,
+	message OrdersEnvelopeMarshalerMqtt5,
+) error {
+	if err := message.MarshalOrdersMqtt5(envelope); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c OrdersMqtt5) PublishOrder(
+	ctx context.Context,
+
+	message OrdersEnvelopeMarshalerMqtt5,
+) error {
+	envelope := mqtt5.NewEnvelopeOut(nil)
+	if err := c.SealOrder(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c OrdersMqtt5) PublishEnvelope(ctx context.Context, envelope mqtt5.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope mqtt5.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c OrdersMqtt5) Publisher() mqtt5.Publisher {
+	return c.publisher
+}
+
+func (c OrdersMqtt5) Publish(ctx context.Context, envelopes ...mqtt5.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type OrdersEnvelopeUnmarshalerMqtt5 interface {
+	UnmarshalOrdersMqtt5(envelope mqtt5.EnvelopeReader) error
+}
+
+func (c OrdersMqtt5) UnsealOrder(
+	envelope mqtt5.EnvelopeReader,
+	message OrdersEnvelopeUnmarshalerMqtt5,
+) error {
+	return message.UnmarshalOrdersMqtt5(envelope)
+}
+
+// SubscribeOrder receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c OrdersMqtt5) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope mqtt5.EnvelopeReader, message any) error {
+		m := message.(*messages.OrderIn)
+		if err2 := c.UnsealOrder(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope mqtt5.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) mqtt5.EnvelopeReader {
+				return &ordersMqtt5BufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope mqtt5.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.OrderIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// ordersMqtt5BufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type ordersMqtt5BufferedEnvelope struct {
+	mqtt5.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *ordersMqtt5BufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *ordersMqtt5BufferedEnvelope) Unwrap() mqtt5.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c OrdersMqtt5) Subscriber() mqtt5.Subscriber {
+	return c.subscriber
+}
+
+func (c OrdersMqtt5) Subscribe(ctx context.Context, cb func(envelope mqtt5.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
+
+func NewOrdersNats(
+
+	publisher nats.Publisher,
+	subscriber nats.Subscriber,
+	opts ...run.MiddlewareOption,
+) *OrdersNats {
+	res := OrdersNats{
+		address: OrdersAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+
+	res.subject = res.address.String()
+	return &res
+}
+
+type OrdersServerNats interface {
+	OpenOrdersNats(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*OrdersNats, error)
+	Producer() nats.Producer
+	Consumer() nats.Consumer
+}
+
+func OpenOrdersNats(
+	ctx context.Context,
+	server OrdersServerNats,
+
+	opBindings *nats.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*OrdersNats, error) {
+	var err error
+	address, err := OrdersAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher nats.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber nats.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewOrdersNats(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type OrdersNats struct {
+	address     run.ParamString
+	publisher   nats.Publisher
+	subscriber  nats.Subscriber
+	middlewares run.Middlewares
+	subject     string
+}
+
+func (c OrdersNats) Subject() string {
+	return c.subject
+}
+
+func (c OrdersNats) Address() run.ParamString {
+	return c.address
+}
+
+func (c OrdersNats) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type OrdersEnvelopeMarshalerNats interface {
+	MarshalOrdersNats(envelope nats.EnvelopeWriter) error
+}
+
+func (c OrdersNats) SealOrder(
+	envelope nats.EnvelopeWriter,
+	message OrdersEnvelopeMarshalerNats,
+) error {
+	if err := message.MarshalOrdersNats(envelope); err != nil {
+		return err
+	}
+
+	envelope.SetSubject(c.Subject())
+	return nil
+}
+
+func (c OrdersNats) PublishOrder(
+	ctx context.Context,
+
+	message OrdersEnvelopeMarshalerNats,
+) error {
+	envelope := nats.NewEnvelopeOut(nil)
+	if err := c.SealOrder(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c OrdersNats) PublishEnvelope(ctx context.Context, envelope nats.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope nats.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c OrdersNats) Publisher() nats.Publisher {
+	return c.publisher
+}
+
+func (c OrdersNats) Publish(ctx context.Context, envelopes ...nats.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type OrdersEnvelopeUnmarshalerNats interface {
+	UnmarshalOrdersNats(envelope nats.EnvelopeReader) error
+}
+
+func (c OrdersNats) UnsealOrder(
+	envelope nats.EnvelopeReader,
+	message OrdersEnvelopeUnmarshalerNats,
+) error {
+	return message.UnmarshalOrdersNats(envelope)
+}
+
+// SubscribeOrder receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c OrdersNats) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope nats.EnvelopeReader, message any) error {
+		m := message.(*messages.OrderIn)
+		if err2 := c.UnsealOrder(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope nats.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) nats.EnvelopeReader {
+				return &ordersNatsBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope nats.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.OrderIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// ordersNatsBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type ordersNatsBufferedEnvelope struct {
+	nats.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *ordersNatsBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *ordersNatsBufferedEnvelope) Unwrap() nats.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c OrdersNats) Subscriber() nats.Subscriber {
+	return c.subscriber
+}
+
+func (c OrdersNats) Subscribe(ctx context.Context, cb func(envelope nats.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
+
+func NewOrdersSQS(
+
+	publisher sqs.Publisher,
+	subscriber sqs.Subscriber,
+	opts ...run.MiddlewareOption,
+) *OrdersSQS {
+	res := OrdersSQS{
+		address: OrdersAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+	return &res
+}
+
+type OrdersServerSQS interface {
+	OpenOrdersSQS(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*OrdersSQS, error)
+	Producer() sqs.Producer
+	Consumer() sqs.Consumer
+}
+
+func OpenOrdersSQS(
+	ctx context.Context,
+	server OrdersServerSQS,
+
+	opBindings *sqs.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*OrdersSQS, error) {
+	var err error
+	address, err := OrdersAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher sqs.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber sqs.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewOrdersSQS(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type OrdersSQS struct {
+	address     run.ParamString
+	publisher   sqs.Publisher
+	subscriber  sqs.Subscriber
+	middlewares run.Middlewares
+}
+
+func (c OrdersSQS) Address() run.ParamString {
+	return c.address
+}
+
+func (c OrdersSQS) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type OrdersEnvelopeMarshalerSQS interface {
+	MarshalOrdersSQS(envelope sqs.EnvelopeWriter) error
+}
+
+func (c OrdersSQS) SealOrder(
+	envelope sqs.EnvelopeWriter,
+	message OrdersEnvelopeMarshalerSQS,
+) error {
+	if err := message.MarshalOrdersSQS(envelope); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c OrdersSQS) PublishOrder(
+	ctx context.Context,
+
+	message OrdersEnvelopeMarshalerSQS,
+) error {
+	envelope := sqs.NewEnvelopeOut(nil)
+	if err := c.SealOrder(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c OrdersSQS) PublishEnvelope(ctx context.Context, envelope sqs.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope sqs.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c OrdersSQS) Publisher() sqs.Publisher {
+	return c.publisher
+}
+
+func (c OrdersSQS) Publish(ctx context.Context, envelopes ...sqs.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type OrdersEnvelopeUnmarshalerSQS interface {
+	UnmarshalOrdersSQS(envelope sqs.EnvelopeReader) error
+}
+
+func (c OrdersSQS) UnsealOrder(
+	envelope sqs.EnvelopeReader,
+	message OrdersEnvelopeUnmarshalerSQS,
+) error {
+	return message.UnmarshalOrdersSQS(envelope)
+}
+
+// SubscribeOrder receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c OrdersSQS) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope sqs.EnvelopeReader, message any) error {
+		m := message.(*messages.OrderIn)
+		if err2 := c.UnsealOrder(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope sqs.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) sqs.EnvelopeReader {
+				return &ordersSQSBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope sqs.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.OrderIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// ordersSQSBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type ordersSQSBufferedEnvelope struct {
+	sqs.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *ordersSQSBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *ordersSQSBufferedEnvelope) Unwrap() sqs.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c OrdersSQS) Subscriber() sqs.Subscriber {
+	return c.subscriber
+}
+
+func (c OrdersSQS) Subscribe(ctx context.Context, cb func(envelope sqs.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
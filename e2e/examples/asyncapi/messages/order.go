@@ -0,0 +1,668 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/googlepubsub"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/mqtt5"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/nats"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/sqs"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+	"strings"
+)
+
+type OrderSender interface {
+	SetPayload(payload struct {
+		ID    string   `json:"id"`
+		Items []string `json:"items"`
+	}) *OrderOut
+	SetHeaders(headers struct {
+		TraceID string `json:"traceId"`
+		Attempt int    `json:"attempt"`
+		Urgent  bool   `json:"urgent"`
+	}) *OrderOut
+}
+
+// OrderOut-- (Outbound Message)
+type OrderOut struct {
+	Payload struct {
+		ID    string   `json:"id"`
+		Items []string `json:"items"`
+	}
+	Headers struct {
+		TraceID string `json:"traceId"`
+		Attempt int    `json:"attempt"`
+		Urgent  bool   `json:"urgent"`
+	}
+}
+
+// Validate checks the OrderOut value against the constraints from the jsonschema definition.
+func (v OrderOut) Validate() error {
+	return nil
+}
+
+func (m *OrderOut) SetPayload(payload struct {
+	ID    string   `json:"id"`
+	Items []string `json:"items"`
+}) *OrderOut {
+	m.Payload = payload
+	return m
+}
+
+func (m *OrderOut) SetHeaders(headers struct {
+	TraceID string `json:"traceId"`
+	Attempt int    `json:"attempt"`
+	Urgent  bool   `json:"urgent"`
+}) *OrderOut {
+	m.Headers = headers
+	return m
+}
+
+// ExampleOrderRetried returns the retried example of Order message declared in the document.
+// Returns error if the example contains fields that are not in the message schema.
+//
+// Order delivered on the second attempt
+func ExampleOrderRetried() (OrderOut, error) {
+	var m OrderOut
+	{
+		dec := json.NewDecoder(strings.NewReader("{\"id\":\"1\",\"items\":[\"apple\",\"pear\"]}"))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&m.Payload); err != nil {
+			return m, fmt.Errorf("decode example payload: %w", err)
+		}
+	}
+	{
+		dec := json.NewDecoder(strings.NewReader("{\"attempt\":2,\"traceId\":\"abc\",\"urgent\":true}"))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&m.Headers); err != nil {
+			return m, fmt.Errorf("decode example headers: %w", err)
+		}
+	}
+	return m, nil
+}
+
+// ExampleOrder1 returns the example of Order message declared in the document.
+// Returns error if the example contains fields that are not in the message schema.
+func ExampleOrder1() (OrderOut, error) {
+	var m OrderOut
+	{
+		dec := json.NewDecoder(strings.NewReader("{\"id\":\"2\"}"))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&m.Payload); err != nil {
+			return m, fmt.Errorf("decode example payload: %w", err)
+		}
+	}
+	return m, nil
+}
+
+type OrderReceiver interface {
+	Payload() struct {
+		ID    string   `json:"id"`
+		Items []string `json:"items"`
+	}
+	Headers() struct {
+		TraceID string `json:"traceId"`
+		Attempt int    `json:"attempt"`
+		Urgent  bool   `json:"urgent"`
+	}
+}
+
+// OrderIn-- (Inbound Message)
+type OrderIn struct {
+	payload struct {
+		ID    string   `json:"id"`
+		Items []string `json:"items"`
+	}
+	headers struct {
+		TraceID string `json:"traceId"`
+		Attempt int    `json:"attempt"`
+		Urgent  bool   `json:"urgent"`
+	}
+}
+
+// Validate checks the OrderIn value against the constraints from the jsonschema definition.
+func (v OrderIn) Validate() error {
+	return nil
+}
+
+func (m *OrderIn) Payload() struct {
+	ID    string   `json:"id"`
+	Items []string `json:"items"`
+} {
+	return m.payload
+}
+
+func (m *OrderIn) Headers() struct {
+	TraceID string `json:"traceId"`
+	Attempt int    `json:"attempt"`
+	Urgent  bool   `json:"urgent"`
+} {
+	return m.headers
+}
+
+func (m *OrderOut) MarshalOrdersAMQP(envelope amqp.EnvelopeWriter) error {
+	return m.MarshalEnvelopeAMQP(envelope)
+}
+
+func (m *OrderOut) MarshalEnvelopeAMQP(envelope amqp.EnvelopeWriter) error {
+	if err := m.MarshalAMQP(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers{
+		"TraceID": m.Headers.TraceID,
+		"Attempt": m.Headers.Attempt,
+		"Urgent":  m.Headers.Urgent,
+	})
+	return nil
+}
+
+func (m *OrderOut) MarshalAMQP(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *OrderIn) UnmarshalOrdersAMQP(envelope amqp.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeAMQP(envelope)
+}
+
+func (m *OrderIn) UnmarshalEnvelopeAMQP(envelope amqp.EnvelopeReader) error {
+	if err := m.UnmarshalAMQP(envelope); err != nil {
+		return err
+	}
+	headers := envelope.Headers()
+	if v, ok := headers["TraceID"]; ok {
+		switch tv := v.(type) {
+		case string:
+			m.headers.TraceID = tv
+		case []byte:
+			m.headers.TraceID = string(tv)
+		}
+	}
+	if v, ok := headers["Attempt"]; ok {
+		switch tv := v.(type) {
+		case int:
+			m.headers.Attempt = tv
+		case []byte:
+			if err := json.Unmarshal(tv, &m.headers.Attempt); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Attempt", err)
+			}
+		case string:
+			if err := json.Unmarshal([]byte(tv), &m.headers.Attempt); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Attempt", err)
+			}
+		}
+	}
+	if v, ok := headers["Urgent"]; ok {
+		switch tv := v.(type) {
+		case bool:
+			m.headers.Urgent = tv
+		case []byte:
+			if err := json.Unmarshal(tv, &m.headers.Urgent); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Urgent", err)
+			}
+		case string:
+			if err := json.Unmarshal([]byte(tv), &m.headers.Urgent); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Urgent", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *OrderIn) UnmarshalAMQP(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *OrderOut) MarshalOrdersGooglepubsub(envelope googlepubsub.EnvelopeWriter) error {
+	return m.MarshalEnvelopeGooglepubsub(envelope)
+}
+
+func (m *OrderOut) MarshalEnvelopeGooglepubsub(envelope googlepubsub.EnvelopeWriter) error {
+	if err := m.MarshalGooglepubsub(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers{
+		"TraceID": m.Headers.TraceID,
+		"Attempt": m.Headers.Attempt,
+		"Urgent":  m.Headers.Urgent,
+	})
+	return nil
+}
+
+func (m *OrderOut) MarshalGooglepubsub(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *OrderIn) UnmarshalOrdersGooglepubsub(envelope googlepubsub.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeGooglepubsub(envelope)
+}
+
+func (m *OrderIn) UnmarshalEnvelopeGooglepubsub(envelope googlepubsub.EnvelopeReader) error {
+	if err := m.UnmarshalGooglepubsub(envelope); err != nil {
+		return err
+	}
+	headers := envelope.Headers()
+	if v, ok := headers["TraceID"]; ok {
+		switch tv := v.(type) {
+		case string:
+			m.headers.TraceID = tv
+		case []byte:
+			m.headers.TraceID = string(tv)
+		}
+	}
+	if v, ok := headers["Attempt"]; ok {
+		switch tv := v.(type) {
+		case int:
+			m.headers.Attempt = tv
+		case []byte:
+			if err := json.Unmarshal(tv, &m.headers.Attempt); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Attempt", err)
+			}
+		case string:
+			if err := json.Unmarshal([]byte(tv), &m.headers.Attempt); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Attempt", err)
+			}
+		}
+	}
+	if v, ok := headers["Urgent"]; ok {
+		switch tv := v.(type) {
+		case bool:
+			m.headers.Urgent = tv
+		case []byte:
+			if err := json.Unmarshal(tv, &m.headers.Urgent); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Urgent", err)
+			}
+		case string:
+			if err := json.Unmarshal([]byte(tv), &m.headers.Urgent); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Urgent", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *OrderIn) UnmarshalGooglepubsub(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *OrderOut) MarshalOrdersKafka(envelope kafka.EnvelopeWriter) error {
+	return m.MarshalEnvelopeKafka(envelope)
+}
+
+func (m *OrderOut) MarshalEnvelopeKafka(envelope kafka.EnvelopeWriter) error {
+	if err := m.MarshalKafka(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers{
+		"TraceID": m.Headers.TraceID,
+		"Attempt": m.Headers.Attempt,
+		"Urgent":  m.Headers.Urgent,
+	})
+	return nil
+}
+
+func (m *OrderOut) MarshalKafka(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *OrderIn) UnmarshalOrdersKafka(envelope kafka.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeKafka(envelope)
+}
+
+func (m *OrderIn) UnmarshalEnvelopeKafka(envelope kafka.EnvelopeReader) error {
+	if err := m.UnmarshalKafka(envelope); err != nil {
+		return err
+	}
+	headers := envelope.Headers()
+	if v, ok := headers["TraceID"]; ok {
+		switch tv := v.(type) {
+		case string:
+			m.headers.TraceID = tv
+		case []byte:
+			m.headers.TraceID = string(tv)
+		}
+	}
+	if v, ok := headers["Attempt"]; ok {
+		switch tv := v.(type) {
+		case int:
+			m.headers.Attempt = tv
+		case []byte:
+			if err := json.Unmarshal(tv, &m.headers.Attempt); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Attempt", err)
+			}
+		case string:
+			if err := json.Unmarshal([]byte(tv), &m.headers.Attempt); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Attempt", err)
+			}
+		}
+	}
+	if v, ok := headers["Urgent"]; ok {
+		switch tv := v.(type) {
+		case bool:
+			m.headers.Urgent = tv
+		case []byte:
+			if err := json.Unmarshal(tv, &m.headers.Urgent); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Urgent", err)
+			}
+		case string:
+			if err := json.Unmarshal([]byte(tv), &m.headers.Urgent); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Urgent", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *OrderIn) UnmarshalKafka(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *OrderOut) MarshalOrdersMqtt5(envelope mqtt5.EnvelopeWriter) error {
+	return m.MarshalEnvelopeMqtt5(envelope)
+}
+
+func (m *OrderOut) MarshalEnvelopeMqtt5(envelope mqtt5.EnvelopeWriter) error {
+	if err := m.MarshalMqtt5(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers{
+		"TraceID": m.Headers.TraceID,
+		"Attempt": m.Headers.Attempt,
+		"Urgent":  m.Headers.Urgent,
+	})
+	return nil
+}
+
+func (m *OrderOut) MarshalMqtt5(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *OrderIn) UnmarshalOrdersMqtt5(envelope mqtt5.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeMqtt5(envelope)
+}
+
+func (m *OrderIn) UnmarshalEnvelopeMqtt5(envelope mqtt5.EnvelopeReader) error {
+	if err := m.UnmarshalMqtt5(envelope); err != nil {
+		return err
+	}
+	headers := envelope.Headers()
+	if v, ok := headers["TraceID"]; ok {
+		switch tv := v.(type) {
+		case string:
+			m.headers.TraceID = tv
+		case []byte:
+			m.headers.TraceID = string(tv)
+		}
+	}
+	if v, ok := headers["Attempt"]; ok {
+		switch tv := v.(type) {
+		case int:
+			m.headers.Attempt = tv
+		case []byte:
+			if err := json.Unmarshal(tv, &m.headers.Attempt); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Attempt", err)
+			}
+		case string:
+			if err := json.Unmarshal([]byte(tv), &m.headers.Attempt); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Attempt", err)
+			}
+		}
+	}
+	if v, ok := headers["Urgent"]; ok {
+		switch tv := v.(type) {
+		case bool:
+			m.headers.Urgent = tv
+		case []byte:
+			if err := json.Unmarshal(tv, &m.headers.Urgent); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Urgent", err)
+			}
+		case string:
+			if err := json.Unmarshal([]byte(tv), &m.headers.Urgent); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Urgent", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *OrderIn) UnmarshalMqtt5(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *OrderOut) MarshalOrdersNats(envelope nats.EnvelopeWriter) error {
+	return m.MarshalEnvelopeNats(envelope)
+}
+
+func (m *OrderOut) MarshalEnvelopeNats(envelope nats.EnvelopeWriter) error {
+	if err := m.MarshalNats(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers{
+		"TraceID": m.Headers.TraceID,
+		"Attempt": m.Headers.Attempt,
+		"Urgent":  m.Headers.Urgent,
+	})
+	return nil
+}
+
+func (m *OrderOut) MarshalNats(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *OrderIn) UnmarshalOrdersNats(envelope nats.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeNats(envelope)
+}
+
+func (m *OrderIn) UnmarshalEnvelopeNats(envelope nats.EnvelopeReader) error {
+	if err := m.UnmarshalNats(envelope); err != nil {
+		return err
+	}
+	headers := envelope.Headers()
+	if v, ok := headers["TraceID"]; ok {
+		switch tv := v.(type) {
+		case string:
+			m.headers.TraceID = tv
+		case []byte:
+			m.headers.TraceID = string(tv)
+		}
+	}
+	if v, ok := headers["Attempt"]; ok {
+		switch tv := v.(type) {
+		case int:
+			m.headers.Attempt = tv
+		case []byte:
+			if err := json.Unmarshal(tv, &m.headers.Attempt); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Attempt", err)
+			}
+		case string:
+			if err := json.Unmarshal([]byte(tv), &m.headers.Attempt); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Attempt", err)
+			}
+		}
+	}
+	if v, ok := headers["Urgent"]; ok {
+		switch tv := v.(type) {
+		case bool:
+			m.headers.Urgent = tv
+		case []byte:
+			if err := json.Unmarshal(tv, &m.headers.Urgent); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Urgent", err)
+			}
+		case string:
+			if err := json.Unmarshal([]byte(tv), &m.headers.Urgent); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Urgent", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *OrderIn) UnmarshalNats(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *OrderOut) MarshalOrdersSQS(envelope sqs.EnvelopeWriter) error {
+	return m.MarshalEnvelopeSQS(envelope)
+}
+
+func (m *OrderOut) MarshalEnvelopeSQS(envelope sqs.EnvelopeWriter) error {
+	if err := m.MarshalSQS(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers{
+		"TraceID": m.Headers.TraceID,
+		"Attempt": m.Headers.Attempt,
+		"Urgent":  m.Headers.Urgent,
+	})
+	return nil
+}
+
+func (m *OrderOut) MarshalSQS(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *OrderIn) UnmarshalOrdersSQS(envelope sqs.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeSQS(envelope)
+}
+
+func (m *OrderIn) UnmarshalEnvelopeSQS(envelope sqs.EnvelopeReader) error {
+	if err := m.UnmarshalSQS(envelope); err != nil {
+		return err
+	}
+	headers := envelope.Headers()
+	if v, ok := headers["TraceID"]; ok {
+		switch tv := v.(type) {
+		case string:
+			m.headers.TraceID = tv
+		case []byte:
+			m.headers.TraceID = string(tv)
+		}
+	}
+	if v, ok := headers["Attempt"]; ok {
+		switch tv := v.(type) {
+		case int:
+			m.headers.Attempt = tv
+		case []byte:
+			if err := json.Unmarshal(tv, &m.headers.Attempt); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Attempt", err)
+			}
+		case string:
+			if err := json.Unmarshal([]byte(tv), &m.headers.Attempt); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Attempt", err)
+			}
+		}
+	}
+	if v, ok := headers["Urgent"]; ok {
+		switch tv := v.(type) {
+		case bool:
+			m.headers.Urgent = tv
+		case []byte:
+			if err := json.Unmarshal(tv, &m.headers.Urgent); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Urgent", err)
+			}
+		case string:
+			if err := json.Unmarshal([]byte(tv), &m.headers.Urgent); err != nil {
+				return fmt.Errorf("decode header %s: %w", "Urgent", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *OrderIn) UnmarshalSQS(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
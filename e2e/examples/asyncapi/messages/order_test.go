@@ -0,0 +1,423 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package messages
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/googlepubsub"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/mqtt5"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/nats"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/sqs"
+	"github.com/bdragon300/go-asyncapi/run"
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+	amqp091Go1 "github.com/rabbitmq/amqp091-go"
+	"testing"
+)
+
+func TestExampleOrderRetried(t *testing.T) {
+	msg, err := ExampleOrderRetried()
+	if err != nil {
+		t.Fatalf("example: %v", err)
+	}
+	if err = msg.Validate(); err != nil {
+		t.Fatalf("validate example: %v", err)
+	}
+
+	t.Run("AMQP", func(t *testing.T) {
+		envelope := amqp.NewEnvelopeOut(nil)
+		if err := msg.MarshalEnvelopeAMQP(envelope); err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		var in OrderIn
+		if err := in.UnmarshalEnvelopeAMQP(amqp.NewEnvelopeIn(
+			&amqp091Go1.Delivery{Headers: envelope.Publishing.Headers, ContentType: envelope.ContentType, Body: envelope.Body},
+			bytes.NewReader(envelope.Body),
+		)); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		wantPayload, err := json.Marshal(msg.Payload)
+		if err != nil {
+			t.Fatalf("encode sent payload: %v", err)
+		}
+		gotPayload, err := json.Marshal(in.Payload())
+		if err != nil {
+			t.Fatalf("encode received payload: %v", err)
+		}
+		if !bytes.Equal(wantPayload, gotPayload) {
+			t.Errorf("payload mismatch:\nwant: %s\n got: %s", wantPayload, gotPayload)
+		}
+		wantHeaders, err := json.Marshal(msg.Headers)
+		if err != nil {
+			t.Fatalf("encode sent headers: %v", err)
+		}
+		gotHeaders, err := json.Marshal(in.Headers())
+		if err != nil {
+			t.Fatalf("encode received headers: %v", err)
+		}
+		if !bytes.Equal(wantHeaders, gotHeaders) {
+			t.Errorf("headers mismatch:\nwant: %s\n got: %s", wantHeaders, gotHeaders)
+		}
+	})
+
+	t.Run("Googlepubsub", func(t *testing.T) {
+		envelope := googlepubsub.NewEnvelopeOut(nil)
+		if err := msg.MarshalEnvelopeGooglepubsub(envelope); err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		var in OrderIn
+		if err := in.UnmarshalEnvelopeGooglepubsub(googlepubsub.NewEnvelopeIn(envelope.Message)); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		wantPayload, err := json.Marshal(msg.Payload)
+		if err != nil {
+			t.Fatalf("encode sent payload: %v", err)
+		}
+		gotPayload, err := json.Marshal(in.Payload())
+		if err != nil {
+			t.Fatalf("encode received payload: %v", err)
+		}
+		if !bytes.Equal(wantPayload, gotPayload) {
+			t.Errorf("payload mismatch:\nwant: %s\n got: %s", wantPayload, gotPayload)
+		}
+		wantHeaders, err := json.Marshal(msg.Headers)
+		if err != nil {
+			t.Fatalf("encode sent headers: %v", err)
+		}
+		gotHeaders, err := json.Marshal(in.Headers())
+		if err != nil {
+			t.Fatalf("encode received headers: %v", err)
+		}
+		if !bytes.Equal(wantHeaders, gotHeaders) {
+			t.Errorf("headers mismatch:\nwant: %s\n got: %s", wantHeaders, gotHeaders)
+		}
+	})
+
+	t.Run("Kafka", func(t *testing.T) {
+		envelope := kafka.NewEnvelopeOut(nil)
+		if err := msg.MarshalEnvelopeKafka(envelope); err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		var in OrderIn
+		if err := in.UnmarshalEnvelopeKafka(kafka.NewEnvelopeIn(envelope.Record)); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		wantPayload, err := json.Marshal(msg.Payload)
+		if err != nil {
+			t.Fatalf("encode sent payload: %v", err)
+		}
+		gotPayload, err := json.Marshal(in.Payload())
+		if err != nil {
+			t.Fatalf("encode received payload: %v", err)
+		}
+		if !bytes.Equal(wantPayload, gotPayload) {
+			t.Errorf("payload mismatch:\nwant: %s\n got: %s", wantPayload, gotPayload)
+		}
+		wantHeaders, err := json.Marshal(msg.Headers)
+		if err != nil {
+			t.Fatalf("encode sent headers: %v", err)
+		}
+		gotHeaders, err := json.Marshal(in.Headers())
+		if err != nil {
+			t.Fatalf("encode received headers: %v", err)
+		}
+		if !bytes.Equal(wantHeaders, gotHeaders) {
+			t.Errorf("headers mismatch:\nwant: %s\n got: %s", wantHeaders, gotHeaders)
+		}
+	})
+
+	t.Run("Mqtt5", func(t *testing.T) {
+		envelope := mqtt5.NewEnvelopeOut(nil)
+		if err := msg.MarshalEnvelopeMqtt5(envelope); err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		var in OrderIn
+		if err := in.UnmarshalEnvelopeMqtt5(mqtt5.NewEnvelopeIn(autopaho.PublishReceived{
+			PublishReceived: paho.PublishReceived{Packet: &envelope.Publish},
+		})); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		wantPayload, err := json.Marshal(msg.Payload)
+		if err != nil {
+			t.Fatalf("encode sent payload: %v", err)
+		}
+		gotPayload, err := json.Marshal(in.Payload())
+		if err != nil {
+			t.Fatalf("encode received payload: %v", err)
+		}
+		if !bytes.Equal(wantPayload, gotPayload) {
+			t.Errorf("payload mismatch:\nwant: %s\n got: %s", wantPayload, gotPayload)
+		}
+		wantHeaders, err := json.Marshal(msg.Headers)
+		if err != nil {
+			t.Fatalf("encode sent headers: %v", err)
+		}
+		gotHeaders, err := json.Marshal(in.Headers())
+		if err != nil {
+			t.Fatalf("encode received headers: %v", err)
+		}
+		if !bytes.Equal(wantHeaders, gotHeaders) {
+			t.Errorf("headers mismatch:\nwant: %s\n got: %s", wantHeaders, gotHeaders)
+		}
+	})
+
+	t.Run("Nats", func(t *testing.T) {
+		envelope := nats.NewEnvelopeOut(nil)
+		if err := msg.MarshalEnvelopeNats(envelope); err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		var in OrderIn
+		if err := in.UnmarshalEnvelopeNats(nats.NewEnvelopeIn(envelope.Msg)); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		wantPayload, err := json.Marshal(msg.Payload)
+		if err != nil {
+			t.Fatalf("encode sent payload: %v", err)
+		}
+		gotPayload, err := json.Marshal(in.Payload())
+		if err != nil {
+			t.Fatalf("encode received payload: %v", err)
+		}
+		if !bytes.Equal(wantPayload, gotPayload) {
+			t.Errorf("payload mismatch:\nwant: %s\n got: %s", wantPayload, gotPayload)
+		}
+		wantHeaders, err := json.Marshal(msg.Headers)
+		if err != nil {
+			t.Fatalf("encode sent headers: %v", err)
+		}
+		gotHeaders, err := json.Marshal(in.Headers())
+		if err != nil {
+			t.Fatalf("encode received headers: %v", err)
+		}
+		if !bytes.Equal(wantHeaders, gotHeaders) {
+			t.Errorf("headers mismatch:\nwant: %s\n got: %s", wantHeaders, gotHeaders)
+		}
+	})
+
+	t.Run("SQS", func(t *testing.T) {
+		envelope := &orderExampleEnvelopeSQS{}
+		if err := msg.MarshalEnvelopeSQS(envelope); err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		var in OrderIn
+		if err := in.UnmarshalEnvelopeSQS(envelope); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		wantPayload, err := json.Marshal(msg.Payload)
+		if err != nil {
+			t.Fatalf("encode sent payload: %v", err)
+		}
+		gotPayload, err := json.Marshal(in.Payload())
+		if err != nil {
+			t.Fatalf("encode received payload: %v", err)
+		}
+		if !bytes.Equal(wantPayload, gotPayload) {
+			t.Errorf("payload mismatch:\nwant: %s\n got: %s", wantPayload, gotPayload)
+		}
+		wantHeaders, err := json.Marshal(msg.Headers)
+		if err != nil {
+			t.Fatalf("encode sent headers: %v", err)
+		}
+		gotHeaders, err := json.Marshal(in.Headers())
+		if err != nil {
+			t.Fatalf("encode received headers: %v", err)
+		}
+		if !bytes.Equal(wantHeaders, gotHeaders) {
+			t.Errorf("headers mismatch:\nwant: %s\n got: %s", wantHeaders, gotHeaders)
+		}
+	})
+}
+
+func TestExampleOrder1(t *testing.T) {
+	msg, err := ExampleOrder1()
+	if err != nil {
+		t.Fatalf("example: %v", err)
+	}
+	if err = msg.Validate(); err != nil {
+		t.Fatalf("validate example: %v", err)
+	}
+
+	t.Run("AMQP", func(t *testing.T) {
+		envelope := amqp.NewEnvelopeOut(nil)
+		if err := msg.MarshalEnvelopeAMQP(envelope); err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		var in OrderIn
+		if err := in.UnmarshalEnvelopeAMQP(amqp.NewEnvelopeIn(
+			&amqp091Go1.Delivery{Headers: envelope.Publishing.Headers, ContentType: envelope.ContentType, Body: envelope.Body},
+			bytes.NewReader(envelope.Body),
+		)); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		wantPayload, err := json.Marshal(msg.Payload)
+		if err != nil {
+			t.Fatalf("encode sent payload: %v", err)
+		}
+		gotPayload, err := json.Marshal(in.Payload())
+		if err != nil {
+			t.Fatalf("encode received payload: %v", err)
+		}
+		if !bytes.Equal(wantPayload, gotPayload) {
+			t.Errorf("payload mismatch:\nwant: %s\n got: %s", wantPayload, gotPayload)
+		}
+	})
+
+	t.Run("Googlepubsub", func(t *testing.T) {
+		envelope := googlepubsub.NewEnvelopeOut(nil)
+		if err := msg.MarshalEnvelopeGooglepubsub(envelope); err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		var in OrderIn
+		if err := in.UnmarshalEnvelopeGooglepubsub(googlepubsub.NewEnvelopeIn(envelope.Message)); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		wantPayload, err := json.Marshal(msg.Payload)
+		if err != nil {
+			t.Fatalf("encode sent payload: %v", err)
+		}
+		gotPayload, err := json.Marshal(in.Payload())
+		if err != nil {
+			t.Fatalf("encode received payload: %v", err)
+		}
+		if !bytes.Equal(wantPayload, gotPayload) {
+			t.Errorf("payload mismatch:\nwant: %s\n got: %s", wantPayload, gotPayload)
+		}
+	})
+
+	t.Run("Kafka", func(t *testing.T) {
+		envelope := kafka.NewEnvelopeOut(nil)
+		if err := msg.MarshalEnvelopeKafka(envelope); err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		var in OrderIn
+		if err := in.UnmarshalEnvelopeKafka(kafka.NewEnvelopeIn(envelope.Record)); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		wantPayload, err := json.Marshal(msg.Payload)
+		if err != nil {
+			t.Fatalf("encode sent payload: %v", err)
+		}
+		gotPayload, err := json.Marshal(in.Payload())
+		if err != nil {
+			t.Fatalf("encode received payload: %v", err)
+		}
+		if !bytes.Equal(wantPayload, gotPayload) {
+			t.Errorf("payload mismatch:\nwant: %s\n got: %s", wantPayload, gotPayload)
+		}
+	})
+
+	t.Run("Mqtt5", func(t *testing.T) {
+		envelope := mqtt5.NewEnvelopeOut(nil)
+		if err := msg.MarshalEnvelopeMqtt5(envelope); err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		var in OrderIn
+		if err := in.UnmarshalEnvelopeMqtt5(mqtt5.NewEnvelopeIn(autopaho.PublishReceived{
+			PublishReceived: paho.PublishReceived{Packet: &envelope.Publish},
+		})); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		wantPayload, err := json.Marshal(msg.Payload)
+		if err != nil {
+			t.Fatalf("encode sent payload: %v", err)
+		}
+		gotPayload, err := json.Marshal(in.Payload())
+		if err != nil {
+			t.Fatalf("encode received payload: %v", err)
+		}
+		if !bytes.Equal(wantPayload, gotPayload) {
+			t.Errorf("payload mismatch:\nwant: %s\n got: %s", wantPayload, gotPayload)
+		}
+	})
+
+	t.Run("Nats", func(t *testing.T) {
+		envelope := nats.NewEnvelopeOut(nil)
+		if err := msg.MarshalEnvelopeNats(envelope); err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		var in OrderIn
+		if err := in.UnmarshalEnvelopeNats(nats.NewEnvelopeIn(envelope.Msg)); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		wantPayload, err := json.Marshal(msg.Payload)
+		if err != nil {
+			t.Fatalf("encode sent payload: %v", err)
+		}
+		gotPayload, err := json.Marshal(in.Payload())
+		if err != nil {
+			t.Fatalf("encode received payload: %v", err)
+		}
+		if !bytes.Equal(wantPayload, gotPayload) {
+			t.Errorf("payload mismatch:\nwant: %s\n got: %s", wantPayload, gotPayload)
+		}
+	})
+
+	t.Run("SQS", func(t *testing.T) {
+		envelope := &orderExampleEnvelopeSQS{}
+		if err := msg.MarshalEnvelopeSQS(envelope); err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		var in OrderIn
+		if err := in.UnmarshalEnvelopeSQS(envelope); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		wantPayload, err := json.Marshal(msg.Payload)
+		if err != nil {
+			t.Fatalf("encode sent payload: %v", err)
+		}
+		gotPayload, err := json.Marshal(in.Payload())
+		if err != nil {
+			t.Fatalf("encode received payload: %v", err)
+		}
+		if !bytes.Equal(wantPayload, gotPayload) {
+			t.Errorf("payload mismatch:\nwant: %s\n got: %s", wantPayload, gotPayload)
+		}
+	})
+}
+
+// orderExampleEnvelopeSQS keeps the marshaled message in memory, it is used if the protocol implementation
+// is not generated or its envelopes can't be converted to each other. Like most implementations, it
+// keeps the header values and content type as bytes in headers. The embedded interfaces are nil, they
+// only complete the method set with protocol-specific methods, which are not called by message code.
+type orderExampleEnvelopeSQS struct {
+	sqs.EnvelopeWriter
+	sqs.EnvelopeReader
+	payload bytes.Buffer
+	headers run.Headers
+}
+
+func (e *orderExampleEnvelopeSQS) Write(p []byte) (int, error) {
+	return e.payload.Write(p)
+}
+
+func (e *orderExampleEnvelopeSQS) Read(p []byte) (int, error) {
+	return e.payload.Read(p)
+}
+
+func (e *orderExampleEnvelopeSQS) ResetPayload() {
+	e.payload.Reset()
+}
+
+func (e *orderExampleEnvelopeSQS) SetHeaders(headers run.Headers) {
+	for k, v := range headers.ToByteValues() {
+		e.setHeader(k, v)
+	}
+}
+
+func (e *orderExampleEnvelopeSQS) SetContentType(contentType string) {
+	e.setHeader("Content-Type", []byte(contentType))
+}
+
+func (e *orderExampleEnvelopeSQS) setHeader(key string, value []byte) {
+	if e.headers == nil {
+		e.headers = make(run.Headers)
+	}
+	e.headers[key] = value
+}
+
+func (e *orderExampleEnvelopeSQS) Headers() run.Headers {
+	return e.headers
+}
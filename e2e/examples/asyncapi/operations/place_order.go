@@ -0,0 +1,665 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/googlepubsub"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/mqtt5"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/nats"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/sqs"
+	"github.com/bdragon300/go-asyncapi/run"
+	"time"
+)
+
+type PlaceOrderServerAMQP interface {
+	OpenOrdersAMQP(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersAMQP, error)
+	OpenPlaceOrderAMQP(context.Context, ...run.MiddlewareOption) (*PlaceOrderAMQP, error)
+	Producer() amqp.Producer
+	Consumer() amqp.Consumer
+}
+
+func OpenPlaceOrderAMQP(
+	ctx context.Context,
+	server PlaceOrderServerAMQP,
+
+	opts ...run.MiddlewareOption,
+) (*PlaceOrderAMQP, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "placeOrder",
+			Protocol:  "amqp",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenOrdersAMQP(
+		run.WithOperationName(ctx, "placeOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlaceOrderAMQP{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// placeOrderAMQPEnvelopeWriter counts the payload bytes written to the envelope.
+type placeOrderAMQPEnvelopeWriter struct {
+	amqp.EnvelopeWriter
+	size int
+}
+
+func (e *placeOrderAMQPEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type PlaceOrderChannelAMQP interface {
+	Close() error
+
+	SealOrder(amqp.EnvelopeWriter, channels.OrdersEnvelopeMarshalerAMQP) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerAMQP) error
+
+	UnsealOrder(amqp.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerAMQP) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+	PublishEnvelope(context.Context, amqp.EnvelopeWriter, any) error
+}
+
+type PlaceOrderAMQP struct {
+	Channel      PlaceOrderChannelAMQP
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c PlaceOrderAMQP) Close() error {
+	return c.Channel.Close()
+}
+
+func (o PlaceOrderAMQP) SealOrder(
+	envelope amqp.EnvelopeWriter,
+	message channels.OrdersEnvelopeMarshalerAMQP,
+) error {
+	return o.Channel.SealOrder(envelope, message)
+}
+
+func (o PlaceOrderAMQP) PublishOrder(
+	ctx context.Context,
+
+	message channels.OrdersEnvelopeMarshalerAMQP,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishOrder(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "order"
+	envelope := amqp.NewEnvelopeOut(nil)
+	counter := &placeOrderAMQPEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealOrder(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
+
+type PlaceOrderServerGooglepubsub interface {
+	OpenOrdersGooglepubsub(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersGooglepubsub, error)
+	OpenPlaceOrderGooglepubsub(context.Context, ...run.MiddlewareOption) (*PlaceOrderGooglepubsub, error)
+	Producer() googlepubsub.Producer
+	Consumer() googlepubsub.Consumer
+}
+
+func OpenPlaceOrderGooglepubsub(
+	ctx context.Context,
+	server PlaceOrderServerGooglepubsub,
+
+	opts ...run.MiddlewareOption,
+) (*PlaceOrderGooglepubsub, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "placeOrder",
+			Protocol:  "googlepubsub",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenOrdersGooglepubsub(
+		run.WithOperationName(ctx, "placeOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlaceOrderGooglepubsub{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// placeOrderGooglepubsubEnvelopeWriter counts the payload bytes written to the envelope.
+type placeOrderGooglepubsubEnvelopeWriter struct {
+	googlepubsub.EnvelopeWriter
+	size int
+}
+
+func (e *placeOrderGooglepubsubEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type PlaceOrderChannelGooglepubsub interface {
+	Close() error
+
+	SealOrder(googlepubsub.EnvelopeWriter, channels.OrdersEnvelopeMarshalerGooglepubsub) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerGooglepubsub) error
+
+	UnsealOrder(googlepubsub.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerGooglepubsub) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+	PublishEnvelope(context.Context, googlepubsub.EnvelopeWriter, any) error
+}
+
+type PlaceOrderGooglepubsub struct {
+	Channel      PlaceOrderChannelGooglepubsub
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c PlaceOrderGooglepubsub) Close() error {
+	return c.Channel.Close()
+}
+
+func (o PlaceOrderGooglepubsub) SealOrder(
+	envelope googlepubsub.EnvelopeWriter,
+	message channels.OrdersEnvelopeMarshalerGooglepubsub,
+) error {
+	return o.Channel.SealOrder(envelope, message)
+}
+
+func (o PlaceOrderGooglepubsub) PublishOrder(
+	ctx context.Context,
+
+	message channels.OrdersEnvelopeMarshalerGooglepubsub,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishOrder(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "order"
+	envelope := googlepubsub.NewEnvelopeOut(nil)
+	counter := &placeOrderGooglepubsubEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealOrder(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
+
+type PlaceOrderServerKafka interface {
+	OpenOrdersKafka(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersKafka, error)
+	OpenPlaceOrderKafka(context.Context, ...run.MiddlewareOption) (*PlaceOrderKafka, error)
+	Producer() kafka.Producer
+	Consumer() kafka.Consumer
+}
+
+func OpenPlaceOrderKafka(
+	ctx context.Context,
+	server PlaceOrderServerKafka,
+
+	opts ...run.MiddlewareOption,
+) (*PlaceOrderKafka, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "placeOrder",
+			Protocol:  "kafka",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenOrdersKafka(
+		run.WithOperationName(ctx, "placeOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlaceOrderKafka{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// placeOrderKafkaEnvelopeWriter counts the payload bytes written to the envelope.
+type placeOrderKafkaEnvelopeWriter struct {
+	kafka.EnvelopeWriter
+	size int
+}
+
+func (e *placeOrderKafkaEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type PlaceOrderChannelKafka interface {
+	Close() error
+
+	SealOrder(kafka.EnvelopeWriter, channels.OrdersEnvelopeMarshalerKafka) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerKafka) error
+
+	UnsealOrder(kafka.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerKafka) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+	PublishEnvelope(context.Context, kafka.EnvelopeWriter, any) error
+}
+
+type PlaceOrderKafka struct {
+	Channel      PlaceOrderChannelKafka
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c PlaceOrderKafka) Close() error {
+	return c.Channel.Close()
+}
+
+func (o PlaceOrderKafka) SealOrder(
+	envelope kafka.EnvelopeWriter,
+	message channels.OrdersEnvelopeMarshalerKafka,
+) error {
+	return o.Channel.SealOrder(envelope, message)
+}
+
+func (o PlaceOrderKafka) PublishOrder(
+	ctx context.Context,
+
+	message channels.OrdersEnvelopeMarshalerKafka,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishOrder(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "order"
+	envelope := kafka.NewEnvelopeOut(nil)
+	counter := &placeOrderKafkaEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealOrder(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
+
+type PlaceOrderServerMqtt5 interface {
+	OpenOrdersMqtt5(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersMqtt5, error)
+	OpenPlaceOrderMqtt5(context.Context, ...run.MiddlewareOption) (*PlaceOrderMqtt5, error)
+	Producer() mqtt5.Producer
+	Consumer() mqtt5.Consumer
+}
+
+func OpenPlaceOrderMqtt5(
+	ctx context.Context,
+	server PlaceOrderServerMqtt5,
+
+	opts ...run.MiddlewareOption,
+) (*PlaceOrderMqtt5, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "placeOrder",
+			Protocol:  "mqtt5",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenOrdersMqtt5(
+		run.WithOperationName(ctx, "placeOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlaceOrderMqtt5{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// placeOrderMqtt5EnvelopeWriter counts the payload bytes written to the envelope.
+type placeOrderMqtt5EnvelopeWriter struct {
+	mqtt5.EnvelopeWriter
+	size int
+}
+
+func (e *placeOrderMqtt5EnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type PlaceOrderChannelMqtt5 interface {
+	Close() error
+
+	SealOrder(mqtt5.EnvelopeWriter, channels.OrdersEnvelopeMarshalerMqtt5) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerMqtt5) error
+
+	UnsealOrder(mqtt5.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerMqtt5) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+	PublishEnvelope(context.Context, mqtt5.EnvelopeWriter, any) error
+}
+
+type PlaceOrderMqtt5 struct {
+	Channel      PlaceOrderChannelMqtt5
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c PlaceOrderMqtt5) Close() error {
+	return c.Channel.Close()
+}
+
+func (o PlaceOrderMqtt5) SealOrder(
+	envelope mqtt5.EnvelopeWriter,
+	message channels.OrdersEnvelopeMarshalerMqtt5,
+) error {
+	return o.Channel.SealOrder(envelope, message)
+}
+
+func (o PlaceOrderMqtt5) PublishOrder(
+	ctx context.Context,
+
+	message channels.OrdersEnvelopeMarshalerMqtt5,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishOrder(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "order"
+	envelope := mqtt5.NewEnvelopeOut(nil)
+	counter := &placeOrderMqtt5EnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealOrder(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
+
+type PlaceOrderServerNats interface {
+	OpenOrdersNats(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersNats, error)
+	OpenPlaceOrderNats(context.Context, ...run.MiddlewareOption) (*PlaceOrderNats, error)
+	Producer() nats.Producer
+	Consumer() nats.Consumer
+}
+
+func OpenPlaceOrderNats(
+	ctx context.Context,
+	server PlaceOrderServerNats,
+
+	opts ...run.MiddlewareOption,
+) (*PlaceOrderNats, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "placeOrder",
+			Protocol:  "nats",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenOrdersNats(
+		run.WithOperationName(ctx, "placeOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlaceOrderNats{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// placeOrderNatsEnvelopeWriter counts the payload bytes written to the envelope.
+type placeOrderNatsEnvelopeWriter struct {
+	nats.EnvelopeWriter
+	size int
+}
+
+func (e *placeOrderNatsEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type PlaceOrderChannelNats interface {
+	Close() error
+
+	SealOrder(nats.EnvelopeWriter, channels.OrdersEnvelopeMarshalerNats) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerNats) error
+
+	UnsealOrder(nats.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerNats) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+	PublishEnvelope(context.Context, nats.EnvelopeWriter, any) error
+}
+
+type PlaceOrderNats struct {
+	Channel      PlaceOrderChannelNats
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c PlaceOrderNats) Close() error {
+	return c.Channel.Close()
+}
+
+func (o PlaceOrderNats) SealOrder(
+	envelope nats.EnvelopeWriter,
+	message channels.OrdersEnvelopeMarshalerNats,
+) error {
+	return o.Channel.SealOrder(envelope, message)
+}
+
+func (o PlaceOrderNats) PublishOrder(
+	ctx context.Context,
+
+	message channels.OrdersEnvelopeMarshalerNats,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishOrder(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "order"
+	envelope := nats.NewEnvelopeOut(nil)
+	counter := &placeOrderNatsEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealOrder(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
+
+type PlaceOrderServerSQS interface {
+	OpenOrdersSQS(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersSQS, error)
+	OpenPlaceOrderSQS(context.Context, ...run.MiddlewareOption) (*PlaceOrderSQS, error)
+	Producer() sqs.Producer
+	Consumer() sqs.Consumer
+}
+
+func OpenPlaceOrderSQS(
+	ctx context.Context,
+	server PlaceOrderServerSQS,
+
+	opts ...run.MiddlewareOption,
+) (*PlaceOrderSQS, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "placeOrder",
+			Protocol:  "sqs",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenOrdersSQS(
+		run.WithOperationName(ctx, "placeOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlaceOrderSQS{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// placeOrderSQSEnvelopeWriter counts the payload bytes written to the envelope.
+type placeOrderSQSEnvelopeWriter struct {
+	sqs.EnvelopeWriter
+	size int
+}
+
+func (e *placeOrderSQSEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type PlaceOrderChannelSQS interface {
+	Close() error
+
+	SealOrder(sqs.EnvelopeWriter, channels.OrdersEnvelopeMarshalerSQS) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerSQS) error
+
+	UnsealOrder(sqs.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerSQS) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+	PublishEnvelope(context.Context, sqs.EnvelopeWriter, any) error
+}
+
+type PlaceOrderSQS struct {
+	Channel      PlaceOrderChannelSQS
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c PlaceOrderSQS) Close() error {
+	return c.Channel.Close()
+}
+
+func (o PlaceOrderSQS) SealOrder(
+	envelope sqs.EnvelopeWriter,
+	message channels.OrdersEnvelopeMarshalerSQS,
+) error {
+	return o.Channel.SealOrder(envelope, message)
+}
+
+func (o PlaceOrderSQS) PublishOrder(
+	ctx context.Context,
+
+	message channels.OrdersEnvelopeMarshalerSQS,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishOrder(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "order"
+	envelope := sqs.NewEnvelopeOut(nil)
+	counter := &placeOrderSQSEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealOrder(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
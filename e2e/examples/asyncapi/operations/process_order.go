@@ -0,0 +1,683 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/googlepubsub"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/mqtt5"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/nats"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/sqs"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+type ProcessOrderServerAMQP interface {
+	OpenOrdersAMQP(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersAMQP, error)
+	OpenProcessOrderAMQP(context.Context, ...run.MiddlewareOption) (*ProcessOrderAMQP, error)
+	Producer() amqp.Producer
+	Consumer() amqp.Consumer
+}
+
+func OpenProcessOrderAMQP(
+	ctx context.Context,
+	server ProcessOrderServerAMQP,
+
+	opts ...run.MiddlewareOption,
+) (*ProcessOrderAMQP, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "processOrder",
+			Protocol:  "amqp",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, processOrderAMQPMetrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenOrdersAMQP(
+		run.WithOperationName(ctx, "processOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProcessOrderAMQP{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// processOrderAMQPEnvelopeReader counts the payload bytes read from the envelope.
+type processOrderAMQPEnvelopeReader struct {
+	amqp.EnvelopeReader
+	size int
+}
+
+func (e *processOrderAMQPEnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// processOrderAMQPMetrics returns the middleware that reports the received messages metrics.
+func processOrderAMQPMetrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[amqp.EnvelopeReader]) run.SubscribeHandler[amqp.EnvelopeReader] {
+		return func(ctx context.Context, envelope amqp.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.OrderIn:
+				labels.Message = "order"
+			}
+			counter := &processOrderAMQPEnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type ProcessOrderChannelAMQP interface {
+	Close() error
+
+	SealOrder(amqp.EnvelopeWriter, channels.OrdersEnvelopeMarshalerAMQP) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerAMQP) error
+
+	UnsealOrder(amqp.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerAMQP) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+}
+
+type ProcessOrderAMQP struct {
+	Channel      ProcessOrderChannelAMQP
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c ProcessOrderAMQP) Close() error {
+	return c.Channel.Close()
+}
+
+func (o ProcessOrderAMQP) UnsealOrder(
+	envelope amqp.EnvelopeReader,
+	message channels.OrdersEnvelopeUnmarshalerAMQP,
+) error {
+	return o.Channel.UnsealOrder(envelope, message)
+}
+
+func (o ProcessOrderAMQP) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeOrder(ctx, cb)
+}
+
+type ProcessOrderServerGooglepubsub interface {
+	OpenOrdersGooglepubsub(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersGooglepubsub, error)
+	OpenProcessOrderGooglepubsub(context.Context, ...run.MiddlewareOption) (*ProcessOrderGooglepubsub, error)
+	Producer() googlepubsub.Producer
+	Consumer() googlepubsub.Consumer
+}
+
+func OpenProcessOrderGooglepubsub(
+	ctx context.Context,
+	server ProcessOrderServerGooglepubsub,
+
+	opts ...run.MiddlewareOption,
+) (*ProcessOrderGooglepubsub, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "processOrder",
+			Protocol:  "googlepubsub",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, processOrderGooglepubsubMetrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenOrdersGooglepubsub(
+		run.WithOperationName(ctx, "processOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProcessOrderGooglepubsub{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// processOrderGooglepubsubEnvelopeReader counts the payload bytes read from the envelope.
+type processOrderGooglepubsubEnvelopeReader struct {
+	googlepubsub.EnvelopeReader
+	size int
+}
+
+func (e *processOrderGooglepubsubEnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// processOrderGooglepubsubMetrics returns the middleware that reports the received messages metrics.
+func processOrderGooglepubsubMetrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[googlepubsub.EnvelopeReader]) run.SubscribeHandler[googlepubsub.EnvelopeReader] {
+		return func(ctx context.Context, envelope googlepubsub.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.OrderIn:
+				labels.Message = "order"
+			}
+			counter := &processOrderGooglepubsubEnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type ProcessOrderChannelGooglepubsub interface {
+	Close() error
+
+	SealOrder(googlepubsub.EnvelopeWriter, channels.OrdersEnvelopeMarshalerGooglepubsub) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerGooglepubsub) error
+
+	UnsealOrder(googlepubsub.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerGooglepubsub) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+}
+
+type ProcessOrderGooglepubsub struct {
+	Channel      ProcessOrderChannelGooglepubsub
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c ProcessOrderGooglepubsub) Close() error {
+	return c.Channel.Close()
+}
+
+func (o ProcessOrderGooglepubsub) UnsealOrder(
+	envelope googlepubsub.EnvelopeReader,
+	message channels.OrdersEnvelopeUnmarshalerGooglepubsub,
+) error {
+	return o.Channel.UnsealOrder(envelope, message)
+}
+
+func (o ProcessOrderGooglepubsub) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeOrder(ctx, cb)
+}
+
+type ProcessOrderServerKafka interface {
+	OpenOrdersKafka(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersKafka, error)
+	OpenProcessOrderKafka(context.Context, ...run.MiddlewareOption) (*ProcessOrderKafka, error)
+	Producer() kafka.Producer
+	Consumer() kafka.Consumer
+}
+
+func OpenProcessOrderKafka(
+	ctx context.Context,
+	server ProcessOrderServerKafka,
+
+	opts ...run.MiddlewareOption,
+) (*ProcessOrderKafka, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "processOrder",
+			Protocol:  "kafka",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, processOrderKafkaMetrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenOrdersKafka(
+		run.WithOperationName(ctx, "processOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProcessOrderKafka{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// processOrderKafkaEnvelopeReader counts the payload bytes read from the envelope.
+type processOrderKafkaEnvelopeReader struct {
+	kafka.EnvelopeReader
+	size int
+}
+
+func (e *processOrderKafkaEnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// processOrderKafkaMetrics returns the middleware that reports the received messages metrics.
+func processOrderKafkaMetrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[kafka.EnvelopeReader]) run.SubscribeHandler[kafka.EnvelopeReader] {
+		return func(ctx context.Context, envelope kafka.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.OrderIn:
+				labels.Message = "order"
+			}
+			counter := &processOrderKafkaEnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type ProcessOrderChannelKafka interface {
+	Close() error
+
+	SealOrder(kafka.EnvelopeWriter, channels.OrdersEnvelopeMarshalerKafka) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerKafka) error
+
+	UnsealOrder(kafka.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerKafka) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+}
+
+type ProcessOrderKafka struct {
+	Channel      ProcessOrderChannelKafka
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c ProcessOrderKafka) Close() error {
+	return c.Channel.Close()
+}
+
+func (o ProcessOrderKafka) UnsealOrder(
+	envelope kafka.EnvelopeReader,
+	message channels.OrdersEnvelopeUnmarshalerKafka,
+) error {
+	return o.Channel.UnsealOrder(envelope, message)
+}
+
+func (o ProcessOrderKafka) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeOrder(ctx, cb)
+}
+
+type ProcessOrderServerMqtt5 interface {
+	OpenOrdersMqtt5(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersMqtt5, error)
+	OpenProcessOrderMqtt5(context.Context, ...run.MiddlewareOption) (*ProcessOrderMqtt5, error)
+	Producer() mqtt5.Producer
+	Consumer() mqtt5.Consumer
+}
+
+func OpenProcessOrderMqtt5(
+	ctx context.Context,
+	server ProcessOrderServerMqtt5,
+
+	opts ...run.MiddlewareOption,
+) (*ProcessOrderMqtt5, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "processOrder",
+			Protocol:  "mqtt5",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, processOrderMqtt5Metrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenOrdersMqtt5(
+		run.WithOperationName(ctx, "processOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProcessOrderMqtt5{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// processOrderMqtt5EnvelopeReader counts the payload bytes read from the envelope.
+type processOrderMqtt5EnvelopeReader struct {
+	mqtt5.EnvelopeReader
+	size int
+}
+
+func (e *processOrderMqtt5EnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// processOrderMqtt5Metrics returns the middleware that reports the received messages metrics.
+func processOrderMqtt5Metrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[mqtt5.EnvelopeReader]) run.SubscribeHandler[mqtt5.EnvelopeReader] {
+		return func(ctx context.Context, envelope mqtt5.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.OrderIn:
+				labels.Message = "order"
+			}
+			counter := &processOrderMqtt5EnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type ProcessOrderChannelMqtt5 interface {
+	Close() error
+
+	SealOrder(mqtt5.EnvelopeWriter, channels.OrdersEnvelopeMarshalerMqtt5) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerMqtt5) error
+
+	UnsealOrder(mqtt5.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerMqtt5) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+}
+
+type ProcessOrderMqtt5 struct {
+	Channel      ProcessOrderChannelMqtt5
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c ProcessOrderMqtt5) Close() error {
+	return c.Channel.Close()
+}
+
+func (o ProcessOrderMqtt5) UnsealOrder(
+	envelope mqtt5.EnvelopeReader,
+	message channels.OrdersEnvelopeUnmarshalerMqtt5,
+) error {
+	return o.Channel.UnsealOrder(envelope, message)
+}
+
+func (o ProcessOrderMqtt5) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeOrder(ctx, cb)
+}
+
+type ProcessOrderServerNats interface {
+	OpenOrdersNats(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersNats, error)
+	OpenProcessOrderNats(context.Context, ...run.MiddlewareOption) (*ProcessOrderNats, error)
+	Producer() nats.Producer
+	Consumer() nats.Consumer
+}
+
+func OpenProcessOrderNats(
+	ctx context.Context,
+	server ProcessOrderServerNats,
+
+	opts ...run.MiddlewareOption,
+) (*ProcessOrderNats, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "processOrder",
+			Protocol:  "nats",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, processOrderNatsMetrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenOrdersNats(
+		run.WithOperationName(ctx, "processOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProcessOrderNats{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// processOrderNatsEnvelopeReader counts the payload bytes read from the envelope.
+type processOrderNatsEnvelopeReader struct {
+	nats.EnvelopeReader
+	size int
+}
+
+func (e *processOrderNatsEnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// processOrderNatsMetrics returns the middleware that reports the received messages metrics.
+func processOrderNatsMetrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[nats.EnvelopeReader]) run.SubscribeHandler[nats.EnvelopeReader] {
+		return func(ctx context.Context, envelope nats.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.OrderIn:
+				labels.Message = "order"
+			}
+			counter := &processOrderNatsEnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type ProcessOrderChannelNats interface {
+	Close() error
+
+	SealOrder(nats.EnvelopeWriter, channels.OrdersEnvelopeMarshalerNats) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerNats) error
+
+	UnsealOrder(nats.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerNats) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+}
+
+type ProcessOrderNats struct {
+	Channel      ProcessOrderChannelNats
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c ProcessOrderNats) Close() error {
+	return c.Channel.Close()
+}
+
+func (o ProcessOrderNats) UnsealOrder(
+	envelope nats.EnvelopeReader,
+	message channels.OrdersEnvelopeUnmarshalerNats,
+) error {
+	return o.Channel.UnsealOrder(envelope, message)
+}
+
+func (o ProcessOrderNats) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeOrder(ctx, cb)
+}
+
+type ProcessOrderServerSQS interface {
+	OpenOrdersSQS(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersSQS, error)
+	OpenProcessOrderSQS(context.Context, ...run.MiddlewareOption) (*ProcessOrderSQS, error)
+	Producer() sqs.Producer
+	Consumer() sqs.Consumer
+}
+
+func OpenProcessOrderSQS(
+	ctx context.Context,
+	server ProcessOrderServerSQS,
+
+	opts ...run.MiddlewareOption,
+) (*ProcessOrderSQS, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "processOrder",
+			Protocol:  "sqs",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, processOrderSQSMetrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenOrdersSQS(
+		run.WithOperationName(ctx, "processOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProcessOrderSQS{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// processOrderSQSEnvelopeReader counts the payload bytes read from the envelope.
+type processOrderSQSEnvelopeReader struct {
+	sqs.EnvelopeReader
+	size int
+}
+
+func (e *processOrderSQSEnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// processOrderSQSMetrics returns the middleware that reports the received messages metrics.
+func processOrderSQSMetrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[sqs.EnvelopeReader]) run.SubscribeHandler[sqs.EnvelopeReader] {
+		return func(ctx context.Context, envelope sqs.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.OrderIn:
+				labels.Message = "order"
+			}
+			counter := &processOrderSQSEnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type ProcessOrderChannelSQS interface {
+	Close() error
+
+	SealOrder(sqs.EnvelopeWriter, channels.OrdersEnvelopeMarshalerSQS) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerSQS) error
+
+	UnsealOrder(sqs.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerSQS) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+}
+
+type ProcessOrderSQS struct {
+	Channel      ProcessOrderChannelSQS
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c ProcessOrderSQS) Close() error {
+	return c.Channel.Close()
+}
+
+func (o ProcessOrderSQS) UnsealOrder(
+	envelope sqs.EnvelopeReader,
+	message channels.OrdersEnvelopeUnmarshalerSQS,
+) error {
+	return o.Channel.UnsealOrder(envelope, message)
+}
+
+func (o ProcessOrderSQS) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeOrder(ctx, cb)
+}
@@ -0,0 +1,68 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package amqp
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/rabbitmq/amqp091-go"
+)
+
+type SubscribeChannel struct {
+	*amqp091.Channel
+	// ConsumerTag uniquely identifies the consumer process. If empty, a unique tag is generated.
+	ConsumerTag string
+	// Additional arguments for the consumer. See ConsumeWithContext docs for details.
+	ConsumeArgs amqp091.Table
+
+	queueName         string
+	channelBindings   *ChannelBindings
+	operationBindings *OperationBindings
+}
+
+func (s SubscribeChannel) Receive(ctx context.Context, cb func(envelope EnvelopeReader)) (err error) {
+	// TODO: consumer tag in x- schema argument
+	// Separate context is used to stop consumer process for a particular consumer tag on function exit.
+	consumerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var ack, exclusive bool
+	if s.operationBindings != nil {
+		ack = s.operationBindings.Ack
+	}
+	if s.channelBindings != nil {
+		exclusive = run.FromPtrOrZero(s.channelBindings.QueueConfiguration.Exclusive)
+	}
+	deliveries, err := s.ConsumeWithContext(
+		consumerCtx,
+		s.queueName,
+		s.ConsumerTag,
+		!ack, // autoAck
+		exclusive,
+		false,
+		false,
+		s.ConsumeArgs,
+	)
+	if err != nil {
+		return err
+	}
+	run.NotifySubscribeReady(ctx)
+
+	for delivery := range deliveries {
+		evlp := NewEnvelopeIn(&delivery, bytes.NewReader(delivery.Body))
+		evlp.ManualAck = ack
+		cb(evlp)
+		if ack && !evlp.Settled() {
+			if e := s.Ack(delivery.DeliveryTag, false); e != nil {
+				return fmt.Errorf("ack: %w", e)
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,151 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package googlepubsub
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"cloud.google.com/go/pubsub/v2"
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultHost                   = "pubsub.googleapis.com"
+	DefaultSubscriptionNameSuffix = "-go-asyncapi"
+)
+
+// NewClient connects to the Pub/Sub service. The project ID is taken from the server URL path, that should be
+// "/projects/{project}" or "/{project}". If the path is empty, the project is detected from the credentials.
+//
+// If the server host is not the Google API host (*.googleapis.com), it's considered as the Pub/Sub emulator, so
+// the client connects to it without TLS and authentication.
+func NewClient(ctx context.Context, serverURL string, security run.AnySecurityScheme, extraOpts ...option.ClientOption) (*Client, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse server url: %w", err)
+	}
+	projectID := strings.TrimPrefix(strings.Trim(u.Path, "/"), "projects/")
+	if projectID == "" {
+		projectID = pubsub.DetectProjectID
+	}
+
+	var opts []option.ClientOption
+	switch {
+	case u.Host == "" || u.Host == defaultHost:
+	case strings.HasSuffix(u.Hostname(), ".googleapis.com"):
+		opts = append(opts, option.WithEndpoint(u.Host))
+	default:
+		opts = append(opts,
+			option.WithEndpoint(u.Host),
+			option.WithoutAuthentication(),
+			option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+		)
+	}
+	if security != nil {
+		authOpt, err := getAuth(security)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, authOpt)
+	}
+
+	cl, err := pubsub.NewClient(ctx, projectID, append(opts, extraOpts...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{Client: cl}, nil
+}
+
+type Client struct {
+	*pubsub.Client
+	// SubscriptionName returns the subscription ID or full name for the topic. If nil, DefaultSubscriptionName is used.
+	SubscriptionName func(topic string, opBindings *OperationBindings) string
+	// DisableSubscriptionCreation disables creating the subscription on subscribing, if it does not exist.
+	DisableSubscriptionCreation bool
+}
+
+func (c *Client) Publisher(_ context.Context, address string, _ *ChannelBindings, _ *OperationBindings, security run.AnySecurityScheme) (Publisher, error) {
+	if security != nil {
+		return nil, fmt.Errorf("operation security schemes are not supported")
+	}
+
+	pub := c.Client.Publisher(address)
+	pub.EnableMessageOrdering = true
+	return &PublishChannel{Publisher: pub}, nil
+}
+
+func (c *Client) Subscriber(ctx context.Context, address string, _ *ChannelBindings, opb *OperationBindings, security run.AnySecurityScheme) (Subscriber, error) {
+	if security != nil {
+		return nil, fmt.Errorf("operation security schemes are not supported")
+	}
+
+	name := DefaultSubscriptionName(address, opb)
+	if c.SubscriptionName != nil {
+		name = c.SubscriptionName(address, opb)
+	}
+	if !c.DisableSubscriptionCreation {
+		if err := c.ensureSubscription(ctx, c.fullName("subscriptions", name), c.fullName("topics", address)); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx2, cancel := context.WithCancel(context.Background())
+	return &SubscribeChannel{
+		Subscriber: c.Client.Subscriber(name),
+		ctx:        ctx2,
+		cancel:     cancel,
+	}, nil
+}
+
+// ensureSubscription creates the subscription with message ordering enabled if it does not exist.
+func (c *Client) ensureSubscription(ctx context.Context, name, topic string) error {
+	_, err := c.SubscriptionAdminClient.GetSubscription(ctx, &pubsubpb.GetSubscriptionRequest{Subscription: name})
+	if status.Code(err) != codes.NotFound {
+		return err
+	}
+	_, err = c.SubscriptionAdminClient.CreateSubscription(ctx, &pubsubpb.Subscription{
+		Name:                  name,
+		Topic:                 topic,
+		EnableMessageOrdering: true,
+	})
+	if err != nil && status.Code(err) != codes.AlreadyExists {
+		return fmt.Errorf("create subscription %q: %w", name, err)
+	}
+	return nil
+}
+
+// fullName returns the full resource name "projects/{project}/{kind}/{id}". If the id is already a full name,
+// returns it as is.
+func (c *Client) fullName(kind, id string) string {
+	if strings.HasPrefix(id, "projects/") {
+		return id
+	}
+	return path.Join("projects", c.Project(), kind, id)
+}
+
+// DefaultSubscriptionName returns the subscription ID made from the topic ID with DefaultSubscriptionNameSuffix.
+func DefaultSubscriptionName(topic string, _ *OperationBindings) string {
+	return path.Base(topic) + DefaultSubscriptionNameSuffix
+}
+
+func getAuth(security run.AnySecurityScheme) (option.ClientOption, error) {
+	switch v := security.(type) {
+	case run.APIKeySecurity:
+		return option.WithAPIKey(v.APIKey()), nil
+	}
+	return nil, errors.New("unsupported security scheme: " + security.AuthType())
+}
@@ -0,0 +1,62 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package googlepubsub
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"context"
+	"sync"
+
+	"cloud.google.com/go/pubsub/v2"
+)
+
+type SubscribeChannel struct {
+	*pubsub.Subscriber
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// Receive receives the messages and calls cb for each of them. Messages are pulled concurrently, but cb is called
+// for one message at a time. The message is acknowledged after cb returns, unless cb has already acknowledged it
+// by Ack or Nack methods of envelope.
+func (s SubscribeChannel) Receive(ctx context.Context, cb func(envelope EnvelopeReader)) error {
+	receiveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-receiveCtx.Done():
+		case <-s.ctx.Done():
+			cancel()
+		}
+	}()
+
+	// The subscription has been created before, so it keeps the messages until they are pulled
+	run.NotifySubscribeReady(ctx)
+	var mu sync.Mutex
+	err := s.Subscriber.Receive(receiveCtx, func(_ context.Context, msg *pubsub.Message) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		envelope := NewEnvelopeIn(msg)
+		cb(envelope)
+		if !envelope.Settled() {
+			envelope.Ack()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return s.ctx.Err()
+}
+
+func (s SubscribeChannel) Close() error {
+	s.cancel()
+	return nil
+}
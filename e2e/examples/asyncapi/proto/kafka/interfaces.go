@@ -0,0 +1,64 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package kafka
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"context"
+	"io"
+)
+
+// Pub
+type (
+	Producer interface {
+		Publisher(ctx context.Context, address string, chBindings *ChannelBindings, opBindings *OperationBindings, security run.AnySecurityScheme) (Publisher, error)
+	}
+	Publisher interface {
+		Send(ctx context.Context, envelopes ...EnvelopeWriter) error
+		Close() error
+	}
+	EnvelopeWriter interface {
+		io.Writer
+		ResetPayload()
+		SetHeaders(headers run.Headers)
+		SetContentType(contentType string)
+		SetBindings(bindings MessageBindings)
+		// SetSchema sets the payload schema format and definition, that are used by schema registry. Definition
+		// is empty if it is not available, e.g. for JSON Schema.
+		SetSchema(format, definition string)
+
+		SetTopic(topic string) // Topic may be different from channel name
+	}
+)
+
+type EnvelopeMarshaler interface {
+	MarshalEnvelopeKafka(envelope EnvelopeWriter) error
+}
+
+// Sub
+type (
+	Consumer interface {
+		Subscriber(ctx context.Context, address string, chBindings *ChannelBindings, opBindings *OperationBindings, security run.AnySecurityScheme) (Subscriber, error)
+	}
+	Subscriber interface {
+		// Receive calls cb for every received message until ctx is done or an error occurs. It must call
+		// run.NotifySubscribeReady(ctx) once the subscription is established, since the generated Request methods
+		// wait for it before sending the request.
+		Receive(ctx context.Context, cb func(envelope EnvelopeReader)) error
+		Close() error
+	}
+	EnvelopeReader interface {
+		io.Reader
+		Headers() run.Headers
+		// VerifyBindings is called before unmarshalling with the bindings of the message. Returns error if the
+		// envelope does not conform them, e.g. the record schema is not registered for the subject.
+		VerifyBindings(bindings MessageBindings) error
+	}
+)
+
+type EnvelopeUnmarshaler interface {
+	UnmarshalEnvelopeKafka(envelope EnvelopeReader) error
+}
@@ -0,0 +1,89 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package nats
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"context"
+	"fmt"
+
+	natsGo "github.com/nats-io/nats.go"
+)
+
+func NewClient(serverURL string, security run.AnySecurityScheme, extraOpts ...natsGo.Option) (*Client, error) {
+	// Unfortunately, the official nats client doesn't accept the context object.
+	if security != nil {
+		authOpt, err := getAuth(security)
+		if err != nil {
+			return nil, err
+		}
+		extraOpts = append(extraOpts, authOpt)
+	}
+
+	client, err := natsGo.Connect(serverURL, extraOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		Conn: client,
+	}, nil
+}
+
+type Client struct {
+	*natsGo.Conn
+}
+
+func (c *Client) Subscriber(_ context.Context, address string, chb *ChannelBindings, opb *OperationBindings, security run.AnySecurityScheme) (Subscriber, error) {
+	if security != nil {
+		return nil, fmt.Errorf("operation security schemes are not supported")
+	}
+
+	ctx2, cancel := context.WithCancel(context.Background())
+	return &Subscription{
+		Client:            c,
+		Subject:           address,
+		channelBindings:   chb,
+		operationBindings: opb,
+		ctx:               ctx2,
+		cancel:            cancel,
+	}, nil
+}
+
+func (c *Client) Publisher(_ context.Context, _ string, chb *ChannelBindings, opb *OperationBindings, security run.AnySecurityScheme) (Publisher, error) {
+	if security != nil {
+		return nil, fmt.Errorf("operation security schemes are not supported")
+	}
+
+	ctx2, cancel := context.WithCancel(context.Background())
+	return &PublishChannel{
+		Client:            c,
+		channelBindings:   chb,
+		operationBindings: opb,
+		ctx:               ctx2,
+		cancel:            cancel,
+	}, nil
+}
+
+func (c *Client) Close() error {
+	if err := c.Conn.Drain(); err != nil {
+		return fmt.Errorf("drain: %w", err)
+	}
+	c.Conn.Close()
+	return nil
+}
+
+func getAuth(security run.AnySecurityScheme) (natsGo.Option, error) {
+	switch v := security.(type) {
+	case run.UserPasswordSecurity:
+		u, p := v.UserPassword()
+		return natsGo.UserInfo(u, p), nil
+	case run.APIKeySecurity:
+		k := v.APIKey()
+		return natsGo.Token(k), nil
+	}
+	return nil, fmt.Errorf("unsupported security scheme: %v", security.AuthType())
+}
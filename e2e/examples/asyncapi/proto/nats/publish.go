@@ -0,0 +1,37 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package nats
+
+import (
+	"context"
+)
+
+type PublishChannel struct {
+	Client *Client
+
+	channelBindings   *ChannelBindings
+	operationBindings *OperationBindings
+	ctx               context.Context
+	cancel            context.CancelFunc
+}
+
+func (p PublishChannel) Send(ctx context.Context, envelopes ...EnvelopeWriter) error {
+	for _, env := range envelopes {
+		if err := p.Client.PublishMsg(env.(*EnvelopeOut).Msg); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.ctx.Done():
+			return p.ctx.Err()
+		default:
+		}
+	}
+	return nil
+}
+
+func (p PublishChannel) Close() error {
+	p.cancel()
+	return nil
+}
@@ -0,0 +1,72 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package nats
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	natsGo "github.com/nats-io/nats.go"
+)
+
+type Subscription struct {
+	Client  *Client
+	Subject string
+
+	channelBindings   *ChannelBindings
+	operationBindings *OperationBindings
+	ctx               context.Context
+	cancel            context.CancelFunc
+}
+
+func (r *Subscription) Receive(ctx context.Context, cb func(envelope EnvelopeReader)) (err error) {
+	var sub *natsGo.Subscription
+	if r.operationBindings != nil && r.operationBindings.Queue != "" {
+		sub, err = r.Client.QueueSubscribeSync(r.Subject, r.operationBindings.Queue)
+		if err != nil {
+			return fmt.Errorf("queue subscribe: %w", err)
+		}
+	} else {
+		sub, err = r.Client.SubscribeSync(r.Subject)
+		if err != nil {
+			return fmt.Errorf("subscribe: %w", err)
+		}
+	}
+
+	// Make sure the server has processed the subscription
+	if err = r.Client.Flush(); err != nil {
+		return errors.Join(fmt.Errorf("flush: %w", err), sub.Unsubscribe())
+	}
+	run.NotifySubscribeReady(ctx)
+
+	errCh := make(chan error)
+	go func() {
+		defer func() { close(errCh) }()
+		for {
+			msg, err2 := sub.NextMsgWithContext(ctx)
+			if err2 != nil {
+				errCh <- errors.Join(fmt.Errorf("next msg: %w", err))
+				return
+			}
+			cb(NewEnvelopeIn(msg))
+		}
+	}()
+
+	select {
+	case <-r.ctx.Done():
+		err = errors.Join(r.ctx.Err(), sub.Drain()) // Unsubscribe
+		return errors.Join(err, <-errCh)            // Wait for goroutine to finish
+	case err = <-errCh:
+		return err
+	}
+}
+
+func (r *Subscription) Close() error {
+	r.cancel()
+	return nil
+}
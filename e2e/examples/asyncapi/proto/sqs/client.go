@@ -0,0 +1,257 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package sqs
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+const (
+	// DefaultReceiveWaitTime is the long polling wait time, used if it is not set in queue bindings.
+	DefaultReceiveWaitTime = 20 * time.Second
+	// localRegion is used for local endpoints such as LocalStack, if the region is not configured.
+	localRegion = "us-east-1"
+)
+
+var awsHostRe = regexp.MustCompile(`^[a-z0-9-]+\.([a-z0-9-]+)\.amazonaws\.com(\.cn)?$`)
+
+// ClientOption modifies the AWS SDK config options before loading the config.
+type ClientOption = func(opts *config.LoadOptions) error
+
+// NewClient creates the SQS client. The AWS config is loaded from the environment, shared config files, etc.
+// as usual for AWS SDK.
+//
+// If the server host is the AWS host, e.g. "sqs.us-east-1.amazonaws.com", the region is taken from it. Otherwise,
+// the server is considered as the local endpoint such as LocalStack, so the client connects to it by plain HTTP
+// with static dummy credentials.
+func NewClient(ctx context.Context, serverURL string, security run.AnySecurityScheme, extraOpts ...ClientOption) (*Client, error) {
+	opts, err := loadOptions(serverURL, security)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, append(opts, extraOpts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("load aws config: %w", err)
+	}
+	if cfg.Region == "" && cfg.BaseEndpoint != nil {
+		cfg.Region = localRegion
+	}
+	return &Client{Client: sqs.NewFromConfig(cfg)}, nil
+}
+
+type Client struct {
+	*sqs.Client
+	// DisableQueueCreation disables creating the queue, if it does not exist.
+	DisableQueueCreation bool
+}
+
+func (c *Client) Publisher(ctx context.Context, address string, chb *ChannelBindings, opb *OperationBindings, security run.AnySecurityScheme) (Publisher, error) {
+	if security != nil {
+		return nil, fmt.Errorf("operation security schemes are not supported")
+	}
+
+	queueURL, err := c.QueueURL(ctx, address, chb, opb)
+	if err != nil {
+		return nil, err
+	}
+	return &PublishChannel{Client: c.Client, QueueURL: queueURL}, nil
+}
+
+func (c *Client) Subscriber(ctx context.Context, address string, chb *ChannelBindings, opb *OperationBindings, security run.AnySecurityScheme) (Subscriber, error) {
+	if security != nil {
+		return nil, fmt.Errorf("operation security schemes are not supported")
+	}
+
+	queueURL, err := c.QueueURL(ctx, address, chb, opb)
+	if err != nil {
+		return nil, err
+	}
+	waitTime := DefaultReceiveWaitTime
+	if chb != nil && chb.Queue.ReceiveMessageWaitTime > 0 {
+		waitTime = chb.Queue.ReceiveMessageWaitTime
+	}
+
+	ctx2, cancel := context.WithCancel(context.Background())
+	return &SubscribeChannel{
+		Client:   c.Client,
+		QueueURL: queueURL,
+		WaitTime: waitTime,
+		ctx:      ctx2,
+		cancel:   cancel,
+	}, nil
+}
+
+// QueueURL returns the URL of the channel queue. If the channel address is URL, returns it as is. Otherwise, the queue
+// name is taken from the channel bindings or the channel address. If the queue does not exist, it is created
+// according to the bindings, unless DisableQueueCreation is set.
+func (c *Client) QueueURL(ctx context.Context, address string, chb *ChannelBindings, opb *OperationBindings) (string, error) {
+	if strings.HasPrefix(address, "https://") || strings.HasPrefix(address, "http://") {
+		return address, nil
+	}
+
+	queue := Queue{Name: address}
+	if chb != nil && chb.Queue.Name != "" {
+		queue = chb.Queue
+	}
+	return c.ensureQueue(ctx, queue, chb, opb)
+}
+
+func (c *Client) ensureQueue(ctx context.Context, queue Queue, chb *ChannelBindings, opb *OperationBindings) (string, error) {
+	res, err := c.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{QueueName: aws.String(queue.Name)})
+	var notExist *types.QueueDoesNotExist
+	switch {
+	case err == nil:
+		return aws.ToString(res.QueueUrl), nil
+	case !errors.As(err, &notExist) || c.DisableQueueCreation:
+		return "", fmt.Errorf("get url of queue %q: %w", queue.Name, err)
+	}
+
+	attrs := queueAttributes(queue)
+	if rp := queue.RedrivePolicy; rp.MaxReceiveCount > 0 {
+		dlqARN, err := c.deadLetterQueueARN(ctx, rp.DeadLetterQueue, queue, chb, opb)
+		if err != nil {
+			return "", err
+		}
+		b, err := json.Marshal(map[string]string{
+			"deadLetterTargetArn": dlqARN,
+			"maxReceiveCount":     strconv.Itoa(rp.MaxReceiveCount),
+		})
+		if err != nil {
+			return "", err
+		}
+		attrs[string(types.QueueAttributeNameRedrivePolicy)] = string(b)
+	}
+
+	created, err := c.CreateQueue(ctx, &sqs.CreateQueueInput{
+		QueueName:  aws.String(queue.Name),
+		Attributes: attrs,
+		Tags:       queue.Tags,
+	})
+	if err != nil {
+		return "", fmt.Errorf("create queue %q: %w", queue.Name, err)
+	}
+	return aws.ToString(created.QueueUrl), nil
+}
+
+// deadLetterQueueARN returns the ARN of dead-letter queue, creating it if needed. The dead-letter queue definition
+// is looked up in the channel and operation bindings by name.
+func (c *Client) deadLetterQueueARN(ctx context.Context, id Identifier, queue Queue, chb *ChannelBindings, opb *OperationBindings) (string, error) {
+	if id.ARN != "" {
+		return id.ARN, nil
+	}
+
+	dlq := Queue{Name: id.Name, FIFOQueue: queue.FIFOQueue}
+	var candidates []Queue
+	if chb != nil {
+		candidates = append(candidates, chb.DeadLetterQueue)
+	}
+	if opb != nil {
+		candidates = append(candidates, opb.Queues...)
+	}
+	for _, q := range candidates {
+		if q.Name != "" && (q.Name == id.Name || id.Name == "") {
+			dlq = q
+			break
+		}
+	}
+	if dlq.Name == "" {
+		return "", fmt.Errorf("dead-letter queue of queue %q is not defined", queue.Name)
+	}
+	if dlq.Name == queue.Name {
+		return "", fmt.Errorf("queue %q cannot be the dead-letter queue of itself", queue.Name)
+	}
+	dlq.RedrivePolicy = RedrivePolicy{}
+
+	dlqURL, err := c.ensureQueue(ctx, dlq, chb, opb)
+	if err != nil {
+		return "", err
+	}
+	res, err := c.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(dlqURL),
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameQueueArn},
+	})
+	if err != nil {
+		return "", fmt.Errorf("get arn of queue %q: %w", dlq.Name, err)
+	}
+	return res.Attributes[string(types.QueueAttributeNameQueueArn)], nil
+}
+
+func queueAttributes(queue Queue) map[string]string {
+	res := make(map[string]string)
+	seconds := func(d time.Duration) string { return strconv.Itoa(int(d / time.Second)) }
+	if queue.FIFOQueue {
+		res[string(types.QueueAttributeNameFifoQueue)] = "true"
+	}
+	if queue.DeduplicationScope != "" {
+		res[string(types.QueueAttributeNameDeduplicationScope)] = string(queue.DeduplicationScope)
+	}
+	if queue.FIFOThroughputLimit != "" {
+		res[string(types.QueueAttributeNameFifoThroughputLimit)] = string(queue.FIFOThroughputLimit)
+	}
+	if queue.DeliveryDelay > 0 {
+		res[string(types.QueueAttributeNameDelaySeconds)] = seconds(queue.DeliveryDelay)
+	}
+	if queue.VisibilityTimeout > 0 {
+		res[string(types.QueueAttributeNameVisibilityTimeout)] = seconds(queue.VisibilityTimeout)
+	}
+	if queue.ReceiveMessageWaitTime > 0 {
+		res[string(types.QueueAttributeNameReceiveMessageWaitTimeSeconds)] = seconds(queue.ReceiveMessageWaitTime)
+	}
+	if queue.MessageRetentionPeriod > 0 {
+		res[string(types.QueueAttributeNameMessageRetentionPeriod)] = seconds(queue.MessageRetentionPeriod)
+	}
+	if queue.Policy != "" {
+		res[string(types.QueueAttributeNamePolicy)] = queue.Policy
+	}
+	return res
+}
+
+func loadOptions(serverURL string, security run.AnySecurityScheme) ([]ClientOption, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse server url: %w", err)
+	}
+
+	var res []ClientOption
+	if m := awsHostRe.FindStringSubmatch(u.Hostname()); m != nil {
+		res = append(res, config.WithRegion(m[1]))
+	} else if u.Host != "" {
+		scheme := u.Scheme
+		if scheme != "https" {
+			scheme = "http"
+		}
+		res = append(res,
+			config.WithBaseEndpoint(scheme+"://"+u.Host),
+			config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider("test", "test", "")),
+		)
+	}
+
+	if security != nil {
+		switch v := security.(type) {
+		case run.UserPasswordSecurity:
+			accessKeyID, secretAccessKey := v.UserPassword()
+			res = append(res, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, "")))
+		default:
+			return nil, errors.New("unsupported security scheme: " + security.AuthType())
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,34 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package sqs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+type PublishChannel struct {
+	Client   *sqs.Client
+	QueueURL string
+}
+
+// Send sends the envelopes to the queue one by one.
+func (p PublishChannel) Send(ctx context.Context, envelopes ...EnvelopeWriter) error {
+	for i, envelope := range envelopes {
+		e := envelope.(*EnvelopeOut)
+		input := *e.SendMessageInput
+		input.QueueUrl = aws.String(p.QueueURL)
+		input.MessageBody = aws.String(string(e.payload))
+		if _, err := p.Client.SendMessage(ctx, &input); err != nil {
+			return fmt.Errorf("envelope #%d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (p PublishChannel) Close() error {
+	return nil
+}
@@ -0,0 +1,144 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package sqs
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// maxReceiveMessages is the maximum number of messages that SQS returns in one ReceiveMessage call.
+const maxReceiveMessages = 10
+
+type SubscribeChannel struct {
+	Client   *sqs.Client
+	QueueURL string
+	// WaitTime is the long polling wait time, at most 20 seconds.
+	WaitTime time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// Receive long-polls the SQS queue and calls cb for each received message. The message is acknowledged (deleted from
+// the queue) after cb returns, unless cb has already acknowledged it by Ack or Nack methods of envelope.
+func (s SubscribeChannel) Receive(ctx context.Context, cb func(envelope EnvelopeReader)) error {
+	receiveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-receiveCtx.Done():
+		case <-s.ctx.Done():
+			cancel()
+		}
+	}()
+	run.NotifySubscribeReady(ctx)
+
+	for {
+		res, err := s.Client.ReceiveMessage(receiveCtx, &sqs.ReceiveMessageInput{
+			QueueUrl:                    aws.String(s.QueueURL),
+			MaxNumberOfMessages:         maxReceiveMessages,
+			WaitTimeSeconds:             int32(s.WaitTime / time.Second),
+			MessageAttributeNames:       []string{"All"},
+			MessageSystemAttributeNames: []types.MessageSystemAttributeName{types.MessageSystemAttributeNameAll},
+		})
+		if err != nil {
+			if receiveCtx.Err() != nil {
+				return receiveCtx.Err()
+			}
+			return fmt.Errorf("receive: %w", err)
+		}
+
+		for _, msg := range res.Messages {
+			// The rest of received messages become visible again after the visibility timeout
+			if receiveCtx.Err() != nil {
+				return receiveCtx.Err()
+			}
+			envelope := NewEnvelopeIn(msg, s.Client, s.QueueURL)
+			cb(envelope)
+			if !envelope.Settled() {
+				// Message has been processed, so acknowledge it even if the receiving is cancelled during cb call
+				if err = envelope.Ack(context.WithoutCancel(receiveCtx)); err != nil {
+					return fmt.Errorf("ack: %w", err)
+				}
+			}
+		}
+	}
+}
+
+func (s SubscribeChannel) Close() error {
+	s.cancel()
+	return nil
+}
+
+// NewEnvelopeIn returns the envelope for the message received from the SQS queue.
+func NewEnvelopeIn(msg types.Message, client *sqs.Client, queueURL string) *EnvelopeIn {
+	return &EnvelopeIn{
+		Message:  msg,
+		client:   client,
+		queueURL: queueURL,
+		rd:       strings.NewReader(aws.ToString(msg.Body)),
+	}
+}
+
+type EnvelopeIn struct {
+	types.Message
+	client   *sqs.Client
+	queueURL string
+	rd       *strings.Reader
+	settled  bool
+}
+
+func (e *EnvelopeIn) Read(p []byte) (n int, err error) {
+	return e.rd.Read(p)
+}
+
+// Headers returns the message attributes. The values of Binary attributes are []byte, others are strings.
+func (e *EnvelopeIn) Headers() run.Headers {
+	hdrs := make(run.Headers, len(e.MessageAttributes))
+	for k, v := range e.MessageAttributes {
+		if v.BinaryValue != nil {
+			hdrs[k] = v.BinaryValue
+		} else {
+			hdrs[k] = aws.ToString(v.StringValue)
+		}
+	}
+	return hdrs
+}
+
+// Ack acknowledges the message by deleting it from the queue.
+func (e *EnvelopeIn) Ack(ctx context.Context) error {
+	e.settled = true
+	_, err := e.client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(e.queueURL),
+		ReceiptHandle: e.ReceiptHandle,
+	})
+	return err
+}
+
+// Nack negatively acknowledges the message by resetting its visibility timeout, so it will be redelivered
+// immediately. After the maximum receive count, the message is moved to the dead-letter queue, if it is configured.
+func (e *EnvelopeIn) Nack(ctx context.Context) error {
+	e.settled = true
+	_, err := e.client.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+		QueueUrl:          aws.String(e.queueURL),
+		ReceiptHandle:     e.ReceiptHandle,
+		VisibilityTimeout: 0,
+	})
+	return err
+}
+
+// Settled returns true if the message has been acknowledged by Ack or Nack.
+func (e *EnvelopeIn) Settled() bool {
+	return e.settled
+}
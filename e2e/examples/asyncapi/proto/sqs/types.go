@@ -0,0 +1,72 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package sqs
+
+import (
+	"time"
+)
+
+type (
+	ServerBindings struct{}
+
+	ChannelBindings struct {
+		// Queue is the queue for this channel. If Queue.Name is empty, the channel address is used as queue name.
+		Queue Queue
+		// DeadLetterQueue is the queue that is the target of the redrive policy of Queue.
+		DeadLetterQueue Queue
+	}
+
+	OperationBindings struct {
+		// Queues are the definitions of queues referenced by this operation, e.g. as dead-letter queues.
+		Queues []Queue
+	}
+
+	MessageBindings struct {
+		// MessageGroupID is the message group ID for FIFO queues.
+		MessageGroupID string
+		// MessageDeduplicationID is the message deduplication ID for FIFO queues.
+		MessageDeduplicationID string
+	}
+)
+
+type Queue struct {
+	// Name of the queue. For FIFO queue, it must end with ".fifo" suffix.
+	Name                   string
+	FIFOQueue              bool
+	DeduplicationScope     DeduplicationScope
+	FIFOThroughputLimit    FIFOThroughputLimit
+	DeliveryDelay          time.Duration
+	VisibilityTimeout      time.Duration
+	ReceiveMessageWaitTime time.Duration
+	MessageRetentionPeriod time.Duration
+	RedrivePolicy          RedrivePolicy
+	// Policy is the queue access policy in JSON.
+	Policy string
+	Tags   map[string]string
+}
+
+type DeduplicationScope string
+
+const (
+	DeduplicationScopeQueue        DeduplicationScope = "queue"
+	DeduplicationScopeMessageGroup DeduplicationScope = "messageGroup"
+)
+
+type FIFOThroughputLimit string
+
+const (
+	FIFOThroughputLimitPerQueue          FIFOThroughputLimit = "perQueue"
+	FIFOThroughputLimitPerMessageGroupID FIFOThroughputLimit = "perMessageGroupId"
+)
+
+type RedrivePolicy struct {
+	// DeadLetterQueue is the queue that receives the messages after MaxReceiveCount failed receives.
+	DeadLetterQueue Identifier
+	MaxReceiveCount int
+}
+
+// Identifier identifies the queue either by ARN or by name.
+type Identifier struct {
+	ARN  string
+	Name string
+}
@@ -0,0 +1,133 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package servers
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+	"net/url"
+)
+
+func AMQPURL() (*url.URL, error) {
+	return &url.URL{Scheme: "amqp", Host: "examples", Path: ""}, nil
+}
+
+func NewAMQP(producer amqp.Producer, consumer amqp.Consumer) *AMQP {
+	return &AMQP{
+		producer: producer,
+		consumer: consumer,
+	}
+}
+
+type AMQPClosable struct {
+	AMQP
+}
+
+func (c AMQPClosable) Close() error {
+	var err error
+	if v, ok := any(c.producer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	if v, ok := any(c.consumer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	return err
+}
+
+func ConnectAMQPBidi(
+	ctx context.Context,
+	url *url.URL,
+
+) (*AMQPClosable, error) {
+	var bindings *amqp.ServerBindings
+	client, err := amqp.NewClient(url.String(), bindings, nil)
+	if err != nil {
+		return nil, err
+	}
+	producer, consumer := client, client
+	return &AMQPClosable{
+		AMQP{producer: producer, consumer: consumer},
+	}, nil
+}
+
+func ConnectAMQPProducer(
+	ctx context.Context,
+	url *url.URL,
+
+) (*AMQPClosable, error) {
+	var bindings *amqp.ServerBindings
+	producer, err := amqp.NewClient(url.String(), bindings, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AMQPClosable{
+		AMQP{producer: producer},
+	}, nil
+}
+
+func ConnectAMQPConsumer(
+	ctx context.Context,
+	url *url.URL,
+
+) (*AMQPClosable, error) {
+	var bindings *amqp.ServerBindings
+	consumer, err := amqp.NewClient(url.String(), bindings, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AMQPClosable{
+		AMQP{consumer: consumer},
+	}, nil
+}
+
+type AMQP struct {
+	producer amqp.Producer
+	consumer amqp.Consumer
+}
+
+func (s AMQP) Name() string {
+	return "AMQP"
+}
+
+func (s AMQP) Producer() amqp.Producer {
+	return s.producer
+}
+
+func (s AMQP) Consumer() amqp.Consumer {
+	return s.consumer
+}
+
+func (s AMQP) OpenOrdersAMQP(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.OrdersAMQP, error) {
+	return channels.OpenOrdersAMQP(
+		ctx, s, nil, security, opts...,
+	)
+}
+
+func (s AMQP) OpenPlaceOrderAMQP(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.PlaceOrderAMQP, error) {
+	return operations.OpenPlaceOrderAMQP(
+		ctx, s, opts...,
+	)
+}
+func (s AMQP) OpenProcessOrderAMQP(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.ProcessOrderAMQP, error) {
+	return operations.OpenProcessOrderAMQP(
+		ctx, s, opts...,
+	)
+}
@@ -0,0 +1,134 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package servers
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/googlepubsub"
+	"github.com/bdragon300/go-asyncapi/run"
+	"google.golang.org/api/option"
+	"io"
+	"net/url"
+)
+
+func GooglepubsubURL() (*url.URL, error) {
+	return &url.URL{Scheme: "googlepubsub", Host: "examples", Path: ""}, nil
+}
+
+func NewGooglepubsub(producer googlepubsub.Producer, consumer googlepubsub.Consumer) *Googlepubsub {
+	return &Googlepubsub{
+		producer: producer,
+		consumer: consumer,
+	}
+}
+
+type GooglepubsubClosable struct {
+	Googlepubsub
+}
+
+func (c GooglepubsubClosable) Close() error {
+	var err error
+	if v, ok := any(c.producer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	if v, ok := any(c.consumer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	return err
+}
+
+func ConnectGooglepubsubBidi(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...option.ClientOption,
+) (*GooglepubsubClosable, error) {
+	client, err := googlepubsub.NewClient(ctx, url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	producer, consumer := client, client
+	return &GooglepubsubClosable{
+		Googlepubsub{producer: producer, consumer: consumer},
+	}, nil
+}
+
+func ConnectGooglepubsubProducer(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...option.ClientOption,
+) (*GooglepubsubClosable, error) {
+	producer, err := googlepubsub.NewClient(ctx, url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &GooglepubsubClosable{
+		Googlepubsub{producer: producer},
+	}, nil
+}
+
+func ConnectGooglepubsubConsumer(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...option.ClientOption,
+) (*GooglepubsubClosable, error) {
+	consumer, err := googlepubsub.NewClient(ctx, url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &GooglepubsubClosable{
+		Googlepubsub{consumer: consumer},
+	}, nil
+}
+
+type Googlepubsub struct {
+	producer googlepubsub.Producer
+	consumer googlepubsub.Consumer
+}
+
+func (s Googlepubsub) Name() string {
+	return "Googlepubsub"
+}
+
+func (s Googlepubsub) Producer() googlepubsub.Producer {
+	return s.producer
+}
+
+func (s Googlepubsub) Consumer() googlepubsub.Consumer {
+	return s.consumer
+}
+
+func (s Googlepubsub) OpenOrdersGooglepubsub(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.OrdersGooglepubsub, error) {
+	return channels.OpenOrdersGooglepubsub(
+		ctx, s, nil, security, opts...,
+	)
+}
+
+func (s Googlepubsub) OpenPlaceOrderGooglepubsub(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.PlaceOrderGooglepubsub, error) {
+	return operations.OpenPlaceOrderGooglepubsub(
+		ctx, s, opts...,
+	)
+}
+func (s Googlepubsub) OpenProcessOrderGooglepubsub(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.ProcessOrderGooglepubsub, error) {
+	return operations.OpenProcessOrderGooglepubsub(
+		ctx, s, opts...,
+	)
+}
@@ -0,0 +1,128 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package servers
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"io"
+	"net/url"
+)
+
+func KafkaURL() (*url.URL, error) {
+	return &url.URL{Scheme: "kafka", Host: "examples", Path: ""}, nil
+}
+
+func NewKafka(producer kafka.Producer, consumer kafka.Consumer) *Kafka {
+	return &Kafka{
+		producer: producer,
+		consumer: consumer,
+	}
+}
+
+type KafkaClosable struct {
+	Kafka
+}
+
+func (c KafkaClosable) Close() error {
+	var err error
+	if v, ok := any(c.producer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	if v, ok := any(c.consumer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	return err
+}
+
+func ConnectKafkaBidi(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...kgo.Opt,
+) (*KafkaClosable, error) {
+	var bindings *kafka.ServerBindings
+	producer := kafka.NewProducer([]string{url.Host}, bindings, nil, opts...)
+	consumer := kafka.NewConsumer([]string{url.Host}, bindings, nil, opts...)
+	return &KafkaClosable{
+		Kafka{producer: producer, consumer: consumer},
+	}, nil
+}
+
+func ConnectKafkaProducer(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...kgo.Opt,
+) (*KafkaClosable, error) {
+	var bindings *kafka.ServerBindings
+	producer := kafka.NewProducer([]string{url.Host}, bindings, nil, opts...)
+	return &KafkaClosable{
+		Kafka{producer: producer},
+	}, nil
+}
+
+func ConnectKafkaConsumer(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...kgo.Opt,
+) (*KafkaClosable, error) {
+	var bindings *kafka.ServerBindings
+	consumer := kafka.NewConsumer([]string{url.Host}, bindings, nil, opts...)
+	return &KafkaClosable{
+		Kafka{consumer: consumer},
+	}, nil
+}
+
+type Kafka struct {
+	producer kafka.Producer
+	consumer kafka.Consumer
+}
+
+func (s Kafka) Name() string {
+	return "Kafka"
+}
+
+func (s Kafka) Producer() kafka.Producer {
+	return s.producer
+}
+
+func (s Kafka) Consumer() kafka.Consumer {
+	return s.consumer
+}
+
+func (s Kafka) OpenOrdersKafka(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.OrdersKafka, error) {
+	return channels.OpenOrdersKafka(
+		ctx, s, nil, security, opts...,
+	)
+}
+
+func (s Kafka) OpenPlaceOrderKafka(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.PlaceOrderKafka, error) {
+	return operations.OpenPlaceOrderKafka(
+		ctx, s, opts...,
+	)
+}
+func (s Kafka) OpenProcessOrderKafka(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.ProcessOrderKafka, error) {
+	return operations.OpenProcessOrderKafka(
+		ctx, s, opts...,
+	)
+}
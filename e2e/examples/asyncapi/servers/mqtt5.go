@@ -0,0 +1,137 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package servers
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/mqtt5"
+	"github.com/bdragon300/go-asyncapi/run"
+	"github.com/eclipse/paho.golang/autopaho"
+	"io"
+	"net/url"
+)
+
+func Mqtt5URL() (*url.URL, error) {
+	return &url.URL{Scheme: "mqtt5", Host: "examples", Path: ""}, nil
+}
+
+func NewMqtt5(producer mqtt5.Producer, consumer mqtt5.Consumer) *Mqtt5 {
+	return &Mqtt5{
+		producer: producer,
+		consumer: consumer,
+	}
+}
+
+type Mqtt5Closable struct {
+	Mqtt5
+}
+
+func (c Mqtt5Closable) Close() error {
+	var err error
+	if v, ok := any(c.producer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	if v, ok := any(c.consumer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	return err
+}
+
+func ConnectMqtt5Bidi(
+	ctx context.Context,
+	url *url.URL,
+
+	initialConf *autopaho.ClientConfig,
+) (*Mqtt5Closable, error) {
+	var bindings *mqtt5.ServerBindings
+	client, err := mqtt5.NewClient(ctx, url.String(), bindings, nil, initialConf)
+	if err != nil {
+		return nil, err
+	}
+	producer, consumer := client, client
+	return &Mqtt5Closable{
+		Mqtt5{producer: producer, consumer: consumer},
+	}, nil
+}
+
+func ConnectMqtt5Producer(
+	ctx context.Context,
+	url *url.URL,
+
+	initialConf *autopaho.ClientConfig,
+) (*Mqtt5Closable, error) {
+	var bindings *mqtt5.ServerBindings
+	producer, err := mqtt5.NewClient(ctx, url.String(), bindings, nil, initialConf)
+	if err != nil {
+		return nil, err
+	}
+	return &Mqtt5Closable{
+		Mqtt5{producer: producer},
+	}, nil
+}
+
+func ConnectMqtt5Consumer(
+	ctx context.Context,
+	url *url.URL,
+
+	initialConf *autopaho.ClientConfig,
+) (*Mqtt5Closable, error) {
+	var bindings *mqtt5.ServerBindings
+	consumer, err := mqtt5.NewClient(ctx, url.String(), bindings, nil, initialConf)
+	if err != nil {
+		return nil, err
+	}
+	return &Mqtt5Closable{
+		Mqtt5{consumer: consumer},
+	}, nil
+}
+
+type Mqtt5 struct {
+	producer mqtt5.Producer
+	consumer mqtt5.Consumer
+}
+
+func (s Mqtt5) Name() string {
+	return "Mqtt5"
+}
+
+func (s Mqtt5) Producer() mqtt5.Producer {
+	return s.producer
+}
+
+func (s Mqtt5) Consumer() mqtt5.Consumer {
+	return s.consumer
+}
+
+func (s Mqtt5) OpenOrdersMqtt5(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.OrdersMqtt5, error) {
+	return channels.OpenOrdersMqtt5(
+		ctx, s, nil, security, opts...,
+	)
+}
+
+func (s Mqtt5) OpenPlaceOrderMqtt5(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.PlaceOrderMqtt5, error) {
+	return operations.OpenPlaceOrderMqtt5(
+		ctx, s, opts...,
+	)
+}
+func (s Mqtt5) OpenProcessOrderMqtt5(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.ProcessOrderMqtt5, error) {
+	return operations.OpenProcessOrderMqtt5(
+		ctx, s, opts...,
+	)
+}
@@ -0,0 +1,134 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package servers
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/nats"
+	"github.com/bdragon300/go-asyncapi/run"
+	natsGo1 "github.com/nats-io/nats.go"
+	"io"
+	"net/url"
+)
+
+func NatsURL() (*url.URL, error) {
+	return &url.URL{Scheme: "nats", Host: "examples", Path: ""}, nil
+}
+
+func NewNats(producer nats.Producer, consumer nats.Consumer) *Nats {
+	return &Nats{
+		producer: producer,
+		consumer: consumer,
+	}
+}
+
+type NatsClosable struct {
+	Nats
+}
+
+func (c NatsClosable) Close() error {
+	var err error
+	if v, ok := any(c.producer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	if v, ok := any(c.consumer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	return err
+}
+
+func ConnectNatsBidi(
+	_ context.Context,
+	url *url.URL,
+
+	opts ...natsGo1.Option,
+) (*NatsClosable, error) {
+	client, err := nats.NewClient(url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	producer, consumer := client, client
+	return &NatsClosable{
+		Nats{producer: producer, consumer: consumer},
+	}, nil
+}
+
+func ConnectNatsProducer(
+	_ context.Context,
+	url *url.URL,
+
+	opts ...natsGo1.Option,
+) (*NatsClosable, error) {
+	producer, err := nats.NewClient(url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &NatsClosable{
+		Nats{producer: producer},
+	}, nil
+}
+
+func ConnectNatsConsumer(
+	_ context.Context,
+	url *url.URL,
+
+	opts ...natsGo1.Option,
+) (*NatsClosable, error) {
+	consumer, err := nats.NewClient(url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &NatsClosable{
+		Nats{consumer: consumer},
+	}, nil
+}
+
+type Nats struct {
+	producer nats.Producer
+	consumer nats.Consumer
+}
+
+func (s Nats) Name() string {
+	return "Nats"
+}
+
+func (s Nats) Producer() nats.Producer {
+	return s.producer
+}
+
+func (s Nats) Consumer() nats.Consumer {
+	return s.consumer
+}
+
+func (s Nats) OpenOrdersNats(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.OrdersNats, error) {
+	return channels.OpenOrdersNats(
+		ctx, s, nil, security, opts...,
+	)
+}
+
+func (s Nats) OpenPlaceOrderNats(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.PlaceOrderNats, error) {
+	return operations.OpenPlaceOrderNats(
+		ctx, s, opts...,
+	)
+}
+func (s Nats) OpenProcessOrderNats(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.ProcessOrderNats, error) {
+	return operations.OpenProcessOrderNats(
+		ctx, s, opts...,
+	)
+}
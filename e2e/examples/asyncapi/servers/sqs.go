@@ -0,0 +1,133 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package servers
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi/proto/sqs"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+	"net/url"
+)
+
+func SQSURL() (*url.URL, error) {
+	return &url.URL{Scheme: "sqs", Host: "examples", Path: ""}, nil
+}
+
+func NewSQS(producer sqs.Producer, consumer sqs.Consumer) *SQS {
+	return &SQS{
+		producer: producer,
+		consumer: consumer,
+	}
+}
+
+type SQSClosable struct {
+	SQS
+}
+
+func (c SQSClosable) Close() error {
+	var err error
+	if v, ok := any(c.producer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	if v, ok := any(c.consumer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	return err
+}
+
+func ConnectSQSBidi(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...sqs.ClientOption,
+) (*SQSClosable, error) {
+	client, err := sqs.NewClient(ctx, url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	producer, consumer := client, client
+	return &SQSClosable{
+		SQS{producer: producer, consumer: consumer},
+	}, nil
+}
+
+func ConnectSQSProducer(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...sqs.ClientOption,
+) (*SQSClosable, error) {
+	producer, err := sqs.NewClient(ctx, url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &SQSClosable{
+		SQS{producer: producer},
+	}, nil
+}
+
+func ConnectSQSConsumer(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...sqs.ClientOption,
+) (*SQSClosable, error) {
+	consumer, err := sqs.NewClient(ctx, url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &SQSClosable{
+		SQS{consumer: consumer},
+	}, nil
+}
+
+type SQS struct {
+	producer sqs.Producer
+	consumer sqs.Consumer
+}
+
+func (s SQS) Name() string {
+	return "SQS"
+}
+
+func (s SQS) Producer() sqs.Producer {
+	return s.producer
+}
+
+func (s SQS) Consumer() sqs.Consumer {
+	return s.consumer
+}
+
+func (s SQS) OpenOrdersSQS(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.OrdersSQS, error) {
+	return channels.OpenOrdersSQS(
+		ctx, s, nil, security, opts...,
+	)
+}
+
+func (s SQS) OpenPlaceOrderSQS(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.PlaceOrderSQS, error) {
+	return operations.OpenPlaceOrderSQS(
+		ctx, s, opts...,
+	)
+}
+func (s SQS) OpenProcessOrderSQS(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.ProcessOrderSQS, error) {
+	return operations.OpenProcessOrderSQS(
+		ctx, s, opts...,
+	)
+}
@@ -0,0 +1,5 @@
+// Package examples checks the generated tests of message examples, that marshal the example into the protocol
+// envelope and unmarshal it back.
+package examples
+
+//go:generate go -C ../.. run ./cmd/go-asyncapi -c e2e/examples/go-asyncapi.yaml code -t e2e/examples/asyncapi -M github.com/bdragon300/go-asyncapi/e2e/examples/asyncapi e2e/examples/asyncapi.yaml
@@ -0,0 +1,231 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package channels
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi/proto/googlepubsub"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+func EventsAddress() run.ParamString {
+	return run.ParamString{
+		Expr: "events",
+	}
+}
+
+func NewEventsGooglepubsub(
+
+	publisher googlepubsub.Publisher,
+	subscriber googlepubsub.Subscriber,
+	opts ...run.MiddlewareOption,
+) *EventsGooglepubsub {
+	res := EventsGooglepubsub{
+		address: EventsAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+	return &res
+}
+
+type EventsServerGooglepubsub interface {
+	OpenEventsGooglepubsub(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*EventsGooglepubsub, error)
+	Producer() googlepubsub.Producer
+	Consumer() googlepubsub.Consumer
+}
+
+func OpenEventsGooglepubsub(
+	ctx context.Context,
+	server EventsServerGooglepubsub,
+
+	opBindings *googlepubsub.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*EventsGooglepubsub, error) {
+	var err error
+	address, err := EventsAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher googlepubsub.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber googlepubsub.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewEventsGooglepubsub(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type EventsGooglepubsub struct {
+	address     run.ParamString
+	publisher   googlepubsub.Publisher
+	subscriber  googlepubsub.Subscriber
+	middlewares run.Middlewares
+}
+
+func (c EventsGooglepubsub) Address() run.ParamString {
+	return c.address
+}
+
+func (c EventsGooglepubsub) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type EventsEnvelopeMarshalerGooglepubsub interface {
+	MarshalEventsGooglepubsub(envelope googlepubsub.EnvelopeWriter) error
+}
+
+func (c EventsGooglepubsub) SealEvent(
+	envelope googlepubsub.EnvelopeWriter,
+	message EventsEnvelopeMarshalerGooglepubsub,
+) error {
+	if err := message.MarshalEventsGooglepubsub(envelope); err != nil {
+		return err
+	}
+
+	envelope.SetBindings(messages.EventBindings{}.Googlepubsub())
+	return nil
+}
+
+func (c EventsGooglepubsub) PublishEvent(
+	ctx context.Context,
+
+	message EventsEnvelopeMarshalerGooglepubsub,
+) error {
+	envelope := googlepubsub.NewEnvelopeOut(nil)
+	if err := c.SealEvent(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c EventsGooglepubsub) PublishEnvelope(ctx context.Context, envelope googlepubsub.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope googlepubsub.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c EventsGooglepubsub) Publisher() googlepubsub.Publisher {
+	return c.publisher
+}
+
+func (c EventsGooglepubsub) Publish(ctx context.Context, envelopes ...googlepubsub.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type EventsEnvelopeUnmarshalerGooglepubsub interface {
+	UnmarshalEventsGooglepubsub(envelope googlepubsub.EnvelopeReader) error
+}
+
+func (c EventsGooglepubsub) UnsealEvent(
+	envelope googlepubsub.EnvelopeReader,
+	message EventsEnvelopeUnmarshalerGooglepubsub,
+) error {
+	return message.UnmarshalEventsGooglepubsub(envelope)
+}
+
+// SubscribeEvent receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c EventsGooglepubsub) SubscribeEvent(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.EventReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope googlepubsub.EnvelopeReader, message any) error {
+		m := message.(*messages.EventIn)
+		if err2 := c.UnsealEvent(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope googlepubsub.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) googlepubsub.EnvelopeReader {
+				return &eventsGooglepubsubBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope googlepubsub.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.EventIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// eventsGooglepubsubBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type eventsGooglepubsubBufferedEnvelope struct {
+	googlepubsub.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *eventsGooglepubsubBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *eventsGooglepubsubBufferedEnvelope) Unwrap() googlepubsub.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c EventsGooglepubsub) Subscriber() googlepubsub.Subscriber {
+	return c.subscriber
+}
+
+func (c EventsGooglepubsub) Subscribe(ctx context.Context, cb func(envelope googlepubsub.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
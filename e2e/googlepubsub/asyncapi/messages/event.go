@@ -0,0 +1,130 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi/proto/googlepubsub"
+	"github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+)
+
+type EventBindings struct{}
+
+func (c EventBindings) Googlepubsub() googlepubsub.MessageBindings {
+	return googlepubsub.MessageBindings{
+		Attributes:  map[string]string{"source": "e2e"},
+		OrderingKey: "default",
+	}
+}
+
+type EventSender interface {
+	SetPayload(payload schemas.Event) *EventOut
+	SetHeaders(headers map[string]any) *EventOut
+}
+
+// EventOut-- (Outbound Message)
+type EventOut struct {
+	Payload schemas.Event
+	Headers map[string]any
+}
+
+// Validate checks the EventOut value against the constraints from the jsonschema definition.
+func (v EventOut) Validate() error {
+	if err := v.Payload.Validate(); err != nil {
+		return fmt.Errorf("Payload: %w", err)
+	}
+	return nil
+}
+
+func (m *EventOut) SetPayload(payload schemas.Event) *EventOut {
+	m.Payload = payload
+	return m
+}
+
+func (m *EventOut) SetHeaders(headers map[string]any) *EventOut {
+	m.Headers = headers
+	return m
+}
+
+type EventReceiver interface {
+	Payload() schemas.Event
+	Headers() map[string]any
+}
+
+// EventIn-- (Inbound Message)
+type EventIn struct {
+	payload schemas.Event
+	headers map[string]any
+}
+
+// Validate checks the EventIn value against the constraints from the jsonschema definition.
+func (v EventIn) Validate() error {
+	if err := v.payload.Validate(); err != nil {
+		return fmt.Errorf("payload: %w", err)
+	}
+	return nil
+}
+
+func (m *EventIn) Payload() schemas.Event {
+	return m.payload
+}
+
+func (m *EventIn) Headers() map[string]any {
+	return m.headers
+}
+
+func (m *EventOut) MarshalEventsGooglepubsub(envelope googlepubsub.EnvelopeWriter) error {
+	return m.MarshalEnvelopeGooglepubsub(envelope)
+}
+
+func (m *EventOut) MarshalEnvelopeGooglepubsub(envelope googlepubsub.EnvelopeWriter) error {
+	if err := m.MarshalGooglepubsub(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers(m.Headers))
+	return nil
+}
+
+func (m *EventOut) MarshalGooglepubsub(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+func (c EventOut) BindingsGooglepubsub() googlepubsub.MessageBindings {
+	return EventBindings{}.Googlepubsub()
+}
+
+func (m *EventIn) UnmarshalEventsGooglepubsub(envelope googlepubsub.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeGooglepubsub(envelope)
+}
+
+func (m *EventIn) UnmarshalEnvelopeGooglepubsub(envelope googlepubsub.EnvelopeReader) error {
+	if err := m.UnmarshalGooglepubsub(envelope); err != nil {
+		return err
+	}
+	m.headers = map[string]any(envelope.Headers())
+	return nil
+}
+
+func (m *EventIn) UnmarshalGooglepubsub(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
+func (c EventIn) BindingsGooglepubsub() googlepubsub.MessageBindings {
+	return EventBindings{}.Googlepubsub()
+}
@@ -0,0 +1,123 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi/proto/googlepubsub"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+type ReceiveEventServerGooglepubsub interface {
+	OpenEventsGooglepubsub(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.EventsGooglepubsub, error)
+	OpenReceiveEventGooglepubsub(context.Context, ...run.MiddlewareOption) (*ReceiveEventGooglepubsub, error)
+	Producer() googlepubsub.Producer
+	Consumer() googlepubsub.Consumer
+}
+
+func OpenReceiveEventGooglepubsub(
+	ctx context.Context,
+	server ReceiveEventServerGooglepubsub,
+
+	opts ...run.MiddlewareOption,
+) (*ReceiveEventGooglepubsub, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "events",
+			Operation: "receiveEvent",
+			Protocol:  "googlepubsub",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, receiveEventGooglepubsubMetrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenEventsGooglepubsub(
+		run.WithOperationName(ctx, "receiveEvent"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReceiveEventGooglepubsub{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// receiveEventGooglepubsubEnvelopeReader counts the payload bytes read from the envelope.
+type receiveEventGooglepubsubEnvelopeReader struct {
+	googlepubsub.EnvelopeReader
+	size int
+}
+
+func (e *receiveEventGooglepubsubEnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// receiveEventGooglepubsubMetrics returns the middleware that reports the received messages metrics.
+func receiveEventGooglepubsubMetrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[googlepubsub.EnvelopeReader]) run.SubscribeHandler[googlepubsub.EnvelopeReader] {
+		return func(ctx context.Context, envelope googlepubsub.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.EventIn:
+				labels.Message = "event"
+			}
+			counter := &receiveEventGooglepubsubEnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type ReceiveEventChannelGooglepubsub interface {
+	Close() error
+
+	SealEvent(googlepubsub.EnvelopeWriter, channels.EventsEnvelopeMarshalerGooglepubsub) error
+	PublishEvent(context.Context, channels.EventsEnvelopeMarshalerGooglepubsub) error
+
+	UnsealEvent(googlepubsub.EnvelopeReader, channels.EventsEnvelopeUnmarshalerGooglepubsub) error
+	SubscribeEvent(context.Context, func(context.Context, messages.EventReceiver) error) error
+}
+
+type ReceiveEventGooglepubsub struct {
+	Channel      ReceiveEventChannelGooglepubsub
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c ReceiveEventGooglepubsub) Close() error {
+	return c.Channel.Close()
+}
+
+func (o ReceiveEventGooglepubsub) UnsealEvent(
+	envelope googlepubsub.EnvelopeReader,
+	message channels.EventsEnvelopeUnmarshalerGooglepubsub,
+) error {
+	return o.Channel.UnsealEvent(envelope, message)
+}
+
+func (o ReceiveEventGooglepubsub) SubscribeEvent(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.EventReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeEvent(ctx, cb)
+}
@@ -0,0 +1,120 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi/proto/googlepubsub"
+	"github.com/bdragon300/go-asyncapi/run"
+	"time"
+)
+
+type SendEventServerGooglepubsub interface {
+	OpenEventsGooglepubsub(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.EventsGooglepubsub, error)
+	OpenSendEventGooglepubsub(context.Context, ...run.MiddlewareOption) (*SendEventGooglepubsub, error)
+	Producer() googlepubsub.Producer
+	Consumer() googlepubsub.Consumer
+}
+
+func OpenSendEventGooglepubsub(
+	ctx context.Context,
+	server SendEventServerGooglepubsub,
+
+	opts ...run.MiddlewareOption,
+) (*SendEventGooglepubsub, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "events",
+			Operation: "sendEvent",
+			Protocol:  "googlepubsub",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenEventsGooglepubsub(
+		run.WithOperationName(ctx, "sendEvent"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendEventGooglepubsub{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// sendEventGooglepubsubEnvelopeWriter counts the payload bytes written to the envelope.
+type sendEventGooglepubsubEnvelopeWriter struct {
+	googlepubsub.EnvelopeWriter
+	size int
+}
+
+func (e *sendEventGooglepubsubEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type SendEventChannelGooglepubsub interface {
+	Close() error
+
+	SealEvent(googlepubsub.EnvelopeWriter, channels.EventsEnvelopeMarshalerGooglepubsub) error
+	PublishEvent(context.Context, channels.EventsEnvelopeMarshalerGooglepubsub) error
+
+	UnsealEvent(googlepubsub.EnvelopeReader, channels.EventsEnvelopeUnmarshalerGooglepubsub) error
+	SubscribeEvent(context.Context, func(context.Context, messages.EventReceiver) error) error
+	PublishEnvelope(context.Context, googlepubsub.EnvelopeWriter, any) error
+}
+
+type SendEventGooglepubsub struct {
+	Channel      SendEventChannelGooglepubsub
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c SendEventGooglepubsub) Close() error {
+	return c.Channel.Close()
+}
+
+func (o SendEventGooglepubsub) SealEvent(
+	envelope googlepubsub.EnvelopeWriter,
+	message channels.EventsEnvelopeMarshalerGooglepubsub,
+) error {
+	return o.Channel.SealEvent(envelope, message)
+}
+
+func (o SendEventGooglepubsub) PublishEvent(
+	ctx context.Context,
+
+	message channels.EventsEnvelopeMarshalerGooglepubsub,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishEvent(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "event"
+	envelope := googlepubsub.NewEnvelopeOut(nil)
+	counter := &sendEventGooglepubsubEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealEvent(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
@@ -0,0 +1,113 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package googlepubsub
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"bytes"
+	"io"
+
+	"cloud.google.com/go/pubsub/v2"
+)
+
+func NewEnvelopeOut(buf []byte) *EnvelopeOut {
+	return &EnvelopeOut{Message: &pubsub.Message{Data: buf}}
+}
+
+type EnvelopeOut struct {
+	*pubsub.Message
+	messageBindings MessageBindings
+}
+
+func (e *EnvelopeOut) Write(p []byte) (n int, err error) {
+	e.Data = append(e.Data, p...)
+	return len(p), nil
+}
+
+func (e *EnvelopeOut) ResetPayload() {
+	e.Data = e.Data[:0]
+}
+
+func (e *EnvelopeOut) SetHeaders(headers run.Headers) {
+	if e.Attributes == nil {
+		e.Attributes = make(map[string]string, len(headers))
+	}
+	for k, v := range headers.ToByteValues() {
+		e.Attributes[k] = string(v)
+	}
+}
+
+func (e *EnvelopeOut) SetContentType(contentType string) {
+	if e.Attributes == nil {
+		e.Attributes = make(map[string]string)
+	}
+	e.Attributes["Content-Type"] = contentType
+}
+
+// SetBindings sets the message bindings. The attributes and ordering key from bindings are set to the message
+// if they are not set yet.
+func (e *EnvelopeOut) SetBindings(bindings MessageBindings) {
+	e.messageBindings = bindings
+	if e.OrderingKey == "" {
+		e.OrderingKey = bindings.OrderingKey
+	}
+	if len(bindings.Attributes) > 0 && e.Attributes == nil {
+		e.Attributes = make(map[string]string, len(bindings.Attributes))
+	}
+	for k, v := range bindings.Attributes {
+		if _, ok := e.Attributes[k]; !ok {
+			e.Attributes[k] = v
+		}
+	}
+}
+
+// SetOrderingKey sets the ordering key of the message. Messages with the same ordering key are delivered to
+// subscribers in the order they were published.
+func (e *EnvelopeOut) SetOrderingKey(key string) {
+	e.OrderingKey = key
+}
+
+func NewEnvelopeIn(msg *pubsub.Message) *EnvelopeIn {
+	return &EnvelopeIn{
+		Message: msg,
+		rd:      bytes.NewReader(msg.Data),
+	}
+}
+
+type EnvelopeIn struct {
+	*pubsub.Message
+	rd      io.Reader
+	settled bool
+}
+
+func (e *EnvelopeIn) Read(p []byte) (n int, err error) {
+	return e.rd.Read(p)
+}
+
+func (e *EnvelopeIn) Headers() run.Headers {
+	hdrs := make(run.Headers, len(e.Attributes))
+	for k, v := range e.Attributes {
+		hdrs[k] = []byte(v)
+	}
+	return hdrs
+}
+
+// Ack acknowledges the message.
+func (e *EnvelopeIn) Ack() {
+	e.settled = true
+	e.Message.Ack()
+}
+
+// Nack negatively acknowledges the message, so it will be redelivered.
+func (e *EnvelopeIn) Nack() {
+	e.settled = true
+	e.Message.Nack()
+}
+
+// Settled returns true if the message has been acknowledged by Ack or Nack.
+func (e *EnvelopeIn) Settled() bool {
+	return e.settled
+}
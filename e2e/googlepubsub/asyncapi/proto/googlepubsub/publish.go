@@ -0,0 +1,37 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package googlepubsub
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/pubsub/v2"
+)
+
+type PublishChannel struct {
+	*pubsub.Publisher
+}
+
+// Send publishes the envelopes and waits until the server accepts all of them. If publishing of a message with
+// ordering key fails, the publishing for this key is resumed, so the next messages with this key can be published.
+func (p PublishChannel) Send(ctx context.Context, envelopes ...EnvelopeWriter) error {
+	results := make([]*pubsub.PublishResult, 0, len(envelopes))
+	for _, envelope := range envelopes {
+		results = append(results, p.Publish(ctx, envelope.(*EnvelopeOut).Message))
+	}
+	for i, res := range results {
+		if _, err := res.Get(ctx); err != nil {
+			if key := envelopes[i].(*EnvelopeOut).OrderingKey; key != "" {
+				p.ResumePublish(key)
+			}
+			return fmt.Errorf("envelope #%d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (p PublishChannel) Close() error {
+	p.Stop()
+	return nil
+}
@@ -0,0 +1,37 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package googlepubsub
+
+type (
+	ServerBindings struct{}
+
+	ChannelBindings struct {
+		Labels                   map[string]string
+		MessageRetentionDuration string // Duration in seconds with "s" suffix, e.g. "86400s"
+		MessageStoragePolicy     MessageStoragePolicy
+		SchemaSettings           SchemaSettings
+	}
+
+	MessageStoragePolicy struct {
+		AllowedPersistenceRegions []string
+	}
+
+	SchemaSettings struct {
+		Encoding        string
+		FirstRevisionID string
+		LastRevisionID  string
+		Name            string
+	}
+
+	OperationBindings struct{}
+
+	MessageBindings struct {
+		Attributes  map[string]string
+		OrderingKey string
+		Schema      MessageSchema
+	}
+
+	MessageSchema struct {
+		Name string
+	}
+)
@@ -0,0 +1,134 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package servers
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi/proto/googlepubsub"
+	"github.com/bdragon300/go-asyncapi/run"
+	"google.golang.org/api/option"
+	"io"
+	"net/url"
+)
+
+func MainURL() (*url.URL, error) {
+	return &url.URL{Scheme: "googlepubsub", Host: "localhost:8085", Path: "/projects/test-project"}, nil
+}
+
+func NewMain(producer googlepubsub.Producer, consumer googlepubsub.Consumer) *Main {
+	return &Main{
+		producer: producer,
+		consumer: consumer,
+	}
+}
+
+type MainClosable struct {
+	Main
+}
+
+func (c MainClosable) Close() error {
+	var err error
+	if v, ok := any(c.producer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	if v, ok := any(c.consumer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	return err
+}
+
+func ConnectMainBidi(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...option.ClientOption,
+) (*MainClosable, error) {
+	client, err := googlepubsub.NewClient(ctx, url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	producer, consumer := client, client
+	return &MainClosable{
+		Main{producer: producer, consumer: consumer},
+	}, nil
+}
+
+func ConnectMainProducer(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...option.ClientOption,
+) (*MainClosable, error) {
+	producer, err := googlepubsub.NewClient(ctx, url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &MainClosable{
+		Main{producer: producer},
+	}, nil
+}
+
+func ConnectMainConsumer(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...option.ClientOption,
+) (*MainClosable, error) {
+	consumer, err := googlepubsub.NewClient(ctx, url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &MainClosable{
+		Main{consumer: consumer},
+	}, nil
+}
+
+type Main struct {
+	producer googlepubsub.Producer
+	consumer googlepubsub.Consumer
+}
+
+func (s Main) Name() string {
+	return "Main"
+}
+
+func (s Main) Producer() googlepubsub.Producer {
+	return s.producer
+}
+
+func (s Main) Consumer() googlepubsub.Consumer {
+	return s.consumer
+}
+
+func (s Main) OpenEventsGooglepubsub(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.EventsGooglepubsub, error) {
+	return channels.OpenEventsGooglepubsub(
+		ctx, s, nil, security, opts...,
+	)
+}
+
+func (s Main) OpenReceiveEventGooglepubsub(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.ReceiveEventGooglepubsub, error) {
+	return operations.OpenReceiveEventGooglepubsub(
+		ctx, s, opts...,
+	)
+}
+func (s Main) OpenSendEventGooglepubsub(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.SendEventGooglepubsub, error) {
+	return operations.OpenSendEventGooglepubsub(
+		ctx, s, opts...,
+	)
+}
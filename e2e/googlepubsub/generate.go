@@ -0,0 +1,4 @@
+// Package googlepubsub checks the Google Cloud Pub/Sub implementation against the in-memory pstest server.
+package googlepubsub
+
+//go:generate go -C ../.. run ./cmd/go-asyncapi code -t e2e/googlepubsub/asyncapi -M github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi e2e/googlepubsub/asyncapi.yaml
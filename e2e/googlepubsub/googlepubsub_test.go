@@ -0,0 +1,156 @@
+package googlepubsub
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"cloud.google.com/go/pubsub/v2/pstest"
+	"github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi/proto/googlepubsub"
+	"github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/e2e/googlepubsub/asyncapi/servers"
+)
+
+const project = "test-project"
+
+func TestRoundTrip(t *testing.T) {
+	ctx, server := startServer(t)
+
+	recvOp, err := operations.OpenReceiveEventGooglepubsub(ctx, server)
+	if err != nil {
+		t.Fatalf("open receive operation: %v", err)
+	}
+	defer recvOp.Close()
+	sendOp, err := operations.OpenSendEventGooglepubsub(ctx, server)
+	if err != nil {
+		t.Fatalf("open send operation: %v", err)
+	}
+	defer sendOp.Close()
+
+	for i := 1; i <= 3; i++ {
+		msg := new(messages.EventOut).SetPayload(schemas.Event{Seq: i}).SetHeaders(map[string]any{"trace": "abc"})
+		if err = sendOp.PublishEvent(ctx, msg); err != nil {
+			t.Fatalf("PublishEvent() error = %v", err)
+		}
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var got []int
+	err = recvOp.SubscribeEvent(subCtx, func(_ context.Context, message messages.EventReceiver) error {
+		got = append(got, message.Payload().Seq)
+		// Attributes from headers and from message bindings
+		for k, want := range map[string]string{"trace": "abc", "source": "e2e", "Content-Type": "application/json"} {
+			if v, _ := message.Headers()[k].([]byte); string(v) != want {
+				t.Errorf("header %q = %q, want %q", k, v, want)
+			}
+		}
+		if len(got) == 3 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("SubscribeEvent() error = %v, want context.Canceled", err)
+	}
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("received events = %v, want [1 2 3]", got)
+	}
+}
+
+func TestOrderingKeys(t *testing.T) {
+	ctx, server := startServer(t)
+
+	// Subscription must be created before publishing, otherwise it does not get the messages
+	ch, err := channels.OpenEventsGooglepubsub(ctx, server, nil, nil)
+	if err != nil {
+		t.Fatalf("open channel: %v", err)
+	}
+	defer ch.Close()
+
+	// Ordering key from message bindings, from envelope and no key
+	const n = 10
+	keys := []string{"default", "custom", ""}
+	for i := 1; i <= n; i++ {
+		for _, key := range keys {
+			envelope := googlepubsub.NewEnvelopeOut(nil)
+			if err = ch.SealEvent(envelope, new(messages.EventOut).SetPayload(schemas.Event{Seq: i})); err != nil {
+				t.Fatalf("SealEvent() error = %v", err)
+			}
+			if key != "default" {
+				envelope.SetOrderingKey(key)
+			}
+			if err = ch.Publish(ctx, envelope); err != nil {
+				t.Fatalf("Publish() error = %v", err)
+			}
+		}
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var mu sync.Mutex
+	got := make(map[string][]int)
+	total := 0
+	err = ch.Subscribe(subCtx, func(envelope googlepubsub.EnvelopeReader) {
+		in := envelope.(*googlepubsub.EnvelopeIn)
+		var m messages.EventIn
+		if err := ch.UnsealEvent(in, &m); err != nil {
+			t.Errorf("UnsealEvent() error = %v", err)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		got[in.OrderingKey] = append(got[in.OrderingKey], m.Payload().Seq)
+		if total++; total == n*len(keys) {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Subscribe() error = %v, want context.Canceled", err)
+	}
+
+	want := make([]int, 0, n)
+	for i := 1; i <= n; i++ {
+		want = append(want, i)
+	}
+	for _, key := range []string{"default", "custom"} {
+		if !slices.Equal(got[key], want) {
+			t.Errorf("events with ordering key %q = %v, want %v", key, got[key], want)
+		}
+	}
+	if len(got[""]) != n {
+		t.Errorf("got %d events without ordering key, want %d", len(got[""]), n)
+	}
+}
+
+// startServer runs the pstest server with the topic and returns the connection to it.
+func startServer(t *testing.T) (context.Context, *servers.Main) {
+	t.Helper()
+	srv := pstest.NewServer()
+	t.Cleanup(func() { _ = srv.Close() })
+
+	ctx := t.Context()
+	// Not a Google API host, so the client connects to the server as to emulator
+	u := &url.URL{Scheme: "googlepubsub", Host: srv.Addr, Path: "/projects/" + project}
+	conn, err := servers.ConnectMainBidi(ctx, u)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	client := conn.Producer().(*googlepubsub.Client)
+	topic := &pubsubpb.Topic{Name: "projects/" + project + "/topics/" + channels.EventsAddress().String()}
+	if _, err = client.TopicAdminClient.CreateTopic(ctx, topic); err != nil {
+		t.Fatalf("create topic: %v", err)
+	}
+	return ctx, &conn.Main
+}
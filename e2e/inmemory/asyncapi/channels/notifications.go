@@ -0,0 +1,276 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package channels
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+func NotificationsAddress() run.ParamString {
+	return run.ParamString{
+		Expr: "notifications",
+	}
+}
+
+type NotificationsBindings struct{}
+
+func (c NotificationsBindings) AMQP() amqp.ChannelBindings {
+	return amqp.ChannelBindings{
+		Is: amqp.ChannelTypeRoutingKey,
+		ExchangeConfiguration: amqp.ExchangeConfiguration{
+			Name: run.ToPtr("notifications"),
+			Type: amqp.ExchangeTypeFanout,
+		},
+	}
+}
+
+func NewNotificationsAMQP(
+
+	publisher amqp.Publisher,
+	subscriber amqp.Subscriber,
+	opts ...run.MiddlewareOption,
+) *NotificationsAMQP {
+	res := NotificationsAMQP{
+		address: NotificationsAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+
+	bindings := NotificationsBindings{}.AMQP()
+	switch bindings.Is {
+	case amqp.ChannelTypeQueue:
+		res.queue = res.address.String()
+	default:
+		res.routingKey = res.address.String()
+	}
+	if bindings.ExchangeConfiguration.Name != nil {
+		res.exchange = *bindings.ExchangeConfiguration.Name
+	}
+	if bindings.QueueConfiguration.Name != "" {
+		res.queue = bindings.QueueConfiguration.Name
+	}
+	return &res
+}
+
+type NotificationsServerAMQP interface {
+	OpenNotificationsAMQP(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*NotificationsAMQP, error)
+	Producer() amqp.Producer
+	Consumer() amqp.Consumer
+}
+
+func OpenNotificationsAMQP(
+	ctx context.Context,
+	server NotificationsServerAMQP,
+
+	opBindings *amqp.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*NotificationsAMQP, error) {
+	var err error
+	chBindings := NotificationsBindings{}.AMQP()
+	address, err := NotificationsAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher amqp.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			&chBindings,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber amqp.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			&chBindings,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewNotificationsAMQP(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type NotificationsAMQP struct {
+	address     run.ParamString
+	publisher   amqp.Publisher
+	subscriber  amqp.Subscriber
+	middlewares run.Middlewares
+	exchange    string
+	queue       string
+	routingKey  string
+}
+
+func (c NotificationsAMQP) Exchange() string {
+	return c.exchange
+}
+
+func (c NotificationsAMQP) Queue() string {
+	return c.queue
+}
+
+func (c NotificationsAMQP) RoutingKey() string {
+	return c.routingKey
+}
+
+func (c NotificationsAMQP) Address() run.ParamString {
+	return c.address
+}
+func (c NotificationsAMQP) Bindings() amqp.ChannelBindings {
+	return NotificationsBindings{}.AMQP()
+}
+
+func (c NotificationsAMQP) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type NotificationsEnvelopeMarshalerAMQP interface {
+	MarshalNotificationsAMQP(envelope amqp.EnvelopeWriter) error
+}
+
+func (c NotificationsAMQP) SealEvent(
+	envelope amqp.EnvelopeWriter,
+	message NotificationsEnvelopeMarshalerAMQP,
+) error {
+	if err := message.MarshalNotificationsAMQP(envelope); err != nil {
+		return err
+	}
+
+	envelope.SetRoutingKey(c.RoutingKey())
+	return nil
+}
+
+func (c NotificationsAMQP) PublishEvent(
+	ctx context.Context,
+
+	message NotificationsEnvelopeMarshalerAMQP,
+) error {
+	envelope := amqp.NewEnvelopeOut(nil)
+	if err := c.SealEvent(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c NotificationsAMQP) PublishEnvelope(ctx context.Context, envelope amqp.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope amqp.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c NotificationsAMQP) Publisher() amqp.Publisher {
+	return c.publisher
+}
+
+func (c NotificationsAMQP) Publish(ctx context.Context, envelopes ...amqp.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type NotificationsEnvelopeUnmarshalerAMQP interface {
+	UnmarshalNotificationsAMQP(envelope amqp.EnvelopeReader) error
+}
+
+func (c NotificationsAMQP) UnsealEvent(
+	envelope amqp.EnvelopeReader,
+	message NotificationsEnvelopeUnmarshalerAMQP,
+) error {
+	return message.UnmarshalNotificationsAMQP(envelope)
+}
+
+// SubscribeEvent receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c NotificationsAMQP) SubscribeEvent(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.EventReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope amqp.EnvelopeReader, message any) error {
+		m := message.(*messages.EventIn)
+		if err2 := c.UnsealEvent(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope amqp.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) amqp.EnvelopeReader {
+				return &notificationsAMQPBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope amqp.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.EventIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// notificationsAMQPBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type notificationsAMQPBufferedEnvelope struct {
+	amqp.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *notificationsAMQPBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *notificationsAMQPBufferedEnvelope) Unwrap() amqp.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c NotificationsAMQP) Subscriber() amqp.Subscriber {
+	return c.subscriber
+}
+
+func (c NotificationsAMQP) Subscribe(ctx context.Context, cb func(envelope amqp.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
@@ -0,0 +1,285 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package channels
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/parameters"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+type OrdersParameters struct {
+	Region parameters.Region
+}
+
+func OrdersAddress(params OrdersParameters) run.ParamString {
+	paramMap := map[string]string{
+		"region": string(params.Region),
+	}
+	return run.ParamString{
+		Expr:       "orders.{region}.created",
+		Parameters: paramMap,
+	}
+}
+
+type OrdersBindings struct{}
+
+func (c OrdersBindings) AMQP() amqp.ChannelBindings {
+	return amqp.ChannelBindings{
+		Is: amqp.ChannelTypeRoutingKey,
+		ExchangeConfiguration: amqp.ExchangeConfiguration{
+			Name: run.ToPtr("orders"),
+			Type: amqp.ExchangeTypeTopic,
+		},
+	}
+}
+
+func NewOrdersAMQP(
+	params OrdersParameters,
+	publisher amqp.Publisher,
+	subscriber amqp.Subscriber,
+	opts ...run.MiddlewareOption,
+) *OrdersAMQP {
+	res := OrdersAMQP{
+		address: OrdersAddress(params), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+
+	bindings := OrdersBindings{}.AMQP()
+	switch bindings.Is {
+	case amqp.ChannelTypeQueue:
+		res.queue = res.address.String()
+	default:
+		res.routingKey = res.address.String()
+	}
+	if bindings.ExchangeConfiguration.Name != nil {
+		res.exchange = *bindings.ExchangeConfiguration.Name
+	}
+	if bindings.QueueConfiguration.Name != "" {
+		res.queue = bindings.QueueConfiguration.Name
+	}
+	return &res
+}
+
+type OrdersServerAMQP interface {
+	OpenOrdersAMQP(context.Context, OrdersParameters, run.AnySecurityScheme, ...run.MiddlewareOption) (*OrdersAMQP, error)
+	Producer() amqp.Producer
+	Consumer() amqp.Consumer
+}
+
+func OpenOrdersAMQP(
+	ctx context.Context,
+	server OrdersServerAMQP,
+	params OrdersParameters,
+	opBindings *amqp.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*OrdersAMQP, error) {
+	var err error
+	chBindings := OrdersBindings{}.AMQP()
+	address, err := OrdersAddress(params).Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher amqp.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			&chBindings,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber amqp.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			&chBindings,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewOrdersAMQP(
+		params,
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type OrdersAMQP struct {
+	address     run.ParamString
+	publisher   amqp.Publisher
+	subscriber  amqp.Subscriber
+	middlewares run.Middlewares
+	exchange    string
+	queue       string
+	routingKey  string
+}
+
+func (c OrdersAMQP) Exchange() string {
+	return c.exchange
+}
+
+func (c OrdersAMQP) Queue() string {
+	return c.queue
+}
+
+func (c OrdersAMQP) RoutingKey() string {
+	return c.routingKey
+}
+
+func (c OrdersAMQP) Address() run.ParamString {
+	return c.address
+}
+func (c OrdersAMQP) Bindings() amqp.ChannelBindings {
+	return OrdersBindings{}.AMQP()
+}
+
+func (c OrdersAMQP) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type OrdersEnvelopeMarshalerAMQP interface {
+	MarshalOrdersAMQP(envelope amqp.EnvelopeWriter) error
+}
+
+func (c OrdersAMQP) SealEvent(
+	envelope amqp.EnvelopeWriter,
+	message OrdersEnvelopeMarshalerAMQP,
+) error {
+	if err := message.MarshalOrdersAMQP(envelope); err != nil {
+		return err
+	}
+
+	envelope.SetRoutingKey(c.RoutingKey())
+	return nil
+}
+
+func (c OrdersAMQP) PublishEvent(
+	ctx context.Context,
+
+	message OrdersEnvelopeMarshalerAMQP,
+) error {
+	envelope := amqp.NewEnvelopeOut(nil)
+	if err := c.SealEvent(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c OrdersAMQP) PublishEnvelope(ctx context.Context, envelope amqp.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope amqp.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c OrdersAMQP) Publisher() amqp.Publisher {
+	return c.publisher
+}
+
+func (c OrdersAMQP) Publish(ctx context.Context, envelopes ...amqp.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type OrdersEnvelopeUnmarshalerAMQP interface {
+	UnmarshalOrdersAMQP(envelope amqp.EnvelopeReader) error
+}
+
+func (c OrdersAMQP) UnsealEvent(
+	envelope amqp.EnvelopeReader,
+	message OrdersEnvelopeUnmarshalerAMQP,
+) error {
+	return message.UnmarshalOrdersAMQP(envelope)
+}
+
+// SubscribeEvent receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c OrdersAMQP) SubscribeEvent(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.EventReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope amqp.EnvelopeReader, message any) error {
+		m := message.(*messages.EventIn)
+		if err2 := c.UnsealEvent(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope amqp.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) amqp.EnvelopeReader {
+				return &ordersAMQPBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope amqp.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.EventIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// ordersAMQPBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type ordersAMQPBufferedEnvelope struct {
+	amqp.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *ordersAMQPBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *ordersAMQPBufferedEnvelope) Unwrap() amqp.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c OrdersAMQP) Subscriber() amqp.Subscriber {
+	return c.subscriber
+}
+
+func (c OrdersAMQP) Subscribe(ctx context.Context, cb func(envelope amqp.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
@@ -0,0 +1,133 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+)
+
+type EventSender interface {
+	SetPayload(payload schemas.Event) *EventOut
+	SetHeaders(headers map[string]any) *EventOut
+}
+
+// EventOut-- (Outbound Message)
+type EventOut struct {
+	Payload schemas.Event
+	Headers map[string]any
+}
+
+// Validate checks the EventOut value against the constraints from the jsonschema definition.
+func (v EventOut) Validate() error {
+	if err := v.Payload.Validate(); err != nil {
+		return fmt.Errorf("Payload: %w", err)
+	}
+	return nil
+}
+
+func (m *EventOut) SetPayload(payload schemas.Event) *EventOut {
+	m.Payload = payload
+	return m
+}
+
+func (m *EventOut) SetHeaders(headers map[string]any) *EventOut {
+	m.Headers = headers
+	return m
+}
+
+type EventReceiver interface {
+	Payload() schemas.Event
+	Headers() map[string]any
+}
+
+// EventIn-- (Inbound Message)
+type EventIn struct {
+	payload schemas.Event
+	headers map[string]any
+}
+
+// Validate checks the EventIn value against the constraints from the jsonschema definition.
+func (v EventIn) Validate() error {
+	if err := v.payload.Validate(); err != nil {
+		return fmt.Errorf("payload: %w", err)
+	}
+	return nil
+}
+
+func (m *EventIn) Payload() schemas.Event {
+	return m.payload
+}
+
+func (m *EventIn) Headers() map[string]any {
+	return m.headers
+}
+
+func (m *EventOut) MarshalNotificationsAMQP(envelope amqp.EnvelopeWriter) error {
+	return m.MarshalEnvelopeAMQP(envelope)
+}
+func (m *EventOut) MarshalOrdersAMQP(envelope amqp.EnvelopeWriter) error {
+	return m.MarshalEnvelopeAMQP(envelope)
+}
+func (m *EventOut) MarshalPaymentsAMQP(envelope amqp.EnvelopeWriter) error {
+	return m.MarshalEnvelopeAMQP(envelope)
+}
+func (m *EventOut) MarshalTasksAMQP(envelope amqp.EnvelopeWriter) error {
+	return m.MarshalEnvelopeAMQP(envelope)
+}
+
+func (m *EventOut) MarshalEnvelopeAMQP(envelope amqp.EnvelopeWriter) error {
+	if err := m.MarshalAMQP(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers(m.Headers))
+	return nil
+}
+
+func (m *EventOut) MarshalAMQP(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *EventIn) UnmarshalNotificationsAMQP(envelope amqp.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeAMQP(envelope)
+}
+func (m *EventIn) UnmarshalOrdersAMQP(envelope amqp.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeAMQP(envelope)
+}
+func (m *EventIn) UnmarshalPaymentsAMQP(envelope amqp.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeAMQP(envelope)
+}
+func (m *EventIn) UnmarshalTasksAMQP(envelope amqp.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeAMQP(envelope)
+}
+
+func (m *EventIn) UnmarshalEnvelopeAMQP(envelope amqp.EnvelopeReader) error {
+	if err := m.UnmarshalAMQP(envelope); err != nil {
+		return err
+	}
+	m.headers = map[string]any(envelope.Headers())
+	return nil
+}
+
+func (m *EventIn) UnmarshalAMQP(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
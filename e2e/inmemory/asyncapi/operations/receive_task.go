@@ -0,0 +1,123 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+type ReceiveTaskServerAMQP interface {
+	OpenTasksAMQP(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.TasksAMQP, error)
+	OpenReceiveTaskAMQP(context.Context, ...run.MiddlewareOption) (*ReceiveTaskAMQP, error)
+	Producer() amqp.Producer
+	Consumer() amqp.Consumer
+}
+
+func OpenReceiveTaskAMQP(
+	ctx context.Context,
+	server ReceiveTaskServerAMQP,
+
+	opts ...run.MiddlewareOption,
+) (*ReceiveTaskAMQP, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "tasks",
+			Operation: "receiveTask",
+			Protocol:  "amqp",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, receiveTaskAMQPMetrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenTasksAMQP(
+		run.WithOperationName(ctx, "receiveTask"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReceiveTaskAMQP{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// receiveTaskAMQPEnvelopeReader counts the payload bytes read from the envelope.
+type receiveTaskAMQPEnvelopeReader struct {
+	amqp.EnvelopeReader
+	size int
+}
+
+func (e *receiveTaskAMQPEnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// receiveTaskAMQPMetrics returns the middleware that reports the received messages metrics.
+func receiveTaskAMQPMetrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[amqp.EnvelopeReader]) run.SubscribeHandler[amqp.EnvelopeReader] {
+		return func(ctx context.Context, envelope amqp.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.EventIn:
+				labels.Message = "event"
+			}
+			counter := &receiveTaskAMQPEnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type ReceiveTaskChannelAMQP interface {
+	Close() error
+
+	SealEvent(amqp.EnvelopeWriter, channels.TasksEnvelopeMarshalerAMQP) error
+	PublishEvent(context.Context, channels.TasksEnvelopeMarshalerAMQP) error
+
+	UnsealEvent(amqp.EnvelopeReader, channels.TasksEnvelopeUnmarshalerAMQP) error
+	SubscribeEvent(context.Context, func(context.Context, messages.EventReceiver) error) error
+}
+
+type ReceiveTaskAMQP struct {
+	Channel      ReceiveTaskChannelAMQP
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c ReceiveTaskAMQP) Close() error {
+	return c.Channel.Close()
+}
+
+func (o ReceiveTaskAMQP) UnsealEvent(
+	envelope amqp.EnvelopeReader,
+	message channels.TasksEnvelopeUnmarshalerAMQP,
+) error {
+	return o.Channel.UnsealEvent(envelope, message)
+}
+
+func (o ReceiveTaskAMQP) SubscribeEvent(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.EventReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeEvent(ctx, cb)
+}
@@ -0,0 +1,120 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/run"
+	"time"
+)
+
+type SendNotificationServerAMQP interface {
+	OpenNotificationsAMQP(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.NotificationsAMQP, error)
+	OpenSendNotificationAMQP(context.Context, ...run.MiddlewareOption) (*SendNotificationAMQP, error)
+	Producer() amqp.Producer
+	Consumer() amqp.Consumer
+}
+
+func OpenSendNotificationAMQP(
+	ctx context.Context,
+	server SendNotificationServerAMQP,
+
+	opts ...run.MiddlewareOption,
+) (*SendNotificationAMQP, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "notifications",
+			Operation: "sendNotification",
+			Protocol:  "amqp",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenNotificationsAMQP(
+		run.WithOperationName(ctx, "sendNotification"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendNotificationAMQP{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// sendNotificationAMQPEnvelopeWriter counts the payload bytes written to the envelope.
+type sendNotificationAMQPEnvelopeWriter struct {
+	amqp.EnvelopeWriter
+	size int
+}
+
+func (e *sendNotificationAMQPEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type SendNotificationChannelAMQP interface {
+	Close() error
+
+	SealEvent(amqp.EnvelopeWriter, channels.NotificationsEnvelopeMarshalerAMQP) error
+	PublishEvent(context.Context, channels.NotificationsEnvelopeMarshalerAMQP) error
+
+	UnsealEvent(amqp.EnvelopeReader, channels.NotificationsEnvelopeUnmarshalerAMQP) error
+	SubscribeEvent(context.Context, func(context.Context, messages.EventReceiver) error) error
+	PublishEnvelope(context.Context, amqp.EnvelopeWriter, any) error
+}
+
+type SendNotificationAMQP struct {
+	Channel      SendNotificationChannelAMQP
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c SendNotificationAMQP) Close() error {
+	return c.Channel.Close()
+}
+
+func (o SendNotificationAMQP) SealEvent(
+	envelope amqp.EnvelopeWriter,
+	message channels.NotificationsEnvelopeMarshalerAMQP,
+) error {
+	return o.Channel.SealEvent(envelope, message)
+}
+
+func (o SendNotificationAMQP) PublishEvent(
+	ctx context.Context,
+
+	message channels.NotificationsEnvelopeMarshalerAMQP,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishEvent(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "event"
+	envelope := amqp.NewEnvelopeOut(nil)
+	counter := &sendNotificationAMQPEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealEvent(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
@@ -0,0 +1,121 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/run"
+	"time"
+)
+
+type SendOrderServerAMQP interface {
+	OpenOrdersAMQP(context.Context, channels.OrdersParameters, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersAMQP, error)
+	OpenSendOrderAMQP(context.Context, channels.OrdersParameters, ...run.MiddlewareOption) (*SendOrderAMQP, error)
+	Producer() amqp.Producer
+	Consumer() amqp.Consumer
+}
+
+func OpenSendOrderAMQP(
+	ctx context.Context,
+	server SendOrderServerAMQP,
+	params channels.OrdersParameters,
+
+	opts ...run.MiddlewareOption,
+) (*SendOrderAMQP, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "sendOrder",
+			Protocol:  "amqp",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenOrdersAMQP(
+		run.WithOperationName(ctx, "sendOrder"),
+		server,
+		params,
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendOrderAMQP{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// sendOrderAMQPEnvelopeWriter counts the payload bytes written to the envelope.
+type sendOrderAMQPEnvelopeWriter struct {
+	amqp.EnvelopeWriter
+	size int
+}
+
+func (e *sendOrderAMQPEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type SendOrderChannelAMQP interface {
+	Close() error
+
+	SealEvent(amqp.EnvelopeWriter, channels.OrdersEnvelopeMarshalerAMQP) error
+	PublishEvent(context.Context, channels.OrdersEnvelopeMarshalerAMQP) error
+
+	UnsealEvent(amqp.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerAMQP) error
+	SubscribeEvent(context.Context, func(context.Context, messages.EventReceiver) error) error
+	PublishEnvelope(context.Context, amqp.EnvelopeWriter, any) error
+}
+
+type SendOrderAMQP struct {
+	Channel      SendOrderChannelAMQP
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c SendOrderAMQP) Close() error {
+	return c.Channel.Close()
+}
+
+func (o SendOrderAMQP) SealEvent(
+	envelope amqp.EnvelopeWriter,
+	message channels.OrdersEnvelopeMarshalerAMQP,
+) error {
+	return o.Channel.SealEvent(envelope, message)
+}
+
+func (o SendOrderAMQP) PublishEvent(
+	ctx context.Context,
+
+	message channels.OrdersEnvelopeMarshalerAMQP,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishEvent(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "event"
+	envelope := amqp.NewEnvelopeOut(nil)
+	counter := &sendOrderAMQPEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealEvent(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
@@ -0,0 +1,120 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/run"
+	"time"
+)
+
+type SendPaymentServerAMQP interface {
+	OpenPaymentsAMQP(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.PaymentsAMQP, error)
+	OpenSendPaymentAMQP(context.Context, ...run.MiddlewareOption) (*SendPaymentAMQP, error)
+	Producer() amqp.Producer
+	Consumer() amqp.Consumer
+}
+
+func OpenSendPaymentAMQP(
+	ctx context.Context,
+	server SendPaymentServerAMQP,
+
+	opts ...run.MiddlewareOption,
+) (*SendPaymentAMQP, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "payments",
+			Operation: "sendPayment",
+			Protocol:  "amqp",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenPaymentsAMQP(
+		run.WithOperationName(ctx, "sendPayment"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendPaymentAMQP{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// sendPaymentAMQPEnvelopeWriter counts the payload bytes written to the envelope.
+type sendPaymentAMQPEnvelopeWriter struct {
+	amqp.EnvelopeWriter
+	size int
+}
+
+func (e *sendPaymentAMQPEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type SendPaymentChannelAMQP interface {
+	Close() error
+
+	SealEvent(amqp.EnvelopeWriter, channels.PaymentsEnvelopeMarshalerAMQP) error
+	PublishEvent(context.Context, channels.PaymentsEnvelopeMarshalerAMQP) error
+
+	UnsealEvent(amqp.EnvelopeReader, channels.PaymentsEnvelopeUnmarshalerAMQP) error
+	SubscribeEvent(context.Context, func(context.Context, messages.EventReceiver) error) error
+	PublishEnvelope(context.Context, amqp.EnvelopeWriter, any) error
+}
+
+type SendPaymentAMQP struct {
+	Channel      SendPaymentChannelAMQP
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c SendPaymentAMQP) Close() error {
+	return c.Channel.Close()
+}
+
+func (o SendPaymentAMQP) SealEvent(
+	envelope amqp.EnvelopeWriter,
+	message channels.PaymentsEnvelopeMarshalerAMQP,
+) error {
+	return o.Channel.SealEvent(envelope, message)
+}
+
+func (o SendPaymentAMQP) PublishEvent(
+	ctx context.Context,
+
+	message channels.PaymentsEnvelopeMarshalerAMQP,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishEvent(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "event"
+	envelope := amqp.NewEnvelopeOut(nil)
+	counter := &sendPaymentAMQPEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealEvent(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
@@ -0,0 +1,120 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/run"
+	"time"
+)
+
+type SendTaskServerAMQP interface {
+	OpenTasksAMQP(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.TasksAMQP, error)
+	OpenSendTaskAMQP(context.Context, ...run.MiddlewareOption) (*SendTaskAMQP, error)
+	Producer() amqp.Producer
+	Consumer() amqp.Consumer
+}
+
+func OpenSendTaskAMQP(
+	ctx context.Context,
+	server SendTaskServerAMQP,
+
+	opts ...run.MiddlewareOption,
+) (*SendTaskAMQP, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "tasks",
+			Operation: "sendTask",
+			Protocol:  "amqp",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenTasksAMQP(
+		run.WithOperationName(ctx, "sendTask"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendTaskAMQP{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// sendTaskAMQPEnvelopeWriter counts the payload bytes written to the envelope.
+type sendTaskAMQPEnvelopeWriter struct {
+	amqp.EnvelopeWriter
+	size int
+}
+
+func (e *sendTaskAMQPEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type SendTaskChannelAMQP interface {
+	Close() error
+
+	SealEvent(amqp.EnvelopeWriter, channels.TasksEnvelopeMarshalerAMQP) error
+	PublishEvent(context.Context, channels.TasksEnvelopeMarshalerAMQP) error
+
+	UnsealEvent(amqp.EnvelopeReader, channels.TasksEnvelopeUnmarshalerAMQP) error
+	SubscribeEvent(context.Context, func(context.Context, messages.EventReceiver) error) error
+	PublishEnvelope(context.Context, amqp.EnvelopeWriter, any) error
+}
+
+type SendTaskAMQP struct {
+	Channel      SendTaskChannelAMQP
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c SendTaskAMQP) Close() error {
+	return c.Channel.Close()
+}
+
+func (o SendTaskAMQP) SealEvent(
+	envelope amqp.EnvelopeWriter,
+	message channels.TasksEnvelopeMarshalerAMQP,
+) error {
+	return o.Channel.SealEvent(envelope, message)
+}
+
+func (o SendTaskAMQP) PublishEvent(
+	ctx context.Context,
+
+	message channels.TasksEnvelopeMarshalerAMQP,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishEvent(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "event"
+	envelope := amqp.NewEnvelopeOut(nil)
+	counter := &sendTaskAMQPEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealEvent(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
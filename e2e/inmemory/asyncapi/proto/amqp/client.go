@@ -0,0 +1,68 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package amqp
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/run"
+	"github.com/bdragon300/go-asyncapi/run/inmemory"
+	"net/url"
+)
+
+// NewClient returns the client connected to the in-memory broker registered for the server URL. Producers and
+// consumers connected to the same server share the broker, so the messages sent by one are received by another.
+func NewClient(_ context.Context, serverURL *url.URL, _ *ServerBindings, _ run.AnySecurityScheme) (*Client, error) {
+	return NewBrokerClient(inmemory.Lookup(serverURL)), nil
+}
+
+// NewBrokerClient returns the client connected to the given in-memory broker.
+func NewBrokerClient(broker *inmemory.Broker) *Client {
+	return &Client{Broker: broker}
+}
+
+// Client is the producer and consumer, that sends and receives the messages through the in-memory broker.
+// Security schemes are accepted, but not checked.
+type Client struct {
+	Broker *inmemory.Broker
+}
+
+func (c *Client) Publisher(_ context.Context, address string, chb *ChannelBindings, _ *OperationBindings, _ run.AnySecurityScheme) (Publisher, error) {
+	var exchange string // By default, publish to the default exchange with empty name
+	if chb != nil {
+		exchange = run.FromPtrOrZero(chb.ExchangeConfiguration.Name)
+	}
+	return &PublishChannel{Client: c, address: address, exchange: exchange}, nil
+}
+
+func (c *Client) Subscriber(_ context.Context, address string, chb *ChannelBindings, _ *OperationBindings, _ run.AnySecurityScheme) (Subscriber, error) {
+	// Queue is bound to the exchange in the same way as the amqp091-go implementation does
+	exchange, routingKey, queue := "", address, address
+	var exchangeType ExchangeType
+	if chb != nil {
+		if chb.Is == ChannelTypeQueue {
+			routingKey = "#" // Receive all messages
+		}
+		if chb.QueueConfiguration.Name != "" {
+			queue = chb.QueueConfiguration.Name
+		}
+		exchange = run.FromPtrOrZero(chb.ExchangeConfiguration.Name)
+		exchangeType = chb.ExchangeConfiguration.Type
+	}
+	matchTopic := inmemory.MatchAMQPTopic(routingKey)
+	filter := func(msg inmemory.Message) bool {
+		if msgExchange, _ := msg.Properties["exchange"].(string); msgExchange != exchange {
+			return false
+		}
+		switch exchangeType {
+		case ExchangeTypeFanout, ExchangeTypeHeaders:
+			return true
+		case ExchangeTypeDirect:
+			return msg.Address == routingKey
+		}
+		if exchange == "" {
+			return msg.Address == queue // Default exchange routes the messages by queue name
+		}
+		return matchTopic(msg)
+	}
+	return &Subscription{Subscription: c.Broker.Subscribe(filter)}, nil
+}
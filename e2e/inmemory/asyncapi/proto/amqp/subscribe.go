@@ -0,0 +1,31 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package amqp
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/run"
+	"github.com/bdragon300/go-asyncapi/run/inmemory"
+)
+
+// Subscription receives the messages from the in-memory broker. The messages published after the subscription
+// has been created are queued, even if Receive is not called yet.
+type Subscription struct {
+	*inmemory.Subscription
+}
+
+// Receive calls cb for every received message until ctx is done or the subscription is closed.
+func (s *Subscription) Receive(ctx context.Context, cb func(envelope EnvelopeReader)) error {
+	run.NotifySubscribeReady(ctx)
+	for {
+		msg, err := s.Next(ctx)
+		switch {
+		case errors.Is(err, inmemory.ErrClosed):
+			return nil
+		case err != nil:
+			return err
+		}
+		cb(NewEnvelopeIn(msg, s.Subscription))
+	}
+}
@@ -0,0 +1,188 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package servers
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+	"net/url"
+)
+
+func MainURL() (*url.URL, error) {
+	return &url.URL{Scheme: "amqp", Host: "localhost:5672", Path: ""}, nil
+}
+
+func NewMain(producer amqp.Producer, consumer amqp.Consumer) *Main {
+	return &Main{
+		producer: producer,
+		consumer: consumer,
+	}
+}
+
+type MainClosable struct {
+	Main
+}
+
+func (c MainClosable) Close() error {
+	var err error
+	if v, ok := any(c.producer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	if v, ok := any(c.consumer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	return err
+}
+func ConnectMainBidi(
+	ctx context.Context,
+	url *url.URL,
+
+) (*MainClosable, error) {
+	var bindings *amqp.ServerBindings
+	client, err := amqp.NewClient(ctx, url, bindings, nil)
+	if err != nil {
+		return nil, err
+	}
+	producer, consumer := client, client
+	return &MainClosable{
+		Main{producer: producer, consumer: consumer},
+	}, nil
+}
+func ConnectMainProducer(
+	ctx context.Context,
+	url *url.URL,
+
+) (*MainClosable, error) {
+	var bindings *amqp.ServerBindings
+	producer, err := amqp.NewClient(ctx, url, bindings, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &MainClosable{
+		Main{producer: producer},
+	}, nil
+}
+func ConnectMainConsumer(
+	ctx context.Context,
+	url *url.URL,
+
+) (*MainClosable, error) {
+	var bindings *amqp.ServerBindings
+	consumer, err := amqp.NewClient(ctx, url, bindings, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &MainClosable{
+		Main{consumer: consumer},
+	}, nil
+}
+
+type Main struct {
+	producer amqp.Producer
+	consumer amqp.Consumer
+}
+
+func (s Main) Name() string {
+	return "Main"
+}
+
+func (s Main) Producer() amqp.Producer {
+	return s.producer
+}
+
+func (s Main) Consumer() amqp.Consumer {
+	return s.consumer
+}
+
+func (s Main) OpenNotificationsAMQP(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.NotificationsAMQP, error) {
+	return channels.OpenNotificationsAMQP(
+		ctx, s, nil, security, opts...,
+	)
+}
+func (s Main) OpenOrdersAMQP(
+	ctx context.Context,
+	params channels.OrdersParameters,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.OrdersAMQP, error) {
+	return channels.OpenOrdersAMQP(
+		ctx, s, params, nil, security, opts...,
+	)
+}
+func (s Main) OpenPaymentsAMQP(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.PaymentsAMQP, error) {
+	return channels.OpenPaymentsAMQP(
+		ctx, s, nil, security, opts...,
+	)
+}
+func (s Main) OpenTasksAMQP(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.TasksAMQP, error) {
+	return channels.OpenTasksAMQP(
+		ctx, s, nil, security, opts...,
+	)
+}
+
+func (s Main) OpenSendNotificationAMQP(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.SendNotificationAMQP, error) {
+	return operations.OpenSendNotificationAMQP(
+		ctx, s, opts...,
+	)
+}
+func (s Main) OpenSendOrderAMQP(
+	ctx context.Context,
+	params channels.OrdersParameters,
+
+	opts ...run.MiddlewareOption,
+) (*operations.SendOrderAMQP, error) {
+	return operations.OpenSendOrderAMQP(
+		ctx, s, params, opts...,
+	)
+}
+func (s Main) OpenSendPaymentAMQP(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.SendPaymentAMQP, error) {
+	return operations.OpenSendPaymentAMQP(
+		ctx, s, opts...,
+	)
+}
+func (s Main) OpenReceiveTaskAMQP(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.ReceiveTaskAMQP, error) {
+	return operations.OpenReceiveTaskAMQP(
+		ctx, s, opts...,
+	)
+}
+func (s Main) OpenSendTaskAMQP(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.SendTaskAMQP, error) {
+	return operations.OpenSendTaskAMQP(
+		ctx, s, opts...,
+	)
+}
@@ -0,0 +1,4 @@
+// Package inmemory checks the message routing of the generated AMQP in-memory implementation.
+package inmemory
+
+//go:generate go -C ../.. run ./cmd/go-asyncapi -c e2e/inmemory/go-asyncapi.yaml code -t e2e/inmemory/asyncapi -M github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi e2e/inmemory/asyncapi.yaml
@@ -0,0 +1,271 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/parameters"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/proto/amqp"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/e2e/inmemory/asyncapi/servers"
+	"github.com/bdragon300/go-asyncapi/run/inmemory"
+)
+
+// endID is the id of the last message published in test, that every subscription under test must receive.
+const endID = "end"
+
+func TestFanoutExchange(t *testing.T) {
+	t.Parallel()
+	ctx, server, broker := connect(t)
+
+	// Every subscription bound to the fanout exchange receives all messages regardless of routing key
+	first := openChannel(t, func() (*channels.NotificationsAMQP, error) { return server.OpenNotificationsAMQP(ctx, nil) })
+	second := openChannel(t, func() (*channels.NotificationsAMQP, error) { return server.OpenNotificationsAMQP(ctx, nil) })
+	payments := openChannel(t, func() (*channels.PaymentsAMQP, error) { return server.OpenPaymentsAMQP(ctx, nil) })
+
+	op, err := server.OpenSendNotificationAMQP(ctx)
+	if err != nil {
+		t.Fatalf("open operation: %v", err)
+	}
+	defer op.Close()
+	if err = op.PublishEvent(ctx, event("n1")); err != nil {
+		t.Fatalf("PublishEvent() error = %v", err)
+	}
+	publishWithRoutingKey(ctx, t, first, "anything", "n2")
+	// Message with the same routing key to another exchange
+	publishWithRoutingKey(ctx, t, payments, "notifications", "p1")
+	publishWithRoutingKey(ctx, t, first, "notifications", endID)
+
+	for _, ch := range []*channels.NotificationsAMQP{first, second} {
+		if got, want := receiveIDs(ctx, t, ch), []string{"n1", "n2"}; !slices.Equal(got, want) {
+			t.Errorf("received %v, want %v", got, want)
+		}
+	}
+	msgs := broker.AssertMessages(t, "notifications", 3, time.Second)
+	if exchange := msgs[1].Properties["exchange"]; exchange != "payments" {
+		t.Errorf("message exchange property = %v, want payments", exchange)
+	}
+}
+
+func TestTopicExchange(t *testing.T) {
+	t.Parallel()
+	ctx, server, _ := connect(t)
+
+	all := openChannel(t, func() (*channels.OrdersAMQP, error) {
+		return server.OpenOrdersAMQP(ctx, channels.OrdersParameters{Region: "*"}, nil)
+	})
+	eu := openChannel(t, func() (*channels.OrdersAMQP, error) {
+		return server.OpenOrdersAMQP(ctx, channels.OrdersParameters{Region: "eu"}, nil)
+	})
+
+	for _, region := range []parameters.Region{"eu", "us"} {
+		op, err := server.OpenSendOrderAMQP(ctx, channels.OrdersParameters{Region: region})
+		if err != nil {
+			t.Fatalf("open operation: %v", err)
+		}
+		if err = op.PublishEvent(ctx, event("o-"+string(region))); err != nil {
+			t.Fatalf("PublishEvent() error = %v", err)
+		}
+		_ = op.Close()
+	}
+	publishWithRoutingKey(ctx, t, eu, "orders.eu.cancelled", "cancelled")
+	publishWithRoutingKey(ctx, t, eu, "orders.eu.created", endID)
+
+	if got, want := receiveIDs(ctx, t, all), []string{"o-eu", "o-us"}; !slices.Equal(got, want) {
+		t.Errorf("orders.*.created received %v, want %v", got, want)
+	}
+	if got, want := receiveIDs(ctx, t, eu), []string{"o-eu"}; !slices.Equal(got, want) {
+		t.Errorf("orders.eu.created received %v, want %v", got, want)
+	}
+}
+
+func TestDirectExchange(t *testing.T) {
+	t.Parallel()
+	ctx, server, _ := connect(t)
+	payments := openChannel(t, func() (*channels.PaymentsAMQP, error) { return server.OpenPaymentsAMQP(ctx, nil) })
+
+	op, err := server.OpenSendPaymentAMQP(ctx)
+	if err != nil {
+		t.Fatalf("open operation: %v", err)
+	}
+	defer op.Close()
+	if err = op.PublishEvent(ctx, event("p1")); err != nil {
+		t.Fatalf("PublishEvent() error = %v", err)
+	}
+	// Direct exchange doesn't support wildcards
+	publishWithRoutingKey(ctx, t, payments, "payments.*", "wildcard")
+	publishWithRoutingKey(ctx, t, payments, "payments.failed", "p2")
+	publishWithRoutingKey(ctx, t, payments, "payments.done", endID)
+
+	if got, want := receiveIDs(ctx, t, payments), []string{"p1"}; !slices.Equal(got, want) {
+		t.Errorf("received %v, want %v", got, want)
+	}
+}
+
+func TestDefaultExchange(t *testing.T) {
+	t.Parallel()
+	ctx, server, _ := connect(t)
+	tasks := openChannel(t, func() (*channels.TasksAMQP, error) { return server.OpenTasksAMQP(ctx, nil) })
+	notifications := openChannel(t, func() (*channels.NotificationsAMQP, error) { return server.OpenNotificationsAMQP(ctx, nil) })
+
+	op, err := server.OpenSendTaskAMQP(ctx)
+	if err != nil {
+		t.Fatalf("open operation: %v", err)
+	}
+	defer op.Close()
+	if err = op.PublishEvent(ctx, event("t1")); err != nil {
+		t.Fatalf("PublishEvent() error = %v", err)
+	}
+	// Default exchange routes by queue name, messages to the named exchanges are not delivered to the queue
+	publishWithRoutingKey(ctx, t, notifications, "tasks", "n1")
+	publishWithRoutingKey(ctx, t, tasks, "other", "t2")
+	publishWithRoutingKey(ctx, t, tasks, "tasks", endID)
+
+	if got, want := receiveIDs(ctx, t, tasks), []string{"t1"}; !slices.Equal(got, want) {
+		t.Errorf("received %v, want %v", got, want)
+	}
+}
+
+func TestNackRequeue(t *testing.T) {
+	t.Parallel()
+	ctx, server, _ := connect(t)
+	tasks := openChannel(t, func() (*channels.TasksAMQP, error) { return server.OpenTasksAMQP(ctx, nil) })
+	for _, id := range []string{"t1", "t2"} {
+		if err := tasks.PublishEvent(ctx, event(id)); err != nil {
+			t.Fatalf("PublishEvent() error = %v", err)
+		}
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	var got []string
+	err := tasks.Subscribe(subCtx, func(envelope amqp.EnvelopeReader) {
+		env := envelope.(*amqp.EnvelopeIn)
+		var msg messages.EventIn
+		if err := tasks.UnsealEvent(env, &msg); err != nil {
+			t.Errorf("UnsealEvent() error = %v", err)
+		}
+		id := msg.Payload().ID
+		got = append(got, id)
+		switch {
+		case id == "t1" && len(got) == 1:
+			_ = env.Nack(true)
+		case id == "t2":
+			_ = env.Reject(false)
+		default:
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Subscribe() error = %v, want %v", err, context.Canceled)
+	}
+	// Requeued message goes to the end of the queue, rejected one is dropped
+	if want := []string{"t1", "t2", "t1"}; !slices.Equal(got, want) {
+		t.Errorf("received %v, want %v", got, want)
+	}
+}
+
+func TestConnectByURL(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	// Unique host isolates the broker from other tests
+	u := &url.URL{Scheme: "amqp", Host: t.Name()}
+	t.Cleanup(func() { inmemory.Register(u, nil) })
+	producer, err := servers.ConnectMainProducer(ctx, u)
+	if err != nil {
+		t.Fatalf("connect producer: %v", err)
+	}
+	defer producer.Close()
+	consumer, err := servers.ConnectMainConsumer(ctx, u)
+	if err != nil {
+		t.Fatalf("connect consumer: %v", err)
+	}
+	defer consumer.Close()
+
+	tasks := openChannel(t, func() (*channels.TasksAMQP, error) { return consumer.OpenTasksAMQP(ctx, nil) })
+	op, err := producer.OpenSendTaskAMQP(ctx)
+	if err != nil {
+		t.Fatalf("open operation: %v", err)
+	}
+	defer op.Close()
+	for _, id := range []string{"t1", endID} {
+		if err = op.PublishEvent(ctx, event(id)); err != nil {
+			t.Fatalf("PublishEvent() error = %v", err)
+		}
+	}
+
+	if got, want := receiveIDs(ctx, t, tasks), []string{"t1"}; !slices.Equal(got, want) {
+		t.Errorf("received %v, want %v", got, want)
+	}
+	inmemory.Lookup(u).AssertMessages(t, "tasks", 2, time.Second)
+}
+
+// connect returns the server connected to the in-memory broker created for the test.
+func connect(t *testing.T) (context.Context, *servers.Main, *inmemory.Broker) {
+	t.Helper()
+	broker := inmemory.NewBroker()
+	client := amqp.NewBrokerClient(broker)
+	return t.Context(), servers.NewMain(client, client), broker
+}
+
+type eventChannel interface {
+	Publish(context.Context, ...amqp.EnvelopeWriter) error
+	SubscribeEvent(context.Context, func(context.Context, messages.EventReceiver) error) error
+	Close() error
+}
+
+// openChannel opens the channel and closes it when the test ends. The channel is subscribed on opening, so it
+// receives all messages published after this call.
+func openChannel[T eventChannel](t *testing.T, open func() (T, error)) T {
+	t.Helper()
+	ch, err := open()
+	if err != nil {
+		t.Fatalf("open channel: %v", err)
+	}
+	t.Cleanup(func() { _ = ch.Close() })
+	return ch
+}
+
+// publishWithRoutingKey publishes the event with the given id through the channel, overriding the routing key.
+func publishWithRoutingKey(ctx context.Context, t *testing.T, ch eventChannel, routingKey, id string) {
+	t.Helper()
+	envelope := amqp.NewEnvelopeOut(nil)
+	if err := event(id).MarshalEnvelopeAMQP(envelope); err != nil {
+		t.Fatalf("MarshalEnvelopeAMQP() error = %v", err)
+	}
+	envelope.SetRoutingKey(routingKey)
+	if err := ch.Publish(ctx, envelope); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+}
+
+// receiveIDs returns the ids of events received by the channel until the event with endID.
+func receiveIDs(ctx context.Context, t *testing.T, ch eventChannel) []string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	errEnd := errors.New("end")
+
+	var res []string
+	err := ch.SubscribeEvent(ctx, func(_ context.Context, message messages.EventReceiver) error {
+		if id := message.Payload().ID; id != endID {
+			res = append(res, id)
+			return nil
+		}
+		return errEnd
+	})
+	if !errors.Is(err, errEnd) {
+		t.Fatalf("SubscribeEvent() error = %v, received %v", err, res)
+	}
+	return res
+}
+
+func event(id string) *messages.EventOut {
+	return new(messages.EventOut).SetPayload(schemas.Event{ID: id})
+}
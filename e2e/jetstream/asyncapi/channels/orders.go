@@ -0,0 +1,238 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package channels
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi/proto/nats"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+func OrdersAddress() run.ParamString {
+	return run.ParamString{
+		Expr: "orders.created",
+	}
+}
+
+func NewOrdersNats(
+
+	publisher nats.Publisher,
+	subscriber nats.Subscriber,
+	opts ...run.MiddlewareOption,
+) *OrdersNats {
+	res := OrdersNats{
+		address: OrdersAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+
+	res.subject = res.address.String()
+	return &res
+}
+
+type OrdersServerNats interface {
+	OpenOrdersNats(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*OrdersNats, error)
+	Producer() nats.Producer
+	Consumer() nats.Consumer
+}
+
+func OpenOrdersNats(
+	ctx context.Context,
+	server OrdersServerNats,
+
+	opBindings *nats.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*OrdersNats, error) {
+	var err error
+	address, err := OrdersAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher nats.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber nats.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewOrdersNats(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type OrdersNats struct {
+	address     run.ParamString
+	publisher   nats.Publisher
+	subscriber  nats.Subscriber
+	middlewares run.Middlewares
+	subject     string
+}
+
+func (c OrdersNats) Subject() string {
+	return c.subject
+}
+
+func (c OrdersNats) Address() run.ParamString {
+	return c.address
+}
+
+func (c OrdersNats) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type OrdersEnvelopeMarshalerNats interface {
+	MarshalOrdersNats(envelope nats.EnvelopeWriter) error
+}
+
+func (c OrdersNats) SealOrderCreated(
+	envelope nats.EnvelopeWriter,
+	message OrdersEnvelopeMarshalerNats,
+) error {
+	if err := message.MarshalOrdersNats(envelope); err != nil {
+		return err
+	}
+
+	envelope.SetSubject(c.Subject())
+	return nil
+}
+
+func (c OrdersNats) PublishOrderCreated(
+	ctx context.Context,
+
+	message OrdersEnvelopeMarshalerNats,
+) error {
+	envelope := nats.NewEnvelopeOut(nil)
+	if err := c.SealOrderCreated(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c OrdersNats) PublishEnvelope(ctx context.Context, envelope nats.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope nats.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c OrdersNats) Publisher() nats.Publisher {
+	return c.publisher
+}
+
+func (c OrdersNats) Publish(ctx context.Context, envelopes ...nats.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type OrdersEnvelopeUnmarshalerNats interface {
+	UnmarshalOrdersNats(envelope nats.EnvelopeReader) error
+}
+
+func (c OrdersNats) UnsealOrderCreated(
+	envelope nats.EnvelopeReader,
+	message OrdersEnvelopeUnmarshalerNats,
+) error {
+	return message.UnmarshalOrdersNats(envelope)
+}
+
+// SubscribeOrderCreated receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c OrdersNats) SubscribeOrderCreated(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderCreatedReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope nats.EnvelopeReader, message any) error {
+		m := message.(*messages.OrderCreatedIn)
+		if err2 := c.UnsealOrderCreated(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope nats.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) nats.EnvelopeReader {
+				return &ordersNatsBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope nats.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.OrderCreatedIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// ordersNatsBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type ordersNatsBufferedEnvelope struct {
+	nats.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *ordersNatsBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *ordersNatsBufferedEnvelope) Unwrap() nats.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c OrdersNats) Subscriber() nats.Subscriber {
+	return c.subscriber
+}
+
+func (c OrdersNats) Subscribe(ctx context.Context, cb func(envelope nats.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
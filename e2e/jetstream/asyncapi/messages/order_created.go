@@ -0,0 +1,115 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi/proto/nats"
+	"github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+)
+
+type OrderCreatedSender interface {
+	SetPayload(payload schemas.Order) *OrderCreatedOut
+	SetHeaders(headers map[string]any) *OrderCreatedOut
+}
+
+// OrderCreatedOut-- (Outbound Message)
+type OrderCreatedOut struct {
+	Payload schemas.Order
+	Headers map[string]any
+}
+
+// Validate checks the OrderCreatedOut value against the constraints from the jsonschema definition.
+func (v OrderCreatedOut) Validate() error {
+	if err := v.Payload.Validate(); err != nil {
+		return fmt.Errorf("Payload: %w", err)
+	}
+	return nil
+}
+
+func (m *OrderCreatedOut) SetPayload(payload schemas.Order) *OrderCreatedOut {
+	m.Payload = payload
+	return m
+}
+
+func (m *OrderCreatedOut) SetHeaders(headers map[string]any) *OrderCreatedOut {
+	m.Headers = headers
+	return m
+}
+
+type OrderCreatedReceiver interface {
+	Payload() schemas.Order
+	Headers() map[string]any
+}
+
+// OrderCreatedIn-- (Inbound Message)
+type OrderCreatedIn struct {
+	payload schemas.Order
+	headers map[string]any
+}
+
+// Validate checks the OrderCreatedIn value against the constraints from the jsonschema definition.
+func (v OrderCreatedIn) Validate() error {
+	if err := v.payload.Validate(); err != nil {
+		return fmt.Errorf("payload: %w", err)
+	}
+	return nil
+}
+
+func (m *OrderCreatedIn) Payload() schemas.Order {
+	return m.payload
+}
+
+func (m *OrderCreatedIn) Headers() map[string]any {
+	return m.headers
+}
+
+func (m *OrderCreatedOut) MarshalOrdersNats(envelope nats.EnvelopeWriter) error {
+	return m.MarshalEnvelopeNats(envelope)
+}
+
+func (m *OrderCreatedOut) MarshalEnvelopeNats(envelope nats.EnvelopeWriter) error {
+	if err := m.MarshalNats(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers(m.Headers))
+	return nil
+}
+
+func (m *OrderCreatedOut) MarshalNats(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *OrderCreatedIn) UnmarshalOrdersNats(envelope nats.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeNats(envelope)
+}
+
+func (m *OrderCreatedIn) UnmarshalEnvelopeNats(envelope nats.EnvelopeReader) error {
+	if err := m.UnmarshalNats(envelope); err != nil {
+		return err
+	}
+	m.headers = map[string]any(envelope.Headers())
+	return nil
+}
+
+func (m *OrderCreatedIn) UnmarshalNats(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi/proto/nats"
+	"github.com/bdragon300/go-asyncapi/run"
+	"time"
+)
+
+type PublishOrderServerNats interface {
+	OpenOrdersNats(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersNats, error)
+	OpenPublishOrderNats(context.Context, ...run.MiddlewareOption) (*PublishOrderNats, error)
+	Producer() nats.Producer
+	Consumer() nats.Consumer
+}
+
+func OpenPublishOrderNats(
+	ctx context.Context,
+	server PublishOrderServerNats,
+
+	opts ...run.MiddlewareOption,
+) (*PublishOrderNats, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "publishOrder",
+			Protocol:  "nats",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenOrdersNats(
+		run.WithOperationName(ctx, "publishOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PublishOrderNats{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// publishOrderNatsEnvelopeWriter counts the payload bytes written to the envelope.
+type publishOrderNatsEnvelopeWriter struct {
+	nats.EnvelopeWriter
+	size int
+}
+
+func (e *publishOrderNatsEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type PublishOrderChannelNats interface {
+	Close() error
+
+	SealOrderCreated(nats.EnvelopeWriter, channels.OrdersEnvelopeMarshalerNats) error
+	PublishOrderCreated(context.Context, channels.OrdersEnvelopeMarshalerNats) error
+
+	UnsealOrderCreated(nats.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerNats) error
+	SubscribeOrderCreated(context.Context, func(context.Context, messages.OrderCreatedReceiver) error) error
+	PublishEnvelope(context.Context, nats.EnvelopeWriter, any) error
+}
+
+type PublishOrderNats struct {
+	Channel      PublishOrderChannelNats
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c PublishOrderNats) Close() error {
+	return c.Channel.Close()
+}
+
+func (o PublishOrderNats) SealOrderCreated(
+	envelope nats.EnvelopeWriter,
+	message channels.OrdersEnvelopeMarshalerNats,
+) error {
+	return o.Channel.SealOrderCreated(envelope, message)
+}
+
+func (o PublishOrderNats) PublishOrderCreated(
+	ctx context.Context,
+
+	message channels.OrdersEnvelopeMarshalerNats,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishOrderCreated(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "orderCreated"
+	envelope := nats.NewEnvelopeOut(nil)
+	counter := &publishOrderNatsEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealOrderCreated(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
@@ -0,0 +1,123 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi/proto/nats"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+type ShipOrdersServerNats interface {
+	OpenOrdersNats(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersNats, error)
+	OpenShipOrdersNats(context.Context, ...run.MiddlewareOption) (*ShipOrdersNats, error)
+	Producer() nats.Producer
+	Consumer() nats.Consumer
+}
+
+func OpenShipOrdersNats(
+	ctx context.Context,
+	server ShipOrdersServerNats,
+
+	opts ...run.MiddlewareOption,
+) (*ShipOrdersNats, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "shipOrders",
+			Protocol:  "nats",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, shipOrdersNatsMetrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenOrdersNats(
+		run.WithOperationName(ctx, "shipOrders"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ShipOrdersNats{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// shipOrdersNatsEnvelopeReader counts the payload bytes read from the envelope.
+type shipOrdersNatsEnvelopeReader struct {
+	nats.EnvelopeReader
+	size int
+}
+
+func (e *shipOrdersNatsEnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// shipOrdersNatsMetrics returns the middleware that reports the received messages metrics.
+func shipOrdersNatsMetrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[nats.EnvelopeReader]) run.SubscribeHandler[nats.EnvelopeReader] {
+		return func(ctx context.Context, envelope nats.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.OrderCreatedIn:
+				labels.Message = "orderCreated"
+			}
+			counter := &shipOrdersNatsEnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type ShipOrdersChannelNats interface {
+	Close() error
+
+	SealOrderCreated(nats.EnvelopeWriter, channels.OrdersEnvelopeMarshalerNats) error
+	PublishOrderCreated(context.Context, channels.OrdersEnvelopeMarshalerNats) error
+
+	UnsealOrderCreated(nats.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerNats) error
+	SubscribeOrderCreated(context.Context, func(context.Context, messages.OrderCreatedReceiver) error) error
+}
+
+type ShipOrdersNats struct {
+	Channel      ShipOrdersChannelNats
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c ShipOrdersNats) Close() error {
+	return c.Channel.Close()
+}
+
+func (o ShipOrdersNats) UnsealOrderCreated(
+	envelope nats.EnvelopeReader,
+	message channels.OrdersEnvelopeUnmarshalerNats,
+) error {
+	return o.Channel.UnsealOrderCreated(envelope, message)
+}
+
+func (o ShipOrdersNats) SubscribeOrderCreated(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderCreatedReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeOrderCreated(ctx, cb)
+}
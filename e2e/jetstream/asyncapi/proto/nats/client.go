@@ -0,0 +1,139 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package nats
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	natsGo "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+func NewClient(serverURL string, security run.AnySecurityScheme, extraOpts ...natsGo.Option) (*Client, error) {
+	// Unfortunately, the official nats client doesn't accept the context object.
+	if security != nil {
+		authOpt, err := getAuth(security)
+		if err != nil {
+			return nil, err
+		}
+		extraOpts = append(extraOpts, authOpt)
+	}
+
+	conn, err := natsGo.Connect(serverURL, extraOpts...)
+	if err != nil {
+		return nil, err
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("jetstream: %w", err)
+	}
+
+	return &Client{
+		Conn:      conn,
+		JetStream: js,
+	}, nil
+}
+
+type Client struct {
+	*natsGo.Conn
+	JetStream jetstream.JetStream
+	// ConsumerConfig returns the configuration of durable consumer, that is created or updated on subscribing
+	// to the subject. The operation is the name of AsyncAPI operation, that subscribes. If nil, DefaultConsumerConfig
+	// is used.
+	ConsumerConfig func(operation, subject string, opBindings *OperationBindings) jetstream.ConsumerConfig
+}
+
+func (c *Client) Subscriber(ctx context.Context, address string, chb *ChannelBindings, opb *OperationBindings, security run.AnySecurityScheme) (Subscriber, error) {
+	if security != nil {
+		return nil, fmt.Errorf("operation security schemes are not supported")
+	}
+
+	stream, err := c.JetStream.StreamNameBySubject(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("find stream by subject %q: %w", address, err)
+	}
+	operation := run.OperationName(ctx)
+	consumerConfig := DefaultConsumerConfig(operation, address, opb)
+	if c.ConsumerConfig != nil {
+		consumerConfig = c.ConsumerConfig(operation, address, opb)
+	}
+	consumer, err := c.JetStream.CreateOrUpdateConsumer(ctx, stream, consumerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create or update consumer %q in stream %q: %w", consumerConfig.Durable, stream, err)
+	}
+
+	ctx2, cancel := context.WithCancel(context.Background())
+	return &Subscription{
+		Client:            c,
+		Subject:           address,
+		Consumer:          consumer,
+		channelBindings:   chb,
+		operationBindings: opb,
+		ctx:               ctx2,
+		cancel:            cancel,
+	}, nil
+}
+
+func (c *Client) Publisher(_ context.Context, _ string, chb *ChannelBindings, opb *OperationBindings, security run.AnySecurityScheme) (Publisher, error) {
+	if security != nil {
+		return nil, fmt.Errorf("operation security schemes are not supported")
+	}
+
+	ctx2, cancel := context.WithCancel(context.Background())
+	return &PublishChannel{
+		Client:            c,
+		channelBindings:   chb,
+		operationBindings: opb,
+		ctx:               ctx2,
+		cancel:            cancel,
+	}, nil
+}
+
+func (c *Client) Close() error {
+	if err := c.Conn.Drain(); err != nil {
+		return fmt.Errorf("drain: %w", err)
+	}
+	c.Conn.Close()
+	return nil
+}
+
+// DefaultConsumerConfig returns the durable pull consumer configuration with explicit acknowledgement, filtered by
+// subject. Consumer name is the queue from operation bindings if set, so the subscribers with the same queue share
+// the messages. Otherwise, the name is made from the operation name and the subject, so every operation receives
+// all messages.
+func DefaultConsumerConfig(operation, subject string, opBindings *OperationBindings) jetstream.ConsumerConfig {
+	name := subject
+	switch {
+	case opBindings != nil && opBindings.Queue != "":
+		name = opBindings.Queue
+	case operation != "":
+		name = operation + "_" + subject
+	}
+	return jetstream.ConsumerConfig{
+		Durable:       consumerNameReplacer.Replace(name),
+		FilterSubject: subject,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+	}
+}
+
+// consumerNameReplacer replaces the characters that are not allowed in consumer name.
+var consumerNameReplacer = strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_", "/", "_", "\\", "_")
+
+func getAuth(security run.AnySecurityScheme) (natsGo.Option, error) {
+	switch v := security.(type) {
+	case run.UserPasswordSecurity:
+		u, p := v.UserPassword()
+		return natsGo.UserInfo(u, p), nil
+	case run.APIKeySecurity:
+		k := v.APIKey()
+		return natsGo.Token(k), nil
+	}
+	return nil, fmt.Errorf("unsupported security scheme: %v", security.AuthType())
+}
@@ -0,0 +1,149 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package nats
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	natsGo "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+func NewEnvelopeOut(buf []byte) *EnvelopeOut {
+	m := natsGo.NewMsg("")
+	m.Data = buf
+	return &EnvelopeOut{Msg: m}
+}
+
+type EnvelopeOut struct {
+	*natsGo.Msg
+	messageBindings MessageBindings
+}
+
+func (e *EnvelopeOut) Write(p []byte) (n int, err error) {
+	e.Data = append(e.Data, p...)
+	return len(p), nil
+}
+
+func (e *EnvelopeOut) ResetPayload() {
+	e.Data = e.Data[:0]
+}
+
+func (e *EnvelopeOut) SetHeaders(headers run.Headers) {
+	if e.Header == nil {
+		e.Header = natsGo.Header{}
+	}
+	for k, v := range headers.ToByteValues() {
+		e.Header.Set(k, string(v))
+	}
+}
+
+func (e *EnvelopeOut) SetContentType(contentType string) {
+	if e.Header == nil {
+		e.Header = natsGo.Header{}
+	}
+	e.Header.Set("Content-Type", contentType)
+}
+
+func (e *EnvelopeOut) SetBindings(bindings MessageBindings) {
+	e.messageBindings = bindings
+}
+
+func (e *EnvelopeOut) SetSubject(subject string) {
+	e.Subject = subject
+}
+
+// MessageID returns the message id from Nats-Msg-Id header.
+func (e *EnvelopeOut) MessageID() string {
+	return e.Header.Get(natsGo.MsgIdHdr)
+}
+
+func NewEnvelopeIn(msg jetstream.Msg) *EnvelopeIn {
+	return &EnvelopeIn{
+		Msg: msg,
+		rd:  bytes.NewReader(msg.Data()),
+	}
+}
+
+type EnvelopeIn struct {
+	jetstream.Msg
+	rd      io.Reader
+	settled bool
+}
+
+func (e *EnvelopeIn) Read(p []byte) (n int, err error) {
+	return e.rd.Read(p)
+}
+
+// MessageID returns the message id from Nats-Msg-Id header.
+func (e *EnvelopeIn) MessageID() string {
+	return e.Msg.Headers().Get(natsGo.MsgIdHdr)
+}
+
+func (e *EnvelopeIn) Headers() run.Headers {
+	h := e.Msg.Headers()
+	if h == nil {
+		return run.Headers{}
+	}
+	hdrs := make(run.Headers, len(h))
+	for k, v := range h {
+		if len(v) > 0 {
+			hdrs[k] = []byte(v[0])
+		}
+	}
+	return hdrs
+}
+
+// Ack acknowledges the message.
+func (e *EnvelopeIn) Ack() error {
+	e.settled = true
+	return e.Msg.Ack()
+}
+
+// Nak negatively acknowledges the message, so the server redelivers it.
+func (e *EnvelopeIn) Nak() error {
+	e.settled = true
+	return e.Msg.Nak()
+}
+
+// Term tells the server to never redeliver the message.
+func (e *EnvelopeIn) Term() error {
+	e.settled = true
+	return e.Msg.Term()
+}
+
+// InProgress tells the server that the message is still being processed, resetting the redelivery timer.
+func (e *EnvelopeIn) InProgress() error {
+	return e.Msg.InProgress()
+}
+
+// DeliveryAttempt returns the number of deliveries of the message from the message metadata.
+func (e *EnvelopeIn) DeliveryAttempt() (int, bool) {
+	md, err := e.Msg.Metadata()
+	if err != nil {
+		return 0, false
+	}
+	return int(md.NumDelivered), true
+}
+
+// Redeliver negatively acknowledges the message, so the server redelivers it after the delay.
+func (e *EnvelopeIn) Redeliver(delay time.Duration) error {
+	e.settled = true
+	return e.Msg.NakWithDelay(delay)
+}
+
+// DeadLetter terminates the message, so the server never redelivers it.
+func (e *EnvelopeIn) DeadLetter() error {
+	return e.Term()
+}
+
+// Settled returns true if the message has been acknowledged or rejected by Ack, Nak or Term.
+func (e *EnvelopeIn) Settled() bool {
+	return e.settled
+}
@@ -0,0 +1,41 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package nats
+
+import (
+	"context"
+	"fmt"
+)
+
+type PublishChannel struct {
+	Client *Client
+
+	channelBindings   *ChannelBindings
+	operationBindings *OperationBindings
+	ctx               context.Context
+	cancel            context.CancelFunc
+}
+
+// Send publishes the envelopes to the JetStream one by one, waiting for the acknowledgement from the server for
+// each of them.
+func (p PublishChannel) Send(ctx context.Context, envelopes ...EnvelopeWriter) error {
+	for _, env := range envelopes {
+		msg := env.(*EnvelopeOut).Msg
+		if _, err := p.Client.JetStream.PublishMsg(ctx, msg); err != nil {
+			return fmt.Errorf("publish to %q: %w", msg.Subject, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.ctx.Done():
+			return p.ctx.Err()
+		default:
+		}
+	}
+	return nil
+}
+
+func (p PublishChannel) Close() error {
+	p.cancel()
+	return nil
+}
@@ -0,0 +1,73 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package nats
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type Subscription struct {
+	Client   *Client
+	Subject  string
+	Consumer jetstream.Consumer
+
+	channelBindings   *ChannelBindings
+	operationBindings *OperationBindings
+	ctx               context.Context
+	cancel            context.CancelFunc
+}
+
+// Receive pulls the messages from the durable consumer and calls cb for each of them. The message is acknowledged
+// after cb returns, unless cb has already acknowledged or rejected it by Ack, Nak or Term methods of envelope.
+func (r *Subscription) Receive(ctx context.Context, cb func(envelope EnvelopeReader)) error {
+	iter, err := r.Consumer.Messages()
+	if err != nil {
+		return fmt.Errorf("messages: %w", err)
+	}
+	run.NotifySubscribeReady(ctx)
+
+	stopCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	go func() {
+		select {
+		case <-stopCtx.Done():
+		case <-r.ctx.Done():
+		}
+		iter.Stop()
+	}()
+
+	for {
+		msg, err := iter.Next()
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case r.ctx.Err() != nil:
+			return r.ctx.Err()
+		case errors.Is(err, jetstream.ErrMsgIteratorClosed):
+			return err
+		case err != nil:
+			return fmt.Errorf("next msg: %w", err)
+		}
+
+		envelope := NewEnvelopeIn(msg)
+		cb(envelope)
+		if !envelope.Settled() {
+			if err = envelope.Ack(); err != nil {
+				return fmt.Errorf("ack: %w", err)
+			}
+		}
+	}
+}
+
+func (r *Subscription) Close() error {
+	r.cancel()
+	return nil
+}
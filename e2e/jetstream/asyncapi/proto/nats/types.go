@@ -0,0 +1,12 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package nats
+
+type (
+	ServerBindings    struct{}
+	ChannelBindings   struct{}
+	OperationBindings struct {
+		Queue string
+	}
+	MessageBindings struct{}
+)
@@ -0,0 +1,143 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package servers
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi/proto/nats"
+	"github.com/bdragon300/go-asyncapi/run"
+	natsGo1 "github.com/nats-io/nats.go"
+	"io"
+	"net/url"
+)
+
+func MainURL() (*url.URL, error) {
+	return &url.URL{Scheme: "nats", Host: "localhost:4222", Path: ""}, nil
+}
+
+func NewMain(producer nats.Producer, consumer nats.Consumer) *Main {
+	return &Main{
+		producer: producer,
+		consumer: consumer,
+	}
+}
+
+type MainClosable struct {
+	Main
+}
+
+func (c MainClosable) Close() error {
+	var err error
+	if v, ok := any(c.producer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	if v, ok := any(c.consumer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	return err
+}
+
+func ConnectMainBidi(
+	_ context.Context,
+	url *url.URL,
+
+	opts ...natsGo1.Option,
+) (*MainClosable, error) {
+	client, err := nats.NewClient(url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	producer, consumer := client, client
+	return &MainClosable{
+		Main{producer: producer, consumer: consumer},
+	}, nil
+}
+
+func ConnectMainProducer(
+	_ context.Context,
+	url *url.URL,
+
+	opts ...natsGo1.Option,
+) (*MainClosable, error) {
+	producer, err := nats.NewClient(url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &MainClosable{
+		Main{producer: producer},
+	}, nil
+}
+
+func ConnectMainConsumer(
+	_ context.Context,
+	url *url.URL,
+
+	opts ...natsGo1.Option,
+) (*MainClosable, error) {
+	consumer, err := nats.NewClient(url.String(), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &MainClosable{
+		Main{consumer: consumer},
+	}, nil
+}
+
+type Main struct {
+	producer nats.Producer
+	consumer nats.Consumer
+}
+
+func (s Main) Name() string {
+	return "Main"
+}
+
+func (s Main) Producer() nats.Producer {
+	return s.producer
+}
+
+func (s Main) Consumer() nats.Consumer {
+	return s.consumer
+}
+
+func (s Main) OpenOrdersNats(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.OrdersNats, error) {
+	return channels.OpenOrdersNats(
+		ctx, s, nil, security, opts...,
+	)
+}
+
+func (s Main) OpenAuditOrdersNats(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.AuditOrdersNats, error) {
+	return operations.OpenAuditOrdersNats(
+		ctx, s, opts...,
+	)
+}
+func (s Main) OpenPublishOrderNats(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.PublishOrderNats, error) {
+	return operations.OpenPublishOrderNats(
+		ctx, s, opts...,
+	)
+}
+func (s Main) OpenShipOrdersNats(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.ShipOrdersNats, error) {
+	return operations.OpenShipOrdersNats(
+		ctx, s, opts...,
+	)
+}
@@ -0,0 +1,4 @@
+// Package jetstream checks the NATS JetStream implementation against the embedded nats-server.
+package jetstream
+
+//go:generate go -C ../.. run ./cmd/go-asyncapi -c e2e/jetstream/go-asyncapi.yaml code -t e2e/jetstream/asyncapi -M github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi e2e/jetstream/asyncapi.yaml
@@ -0,0 +1,313 @@
+package jetstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi/proto/nats"
+	"github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/e2e/jetstream/asyncapi/servers"
+	"github.com/bdragon300/go-asyncapi/run"
+	natsserver "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const streamName = "ORDERS"
+
+func TestPublishAck(t *testing.T) {
+	ctx, u := startServer(t)
+	// The publish operation opens the channel for both directions, and subscriber fails without the stream
+	conn, err := servers.ConnectMainProducer(ctx, u)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer conn.Close()
+	server := &conn.Main
+
+	op, err := operations.OpenPublishOrderNats(ctx, server)
+	if err != nil {
+		t.Fatalf("open operation: %v", err)
+	}
+	defer op.Close()
+
+	// No stream listens the subject, so the server does not acknowledge the message
+	if err = op.PublishOrderCreated(ctx, newOrder("1")); !errors.Is(err, jetstream.ErrNoStreamResponse) {
+		t.Fatalf("PublishOrderCreated() without stream error = %v, want %v", err, jetstream.ErrNoStreamResponse)
+	}
+
+	createStream(ctx, t, server)
+	if err = op.PublishOrderCreated(ctx, newOrder("1")); err != nil {
+		t.Fatalf("PublishOrderCreated() error = %v", err)
+	}
+	info, err := jetStream(server).Stream(ctx, streamName)
+	if err != nil {
+		t.Fatalf("get stream: %v", err)
+	}
+	if n := info.CachedInfo().State.Msgs; n != 1 {
+		t.Errorf("stream messages = %d, want 1", n)
+	}
+}
+
+func TestDurableConsumers(t *testing.T) {
+	server := connectWithStream(t)
+	ctx := t.Context()
+	publishOrders(ctx, t, server, "1", "2", "3")
+
+	// Independent operations have own durable consumers, so each of them receives all messages
+	for _, open := range []func() (orderSubscriber, error){
+		func() (orderSubscriber, error) { return operations.OpenAuditOrdersNats(ctx, server) },
+		func() (orderSubscriber, error) { return operations.OpenShipOrdersNats(ctx, server) },
+	} {
+		op, err := open()
+		if err != nil {
+			t.Fatalf("open operation: %v", err)
+		}
+		if got := receiveOrders(ctx, t, op, 3); !slices.Equal(got, []string{"1", "2", "3"}) {
+			t.Errorf("received orders = %v, want [1 2 3]", got)
+		}
+		op.Close()
+	}
+	for _, name := range []string{"auditOrders_orders_created", "shipOrders_orders_created"} {
+		if _, err := jetStream(server).Consumer(ctx, streamName, name); err != nil {
+			t.Errorf("get consumer %q: %v", name, err)
+		}
+	}
+
+	// Durable consumer resumes after the acknowledged messages
+	publishOrders(ctx, t, server, "4")
+	op, err := operations.OpenAuditOrdersNats(ctx, server)
+	if err != nil {
+		t.Fatalf("reopen operation: %v", err)
+	}
+	defer op.Close()
+	if got := receiveOrders(ctx, t, op, 1); !slices.Equal(got, []string{"4"}) {
+		t.Errorf("received orders after reopen = %v, want [4]", got)
+	}
+}
+
+func TestAcknowledgement(t *testing.T) {
+	server := connectWithStream(t)
+	ctx := t.Context()
+	publishOrders(ctx, t, server, "ack", "nak", "term", "redeliver")
+
+	ch, err := channels.OpenOrdersNats(run.WithOperationName(ctx, "manual"), server, nil, nil)
+	if err != nil {
+		t.Fatalf("open channel: %v", err)
+	}
+	defer ch.Close()
+
+	// Order id -> delivery attempts
+	deliveries := make(map[string][]int)
+	want := map[string][]int{"ack": {1}, "nak": {1, 2}, "term": {1}, "redeliver": {1, 2}}
+	subCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	err = ch.Subscribe(subCtx, func(envelope nats.EnvelopeReader) {
+		in := envelope.(*nats.EnvelopeIn)
+		var order schemas.Order
+		if err := json.Unmarshal(in.Data(), &order); err != nil {
+			t.Errorf("decode payload: %v", err)
+			return
+		}
+		attempt, ok := in.DeliveryAttempt()
+		if !ok {
+			t.Errorf("no delivery attempt for %q", order.ID)
+		}
+		deliveries[order.ID] = append(deliveries[order.ID], attempt)
+
+		if attempt == 1 {
+			switch order.ID {
+			case "nak":
+				err = in.Nak()
+			case "term":
+				err = in.Term()
+			case "redeliver":
+				err = in.Redeliver(100 * time.Millisecond)
+			}
+			if err != nil {
+				t.Errorf("settle %q: %v", order.ID, err)
+			}
+		}
+		if len(deliveries) == len(want) && len(deliveries["nak"]) == 2 && len(deliveries["redeliver"]) == 2 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Subscribe() error = %v, want context.Canceled", err)
+	}
+	for id, attempts := range want {
+		if !slices.Equal(deliveries[id], attempts) {
+			t.Errorf("order %q delivery attempts = %v, want %v", id, deliveries[id], attempts)
+		}
+	}
+
+	consumer, err := jetStream(server).Consumer(ctx, streamName, "manual_orders_created")
+	if err != nil {
+		t.Fatalf("get consumer: %v", err)
+	}
+	// Acknowledgements are asynchronous, so wait until the server handles them
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		info, err := consumer.Info(ctx)
+		if err != nil {
+			t.Fatalf("consumer info: %v", err)
+		}
+		if info.NumAckPending == 0 && info.NumPending == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("consumer has %d messages pending acknowledgement, %d messages pending", info.NumAckPending, info.NumPending)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	server := connectWithStream(t)
+	ctx := t.Context()
+	publishOrders(ctx, t, server, "fail", "ok")
+
+	const backoff = 100 * time.Millisecond
+	op, err := operations.OpenShipOrdersNats(ctx, server, run.WithRetry(run.RetryPolicy{MaxAttempts: 3, InitialBackoff: backoff}))
+	if err != nil {
+		t.Fatalf("open operation: %v", err)
+	}
+	defer op.Close()
+
+	subCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var failed []time.Time
+	var succeeded int
+	err = op.SubscribeOrderCreated(subCtx, func(_ context.Context, message messages.OrderCreatedReceiver) error {
+		if message.Payload().ID == "ok" {
+			succeeded++
+			return nil
+		}
+		if failed = append(failed, time.Now()); len(failed) == 3 {
+			// Let the server redeliver the message if it hasn't been terminated
+			time.AfterFunc(3*backoff, cancel)
+		}
+		return errors.New("handle failed")
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("SubscribeOrderCreated() error = %v, want context.Canceled", err)
+	}
+
+	// The server redelivers the message after backoff interval, that doubles with every attempt. After the last attempt
+	// the message is terminated and not redelivered anymore.
+	if len(failed) != 3 || succeeded != 1 {
+		t.Fatalf("failed message deliveries = %d, succeeded = %d, want 3 and 1", len(failed), succeeded)
+	}
+	for i, want := range []time.Duration{backoff, 2 * backoff} {
+		if got := failed[i+1].Sub(failed[i]); got < want {
+			t.Errorf("interval before attempt %d = %v, want at least %v", i+2, got, want)
+		}
+	}
+	consumer, err := jetStream(server).Consumer(ctx, streamName, "shipOrders_orders_created")
+	if err != nil {
+		t.Fatalf("get consumer: %v", err)
+	}
+	info, err := consumer.Info(ctx)
+	if err != nil {
+		t.Fatalf("consumer info: %v", err)
+	}
+	if info.NumAckPending != 0 || info.NumRedelivered != 0 {
+		t.Errorf("consumer has %d messages pending acknowledgement, %d redelivered, want 0", info.NumAckPending, info.NumRedelivered)
+	}
+}
+
+type orderSubscriber interface {
+	SubscribeOrderCreated(ctx context.Context, cb func(ctx context.Context, message messages.OrderCreatedReceiver) error) error
+	Close() error
+}
+
+// receiveOrders subscribes and returns ids of the first n received orders.
+func receiveOrders(ctx context.Context, t *testing.T, op orderSubscriber, n int) []string {
+	t.Helper()
+	subCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var mu sync.Mutex
+	var res []string
+	err := op.SubscribeOrderCreated(subCtx, func(_ context.Context, message messages.OrderCreatedReceiver) error {
+		mu.Lock()
+		defer mu.Unlock()
+		res = append(res, message.Payload().ID)
+		if len(res) == n {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("SubscribeOrderCreated() error = %v, want context.Canceled", err)
+	}
+	return res
+}
+
+func startServer(t *testing.T) (context.Context, *url.URL) {
+	t.Helper()
+	opts := natsserver.DefaultTestOptions
+	opts.Port = -1
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	srv := natsserver.RunServer(&opts)
+	t.Cleanup(srv.Shutdown)
+
+	u, err := url.Parse(srv.ClientURL())
+	if err != nil {
+		t.Fatalf("parse server url: %v", err)
+	}
+	return t.Context(), u
+}
+
+// connectWithStream starts the server, creates the stream and returns the bidirectional connection to the server.
+func connectWithStream(t *testing.T) *servers.Main {
+	t.Helper()
+	ctx, u := startServer(t)
+	conn, err := servers.ConnectMainBidi(ctx, u)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	createStream(ctx, t, &conn.Main)
+	return &conn.Main
+}
+
+func createStream(ctx context.Context, t *testing.T, server *servers.Main) {
+	t.Helper()
+	_, err := jetStream(server).CreateStream(ctx, jetstream.StreamConfig{Name: streamName, Subjects: []string{"orders.>"}})
+	if err != nil {
+		t.Fatalf("create stream: %v", err)
+	}
+}
+
+func publishOrders(ctx context.Context, t *testing.T, server *servers.Main, ids ...string) {
+	t.Helper()
+	op, err := operations.OpenPublishOrderNats(ctx, server)
+	if err != nil {
+		t.Fatalf("open operation: %v", err)
+	}
+	defer op.Close()
+	for _, id := range ids {
+		if err = op.PublishOrderCreated(ctx, newOrder(id)); err != nil {
+			t.Fatalf("publish order %q: %v", id, err)
+		}
+	}
+}
+
+func jetStream(server *servers.Main) jetstream.JetStream {
+	return server.Producer().(*nats.Client).JetStream
+}
+
+func newOrder(id string) *messages.OrderCreatedOut {
+	return new(messages.OrderCreatedOut).SetPayload(schemas.Order{ID: id})
+}
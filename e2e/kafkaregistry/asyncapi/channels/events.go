@@ -0,0 +1,242 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package channels
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/kafkaregistry/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/kafkaregistry/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+func EventsAddress() run.ParamString {
+	return run.ParamString{
+		Expr: "events",
+	}
+}
+
+func NewEventsKafka(
+
+	publisher kafka.Publisher,
+	subscriber kafka.Subscriber,
+	opts ...run.MiddlewareOption,
+) *EventsKafka {
+	res := EventsKafka{
+		address: EventsAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+
+	res.topic = res.address.String()
+	return &res
+}
+
+type EventsServerKafka interface {
+	OpenEventsKafka(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*EventsKafka, error)
+	Producer() kafka.Producer
+	Consumer() kafka.Consumer
+}
+
+func OpenEventsKafka(
+	ctx context.Context,
+	server EventsServerKafka,
+
+	opBindings *kafka.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*EventsKafka, error) {
+	var err error
+	address, err := EventsAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher kafka.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber kafka.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewEventsKafka(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type EventsKafka struct {
+	address     run.ParamString
+	publisher   kafka.Publisher
+	subscriber  kafka.Subscriber
+	middlewares run.Middlewares
+	topic       string
+}
+
+func (c EventsKafka) Topic() string {
+	return c.topic
+}
+
+func (c EventsKafka) Address() run.ParamString {
+	return c.address
+}
+
+func (c EventsKafka) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type EventsEnvelopeMarshalerKafka interface {
+	MarshalEventsKafka(envelope kafka.EnvelopeWriter) error
+}
+
+func (c EventsKafka) SealEvent(
+	envelope kafka.EnvelopeWriter,
+	message EventsEnvelopeMarshalerKafka,
+) error {
+	if err := message.MarshalEventsKafka(envelope); err != nil {
+		return err
+	}
+
+	envelope.SetTopic(c.Topic())
+	envelope.SetBindings(messages.EventBindings{}.Kafka())
+	return nil
+}
+
+func (c EventsKafka) PublishEvent(
+	ctx context.Context,
+
+	message EventsEnvelopeMarshalerKafka,
+) error {
+	envelope := kafka.NewEnvelopeOut(nil)
+	if err := c.SealEvent(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c EventsKafka) PublishEnvelope(ctx context.Context, envelope kafka.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope kafka.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c EventsKafka) Publisher() kafka.Publisher {
+	return c.publisher
+}
+
+func (c EventsKafka) Publish(ctx context.Context, envelopes ...kafka.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type EventsEnvelopeUnmarshalerKafka interface {
+	UnmarshalEventsKafka(envelope kafka.EnvelopeReader) error
+}
+
+func (c EventsKafka) UnsealEvent(
+	envelope kafka.EnvelopeReader,
+	message EventsEnvelopeUnmarshalerKafka,
+) error {
+	if err := envelope.VerifyBindings(messages.EventBindings{}.Kafka()); err != nil {
+		return err
+	}
+	return message.UnmarshalEventsKafka(envelope)
+}
+
+// SubscribeEvent receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c EventsKafka) SubscribeEvent(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.EventReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope kafka.EnvelopeReader, message any) error {
+		m := message.(*messages.EventIn)
+		if err2 := c.UnsealEvent(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope kafka.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) kafka.EnvelopeReader {
+				return &eventsKafkaBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope kafka.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.EventIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// eventsKafkaBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type eventsKafkaBufferedEnvelope struct {
+	kafka.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *eventsKafkaBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *eventsKafkaBufferedEnvelope) Unwrap() kafka.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c EventsKafka) Subscriber() kafka.Subscriber {
+	return c.subscriber
+}
+
+func (c EventsKafka) Subscribe(ctx context.Context, cb func(envelope kafka.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
@@ -0,0 +1,134 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package messages
+
+import (
+	"encoding/json"
+	"github.com/bdragon300/go-asyncapi/e2e/kafkaregistry/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+)
+
+type EventBindings struct{}
+
+func (c EventBindings) Kafka() kafka.MessageBindings {
+	return kafka.MessageBindings{
+
+		SchemaIDLocation: "header",
+	}
+}
+
+type EventSender interface {
+	SetPayload(payload struct {
+		Name string `avro:"name" json:"name"`
+	}) *EventOut
+	SetHeaders(headers map[string]any) *EventOut
+}
+
+// EventOut-- (Outbound Message)
+type EventOut struct {
+	Payload struct {
+		Name string `avro:"name" json:"name"`
+	}
+	Headers map[string]any
+}
+
+// Validate checks the EventOut value against the constraints from the jsonschema definition.
+func (v EventOut) Validate() error {
+	return nil
+}
+
+func (m *EventOut) SetPayload(payload struct {
+	Name string `avro:"name" json:"name"`
+}) *EventOut {
+	m.Payload = payload
+	return m
+}
+
+func (m *EventOut) SetHeaders(headers map[string]any) *EventOut {
+	m.Headers = headers
+	return m
+}
+
+type EventReceiver interface {
+	Payload() struct {
+		Name string `avro:"name" json:"name"`
+	}
+	Headers() map[string]any
+}
+
+// EventIn-- (Inbound Message)
+type EventIn struct {
+	payload struct {
+		Name string `avro:"name" json:"name"`
+	}
+	headers map[string]any
+}
+
+// Validate checks the EventIn value against the constraints from the jsonschema definition.
+func (v EventIn) Validate() error {
+	return nil
+}
+
+func (m *EventIn) Payload() struct {
+	Name string `avro:"name" json:"name"`
+} {
+	return m.payload
+}
+
+func (m *EventIn) Headers() map[string]any {
+	return m.headers
+}
+
+func (m *EventOut) MarshalEventsKafka(envelope kafka.EnvelopeWriter) error {
+	return m.MarshalEnvelopeKafka(envelope)
+}
+
+func (m *EventOut) MarshalEnvelopeKafka(envelope kafka.EnvelopeWriter) error {
+	if err := m.MarshalKafka(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers(m.Headers))
+	return nil
+}
+
+func (m *EventOut) MarshalKafka(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+func (c EventOut) BindingsKafka() kafka.MessageBindings {
+	return EventBindings{}.Kafka()
+}
+
+func (m *EventIn) UnmarshalEventsKafka(envelope kafka.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeKafka(envelope)
+}
+
+func (m *EventIn) UnmarshalEnvelopeKafka(envelope kafka.EnvelopeReader) error {
+	if err := m.UnmarshalKafka(envelope); err != nil {
+		return err
+	}
+	m.headers = map[string]any(envelope.Headers())
+	return nil
+}
+
+func (m *EventIn) UnmarshalKafka(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
+func (c EventIn) BindingsKafka() kafka.MessageBindings {
+	return EventBindings{}.Kafka()
+}
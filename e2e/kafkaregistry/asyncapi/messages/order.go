@@ -0,0 +1,121 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package messages
+
+import (
+	"github.com/bdragon300/go-asyncapi/e2e/kafkaregistry/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+	"github.com/hamba/avro/v2"
+	"io"
+)
+
+// OrderAvroSchema is the Avro schema of Order payload.
+var OrderAvroSchema = avro.MustParse("{\"fields\":[{\"name\":\"id\",\"type\":\"string\"}],\"name\":\"Order\",\"type\":\"record\"}")
+
+type OrderSender interface {
+	SetPayload(payload struct {
+		ID string `avro:"id" json:"id"`
+	}) *OrderOut
+	SetHeaders(headers map[string]any) *OrderOut
+}
+
+// OrderOut-- (Outbound Message)
+type OrderOut struct {
+	Payload struct {
+		ID string `avro:"id" json:"id"`
+	}
+	Headers map[string]any
+}
+
+// Validate checks the OrderOut value against the constraints from the jsonschema definition.
+func (v OrderOut) Validate() error {
+	return nil
+}
+
+func (m *OrderOut) SetPayload(payload struct {
+	ID string `avro:"id" json:"id"`
+}) *OrderOut {
+	m.Payload = payload
+	return m
+}
+
+func (m *OrderOut) SetHeaders(headers map[string]any) *OrderOut {
+	m.Headers = headers
+	return m
+}
+
+type OrderReceiver interface {
+	Payload() struct {
+		ID string `avro:"id" json:"id"`
+	}
+	Headers() map[string]any
+}
+
+// OrderIn-- (Inbound Message)
+type OrderIn struct {
+	payload struct {
+		ID string `avro:"id" json:"id"`
+	}
+	headers map[string]any
+}
+
+// Validate checks the OrderIn value against the constraints from the jsonschema definition.
+func (v OrderIn) Validate() error {
+	return nil
+}
+
+func (m *OrderIn) Payload() struct {
+	ID string `avro:"id" json:"id"`
+} {
+	return m.payload
+}
+
+func (m *OrderIn) Headers() map[string]any {
+	return m.headers
+}
+
+func (m *OrderOut) MarshalOrdersKafka(envelope kafka.EnvelopeWriter) error {
+	return m.MarshalEnvelopeKafka(envelope)
+}
+
+func (m *OrderOut) MarshalEnvelopeKafka(envelope kafka.EnvelopeWriter) error {
+	if err := m.MarshalKafka(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/vnd.apache.avro")
+	envelope.SetSchema("application/vnd.apache.avro;version=1.9.0", "{\"fields\":[{\"name\":\"id\",\"type\":\"string\"}],\"name\":\"Order\",\"type\":\"record\"}")
+	envelope.SetHeaders(run.Headers(m.Headers))
+	return nil
+}
+
+func (m *OrderOut) MarshalKafka(w io.Writer) error {
+	// MIME type: application/vnd.apache.avro
+
+	enc := avro.NewEncoderForSchema(OrderAvroSchema, w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *OrderIn) UnmarshalOrdersKafka(envelope kafka.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeKafka(envelope)
+}
+
+func (m *OrderIn) UnmarshalEnvelopeKafka(envelope kafka.EnvelopeReader) error {
+	if err := m.UnmarshalKafka(envelope); err != nil {
+		return err
+	}
+	m.headers = map[string]any(envelope.Headers())
+	return nil
+}
+
+func (m *OrderIn) UnmarshalKafka(r io.Reader) error {
+	// MIME type: application/vnd.apache.avro
+
+	dec := avro.NewDecoderForSchema(OrderAvroSchema, r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
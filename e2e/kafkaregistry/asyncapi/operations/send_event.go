@@ -0,0 +1,120 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/e2e/kafkaregistry/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/kafkaregistry/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/kafkaregistry/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+	"time"
+)
+
+type SendEventServerKafka interface {
+	OpenEventsKafka(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.EventsKafka, error)
+	OpenSendEventKafka(context.Context, ...run.MiddlewareOption) (*SendEventKafka, error)
+	Producer() kafka.Producer
+	Consumer() kafka.Consumer
+}
+
+func OpenSendEventKafka(
+	ctx context.Context,
+	server SendEventServerKafka,
+
+	opts ...run.MiddlewareOption,
+) (*SendEventKafka, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "events",
+			Operation: "sendEvent",
+			Protocol:  "kafka",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenEventsKafka(
+		run.WithOperationName(ctx, "sendEvent"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendEventKafka{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// sendEventKafkaEnvelopeWriter counts the payload bytes written to the envelope.
+type sendEventKafkaEnvelopeWriter struct {
+	kafka.EnvelopeWriter
+	size int
+}
+
+func (e *sendEventKafkaEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type SendEventChannelKafka interface {
+	Close() error
+
+	SealEvent(kafka.EnvelopeWriter, channels.EventsEnvelopeMarshalerKafka) error
+	PublishEvent(context.Context, channels.EventsEnvelopeMarshalerKafka) error
+
+	UnsealEvent(kafka.EnvelopeReader, channels.EventsEnvelopeUnmarshalerKafka) error
+	SubscribeEvent(context.Context, func(context.Context, messages.EventReceiver) error) error
+	PublishEnvelope(context.Context, kafka.EnvelopeWriter, any) error
+}
+
+type SendEventKafka struct {
+	Channel      SendEventChannelKafka
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c SendEventKafka) Close() error {
+	return c.Channel.Close()
+}
+
+func (o SendEventKafka) SealEvent(
+	envelope kafka.EnvelopeWriter,
+	message channels.EventsEnvelopeMarshalerKafka,
+) error {
+	return o.Channel.SealEvent(envelope, message)
+}
+
+func (o SendEventKafka) PublishEvent(
+	ctx context.Context,
+
+	message channels.EventsEnvelopeMarshalerKafka,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishEvent(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "event"
+	envelope := kafka.NewEnvelopeOut(nil)
+	counter := &sendEventKafkaEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealEvent(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
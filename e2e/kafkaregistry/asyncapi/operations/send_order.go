@@ -0,0 +1,120 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/e2e/kafkaregistry/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/kafkaregistry/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/kafkaregistry/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+	"time"
+)
+
+type SendOrderServerKafka interface {
+	OpenOrdersKafka(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersKafka, error)
+	OpenSendOrderKafka(context.Context, ...run.MiddlewareOption) (*SendOrderKafka, error)
+	Producer() kafka.Producer
+	Consumer() kafka.Consumer
+}
+
+func OpenSendOrderKafka(
+	ctx context.Context,
+	server SendOrderServerKafka,
+
+	opts ...run.MiddlewareOption,
+) (*SendOrderKafka, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "sendOrder",
+			Protocol:  "kafka",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenOrdersKafka(
+		run.WithOperationName(ctx, "sendOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendOrderKafka{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// sendOrderKafkaEnvelopeWriter counts the payload bytes written to the envelope.
+type sendOrderKafkaEnvelopeWriter struct {
+	kafka.EnvelopeWriter
+	size int
+}
+
+func (e *sendOrderKafkaEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type SendOrderChannelKafka interface {
+	Close() error
+
+	SealOrder(kafka.EnvelopeWriter, channels.OrdersEnvelopeMarshalerKafka) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerKafka) error
+
+	UnsealOrder(kafka.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerKafka) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+	PublishEnvelope(context.Context, kafka.EnvelopeWriter, any) error
+}
+
+type SendOrderKafka struct {
+	Channel      SendOrderChannelKafka
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c SendOrderKafka) Close() error {
+	return c.Channel.Close()
+}
+
+func (o SendOrderKafka) SealOrder(
+	envelope kafka.EnvelopeWriter,
+	message channels.OrdersEnvelopeMarshalerKafka,
+) error {
+	return o.Channel.SealOrder(envelope, message)
+}
+
+func (o SendOrderKafka) PublishOrder(
+	ctx context.Context,
+
+	message channels.OrdersEnvelopeMarshalerKafka,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishOrder(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "order"
+	envelope := kafka.NewEnvelopeOut(nil)
+	counter := &sendOrderKafkaEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealOrder(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
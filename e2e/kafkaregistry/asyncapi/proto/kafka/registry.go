@@ -0,0 +1,289 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package kafka
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"mime"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sr"
+)
+
+// SchemaIDHeader is the record header the schema ID is written to (as big-endian uint32) when the message
+// schemaIdLocation binding is "header".
+const SchemaIDHeader = "schemaId"
+
+// ErrSchemaRegistry is returned when the record can not be encoded or decoded using the schema registry.
+var ErrSchemaRegistry = errors.New("schema registry")
+
+// NewSchemaRegistry returns a new schema registry client. Options are passed to the underlying franz-go client,
+// e.g. sr.URLs, sr.BasicAuth, sr.HTTPClient.
+func NewSchemaRegistry(opts ...sr.ClientOpt) (*SchemaRegistry, error) {
+	cl, err := sr.NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &SchemaRegistry{
+		Client:            cl,
+		AutoRegister:      true,
+		subjectSchemas:    make(map[string]registrySchema),
+		registeredSchemas: make(map[string]registrySchema),
+		idSchemas:         make(map[int]registrySchema),
+	}, nil
+}
+
+// SchemaRegistry is a client for the Confluent-compatible schema registry. It resolves the schema IDs for the
+// produced records and verifies the schema IDs of the consumed ones. Resolved schemas are cached.
+//
+// The lock is not held during the registry requests, so the concurrent requests for the same schema that is not
+// cached yet may be sent several times. They get the same result.
+type SchemaRegistry struct {
+	*sr.Client
+	// AutoRegister enables the registration of the message schema on produce, like the auto.register.schemas option
+	// of Confluent serializers. If disabled or the message schema definition is unknown (e.g. JSON Schema), the
+	// latest schema registered for the subject is used. Enabled by NewSchemaRegistry.
+	AutoRegister bool
+
+	mu                sync.Mutex
+	subjectSchemas    map[string]registrySchema
+	registeredSchemas map[string]registrySchema // Key is subject and schema definition
+	idSchemas         map[int]registrySchema
+}
+
+type registrySchema struct {
+	ID       int
+	Type     sr.SchemaType
+	Subjects []string
+}
+
+// verifySubject returns error if the schema is not registered for the subject derived from the topic according to
+// the lookup strategy.
+func (s registrySchema) verifySubject(topic, strategy string) error {
+	subject, err := schemaSubject(topic, strategy)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSchemaRegistry, err)
+	}
+	if !slices.Contains(s.Subjects, subject) {
+		return fmt.Errorf("%w: schema id %d is not registered for subject %q", ErrSchemaRegistry, s.ID, subject)
+	}
+	return nil
+}
+
+// Register registers the schema in registry under the given subject (or looks up the existing one) and returns
+// its ID. The records produced to this subject are encoded with this ID afterward.
+func (r *SchemaRegistry) Register(ctx context.Context, subject string, schema sr.Schema) (int, error) {
+	ss, err := r.CreateSchema(ctx, subject, schema)
+	if err != nil {
+		return 0, fmt.Errorf("%w: register schema for subject %q: %w", ErrSchemaRegistry, subject, err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subjectSchemas[subject] = registrySchema{ID: ss.ID, Type: ss.Type, Subjects: []string{subject}}
+	return ss.ID, nil
+}
+
+// EncodeRecord writes the schema ID to the record according to the message bindings. The subject is derived from
+// the record topic. If AutoRegister is enabled and the message schema definition is set, the schema is registered
+// under the subject, otherwise the latest subject schema is looked up. If the format (message schema format or
+// content type) is set, it must match the schema type in registry.
+func (r *SchemaRegistry) EncodeRecord(
+	ctx context.Context,
+	record *kgo.Record,
+	bindings MessageBindings,
+	format, definition string,
+) error {
+	subject, err := schemaSubject(record.Topic, bindings.SchemaLookupStrategy)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSchemaRegistry, err)
+	}
+	var schema registrySchema
+	if r.AutoRegister && definition != "" {
+		typ, ok := schemaTypeByFormat(format)
+		if !ok {
+			return fmt.Errorf("%w: cannot determine the schema type of format %q to register", ErrSchemaRegistry, format)
+		}
+		schema, err = r.registeredSchema(ctx, subject, sr.Schema{Schema: definition, Type: typ})
+	} else {
+		schema, err = r.subjectSchema(ctx, subject)
+	}
+	if err != nil {
+		return err
+	}
+	if typ, ok := schemaTypeByFormat(format); ok && typ != schema.Type {
+		return fmt.Errorf(
+			"%w: subject %q has schema type %s, but the message format %q implies %s",
+			ErrSchemaRegistry, subject, schema.Type, format, typ,
+		)
+	}
+
+	switch bindings.SchemaIDLocation {
+	case "", "payload":
+		switch bindings.SchemaIDPayloadEncoding {
+		case "", "confluent":
+		default:
+			return fmt.Errorf("%w: unsupported schema id payload encoding %q", ErrSchemaRegistry, bindings.SchemaIDPayloadEncoding)
+		}
+		var index []int
+		if schema.Type == sr.TypeProtobuf {
+			index = []int{0} // The first message in the proto file
+		}
+		buf, _ := new(sr.ConfluentHeader).AppendEncode(make([]byte, 0, len(record.Value)+6), schema.ID, index)
+		record.Value = append(buf, record.Value...)
+	case "header":
+		record.Headers = append(record.Headers, kgo.RecordHeader{
+			Key:   SchemaIDHeader,
+			Value: binary.BigEndian.AppendUint32(nil, uint32(schema.ID)),
+		})
+	default:
+		return fmt.Errorf("%w: unsupported schema id location %q", ErrSchemaRegistry, bindings.SchemaIDLocation)
+	}
+	return nil
+}
+
+// DecodeRecord extracts the schema ID from the record header or from the payload and verifies that the schema
+// with this ID is registered for the subject derived from the record topic according to the message bindings.
+// Returns the schema ID and the payload without the wire-format prefix.
+func (r *SchemaRegistry) DecodeRecord(ctx context.Context, record *kgo.Record, bindings MessageBindings) (int, []byte, error) {
+	schema, payload, err := r.decodeRecord(ctx, record)
+	if err != nil {
+		return 0, nil, err
+	}
+	if err = schema.verifySubject(record.Topic, bindings.SchemaLookupStrategy); err != nil {
+		return 0, nil, err
+	}
+	return schema.ID, payload, nil
+}
+
+// decodeRecord is DecodeRecord without the subject verification. Returns the schema and the payload without the
+// wire-format prefix.
+func (r *SchemaRegistry) decodeRecord(ctx context.Context, record *kgo.Record) (registrySchema, []byte, error) {
+	var id int
+	var inPayload bool
+	payload := record.Value
+	if i := slices.IndexFunc(record.Headers, func(h kgo.RecordHeader) bool { return h.Key == SchemaIDHeader }); i >= 0 {
+		h := record.Headers[i].Value
+		if len(h) != 4 {
+			return registrySchema{}, nil, fmt.Errorf("%w: bad %q header length %d", ErrSchemaRegistry, SchemaIDHeader, len(h))
+		}
+		id = int(binary.BigEndian.Uint32(h))
+	} else {
+		var err error
+		if id, payload, err = new(sr.ConfluentHeader).DecodeID(payload); err != nil {
+			return registrySchema{}, nil, fmt.Errorf("%w: decode schema id: %w", ErrSchemaRegistry, err)
+		}
+		inPayload = true
+	}
+
+	schema, err := r.idSchema(ctx, id)
+	if err != nil {
+		return registrySchema{}, nil, err
+	}
+	if inPayload && schema.Type == sr.TypeProtobuf {
+		if _, payload, err = new(sr.ConfluentHeader).DecodeIndex(payload, 0); err != nil {
+			return registrySchema{}, nil, fmt.Errorf("%w: decode protobuf message index: %w", ErrSchemaRegistry, err)
+		}
+	}
+	return schema, payload, nil
+}
+
+func (r *SchemaRegistry) subjectSchema(ctx context.Context, subject string) (registrySchema, error) {
+	r.mu.Lock()
+	s, ok := r.subjectSchemas[subject]
+	r.mu.Unlock()
+	if ok {
+		return s, nil
+	}
+
+	ss, err := r.SchemaByVersion(ctx, subject, -1)
+	if err != nil {
+		return registrySchema{}, fmt.Errorf("%w: get latest schema for subject %q: %w", ErrSchemaRegistry, subject, err)
+	}
+	s = registrySchema{ID: ss.ID, Type: ss.Type, Subjects: []string{subject}}
+	r.mu.Lock()
+	r.subjectSchemas[subject] = s
+	r.mu.Unlock()
+	return s, nil
+}
+
+func (r *SchemaRegistry) registeredSchema(ctx context.Context, subject string, schema sr.Schema) (registrySchema, error) {
+	key := subject + "\x00" + schema.Schema
+	r.mu.Lock()
+	s, ok := r.registeredSchemas[key]
+	r.mu.Unlock()
+	if ok {
+		return s, nil
+	}
+
+	// Registry returns the existing schema ID if the same schema is already registered under the subject
+	ss, err := r.CreateSchema(ctx, subject, schema)
+	if err != nil {
+		return registrySchema{}, fmt.Errorf("%w: register schema for subject %q: %w", ErrSchemaRegistry, subject, err)
+	}
+	s = registrySchema{ID: ss.ID, Type: ss.Type, Subjects: []string{subject}}
+	r.mu.Lock()
+	r.registeredSchemas[key] = s
+	r.mu.Unlock()
+	return s, nil
+}
+
+func (r *SchemaRegistry) idSchema(ctx context.Context, id int) (registrySchema, error) {
+	r.mu.Lock()
+	s, ok := r.idSchemas[id]
+	r.mu.Unlock()
+	if ok {
+		return s, nil
+	}
+
+	schema, err := r.SchemaByID(ctx, id)
+	if err != nil {
+		return registrySchema{}, fmt.Errorf("%w: get schema by id %d: %w", ErrSchemaRegistry, id, err)
+	}
+	versions, err := r.SchemaVersionsByID(ctx, id)
+	if err != nil {
+		return registrySchema{}, fmt.Errorf("%w: get subjects by schema id %d: %w", ErrSchemaRegistry, id, err)
+	}
+	s = registrySchema{ID: id, Type: schema.Type}
+	for _, v := range versions {
+		s.Subjects = append(s.Subjects, v.Subject)
+	}
+	r.mu.Lock()
+	r.idSchemas[id] = s
+	r.mu.Unlock()
+	return s, nil
+}
+
+// schemaSubject returns the registry subject name for the topic according to the lookup strategy. Strategies
+// that require the record name from schema contents are not supported.
+func schemaSubject(topic, strategy string) (string, error) {
+	switch strategy {
+	case "", "TopicNameStrategy", "TopicIdStrategy":
+		return topic + "-value", nil
+	}
+	return "", fmt.Errorf("unsupported schema lookup strategy %q", strategy)
+}
+
+// schemaTypeByFormat guesses the registry schema type by the message schema format or content type, e.g.
+// "application/vnd.apache.avro+json;version=1.9.0" is AVRO, "application/x-protobuf" is PROTOBUF,
+// "application/json" is JSON.
+func schemaTypeByFormat(format string) (sr.SchemaType, bool) {
+	mediaType, _, err := mime.ParseMediaType(format)
+	if err != nil {
+		mediaType = strings.ToLower(format)
+	}
+	switch {
+	case strings.Contains(mediaType, "avro"):
+		return sr.TypeAvro, true
+	case strings.Contains(mediaType, "protobuf"):
+		return sr.TypeProtobuf, true
+	case strings.HasSuffix(mediaType, "/json"), strings.HasSuffix(mediaType, "+json"):
+		return sr.TypeJSON, true
+	}
+	return 0, false
+}
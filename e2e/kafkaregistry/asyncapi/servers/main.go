@@ -0,0 +1,138 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package servers
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/kafkaregistry/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/kafkaregistry/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/kafkaregistry/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"io"
+	"net/url"
+)
+
+func MainURL() (*url.URL, error) {
+	return &url.URL{Scheme: "kafka", Host: "localhost:9092", Path: ""}, nil
+}
+
+func NewMain(producer kafka.Producer, consumer kafka.Consumer) *Main {
+	return &Main{
+		producer: producer,
+		consumer: consumer,
+	}
+}
+
+type MainClosable struct {
+	Main
+}
+
+func (c MainClosable) Close() error {
+	var err error
+	if v, ok := any(c.producer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	if v, ok := any(c.consumer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	return err
+}
+
+func ConnectMainBidi(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...kgo.Opt,
+) (*MainClosable, error) {
+	var bindings *kafka.ServerBindings
+	producer := kafka.NewProducer([]string{url.Host}, bindings, nil, opts...)
+	consumer := kafka.NewConsumer([]string{url.Host}, bindings, nil, opts...)
+	return &MainClosable{
+		Main{producer: producer, consumer: consumer},
+	}, nil
+}
+
+func ConnectMainProducer(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...kgo.Opt,
+) (*MainClosable, error) {
+	var bindings *kafka.ServerBindings
+	producer := kafka.NewProducer([]string{url.Host}, bindings, nil, opts...)
+	return &MainClosable{
+		Main{producer: producer},
+	}, nil
+}
+
+func ConnectMainConsumer(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...kgo.Opt,
+) (*MainClosable, error) {
+	var bindings *kafka.ServerBindings
+	consumer := kafka.NewConsumer([]string{url.Host}, bindings, nil, opts...)
+	return &MainClosable{
+		Main{consumer: consumer},
+	}, nil
+}
+
+type Main struct {
+	producer kafka.Producer
+	consumer kafka.Consumer
+}
+
+func (s Main) Name() string {
+	return "Main"
+}
+
+func (s Main) Producer() kafka.Producer {
+	return s.producer
+}
+
+func (s Main) Consumer() kafka.Consumer {
+	return s.consumer
+}
+
+func (s Main) OpenOrdersKafka(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.OrdersKafka, error) {
+	return channels.OpenOrdersKafka(
+		ctx, s, nil, security, opts...,
+	)
+}
+func (s Main) OpenEventsKafka(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.EventsKafka, error) {
+	return channels.OpenEventsKafka(
+		ctx, s, nil, security, opts...,
+	)
+}
+
+func (s Main) OpenSendOrderKafka(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.SendOrderKafka, error) {
+	return operations.OpenSendOrderKafka(
+		ctx, s, opts...,
+	)
+}
+func (s Main) OpenSendEventKafka(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.SendEventKafka, error) {
+	return operations.OpenSendEventKafka(
+		ctx, s, opts...,
+	)
+}
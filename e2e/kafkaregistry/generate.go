@@ -0,0 +1,4 @@
+// Package kafkaregistry checks the schema registry support of franz-go implementation against a fake registry.
+package kafkaregistry
+
+//go:generate go -C ../.. run ./cmd/go-asyncapi code -t e2e/kafkaregistry/asyncapi -M github.com/bdragon300/go-asyncapi/e2e/kafkaregistry/asyncapi e2e/kafkaregistry/asyncapi.yaml
@@ -0,0 +1,214 @@
+package kafkaregistry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bdragon300/go-asyncapi/e2e/kafkaregistry/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/kafkaregistry/asyncapi/proto/kafka"
+	"github.com/twmb/franz-go/pkg/sr"
+	"github.com/twmb/franz-go/pkg/sr/srfake"
+)
+
+const (
+	orderSchemaID = 7
+	eventSchemaID = 8
+)
+
+func newRegistry(t *testing.T) *kafka.SchemaRegistry {
+	t.Helper()
+	fake := srfake.New()
+	t.Cleanup(fake.Close)
+	fake.SeedSchema("orders-value", 1, orderSchemaID, sr.Schema{
+		Schema: `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"}]}`,
+		Type:   sr.TypeAvro,
+	})
+	fake.SeedSchema("events-value", 1, eventSchemaID, sr.Schema{Schema: `{"type":"object"}`, Type: sr.TypeJSON})
+	fake.SeedSchema("mismatch-value", 1, 9, sr.Schema{Schema: `{"type":"object"}`, Type: sr.TypeJSON})
+
+	registry, err := kafka.NewSchemaRegistry(sr.URLs(fake.URL()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return registry
+}
+
+func TestEncodeDecodeRecord(t *testing.T) {
+	ctx := context.Background()
+	registry := newRegistry(t)
+	registry.AutoRegister = false // Use the seeded schemas
+
+	order := messages.OrderOut{}
+	order.Payload.ID = "o1"
+	event := messages.EventOut{}
+	event.Payload.Name = "created"
+
+	tests := []struct {
+		name           string
+		topic          string
+		marshal        func(envelope *kafka.EnvelopeOut) error
+		wantFormat     string
+		wantID         int
+		wantIDInHeader bool
+	}{
+		{
+			name:  "avro schema id in payload",
+			topic: "orders",
+			marshal: func(envelope *kafka.EnvelopeOut) error {
+				return order.MarshalEnvelopeKafka(envelope)
+			},
+			wantFormat: "application/vnd.apache.avro;version=1.9.0",
+			wantID:     orderSchemaID,
+		},
+		{
+			name:  "json schema id in header",
+			topic: "events",
+			marshal: func(envelope *kafka.EnvelopeOut) error {
+				envelope.SetBindings(messages.EventBindings{}.Kafka())
+				return event.MarshalEnvelopeKafka(envelope)
+			},
+			wantFormat:     "application/json",
+			wantID:         eventSchemaID,
+			wantIDInHeader: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope := kafka.NewEnvelopeOut(nil)
+			envelope.SetTopic(tt.topic)
+			if err := tt.marshal(envelope); err != nil {
+				t.Fatal(err)
+			}
+			if envelope.Format() != tt.wantFormat {
+				t.Errorf("Format() = %q, want %q", envelope.Format(), tt.wantFormat)
+			}
+			payload := bytes.Clone(envelope.Value)
+
+			record := envelope.AsFranzGoRecord()
+			if err := registry.EncodeRecord(ctx, record, envelope.Bindings(), envelope.Format(), envelope.SchemaDefinition()); err != nil {
+				t.Fatalf("EncodeRecord() error = %v", err)
+			}
+			// The record value is left untouched if the schema id is written to the header
+			if inHeader := bytes.Equal(record.Value, payload); inHeader != tt.wantIDInHeader {
+				t.Errorf("schema id in header = %v, want %v", inHeader, tt.wantIDInHeader)
+			}
+
+			id, decoded, err := registry.DecodeRecord(ctx, record, kafka.MessageBindings{})
+			if err != nil {
+				t.Fatalf("DecodeRecord() error = %v", err)
+			}
+			if id != tt.wantID {
+				t.Errorf("DecodeRecord() id = %d, want %d", id, tt.wantID)
+			}
+			if !bytes.Equal(decoded, payload) {
+				t.Errorf("DecodeRecord() payload = %v, want %v", decoded, payload)
+			}
+		})
+	}
+}
+
+func TestEncodeRecordFormatMismatch(t *testing.T) {
+	registry := newRegistry(t)
+	registry.AutoRegister = false
+
+	order := messages.OrderOut{}
+	envelope := kafka.NewEnvelopeOut(nil)
+	envelope.SetTopic("mismatch")
+	if err := order.MarshalEnvelopeKafka(envelope); err != nil {
+		t.Fatal(err)
+	}
+	err := registry.EncodeRecord(context.Background(), envelope.AsFranzGoRecord(), envelope.Bindings(), envelope.Format(), envelope.SchemaDefinition())
+	if !errors.Is(err, kafka.ErrSchemaRegistry) {
+		t.Errorf("EncodeRecord() error = %v, want %v", err, kafka.ErrSchemaRegistry)
+	}
+}
+
+func TestDecodeRecordWrongSubject(t *testing.T) {
+	ctx := context.Background()
+	registry := newRegistry(t)
+
+	order := messages.OrderOut{}
+	envelope := kafka.NewEnvelopeOut(nil)
+	envelope.SetTopic("orders")
+	if err := order.MarshalEnvelopeKafka(envelope); err != nil {
+		t.Fatal(err)
+	}
+	record := envelope.AsFranzGoRecord()
+	if err := registry.EncodeRecord(ctx, record, envelope.Bindings(), envelope.Format(), envelope.SchemaDefinition()); err != nil {
+		t.Fatal(err)
+	}
+	record.Topic = "events"
+	if _, _, err := registry.DecodeRecord(ctx, record, kafka.MessageBindings{}); !errors.Is(err, kafka.ErrSchemaRegistry) {
+		t.Errorf("DecodeRecord() error = %v, want %v", err, kafka.ErrSchemaRegistry)
+	}
+}
+
+func TestEncodeRecordRegister(t *testing.T) {
+	ctx := context.Background()
+	registry := newRegistry(t)
+
+	order := messages.OrderOut{}
+	var ids []int
+	for range 2 {
+		envelope := kafka.NewEnvelopeOut(nil)
+		envelope.SetTopic("new-orders")
+		if err := order.MarshalEnvelopeKafka(envelope); err != nil {
+			t.Fatal(err)
+		}
+		record := envelope.AsFranzGoRecord()
+		if err := registry.EncodeRecord(ctx, record, envelope.Bindings(), envelope.Format(), envelope.SchemaDefinition()); err != nil {
+			t.Fatalf("EncodeRecord() error = %v", err)
+		}
+		id, _, err := registry.DecodeRecord(ctx, record, kafka.MessageBindings{})
+		if err != nil {
+			t.Fatalf("DecodeRecord() error = %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	ss, err := registry.SchemaByVersion(ctx, "new-orders-value", -1)
+	if err != nil {
+		t.Fatalf("schema is not registered: %v", err)
+	}
+	if ss.Type != sr.TypeAvro {
+		t.Errorf("registered schema type = %v, want %v", ss.Type, sr.TypeAvro)
+	}
+	if ids[0] != ss.ID || ids[1] != ss.ID {
+		t.Errorf("record schema ids = %v, want %d", ids, ss.ID)
+	}
+}
+
+func TestDecodeRecordLookupStrategy(t *testing.T) {
+	ctx := context.Background()
+	registry := newRegistry(t)
+
+	order := messages.OrderOut{}
+	envelope := kafka.NewEnvelopeOut(nil)
+	envelope.SetTopic("orders")
+	if err := order.MarshalEnvelopeKafka(envelope); err != nil {
+		t.Fatal(err)
+	}
+	record := envelope.AsFranzGoRecord()
+	if err := registry.EncodeRecord(ctx, record, envelope.Bindings(), envelope.Format(), envelope.SchemaDefinition()); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		strategy string
+		wantErr  bool
+	}{
+		{"TopicNameStrategy", false},
+		{"TopicIdStrategy", false},
+		{"RecordNameStrategy", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			_, _, err := registry.DecodeRecord(ctx, record, kafka.MessageBindings{SchemaLookupStrategy: tt.strategy})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DecodeRecord() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package channels
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/parameters"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+type OrdersParameters struct {
+	Region parameters.Region
+}
+
+func OrdersAddress(params OrdersParameters) run.ParamString {
+	paramMap := map[string]string{
+		"region": string(params.Region),
+	}
+	return run.ParamString{
+		Expr:       "orders.{region}",
+		Parameters: paramMap,
+	}
+}
+
+func NewOrdersKafka(
+	params OrdersParameters,
+	publisher kafka.Publisher,
+	subscriber kafka.Subscriber,
+	opts ...run.MiddlewareOption,
+) *OrdersKafka {
+	res := OrdersKafka{
+		address: OrdersAddress(params), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+
+	res.topic = res.address.String()
+	return &res
+}
+
+type OrdersServerKafka interface {
+	OpenOrdersKafka(context.Context, OrdersParameters, run.AnySecurityScheme, ...run.MiddlewareOption) (*OrdersKafka, error)
+	Producer() kafka.Producer
+	Consumer() kafka.Consumer
+}
+
+// MockOrdersServerKafka is the call-recording mock of OrdersServerKafka.
+// Methods return the values from the corresponding *Func fields if set, otherwise they return nil values.
+type MockOrdersServerKafka struct {
+	run.MockRecorder
+	OpenOrdersKafkaFunc func(ctx context.Context, params OrdersParameters, security run.AnySecurityScheme, opts ...run.MiddlewareOption) (*OrdersKafka, error)
+	ProducerFunc        func() kafka.Producer
+	ConsumerFunc        func() kafka.Consumer
+}
+
+func (m *MockOrdersServerKafka) OpenOrdersKafka(ctx context.Context, params OrdersParameters, security run.AnySecurityScheme, opts ...run.MiddlewareOption) (*OrdersKafka, error) {
+	m.Record("OpenOrdersKafka", ctx, params, security, opts)
+	if m.OpenOrdersKafkaFunc != nil {
+		return m.OpenOrdersKafkaFunc(ctx, params, security, opts...)
+	}
+	return nil, nil
+}
+
+func (m *MockOrdersServerKafka) Producer() kafka.Producer {
+	m.Record("Producer")
+	if m.ProducerFunc != nil {
+		return m.ProducerFunc()
+	}
+	return nil
+}
+
+func (m *MockOrdersServerKafka) Consumer() kafka.Consumer {
+	m.Record("Consumer")
+	if m.ConsumerFunc != nil {
+		return m.ConsumerFunc()
+	}
+	return nil
+}
+
+func OpenOrdersKafka(
+	ctx context.Context,
+	server OrdersServerKafka,
+	params OrdersParameters,
+	opBindings *kafka.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*OrdersKafka, error) {
+	var err error
+	address, err := OrdersAddress(params).Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher kafka.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber kafka.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewOrdersKafka(
+		params,
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type OrdersKafka struct {
+	address     run.ParamString
+	publisher   kafka.Publisher
+	subscriber  kafka.Subscriber
+	middlewares run.Middlewares
+	topic       string
+}
+
+func (c OrdersKafka) Topic() string {
+	return c.topic
+}
+
+func (c OrdersKafka) Address() run.ParamString {
+	return c.address
+}
+
+func (c OrdersKafka) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type OrdersEnvelopeMarshalerKafka interface {
+	MarshalOrdersKafka(envelope kafka.EnvelopeWriter) error
+}
+
+func (c OrdersKafka) SealOrder(
+	envelope kafka.EnvelopeWriter,
+	message OrdersEnvelopeMarshalerKafka,
+) error {
+	if err := message.MarshalOrdersKafka(envelope); err != nil {
+		return err
+	}
+
+	envelope.SetTopic(c.Topic())
+	return nil
+}
+
+func (c OrdersKafka) PublishOrder(
+	ctx context.Context,
+
+	message OrdersEnvelopeMarshalerKafka,
+) error {
+	envelope := kafka.NewEnvelopeOut(nil)
+	if err := c.SealOrder(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c OrdersKafka) PublishEnvelope(ctx context.Context, envelope kafka.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope kafka.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c OrdersKafka) Publisher() kafka.Publisher {
+	return c.publisher
+}
+
+func (c OrdersKafka) Publish(ctx context.Context, envelopes ...kafka.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type OrdersEnvelopeUnmarshalerKafka interface {
+	UnmarshalOrdersKafka(envelope kafka.EnvelopeReader) error
+}
+
+func (c OrdersKafka) UnsealOrder(
+	envelope kafka.EnvelopeReader,
+	message OrdersEnvelopeUnmarshalerKafka,
+) error {
+	if err := envelope.VerifyBindings(kafka.MessageBindings{}); err != nil {
+		return err
+	}
+	return message.UnmarshalOrdersKafka(envelope)
+}
+
+// SubscribeOrder receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c OrdersKafka) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope kafka.EnvelopeReader, message any) error {
+		m := message.(*messages.OrderIn)
+		if err2 := c.UnsealOrder(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope kafka.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) kafka.EnvelopeReader {
+				return &ordersKafkaBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope kafka.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.OrderIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// ordersKafkaBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type ordersKafkaBufferedEnvelope struct {
+	kafka.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *ordersKafkaBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *ordersKafkaBufferedEnvelope) Unwrap() kafka.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c OrdersKafka) Subscriber() kafka.Subscriber {
+	return c.subscriber
+}
+
+func (c OrdersKafka) Subscribe(ctx context.Context, cb func(envelope kafka.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
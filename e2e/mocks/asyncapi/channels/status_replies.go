@@ -0,0 +1,272 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package channels
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+func StatusRepliesAddress() run.ParamString {
+	return run.ParamString{
+		Expr: "status-replies",
+	}
+}
+
+func NewStatusRepliesKafka(
+
+	publisher kafka.Publisher,
+	subscriber kafka.Subscriber,
+	opts ...run.MiddlewareOption,
+) *StatusRepliesKafka {
+	res := StatusRepliesKafka{
+		address: StatusRepliesAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+
+	res.topic = res.address.String()
+	return &res
+}
+
+type StatusRepliesServerKafka interface {
+	OpenStatusRepliesKafka(context.Context, ...run.MiddlewareOption) (*StatusRepliesKafka, error)
+	Producer() kafka.Producer
+	Consumer() kafka.Consumer
+}
+
+// MockStatusRepliesServerKafka is the call-recording mock of StatusRepliesServerKafka.
+// Methods return the values from the corresponding *Func fields if set, otherwise they return nil values.
+type MockStatusRepliesServerKafka struct {
+	run.MockRecorder
+	OpenStatusRepliesKafkaFunc func(ctx context.Context, opts ...run.MiddlewareOption) (*StatusRepliesKafka, error)
+	ProducerFunc               func() kafka.Producer
+	ConsumerFunc               func() kafka.Consumer
+}
+
+func (m *MockStatusRepliesServerKafka) OpenStatusRepliesKafka(ctx context.Context, opts ...run.MiddlewareOption) (*StatusRepliesKafka, error) {
+	m.Record("OpenStatusRepliesKafka", ctx, opts)
+	if m.OpenStatusRepliesKafkaFunc != nil {
+		return m.OpenStatusRepliesKafkaFunc(ctx, opts...)
+	}
+	return nil, nil
+}
+
+func (m *MockStatusRepliesServerKafka) Producer() kafka.Producer {
+	m.Record("Producer")
+	if m.ProducerFunc != nil {
+		return m.ProducerFunc()
+	}
+	return nil
+}
+
+func (m *MockStatusRepliesServerKafka) Consumer() kafka.Consumer {
+	m.Record("Consumer")
+	if m.ConsumerFunc != nil {
+		return m.ConsumerFunc()
+	}
+	return nil
+}
+
+func OpenStatusRepliesKafka(
+	ctx context.Context,
+	server StatusRepliesServerKafka,
+
+	opts ...run.MiddlewareOption,
+) (*StatusRepliesKafka, error) {
+	var err error
+	address, err := StatusRepliesAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher kafka.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			nil,
+			nil,
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber kafka.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			nil,
+			nil,
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewStatusRepliesKafka(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type StatusRepliesKafka struct {
+	address     run.ParamString
+	publisher   kafka.Publisher
+	subscriber  kafka.Subscriber
+	middlewares run.Middlewares
+	topic       string
+}
+
+func (c StatusRepliesKafka) Topic() string {
+	return c.topic
+}
+
+func (c StatusRepliesKafka) Address() run.ParamString {
+	return c.address
+}
+
+func (c StatusRepliesKafka) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type StatusRepliesEnvelopeMarshalerKafka interface {
+	MarshalStatusRepliesKafka(envelope kafka.EnvelopeWriter) error
+}
+
+func (c StatusRepliesKafka) SealStatus(
+	envelope kafka.EnvelopeWriter,
+	message StatusRepliesEnvelopeMarshalerKafka,
+) error {
+	if err := message.MarshalStatusRepliesKafka(envelope); err != nil {
+		return err
+	}
+
+	envelope.SetTopic(c.Topic())
+	return nil
+}
+
+func (c StatusRepliesKafka) PublishStatus(
+	ctx context.Context,
+
+	message StatusRepliesEnvelopeMarshalerKafka,
+) error {
+	envelope := kafka.NewEnvelopeOut(nil)
+	if err := c.SealStatus(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c StatusRepliesKafka) PublishEnvelope(ctx context.Context, envelope kafka.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope kafka.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c StatusRepliesKafka) Publisher() kafka.Publisher {
+	return c.publisher
+}
+
+func (c StatusRepliesKafka) Publish(ctx context.Context, envelopes ...kafka.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type StatusRepliesEnvelopeUnmarshalerKafka interface {
+	UnmarshalStatusRepliesKafka(envelope kafka.EnvelopeReader) error
+}
+
+func (c StatusRepliesKafka) UnsealStatus(
+	envelope kafka.EnvelopeReader,
+	message StatusRepliesEnvelopeUnmarshalerKafka,
+) error {
+	if err := envelope.VerifyBindings(kafka.MessageBindings{}); err != nil {
+		return err
+	}
+	return message.UnmarshalStatusRepliesKafka(envelope)
+}
+
+// SubscribeStatus receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c StatusRepliesKafka) SubscribeStatus(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.StatusReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope kafka.EnvelopeReader, message any) error {
+		m := message.(*messages.StatusIn)
+		if err2 := c.UnsealStatus(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope kafka.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) kafka.EnvelopeReader {
+				return &statusRepliesKafkaBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope kafka.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.StatusIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// statusRepliesKafkaBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type statusRepliesKafkaBufferedEnvelope struct {
+	kafka.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *statusRepliesKafkaBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *statusRepliesKafkaBufferedEnvelope) Unwrap() kafka.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c StatusRepliesKafka) Subscriber() kafka.Subscriber {
+	return c.subscriber
+}
+
+func (c StatusRepliesKafka) Subscribe(ctx context.Context, cb func(envelope kafka.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
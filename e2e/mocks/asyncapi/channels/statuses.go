@@ -0,0 +1,274 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package channels
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+func StatusesAddress() run.ParamString {
+	return run.ParamString{
+		Expr: "statuses",
+	}
+}
+
+func NewStatusesKafka(
+
+	publisher kafka.Publisher,
+	subscriber kafka.Subscriber,
+	opts ...run.MiddlewareOption,
+) *StatusesKafka {
+	res := StatusesKafka{
+		address: StatusesAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+
+	res.topic = res.address.String()
+	return &res
+}
+
+type StatusesServerKafka interface {
+	OpenStatusesKafka(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*StatusesKafka, error)
+	Producer() kafka.Producer
+	Consumer() kafka.Consumer
+}
+
+// MockStatusesServerKafka is the call-recording mock of StatusesServerKafka.
+// Methods return the values from the corresponding *Func fields if set, otherwise they return nil values.
+type MockStatusesServerKafka struct {
+	run.MockRecorder
+	OpenStatusesKafkaFunc func(ctx context.Context, security run.AnySecurityScheme, opts ...run.MiddlewareOption) (*StatusesKafka, error)
+	ProducerFunc          func() kafka.Producer
+	ConsumerFunc          func() kafka.Consumer
+}
+
+func (m *MockStatusesServerKafka) OpenStatusesKafka(ctx context.Context, security run.AnySecurityScheme, opts ...run.MiddlewareOption) (*StatusesKafka, error) {
+	m.Record("OpenStatusesKafka", ctx, security, opts)
+	if m.OpenStatusesKafkaFunc != nil {
+		return m.OpenStatusesKafkaFunc(ctx, security, opts...)
+	}
+	return nil, nil
+}
+
+func (m *MockStatusesServerKafka) Producer() kafka.Producer {
+	m.Record("Producer")
+	if m.ProducerFunc != nil {
+		return m.ProducerFunc()
+	}
+	return nil
+}
+
+func (m *MockStatusesServerKafka) Consumer() kafka.Consumer {
+	m.Record("Consumer")
+	if m.ConsumerFunc != nil {
+		return m.ConsumerFunc()
+	}
+	return nil
+}
+
+func OpenStatusesKafka(
+	ctx context.Context,
+	server StatusesServerKafka,
+
+	opBindings *kafka.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*StatusesKafka, error) {
+	var err error
+	address, err := StatusesAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher kafka.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber kafka.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewStatusesKafka(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type StatusesKafka struct {
+	address     run.ParamString
+	publisher   kafka.Publisher
+	subscriber  kafka.Subscriber
+	middlewares run.Middlewares
+	topic       string
+}
+
+func (c StatusesKafka) Topic() string {
+	return c.topic
+}
+
+func (c StatusesKafka) Address() run.ParamString {
+	return c.address
+}
+
+func (c StatusesKafka) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type StatusesEnvelopeMarshalerKafka interface {
+	MarshalStatusesKafka(envelope kafka.EnvelopeWriter) error
+}
+
+func (c StatusesKafka) SealStatusRequest(
+	envelope kafka.EnvelopeWriter,
+	message StatusesEnvelopeMarshalerKafka,
+) error {
+	if err := message.MarshalStatusesKafka(envelope); err != nil {
+		return err
+	}
+
+	envelope.SetTopic(c.Topic())
+	return nil
+}
+
+func (c StatusesKafka) PublishStatusRequest(
+	ctx context.Context,
+
+	message StatusesEnvelopeMarshalerKafka,
+) error {
+	envelope := kafka.NewEnvelopeOut(nil)
+	if err := c.SealStatusRequest(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c StatusesKafka) PublishEnvelope(ctx context.Context, envelope kafka.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope kafka.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c StatusesKafka) Publisher() kafka.Publisher {
+	return c.publisher
+}
+
+func (c StatusesKafka) Publish(ctx context.Context, envelopes ...kafka.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type StatusesEnvelopeUnmarshalerKafka interface {
+	UnmarshalStatusesKafka(envelope kafka.EnvelopeReader) error
+}
+
+func (c StatusesKafka) UnsealStatusRequest(
+	envelope kafka.EnvelopeReader,
+	message StatusesEnvelopeUnmarshalerKafka,
+) error {
+	if err := envelope.VerifyBindings(kafka.MessageBindings{}); err != nil {
+		return err
+	}
+	return message.UnmarshalStatusesKafka(envelope)
+}
+
+// SubscribeStatusRequest receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c StatusesKafka) SubscribeStatusRequest(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.StatusRequestReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope kafka.EnvelopeReader, message any) error {
+		m := message.(*messages.StatusRequestIn)
+		if err2 := c.UnsealStatusRequest(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope kafka.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) kafka.EnvelopeReader {
+				return &statusesKafkaBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope kafka.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.StatusRequestIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// statusesKafkaBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type statusesKafkaBufferedEnvelope struct {
+	kafka.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *statusesKafkaBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *statusesKafkaBufferedEnvelope) Unwrap() kafka.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c StatusesKafka) Subscriber() kafka.Subscriber {
+	return c.subscriber
+}
+
+func (c StatusesKafka) Subscribe(ctx context.Context, cb func(envelope kafka.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
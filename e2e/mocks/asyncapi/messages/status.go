@@ -0,0 +1,146 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package messages
+
+import (
+	"encoding/json"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+)
+
+type StatusSender interface {
+	SetPayload(payload string) *StatusOut
+	SetHeaders(headers struct {
+		CorrelationID string `json:"correlationId"`
+	}) *StatusOut
+	SetCorrelationID(value string) *StatusOut
+}
+
+// StatusOut-- (Outbound Message)
+type StatusOut struct {
+	Payload string
+	Headers struct {
+		CorrelationID string `json:"correlationId"`
+	}
+}
+
+// Validate checks the StatusOut value against the constraints from the jsonschema definition.
+func (v StatusOut) Validate() error {
+	return nil
+}
+
+func (m *StatusOut) SetPayload(payload string) *StatusOut {
+	m.Payload = payload
+	return m
+}
+
+func (m *StatusOut) SetHeaders(headers struct {
+	CorrelationID string `json:"correlationId"`
+}) *StatusOut {
+	m.Headers = headers
+	return m
+}
+func (m *StatusOut) SetCorrelationID(value string) *StatusOut {
+	v0 := m.Headers
+
+	v0.CorrelationID = value
+	m.Headers = v0
+	return m
+}
+
+type StatusReceiver interface {
+	Payload() string
+	Headers() struct {
+		CorrelationID string `json:"correlationId"`
+	}
+	CorrelationID() (value string, err error)
+}
+
+// StatusIn-- (Inbound Message)
+type StatusIn struct {
+	payload string
+	headers struct {
+		CorrelationID string `json:"correlationId"`
+	}
+}
+
+// Validate checks the StatusIn value against the constraints from the jsonschema definition.
+func (v StatusIn) Validate() error {
+	return nil
+}
+
+func (m *StatusIn) Payload() string {
+	return m.payload
+}
+
+func (m *StatusIn) Headers() struct {
+	CorrelationID string `json:"correlationId"`
+} {
+	return m.headers
+}
+func (m StatusIn) CorrelationID() (value string, err error) {
+	v0 := m.headers
+
+	// correlationId
+	v1 := v0.CorrelationID
+	value = v1
+	return
+}
+
+func (m *StatusOut) MarshalStatusRepliesKafka(envelope kafka.EnvelopeWriter) error {
+	return m.MarshalEnvelopeKafka(envelope)
+}
+
+func (m *StatusOut) MarshalEnvelopeKafka(envelope kafka.EnvelopeWriter) error {
+	if err := m.MarshalKafka(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers{
+		"CorrelationID": m.Headers.CorrelationID,
+	})
+	return nil
+}
+
+func (m *StatusOut) MarshalKafka(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *StatusIn) UnmarshalStatusRepliesKafka(envelope kafka.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeKafka(envelope)
+}
+
+func (m *StatusIn) UnmarshalEnvelopeKafka(envelope kafka.EnvelopeReader) error {
+	if err := m.UnmarshalKafka(envelope); err != nil {
+		return err
+	}
+	headers := envelope.Headers()
+	if v, ok := headers["CorrelationID"]; ok {
+		switch tv := v.(type) {
+		case string:
+			m.headers.CorrelationID = tv
+		case []byte:
+			m.headers.CorrelationID = string(tv)
+		}
+	}
+	return nil
+}
+
+func (m *StatusIn) UnmarshalKafka(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
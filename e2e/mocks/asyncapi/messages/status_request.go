@@ -0,0 +1,154 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+)
+
+type StatusRequestSender interface {
+	SetPayload(payload schemas.Order) *StatusRequestOut
+	SetHeaders(headers struct {
+		CorrelationID string `json:"correlationId"`
+	}) *StatusRequestOut
+	SetCorrelationID(value string) *StatusRequestOut
+}
+
+// StatusRequestOut-- (Outbound Message)
+type StatusRequestOut struct {
+	Payload schemas.Order
+	Headers struct {
+		CorrelationID string `json:"correlationId"`
+	}
+}
+
+// Validate checks the StatusRequestOut value against the constraints from the jsonschema definition.
+func (v StatusRequestOut) Validate() error {
+	if err := v.Payload.Validate(); err != nil {
+		return fmt.Errorf("Payload: %w", err)
+	}
+	return nil
+}
+
+func (m *StatusRequestOut) SetPayload(payload schemas.Order) *StatusRequestOut {
+	m.Payload = payload
+	return m
+}
+
+func (m *StatusRequestOut) SetHeaders(headers struct {
+	CorrelationID string `json:"correlationId"`
+}) *StatusRequestOut {
+	m.Headers = headers
+	return m
+}
+func (m *StatusRequestOut) SetCorrelationID(value string) *StatusRequestOut {
+	v0 := m.Headers
+
+	v0.CorrelationID = value
+	m.Headers = v0
+	return m
+}
+
+type StatusRequestReceiver interface {
+	Payload() schemas.Order
+	Headers() struct {
+		CorrelationID string `json:"correlationId"`
+	}
+	CorrelationID() (value string, err error)
+}
+
+// StatusRequestIn-- (Inbound Message)
+type StatusRequestIn struct {
+	payload schemas.Order
+	headers struct {
+		CorrelationID string `json:"correlationId"`
+	}
+}
+
+// Validate checks the StatusRequestIn value against the constraints from the jsonschema definition.
+func (v StatusRequestIn) Validate() error {
+	if err := v.payload.Validate(); err != nil {
+		return fmt.Errorf("payload: %w", err)
+	}
+	return nil
+}
+
+func (m *StatusRequestIn) Payload() schemas.Order {
+	return m.payload
+}
+
+func (m *StatusRequestIn) Headers() struct {
+	CorrelationID string `json:"correlationId"`
+} {
+	return m.headers
+}
+func (m StatusRequestIn) CorrelationID() (value string, err error) {
+	v0 := m.headers
+
+	// correlationId
+	v1 := v0.CorrelationID
+	value = v1
+	return
+}
+
+func (m *StatusRequestOut) MarshalStatusesKafka(envelope kafka.EnvelopeWriter) error {
+	return m.MarshalEnvelopeKafka(envelope)
+}
+
+func (m *StatusRequestOut) MarshalEnvelopeKafka(envelope kafka.EnvelopeWriter) error {
+	if err := m.MarshalKafka(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers{
+		"CorrelationID": m.Headers.CorrelationID,
+	})
+	return nil
+}
+
+func (m *StatusRequestOut) MarshalKafka(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *StatusRequestIn) UnmarshalStatusesKafka(envelope kafka.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeKafka(envelope)
+}
+
+func (m *StatusRequestIn) UnmarshalEnvelopeKafka(envelope kafka.EnvelopeReader) error {
+	if err := m.UnmarshalKafka(envelope); err != nil {
+		return err
+	}
+	headers := envelope.Headers()
+	if v, ok := headers["CorrelationID"]; ok {
+		switch tv := v.(type) {
+		case string:
+			m.headers.CorrelationID = tv
+		case []byte:
+			m.headers.CorrelationID = string(tv)
+		}
+	}
+	return nil
+}
+
+func (m *StatusRequestIn) UnmarshalKafka(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,346 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+	"time"
+)
+
+type GetStatusServerKafka interface {
+	OpenStatusesKafka(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.StatusesKafka, error)
+	OpenGetStatusKafka(context.Context, ...run.MiddlewareOption) (*GetStatusKafka, error)
+	Producer() kafka.Producer
+	Consumer() kafka.Consumer
+}
+
+// MockGetStatusServerKafka is the call-recording mock of GetStatusServerKafka.
+// Methods return the values from the corresponding *Func fields if set, otherwise they return nil values.
+type MockGetStatusServerKafka struct {
+	run.MockRecorder
+	OpenStatusesKafkaFunc  func(ctx context.Context, security run.AnySecurityScheme, opts ...run.MiddlewareOption) (*channels.StatusesKafka, error)
+	OpenGetStatusKafkaFunc func(ctx context.Context, opts ...run.MiddlewareOption) (*GetStatusKafka, error)
+	ProducerFunc           func() kafka.Producer
+	ConsumerFunc           func() kafka.Consumer
+}
+
+func (m *MockGetStatusServerKafka) OpenStatusesKafka(ctx context.Context, security run.AnySecurityScheme, opts ...run.MiddlewareOption) (*channels.StatusesKafka, error) {
+	m.Record("OpenStatusesKafka", ctx, security, opts)
+	if m.OpenStatusesKafkaFunc != nil {
+		return m.OpenStatusesKafkaFunc(ctx, security, opts...)
+	}
+	return nil, nil
+}
+
+func (m *MockGetStatusServerKafka) OpenGetStatusKafka(ctx context.Context, opts ...run.MiddlewareOption) (*GetStatusKafka, error) {
+	m.Record("OpenGetStatusKafka", ctx, opts)
+	if m.OpenGetStatusKafkaFunc != nil {
+		return m.OpenGetStatusKafkaFunc(ctx, opts...)
+	}
+	return nil, nil
+}
+
+func (m *MockGetStatusServerKafka) Producer() kafka.Producer {
+	m.Record("Producer")
+	if m.ProducerFunc != nil {
+		return m.ProducerFunc()
+	}
+	return nil
+}
+
+func (m *MockGetStatusServerKafka) Consumer() kafka.Consumer {
+	m.Record("Consumer")
+	if m.ConsumerFunc != nil {
+		return m.ConsumerFunc()
+	}
+	return nil
+}
+
+func OpenGetStatusKafka(
+	ctx context.Context,
+	server GetStatusServerKafka,
+
+	opts ...run.MiddlewareOption,
+) (*GetStatusKafka, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "statuses",
+			Operation: "getStatus",
+			Protocol:  "kafka",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenStatusesKafka(
+		run.WithOperationName(ctx, "getStatus"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetStatusKafka{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// getStatusKafkaEnvelopeWriter counts the payload bytes written to the envelope.
+type getStatusKafkaEnvelopeWriter struct {
+	kafka.EnvelopeWriter
+	size int
+}
+
+func (e *getStatusKafkaEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type GetStatusChannelKafka interface {
+	Close() error
+
+	SealStatusRequest(kafka.EnvelopeWriter, channels.StatusesEnvelopeMarshalerKafka) error
+	PublishStatusRequest(context.Context, channels.StatusesEnvelopeMarshalerKafka) error
+
+	UnsealStatusRequest(kafka.EnvelopeReader, channels.StatusesEnvelopeUnmarshalerKafka) error
+	SubscribeStatusRequest(context.Context, func(context.Context, messages.StatusRequestReceiver) error) error
+	PublishEnvelope(context.Context, kafka.EnvelopeWriter, any) error
+}
+
+// MockGetStatusChannelKafka is the call-recording mock of GetStatusChannelKafka.
+// Methods return the values from the corresponding *Func fields if set, otherwise they return nil values.
+type MockGetStatusChannelKafka struct {
+	run.MockRecorder
+	CloseFunc                  func() error
+	SealStatusRequestFunc      func(envelope kafka.EnvelopeWriter, message channels.StatusesEnvelopeMarshalerKafka) error
+	PublishStatusRequestFunc   func(ctx context.Context, message channels.StatusesEnvelopeMarshalerKafka) error
+	UnsealStatusRequestFunc    func(envelope kafka.EnvelopeReader, message channels.StatusesEnvelopeUnmarshalerKafka) error
+	SubscribeStatusRequestFunc func(ctx context.Context, cb func(ctx context.Context, message messages.StatusRequestReceiver) error) error
+	PublishEnvelopeFunc        func(ctx context.Context, envelope kafka.EnvelopeWriter, message any) error
+}
+
+func (m *MockGetStatusChannelKafka) Close() error {
+	m.Record("Close")
+	if m.CloseFunc != nil {
+		return m.CloseFunc()
+	}
+	return nil
+}
+
+func (m *MockGetStatusChannelKafka) SealStatusRequest(envelope kafka.EnvelopeWriter, message channels.StatusesEnvelopeMarshalerKafka) error {
+	m.Record("SealStatusRequest", envelope, message)
+	if m.SealStatusRequestFunc != nil {
+		return m.SealStatusRequestFunc(envelope, message)
+	}
+	return nil
+}
+
+func (m *MockGetStatusChannelKafka) PublishStatusRequest(ctx context.Context, message channels.StatusesEnvelopeMarshalerKafka) error {
+	m.Record("PublishStatusRequest", ctx, message)
+	if m.PublishStatusRequestFunc != nil {
+		return m.PublishStatusRequestFunc(ctx, message)
+	}
+	return nil
+}
+
+func (m *MockGetStatusChannelKafka) UnsealStatusRequest(envelope kafka.EnvelopeReader, message channels.StatusesEnvelopeUnmarshalerKafka) error {
+	m.Record("UnsealStatusRequest", envelope, message)
+	if m.UnsealStatusRequestFunc != nil {
+		return m.UnsealStatusRequestFunc(envelope, message)
+	}
+	return nil
+}
+
+func (m *MockGetStatusChannelKafka) SubscribeStatusRequest(ctx context.Context, cb func(ctx context.Context, message messages.StatusRequestReceiver) error) error {
+	m.Record("SubscribeStatusRequest", ctx, cb)
+	run.NotifySubscribeReady(ctx)
+	if m.SubscribeStatusRequestFunc != nil {
+		return m.SubscribeStatusRequestFunc(ctx, cb)
+	}
+	return nil
+}
+
+func (m *MockGetStatusChannelKafka) PublishEnvelope(ctx context.Context, envelope kafka.EnvelopeWriter, message any) error {
+	m.Record("PublishEnvelope", ctx, envelope, message)
+	if m.PublishEnvelopeFunc != nil {
+		return m.PublishEnvelopeFunc(ctx, envelope, message)
+	}
+	return nil
+}
+
+type GetStatusKafka struct {
+	Channel      GetStatusChannelKafka
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c GetStatusKafka) Close() error {
+	return c.Channel.Close()
+}
+
+func (c GetStatusKafka) Reply(channel GetStatusReplyChannelKafka) *GetStatusKafkaReply {
+	return &GetStatusKafkaReply{Channel: channel}
+}
+func (o GetStatusKafka) SealStatusRequest(
+	envelope kafka.EnvelopeWriter,
+	message channels.StatusesEnvelopeMarshalerKafka,
+) error {
+	return o.Channel.SealStatusRequest(envelope, message)
+}
+
+func (o GetStatusKafka) PublishStatusRequest(
+	ctx context.Context,
+
+	message channels.StatusesEnvelopeMarshalerKafka,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishStatusRequest(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "statusRequest"
+	envelope := kafka.NewEnvelopeOut(nil)
+	counter := &getStatusKafkaEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealStatusRequest(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
+
+type GetStatusReplyChannelKafka interface {
+	Close() error
+
+	UnsealStatus(kafka.EnvelopeReader, channels.StatusRepliesEnvelopeUnmarshalerKafka) error
+	SubscribeStatus(context.Context, func(context.Context, messages.StatusReceiver) error) error
+}
+
+// MockGetStatusReplyChannelKafka is the call-recording mock of GetStatusReplyChannelKafka.
+// Methods return the values from the corresponding *Func fields if set, otherwise they return nil values.
+type MockGetStatusReplyChannelKafka struct {
+	run.MockRecorder
+	CloseFunc           func() error
+	UnsealStatusFunc    func(envelope kafka.EnvelopeReader, message channels.StatusRepliesEnvelopeUnmarshalerKafka) error
+	SubscribeStatusFunc func(ctx context.Context, cb func(ctx context.Context, message messages.StatusReceiver) error) error
+}
+
+func (m *MockGetStatusReplyChannelKafka) Close() error {
+	m.Record("Close")
+	if m.CloseFunc != nil {
+		return m.CloseFunc()
+	}
+	return nil
+}
+
+func (m *MockGetStatusReplyChannelKafka) UnsealStatus(envelope kafka.EnvelopeReader, message channels.StatusRepliesEnvelopeUnmarshalerKafka) error {
+	m.Record("UnsealStatus", envelope, message)
+	if m.UnsealStatusFunc != nil {
+		return m.UnsealStatusFunc(envelope, message)
+	}
+	return nil
+}
+
+func (m *MockGetStatusReplyChannelKafka) SubscribeStatus(ctx context.Context, cb func(ctx context.Context, message messages.StatusReceiver) error) error {
+	m.Record("SubscribeStatus", ctx, cb)
+	run.NotifySubscribeReady(ctx)
+	if m.SubscribeStatusFunc != nil {
+		return m.SubscribeStatusFunc(ctx, cb)
+	}
+	return nil
+}
+
+type GetStatusKafkaReply struct {
+	Channel GetStatusReplyChannelKafka
+}
+
+func (o GetStatusKafkaReply) UnsealStatus(
+	envelope kafka.EnvelopeReader,
+	message channels.StatusRepliesEnvelopeUnmarshalerKafka,
+) error {
+	return o.Channel.UnsealStatus(envelope, message)
+}
+
+func (o GetStatusKafkaReply) SubscribeStatus(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.StatusReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeStatus(ctx, cb)
+}
+
+// GetStatusKafkaRequester sends the requests to the operation channel and waits for the replies
+// on the reply channel, matching them to requests by correlation id.
+type GetStatusKafkaRequester struct {
+	Operation GetStatusKafka
+	Reply     *GetStatusKafkaReply
+	requests  *run.Requests[string, messages.StatusReceiver]
+}
+
+// Requester returns a new requester for this operation. The reply channel is subscribed on the first request,
+// the subscription is shared by all subsequent requests until the requester is closed.
+func (c GetStatusKafka) Requester(channel GetStatusReplyChannelKafka) *GetStatusKafkaRequester {
+	return &GetStatusKafkaRequester{
+		Operation: c,
+		Reply:     c.Reply(channel),
+		requests:  run.NewRequests[string, messages.StatusReceiver](),
+	}
+}
+
+// Close stops the reply subscription. The operation and reply channels are not closed.
+func (r *GetStatusKafkaRequester) Close() error {
+	return r.requests.Close()
+}
+
+// RequestStatusRequest sends the message with a new correlation id and waits for the reply with the same
+// correlation id until ctx is done.
+func (r *GetStatusKafkaRequester) RequestStatusRequest(
+	ctx context.Context,
+	message *messages.StatusRequestOut,
+) (messages.StatusReceiver, error) {
+	correlationID := run.NewCorrelationID()
+	message.SetCorrelationID(correlationID)
+
+	var replyAddress string
+	if v, ok := r.Reply.Channel.(interface{ Topic() string }); ok {
+		replyAddress = v.Topic()
+	}
+	envelope := kafka.NewEnvelopeOut(nil)
+	if err := r.Operation.Channel.SealStatusRequest(envelope, message); err != nil {
+		return nil, err
+	}
+
+	envelope.SetHeaders(run.Headers{kafka.ReplyTopicHeader: replyAddress})
+
+	return r.requests.Do(ctx, correlationID, r.subscribe, func(ctx context.Context) error {
+		return r.Operation.Channel.PublishEnvelope(ctx, envelope, message)
+	})
+}
+
+func (r *GetStatusKafkaRequester) subscribe(
+	ctx context.Context,
+	resolve func(correlationID string, reply messages.StatusReceiver),
+) error {
+	return r.Reply.Channel.SubscribeStatus(ctx, func(_ context.Context, message messages.StatusReceiver) error {
+		// Messages without correlation id can't be matched to any request
+		if correlationID, err := message.CorrelationID(); err == nil {
+			resolve(correlationID, message)
+		}
+		return nil
+	})
+}
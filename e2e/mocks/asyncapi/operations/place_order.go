@@ -0,0 +1,224 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+	"time"
+)
+
+type PlaceOrderServerKafka interface {
+	OpenOrdersKafka(context.Context, channels.OrdersParameters, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersKafka, error)
+	OpenPlaceOrderKafka(context.Context, channels.OrdersParameters, ...run.MiddlewareOption) (*PlaceOrderKafka, error)
+	Producer() kafka.Producer
+	Consumer() kafka.Consumer
+}
+
+// MockPlaceOrderServerKafka is the call-recording mock of PlaceOrderServerKafka.
+// Methods return the values from the corresponding *Func fields if set, otherwise they return nil values.
+type MockPlaceOrderServerKafka struct {
+	run.MockRecorder
+	OpenOrdersKafkaFunc     func(ctx context.Context, params channels.OrdersParameters, security run.AnySecurityScheme, opts ...run.MiddlewareOption) (*channels.OrdersKafka, error)
+	OpenPlaceOrderKafkaFunc func(ctx context.Context, params channels.OrdersParameters, opts ...run.MiddlewareOption) (*PlaceOrderKafka, error)
+	ProducerFunc            func() kafka.Producer
+	ConsumerFunc            func() kafka.Consumer
+}
+
+func (m *MockPlaceOrderServerKafka) OpenOrdersKafka(ctx context.Context, params channels.OrdersParameters, security run.AnySecurityScheme, opts ...run.MiddlewareOption) (*channels.OrdersKafka, error) {
+	m.Record("OpenOrdersKafka", ctx, params, security, opts)
+	if m.OpenOrdersKafkaFunc != nil {
+		return m.OpenOrdersKafkaFunc(ctx, params, security, opts...)
+	}
+	return nil, nil
+}
+
+func (m *MockPlaceOrderServerKafka) OpenPlaceOrderKafka(ctx context.Context, params channels.OrdersParameters, opts ...run.MiddlewareOption) (*PlaceOrderKafka, error) {
+	m.Record("OpenPlaceOrderKafka", ctx, params, opts)
+	if m.OpenPlaceOrderKafkaFunc != nil {
+		return m.OpenPlaceOrderKafkaFunc(ctx, params, opts...)
+	}
+	return nil, nil
+}
+
+func (m *MockPlaceOrderServerKafka) Producer() kafka.Producer {
+	m.Record("Producer")
+	if m.ProducerFunc != nil {
+		return m.ProducerFunc()
+	}
+	return nil
+}
+
+func (m *MockPlaceOrderServerKafka) Consumer() kafka.Consumer {
+	m.Record("Consumer")
+	if m.ConsumerFunc != nil {
+		return m.ConsumerFunc()
+	}
+	return nil
+}
+
+func OpenPlaceOrderKafka(
+	ctx context.Context,
+	server PlaceOrderServerKafka,
+	params channels.OrdersParameters,
+
+	opts ...run.MiddlewareOption,
+) (*PlaceOrderKafka, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "placeOrder",
+			Protocol:  "kafka",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenOrdersKafka(
+		run.WithOperationName(ctx, "placeOrder"),
+		server,
+		params,
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlaceOrderKafka{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// placeOrderKafkaEnvelopeWriter counts the payload bytes written to the envelope.
+type placeOrderKafkaEnvelopeWriter struct {
+	kafka.EnvelopeWriter
+	size int
+}
+
+func (e *placeOrderKafkaEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type PlaceOrderChannelKafka interface {
+	Close() error
+
+	SealOrder(kafka.EnvelopeWriter, channels.OrdersEnvelopeMarshalerKafka) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerKafka) error
+
+	UnsealOrder(kafka.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerKafka) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+	PublishEnvelope(context.Context, kafka.EnvelopeWriter, any) error
+}
+
+// MockPlaceOrderChannelKafka is the call-recording mock of PlaceOrderChannelKafka.
+// Methods return the values from the corresponding *Func fields if set, otherwise they return nil values.
+type MockPlaceOrderChannelKafka struct {
+	run.MockRecorder
+	CloseFunc           func() error
+	SealOrderFunc       func(envelope kafka.EnvelopeWriter, message channels.OrdersEnvelopeMarshalerKafka) error
+	PublishOrderFunc    func(ctx context.Context, message channels.OrdersEnvelopeMarshalerKafka) error
+	UnsealOrderFunc     func(envelope kafka.EnvelopeReader, message channels.OrdersEnvelopeUnmarshalerKafka) error
+	SubscribeOrderFunc  func(ctx context.Context, cb func(ctx context.Context, message messages.OrderReceiver) error) error
+	PublishEnvelopeFunc func(ctx context.Context, envelope kafka.EnvelopeWriter, message any) error
+}
+
+func (m *MockPlaceOrderChannelKafka) Close() error {
+	m.Record("Close")
+	if m.CloseFunc != nil {
+		return m.CloseFunc()
+	}
+	return nil
+}
+
+func (m *MockPlaceOrderChannelKafka) SealOrder(envelope kafka.EnvelopeWriter, message channels.OrdersEnvelopeMarshalerKafka) error {
+	m.Record("SealOrder", envelope, message)
+	if m.SealOrderFunc != nil {
+		return m.SealOrderFunc(envelope, message)
+	}
+	return nil
+}
+
+func (m *MockPlaceOrderChannelKafka) PublishOrder(ctx context.Context, message channels.OrdersEnvelopeMarshalerKafka) error {
+	m.Record("PublishOrder", ctx, message)
+	if m.PublishOrderFunc != nil {
+		return m.PublishOrderFunc(ctx, message)
+	}
+	return nil
+}
+
+func (m *MockPlaceOrderChannelKafka) UnsealOrder(envelope kafka.EnvelopeReader, message channels.OrdersEnvelopeUnmarshalerKafka) error {
+	m.Record("UnsealOrder", envelope, message)
+	if m.UnsealOrderFunc != nil {
+		return m.UnsealOrderFunc(envelope, message)
+	}
+	return nil
+}
+
+func (m *MockPlaceOrderChannelKafka) SubscribeOrder(ctx context.Context, cb func(ctx context.Context, message messages.OrderReceiver) error) error {
+	m.Record("SubscribeOrder", ctx, cb)
+	run.NotifySubscribeReady(ctx)
+	if m.SubscribeOrderFunc != nil {
+		return m.SubscribeOrderFunc(ctx, cb)
+	}
+	return nil
+}
+
+func (m *MockPlaceOrderChannelKafka) PublishEnvelope(ctx context.Context, envelope kafka.EnvelopeWriter, message any) error {
+	m.Record("PublishEnvelope", ctx, envelope, message)
+	if m.PublishEnvelopeFunc != nil {
+		return m.PublishEnvelopeFunc(ctx, envelope, message)
+	}
+	return nil
+}
+
+type PlaceOrderKafka struct {
+	Channel      PlaceOrderChannelKafka
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c PlaceOrderKafka) Close() error {
+	return c.Channel.Close()
+}
+
+func (o PlaceOrderKafka) SealOrder(
+	envelope kafka.EnvelopeWriter,
+	message channels.OrdersEnvelopeMarshalerKafka,
+) error {
+	return o.Channel.SealOrder(envelope, message)
+}
+
+func (o PlaceOrderKafka) PublishOrder(
+	ctx context.Context,
+
+	message channels.OrdersEnvelopeMarshalerKafka,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishOrder(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "order"
+	envelope := kafka.NewEnvelopeOut(nil)
+	counter := &placeOrderKafkaEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealOrder(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
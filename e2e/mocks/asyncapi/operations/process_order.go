@@ -0,0 +1,218 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+type ProcessOrderServerKafka interface {
+	OpenOrdersKafka(context.Context, channels.OrdersParameters, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersKafka, error)
+	OpenProcessOrderKafka(context.Context, channels.OrdersParameters, ...run.MiddlewareOption) (*ProcessOrderKafka, error)
+	Producer() kafka.Producer
+	Consumer() kafka.Consumer
+}
+
+// MockProcessOrderServerKafka is the call-recording mock of ProcessOrderServerKafka.
+// Methods return the values from the corresponding *Func fields if set, otherwise they return nil values.
+type MockProcessOrderServerKafka struct {
+	run.MockRecorder
+	OpenOrdersKafkaFunc       func(ctx context.Context, params channels.OrdersParameters, security run.AnySecurityScheme, opts ...run.MiddlewareOption) (*channels.OrdersKafka, error)
+	OpenProcessOrderKafkaFunc func(ctx context.Context, params channels.OrdersParameters, opts ...run.MiddlewareOption) (*ProcessOrderKafka, error)
+	ProducerFunc              func() kafka.Producer
+	ConsumerFunc              func() kafka.Consumer
+}
+
+func (m *MockProcessOrderServerKafka) OpenOrdersKafka(ctx context.Context, params channels.OrdersParameters, security run.AnySecurityScheme, opts ...run.MiddlewareOption) (*channels.OrdersKafka, error) {
+	m.Record("OpenOrdersKafka", ctx, params, security, opts)
+	if m.OpenOrdersKafkaFunc != nil {
+		return m.OpenOrdersKafkaFunc(ctx, params, security, opts...)
+	}
+	return nil, nil
+}
+
+func (m *MockProcessOrderServerKafka) OpenProcessOrderKafka(ctx context.Context, params channels.OrdersParameters, opts ...run.MiddlewareOption) (*ProcessOrderKafka, error) {
+	m.Record("OpenProcessOrderKafka", ctx, params, opts)
+	if m.OpenProcessOrderKafkaFunc != nil {
+		return m.OpenProcessOrderKafkaFunc(ctx, params, opts...)
+	}
+	return nil, nil
+}
+
+func (m *MockProcessOrderServerKafka) Producer() kafka.Producer {
+	m.Record("Producer")
+	if m.ProducerFunc != nil {
+		return m.ProducerFunc()
+	}
+	return nil
+}
+
+func (m *MockProcessOrderServerKafka) Consumer() kafka.Consumer {
+	m.Record("Consumer")
+	if m.ConsumerFunc != nil {
+		return m.ConsumerFunc()
+	}
+	return nil
+}
+
+func OpenProcessOrderKafka(
+	ctx context.Context,
+	server ProcessOrderServerKafka,
+	params channels.OrdersParameters,
+
+	opts ...run.MiddlewareOption,
+) (*ProcessOrderKafka, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "processOrder",
+			Protocol:  "kafka",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, processOrderKafkaMetrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenOrdersKafka(
+		run.WithOperationName(ctx, "processOrder"),
+		server,
+		params,
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProcessOrderKafka{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// processOrderKafkaEnvelopeReader counts the payload bytes read from the envelope.
+type processOrderKafkaEnvelopeReader struct {
+	kafka.EnvelopeReader
+	size int
+}
+
+func (e *processOrderKafkaEnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// processOrderKafkaMetrics returns the middleware that reports the received messages metrics.
+func processOrderKafkaMetrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[kafka.EnvelopeReader]) run.SubscribeHandler[kafka.EnvelopeReader] {
+		return func(ctx context.Context, envelope kafka.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.OrderIn:
+				labels.Message = "order"
+			}
+			counter := &processOrderKafkaEnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type ProcessOrderChannelKafka interface {
+	Close() error
+
+	SealOrder(kafka.EnvelopeWriter, channels.OrdersEnvelopeMarshalerKafka) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerKafka) error
+
+	UnsealOrder(kafka.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerKafka) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+}
+
+// MockProcessOrderChannelKafka is the call-recording mock of ProcessOrderChannelKafka.
+// Methods return the values from the corresponding *Func fields if set, otherwise they return nil values.
+type MockProcessOrderChannelKafka struct {
+	run.MockRecorder
+	CloseFunc          func() error
+	SealOrderFunc      func(envelope kafka.EnvelopeWriter, message channels.OrdersEnvelopeMarshalerKafka) error
+	PublishOrderFunc   func(ctx context.Context, message channels.OrdersEnvelopeMarshalerKafka) error
+	UnsealOrderFunc    func(envelope kafka.EnvelopeReader, message channels.OrdersEnvelopeUnmarshalerKafka) error
+	SubscribeOrderFunc func(ctx context.Context, cb func(ctx context.Context, message messages.OrderReceiver) error) error
+}
+
+func (m *MockProcessOrderChannelKafka) Close() error {
+	m.Record("Close")
+	if m.CloseFunc != nil {
+		return m.CloseFunc()
+	}
+	return nil
+}
+
+func (m *MockProcessOrderChannelKafka) SealOrder(envelope kafka.EnvelopeWriter, message channels.OrdersEnvelopeMarshalerKafka) error {
+	m.Record("SealOrder", envelope, message)
+	if m.SealOrderFunc != nil {
+		return m.SealOrderFunc(envelope, message)
+	}
+	return nil
+}
+
+func (m *MockProcessOrderChannelKafka) PublishOrder(ctx context.Context, message channels.OrdersEnvelopeMarshalerKafka) error {
+	m.Record("PublishOrder", ctx, message)
+	if m.PublishOrderFunc != nil {
+		return m.PublishOrderFunc(ctx, message)
+	}
+	return nil
+}
+
+func (m *MockProcessOrderChannelKafka) UnsealOrder(envelope kafka.EnvelopeReader, message channels.OrdersEnvelopeUnmarshalerKafka) error {
+	m.Record("UnsealOrder", envelope, message)
+	if m.UnsealOrderFunc != nil {
+		return m.UnsealOrderFunc(envelope, message)
+	}
+	return nil
+}
+
+func (m *MockProcessOrderChannelKafka) SubscribeOrder(ctx context.Context, cb func(ctx context.Context, message messages.OrderReceiver) error) error {
+	m.Record("SubscribeOrder", ctx, cb)
+	run.NotifySubscribeReady(ctx)
+	if m.SubscribeOrderFunc != nil {
+		return m.SubscribeOrderFunc(ctx, cb)
+	}
+	return nil
+}
+
+type ProcessOrderKafka struct {
+	Channel      ProcessOrderChannelKafka
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c ProcessOrderKafka) Close() error {
+	return c.Channel.Close()
+}
+
+func (o ProcessOrderKafka) UnsealOrder(
+	envelope kafka.EnvelopeReader,
+	message channels.OrdersEnvelopeUnmarshalerKafka,
+) error {
+	return o.Channel.UnsealOrder(envelope, message)
+}
+
+func (o ProcessOrderKafka) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeOrder(ctx, cb)
+}
@@ -0,0 +1,142 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package kafka
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+)
+
+func NewConsumer(hosts []string, bindings *ServerBindings, security run.AnySecurityScheme, extraOpts ...kgo.Opt) *ConsumeClient {
+	return &ConsumeClient{
+		hosts:     hosts,
+		bindings:  bindings,
+		extraOpts: extraOpts,
+		security:  security,
+	}
+}
+
+type ConsumeClient struct {
+	// SchemaRegistry is used to verify and strip the schema ID from consumed records. If nil, the client is created
+	// from the schemaRegistryUrl server binding if it is set.
+	SchemaRegistry *SchemaRegistry
+
+	hosts     []string
+	bindings  *ServerBindings
+	extraOpts []kgo.Opt
+	security  run.AnySecurityScheme
+}
+
+func (c ConsumeClient) Subscriber(_ context.Context, address string, chb *ChannelBindings, opb *OperationBindings, security run.AnySecurityScheme) (Subscriber, error) {
+	// TODO: chb.ClientID, chb.GroupID
+	var opts []kgo.Opt
+
+	opts = append(opts, kgo.SeedBrokers(c.hosts...))
+
+	var saslMech []sasl.Mechanism
+	for _, sec := range []run.AnySecurityScheme{c.security, security} {
+		if sec == nil {
+			continue
+		}
+		mech, err := toSaslMechanism(sec)
+		if err != nil {
+			return nil, err
+		}
+		saslMech = append(saslMech, mech)
+	}
+	if len(saslMech) > 0 {
+		opts = append(opts, kgo.SASL(saslMech...))
+	}
+
+	topic := address
+	if chb != nil && chb.Topic != "" {
+		topic = chb.Topic
+	}
+	if topic != "" {
+		opts = append(opts, kgo.ConsumeTopics(topic))
+	}
+	opts = append(opts, c.extraOpts...)
+
+	registry, err := serverSchemaRegistry(c.SchemaRegistry, c.bindings)
+	if err != nil {
+		return nil, err
+	}
+
+	cl, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SubscribeChannel{
+		Client:            cl,
+		Topic:             topic,
+		SchemaRegistry:    registry,
+		channelBindings:   chb,
+		operationBindings: opb,
+	}, nil
+}
+
+type SubscribeChannel struct {
+	*kgo.Client
+	Topic             string
+	IgnoreFetchErrors bool // TODO: add opts for Subscriber/Publisher interfaces
+	SchemaRegistry    *SchemaRegistry
+	channelBindings   *ChannelBindings
+	operationBindings *OperationBindings
+}
+
+func (s SubscribeChannel) Receive(ctx context.Context, cb func(envelope EnvelopeReader)) error {
+	run.NotifySubscribeReady(ctx)
+	for {
+		fetches := s.Client.PollFetches(ctx)
+		if fetches.Err0() != nil {
+			return fetches.Err0()
+		}
+		var batchError error
+
+		if !s.IgnoreFetchErrors {
+			fetches.EachError(func(topic string, partition int32, err error) {
+				batchError = errors.Join(batchError, fmt.Errorf("topic=%q, partition=%v: %w", topic, partition, err))
+			})
+		}
+		if batchError != nil {
+			return fmt.Errorf("fetch errors: %w", batchError)
+		}
+
+		fetches.EachRecord(func(r *kgo.Record) {
+			select {
+			case <-ctx.Done():
+			default:
+				cb(s.newEnvelopeIn(ctx, r))
+			}
+		})
+	}
+}
+
+// newEnvelopeIn returns the envelope for the record. If schema registry is set, the schema ID is stripped from
+// the record, and the schema subject is verified later by EnvelopeIn.VerifyBindings. Error is returned on envelope read.
+func (s SubscribeChannel) newEnvelopeIn(ctx context.Context, r *kgo.Record) *EnvelopeIn {
+	res := NewEnvelopeIn(r)
+	if s.SchemaRegistry != nil {
+		schema, payload, err := s.SchemaRegistry.decodeRecord(ctx, r)
+		res.rd.Reset(payload)
+		if err == nil {
+			res.schema = &schema
+		}
+		res.err = err
+	}
+	return res
+}
+
+func (s SubscribeChannel) Close() error {
+	s.Client.Close()
+	return nil
+}
@@ -0,0 +1,129 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package kafka
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"bytes"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func NewEnvelopeOut(buf []byte) *EnvelopeOut {
+	return &EnvelopeOut{
+		Record: &kgo.Record{Value: buf},
+	}
+}
+
+type EnvelopeOut struct {
+	*kgo.Record
+	messageBindings  MessageBindings
+	contentType      string
+	schemaFormat     string
+	schemaDefinition string
+}
+
+func (e *EnvelopeOut) Write(p []byte) (n int, err error) {
+	e.Value = append(e.Value, p...)
+	return len(p), nil
+}
+
+func (e *EnvelopeOut) ResetPayload() {
+	e.Value = e.Value[:0]
+}
+
+func (e *EnvelopeOut) SetHeaders(headers run.Headers) {
+	for k, v := range headers.ToByteValues() {
+		e.Record.Headers = append(e.Record.Headers, kgo.RecordHeader{Key: k, Value: v})
+	}
+}
+
+func (e *EnvelopeOut) SetContentType(contentType string) {
+	e.Record.Headers = append(e.Record.Headers, kgo.RecordHeader{Key: "Content-Type", Value: []byte(contentType)})
+	e.contentType = contentType
+}
+
+// SetSchema sets the message payload schema format and definition. The format takes precedence over content type
+// when the schema type is determined for the schema registry. The definition is registered in the schema registry
+// on produce.
+func (e *EnvelopeOut) SetSchema(format, definition string) {
+	e.schemaFormat = format
+	e.schemaDefinition = definition
+}
+
+func (e *EnvelopeOut) SetBindings(bindings MessageBindings) {
+	e.messageBindings = bindings
+}
+
+func (e *EnvelopeOut) SetTopic(topic string) {
+	e.Topic = topic
+}
+
+func (e *EnvelopeOut) AsFranzGoRecord() *kgo.Record {
+	return e.Record
+}
+
+func (e *EnvelopeOut) Bindings() MessageBindings {
+	return e.messageBindings
+}
+
+func (e *EnvelopeOut) Format() string {
+	if e.schemaFormat != "" {
+		return e.schemaFormat
+	}
+	return e.contentType
+}
+
+func (e *EnvelopeOut) SchemaDefinition() string {
+	return e.schemaDefinition
+}
+
+func NewEnvelopeIn(r *kgo.Record) *EnvelopeIn {
+	return &EnvelopeIn{
+		Record: r,
+		rd:     bytes.NewReader(r.Value),
+	}
+}
+
+type EnvelopeIn struct {
+	*kgo.Record
+	rd     *bytes.Reader
+	schema *registrySchema
+	err    error
+}
+
+func (e EnvelopeIn) Read(p []byte) (n int, err error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	return e.rd.Read(p)
+}
+
+// SchemaID returns the schema registry ID of the record schema. Returns 0 if schema registry is not used.
+func (e EnvelopeIn) SchemaID() int {
+	if e.schema == nil {
+		return 0
+	}
+	return e.schema.ID
+}
+
+// VerifyBindings verifies the record against the bindings of the message it is unmarshalled to. If schema registry
+// is used, the schema must be registered for the subject derived by the schemaLookupStrategy binding. The
+// verification error is also returned on envelope read.
+func (e *EnvelopeIn) VerifyBindings(bindings MessageBindings) error {
+	if e.err == nil && e.schema != nil {
+		e.err = e.schema.verifySubject(e.Topic, bindings.SchemaLookupStrategy)
+	}
+	return e.err
+}
+
+func (e EnvelopeIn) Headers() run.Headers {
+	res := make(run.Headers, len(e.Record.Headers))
+	for _, h := range e.Record.Headers {
+		res[h.Key] = h.Value
+	}
+	return res
+}
@@ -0,0 +1,170 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+)
+
+// MockProducer is the call-recording mock of Producer. Publisher returns the values from PublisherFunc if set,
+// otherwise it returns nil values.
+type MockProducer struct {
+	run.MockRecorder
+	PublisherFunc func(ctx context.Context, address string, chBindings *ChannelBindings, opBindings *OperationBindings, security run.AnySecurityScheme) (Publisher, error)
+}
+
+func (m *MockProducer) Publisher(ctx context.Context, address string, chBindings *ChannelBindings, opBindings *OperationBindings, security run.AnySecurityScheme) (Publisher, error) {
+	m.Record("Publisher", ctx, address, chBindings, opBindings, security)
+	if m.PublisherFunc != nil {
+		return m.PublisherFunc(ctx, address, chBindings, opBindings, security)
+	}
+	return nil, nil
+}
+
+// MockPublisher is the call-recording mock of Publisher. Methods return the values from the corresponding *Func
+// fields if set, otherwise they return nil.
+type MockPublisher struct {
+	run.MockRecorder
+	SendFunc  func(ctx context.Context, envelopes ...EnvelopeWriter) error
+	CloseFunc func() error
+}
+
+func (m *MockPublisher) Send(ctx context.Context, envelopes ...EnvelopeWriter) error {
+	m.Record("Send", ctx, envelopes)
+	if m.SendFunc != nil {
+		return m.SendFunc(ctx, envelopes...)
+	}
+	return nil
+}
+
+func (m *MockPublisher) Close() error {
+	m.Record("Close")
+	if m.CloseFunc != nil {
+		return m.CloseFunc()
+	}
+	return nil
+}
+
+// MockEnvelopeWriter is the call-recording mock of EnvelopeWriter. Write records a copy of written bytes and
+// returns the values from WriteFunc if set, otherwise it reports all bytes as written.
+type MockEnvelopeWriter struct {
+	run.MockRecorder
+	WriteFunc func(p []byte) (n int, err error)
+}
+
+func (m *MockEnvelopeWriter) Write(p []byte) (n int, err error) {
+	m.Record("Write", bytes.Clone(p))
+	if m.WriteFunc != nil {
+		return m.WriteFunc(p)
+	}
+	return len(p), nil
+}
+
+func (m *MockEnvelopeWriter) ResetPayload() {
+	m.Record("ResetPayload")
+}
+
+func (m *MockEnvelopeWriter) SetHeaders(headers run.Headers) {
+	m.Record("SetHeaders", headers)
+}
+
+func (m *MockEnvelopeWriter) SetContentType(contentType string) {
+	m.Record("SetContentType", contentType)
+}
+
+func (m *MockEnvelopeWriter) SetBindings(bindings MessageBindings) {
+	m.Record("SetBindings", bindings)
+}
+
+func (m *MockEnvelopeWriter) SetTopic(topic string) {
+	m.Record("SetTopic", topic)
+}
+
+func (m *MockEnvelopeWriter) SetSchema(format, definition string) {
+	m.Record("SetSchema", format, definition)
+}
+
+// MockConsumer is the call-recording mock of Consumer. Subscriber returns the values from SubscriberFunc if set,
+// otherwise it returns nil values.
+type MockConsumer struct {
+	run.MockRecorder
+	SubscriberFunc func(ctx context.Context, address string, chBindings *ChannelBindings, opBindings *OperationBindings, security run.AnySecurityScheme) (Subscriber, error)
+}
+
+func (m *MockConsumer) Subscriber(ctx context.Context, address string, chBindings *ChannelBindings, opBindings *OperationBindings, security run.AnySecurityScheme) (Subscriber, error) {
+	m.Record("Subscriber", ctx, address, chBindings, opBindings, security)
+	if m.SubscriberFunc != nil {
+		return m.SubscriberFunc(ctx, address, chBindings, opBindings, security)
+	}
+	return nil, nil
+}
+
+// MockSubscriber is the call-recording mock of Subscriber. Methods return the values from the corresponding *Func
+// fields if set, otherwise they return nil. Set ReceiveFunc to deliver the envelopes to the callback.
+type MockSubscriber struct {
+	run.MockRecorder
+	ReceiveFunc func(ctx context.Context, cb func(envelope EnvelopeReader)) error
+	CloseFunc   func() error
+}
+
+func (m *MockSubscriber) Receive(ctx context.Context, cb func(envelope EnvelopeReader)) error {
+	m.Record("Receive", ctx, cb)
+	run.NotifySubscribeReady(ctx)
+	if m.ReceiveFunc != nil {
+		return m.ReceiveFunc(ctx, cb)
+	}
+	return nil
+}
+
+func (m *MockSubscriber) Close() error {
+	m.Record("Close")
+	if m.CloseFunc != nil {
+		return m.CloseFunc()
+	}
+	return nil
+}
+
+// MockEnvelopeReader is the call-recording mock of EnvelopeReader. Read returns the values from ReadFunc if set,
+// otherwise it reads the Payload. Other methods return the values from the corresponding *Func fields if set,
+// otherwise they return nil values.
+type MockEnvelopeReader struct {
+	run.MockRecorder
+	Payload            []byte
+	ReadFunc           func(p []byte) (n int, err error)
+	HeadersFunc        func() run.Headers
+	VerifyBindingsFunc func(bindings MessageBindings) error
+
+	offset int
+}
+
+func (m *MockEnvelopeReader) Read(p []byte) (n int, err error) {
+	m.Record("Read")
+	if m.ReadFunc != nil {
+		return m.ReadFunc(p)
+	}
+	if m.offset >= len(m.Payload) {
+		return 0, io.EOF
+	}
+	n = copy(p, m.Payload[m.offset:])
+	m.offset += n
+	return n, nil
+}
+
+func (m *MockEnvelopeReader) Headers() run.Headers {
+	m.Record("Headers")
+	if m.HeadersFunc != nil {
+		return m.HeadersFunc()
+	}
+	return nil
+}
+
+func (m *MockEnvelopeReader) VerifyBindings(bindings MessageBindings) error {
+	m.Record("VerifyBindings", bindings)
+	if m.VerifyBindingsFunc != nil {
+		return m.VerifyBindingsFunc(bindings)
+	}
+	return nil
+}
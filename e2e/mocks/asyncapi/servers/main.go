@@ -0,0 +1,158 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package servers
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"io"
+	"net/url"
+)
+
+func MainURL() (*url.URL, error) {
+	return &url.URL{Scheme: "kafka", Host: "localhost:9092", Path: ""}, nil
+}
+
+func NewMain(producer kafka.Producer, consumer kafka.Consumer) *Main {
+	return &Main{
+		producer: producer,
+		consumer: consumer,
+	}
+}
+
+type MainClosable struct {
+	Main
+}
+
+func (c MainClosable) Close() error {
+	var err error
+	if v, ok := any(c.producer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	if v, ok := any(c.consumer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	return err
+}
+
+func ConnectMainBidi(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...kgo.Opt,
+) (*MainClosable, error) {
+	var bindings *kafka.ServerBindings
+	producer := kafka.NewProducer([]string{url.Host}, bindings, nil, opts...)
+	consumer := kafka.NewConsumer([]string{url.Host}, bindings, nil, opts...)
+	return &MainClosable{
+		Main{producer: producer, consumer: consumer},
+	}, nil
+}
+
+func ConnectMainProducer(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...kgo.Opt,
+) (*MainClosable, error) {
+	var bindings *kafka.ServerBindings
+	producer := kafka.NewProducer([]string{url.Host}, bindings, nil, opts...)
+	return &MainClosable{
+		Main{producer: producer},
+	}, nil
+}
+
+func ConnectMainConsumer(
+	ctx context.Context,
+	url *url.URL,
+
+	opts ...kgo.Opt,
+) (*MainClosable, error) {
+	var bindings *kafka.ServerBindings
+	consumer := kafka.NewConsumer([]string{url.Host}, bindings, nil, opts...)
+	return &MainClosable{
+		Main{consumer: consumer},
+	}, nil
+}
+
+type Main struct {
+	producer kafka.Producer
+	consumer kafka.Consumer
+}
+
+func (s Main) Name() string {
+	return "Main"
+}
+
+func (s Main) Producer() kafka.Producer {
+	return s.producer
+}
+
+func (s Main) Consumer() kafka.Consumer {
+	return s.consumer
+}
+
+func (s Main) OpenOrdersKafka(
+	ctx context.Context,
+	params channels.OrdersParameters,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.OrdersKafka, error) {
+	return channels.OpenOrdersKafka(
+		ctx, s, params, nil, security, opts...,
+	)
+}
+func (s Main) OpenStatusesKafka(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.StatusesKafka, error) {
+	return channels.OpenStatusesKafka(
+		ctx, s, nil, security, opts...,
+	)
+}
+func (s Main) OpenStatusRepliesKafka(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*channels.StatusRepliesKafka, error) {
+	return channels.OpenStatusRepliesKafka(
+		ctx, s, opts...,
+	)
+}
+
+func (s Main) OpenPlaceOrderKafka(
+	ctx context.Context,
+	params channels.OrdersParameters,
+
+	opts ...run.MiddlewareOption,
+) (*operations.PlaceOrderKafka, error) {
+	return operations.OpenPlaceOrderKafka(
+		ctx, s, params, opts...,
+	)
+}
+func (s Main) OpenProcessOrderKafka(
+	ctx context.Context,
+	params channels.OrdersParameters,
+
+	opts ...run.MiddlewareOption,
+) (*operations.ProcessOrderKafka, error) {
+	return operations.OpenProcessOrderKafka(
+		ctx, s, params, opts...,
+	)
+}
+func (s Main) OpenGetStatusKafka(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.GetStatusKafka, error) {
+	return operations.OpenGetStatusKafka(
+		ctx, s, opts...,
+	)
+}
@@ -0,0 +1,4 @@
+// Package mocks checks the generated call-recording mocks against the generated operations and channels.
+package mocks
+
+//go:generate go -C ../.. run ./cmd/go-asyncapi -c e2e/mocks/go-asyncapi.yaml code -t e2e/mocks/asyncapi -M github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi e2e/mocks/asyncapi.yaml
@@ -0,0 +1,265 @@
+package mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/e2e/mocks/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+var eu = channels.OrdersParameters{Region: "eu"}
+
+func TestProducerMocks(t *testing.T) {
+	ctx := t.Context()
+	errSend := errors.New("send failed")
+	publisher := &kafka.MockPublisher{}
+	producer := &kafka.MockProducer{
+		PublisherFunc: func(context.Context, string, *kafka.ChannelBindings, *kafka.OperationBindings, run.AnySecurityScheme) (kafka.Publisher, error) {
+			return publisher, nil
+		},
+	}
+	server := &operations.MockPlaceOrderServerKafka{ProducerFunc: func() kafka.Producer { return producer }}
+
+	op, err := operations.OpenPlaceOrderKafka(ctx, server, eu)
+	if err != nil {
+		t.Fatalf("OpenPlaceOrderKafka() error = %v", err)
+	}
+	if calls := producer.Calls("Publisher"); len(calls) != 1 || calls[0].Args[1] != "orders.eu" {
+		t.Fatalf("Publisher calls = %+v, want one call with address orders.eu", calls)
+	}
+	// Operation for sending doesn't subscribe
+	if got := methods(server.Calls("")); !slices.Equal(got, []string{"Producer", "Consumer"}) {
+		t.Errorf("server calls = %v", got)
+	}
+
+	if err = op.PublishOrder(ctx, order("1")); err != nil {
+		t.Fatalf("PublishOrder() error = %v", err)
+	}
+	publisher.SendFunc = func(context.Context, ...kafka.EnvelopeWriter) error { return errSend }
+	if err = op.PublishOrder(ctx, order("2")); !errors.Is(err, errSend) {
+		t.Fatalf("PublishOrder() error = %v, want %v", err, errSend)
+	}
+
+	calls := publisher.Calls("Send")
+	if len(calls) != 2 {
+		t.Fatalf("Send calls = %d, want 2", len(calls))
+	}
+	envelopes := calls[0].Args[1].([]kafka.EnvelopeWriter)
+	record := envelopes[0].(*kafka.EnvelopeOut).AsFranzGoRecord()
+	if record.Topic != "orders.eu" || string(record.Value) != `{"id":"1"}`+"\n" {
+		t.Errorf("sent record topic = %q, value = %q", record.Topic, record.Value)
+	}
+
+	// Envelope mock records the sealing steps
+	writer := &kafka.MockEnvelopeWriter{}
+	if err = op.SealOrder(writer, order("3")); err != nil {
+		t.Fatalf("SealOrder() error = %v", err)
+	}
+	if got, want := methods(writer.Calls("")), []string{"Write", "SetContentType", "SetHeaders", "SetTopic"}; !slices.Equal(got, want) {
+		t.Errorf("envelope calls = %v, want %v", got, want)
+	}
+	if got := writer.Calls("Write")[0].Args[0].([]byte); string(got) != `{"id":"3"}`+"\n" {
+		t.Errorf("written payload = %q", got)
+	}
+	if got := writer.Calls("SetHeaders")[0].Args[0].(run.Headers); got["TraceID"] != "trace-3" {
+		t.Errorf("headers = %v", got)
+	}
+
+	publisher.CloseFunc = func() error { return errSend }
+	if err = op.Close(); !errors.Is(err, errSend) {
+		t.Errorf("Close() error = %v, want %v", err, errSend)
+	}
+	if n := publisher.CallCount("Close"); n != 1 {
+		t.Errorf("publisher Close calls = %d, want 1", n)
+	}
+}
+
+func TestProducerMockError(t *testing.T) {
+	errPublisher := errors.New("no publisher")
+	producer := &kafka.MockProducer{
+		PublisherFunc: func(context.Context, string, *kafka.ChannelBindings, *kafka.OperationBindings, run.AnySecurityScheme) (kafka.Publisher, error) {
+			return nil, errPublisher
+		},
+	}
+	server := &channels.MockOrdersServerKafka{ProducerFunc: func() kafka.Producer { return producer }}
+
+	if _, err := channels.OpenOrdersKafka(t.Context(), server, eu, nil, nil); !errors.Is(err, errPublisher) {
+		t.Fatalf("OpenOrdersKafka() error = %v, want %v", err, errPublisher)
+	}
+	// Server mock without ConsumerFunc returns nil consumer
+	if n := server.CallCount("Consumer"); n != 0 {
+		t.Errorf("Consumer calls = %d, want 0 after producer failure", n)
+	}
+}
+
+func TestConsumerMocks(t *testing.T) {
+	ctx := t.Context()
+	envelopes := []*kafka.MockEnvelopeReader{
+		{Payload: []byte(`{"id":"1"}`), HeadersFunc: func() run.Headers { return run.Headers{"TraceID": []byte("trace-1")} }},
+		{Payload: []byte(`{"id":"2"}`)},
+		{Payload: []byte(`not json`)},
+	}
+	subscriber := &kafka.MockSubscriber{
+		ReceiveFunc: func(ctx context.Context, cb func(envelope kafka.EnvelopeReader)) error {
+			for _, e := range envelopes {
+				cb(e)
+			}
+			<-ctx.Done()
+			return nil
+		},
+	}
+	consumer := &kafka.MockConsumer{
+		SubscriberFunc: func(context.Context, string, *kafka.ChannelBindings, *kafka.OperationBindings, run.AnySecurityScheme) (kafka.Subscriber, error) {
+			return subscriber, nil
+		},
+	}
+	server := &operations.MockProcessOrderServerKafka{ConsumerFunc: func() kafka.Consumer { return consumer }}
+
+	op, err := operations.OpenProcessOrderKafka(ctx, server, eu)
+	if err != nil {
+		t.Fatalf("OpenProcessOrderKafka() error = %v", err)
+	}
+	defer op.Close()
+
+	var got []messages.OrderReceiver
+	err = op.SubscribeOrder(ctx, func(_ context.Context, message messages.OrderReceiver) error {
+		got = append(got, message)
+		return nil
+	})
+	if !errors.Is(err, run.ErrUnsealEnvelope) {
+		t.Fatalf("SubscribeOrder() error = %v, want %v", err, run.ErrUnsealEnvelope)
+	}
+	if len(got) != 2 || got[0].Payload().ID != "1" || got[0].Headers().TraceID != "trace-1" || got[1].Payload().ID != "2" {
+		t.Errorf("received messages = %+v", got)
+	}
+	if n := subscriber.CallCount("Receive"); n != 1 {
+		t.Errorf("Receive calls = %d, want 1", n)
+	}
+	for i, e := range envelopes {
+		if e.CallCount("Read") == 0 {
+			t.Errorf("envelope %d is not read", i)
+		}
+	}
+}
+
+func TestChannelMock(t *testing.T) {
+	ctx := t.Context()
+	errPublish := errors.New("publish failed")
+	ch := &operations.MockPlaceOrderChannelKafka{}
+	server := &operations.MockPlaceOrderServerKafka{
+		OpenPlaceOrderKafkaFunc: func(context.Context, channels.OrdersParameters, ...run.MiddlewareOption) (*operations.PlaceOrderKafka, error) {
+			return &operations.PlaceOrderKafka{Channel: ch}, nil
+		},
+	}
+
+	op, err := server.OpenPlaceOrderKafka(ctx, eu)
+	if err != nil {
+		t.Fatalf("OpenPlaceOrderKafka() error = %v", err)
+	}
+	if calls := server.Calls("OpenPlaceOrderKafka"); len(calls) != 1 || calls[0].Args[1] != eu {
+		t.Errorf("OpenPlaceOrderKafka calls = %+v, want params %v", calls, eu)
+	}
+
+	msg := order("1")
+	if err = op.PublishOrder(ctx, msg); err != nil {
+		t.Fatalf("PublishOrder() error = %v", err)
+	}
+	ch.PublishOrderFunc = func(context.Context, channels.OrdersEnvelopeMarshalerKafka) error { return errPublish }
+	if err = op.PublishOrder(ctx, msg); !errors.Is(err, errPublish) {
+		t.Fatalf("PublishOrder() error = %v, want %v", err, errPublish)
+	}
+	calls := ch.Calls("PublishOrder")
+	if len(calls) != 2 || calls[0].Args[1] != msg {
+		t.Errorf("PublishOrder calls = %+v, want 2 calls with the message", calls)
+	}
+
+	ch.ResetCalls()
+	_ = op.Close()
+	if got := methods(ch.Calls("")); !slices.Equal(got, []string{"Close"}) {
+		t.Errorf("channel calls after reset = %v", got)
+	}
+}
+
+func TestRequesterMocks(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	requests := make(chan *messages.StatusRequestOut, 1)
+	ch := &operations.MockGetStatusChannelKafka{
+		PublishEnvelopeFunc: func(_ context.Context, _ kafka.EnvelopeWriter, message any) error {
+			requests <- message.(*messages.StatusRequestOut)
+			return nil
+		},
+	}
+	replyCh := &operations.MockGetStatusReplyChannelKafka{
+		SubscribeStatusFunc: func(ctx context.Context, cb func(context.Context, messages.StatusReceiver) error) error {
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case req := <-requests:
+					if err := cb(ctx, status(t, req.Headers.CorrelationID, "shipped")); err != nil {
+						return err
+					}
+				}
+			}
+		},
+	}
+
+	requester := operations.GetStatusKafka{Channel: ch}.Requester(replyCh)
+	defer requester.Close()
+	req := new(messages.StatusRequestOut).SetPayload(schemas.Order{ID: "1"})
+	reply, err := requester.RequestStatusRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("RequestStatusRequest() error = %v", err)
+	}
+	if reply.Payload() != "shipped" || reply.Headers().CorrelationID != req.Headers.CorrelationID {
+		t.Errorf("reply = %q with correlation id %q, want shipped with %q", reply.Payload(), reply.Headers().CorrelationID, req.Headers.CorrelationID)
+	}
+	if n := ch.CallCount("SealStatusRequest"); n != 1 {
+		t.Errorf("SealStatusRequest calls = %d, want 1", n)
+	}
+	if n := replyCh.CallCount("SubscribeStatus"); n != 1 {
+		t.Errorf("SubscribeStatus calls = %d, want 1", n)
+	}
+}
+
+func order(id string) *messages.OrderOut {
+	msg := new(messages.OrderOut).SetPayload(schemas.Order{ID: id})
+	msg.Headers.TraceID = "trace-" + id
+	return msg
+}
+
+// status returns the received reply message, unmarshalled from the envelope mock.
+func status(t *testing.T, correlationID, value string) messages.StatusReceiver {
+	t.Helper()
+	payload, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	envelope := &kafka.MockEnvelopeReader{
+		Payload:     payload,
+		HeadersFunc: func() run.Headers { return run.Headers{"CorrelationID": correlationID} },
+	}
+	res := new(messages.StatusIn)
+	if err = res.UnmarshalEnvelopeKafka(envelope); err != nil {
+		t.Fatalf("UnmarshalEnvelopeKafka() error = %v", err)
+	}
+	return res
+}
+
+func methods(calls []run.MockCall) []string {
+	var res []string
+	for _, c := range calls {
+		res = append(res, c.Method)
+	}
+	return res
+}
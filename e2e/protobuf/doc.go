@@ -0,0 +1,3 @@
+// Package protobuf checks the runtime protobuf codec against google.golang.org/protobuf library, encoding and
+// decoding the messages that have the fields of every kind.
+package protobuf
@@ -0,0 +1,283 @@
+package protobuf
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bdragon300/go-asyncapi/run/protobuf"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+type testInner struct {
+	Name  string `protobuf:"1,string"`
+	Value *int32 `protobuf:"2,int32"`
+}
+
+type testMessage struct {
+	Int32    int32                `protobuf:"1,int32"`
+	Int64    int64                `protobuf:"2,int64"`
+	Uint32   uint32               `protobuf:"3,uint32"`
+	Uint64   uint64               `protobuf:"4,uint64"`
+	Sint32   int32                `protobuf:"5,sint32"`
+	Sint64   int64                `protobuf:"6,sint64"`
+	Fixed32  uint32               `protobuf:"7,fixed32"`
+	Fixed64  uint64               `protobuf:"8,fixed64"`
+	Sfixed32 int32                `protobuf:"9,sfixed32"`
+	Sfixed64 int64                `protobuf:"10,sfixed64"`
+	Float    float32              `protobuf:"11,float"`
+	Double   float64              `protobuf:"12,double"`
+	Bool     bool                 `protobuf:"13,bool"`
+	String   string               `protobuf:"14,string"`
+	Bytes    []byte               `protobuf:"15,bytes"`
+	Color    int32                `protobuf:"16,enum"`
+	Sints    []int32              `protobuf:"17,sint32"`
+	Doubles  []float64            `protobuf:"18,double"`
+	Strings  []string             `protobuf:"19,string"`
+	Inner    *testInner           `protobuf:"20,message"`
+	Inners   []testInner          `protobuf:"21,message"`
+	Counts   map[string]int64     `protobuf:"22,map,string,int64"`
+	ByID     map[int32]*testInner `protobuf:"23,map,int32,message"`
+	Optional *int64               `protobuf:"24,int64"`
+	Ignored  string
+}
+
+// testDescriptor returns the descriptor of the protobuf message equivalent to testMessage:
+//
+//	syntax = "proto3";
+//	enum Color { RED = 0; GREEN = 1; BLUE = 2; }
+//	message Inner { string name = 1; optional int32 value = 2; }
+//	message Message {
+//	  int32 int32 = 1; ... Color color = 16;
+//	  repeated sint32 sints = 17; repeated double doubles = 18; repeated string strings = 19;
+//	  Inner inner = 20; repeated Inner inners = 21;
+//	  map<string, int64> counts = 22; map<int32, Inner> by_id = 23;
+//	  optional int64 optional = 24;
+//	}
+func testDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Type:     typ.Enum(),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		}
+	}
+	repeated := func(f *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+		f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		return f
+	}
+	typeName := func(f *descriptorpb.FieldDescriptorProto, name string) *descriptorpb.FieldDescriptorProto {
+		f.TypeName = proto.String(name)
+		return f
+	}
+	optional := func(f *descriptorpb.FieldDescriptorProto, oneofIndex int32) *descriptorpb.FieldDescriptorProto {
+		f.Proto3Optional = proto.Bool(true)
+		f.OneofIndex = proto.Int32(oneofIndex)
+		return f
+	}
+	mapEntry := func(name string, key, value *descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+		return &descriptorpb.DescriptorProto{
+			Name:    proto.String(name),
+			Field:   []*descriptorpb.FieldDescriptorProto{key, value},
+			Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+		}
+	}
+
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Color"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("RED"), Number: proto.Int32(0)},
+				{Name: proto.String("GREEN"), Number: proto.Int32(1)},
+				{Name: proto.String("BLUE"), Number: proto.Int32(2)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Inner"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+					optional(field("value", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32), 0),
+				},
+				OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("_value")}},
+			},
+			{
+				Name: proto.String("Message"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("int32", 1, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+					field("int64", 2, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+					field("uint32", 3, descriptorpb.FieldDescriptorProto_TYPE_UINT32),
+					field("uint64", 4, descriptorpb.FieldDescriptorProto_TYPE_UINT64),
+					field("sint32", 5, descriptorpb.FieldDescriptorProto_TYPE_SINT32),
+					field("sint64", 6, descriptorpb.FieldDescriptorProto_TYPE_SINT64),
+					field("fixed32", 7, descriptorpb.FieldDescriptorProto_TYPE_FIXED32),
+					field("fixed64", 8, descriptorpb.FieldDescriptorProto_TYPE_FIXED64),
+					field("sfixed32", 9, descriptorpb.FieldDescriptorProto_TYPE_SFIXED32),
+					field("sfixed64", 10, descriptorpb.FieldDescriptorProto_TYPE_SFIXED64),
+					field("float", 11, descriptorpb.FieldDescriptorProto_TYPE_FLOAT),
+					field("double", 12, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE),
+					field("bool", 13, descriptorpb.FieldDescriptorProto_TYPE_BOOL),
+					field("string", 14, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+					field("bytes", 15, descriptorpb.FieldDescriptorProto_TYPE_BYTES),
+					typeName(field("color", 16, descriptorpb.FieldDescriptorProto_TYPE_ENUM), ".test.Color"),
+					repeated(field("sints", 17, descriptorpb.FieldDescriptorProto_TYPE_SINT32)),
+					repeated(field("doubles", 18, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE)),
+					repeated(field("strings", 19, descriptorpb.FieldDescriptorProto_TYPE_STRING)),
+					typeName(field("inner", 20, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE), ".test.Inner"),
+					typeName(repeated(field("inners", 21, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE)), ".test.Inner"),
+					typeName(repeated(field("counts", 22, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE)), ".test.Message.CountsEntry"),
+					typeName(repeated(field("byId", 23, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE)), ".test.Message.ByIdEntry"),
+					optional(field("optional", 24, descriptorpb.FieldDescriptorProto_TYPE_INT64), 0),
+				},
+				NestedType: []*descriptorpb.DescriptorProto{
+					mapEntry("CountsEntry",
+						field("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+						field("value", 2, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+					),
+					mapEntry("ByIdEntry",
+						field("key", 1, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+						typeName(field("value", 2, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE), ".test.Inner"),
+					),
+				},
+				OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("_optional")}},
+			},
+		},
+	}
+
+	file, err := protodesc.NewFile(fd, nil)
+	if err != nil {
+		t.Fatalf("build descriptor: %v", err)
+	}
+	return file.Messages().ByName("Message")
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// interopTests are the testMessage values paired with the equivalent protobuf JSON representation.
+var interopTests = []struct {
+	name  string
+	value testMessage
+	json  string
+}{
+	{
+		name:  "empty",
+		value: testMessage{},
+		json:  `{}`,
+	},
+	{
+		name: "varint",
+		value: testMessage{
+			Int32: -1, Int64: -1 << 40, Uint32: 1<<32 - 1, Uint64: 1<<64 - 1, Bool: true, Color: 2,
+		},
+		json: `{"int32": -1, "int64": "-1099511627776", "uint32": 4294967295, "uint64": "18446744073709551615", "bool": true, "color": "BLUE"}`,
+	},
+	{
+		name:  "zigzag",
+		value: testMessage{Sint32: -2147483648, Sint64: 9223372036854775807},
+		json:  `{"sint32": -2147483648, "sint64": "9223372036854775807"}`,
+	},
+	{
+		name:  "fixed",
+		value: testMessage{Fixed32: 7, Fixed64: 1 << 63, Sfixed32: -7, Sfixed64: -1 << 63, Float: 1.5, Double: -0.25},
+		json:  `{"fixed32": 7, "fixed64": "9223372036854775808", "sfixed32": -7, "sfixed64": "-9223372036854775808", "float": 1.5, "double": -0.25}`,
+	},
+	{
+		name:  "length delimited",
+		value: testMessage{String: "héllo", Bytes: []byte{0, 1, 2}},
+		json:  `{"string": "héllo", "bytes": "AAEC"}`,
+	},
+	{
+		name:  "packed repeated",
+		value: testMessage{Sints: []int32{0, -1, 1, -300}, Doubles: []float64{1, 2.5}},
+		json:  `{"sints": [0, -1, 1, -300], "doubles": [1, 2.5]}`,
+	},
+	{
+		name:  "repeated strings",
+		value: testMessage{Strings: []string{"a", "", "c"}},
+		json:  `{"strings": ["a", "", "c"]}`,
+	},
+	{
+		name: "nested messages",
+		value: testMessage{
+			Inner:  &testInner{Name: "x", Value: ptr[int32](0)},
+			Inners: []testInner{{Name: "a"}, {Value: ptr[int32](-5)}},
+		},
+		json: `{"inner": {"name": "x", "value": 0}, "inners": [{"name": "a"}, {"value": -5}]}`,
+	},
+	{
+		name: "maps",
+		value: testMessage{
+			Counts: map[string]int64{"a": 1, "b": -2, "": 0},
+			ByID:   map[int32]*testInner{1: {Name: "one"}, -1: {Value: ptr[int32](3)}},
+		},
+		json: `{"counts": {"a": "1", "b": "-2", "": "0"}, "byId": {"1": {"name": "one"}, "-1": {"value": 3}}}`,
+	},
+	{
+		name:  "explicit presence",
+		value: testMessage{Optional: ptr[int64](0)},
+		json:  `{"optional": "0"}`,
+	},
+}
+
+func TestMarshalInterop(t *testing.T) {
+	desc := testDescriptor(t)
+
+	for _, tt := range interopTests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := dynamicpb.NewMessage(desc)
+			if err := protojson.Unmarshal([]byte(tt.json), want); err != nil {
+				t.Fatalf("protojson.Unmarshal() error = %v", err)
+			}
+
+			data, err := protobuf.Marshal(&tt.value)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			got := dynamicpb.NewMessage(desc)
+			if err = proto.Unmarshal(data, got); err != nil {
+				t.Fatalf("proto.Unmarshal() error = %v", err)
+			}
+			if !proto.Equal(got, want) {
+				t.Errorf("Marshal() decoded by protobuf = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalInterop(t *testing.T) {
+	desc := testDescriptor(t)
+
+	for _, tt := range interopTests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := dynamicpb.NewMessage(desc)
+			if err := protojson.Unmarshal([]byte(tt.json), msg); err != nil {
+				t.Fatalf("protojson.Unmarshal() error = %v", err)
+			}
+			data, err := proto.Marshal(msg)
+			if err != nil {
+				t.Fatalf("proto.Marshal() error = %v", err)
+			}
+
+			var got testMessage
+			if err = protobuf.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.value) {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.value)
+			}
+		})
+	}
+}
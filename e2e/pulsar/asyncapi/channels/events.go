@@ -0,0 +1,230 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package channels
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/proto/pulsar"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+func EventsAddress() run.ParamString {
+	return run.ParamString{
+		Expr: "events",
+	}
+}
+
+func NewEventsPulsar(
+
+	publisher pulsar.Publisher,
+	subscriber pulsar.Subscriber,
+	opts ...run.MiddlewareOption,
+) *EventsPulsar {
+	res := EventsPulsar{
+		address: EventsAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+	return &res
+}
+
+type EventsServerPulsar interface {
+	OpenEventsPulsar(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*EventsPulsar, error)
+	Producer() pulsar.Producer
+	Consumer() pulsar.Consumer
+}
+
+func OpenEventsPulsar(
+	ctx context.Context,
+	server EventsServerPulsar,
+
+	opBindings *pulsar.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*EventsPulsar, error) {
+	var err error
+	address, err := EventsAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher pulsar.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber pulsar.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewEventsPulsar(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type EventsPulsar struct {
+	address     run.ParamString
+	publisher   pulsar.Publisher
+	subscriber  pulsar.Subscriber
+	middlewares run.Middlewares
+}
+
+func (c EventsPulsar) Address() run.ParamString {
+	return c.address
+}
+
+func (c EventsPulsar) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type EventsEnvelopeMarshalerPulsar interface {
+	MarshalEventsPulsar(envelope pulsar.EnvelopeWriter) error
+}
+
+func (c EventsPulsar) SealEvent(
+	envelope pulsar.EnvelopeWriter,
+	message EventsEnvelopeMarshalerPulsar,
+) error {
+	if err := message.MarshalEventsPulsar(envelope); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c EventsPulsar) PublishEvent(
+	ctx context.Context,
+
+	message EventsEnvelopeMarshalerPulsar,
+) error {
+	envelope := pulsar.NewEnvelopeOut(nil)
+	if err := c.SealEvent(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c EventsPulsar) PublishEnvelope(ctx context.Context, envelope pulsar.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope pulsar.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c EventsPulsar) Publisher() pulsar.Publisher {
+	return c.publisher
+}
+
+func (c EventsPulsar) Publish(ctx context.Context, envelopes ...pulsar.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type EventsEnvelopeUnmarshalerPulsar interface {
+	UnmarshalEventsPulsar(envelope pulsar.EnvelopeReader) error
+}
+
+func (c EventsPulsar) UnsealEvent(
+	envelope pulsar.EnvelopeReader,
+	message EventsEnvelopeUnmarshalerPulsar,
+) error {
+	return message.UnmarshalEventsPulsar(envelope)
+}
+
+// SubscribeEvent receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c EventsPulsar) SubscribeEvent(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.EventReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope pulsar.EnvelopeReader, message any) error {
+		m := message.(*messages.EventIn)
+		if err2 := c.UnsealEvent(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope pulsar.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) pulsar.EnvelopeReader {
+				return &eventsPulsarBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope pulsar.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.EventIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// eventsPulsarBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type eventsPulsarBufferedEnvelope struct {
+	pulsar.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *eventsPulsarBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *eventsPulsarBufferedEnvelope) Unwrap() pulsar.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c EventsPulsar) Subscriber() pulsar.Subscriber {
+	return c.subscriber
+}
+
+func (c EventsPulsar) Subscribe(ctx context.Context, cb func(envelope pulsar.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
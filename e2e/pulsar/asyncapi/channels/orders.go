@@ -0,0 +1,243 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package channels
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/proto/pulsar"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+func OrdersAddress() run.ParamString {
+	return run.ParamString{
+		Expr: "orders",
+	}
+}
+
+type OrdersBindings struct{}
+
+func (c OrdersBindings) Pulsar() pulsar.ChannelBindings {
+	return pulsar.ChannelBindings{
+		Namespace:   "shop",
+		Persistence: "non-persistent",
+	}
+}
+
+func NewOrdersPulsar(
+
+	publisher pulsar.Publisher,
+	subscriber pulsar.Subscriber,
+	opts ...run.MiddlewareOption,
+) *OrdersPulsar {
+	res := OrdersPulsar{
+		address: OrdersAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+	return &res
+}
+
+type OrdersServerPulsar interface {
+	OpenOrdersPulsar(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*OrdersPulsar, error)
+	Producer() pulsar.Producer
+	Consumer() pulsar.Consumer
+}
+
+func OpenOrdersPulsar(
+	ctx context.Context,
+	server OrdersServerPulsar,
+
+	opBindings *pulsar.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*OrdersPulsar, error) {
+	var err error
+	chBindings := OrdersBindings{}.Pulsar()
+	address, err := OrdersAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher pulsar.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			&chBindings,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber pulsar.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			&chBindings,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewOrdersPulsar(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type OrdersPulsar struct {
+	address     run.ParamString
+	publisher   pulsar.Publisher
+	subscriber  pulsar.Subscriber
+	middlewares run.Middlewares
+}
+
+func (c OrdersPulsar) Address() run.ParamString {
+	return c.address
+}
+func (c OrdersPulsar) Bindings() pulsar.ChannelBindings {
+	return OrdersBindings{}.Pulsar()
+}
+
+func (c OrdersPulsar) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type OrdersEnvelopeMarshalerPulsar interface {
+	MarshalOrdersPulsar(envelope pulsar.EnvelopeWriter) error
+}
+
+func (c OrdersPulsar) SealOrder(
+	envelope pulsar.EnvelopeWriter,
+	message OrdersEnvelopeMarshalerPulsar,
+) error {
+	if err := message.MarshalOrdersPulsar(envelope); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c OrdersPulsar) PublishOrder(
+	ctx context.Context,
+
+	message OrdersEnvelopeMarshalerPulsar,
+) error {
+	envelope := pulsar.NewEnvelopeOut(nil)
+	if err := c.SealOrder(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c OrdersPulsar) PublishEnvelope(ctx context.Context, envelope pulsar.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope pulsar.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c OrdersPulsar) Publisher() pulsar.Publisher {
+	return c.publisher
+}
+
+func (c OrdersPulsar) Publish(ctx context.Context, envelopes ...pulsar.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type OrdersEnvelopeUnmarshalerPulsar interface {
+	UnmarshalOrdersPulsar(envelope pulsar.EnvelopeReader) error
+}
+
+func (c OrdersPulsar) UnsealOrder(
+	envelope pulsar.EnvelopeReader,
+	message OrdersEnvelopeUnmarshalerPulsar,
+) error {
+	return message.UnmarshalOrdersPulsar(envelope)
+}
+
+// SubscribeOrder receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c OrdersPulsar) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope pulsar.EnvelopeReader, message any) error {
+		m := message.(*messages.OrderIn)
+		if err2 := c.UnsealOrder(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope pulsar.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) pulsar.EnvelopeReader {
+				return &ordersPulsarBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope pulsar.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.OrderIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// ordersPulsarBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type ordersPulsarBufferedEnvelope struct {
+	pulsar.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *ordersPulsarBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *ordersPulsarBufferedEnvelope) Unwrap() pulsar.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c OrdersPulsar) Subscriber() pulsar.Subscriber {
+	return c.subscriber
+}
+
+func (c OrdersPulsar) Subscribe(ctx context.Context, cb func(envelope pulsar.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
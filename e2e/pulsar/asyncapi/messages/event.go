@@ -0,0 +1,115 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/proto/pulsar"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+)
+
+type EventSender interface {
+	SetPayload(payload schemas.Order) *EventOut
+	SetHeaders(headers map[string]any) *EventOut
+}
+
+// EventOut-- (Outbound Message)
+type EventOut struct {
+	Payload schemas.Order
+	Headers map[string]any
+}
+
+// Validate checks the EventOut value against the constraints from the jsonschema definition.
+func (v EventOut) Validate() error {
+	if err := v.Payload.Validate(); err != nil {
+		return fmt.Errorf("Payload: %w", err)
+	}
+	return nil
+}
+
+func (m *EventOut) SetPayload(payload schemas.Order) *EventOut {
+	m.Payload = payload
+	return m
+}
+
+func (m *EventOut) SetHeaders(headers map[string]any) *EventOut {
+	m.Headers = headers
+	return m
+}
+
+type EventReceiver interface {
+	Payload() schemas.Order
+	Headers() map[string]any
+}
+
+// EventIn-- (Inbound Message)
+type EventIn struct {
+	payload schemas.Order
+	headers map[string]any
+}
+
+// Validate checks the EventIn value against the constraints from the jsonschema definition.
+func (v EventIn) Validate() error {
+	if err := v.payload.Validate(); err != nil {
+		return fmt.Errorf("payload: %w", err)
+	}
+	return nil
+}
+
+func (m *EventIn) Payload() schemas.Order {
+	return m.payload
+}
+
+func (m *EventIn) Headers() map[string]any {
+	return m.headers
+}
+
+func (m *EventOut) MarshalEventsPulsar(envelope pulsar.EnvelopeWriter) error {
+	return m.MarshalEnvelopePulsar(envelope)
+}
+
+func (m *EventOut) MarshalEnvelopePulsar(envelope pulsar.EnvelopeWriter) error {
+	if err := m.MarshalPulsar(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers(m.Headers))
+	return nil
+}
+
+func (m *EventOut) MarshalPulsar(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *EventIn) UnmarshalEventsPulsar(envelope pulsar.EnvelopeReader) error {
+	return m.UnmarshalEnvelopePulsar(envelope)
+}
+
+func (m *EventIn) UnmarshalEnvelopePulsar(envelope pulsar.EnvelopeReader) error {
+	if err := m.UnmarshalPulsar(envelope); err != nil {
+		return err
+	}
+	m.headers = map[string]any(envelope.Headers())
+	return nil
+}
+
+func (m *EventIn) UnmarshalPulsar(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
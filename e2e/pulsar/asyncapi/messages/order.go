@@ -0,0 +1,137 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/proto/pulsar"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+)
+
+type OrderSender interface {
+	SetPayload(payload schemas.Order) *OrderOut
+	SetHeaders(headers struct {
+		TraceID string `json:"traceId"`
+	}) *OrderOut
+}
+
+// OrderOut-- (Outbound Message)
+type OrderOut struct {
+	Payload schemas.Order
+	Headers struct {
+		TraceID string `json:"traceId"`
+	}
+}
+
+// Validate checks the OrderOut value against the constraints from the jsonschema definition.
+func (v OrderOut) Validate() error {
+	if err := v.Payload.Validate(); err != nil {
+		return fmt.Errorf("Payload: %w", err)
+	}
+	return nil
+}
+
+func (m *OrderOut) SetPayload(payload schemas.Order) *OrderOut {
+	m.Payload = payload
+	return m
+}
+
+func (m *OrderOut) SetHeaders(headers struct {
+	TraceID string `json:"traceId"`
+}) *OrderOut {
+	m.Headers = headers
+	return m
+}
+
+type OrderReceiver interface {
+	Payload() schemas.Order
+	Headers() struct {
+		TraceID string `json:"traceId"`
+	}
+}
+
+// OrderIn-- (Inbound Message)
+type OrderIn struct {
+	payload schemas.Order
+	headers struct {
+		TraceID string `json:"traceId"`
+	}
+}
+
+// Validate checks the OrderIn value against the constraints from the jsonschema definition.
+func (v OrderIn) Validate() error {
+	if err := v.payload.Validate(); err != nil {
+		return fmt.Errorf("payload: %w", err)
+	}
+	return nil
+}
+
+func (m *OrderIn) Payload() schemas.Order {
+	return m.payload
+}
+
+func (m *OrderIn) Headers() struct {
+	TraceID string `json:"traceId"`
+} {
+	return m.headers
+}
+
+func (m *OrderOut) MarshalOrdersPulsar(envelope pulsar.EnvelopeWriter) error {
+	return m.MarshalEnvelopePulsar(envelope)
+}
+
+func (m *OrderOut) MarshalEnvelopePulsar(envelope pulsar.EnvelopeWriter) error {
+	if err := m.MarshalPulsar(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers{
+		"TraceID": m.Headers.TraceID,
+	})
+	return nil
+}
+
+func (m *OrderOut) MarshalPulsar(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *OrderIn) UnmarshalOrdersPulsar(envelope pulsar.EnvelopeReader) error {
+	return m.UnmarshalEnvelopePulsar(envelope)
+}
+
+func (m *OrderIn) UnmarshalEnvelopePulsar(envelope pulsar.EnvelopeReader) error {
+	if err := m.UnmarshalPulsar(envelope); err != nil {
+		return err
+	}
+	headers := envelope.Headers()
+	if v, ok := headers["TraceID"]; ok {
+		switch tv := v.(type) {
+		case string:
+			m.headers.TraceID = tv
+		case []byte:
+			m.headers.TraceID = string(tv)
+		}
+	}
+	return nil
+}
+
+func (m *OrderIn) UnmarshalPulsar(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
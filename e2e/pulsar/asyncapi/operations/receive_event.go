@@ -0,0 +1,123 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/proto/pulsar"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+type ReceiveEventServerPulsar interface {
+	OpenEventsPulsar(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.EventsPulsar, error)
+	OpenReceiveEventPulsar(context.Context, ...run.MiddlewareOption) (*ReceiveEventPulsar, error)
+	Producer() pulsar.Producer
+	Consumer() pulsar.Consumer
+}
+
+func OpenReceiveEventPulsar(
+	ctx context.Context,
+	server ReceiveEventServerPulsar,
+
+	opts ...run.MiddlewareOption,
+) (*ReceiveEventPulsar, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "events",
+			Operation: "receiveEvent",
+			Protocol:  "pulsar",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, receiveEventPulsarMetrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenEventsPulsar(
+		run.WithOperationName(ctx, "receiveEvent"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReceiveEventPulsar{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// receiveEventPulsarEnvelopeReader counts the payload bytes read from the envelope.
+type receiveEventPulsarEnvelopeReader struct {
+	pulsar.EnvelopeReader
+	size int
+}
+
+func (e *receiveEventPulsarEnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// receiveEventPulsarMetrics returns the middleware that reports the received messages metrics.
+func receiveEventPulsarMetrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[pulsar.EnvelopeReader]) run.SubscribeHandler[pulsar.EnvelopeReader] {
+		return func(ctx context.Context, envelope pulsar.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.EventIn:
+				labels.Message = "event"
+			}
+			counter := &receiveEventPulsarEnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type ReceiveEventChannelPulsar interface {
+	Close() error
+
+	SealEvent(pulsar.EnvelopeWriter, channels.EventsEnvelopeMarshalerPulsar) error
+	PublishEvent(context.Context, channels.EventsEnvelopeMarshalerPulsar) error
+
+	UnsealEvent(pulsar.EnvelopeReader, channels.EventsEnvelopeUnmarshalerPulsar) error
+	SubscribeEvent(context.Context, func(context.Context, messages.EventReceiver) error) error
+}
+
+type ReceiveEventPulsar struct {
+	Channel      ReceiveEventChannelPulsar
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c ReceiveEventPulsar) Close() error {
+	return c.Channel.Close()
+}
+
+func (o ReceiveEventPulsar) UnsealEvent(
+	envelope pulsar.EnvelopeReader,
+	message channels.EventsEnvelopeUnmarshalerPulsar,
+) error {
+	return o.Channel.UnsealEvent(envelope, message)
+}
+
+func (o ReceiveEventPulsar) SubscribeEvent(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.EventReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeEvent(ctx, cb)
+}
@@ -0,0 +1,123 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/proto/pulsar"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+type ReceiveOrderServerPulsar interface {
+	OpenOrdersPulsar(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersPulsar, error)
+	OpenReceiveOrderPulsar(context.Context, ...run.MiddlewareOption) (*ReceiveOrderPulsar, error)
+	Producer() pulsar.Producer
+	Consumer() pulsar.Consumer
+}
+
+func OpenReceiveOrderPulsar(
+	ctx context.Context,
+	server ReceiveOrderServerPulsar,
+
+	opts ...run.MiddlewareOption,
+) (*ReceiveOrderPulsar, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "receiveOrder",
+			Protocol:  "pulsar",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, receiveOrderPulsarMetrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenOrdersPulsar(
+		run.WithOperationName(ctx, "receiveOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReceiveOrderPulsar{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// receiveOrderPulsarEnvelopeReader counts the payload bytes read from the envelope.
+type receiveOrderPulsarEnvelopeReader struct {
+	pulsar.EnvelopeReader
+	size int
+}
+
+func (e *receiveOrderPulsarEnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// receiveOrderPulsarMetrics returns the middleware that reports the received messages metrics.
+func receiveOrderPulsarMetrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[pulsar.EnvelopeReader]) run.SubscribeHandler[pulsar.EnvelopeReader] {
+		return func(ctx context.Context, envelope pulsar.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.OrderIn:
+				labels.Message = "order"
+			}
+			counter := &receiveOrderPulsarEnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type ReceiveOrderChannelPulsar interface {
+	Close() error
+
+	SealOrder(pulsar.EnvelopeWriter, channels.OrdersEnvelopeMarshalerPulsar) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerPulsar) error
+
+	UnsealOrder(pulsar.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerPulsar) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+}
+
+type ReceiveOrderPulsar struct {
+	Channel      ReceiveOrderChannelPulsar
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c ReceiveOrderPulsar) Close() error {
+	return c.Channel.Close()
+}
+
+func (o ReceiveOrderPulsar) UnsealOrder(
+	envelope pulsar.EnvelopeReader,
+	message channels.OrdersEnvelopeUnmarshalerPulsar,
+) error {
+	return o.Channel.UnsealOrder(envelope, message)
+}
+
+func (o ReceiveOrderPulsar) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeOrder(ctx, cb)
+}
@@ -0,0 +1,120 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/proto/pulsar"
+	"github.com/bdragon300/go-asyncapi/run"
+	"time"
+)
+
+type SendEventServerPulsar interface {
+	OpenEventsPulsar(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.EventsPulsar, error)
+	OpenSendEventPulsar(context.Context, ...run.MiddlewareOption) (*SendEventPulsar, error)
+	Producer() pulsar.Producer
+	Consumer() pulsar.Consumer
+}
+
+func OpenSendEventPulsar(
+	ctx context.Context,
+	server SendEventServerPulsar,
+
+	opts ...run.MiddlewareOption,
+) (*SendEventPulsar, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "events",
+			Operation: "sendEvent",
+			Protocol:  "pulsar",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenEventsPulsar(
+		run.WithOperationName(ctx, "sendEvent"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendEventPulsar{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// sendEventPulsarEnvelopeWriter counts the payload bytes written to the envelope.
+type sendEventPulsarEnvelopeWriter struct {
+	pulsar.EnvelopeWriter
+	size int
+}
+
+func (e *sendEventPulsarEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type SendEventChannelPulsar interface {
+	Close() error
+
+	SealEvent(pulsar.EnvelopeWriter, channels.EventsEnvelopeMarshalerPulsar) error
+	PublishEvent(context.Context, channels.EventsEnvelopeMarshalerPulsar) error
+
+	UnsealEvent(pulsar.EnvelopeReader, channels.EventsEnvelopeUnmarshalerPulsar) error
+	SubscribeEvent(context.Context, func(context.Context, messages.EventReceiver) error) error
+	PublishEnvelope(context.Context, pulsar.EnvelopeWriter, any) error
+}
+
+type SendEventPulsar struct {
+	Channel      SendEventChannelPulsar
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c SendEventPulsar) Close() error {
+	return c.Channel.Close()
+}
+
+func (o SendEventPulsar) SealEvent(
+	envelope pulsar.EnvelopeWriter,
+	message channels.EventsEnvelopeMarshalerPulsar,
+) error {
+	return o.Channel.SealEvent(envelope, message)
+}
+
+func (o SendEventPulsar) PublishEvent(
+	ctx context.Context,
+
+	message channels.EventsEnvelopeMarshalerPulsar,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishEvent(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "event"
+	envelope := pulsar.NewEnvelopeOut(nil)
+	counter := &sendEventPulsarEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealEvent(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
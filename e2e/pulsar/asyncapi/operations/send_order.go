@@ -0,0 +1,120 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/proto/pulsar"
+	"github.com/bdragon300/go-asyncapi/run"
+	"time"
+)
+
+type SendOrderServerPulsar interface {
+	OpenOrdersPulsar(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersPulsar, error)
+	OpenSendOrderPulsar(context.Context, ...run.MiddlewareOption) (*SendOrderPulsar, error)
+	Producer() pulsar.Producer
+	Consumer() pulsar.Consumer
+}
+
+func OpenSendOrderPulsar(
+	ctx context.Context,
+	server SendOrderServerPulsar,
+
+	opts ...run.MiddlewareOption,
+) (*SendOrderPulsar, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "sendOrder",
+			Protocol:  "pulsar",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenOrdersPulsar(
+		run.WithOperationName(ctx, "sendOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendOrderPulsar{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// sendOrderPulsarEnvelopeWriter counts the payload bytes written to the envelope.
+type sendOrderPulsarEnvelopeWriter struct {
+	pulsar.EnvelopeWriter
+	size int
+}
+
+func (e *sendOrderPulsarEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type SendOrderChannelPulsar interface {
+	Close() error
+
+	SealOrder(pulsar.EnvelopeWriter, channels.OrdersEnvelopeMarshalerPulsar) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerPulsar) error
+
+	UnsealOrder(pulsar.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerPulsar) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+	PublishEnvelope(context.Context, pulsar.EnvelopeWriter, any) error
+}
+
+type SendOrderPulsar struct {
+	Channel      SendOrderChannelPulsar
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c SendOrderPulsar) Close() error {
+	return c.Channel.Close()
+}
+
+func (o SendOrderPulsar) SealOrder(
+	envelope pulsar.EnvelopeWriter,
+	message channels.OrdersEnvelopeMarshalerPulsar,
+) error {
+	return o.Channel.SealOrder(envelope, message)
+}
+
+func (o SendOrderPulsar) PublishOrder(
+	ctx context.Context,
+
+	message channels.OrdersEnvelopeMarshalerPulsar,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishOrder(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "order"
+	envelope := pulsar.NewEnvelopeOut(nil)
+	counter := &sendOrderPulsarEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealOrder(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
@@ -0,0 +1,129 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package pulsar
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+)
+
+const (
+	defaultTenant           = "public"
+	defaultPersistence      = "persistent"
+	DefaultSubscriptionName = "go-asyncapi"
+)
+
+// ClientOption modifies the Pulsar client options before connecting.
+type ClientOption func(opts *pulsar.ClientOptions)
+
+func NewClient(serverURL string, bindings *ServerBindings, security run.AnySecurityScheme, extraOpts ...ClientOption) (*Client, error) {
+	opts := pulsar.ClientOptions{URL: serverURL}
+	if security != nil {
+		auth, err := getAuth(security)
+		if err != nil {
+			return nil, err
+		}
+		opts.Authentication = auth
+	}
+	for _, o := range extraOpts {
+		o(&opts)
+	}
+
+	cl, err := pulsar.NewClient(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Client{Client: cl, Tenant: defaultTenant}
+	if bindings != nil && bindings.Tenant != "" {
+		res.Tenant = bindings.Tenant
+	}
+	return res, nil
+}
+
+type Client struct {
+	pulsar.Client
+	// Tenant is used to build the fully qualified topic names. Taken from the server bindings, "public" by default.
+	Tenant string
+	// ConsumerOptions returns the options of consumer, that is created on subscribing to the topic.
+	// If nil, DefaultConsumerOptions is used.
+	ConsumerOptions func(topic string, opBindings *OperationBindings) pulsar.ConsumerOptions
+}
+
+func (c *Client) Publisher(_ context.Context, address string, chb *ChannelBindings, _ *OperationBindings, security run.AnySecurityScheme) (Publisher, error) {
+	if security != nil {
+		return nil, fmt.Errorf("operation security schemes are not supported")
+	}
+
+	topic := c.TopicName(address, chb)
+	producer, err := c.CreateProducer(pulsar.ProducerOptions{Topic: topic})
+	if err != nil {
+		return nil, fmt.Errorf("create producer for topic %q: %w", topic, err)
+	}
+	return &PublishChannel{Producer: producer}, nil
+}
+
+func (c *Client) Subscriber(_ context.Context, address string, chb *ChannelBindings, opb *OperationBindings, security run.AnySecurityScheme) (Subscriber, error) {
+	if security != nil {
+		return nil, fmt.Errorf("operation security schemes are not supported")
+	}
+
+	topic := c.TopicName(address, chb)
+	consumerOptions := DefaultConsumerOptions(topic, opb)
+	if c.ConsumerOptions != nil {
+		consumerOptions = c.ConsumerOptions(topic, opb)
+	}
+	consumer, err := c.Subscribe(consumerOptions)
+	if err != nil {
+		return nil, fmt.Errorf("subscribe to topic %q: %w", topic, err)
+	}
+	return &SubscribeChannel{Consumer: consumer}, nil
+}
+
+func (c *Client) Close() error {
+	c.Client.Close()
+	return nil
+}
+
+// TopicName returns the fully qualified topic name "{persistence}://{tenant}/{namespace}/{address}" if namespace
+// is set in channel bindings. Otherwise, the address is returned as is, so it may be either the short topic
+// name (that Pulsar resolves to the "public" tenant and "default" namespace) or the fully qualified one.
+func (c *Client) TopicName(address string, chb *ChannelBindings) string {
+	if chb == nil || chb.Namespace == "" || strings.Contains(address, "://") {
+		return address
+	}
+	persistence := chb.Persistence
+	if persistence == "" {
+		persistence = defaultPersistence
+	}
+	return fmt.Sprintf("%s://%s/%s/%s", persistence, c.Tenant, chb.Namespace, address)
+}
+
+// DefaultConsumerOptions returns the options of the shared subscription with DefaultSubscriptionName, so all
+// the subscribers of the topic share the messages.
+func DefaultConsumerOptions(topic string, _ *OperationBindings) pulsar.ConsumerOptions {
+	return pulsar.ConsumerOptions{
+		Topic:            topic,
+		SubscriptionName: DefaultSubscriptionName,
+		Type:             pulsar.Shared,
+	}
+}
+
+func getAuth(security run.AnySecurityScheme) (pulsar.Authentication, error) {
+	switch v := security.(type) {
+	case run.UserPasswordSecurity:
+		u, p := v.UserPassword()
+		return pulsar.NewAuthenticationBasic(u, p)
+	case run.APIKeySecurity:
+		k := v.APIKey()
+		return pulsar.NewAuthenticationToken(k), nil
+	}
+	return nil, fmt.Errorf("unsupported security scheme: %v", security.AuthType())
+}
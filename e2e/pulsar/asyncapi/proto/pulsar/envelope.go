@@ -0,0 +1,97 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package pulsar
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+)
+
+func NewEnvelopeOut(buf []byte) *EnvelopeOut {
+	return &EnvelopeOut{ProducerMessage: &pulsar.ProducerMessage{Payload: buf}}
+}
+
+type EnvelopeOut struct {
+	*pulsar.ProducerMessage
+	messageBindings MessageBindings
+}
+
+func (e *EnvelopeOut) Write(p []byte) (n int, err error) {
+	e.Payload = append(e.Payload, p...)
+	return len(p), nil
+}
+
+func (e *EnvelopeOut) ResetPayload() {
+	e.Payload = e.Payload[:0]
+}
+
+func (e *EnvelopeOut) SetHeaders(headers run.Headers) {
+	if e.Properties == nil {
+		e.Properties = make(map[string]string, len(headers))
+	}
+	for k, v := range headers.ToByteValues() {
+		e.Properties[k] = string(v)
+	}
+}
+
+func (e *EnvelopeOut) SetContentType(contentType string) {
+	if e.Properties == nil {
+		e.Properties = make(map[string]string)
+	}
+	e.Properties["Content-Type"] = contentType
+}
+
+func (e *EnvelopeOut) SetBindings(bindings MessageBindings) {
+	e.messageBindings = bindings
+}
+
+func NewEnvelopeIn(msg pulsar.Message, consumer pulsar.Consumer) *EnvelopeIn {
+	return &EnvelopeIn{
+		Message:  msg,
+		consumer: consumer,
+		rd:       bytes.NewReader(msg.Payload()),
+	}
+}
+
+type EnvelopeIn struct {
+	pulsar.Message
+	consumer pulsar.Consumer
+	rd       io.Reader
+	settled  bool
+}
+
+func (e *EnvelopeIn) Read(p []byte) (n int, err error) {
+	return e.rd.Read(p)
+}
+
+func (e *EnvelopeIn) Headers() run.Headers {
+	props := e.Properties()
+	hdrs := make(run.Headers, len(props))
+	for k, v := range props {
+		hdrs[k] = []byte(v)
+	}
+	return hdrs
+}
+
+// Ack acknowledges the message.
+func (e *EnvelopeIn) Ack() error {
+	e.settled = true
+	return e.consumer.Ack(e.Message)
+}
+
+// Nack negatively acknowledges the message, so it will be redelivered later.
+func (e *EnvelopeIn) Nack() {
+	e.settled = true
+	e.consumer.Nack(e.Message)
+}
+
+// Settled returns true if the message has been acknowledged by Ack or Nack.
+func (e *EnvelopeIn) Settled() bool {
+	return e.settled
+}
@@ -0,0 +1,29 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package pulsar
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+)
+
+type PublishChannel struct {
+	Producer pulsar.Producer
+}
+
+// Send sends the envelopes one by one, waiting for the acknowledgement from the broker for each of them.
+func (p PublishChannel) Send(ctx context.Context, envelopes ...EnvelopeWriter) error {
+	for i, envelope := range envelopes {
+		if _, err := p.Producer.Send(ctx, envelope.(*EnvelopeOut).ProducerMessage); err != nil {
+			return fmt.Errorf("envelope #%d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (p PublishChannel) Close() error {
+	p.Producer.Close()
+	return nil
+}
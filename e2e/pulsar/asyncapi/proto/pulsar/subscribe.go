@@ -0,0 +1,46 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package pulsar
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+)
+
+type SubscribeChannel struct {
+	Consumer pulsar.Consumer
+}
+
+// Receive receives the messages and calls cb for each of them. The message is acknowledged after cb returns, unless
+// cb has already acknowledged it by Ack or Nack methods of envelope.
+func (s SubscribeChannel) Receive(ctx context.Context, cb func(envelope EnvelopeReader)) error {
+	run.NotifySubscribeReady(ctx)
+	for {
+		msg, err := s.Consumer.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("receive: %w", err)
+		}
+
+		envelope := NewEnvelopeIn(msg, s.Consumer)
+		cb(envelope)
+		if !envelope.Settled() {
+			if err = envelope.Ack(); err != nil {
+				return fmt.Errorf("ack: %w", err)
+			}
+		}
+	}
+}
+
+func (s SubscribeChannel) Close() error {
+	s.Consumer.Close()
+	return nil
+}
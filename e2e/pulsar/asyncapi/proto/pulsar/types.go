@@ -0,0 +1,31 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package pulsar
+
+import (
+	"time"
+)
+
+type (
+	ServerBindings struct {
+		Tenant string
+	}
+
+	ChannelBindings struct {
+		Namespace      string
+		Persistence    string
+		Compaction     int // MB
+		GeoReplication []string
+		Retention      Retention
+		TTL            time.Duration
+		Deduplication  bool
+	}
+
+	Retention struct {
+		Time time.Duration
+		Size int // MB
+	}
+
+	OperationBindings struct{}
+	MessageBindings   struct{}
+)
@@ -0,0 +1,178 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package servers
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/proto/pulsar"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+	"net/url"
+)
+
+func MainURL() (*url.URL, error) {
+	return &url.URL{Scheme: "pulsar", Host: "localhost:6650", Path: ""}, nil
+}
+
+type MainBindings struct{}
+
+func (c MainBindings) Pulsar() pulsar.ServerBindings {
+	return pulsar.ServerBindings{
+		Tenant: "acme",
+	}
+}
+
+func NewMain(producer pulsar.Producer, consumer pulsar.Consumer) *Main {
+	return &Main{
+		producer: producer,
+		consumer: consumer,
+	}
+}
+
+type MainClosable struct {
+	Main
+}
+
+func (c MainClosable) Close() error {
+	var err error
+	if v, ok := any(c.producer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	if v, ok := any(c.consumer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	return err
+}
+
+func ConnectMainBidi(
+	_ context.Context,
+	url *url.URL,
+
+	opts ...pulsar.ClientOption,
+) (*MainClosable, error) {
+	var bindings *pulsar.ServerBindings
+	bindings = run.ToPtr(MainBindings{}.Pulsar())
+	client, err := pulsar.NewClient(url.String(), bindings, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	producer, consumer := client, client
+	return &MainClosable{
+		Main{producer: producer, consumer: consumer},
+	}, nil
+}
+
+func ConnectMainProducer(
+	_ context.Context,
+	url *url.URL,
+
+	opts ...pulsar.ClientOption,
+) (*MainClosable, error) {
+	var bindings *pulsar.ServerBindings
+	bindings = run.ToPtr(MainBindings{}.Pulsar())
+	producer, err := pulsar.NewClient(url.String(), bindings, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &MainClosable{
+		Main{producer: producer},
+	}, nil
+}
+
+func ConnectMainConsumer(
+	_ context.Context,
+	url *url.URL,
+
+	opts ...pulsar.ClientOption,
+) (*MainClosable, error) {
+	var bindings *pulsar.ServerBindings
+	bindings = run.ToPtr(MainBindings{}.Pulsar())
+	consumer, err := pulsar.NewClient(url.String(), bindings, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &MainClosable{
+		Main{consumer: consumer},
+	}, nil
+}
+
+type Main struct {
+	producer pulsar.Producer
+	consumer pulsar.Consumer
+}
+
+func (s Main) Name() string {
+	return "Main"
+}
+
+func (s Main) Producer() pulsar.Producer {
+	return s.producer
+}
+
+func (s Main) Consumer() pulsar.Consumer {
+	return s.consumer
+}
+func (s Main) Bindings() pulsar.ServerBindings {
+	return MainBindings{}.Pulsar()
+}
+
+func (s Main) OpenOrdersPulsar(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.OrdersPulsar, error) {
+	return channels.OpenOrdersPulsar(
+		ctx, s, nil, security, opts...,
+	)
+}
+func (s Main) OpenEventsPulsar(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.EventsPulsar, error) {
+	return channels.OpenEventsPulsar(
+		ctx, s, nil, security, opts...,
+	)
+}
+
+func (s Main) OpenReceiveOrderPulsar(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.ReceiveOrderPulsar, error) {
+	return operations.OpenReceiveOrderPulsar(
+		ctx, s, opts...,
+	)
+}
+func (s Main) OpenSendOrderPulsar(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.SendOrderPulsar, error) {
+	return operations.OpenSendOrderPulsar(
+		ctx, s, opts...,
+	)
+}
+func (s Main) OpenReceiveEventPulsar(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.ReceiveEventPulsar, error) {
+	return operations.OpenReceiveEventPulsar(
+		ctx, s, opts...,
+	)
+}
+func (s Main) OpenSendEventPulsar(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.SendEventPulsar, error) {
+	return operations.OpenSendEventPulsar(
+		ctx, s, opts...,
+	)
+}
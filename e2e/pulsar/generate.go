@@ -0,0 +1,4 @@
+// Package pulsar checks the Apache Pulsar implementation against the in-memory Pulsar client.
+package pulsar
+
+//go:generate go -C ../.. run ./cmd/go-asyncapi code -t e2e/pulsar/asyncapi -M github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi e2e/pulsar/asyncapi.yaml
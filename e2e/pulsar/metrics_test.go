@@ -0,0 +1,135 @@
+package pulsar
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/proto/pulsar"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+func TestMetrics(t *testing.T) {
+	ctx, server, fake := connect(t)
+	metrics := &fakeMetrics{}
+	errHandle := errors.New("handle failed")
+
+	sendOp, err := operations.OpenSendOrderPulsar(ctx, server, run.WithMetrics(metrics))
+	if err != nil {
+		t.Fatalf("open send operation: %v", err)
+	}
+	defer sendOp.Close()
+	recvOp, err := operations.OpenReceiveOrderPulsar(ctx, server, run.WithMetrics(metrics))
+	if err != nil {
+		t.Fatalf("open receive operation: %v", err)
+	}
+	defer recvOp.Close()
+
+	for _, id := range []string{"o1", "o2"} {
+		if err = sendOp.PublishOrder(ctx, new(messages.OrderOut).SetPayload(schemas.Order{ID: id})); err != nil {
+			t.Fatalf("PublishOrder() error = %v", err)
+		}
+	}
+	if err = sendOp.PublishOrder(ctx, failingMarshaler{}); err == nil {
+		t.Fatal("PublishOrder() error = nil, want marshal error")
+	}
+
+	// Error returned from the callback stops the subscription
+	subCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	err = recvOp.SubscribeOrder(subCtx, func(_ context.Context, message messages.OrderReceiver) error {
+		if message.Payload().ID == "o2" {
+			return errHandle
+		}
+		return nil
+	})
+	if !errors.Is(err, errHandle) {
+		t.Fatalf("SubscribeOrder() error = %v, want %v", err, errHandle)
+	}
+
+	fake.topic("non-persistent://acme/shop/orders").queue <- &fakeMessage{payload: []byte("not json")}
+	err = recvOp.SubscribeOrder(subCtx, func(context.Context, messages.OrderReceiver) error {
+		t.Error("callback is called for malformed message")
+		return nil
+	})
+	if !errors.Is(err, run.ErrUnsealEnvelope) {
+		t.Fatalf("SubscribeOrder() error = %v, want %v", err, run.ErrUnsealEnvelope)
+	}
+
+	const size = len(`{"id":"o1","amount":0}`) + 1 // Encoder adds a newline
+	// Server label is the Go name of the server
+	send := run.MetricLabels{Server: "Main", Channel: "orders", Operation: "sendOrder", Message: "order", Protocol: "pulsar"}
+	receive := run.MetricLabels{Server: "Main", Channel: "orders", Operation: "receiveOrder", Message: "order", Protocol: "pulsar"}
+	want := []metricCall{
+		{name: "published", labels: send, size: size},
+		{name: "published", labels: send, size: size},
+		{name: "marshal failed", labels: send, err: errMarshal},
+		{name: "received", labels: receive, size: size},
+		{name: "received", labels: receive, size: size, err: errHandle},
+		{name: "unmarshal failed", labels: receive, err: run.ErrUnsealEnvelope},
+		{name: "received", labels: receive, size: len("not json"), err: run.ErrUnsealEnvelope},
+	}
+	if got := metrics.get(); !slices.EqualFunc(got, want, metricCall.equal) {
+		t.Errorf("metrics calls:\n%+v\nwant:\n%+v", got, want)
+	}
+}
+
+var errMarshal = errors.New("marshal failed")
+
+type failingMarshaler struct{}
+
+func (failingMarshaler) MarshalOrdersPulsar(pulsar.EnvelopeWriter) error {
+	return errMarshal
+}
+
+type metricCall struct {
+	name   string
+	labels run.MetricLabels
+	size   int
+	err    error
+}
+
+func (c metricCall) equal(other metricCall) bool {
+	return c.name == other.name && c.labels == other.labels && c.size == other.size &&
+		errors.Is(c.err, other.err) && (c.err == nil) == (other.err == nil)
+}
+
+// fakeMetrics records the calls of run.Metrics methods.
+type fakeMetrics struct {
+	mu    sync.Mutex
+	calls []metricCall
+}
+
+func (m *fakeMetrics) MessagePublished(labels run.MetricLabels, size int, _ time.Duration, err error) {
+	m.add(metricCall{name: "published", labels: labels, size: size, err: err})
+}
+
+func (m *fakeMetrics) MessageReceived(labels run.MetricLabels, size int, err error) {
+	m.add(metricCall{name: "received", labels: labels, size: size, err: err})
+}
+
+func (m *fakeMetrics) MarshalFailed(labels run.MetricLabels, err error) {
+	m.add(metricCall{name: "marshal failed", labels: labels, err: err})
+}
+
+func (m *fakeMetrics) UnmarshalFailed(labels run.MetricLabels, err error) {
+	m.add(metricCall{name: "unmarshal failed", labels: labels, err: err})
+}
+
+func (m *fakeMetrics) add(c metricCall) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, c)
+}
+
+func (m *fakeMetrics) get() []metricCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.calls)
+}
@@ -0,0 +1,296 @@
+package pulsar
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	pulsarclient "github.com/apache/pulsar-client-go/pulsar"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/proto/pulsar"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/e2e/pulsar/asyncapi/servers"
+)
+
+func TestRoundTrip(t *testing.T) {
+	ctx, server, fake := connect(t)
+
+	recvOp, err := operations.OpenReceiveOrderPulsar(ctx, server)
+	if err != nil {
+		t.Fatalf("open receive operation: %v", err)
+	}
+	defer recvOp.Close()
+	sendOp, err := operations.OpenSendOrderPulsar(ctx, server)
+	if err != nil {
+		t.Fatalf("open send operation: %v", err)
+	}
+	defer sendOp.Close()
+
+	for i := 1; i <= 3; i++ {
+		msg := new(messages.OrderOut).SetPayload(schemas.Order{ID: "o1", Amount: i})
+		msg.Headers.TraceID = "abc"
+		if err = sendOp.PublishOrder(ctx, msg); err != nil {
+			t.Fatalf("PublishOrder() error = %v", err)
+		}
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var got []int
+	err = recvOp.SubscribeOrder(subCtx, func(_ context.Context, message messages.OrderReceiver) error {
+		got = append(got, message.Payload().Amount)
+		if message.Headers().TraceID != "abc" {
+			t.Errorf("TraceID header = %q, want %q", message.Headers().TraceID, "abc")
+		}
+		if len(got) == 3 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("SubscribeOrder() error = %v, want context.Canceled", err)
+	}
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("received orders = %v, want [1 2 3]", got)
+	}
+
+	// Topic name is built from tenant in server bindings, namespace and persistence in channel bindings. Both operations
+	// open the producer and consumer, since the connection is bidirectional.
+	const topic = "non-persistent://acme/shop/orders"
+	if want := []string{topic, topic}; !slices.Equal(fake.producers, want) {
+		t.Errorf("producers topics = %v, want %v", fake.producers, want)
+	}
+	if len(fake.consumers) != 2 {
+		t.Fatalf("consumers count = %d, want 2", len(fake.consumers))
+	}
+	for _, c := range fake.consumers {
+		if c.Topic != topic || c.SubscriptionName != pulsar.DefaultSubscriptionName || c.Type != pulsarclient.Shared {
+			t.Errorf("consumer topic = %q, subscription = %q, type = %v, want %q, %q, %v",
+				c.Topic, c.SubscriptionName, c.Type, topic, pulsar.DefaultSubscriptionName, pulsarclient.Shared)
+		}
+	}
+	// Messages are acknowledged automatically after callback returns
+	if acked := fake.topic(topic).acked(); len(acked) != 3 {
+		t.Errorf("acked messages = %d, want 3", len(acked))
+	}
+	if props := fake.topic(topic).lastProperties; props["Content-Type"] != "application/json" || props["TraceID"] != "abc" {
+		t.Errorf("message properties = %v, want Content-Type and TraceID", props)
+	}
+}
+
+func TestTopicNameWithoutNamespace(t *testing.T) {
+	ctx, server, fake := connect(t)
+
+	sendOp, err := operations.OpenSendEventPulsar(ctx, server)
+	if err != nil {
+		t.Fatalf("open send operation: %v", err)
+	}
+	defer sendOp.Close()
+	if err = sendOp.PublishEvent(ctx, new(messages.EventOut).SetPayload(schemas.Order{ID: "e1"})); err != nil {
+		t.Fatalf("PublishEvent() error = %v", err)
+	}
+
+	// Address is used as topic name as is
+	if want := []string{"events"}; !slices.Equal(fake.producers, want) {
+		t.Errorf("producers topics = %v, want %v", fake.producers, want)
+	}
+}
+
+func TestNack(t *testing.T) {
+	ctx, server, fake := connect(t)
+	client := server.Consumer().(*pulsar.Client)
+	client.ConsumerOptions = func(topic string, _ *pulsar.OperationBindings) pulsarclient.ConsumerOptions {
+		return pulsarclient.ConsumerOptions{Topic: topic, SubscriptionName: "custom", Type: pulsarclient.Exclusive}
+	}
+
+	ch, err := channels.OpenEventsPulsar(ctx, server, nil, nil)
+	if err != nil {
+		t.Fatalf("open channel: %v", err)
+	}
+	defer ch.Close()
+	if err = ch.PublishEvent(ctx, new(messages.EventOut).SetPayload(schemas.Order{ID: "e1"})); err != nil {
+		t.Fatalf("PublishEvent() error = %v", err)
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	deliveries := 0
+	err = ch.Subscribe(subCtx, func(envelope pulsar.EnvelopeReader) {
+		var m messages.EventIn
+		if err := ch.UnsealEvent(envelope, &m); err != nil {
+			t.Errorf("UnsealEvent() error = %v", err)
+		}
+		if m.Payload().ID != "e1" {
+			t.Errorf("payload ID = %q, want %q", m.Payload().ID, "e1")
+		}
+		// Nacked message is redelivered, and then acknowledged automatically
+		if deliveries++; deliveries == 1 {
+			envelope.(*pulsar.EnvelopeIn).Nack()
+			return
+		}
+		cancel()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Subscribe() error = %v, want context.Canceled", err)
+	}
+
+	topic := fake.topic("events")
+	if deliveries != 2 || len(topic.nacked()) != 1 || len(topic.acked()) != 1 {
+		t.Errorf("deliveries = %d, nacked = %d, acked = %d, want 2, 1, 1", deliveries, len(topic.nacked()), len(topic.acked()))
+	}
+	if len(fake.consumers) != 1 || fake.consumers[0].SubscriptionName != "custom" || fake.consumers[0].Type != pulsarclient.Exclusive {
+		t.Errorf("consumers = %+v, want custom exclusive subscription", fake.consumers)
+	}
+}
+
+// connect connects to the server and replaces the Pulsar client with the in-memory one.
+func connect(t *testing.T) (context.Context, *servers.Main, *fakeClient) {
+	t.Helper()
+	ctx := t.Context()
+	conn, err := servers.ConnectMainBidi(ctx, &url.URL{Scheme: "pulsar", Host: "localhost:6650"})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	client := conn.Producer().(*pulsar.Client)
+	client.Client.Close() // No connection is established until the first producer or consumer is created
+	fake := &fakeClient{topics: make(map[string]*fakeTopic)}
+	client.Client = fake
+	return ctx, &conn.Main, fake
+}
+
+// fakeClient is the in-memory Pulsar client. Every topic has one shared subscription, so all consumers of the topic
+// share its messages.
+type fakeClient struct {
+	pulsarclient.Client // Other methods are not used
+
+	mu        sync.Mutex
+	topics    map[string]*fakeTopic
+	producers []string
+	consumers []pulsarclient.ConsumerOptions
+}
+
+func (c *fakeClient) CreateProducer(opts pulsarclient.ProducerOptions) (pulsarclient.Producer, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.producers = append(c.producers, opts.Topic)
+	return &fakeProducer{topic: c.getTopic(opts.Topic)}, nil
+}
+
+func (c *fakeClient) Subscribe(opts pulsarclient.ConsumerOptions) (pulsarclient.Consumer, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.consumers = append(c.consumers, opts)
+	return &fakeConsumer{topic: c.getTopic(opts.Topic)}, nil
+}
+
+func (c *fakeClient) Close() {}
+
+func (c *fakeClient) topic(name string) *fakeTopic {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.getTopic(name)
+}
+
+func (c *fakeClient) getTopic(name string) *fakeTopic {
+	if _, ok := c.topics[name]; !ok {
+		c.topics[name] = &fakeTopic{name: name, queue: make(chan *fakeMessage, 100)}
+	}
+	return c.topics[name]
+}
+
+type fakeTopic struct {
+	name  string
+	queue chan *fakeMessage
+
+	mu             sync.Mutex
+	lastProperties map[string]string
+	settled        []string
+}
+
+func (t *fakeTopic) settle(kind string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.settled = append(t.settled, kind)
+}
+
+func (t *fakeTopic) acked() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return slices.DeleteFunc(slices.Clone(t.settled), func(s string) bool { return s != "ack" })
+}
+
+func (t *fakeTopic) nacked() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return slices.DeleteFunc(slices.Clone(t.settled), func(s string) bool { return s != "nack" })
+}
+
+type fakeProducer struct {
+	pulsarclient.Producer // Other methods are not used
+	topic                 *fakeTopic
+}
+
+func (p *fakeProducer) Send(_ context.Context, msg *pulsarclient.ProducerMessage) (pulsarclient.MessageID, error) {
+	p.topic.mu.Lock()
+	p.topic.lastProperties = msg.Properties
+	p.topic.mu.Unlock()
+	// Payload buffer may be reused by the caller
+	p.topic.queue <- &fakeMessage{topic: p.topic.name, payload: slices.Clone(msg.Payload), properties: msg.Properties}
+	return nil, nil
+}
+
+func (p *fakeProducer) Close() {}
+
+type fakeConsumer struct {
+	pulsarclient.Consumer // Other methods are not used
+	topic                 *fakeTopic
+}
+
+func (c *fakeConsumer) Receive(ctx context.Context) (pulsarclient.Message, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case msg := <-c.topic.queue:
+		return msg, nil
+	}
+}
+
+func (c *fakeConsumer) Ack(pulsarclient.Message) error {
+	c.topic.settle("ack")
+	return nil
+}
+
+// Nack redelivers the message immediately, unlike the real broker that does it after a delay.
+func (c *fakeConsumer) Nack(msg pulsarclient.Message) {
+	c.topic.settle("nack")
+	c.topic.queue <- msg.(*fakeMessage)
+}
+
+func (c *fakeConsumer) Close() {}
+
+type fakeMessage struct {
+	pulsarclient.Message // Other methods are not used
+	topic                string
+	payload              []byte
+	properties           map[string]string
+}
+
+func (m *fakeMessage) Topic() string {
+	return m.topic
+}
+
+func (m *fakeMessage) Payload() []byte {
+	return m.payload
+}
+
+func (m *fakeMessage) Properties() map[string]string {
+	return m.properties
+}
@@ -0,0 +1,239 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package channels
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+func PriceRepliesAddress() run.ParamString {
+	return run.ParamString{
+		Expr: "price-replies",
+	}
+}
+
+func NewPriceRepliesKafka(
+
+	publisher kafka.Publisher,
+	subscriber kafka.Subscriber,
+	opts ...run.MiddlewareOption,
+) *PriceRepliesKafka {
+	res := PriceRepliesKafka{
+		address: PriceRepliesAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+
+	res.topic = res.address.String()
+	return &res
+}
+
+type PriceRepliesServerKafka interface {
+	OpenPriceRepliesKafka(context.Context, ...run.MiddlewareOption) (*PriceRepliesKafka, error)
+	Producer() kafka.Producer
+	Consumer() kafka.Consumer
+}
+
+func OpenPriceRepliesKafka(
+	ctx context.Context,
+	server PriceRepliesServerKafka,
+
+	opts ...run.MiddlewareOption,
+) (*PriceRepliesKafka, error) {
+	var err error
+	address, err := PriceRepliesAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher kafka.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			nil,
+			nil,
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber kafka.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			nil,
+			nil,
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewPriceRepliesKafka(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type PriceRepliesKafka struct {
+	address     run.ParamString
+	publisher   kafka.Publisher
+	subscriber  kafka.Subscriber
+	middlewares run.Middlewares
+	topic       string
+}
+
+func (c PriceRepliesKafka) Topic() string {
+	return c.topic
+}
+
+func (c PriceRepliesKafka) Address() run.ParamString {
+	return c.address
+}
+
+func (c PriceRepliesKafka) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type PriceRepliesEnvelopeMarshalerKafka interface {
+	MarshalPriceRepliesKafka(envelope kafka.EnvelopeWriter) error
+}
+
+func (c PriceRepliesKafka) SealPriceReply(
+	envelope kafka.EnvelopeWriter,
+	message PriceRepliesEnvelopeMarshalerKafka,
+) error {
+	if err := message.MarshalPriceRepliesKafka(envelope); err != nil {
+		return err
+	}
+
+	envelope.SetTopic(c.Topic())
+	return nil
+}
+
+func (c PriceRepliesKafka) PublishPriceReply(
+	ctx context.Context,
+
+	message PriceRepliesEnvelopeMarshalerKafka,
+) error {
+	envelope := kafka.NewEnvelopeOut(nil)
+	if err := c.SealPriceReply(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c PriceRepliesKafka) PublishEnvelope(ctx context.Context, envelope kafka.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope kafka.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c PriceRepliesKafka) Publisher() kafka.Publisher {
+	return c.publisher
+}
+
+func (c PriceRepliesKafka) Publish(ctx context.Context, envelopes ...kafka.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type PriceRepliesEnvelopeUnmarshalerKafka interface {
+	UnmarshalPriceRepliesKafka(envelope kafka.EnvelopeReader) error
+}
+
+func (c PriceRepliesKafka) UnsealPriceReply(
+	envelope kafka.EnvelopeReader,
+	message PriceRepliesEnvelopeUnmarshalerKafka,
+) error {
+	if err := envelope.VerifyBindings(kafka.MessageBindings{}); err != nil {
+		return err
+	}
+	return message.UnmarshalPriceRepliesKafka(envelope)
+}
+
+// SubscribePriceReply receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c PriceRepliesKafka) SubscribePriceReply(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.PriceReplyReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope kafka.EnvelopeReader, message any) error {
+		m := message.(*messages.PriceReplyIn)
+		if err2 := c.UnsealPriceReply(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope kafka.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) kafka.EnvelopeReader {
+				return &priceRepliesKafkaBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope kafka.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.PriceReplyIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// priceRepliesKafkaBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type priceRepliesKafkaBufferedEnvelope struct {
+	kafka.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *priceRepliesKafkaBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *priceRepliesKafkaBufferedEnvelope) Unwrap() kafka.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c PriceRepliesKafka) Subscriber() kafka.Subscriber {
+	return c.subscriber
+}
+
+func (c PriceRepliesKafka) Subscribe(ctx context.Context, cb func(envelope kafka.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
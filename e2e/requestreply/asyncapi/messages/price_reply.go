@@ -0,0 +1,176 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+	"strings"
+)
+
+type PriceReplySender interface {
+	SetPayload(payload schemas.PriceReply) *PriceReplyOut
+	SetHeaders(headers struct {
+		CorrelationID string `json:"correlationId"`
+	}) *PriceReplyOut
+	SetCorrelationID(value string) *PriceReplyOut
+}
+
+// PriceReplyOut-- (Outbound Message)
+type PriceReplyOut struct {
+	Payload schemas.PriceReply
+	Headers struct {
+		CorrelationID string `json:"correlationId"`
+	}
+}
+
+// Validate checks the PriceReplyOut value against the constraints from the jsonschema definition.
+func (v PriceReplyOut) Validate() error {
+	if err := v.Payload.Validate(); err != nil {
+		return fmt.Errorf("Payload: %w", err)
+	}
+	return nil
+}
+
+func (m *PriceReplyOut) SetPayload(payload schemas.PriceReply) *PriceReplyOut {
+	m.Payload = payload
+	return m
+}
+
+func (m *PriceReplyOut) SetHeaders(headers struct {
+	CorrelationID string `json:"correlationId"`
+}) *PriceReplyOut {
+	m.Headers = headers
+	return m
+}
+func (m *PriceReplyOut) SetCorrelationID(value string) *PriceReplyOut {
+	v0 := m.Headers
+
+	v0.CorrelationID = value
+	m.Headers = v0
+	return m
+}
+
+// ExamplePriceReply returns the example of PriceReply message declared in the document.
+// Returns error if the example contains fields that are not in the message schema.
+func ExamplePriceReply() (PriceReplyOut, error) {
+	var m PriceReplyOut
+	{
+		dec := json.NewDecoder(strings.NewReader("{\"item\":\"apple\",\"price\":3}"))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&m.Payload); err != nil {
+			return m, fmt.Errorf("decode example payload: %w", err)
+		}
+	}
+	{
+		dec := json.NewDecoder(strings.NewReader("{\"correlationId\":\"1\"}"))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&m.Headers); err != nil {
+			return m, fmt.Errorf("decode example headers: %w", err)
+		}
+	}
+	return m, nil
+}
+
+type PriceReplyReceiver interface {
+	Payload() schemas.PriceReply
+	Headers() struct {
+		CorrelationID string `json:"correlationId"`
+	}
+	CorrelationID() (value string, err error)
+}
+
+// PriceReplyIn-- (Inbound Message)
+type PriceReplyIn struct {
+	payload schemas.PriceReply
+	headers struct {
+		CorrelationID string `json:"correlationId"`
+	}
+}
+
+// Validate checks the PriceReplyIn value against the constraints from the jsonschema definition.
+func (v PriceReplyIn) Validate() error {
+	if err := v.payload.Validate(); err != nil {
+		return fmt.Errorf("payload: %w", err)
+	}
+	return nil
+}
+
+func (m *PriceReplyIn) Payload() schemas.PriceReply {
+	return m.payload
+}
+
+func (m *PriceReplyIn) Headers() struct {
+	CorrelationID string `json:"correlationId"`
+} {
+	return m.headers
+}
+func (m PriceReplyIn) CorrelationID() (value string, err error) {
+	v0 := m.headers
+
+	// correlationId
+	v1 := v0.CorrelationID
+	value = v1
+	return
+}
+
+func (m *PriceReplyOut) MarshalPriceRepliesKafka(envelope kafka.EnvelopeWriter) error {
+	return m.MarshalEnvelopeKafka(envelope)
+}
+
+func (m *PriceReplyOut) MarshalEnvelopeKafka(envelope kafka.EnvelopeWriter) error {
+	if err := m.MarshalKafka(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers{
+		"CorrelationID": m.Headers.CorrelationID,
+	})
+	return nil
+}
+
+func (m *PriceReplyOut) MarshalKafka(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *PriceReplyIn) UnmarshalPriceRepliesKafka(envelope kafka.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeKafka(envelope)
+}
+
+func (m *PriceReplyIn) UnmarshalEnvelopeKafka(envelope kafka.EnvelopeReader) error {
+	if err := m.UnmarshalKafka(envelope); err != nil {
+		return err
+	}
+	headers := envelope.Headers()
+	if v, ok := headers["CorrelationID"]; ok {
+		switch tv := v.(type) {
+		case string:
+			m.headers.CorrelationID = tv
+		case []byte:
+			m.headers.CorrelationID = string(tv)
+		}
+	}
+	return nil
+}
+
+func (m *PriceReplyIn) UnmarshalKafka(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
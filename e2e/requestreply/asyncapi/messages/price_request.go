@@ -0,0 +1,176 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+	"strings"
+)
+
+type PriceRequestSender interface {
+	SetPayload(payload schemas.PriceRequest) *PriceRequestOut
+	SetHeaders(headers struct {
+		CorrelationID string `json:"correlationId"`
+	}) *PriceRequestOut
+	SetCorrelationID(value string) *PriceRequestOut
+}
+
+// PriceRequestOut-- (Outbound Message)
+type PriceRequestOut struct {
+	Payload schemas.PriceRequest
+	Headers struct {
+		CorrelationID string `json:"correlationId"`
+	}
+}
+
+// Validate checks the PriceRequestOut value against the constraints from the jsonschema definition.
+func (v PriceRequestOut) Validate() error {
+	if err := v.Payload.Validate(); err != nil {
+		return fmt.Errorf("Payload: %w", err)
+	}
+	return nil
+}
+
+func (m *PriceRequestOut) SetPayload(payload schemas.PriceRequest) *PriceRequestOut {
+	m.Payload = payload
+	return m
+}
+
+func (m *PriceRequestOut) SetHeaders(headers struct {
+	CorrelationID string `json:"correlationId"`
+}) *PriceRequestOut {
+	m.Headers = headers
+	return m
+}
+func (m *PriceRequestOut) SetCorrelationID(value string) *PriceRequestOut {
+	v0 := m.Headers
+
+	v0.CorrelationID = value
+	m.Headers = v0
+	return m
+}
+
+// ExamplePriceRequestApple returns the apple example of PriceRequest message declared in the document.
+// Returns error if the example contains fields that are not in the message schema.
+func ExamplePriceRequestApple() (PriceRequestOut, error) {
+	var m PriceRequestOut
+	{
+		dec := json.NewDecoder(strings.NewReader("{\"item\":\"apple\"}"))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&m.Payload); err != nil {
+			return m, fmt.Errorf("decode example payload: %w", err)
+		}
+	}
+	{
+		dec := json.NewDecoder(strings.NewReader("{\"correlationId\":\"1\"}"))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&m.Headers); err != nil {
+			return m, fmt.Errorf("decode example headers: %w", err)
+		}
+	}
+	return m, nil
+}
+
+type PriceRequestReceiver interface {
+	Payload() schemas.PriceRequest
+	Headers() struct {
+		CorrelationID string `json:"correlationId"`
+	}
+	CorrelationID() (value string, err error)
+}
+
+// PriceRequestIn-- (Inbound Message)
+type PriceRequestIn struct {
+	payload schemas.PriceRequest
+	headers struct {
+		CorrelationID string `json:"correlationId"`
+	}
+}
+
+// Validate checks the PriceRequestIn value against the constraints from the jsonschema definition.
+func (v PriceRequestIn) Validate() error {
+	if err := v.payload.Validate(); err != nil {
+		return fmt.Errorf("payload: %w", err)
+	}
+	return nil
+}
+
+func (m *PriceRequestIn) Payload() schemas.PriceRequest {
+	return m.payload
+}
+
+func (m *PriceRequestIn) Headers() struct {
+	CorrelationID string `json:"correlationId"`
+} {
+	return m.headers
+}
+func (m PriceRequestIn) CorrelationID() (value string, err error) {
+	v0 := m.headers
+
+	// correlationId
+	v1 := v0.CorrelationID
+	value = v1
+	return
+}
+
+func (m *PriceRequestOut) MarshalPricesKafka(envelope kafka.EnvelopeWriter) error {
+	return m.MarshalEnvelopeKafka(envelope)
+}
+
+func (m *PriceRequestOut) MarshalEnvelopeKafka(envelope kafka.EnvelopeWriter) error {
+	if err := m.MarshalKafka(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers{
+		"CorrelationID": m.Headers.CorrelationID,
+	})
+	return nil
+}
+
+func (m *PriceRequestOut) MarshalKafka(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *PriceRequestIn) UnmarshalPricesKafka(envelope kafka.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeKafka(envelope)
+}
+
+func (m *PriceRequestIn) UnmarshalEnvelopeKafka(envelope kafka.EnvelopeReader) error {
+	if err := m.UnmarshalKafka(envelope); err != nil {
+		return err
+	}
+	headers := envelope.Headers()
+	if v, ok := headers["CorrelationID"]; ok {
+		switch tv := v.(type) {
+		case string:
+			m.headers.CorrelationID = tv
+		case []byte:
+			m.headers.CorrelationID = string(tv)
+		}
+	}
+	return nil
+}
+
+func (m *PriceRequestIn) UnmarshalKafka(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package messages
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/proto/kafka"
+	"testing"
+)
+
+func TestExamplePriceRequestApple(t *testing.T) {
+	msg, err := ExamplePriceRequestApple()
+	if err != nil {
+		t.Fatalf("example: %v", err)
+	}
+	if err = msg.Validate(); err != nil {
+		t.Fatalf("validate example: %v", err)
+	}
+
+	t.Run("Kafka", func(t *testing.T) {
+		envelope := kafka.NewEnvelopeOut(nil)
+		if err := msg.MarshalEnvelopeKafka(envelope); err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		var in PriceRequestIn
+		if err := in.UnmarshalEnvelopeKafka(kafka.NewEnvelopeIn(envelope.Message(""))); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		wantPayload, err := json.Marshal(msg.Payload)
+		if err != nil {
+			t.Fatalf("encode sent payload: %v", err)
+		}
+		gotPayload, err := json.Marshal(in.Payload())
+		if err != nil {
+			t.Fatalf("encode received payload: %v", err)
+		}
+		if !bytes.Equal(wantPayload, gotPayload) {
+			t.Errorf("payload mismatch:\nwant: %s\n got: %s", wantPayload, gotPayload)
+		}
+		wantHeaders, err := json.Marshal(msg.Headers)
+		if err != nil {
+			t.Fatalf("encode sent headers: %v", err)
+		}
+		gotHeaders, err := json.Marshal(in.Headers())
+		if err != nil {
+			t.Fatalf("encode received headers: %v", err)
+		}
+		if !bytes.Equal(wantHeaders, gotHeaders) {
+			t.Errorf("headers mismatch:\nwant: %s\n got: %s", wantHeaders, gotHeaders)
+		}
+	})
+}
@@ -0,0 +1,209 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+	"time"
+)
+
+type GetPriceServerKafka interface {
+	OpenPricesKafka(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.PricesKafka, error)
+	OpenGetPriceKafka(context.Context, ...run.MiddlewareOption) (*GetPriceKafka, error)
+	Producer() kafka.Producer
+	Consumer() kafka.Consumer
+}
+
+func OpenGetPriceKafka(
+	ctx context.Context,
+	server GetPriceServerKafka,
+
+	opts ...run.MiddlewareOption,
+) (*GetPriceKafka, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "prices",
+			Operation: "getPrice",
+			Protocol:  "kafka",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenPricesKafka(
+		run.WithOperationName(ctx, "getPrice"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetPriceKafka{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// getPriceKafkaEnvelopeWriter counts the payload bytes written to the envelope.
+type getPriceKafkaEnvelopeWriter struct {
+	kafka.EnvelopeWriter
+	size int
+}
+
+func (e *getPriceKafkaEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type GetPriceChannelKafka interface {
+	Close() error
+
+	SealPriceRequest(kafka.EnvelopeWriter, channels.PricesEnvelopeMarshalerKafka) error
+	PublishPriceRequest(context.Context, channels.PricesEnvelopeMarshalerKafka) error
+
+	UnsealPriceRequest(kafka.EnvelopeReader, channels.PricesEnvelopeUnmarshalerKafka) error
+	SubscribePriceRequest(context.Context, func(context.Context, messages.PriceRequestReceiver) error) error
+	PublishEnvelope(context.Context, kafka.EnvelopeWriter, any) error
+}
+
+type GetPriceKafka struct {
+	Channel      GetPriceChannelKafka
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c GetPriceKafka) Close() error {
+	return c.Channel.Close()
+}
+
+func (c GetPriceKafka) Reply(channel GetPriceReplyChannelKafka) *GetPriceKafkaReply {
+	return &GetPriceKafkaReply{Channel: channel}
+}
+func (o GetPriceKafka) SealPriceRequest(
+	envelope kafka.EnvelopeWriter,
+	message channels.PricesEnvelopeMarshalerKafka,
+) error {
+	return o.Channel.SealPriceRequest(envelope, message)
+}
+
+func (o GetPriceKafka) PublishPriceRequest(
+	ctx context.Context,
+
+	message channels.PricesEnvelopeMarshalerKafka,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishPriceRequest(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "priceRequest"
+	envelope := kafka.NewEnvelopeOut(nil)
+	counter := &getPriceKafkaEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealPriceRequest(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
+
+type GetPriceReplyChannelKafka interface {
+	Close() error
+
+	UnsealPriceReply(kafka.EnvelopeReader, channels.PriceRepliesEnvelopeUnmarshalerKafka) error
+	SubscribePriceReply(context.Context, func(context.Context, messages.PriceReplyReceiver) error) error
+}
+
+type GetPriceKafkaReply struct {
+	Channel GetPriceReplyChannelKafka
+}
+
+func (o GetPriceKafkaReply) UnsealPriceReply(
+	envelope kafka.EnvelopeReader,
+	message channels.PriceRepliesEnvelopeUnmarshalerKafka,
+) error {
+	return o.Channel.UnsealPriceReply(envelope, message)
+}
+
+func (o GetPriceKafkaReply) SubscribePriceReply(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.PriceReplyReceiver) error,
+) (err error) {
+	return o.Channel.SubscribePriceReply(ctx, cb)
+}
+
+// GetPriceKafkaRequester sends the requests to the operation channel and waits for the replies
+// on the reply channel, matching them to requests by correlation id.
+type GetPriceKafkaRequester struct {
+	Operation GetPriceKafka
+	Reply     *GetPriceKafkaReply
+	requests  *run.Requests[string, messages.PriceReplyReceiver]
+}
+
+// Requester returns a new requester for this operation. The reply channel is subscribed on the first request,
+// the subscription is shared by all subsequent requests until the requester is closed.
+func (c GetPriceKafka) Requester(channel GetPriceReplyChannelKafka) *GetPriceKafkaRequester {
+	return &GetPriceKafkaRequester{
+		Operation: c,
+		Reply:     c.Reply(channel),
+		requests:  run.NewRequests[string, messages.PriceReplyReceiver](),
+	}
+}
+
+// Close stops the reply subscription. The operation and reply channels are not closed.
+func (r *GetPriceKafkaRequester) Close() error {
+	return r.requests.Close()
+}
+
+// RequestPriceRequest sends the message with a new correlation id and waits for the reply with the same
+// correlation id until ctx is done.
+func (r *GetPriceKafkaRequester) RequestPriceRequest(
+	ctx context.Context,
+	message *messages.PriceRequestOut,
+) (messages.PriceReplyReceiver, error) {
+	correlationID := run.NewCorrelationID()
+	message.SetCorrelationID(correlationID)
+
+	var replyAddress string
+	if v, ok := r.Reply.Channel.(interface{ Topic() string }); ok {
+		replyAddress = v.Topic()
+	}
+	envelope := kafka.NewEnvelopeOut(nil)
+	if err := r.Operation.Channel.SealPriceRequest(envelope, message); err != nil {
+		return nil, err
+	}
+
+	envelope.SetHeaders(run.Headers{kafka.ReplyTopicHeader: replyAddress})
+
+	return r.requests.Do(ctx, correlationID, r.subscribe, func(ctx context.Context) error {
+		return r.Operation.Channel.PublishEnvelope(ctx, envelope, message)
+	})
+}
+
+func (r *GetPriceKafkaRequester) subscribe(
+	ctx context.Context,
+	resolve func(correlationID string, reply messages.PriceReplyReceiver),
+) error {
+	return r.Reply.Channel.SubscribePriceReply(ctx, func(_ context.Context, message messages.PriceReplyReceiver) error {
+		// Messages without correlation id can't be matched to any request
+		if correlationID, err := message.CorrelationID(); err == nil {
+			resolve(correlationID, message)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,152 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+type QuotePriceServerKafka interface {
+	OpenPricesKafka(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.PricesKafka, error)
+	OpenQuotePriceKafka(context.Context, ...run.MiddlewareOption) (*QuotePriceKafka, error)
+	Producer() kafka.Producer
+	Consumer() kafka.Consumer
+}
+
+func OpenQuotePriceKafka(
+	ctx context.Context,
+	server QuotePriceServerKafka,
+
+	opts ...run.MiddlewareOption,
+) (*QuotePriceKafka, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "prices",
+			Operation: "quotePrice",
+			Protocol:  "kafka",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, quotePriceKafkaMetrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenPricesKafka(
+		run.WithOperationName(ctx, "quotePrice"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QuotePriceKafka{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// quotePriceKafkaEnvelopeReader counts the payload bytes read from the envelope.
+type quotePriceKafkaEnvelopeReader struct {
+	kafka.EnvelopeReader
+	size int
+}
+
+func (e *quotePriceKafkaEnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// quotePriceKafkaMetrics returns the middleware that reports the received messages metrics.
+func quotePriceKafkaMetrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[kafka.EnvelopeReader]) run.SubscribeHandler[kafka.EnvelopeReader] {
+		return func(ctx context.Context, envelope kafka.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.PriceRequestIn:
+				labels.Message = "priceRequest"
+			}
+			counter := &quotePriceKafkaEnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type QuotePriceChannelKafka interface {
+	Close() error
+
+	SealPriceRequest(kafka.EnvelopeWriter, channels.PricesEnvelopeMarshalerKafka) error
+	PublishPriceRequest(context.Context, channels.PricesEnvelopeMarshalerKafka) error
+
+	UnsealPriceRequest(kafka.EnvelopeReader, channels.PricesEnvelopeUnmarshalerKafka) error
+	SubscribePriceRequest(context.Context, func(context.Context, messages.PriceRequestReceiver) error) error
+}
+
+type QuotePriceKafka struct {
+	Channel      QuotePriceChannelKafka
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c QuotePriceKafka) Close() error {
+	return c.Channel.Close()
+}
+
+func (c QuotePriceKafka) Reply(channel QuotePriceReplyChannelKafka) *QuotePriceKafkaReply {
+	return &QuotePriceKafkaReply{Channel: channel}
+}
+func (o QuotePriceKafka) UnsealPriceRequest(
+	envelope kafka.EnvelopeReader,
+	message channels.PricesEnvelopeUnmarshalerKafka,
+) error {
+	return o.Channel.UnsealPriceRequest(envelope, message)
+}
+
+func (o QuotePriceKafka) SubscribePriceRequest(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.PriceRequestReceiver) error,
+) (err error) {
+	return o.Channel.SubscribePriceRequest(ctx, cb)
+}
+
+type QuotePriceReplyChannelKafka interface {
+	Close() error
+
+	SealPriceReply(kafka.EnvelopeWriter, channels.PriceRepliesEnvelopeMarshalerKafka) error
+	PublishPriceReply(context.Context, channels.PriceRepliesEnvelopeMarshalerKafka) error
+}
+
+type QuotePriceKafkaReply struct {
+	Channel QuotePriceReplyChannelKafka
+}
+
+func (o QuotePriceKafkaReply) SealPriceReply(
+	envelope kafka.EnvelopeWriter,
+	message channels.PriceRepliesEnvelopeMarshalerKafka,
+) error {
+	return o.Channel.SealPriceReply(envelope, message)
+}
+
+func (o QuotePriceKafkaReply) ReplyPriceReply(
+	ctx context.Context,
+
+	message channels.PriceRepliesEnvelopeMarshalerKafka,
+) error {
+	return o.Channel.PublishPriceReply(ctx, message)
+}
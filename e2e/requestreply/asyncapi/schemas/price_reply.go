@@ -0,0 +1,13 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package schemas
+
+type PriceReply struct {
+	Item  string `json:"item"`
+	Price int    `json:"price"`
+}
+
+// Validate checks the PriceReply value against the constraints from the jsonschema definition.
+func (v PriceReply) Validate() error {
+	return nil
+}
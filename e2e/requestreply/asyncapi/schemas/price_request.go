@@ -0,0 +1,12 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package schemas
+
+type PriceRequest struct {
+	Item string `json:"item"`
+}
+
+// Validate checks the PriceRequest value against the constraints from the jsonschema definition.
+func (v PriceRequest) Validate() error {
+	return nil
+}
@@ -0,0 +1,139 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package servers
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+	"net/url"
+)
+
+func MainURL() (*url.URL, error) {
+	return &url.URL{Scheme: "kafka", Host: "requestreply", Path: ""}, nil
+}
+
+func NewMain(producer kafka.Producer, consumer kafka.Consumer) *Main {
+	return &Main{
+		producer: producer,
+		consumer: consumer,
+	}
+}
+
+type MainClosable struct {
+	Main
+}
+
+func (c MainClosable) Close() error {
+	var err error
+	if v, ok := any(c.producer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	if v, ok := any(c.consumer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	return err
+}
+func ConnectMainBidi(
+	ctx context.Context,
+	url *url.URL,
+
+) (*MainClosable, error) {
+	var bindings *kafka.ServerBindings
+	client, err := kafka.NewClient(ctx, url, bindings, nil)
+	if err != nil {
+		return nil, err
+	}
+	producer, consumer := client, client
+	return &MainClosable{
+		Main{producer: producer, consumer: consumer},
+	}, nil
+}
+func ConnectMainProducer(
+	ctx context.Context,
+	url *url.URL,
+
+) (*MainClosable, error) {
+	var bindings *kafka.ServerBindings
+	producer, err := kafka.NewClient(ctx, url, bindings, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &MainClosable{
+		Main{producer: producer},
+	}, nil
+}
+func ConnectMainConsumer(
+	ctx context.Context,
+	url *url.URL,
+
+) (*MainClosable, error) {
+	var bindings *kafka.ServerBindings
+	consumer, err := kafka.NewClient(ctx, url, bindings, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &MainClosable{
+		Main{consumer: consumer},
+	}, nil
+}
+
+type Main struct {
+	producer kafka.Producer
+	consumer kafka.Consumer
+}
+
+func (s Main) Name() string {
+	return "Main"
+}
+
+func (s Main) Producer() kafka.Producer {
+	return s.producer
+}
+
+func (s Main) Consumer() kafka.Consumer {
+	return s.consumer
+}
+
+func (s Main) OpenPricesKafka(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.PricesKafka, error) {
+	return channels.OpenPricesKafka(
+		ctx, s, nil, security, opts...,
+	)
+}
+func (s Main) OpenPriceRepliesKafka(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*channels.PriceRepliesKafka, error) {
+	return channels.OpenPriceRepliesKafka(
+		ctx, s, opts...,
+	)
+}
+
+func (s Main) OpenGetPriceKafka(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.GetPriceKafka, error) {
+	return operations.OpenGetPriceKafka(
+		ctx, s, opts...,
+	)
+}
+func (s Main) OpenQuotePriceKafka(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.QuotePriceKafka, error) {
+	return operations.OpenQuotePriceKafka(
+		ctx, s, opts...,
+	)
+}
@@ -0,0 +1,5 @@
+// Package requestreply checks the generated requester against the replier on the in-memory broker, and the
+// tests generated from the message examples.
+package requestreply
+
+//go:generate go -C ../.. run ./cmd/go-asyncapi -c e2e/requestreply/go-asyncapi.yaml code -t e2e/requestreply/asyncapi -M github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi e2e/requestreply/asyncapi.yaml
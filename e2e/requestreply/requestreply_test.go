@@ -0,0 +1,114 @@
+package requestreply
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/e2e/requestreply/asyncapi/servers"
+)
+
+var prices = map[string]int{"apple": 3, "pear": 5}
+
+func TestRequest(t *testing.T) {
+	ctx, server := connect(t)
+	replyTopics := serveQuotes(ctx, t, server)
+
+	op, err := server.OpenGetPriceKafka(ctx)
+	if err != nil {
+		t.Fatalf("open operation: %v", err)
+	}
+	defer op.Close()
+	replyCh, err := server.OpenPriceRepliesKafka(ctx)
+	if err != nil {
+		t.Fatalf("open reply channel: %v", err)
+	}
+	defer replyCh.Close()
+	requester := op.Requester(replyCh)
+	defer requester.Close()
+
+	for item, want := range prices {
+		reqCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		reply, err := requester.RequestPriceRequest(reqCtx, new(messages.PriceRequestOut).SetPayload(schemas.PriceRequest{Item: item}))
+		cancel()
+		if err != nil {
+			t.Fatalf("RequestPriceRequest(%q) error = %v", item, err)
+		}
+		if got := reply.Payload(); got.Item != item || got.Price != want {
+			t.Errorf("RequestPriceRequest(%q) reply = %+v, want price %d", item, got, want)
+		}
+		// Requester tells the replier where to send the reply
+		if topic := <-replyTopics; topic != channels.PriceRepliesAddress().String() {
+			t.Errorf("reply topic header = %q, want %q", topic, channels.PriceRepliesAddress().String())
+		}
+	}
+}
+
+// connect returns the connection to the in-memory broker unique for the test.
+func connect(t *testing.T) (context.Context, *servers.Main) {
+	t.Helper()
+	ctx := t.Context()
+	conn, err := servers.ConnectMainBidi(ctx, &url.URL{Scheme: "kafka", Host: t.Name()})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return ctx, &conn.Main
+}
+
+// serveQuotes runs the replier until the test ends. It returns the channel with reply topic headers of the
+// received requests.
+func serveQuotes(ctx context.Context, t *testing.T, server *servers.Main) <-chan string {
+	t.Helper()
+	ch, err := server.OpenPricesKafka(ctx, nil)
+	if err != nil {
+		t.Fatalf("open channel: %v", err)
+	}
+	replyCh, err := server.OpenPriceRepliesKafka(ctx)
+	if err != nil {
+		t.Fatalf("open reply channel: %v", err)
+	}
+
+	replyTopics := make(chan string, 100)
+	subCtx, cancel := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() {
+		done <- ch.Subscribe(subCtx, func(envelope kafka.EnvelopeReader) {
+			var req messages.PriceRequestIn
+			if err := ch.UnsealPriceRequest(envelope, &req); err != nil {
+				t.Errorf("UnsealPriceRequest() error = %v", err)
+				return
+			}
+			topic, _ := envelope.Headers()[kafka.ReplyTopicHeader].(string)
+			replyTopics <- topic
+
+			correlationID, err := req.CorrelationID()
+			if err != nil {
+				t.Errorf("CorrelationID() error = %v", err)
+				return
+			}
+			item := req.Payload().Item
+			reply := new(messages.PriceReplyOut).
+				SetPayload(schemas.PriceReply{Item: item, Price: prices[item]}).
+				SetCorrelationID(correlationID)
+			if err := replyCh.PublishPriceReply(subCtx, reply); err != nil {
+				t.Errorf("PublishPriceReply() error = %v", err)
+			}
+		})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; !errors.Is(err, context.Canceled) {
+			t.Errorf("Subscribe() error = %v, want context.Canceled", err)
+		}
+		_ = ch.Close()
+		_ = replyCh.Close()
+	})
+	return replyTopics
+}
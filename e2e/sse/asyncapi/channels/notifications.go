@@ -0,0 +1,230 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package channels
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/sse/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/sse/asyncapi/proto/sse"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+func NotificationsAddress() run.ParamString {
+	return run.ParamString{
+		Expr: "/notifications",
+	}
+}
+
+func NewNotificationsSSE(
+
+	publisher sse.Publisher,
+	subscriber sse.Subscriber,
+	opts ...run.MiddlewareOption,
+) *NotificationsSSE {
+	res := NotificationsSSE{
+		address: NotificationsAddress(), publisher: publisher, subscriber: subscriber,
+		middlewares: run.NewMiddlewares(opts...),
+	}
+	return &res
+}
+
+type NotificationsServerSSE interface {
+	OpenNotificationsSSE(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*NotificationsSSE, error)
+	Producer() sse.Producer
+	Consumer() sse.Consumer
+}
+
+func OpenNotificationsSSE(
+	ctx context.Context,
+	server NotificationsServerSSE,
+
+	opBindings *sse.OperationBindings,
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*NotificationsSSE, error) {
+	var err error
+	address, err := NotificationsAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	var publisher sse.Publisher
+	producer := server.Producer()
+	if producer != nil {
+		publisher, err = producer.Publisher(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subscriber sse.Subscriber
+	consumer := server.Consumer()
+	if consumer != nil {
+		subscriber, err = consumer.Subscriber(
+			ctx,
+			address,
+			nil,
+			opBindings,
+			security,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewNotificationsSSE(
+
+		publisher,
+		subscriber,
+		opts...,
+	), nil
+}
+
+type NotificationsSSE struct {
+	address     run.ParamString
+	publisher   sse.Publisher
+	subscriber  sse.Subscriber
+	middlewares run.Middlewares
+}
+
+func (c NotificationsSSE) Address() run.ParamString {
+	return c.address
+}
+
+func (c NotificationsSSE) Close() (err error) {
+	if c.publisher != nil {
+		err = errors.Join(err, c.publisher.Close())
+	}
+	if c.subscriber != nil {
+		err = errors.Join(err, c.subscriber.Close())
+	}
+	return
+}
+
+type NotificationsEnvelopeMarshalerSSE interface {
+	MarshalNotificationsSSE(envelope sse.EnvelopeWriter) error
+}
+
+func (c NotificationsSSE) SealNotification(
+	envelope sse.EnvelopeWriter,
+	message NotificationsEnvelopeMarshalerSSE,
+) error {
+	if err := message.MarshalNotificationsSSE(envelope); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c NotificationsSSE) PublishNotification(
+	ctx context.Context,
+
+	message NotificationsEnvelopeMarshalerSSE,
+) error {
+	envelope := sse.NewEnvelopeOut(nil)
+	if err := c.SealNotification(envelope, message); err != nil {
+		return err
+	}
+
+	return c.PublishEnvelope(ctx, envelope, message)
+}
+
+// PublishEnvelope sends the sealed envelope through the publish middlewares. The message is the typed message that
+// has been sealed to the envelope.
+func (c NotificationsSSE) PublishEnvelope(ctx context.Context, envelope sse.EnvelopeWriter, message any) error {
+	handler := run.PublishChain(c.middlewares, func(ctx context.Context, envelope sse.EnvelopeWriter, _ any) error {
+		return c.Publish(ctx, envelope)
+	})
+	return handler(ctx, envelope, message)
+}
+
+func (c NotificationsSSE) Publisher() sse.Publisher {
+	return c.publisher
+}
+
+func (c NotificationsSSE) Publish(ctx context.Context, envelopes ...sse.EnvelopeWriter) error {
+	return c.publisher.Send(ctx, envelopes...)
+}
+
+type NotificationsEnvelopeUnmarshalerSSE interface {
+	UnmarshalNotificationsSSE(envelope sse.EnvelopeReader) error
+}
+
+func (c NotificationsSSE) UnsealNotification(
+	envelope sse.EnvelopeReader,
+	message NotificationsEnvelopeUnmarshalerSSE,
+) error {
+	return message.UnmarshalNotificationsSSE(envelope)
+}
+
+// SubscribeNotification receives the messages and calls cb for each of them until ctx is done or an error occurs.
+// If cb returns an error, the message is handled according to the retry policy and dead-letter handler
+// passed in channel options. Without them, the error stops the subscription and is returned.
+func (c NotificationsSSE) SubscribeNotification(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.NotificationReceiver) error,
+) (err error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := run.SubscribeChain(c.middlewares, func(ctx context.Context, envelope sse.EnvelopeReader, message any) error {
+		m := message.(*messages.NotificationIn)
+		if err2 := c.UnsealNotification(envelope, m); err2 != nil {
+			return fmt.Errorf("%w: %w", run.ErrUnsealEnvelope, err2)
+		}
+		return cb(ctx, m)
+	})
+	subErr := c.Subscribe(subCtx, func(envelope sse.EnvelopeReader) {
+		err2 := run.HandleEnvelope(
+			subCtx,
+			c.middlewares,
+			envelope,
+			func(payload []byte) sse.EnvelopeReader {
+				return &notificationsSSEBufferedEnvelope{EnvelopeReader: envelope, payload: bytes.NewReader(payload)}
+			},
+			func(ctx context.Context, envelope sse.EnvelopeReader) error {
+				return handler(ctx, envelope, new(messages.NotificationIn))
+			},
+		)
+		if err2 != nil {
+			err = err2
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return subErr
+}
+
+// notificationsSSEBufferedEnvelope is the received envelope with the payload read into memory,
+// that is passed to the subscription handler on every retry attempt.
+type notificationsSSEBufferedEnvelope struct {
+	sse.EnvelopeReader
+	payload *bytes.Reader
+}
+
+func (e *notificationsSSEBufferedEnvelope) Read(p []byte) (n int, err error) {
+	return e.payload.Read(p)
+}
+
+// Unwrap returns the original envelope received from the subscriber, e.g. to get the protocol-specific fields in
+// the subscribe middleware.
+func (e *notificationsSSEBufferedEnvelope) Unwrap() sse.EnvelopeReader {
+	return e.EnvelopeReader
+}
+
+func (c NotificationsSSE) Subscriber() sse.Subscriber {
+	return c.subscriber
+}
+
+func (c NotificationsSSE) Subscribe(ctx context.Context, cb func(envelope sse.EnvelopeReader)) error {
+	return c.subscriber.Receive(ctx, cb)
+}
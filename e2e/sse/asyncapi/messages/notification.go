@@ -0,0 +1,115 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/sse/asyncapi/proto/sse"
+	"github.com/bdragon300/go-asyncapi/e2e/sse/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+)
+
+type NotificationSender interface {
+	SetPayload(payload schemas.Notification) *NotificationOut
+	SetHeaders(headers map[string]any) *NotificationOut
+}
+
+// NotificationOut-- (Outbound Message)
+type NotificationOut struct {
+	Payload schemas.Notification
+	Headers map[string]any
+}
+
+// Validate checks the NotificationOut value against the constraints from the jsonschema definition.
+func (v NotificationOut) Validate() error {
+	if err := v.Payload.Validate(); err != nil {
+		return fmt.Errorf("Payload: %w", err)
+	}
+	return nil
+}
+
+func (m *NotificationOut) SetPayload(payload schemas.Notification) *NotificationOut {
+	m.Payload = payload
+	return m
+}
+
+func (m *NotificationOut) SetHeaders(headers map[string]any) *NotificationOut {
+	m.Headers = headers
+	return m
+}
+
+type NotificationReceiver interface {
+	Payload() schemas.Notification
+	Headers() map[string]any
+}
+
+// NotificationIn-- (Inbound Message)
+type NotificationIn struct {
+	payload schemas.Notification
+	headers map[string]any
+}
+
+// Validate checks the NotificationIn value against the constraints from the jsonschema definition.
+func (v NotificationIn) Validate() error {
+	if err := v.payload.Validate(); err != nil {
+		return fmt.Errorf("payload: %w", err)
+	}
+	return nil
+}
+
+func (m *NotificationIn) Payload() schemas.Notification {
+	return m.payload
+}
+
+func (m *NotificationIn) Headers() map[string]any {
+	return m.headers
+}
+
+func (m *NotificationOut) MarshalNotificationsSSE(envelope sse.EnvelopeWriter) error {
+	return m.MarshalEnvelopeSSE(envelope)
+}
+
+func (m *NotificationOut) MarshalEnvelopeSSE(envelope sse.EnvelopeWriter) error {
+	if err := m.MarshalSSE(envelope); err != nil {
+		return err
+	}
+	envelope.SetContentType("application/json")
+	envelope.SetHeaders(run.Headers(m.Headers))
+	return nil
+}
+
+func (m *NotificationOut) MarshalSSE(w io.Writer) error {
+	// MIME type: application/json
+
+	// Default encoder
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(m.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *NotificationIn) UnmarshalNotificationsSSE(envelope sse.EnvelopeReader) error {
+	return m.UnmarshalEnvelopeSSE(envelope)
+}
+
+func (m *NotificationIn) UnmarshalEnvelopeSSE(envelope sse.EnvelopeReader) error {
+	if err := m.UnmarshalSSE(envelope); err != nil {
+		return err
+	}
+	m.headers = map[string]any(envelope.Headers())
+	return nil
+}
+
+func (m *NotificationIn) UnmarshalSSE(r io.Reader) error {
+	// MIME type: application/json
+
+	// Default decoder
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&m.payload); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/sse/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/sse/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/sse/asyncapi/proto/sse"
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+type ReceiveNotificationServerSSE interface {
+	OpenNotificationsSSE(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.NotificationsSSE, error)
+	OpenReceiveNotificationSSE(context.Context, ...run.MiddlewareOption) (*ReceiveNotificationSSE, error)
+	Producer() sse.Producer
+	Consumer() sse.Consumer
+}
+
+func OpenReceiveNotificationSSE(
+	ctx context.Context,
+	server ReceiveNotificationServerSSE,
+
+	opts ...run.MiddlewareOption,
+) (*ReceiveNotificationSSE, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "notifications",
+			Operation: "receiveNotification",
+			Protocol:  "sse",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, receiveNotificationSSEMetrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenNotificationsSSE(
+		run.WithOperationName(ctx, "receiveNotification"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReceiveNotificationSSE{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// receiveNotificationSSEEnvelopeReader counts the payload bytes read from the envelope.
+type receiveNotificationSSEEnvelopeReader struct {
+	sse.EnvelopeReader
+	size int
+}
+
+func (e *receiveNotificationSSEEnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// receiveNotificationSSEMetrics returns the middleware that reports the received messages metrics.
+func receiveNotificationSSEMetrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[sse.EnvelopeReader]) run.SubscribeHandler[sse.EnvelopeReader] {
+		return func(ctx context.Context, envelope sse.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.NotificationIn:
+				labels.Message = "notification"
+			}
+			counter := &receiveNotificationSSEEnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type ReceiveNotificationChannelSSE interface {
+	Close() error
+
+	SealNotification(sse.EnvelopeWriter, channels.NotificationsEnvelopeMarshalerSSE) error
+	PublishNotification(context.Context, channels.NotificationsEnvelopeMarshalerSSE) error
+
+	UnsealNotification(sse.EnvelopeReader, channels.NotificationsEnvelopeUnmarshalerSSE) error
+	SubscribeNotification(context.Context, func(context.Context, messages.NotificationReceiver) error) error
+}
+
+type ReceiveNotificationSSE struct {
+	Channel      ReceiveNotificationChannelSSE
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c ReceiveNotificationSSE) Close() error {
+	return c.Channel.Close()
+}
+
+func (o ReceiveNotificationSSE) UnsealNotification(
+	envelope sse.EnvelopeReader,
+	message channels.NotificationsEnvelopeUnmarshalerSSE,
+) error {
+	return o.Channel.UnsealNotification(envelope, message)
+}
+
+func (o ReceiveNotificationSSE) SubscribeNotification(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.NotificationReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeNotification(ctx, cb)
+}
@@ -0,0 +1,120 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/e2e/sse/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/sse/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/sse/asyncapi/proto/sse"
+	"github.com/bdragon300/go-asyncapi/run"
+	"time"
+)
+
+type SendNotificationServerSSE interface {
+	OpenNotificationsSSE(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.NotificationsSSE, error)
+	OpenSendNotificationSSE(context.Context, ...run.MiddlewareOption) (*SendNotificationSSE, error)
+	Producer() sse.Producer
+	Consumer() sse.Consumer
+}
+
+func OpenSendNotificationSSE(
+	ctx context.Context,
+	server SendNotificationServerSSE,
+
+	opts ...run.MiddlewareOption,
+) (*SendNotificationSSE, error) {
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "notifications",
+			Operation: "sendNotification",
+			Protocol:  "sse",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenNotificationsSSE(
+		run.WithOperationName(ctx, "sendNotification"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendNotificationSSE{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// sendNotificationSSEEnvelopeWriter counts the payload bytes written to the envelope.
+type sendNotificationSSEEnvelopeWriter struct {
+	sse.EnvelopeWriter
+	size int
+}
+
+func (e *sendNotificationSSEEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type SendNotificationChannelSSE interface {
+	Close() error
+
+	SealNotification(sse.EnvelopeWriter, channels.NotificationsEnvelopeMarshalerSSE) error
+	PublishNotification(context.Context, channels.NotificationsEnvelopeMarshalerSSE) error
+
+	UnsealNotification(sse.EnvelopeReader, channels.NotificationsEnvelopeUnmarshalerSSE) error
+	SubscribeNotification(context.Context, func(context.Context, messages.NotificationReceiver) error) error
+	PublishEnvelope(context.Context, sse.EnvelopeWriter, any) error
+}
+
+type SendNotificationSSE struct {
+	Channel      SendNotificationChannelSSE
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c SendNotificationSSE) Close() error {
+	return c.Channel.Close()
+}
+
+func (o SendNotificationSSE) SealNotification(
+	envelope sse.EnvelopeWriter,
+	message channels.NotificationsEnvelopeMarshalerSSE,
+) error {
+	return o.Channel.SealNotification(envelope, message)
+}
+
+func (o SendNotificationSSE) PublishNotification(
+	ctx context.Context,
+
+	message channels.NotificationsEnvelopeMarshalerSSE,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishNotification(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "notification"
+	envelope := sse.NewEnvelopeOut(nil)
+	counter := &sendNotificationSSEEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealNotification(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
@@ -0,0 +1,52 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package sse
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+func NewConsumer(serverURL *url.URL, bindings *ServerBindings, security run.AnySecurityScheme) *ConsumeClient {
+	return &ConsumeClient{
+		Client:    http.DefaultClient,
+		bindings:  bindings,
+		serverURL: serverURL,
+		security:  security,
+	}
+}
+
+type ConsumeClient struct {
+	// Client is the HTTP client used to connect to the event stream. It must not have a timeout, since the
+	// connection is long-lived.
+	Client    *http.Client
+	bindings  *ServerBindings
+	serverURL *url.URL
+	security  run.AnySecurityScheme
+}
+
+func (c ConsumeClient) Subscriber(_ context.Context, address string, chb *ChannelBindings, opb *OperationBindings, security run.AnySecurityScheme) (Subscriber, error) {
+	u := *c.serverURL
+	if u.Scheme != "http" && u.Scheme != "https" {
+		u.Scheme = "http" // E.g. "sse://" scheme, that is derived from the protocol name
+	}
+	// Append the address to the server URL path
+	if u.Path == "" {
+		// url.JoinPath requires a leading slash in empty path to give the correct result
+		// otherwise it will return the path as is, e.g. for "foo" it sets path to "foo" instead of "/foo"
+		u.Path = "/"
+	}
+	if address != "" {
+		u = *u.JoinPath(address)
+	}
+	s := c.security
+	if security != nil {
+		s = security
+	}
+	return NewSubscriber(c.Client, chb, opb, &u, s), nil
+}
@@ -0,0 +1,100 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package sse
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"bytes"
+	"time"
+)
+
+func NewEnvelopeOut(buf []byte) *EnvelopeOut {
+	return &EnvelopeOut{Buffer: bytes.NewBuffer(buf)}
+}
+
+type EnvelopeOut struct {
+	*bytes.Buffer
+	eventType   string
+	eventID     string
+	retry       time.Duration
+	headers     run.Headers
+	contentType string
+}
+
+func (e *EnvelopeOut) ResetPayload() {
+	e.Buffer.Reset()
+}
+
+// SetHeaders sets the message headers. SSE events have no headers, so they are not sent.
+func (e *EnvelopeOut) SetHeaders(headers run.Headers) {
+	if e.headers == nil {
+		e.headers = make(run.Headers, len(headers))
+	}
+	for k, v := range headers {
+		e.headers[k] = v
+	}
+}
+
+func (e *EnvelopeOut) SetContentType(contentType string) {
+	e.contentType = contentType
+}
+
+func (e *EnvelopeOut) SetBindings(_ MessageBindings) {}
+
+// SetEventType sets the "event" field of the event. If not set, the client treats the event as "message".
+func (e *EnvelopeOut) SetEventType(eventType string) {
+	e.eventType = eventType
+}
+
+// SetEventID sets the "id" field of the event. If not set, the publisher assigns the sequential number to the event.
+func (e *EnvelopeOut) SetEventID(id string) {
+	e.eventID = id
+}
+
+// SetRetry sets the "retry" field of the event, that is the client reconnection delay.
+func (e *EnvelopeOut) SetRetry(retry time.Duration) {
+	e.retry = retry
+}
+
+func (e *EnvelopeOut) EventType() string {
+	return e.eventType
+}
+
+func (e *EnvelopeOut) EventID() string {
+	return e.eventID
+}
+
+func (e *EnvelopeOut) Retry() time.Duration {
+	return e.retry
+}
+
+func NewEnvelopeIn(eventType, eventID string, data []byte) *EnvelopeIn {
+	return &EnvelopeIn{eventType: eventType, eventID: eventID, reader: bytes.NewReader(data)}
+}
+
+type EnvelopeIn struct {
+	eventType string
+	eventID   string
+	reader    *bytes.Reader
+}
+
+func (e *EnvelopeIn) Read(p []byte) (n int, err error) {
+	return e.reader.Read(p)
+}
+
+func (e *EnvelopeIn) Headers() run.Headers {
+	return nil
+}
+
+// EventType returns the "event" field of the event, or "message" if it is not set.
+func (e *EnvelopeIn) EventType() string {
+	return e.eventType
+}
+
+// EventID returns the last event ID, i.e. the "id" field of this or one of previous events in the stream.
+func (e *EnvelopeIn) EventID() string {
+	return e.eventID
+}
@@ -0,0 +1,116 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package sse
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// DefaultReplayBufferSize is the default number of last events of a channel kept to replay them to the reconnected
+// clients.
+const DefaultReplayBufferSize = 100
+
+func NewProducer(bindings *ServerBindings, security run.AnySecurityScheme) *ProduceClient {
+	return &ProduceClient{
+		ReplayBufferSize: DefaultReplayBufferSize,
+		bindings:         bindings,
+		security:         security,
+		streams:          make(map[string]*Stream),
+		mu:               new(sync.Mutex),
+	}
+}
+
+// ProduceClient is the [http.Handler] that streams the published events to the connected clients. Every channel is
+// served on its address path.
+type ProduceClient struct {
+	http.ServeMux
+	// ReplayBufferSize is the number of last events of a channel to keep. When the client reconnects with the
+	// Last-Event-ID header, the events after the given ID are sent to it first. Zero disables the replaying.
+	ReplayBufferSize int
+	bindings         *ServerBindings
+	security         run.AnySecurityScheme
+	streams          map[string]*Stream
+	mu               *sync.Mutex
+}
+
+func (p *ProduceClient) Publisher(_ context.Context, address string, chb *ChannelBindings, opb *OperationBindings, security run.AnySecurityScheme) (Publisher, error) {
+	sec := p.security
+	if security != nil {
+		sec = security
+	}
+	// Because of the HTTP server implementation, we can't change HTTP handler once it has been registered.
+	// So, here the security is used only on the first publisher for a channel, it is ignored on subsequent calls
+	// for this channel even if different.
+	stream := p.ensureStream(address, sec)
+	return NewPublisher(chb, opb, stream), nil
+}
+
+// Close disconnects all clients.
+func (p *ProduceClient) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, stream := range p.streams {
+		stream.Close()
+	}
+	return nil
+}
+
+func (p *ProduceClient) ensureStream(address string, sec run.AnySecurityScheme) *Stream {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if stream, ok := p.streams[address]; ok { // HandleFunc panics if called more than once for the same channel
+		return stream
+	}
+	stream := NewStream(p.ReplayBufferSize)
+	p.streams[address] = stream
+	p.HandleFunc(address, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if sec != nil {
+			authOk, err := p.checkSecurity(req, sec)
+			if err != nil {
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+				return
+			}
+			if !authOk {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+		stream.ServeHTTP(w, req)
+	})
+	return stream
+}
+
+func (p *ProduceClient) checkSecurity(req *http.Request, sec run.AnySecurityScheme) (bool, error) {
+	uUser, uPass, ok := req.BasicAuth()
+	if !ok {
+		return false, nil // No auth provided
+	}
+
+	switch v := sec.(type) {
+	case run.UserPasswordSecurity:
+		user, pass := v.UserPassword()
+		return uUser == user && uPass == pass, nil
+	case run.APIKeySecurity:
+		key := v.APIKey()
+		switch v.In() {
+		case "user":
+			return uUser == key, nil
+		case "password":
+			return uPass == key, nil
+		}
+	}
+
+	return false, fmt.Errorf("unsupported security scheme: %v", sec.AuthType())
+}
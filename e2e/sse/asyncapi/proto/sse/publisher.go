@@ -0,0 +1,215 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package sse
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// clientBufferSize is the number of events buffered for each client. The client that doesn't keep up is disconnected,
+// so it can reconnect and receive the missed events from the replay buffer.
+const clientBufferSize = 64
+
+func NewPublisher(chb *ChannelBindings, opb *OperationBindings, stream *Stream) *PublishChannel {
+	return &PublishChannel{
+		Stream:            stream,
+		channelBindings:   chb,
+		operationBindings: opb,
+	}
+}
+
+type PublishChannel struct {
+	*Stream
+	channelBindings   *ChannelBindings
+	operationBindings *OperationBindings
+}
+
+type ImplementationRecord interface {
+	Bytes() []byte
+	EventType() string
+	EventID() string
+	Retry() time.Duration
+}
+
+func (p PublishChannel) Send(ctx context.Context, envelopes ...EnvelopeWriter) error {
+	for _, envelope := range envelopes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		ir := envelope.(ImplementationRecord)
+		p.Stream.Broadcast(ir.EventType(), ir.EventID(), ir.Retry(), ir.Bytes())
+	}
+	return nil
+}
+
+func (p PublishChannel) Close() error {
+	return nil
+}
+
+func NewStream(replayBufferSize int) *Stream {
+	return &Stream{
+		replayBufferSize: replayBufferSize,
+		clients:          make(map[chan []byte]struct{}),
+		mu:               new(sync.Mutex),
+	}
+}
+
+type streamEvent struct {
+	id    string
+	frame []byte
+}
+
+// Stream is the [http.Handler] that sends the broadcasted events to all connected clients as the
+// "text/event-stream" response.
+type Stream struct {
+	replayBufferSize int
+	replay           []streamEvent
+	clients          map[chan []byte]struct{}
+	lastID           uint64
+	closed           bool
+	mu               *sync.Mutex
+}
+
+// Broadcast sends the event to all connected clients. Empty id is replaced by the next sequential number.
+func (s *Stream) Broadcast(eventType, id string, retry time.Duration, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id == "" {
+		s.lastID++
+		id = strconv.FormatUint(s.lastID, 10)
+	}
+	frame := formatEvent(eventType, id, retry, data)
+	if s.replayBufferSize > 0 {
+		if len(s.replay) >= s.replayBufferSize {
+			s.replay = s.replay[1:]
+		}
+		s.replay = append(s.replay, streamEvent{id: id, frame: frame})
+	}
+	for ch := range s.clients {
+		select {
+		case ch <- frame:
+		default:
+			delete(s.clients, ch)
+			close(ch)
+		}
+	}
+}
+
+// Close disconnects all clients.
+func (s *Stream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for ch := range s.clients {
+		delete(s.clients, ch)
+		close(ch)
+	}
+}
+
+func (s *Stream) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	ch, backlog, ok := s.connect(req.Header.Get("Last-Event-ID"))
+	if !ok {
+		w.WriteHeader(http.StatusNoContent) // Tells the client to stop reconnecting
+		return
+	}
+	defer s.disconnect(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	for _, frame := range backlog {
+		if _, err := w.Write(frame); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case frame, ok := <-ch:
+			if !ok {
+				return
+			}
+			if _, err := w.Write(frame); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// connect registers a new client and returns the events to replay after lastEventID, if it's found in the replay
+// buffer. Returns false if the stream is closed.
+func (s *Stream) connect(lastEventID string) (chan []byte, [][]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, nil, false
+	}
+	var backlog [][]byte
+	if lastEventID != "" {
+		for i := len(s.replay) - 1; i >= 0; i-- {
+			if s.replay[i].id == lastEventID {
+				for _, e := range s.replay[i+1:] {
+					backlog = append(backlog, e.frame)
+				}
+				break
+			}
+		}
+	}
+	ch := make(chan []byte, clientBufferSize)
+	s.clients[ch] = struct{}{}
+	return ch, backlog, true
+}
+
+func (s *Stream) disconnect(ch chan []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.clients[ch]; ok {
+		delete(s.clients, ch)
+		close(ch)
+	}
+}
+
+// formatEvent returns the event in the "text/event-stream" format. Every line of data is sent in a separate
+// "data" field, the trailing newline is omitted.
+func formatEvent(eventType, id string, retry time.Duration, data []byte) []byte {
+	var b bytes.Buffer
+	if id != "" {
+		b.WriteString("id: " + singleLine(id) + "\n")
+	}
+	if eventType != "" {
+		b.WriteString("event: " + singleLine(eventType) + "\n")
+	}
+	if retry > 0 {
+		b.WriteString("retry: " + strconv.FormatInt(retry.Milliseconds(), 10) + "\n")
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n"), "\n") {
+		b.WriteString("data: " + line + "\n")
+	}
+	b.WriteString("\n")
+	return b.Bytes()
+}
+
+func singleLine(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
@@ -0,0 +1,188 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package sse
+
+import (
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultReconnectDelay is the delay before reconnecting to the event stream, unless the server sets the other one
+// in the "retry" field.
+const DefaultReconnectDelay = 3 * time.Second
+
+// maxLineSize is the maximum size of a line in the event stream.
+const maxLineSize = 1024 * 1024
+
+// ErrStreamClosed is returned when the server responds with 204 No Content, that means the client must not reconnect.
+var ErrStreamClosed = errors.New("event stream is closed by server")
+
+func NewSubscriber(client *http.Client, chb *ChannelBindings, opb *OperationBindings, channelURL *url.URL, sec run.AnySecurityScheme) *SubscribeChannel {
+	res := SubscribeChannel{
+		Client:            client,
+		ReconnectDelay:    DefaultReconnectDelay,
+		channelURL:        channelURL,
+		channelBindings:   chb,
+		operationBindings: opb,
+		security:          sec,
+	}
+	res.ctx, res.cancel = context.WithCancel(context.Background())
+	return &res
+}
+
+type SubscribeChannel struct {
+	Client *http.Client
+	// ReconnectDelay is the delay before reconnecting after the connection is lost.
+	ReconnectDelay time.Duration
+	// LastEventID is the event ID to resume the stream from. It's sent in the Last-Event-ID header on the first
+	// connection, on reconnections the ID of the last received event is sent.
+	LastEventID       string
+	channelURL        *url.URL
+	channelBindings   *ChannelBindings
+	operationBindings *OperationBindings
+	security          run.AnySecurityScheme
+	ctx               context.Context
+	cancel            context.CancelFunc
+}
+
+// Receive connects to the event stream and calls cb for each received event. If the connection is lost, it
+// reconnects after ReconnectDelay sending the ID of the last received event in the Last-Event-ID header, so
+// the server is able to send the missed events. If the server responds with a status other than 200 OK,
+// Receive returns an error without reconnecting.
+func (s *SubscribeChannel) Receive(ctx context.Context, cb func(envelope EnvelopeReader)) error {
+	receiveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-receiveCtx.Done():
+		case <-s.ctx.Done():
+			cancel()
+		}
+	}()
+
+	lastEventID := s.LastEventID
+	delay := s.ReconnectDelay
+	for {
+		reconnect, err := s.readStream(receiveCtx, &lastEventID, &delay, cb)
+		if receiveCtx.Err() != nil {
+			return receiveCtx.Err()
+		}
+		if !reconnect {
+			return err
+		}
+		select {
+		case <-receiveCtx.Done():
+			return receiveCtx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (s *SubscribeChannel) Close() error {
+	s.cancel()
+	return nil
+}
+
+// readStream reads the event stream until the connection is closed. Returns true if the client should reconnect.
+func (s *SubscribeChannel) readStream(ctx context.Context, lastEventID *string, delay *time.Duration, cb func(envelope EnvelopeReader)) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.channelURL.String(), nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if *lastEventID != "" {
+		req.Header.Set("Last-Event-ID", *lastEventID)
+	}
+	if s.security != nil {
+		if err = s.applySecurity(req); err != nil {
+			return false, err
+		}
+	}
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNoContent:
+		return false, ErrStreamClosed
+	case resp.StatusCode != http.StatusOK:
+		return false, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	run.NotifySubscribeReady(ctx)
+
+	var eventType string
+	var data bytes.Buffer
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(nil, maxLineSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			// Dispatch the event
+			if data.Len() > 0 {
+				if eventType == "" {
+					eventType = "message"
+				}
+				payload := bytes.TrimSuffix(data.Bytes(), []byte("\n"))
+				cb(NewEnvelopeIn(eventType, *lastEventID, bytes.Clone(payload)))
+			}
+			eventType = ""
+			data.Reset()
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "": // Comment
+		case "event":
+			eventType = value
+		case "data":
+			data.WriteString(value)
+			data.WriteByte('\n')
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				*lastEventID = value
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(value, 10, 63); err == nil {
+				*delay = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+	return true, scanner.Err()
+}
+
+func (s *SubscribeChannel) applySecurity(req *http.Request) error {
+	switch v := s.security.(type) {
+	case run.UserPasswordSecurity:
+		req.SetBasicAuth(v.UserPassword())
+	case run.APIKeySecurity:
+		key := v.APIKey()
+		switch v.In() {
+		case "user":
+			req.SetBasicAuth(key, "")
+		case "password":
+			req.SetBasicAuth("", key)
+		default:
+			return fmt.Errorf("unsupported 'in' for apiKey security scheme: %s", v.In())
+		}
+	default:
+		return fmt.Errorf("unsupported security scheme: %v", s.security.AuthType())
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package sse
+
+type (
+	ServerBindings    struct{}
+	ChannelBindings   struct{}
+	OperationBindings struct{}
+	MessageBindings   struct{}
+)
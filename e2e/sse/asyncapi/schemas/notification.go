@@ -0,0 +1,12 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package schemas
+
+type Notification struct {
+	Text string `json:"text"`
+}
+
+// Validate checks the Notification value against the constraints from the jsonschema definition.
+func (v Notification) Validate() error {
+	return nil
+}
@@ -0,0 +1,124 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package servers
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/sse/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/sse/asyncapi/operations"
+	"github.com/bdragon300/go-asyncapi/e2e/sse/asyncapi/proto/sse"
+	"github.com/bdragon300/go-asyncapi/run"
+	"io"
+	"net/url"
+)
+
+func MainURL() (*url.URL, error) {
+	return &url.URL{Scheme: "sse", Host: "localhost:8080", Path: ""}, nil
+}
+
+func NewMain(producer sse.Producer, consumer sse.Consumer) *Main {
+	return &Main{
+		producer: producer,
+		consumer: consumer,
+	}
+}
+
+type MainClosable struct {
+	Main
+}
+
+func (c MainClosable) Close() error {
+	var err error
+	if v, ok := any(c.producer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	if v, ok := any(c.consumer).(io.Closer); ok {
+		err = errors.Join(err, v.Close())
+	}
+	return err
+}
+
+func ConnectMainBidi(
+	ctx context.Context,
+	url *url.URL,
+
+) (*MainClosable, error) {
+	var bindings *sse.ServerBindings
+	producer := sse.NewProducer(bindings, nil)
+	consumer := sse.NewConsumer(url, bindings, nil)
+	return &MainClosable{
+		Main{producer: producer, consumer: consumer},
+	}, nil
+}
+
+func ConnectMainProducer(
+	ctx context.Context,
+	url *url.URL,
+
+) (*MainClosable, error) {
+	var bindings *sse.ServerBindings
+	producer := sse.NewProducer(bindings, nil)
+	return &MainClosable{
+		Main{producer: producer},
+	}, nil
+}
+
+func ConnectMainConsumer(
+	ctx context.Context,
+	url *url.URL,
+
+) (*MainClosable, error) {
+	var bindings *sse.ServerBindings
+	consumer := sse.NewConsumer(url, bindings, nil)
+	return &MainClosable{
+		Main{consumer: consumer},
+	}, nil
+}
+
+type Main struct {
+	producer sse.Producer
+	consumer sse.Consumer
+}
+
+func (s Main) Name() string {
+	return "Main"
+}
+
+func (s Main) Producer() sse.Producer {
+	return s.producer
+}
+
+func (s Main) Consumer() sse.Consumer {
+	return s.consumer
+}
+
+func (s Main) OpenNotificationsSSE(
+	ctx context.Context,
+
+	security run.AnySecurityScheme,
+	opts ...run.MiddlewareOption,
+) (*channels.NotificationsSSE, error) {
+	return channels.OpenNotificationsSSE(
+		ctx, s, nil, security, opts...,
+	)
+}
+
+func (s Main) OpenReceiveNotificationSSE(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.ReceiveNotificationSSE, error) {
+	return operations.OpenReceiveNotificationSSE(
+		ctx, s, opts...,
+	)
+}
+func (s Main) OpenSendNotificationSSE(
+	ctx context.Context,
+
+	opts ...run.MiddlewareOption,
+) (*operations.SendNotificationSSE, error) {
+	return operations.OpenSendNotificationSSE(
+		ctx, s, opts...,
+	)
+}
@@ -0,0 +1,4 @@
+// Package sse checks the Server-Sent Events implementation against the httptest server.
+package sse
+
+//go:generate go -C ../.. run ./cmd/go-asyncapi code -t e2e/sse/asyncapi -M github.com/bdragon300/go-asyncapi/e2e/sse/asyncapi e2e/sse/asyncapi.yaml
@@ -0,0 +1,327 @@
+package sse
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bdragon300/go-asyncapi/e2e/sse/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/sse/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/sse/asyncapi/proto/sse"
+	"github.com/bdragon300/go-asyncapi/e2e/sse/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/e2e/sse/asyncapi/servers"
+)
+
+func TestStreamFraming(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType string
+		id        string
+		retry     time.Duration
+		data      string
+		want      string
+	}{
+		{
+			name: "data only",
+			data: "hello",
+			want: "id: 1\ndata: hello\n\n",
+		},
+		{
+			name:      "all fields",
+			eventType: "created",
+			id:        "abc",
+			retry:     1500 * time.Millisecond,
+			data:      "hello",
+			want:      "id: abc\nevent: created\nretry: 1500\ndata: hello\n\n",
+		},
+		{
+			name: "multiline data",
+			data: "line1\r\nline2\nline3\n",
+			want: "id: 1\ndata: line1\ndata: line2\ndata: line3\n\n",
+		},
+		{
+			name:      "newlines in fields",
+			eventType: "cre\nated",
+			id:        "a\r\nbc",
+			data:      "",
+			want:      "id: abc\nevent: created\ndata: \n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := sse.NewStream(10)
+			srv := httptest.NewServer(stream)
+			defer srv.Close()
+
+			resp, events := connect(t, srv.URL, "")
+			defer resp.Body.Close()
+			for k, want := range map[string]string{"Content-Type": "text/event-stream", "Cache-Control": "no-cache"} {
+				if v := resp.Header.Get(k); v != want {
+					t.Errorf("header %q = %q, want %q", k, v, want)
+				}
+			}
+
+			stream.Broadcast(tt.eventType, tt.id, tt.retry, []byte(tt.data))
+			if got := nextEvent(t, events); got != tt.want {
+				t.Errorf("event = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamReplay(t *testing.T) {
+	tests := []struct {
+		name        string
+		lastEventID string
+		want        []string
+	}{
+		{name: "no last event id", want: []string{"6"}},
+		{name: "replay after last event id", lastEventID: "3", want: []string{"4", "5", "6"}},
+		{name: "last event is the latest", lastEventID: "5", want: []string{"6"}},
+		{name: "last event id is evicted from buffer", lastEventID: "1", want: []string{"6"}},
+		{name: "unknown last event id", lastEventID: "foo", want: []string{"6"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := sse.NewStream(3)
+			srv := httptest.NewServer(stream)
+			defer srv.Close()
+			for range 5 {
+				stream.Broadcast("", "", 0, []byte("backlog"))
+			}
+
+			resp, events := connect(t, srv.URL, tt.lastEventID)
+			defer resp.Body.Close()
+			stream.Broadcast("", "", 0, []byte("live"))
+
+			var got []string
+			for range tt.want {
+				got = append(got, strings.TrimPrefix(strings.SplitN(nextEvent(t, events), "\n", 2)[0], "id: "))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("event ids = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamClosed(t *testing.T) {
+	stream := sse.NewStream(10)
+	srv := httptest.NewServer(stream)
+	defer srv.Close()
+
+	resp, events := connect(t, srv.URL, "")
+	defer resp.Body.Close()
+	stream.Close()
+	select {
+	case ev, ok := <-events:
+		if ok {
+			t.Fatalf("got event %q after closing, want disconnect", ev)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for disconnect")
+	}
+
+	// Closed stream tells the clients to stop reconnecting
+	resp2, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	resp2.Body.Close()
+	if resp2.StatusCode != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", resp2.StatusCode, http.StatusNoContent)
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	ctx := t.Context()
+	producer, err := servers.ConnectMainProducer(ctx, nil)
+	if err != nil {
+		t.Fatalf("connect producer: %v", err)
+	}
+	defer producer.Close()
+	pubCh, err := channels.OpenNotificationsSSE(ctx, producer, nil, nil)
+	if err != nil {
+		t.Fatalf("open publish channel: %v", err)
+	}
+	defer pubCh.Close()
+
+	// Record the Last-Event-ID header of every connection and notify when the connection is ready to get events
+	var mu sync.Mutex
+	var lastEventIDs []string
+	connected := make(chan struct{}, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		lastEventIDs = append(lastEventIDs, req.Header.Get("Last-Event-ID"))
+		mu.Unlock()
+		producer.Producer().(*sse.ProduceClient).ServeHTTP(&flushNotifier{ResponseWriter: w, notify: connected}, req)
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("parse url: %v", err)
+	}
+	consumer, err := servers.ConnectMainConsumer(ctx, u)
+	if err != nil {
+		t.Fatalf("connect consumer: %v", err)
+	}
+	defer consumer.Close()
+	subCh, err := channels.OpenNotificationsSSE(ctx, consumer, nil, nil)
+	if err != nil {
+		t.Fatalf("open subscribe channel: %v", err)
+	}
+	defer subCh.Close()
+
+	type received struct{ id, text string }
+	receivedCh := make(chan received, 10)
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	subErr := make(chan error, 1)
+	go func() {
+		subErr <- subCh.Subscribe(subCtx, func(envelope sse.EnvelopeReader) {
+			var m messages.NotificationIn
+			if err := subCh.UnsealNotification(envelope, &m); err != nil {
+				t.Errorf("UnsealNotification() error = %v", err)
+				return
+			}
+			receivedCh <- received{id: envelope.(*sse.EnvelopeIn).EventID(), text: m.Payload().Text}
+		})
+	}()
+	publish := func(text string, retry time.Duration) {
+		t.Helper()
+		envelope := sse.NewEnvelopeOut(nil)
+		if err := pubCh.SealNotification(envelope, new(messages.NotificationOut).SetPayload(schemas.Notification{Text: text})); err != nil {
+			t.Fatalf("SealNotification() error = %v", err)
+		}
+		envelope.SetRetry(retry)
+		if err := pubCh.Publish(ctx, envelope); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	wait := func(what string) {
+		t.Helper()
+		select {
+		case <-connected:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for %s", what)
+		}
+	}
+
+	wait("connection")
+	// Server sets the short reconnection delay instead of the default one
+	publish("a", 10*time.Millisecond)
+	var got []received
+	select {
+	case r := <-receivedCh:
+		got = append(got, r)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for event")
+	}
+
+	// Events published while the client is disconnected are replayed after reconnection
+	srv.CloseClientConnections()
+	publish("b", 0)
+	publish("c", 0)
+	wait("reconnection")
+	publish("d", 0)
+	for range 3 {
+		select {
+		case r := <-receivedCh:
+			got = append(got, r)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for events, got %v", got)
+		}
+	}
+	cancel()
+	if err = <-subErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("Subscribe() error = %v, want context.Canceled", err)
+	}
+
+	want := []received{{"1", "a"}, {"2", "b"}, {"3", "c"}, {"4", "d"}}
+	if !slices.Equal(got, want) {
+		t.Errorf("received = %v, want %v", got, want)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if !slices.Equal(lastEventIDs, []string{"", "1"}) {
+		t.Errorf("Last-Event-ID headers = %q, want %q", lastEventIDs, []string{"", "1"})
+	}
+}
+
+// connect makes the request to the event stream and returns the response and the channel with raw events.
+// The channel is closed when the stream ends.
+func connect(t *testing.T, u, lastEventID string) (*http.Response, <-chan string) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, u, nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	// Response headers are sent after the client has been registered in stream
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	events := make(chan string, 10)
+	go func() {
+		defer close(events)
+		rd := bufio.NewReader(resp.Body)
+		var event strings.Builder
+		for {
+			line, err := rd.ReadString('\n')
+			if err != nil {
+				return
+			}
+			event.WriteString(line)
+			if line == "\n" {
+				events <- event.String()
+				event.Reset()
+			}
+		}
+	}()
+	return resp, events
+}
+
+func nextEvent(t *testing.T, events <-chan string) string {
+	t.Helper()
+	select {
+	case ev, ok := <-events:
+		if !ok {
+			t.Fatal("stream is closed")
+		}
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for event")
+	}
+	return ""
+}
+
+// flushNotifier notifies on the first flush of the response, that means the client is ready to receive events.
+type flushNotifier struct {
+	http.ResponseWriter
+	notify  chan<- struct{}
+	flushed bool
+}
+
+func (f *flushNotifier) Flush() {
+	f.ResponseWriter.(http.Flusher).Flush()
+	if !f.flushed {
+		f.flushed = true
+		f.notify <- struct{}{}
+	}
+}
@@ -0,0 +1,176 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"github.com/bdragon300/go-asyncapi/e2e/tracing/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/tracing/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/tracing/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type ProcessOrderServerKafka interface {
+	OpenOrdersKafka(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersKafka, error)
+	OpenProcessOrderKafka(context.Context, ...run.MiddlewareOption) (*ProcessOrderKafka, error)
+	Producer() kafka.Producer
+	Consumer() kafka.Consumer
+}
+
+func OpenProcessOrderKafka(
+	ctx context.Context,
+	server ProcessOrderServerKafka,
+
+	opts ...run.MiddlewareOption,
+) (*ProcessOrderKafka, error) {
+	destination, err := channels.OrdersAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	opts = append([]run.MiddlewareOption{processOrderKafkaTracing(destination)}, opts...)
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "processOrder",
+			Protocol:  "kafka",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+		opts = append(opts, processOrderKafkaMetrics(metrics, metricLabels))
+	}
+	ch, err := channels.OpenOrdersKafka(
+		run.WithOperationName(ctx, "processOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProcessOrderKafka{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// processOrderKafkaTracing returns the middlewares that trace the operation with OpenTelemetry.
+// Trace context is propagated through the message headers.
+func processOrderKafkaTracing(destination string) run.MiddlewareOption {
+	tracer := otel.Tracer("github.com/bdragon300/go-asyncapi")
+	attrs := []attribute.KeyValue{
+		attribute.String("messaging.system", "kafka"),
+		attribute.String("messaging.destination.name", destination),
+	}
+	return func(m *run.Middlewares) {
+		run.WithSubscribeMiddleware(func(next run.SubscribeHandler[kafka.EnvelopeReader]) run.SubscribeHandler[kafka.EnvelopeReader] {
+			return func(ctx context.Context, envelope kafka.EnvelopeReader, message any) error {
+				ctx = otel.GetTextMapPropagator().Extract(ctx, envelope.Headers())
+				ctx, span := tracer.Start(
+					ctx,
+					"processOrder",
+					trace.WithSpanKind(trace.SpanKindConsumer),
+					trace.WithAttributes(attrs...),
+					trace.WithAttributes(
+						attribute.String("messaging.operation.name", "receive"),
+						attribute.String("messaging.operation.type", "process"),
+					),
+				)
+				defer span.End()
+
+				if v, ok := envelope.(interface{ MessageID() string }); ok && v.MessageID() != "" {
+					span.SetAttributes(attribute.String("messaging.message.id", v.MessageID()))
+				}
+
+				err := next(ctx, envelope, message)
+				if v, ok := message.(interface{ CorrelationID() (string, error) }); ok {
+					if id, err2 := v.CorrelationID(); err2 == nil && id != "" {
+						span.SetAttributes(attribute.String("messaging.message.conversation_id", id))
+					}
+				}
+				if err != nil {
+					span.RecordError(err)
+					span.SetStatus(codes.Error, err.Error())
+				}
+				return err
+			}
+		})(m)
+	}
+}
+
+// processOrderKafkaEnvelopeReader counts the payload bytes read from the envelope.
+type processOrderKafkaEnvelopeReader struct {
+	kafka.EnvelopeReader
+	size int
+}
+
+func (e *processOrderKafkaEnvelopeReader) Read(p []byte) (n int, err error) {
+	n, err = e.EnvelopeReader.Read(p)
+	e.size += n
+	return
+}
+
+// processOrderKafkaMetrics returns the middleware that reports the received messages metrics.
+func processOrderKafkaMetrics(metrics run.Metrics, labels run.MetricLabels) run.MiddlewareOption {
+	return run.WithSubscribeMiddleware(func(next run.SubscribeHandler[kafka.EnvelopeReader]) run.SubscribeHandler[kafka.EnvelopeReader] {
+		return func(ctx context.Context, envelope kafka.EnvelopeReader, message any) error {
+			labels := labels
+			switch message.(type) {
+			case *messages.OrderIn:
+				labels.Message = "order"
+			}
+			counter := &processOrderKafkaEnvelopeReader{EnvelopeReader: envelope}
+			err := next(ctx, counter, message)
+			if errors.Is(err, run.ErrUnsealEnvelope) {
+				metrics.UnmarshalFailed(labels, err)
+			}
+			metrics.MessageReceived(labels, counter.size, err)
+			return err
+		}
+	})
+}
+
+type ProcessOrderChannelKafka interface {
+	Close() error
+
+	SealOrder(kafka.EnvelopeWriter, channels.OrdersEnvelopeMarshalerKafka) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerKafka) error
+
+	UnsealOrder(kafka.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerKafka) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+}
+
+type ProcessOrderKafka struct {
+	Channel      ProcessOrderChannelKafka
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c ProcessOrderKafka) Close() error {
+	return c.Channel.Close()
+}
+
+func (o ProcessOrderKafka) UnsealOrder(
+	envelope kafka.EnvelopeReader,
+	message channels.OrdersEnvelopeUnmarshalerKafka,
+) error {
+	return o.Channel.UnsealOrder(envelope, message)
+}
+
+func (o ProcessOrderKafka) SubscribeOrder(
+	ctx context.Context,
+	cb func(ctx context.Context, message messages.OrderReceiver) error,
+) (err error) {
+	return o.Channel.SubscribeOrder(ctx, cb)
+}
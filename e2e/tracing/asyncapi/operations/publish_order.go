@@ -0,0 +1,172 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package operations
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/e2e/tracing/asyncapi/channels"
+	"github.com/bdragon300/go-asyncapi/e2e/tracing/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/tracing/asyncapi/proto/kafka"
+	"github.com/bdragon300/go-asyncapi/run"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+type PublishOrderServerKafka interface {
+	OpenOrdersKafka(context.Context, run.AnySecurityScheme, ...run.MiddlewareOption) (*channels.OrdersKafka, error)
+	OpenPublishOrderKafka(context.Context, ...run.MiddlewareOption) (*PublishOrderKafka, error)
+	Producer() kafka.Producer
+	Consumer() kafka.Consumer
+}
+
+func OpenPublishOrderKafka(
+	ctx context.Context,
+	server PublishOrderServerKafka,
+
+	opts ...run.MiddlewareOption,
+) (*PublishOrderKafka, error) {
+	destination, err := channels.OrdersAddress().Expand()
+	if err != nil {
+		return nil, err
+	}
+	opts = append([]run.MiddlewareOption{publishOrderKafkaTracing(destination)}, opts...)
+	metrics := run.NewMiddlewares(opts...).Metrics()
+	var metricLabels run.MetricLabels
+	if metrics != nil {
+		metricLabels = run.MetricLabels{
+			Channel:   "orders",
+			Operation: "publishOrder",
+			Protocol:  "kafka",
+		}
+		if v, ok := server.(interface{ Name() string }); ok {
+			metricLabels.Server = v.Name()
+		}
+	}
+	ch, err := channels.OpenOrdersKafka(
+		run.WithOperationName(ctx, "publishOrder"),
+		server,
+
+		nil,
+		nil,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PublishOrderKafka{
+		Channel:      ch,
+		metrics:      metrics,
+		metricLabels: metricLabels,
+	}, nil
+}
+
+// publishOrderKafkaTracing returns the middlewares that trace the operation with OpenTelemetry.
+// Trace context is propagated through the message headers.
+func publishOrderKafkaTracing(destination string) run.MiddlewareOption {
+	tracer := otel.Tracer("github.com/bdragon300/go-asyncapi")
+	attrs := []attribute.KeyValue{
+		attribute.String("messaging.system", "kafka"),
+		attribute.String("messaging.destination.name", destination),
+	}
+	return func(m *run.Middlewares) {
+		run.WithPublishMiddleware(func(next run.PublishHandler[kafka.EnvelopeWriter]) run.PublishHandler[kafka.EnvelopeWriter] {
+			return func(ctx context.Context, envelope kafka.EnvelopeWriter, message any) error {
+				ctx, span := tracer.Start(
+					ctx,
+					"publishOrder",
+					trace.WithSpanKind(trace.SpanKindProducer),
+					trace.WithAttributes(attrs...),
+					trace.WithAttributes(
+						attribute.String("messaging.operation.name", "send"),
+						attribute.String("messaging.operation.type", "send"),
+					),
+				)
+				defer span.End()
+
+				headers := make(run.Headers)
+				otel.GetTextMapPropagator().Inject(ctx, headers)
+				if len(headers) > 0 {
+					envelope.SetHeaders(headers)
+				}
+				if v, ok := envelope.(interface{ MessageID() string }); ok && v.MessageID() != "" {
+					span.SetAttributes(attribute.String("messaging.message.id", v.MessageID()))
+				}
+
+				err := next(ctx, envelope, message)
+				if err != nil {
+					span.RecordError(err)
+					span.SetStatus(codes.Error, err.Error())
+				}
+				return err
+			}
+		})(m)
+	}
+}
+
+// publishOrderKafkaEnvelopeWriter counts the payload bytes written to the envelope.
+type publishOrderKafkaEnvelopeWriter struct {
+	kafka.EnvelopeWriter
+	size int
+}
+
+func (e *publishOrderKafkaEnvelopeWriter) Write(p []byte) (n int, err error) {
+	n, err = e.EnvelopeWriter.Write(p)
+	e.size += n
+	return
+}
+
+type PublishOrderChannelKafka interface {
+	Close() error
+
+	SealOrder(kafka.EnvelopeWriter, channels.OrdersEnvelopeMarshalerKafka) error
+	PublishOrder(context.Context, channels.OrdersEnvelopeMarshalerKafka) error
+
+	UnsealOrder(kafka.EnvelopeReader, channels.OrdersEnvelopeUnmarshalerKafka) error
+	SubscribeOrder(context.Context, func(context.Context, messages.OrderReceiver) error) error
+	PublishEnvelope(context.Context, kafka.EnvelopeWriter, any) error
+}
+
+type PublishOrderKafka struct {
+	Channel      PublishOrderChannelKafka
+	metrics      run.Metrics
+	metricLabels run.MetricLabels
+}
+
+func (c PublishOrderKafka) Close() error {
+	return c.Channel.Close()
+}
+
+func (o PublishOrderKafka) SealOrder(
+	envelope kafka.EnvelopeWriter,
+	message channels.OrdersEnvelopeMarshalerKafka,
+) error {
+	return o.Channel.SealOrder(envelope, message)
+}
+
+func (o PublishOrderKafka) PublishOrder(
+	ctx context.Context,
+
+	message channels.OrdersEnvelopeMarshalerKafka,
+) error {
+	if o.metrics == nil {
+		return o.Channel.PublishOrder(ctx, message)
+	}
+
+	labels := o.metricLabels
+	labels.Message = "order"
+	envelope := kafka.NewEnvelopeOut(nil)
+	counter := &publishOrderKafkaEnvelopeWriter{EnvelopeWriter: envelope}
+	if err := o.Channel.SealOrder(counter, message); err != nil {
+		o.metrics.MarshalFailed(labels, err)
+		return err
+	}
+
+	start := time.Now()
+	err := o.Channel.PublishEnvelope(ctx, envelope, message)
+	o.metrics.MessagePublished(labels, counter.size, time.Since(start), err)
+	return err
+}
@@ -0,0 +1,42 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package kafka
+
+import (
+	"context"
+	"github.com/bdragon300/go-asyncapi/run"
+	"github.com/bdragon300/go-asyncapi/run/inmemory"
+	"net/url"
+)
+
+// NewClient returns the client connected to the in-memory broker registered for the server URL. Producers and
+// consumers connected to the same server share the broker, so the messages sent by one are received by another.
+func NewClient(_ context.Context, serverURL *url.URL, _ *ServerBindings, _ run.AnySecurityScheme) (*Client, error) {
+	return NewBrokerClient(inmemory.Lookup(serverURL)), nil
+}
+
+// NewBrokerClient returns the client connected to the given in-memory broker.
+func NewBrokerClient(broker *inmemory.Broker) *Client {
+	return &Client{Broker: broker}
+}
+
+// Client is the producer and consumer, that sends and receives the messages through the in-memory broker.
+// Security schemes are accepted, but not checked.
+type Client struct {
+	Broker *inmemory.Broker
+}
+
+func (c *Client) Publisher(_ context.Context, address string, chb *ChannelBindings, _ *OperationBindings, _ run.AnySecurityScheme) (Publisher, error) {
+	if chb != nil && chb.Topic != "" {
+		address = chb.Topic
+	}
+	return &PublishChannel{Client: c, address: address}, nil
+}
+
+func (c *Client) Subscriber(_ context.Context, address string, chb *ChannelBindings, _ *OperationBindings, _ run.AnySecurityScheme) (Subscriber, error) {
+	if chb != nil && chb.Topic != "" {
+		address = chb.Topic
+	}
+	filter := inmemory.MatchAddress(address)
+	return &Subscription{Subscription: c.Broker.Subscribe(filter)}, nil
+}
@@ -0,0 +1,99 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package kafka
+
+import (
+	"bytes"
+	"github.com/bdragon300/go-asyncapi/run"
+	"github.com/bdragon300/go-asyncapi/run/inmemory"
+	"io"
+)
+
+func NewEnvelopeOut(buf []byte) *EnvelopeOut {
+	return &EnvelopeOut{Buffer: bytes.NewBuffer(buf)}
+}
+
+type EnvelopeOut struct {
+	*bytes.Buffer
+	headers     run.Headers
+	contentType string
+	address     string
+	properties  map[string]any
+}
+
+func (e *EnvelopeOut) ResetPayload() {
+	e.Buffer.Reset()
+}
+
+func (e *EnvelopeOut) SetHeaders(headers run.Headers) {
+	if e.headers == nil {
+		e.headers = make(run.Headers, len(headers))
+	}
+	for k, v := range headers {
+		e.headers[k] = v
+	}
+}
+
+func (e *EnvelopeOut) SetContentType(contentType string) {
+	e.contentType = contentType
+}
+
+func (e *EnvelopeOut) SetBindings(_ MessageBindings) {}
+
+func (e *EnvelopeOut) SetTopic(topic string) {
+	e.address = topic
+}
+
+func (e *EnvelopeOut) SetSchema(_, _ string) {}
+
+func (e *EnvelopeOut) setProperty(key string, value any) {
+	if e.properties == nil {
+		e.properties = make(map[string]any)
+	}
+	e.properties[key] = value
+}
+
+// Message returns the broker message made from envelope. The envelope address, if set, overrides the defaultAddress.
+func (e *EnvelopeOut) Message(defaultAddress string) inmemory.Message {
+	msg := inmemory.Message{
+		Address:     defaultAddress,
+		Payload:     bytes.Clone(e.Bytes()),
+		Headers:     make(run.Headers, len(e.headers)),
+		ContentType: e.contentType,
+		Properties:  make(map[string]any, len(e.properties)),
+	}
+	if e.address != "" {
+		msg.Address = e.address
+	}
+	for k, v := range e.headers {
+		msg.Headers[k] = v
+	}
+	for k, v := range e.properties {
+		msg.Properties[k] = v
+	}
+	return msg
+}
+
+func NewEnvelopeIn(msg inmemory.Message) *EnvelopeIn {
+	return &EnvelopeIn{
+		Message: msg,
+		reader:  bytes.NewReader(msg.Payload),
+	}
+}
+
+type EnvelopeIn struct {
+	Message inmemory.Message
+	reader  io.Reader
+}
+
+func (e *EnvelopeIn) Read(p []byte) (n int, err error) {
+	return e.reader.Read(p)
+}
+
+func (e *EnvelopeIn) Headers() run.Headers {
+	return e.Message.Headers
+}
+
+func (e *EnvelopeIn) VerifyBindings(_ MessageBindings) error {
+	return nil
+}
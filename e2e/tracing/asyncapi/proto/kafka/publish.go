@@ -0,0 +1,29 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package kafka
+
+import (
+	"context"
+)
+
+// PublishChannel publishes the envelopes to the in-memory broker. The message address is the channel address,
+// unless it is changed in envelope by SetTopic.
+type PublishChannel struct {
+	Client *Client
+
+	address string
+}
+
+func (p *PublishChannel) Send(ctx context.Context, envelopes ...EnvelopeWriter) error {
+	for _, env := range envelopes {
+		msg := env.(*EnvelopeOut).Message(p.address)
+		if err := p.Client.Broker.Publish(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PublishChannel) Close() error {
+	return nil
+}
@@ -0,0 +1,50 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package kafka
+
+import (
+	"time"
+)
+
+type (
+	ServerBindings struct {
+		SchemaRegistryURL    string
+		SchemaRegistryVendor string
+	}
+
+	ChannelBindings struct {
+		Topic              string
+		Partitions         int
+		Replicas           int
+		TopicConfiguration TopicConfiguration
+	}
+
+	TopicConfiguration struct {
+		CleanupPolicy       TopicCleanupPolicy
+		RetentionTime       time.Duration
+		RetentionBytes      int
+		DeleteRetentionTime time.Duration
+		MaxMessageBytes     int
+	}
+
+	TopicCleanupPolicy struct {
+		Delete  bool
+		Compact bool
+	}
+
+	OperationBindings struct {
+		ClientID any // jsonschema contents
+		GroupID  any // jsonschema contents
+	}
+
+	MessageBindings struct {
+		Key                     any // TODO: jsonschema
+		SchemaIDLocation        string
+		SchemaIDPayloadEncoding string
+		SchemaLookupStrategy    string
+	}
+)
+
+// ReplyTopicHeader is the record header that keeps the topic to send the reply to. The requester sets it to the
+// reply channel topic. The header name is the same as in Spring for Apache Kafka.
+const ReplyTopicHeader = "kafka_replyTopic"
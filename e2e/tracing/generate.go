@@ -0,0 +1,4 @@
+// Package tracing checks the OpenTelemetry spans of the operations against the in-memory span exporter.
+package tracing
+
+//go:generate go -C ../.. run ./cmd/go-asyncapi -c e2e/tracing/go-asyncapi.yaml code -t e2e/tracing/asyncapi -M github.com/bdragon300/go-asyncapi/e2e/tracing/asyncapi e2e/tracing/asyncapi.yaml
@@ -0,0 +1,132 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bdragon300/go-asyncapi/e2e/tracing/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/tracing/asyncapi/schemas"
+	"github.com/bdragon300/go-asyncapi/e2e/tracing/asyncapi/servers"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	ctx := t.Context()
+	conn, err := servers.ConnectMainBidi(ctx, &url.URL{Scheme: "kafka", Host: t.Name()})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer conn.Close()
+	recvOp, err := conn.OpenProcessOrderKafka(ctx)
+	if err != nil {
+		t.Fatalf("open receive operation: %v", err)
+	}
+	defer recvOp.Close()
+	sendOp, err := conn.OpenPublishOrderKafka(ctx)
+	if err != nil {
+		t.Fatalf("open send operation: %v", err)
+	}
+	defer sendOp.Close()
+
+	tracer := provider.Tracer("test")
+	rootCtx, root := tracer.Start(ctx, "root")
+	if err = sendOp.PublishOrder(rootCtx, new(messages.OrderOut).SetPayload(schemas.Order{ID: "1"})); err != nil {
+		t.Fatalf("PublishOrder() error = %v", err)
+	}
+	root.End()
+
+	subCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	err = recvOp.SubscribeOrder(subCtx, func(ctx context.Context, _ messages.OrderReceiver) error {
+		// Callback gets the context with the receive span
+		_, span := tracer.Start(ctx, "handle")
+		span.End()
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("SubscribeOrder() error = %v, want context.Canceled", err)
+	}
+
+	spans := make(map[string]tracetest.SpanStub)
+	for _, s := range exporter.GetSpans() {
+		spans[s.Name] = s
+	}
+	for _, name := range []string{"root", "publishOrder", "processOrder", "handle"} {
+		if _, ok := spans[name]; !ok {
+			t.Fatalf("span %q is not exported, got %v", name, exporter.GetSpans())
+		}
+	}
+
+	tests := []struct {
+		name   string
+		kind   trace.SpanKind
+		parent string
+		attrs  map[attribute.Key]string
+	}{
+		{
+			name:   "publishOrder",
+			kind:   trace.SpanKindProducer,
+			parent: "root",
+			attrs: map[attribute.Key]string{
+				"messaging.system":           "kafka",
+				"messaging.destination.name": "orders",
+				"messaging.operation.name":   "send",
+				"messaging.operation.type":   "send",
+			},
+		},
+		{
+			// Trace context is propagated through the message headers
+			name:   "processOrder",
+			kind:   trace.SpanKindConsumer,
+			parent: "publishOrder",
+			attrs: map[attribute.Key]string{
+				"messaging.system":           "kafka",
+				"messaging.destination.name": "orders",
+				"messaging.operation.name":   "receive",
+				"messaging.operation.type":   "process",
+			},
+		},
+		{
+			name:   "handle",
+			kind:   trace.SpanKindInternal,
+			parent: "processOrder",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			span := spans[tt.name]
+			if span.SpanKind != tt.kind {
+				t.Errorf("span kind = %v, want %v", span.SpanKind, tt.kind)
+			}
+			parent := spans[tt.parent].SpanContext
+			if span.Parent.TraceID() != parent.TraceID() || span.Parent.SpanID() != parent.SpanID() {
+				t.Errorf("parent = %v/%v, want span %q %v/%v",
+					span.Parent.TraceID(), span.Parent.SpanID(), tt.parent, parent.TraceID(), parent.SpanID())
+			}
+			got := make(map[attribute.Key]string)
+			for _, kv := range span.Attributes {
+				got[kv.Key] = kv.Value.Emit()
+			}
+			for k, want := range tt.attrs {
+				if got[k] != want {
+					t.Errorf("attribute %q = %q, want %q", k, got[k], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package messages
+
+import (
+	"fmt"
+	"github.com/bdragon300/go-asyncapi/e2e/validation/asyncapi/schemas"
+)
+
+type LightMeasuredSender interface {
+	SetPayload(payload schemas.LightMeasuredPayload) *LightMeasuredOut
+	SetHeaders(headers map[string]any) *LightMeasuredOut
+}
+
+// LightMeasuredOut-- (Outbound Message)
+type LightMeasuredOut struct {
+	Payload schemas.LightMeasuredPayload
+	Headers map[string]any
+}
+
+// Validate checks the LightMeasuredOut value against the constraints from the jsonschema definition.
+func (v LightMeasuredOut) Validate() error {
+	if err := v.Payload.Validate(); err != nil {
+		return fmt.Errorf("Payload: %w", err)
+	}
+	return nil
+}
+
+func (m *LightMeasuredOut) SetPayload(payload schemas.LightMeasuredPayload) *LightMeasuredOut {
+	m.Payload = payload
+	return m
+}
+
+func (m *LightMeasuredOut) SetHeaders(headers map[string]any) *LightMeasuredOut {
+	m.Headers = headers
+	return m
+}
+
+type LightMeasuredReceiver interface {
+	Payload() schemas.LightMeasuredPayload
+	Headers() map[string]any
+}
+
+// LightMeasuredIn-- (Inbound Message)
+type LightMeasuredIn struct {
+	payload schemas.LightMeasuredPayload
+	headers map[string]any
+}
+
+// Validate checks the LightMeasuredIn value against the constraints from the jsonschema definition.
+func (v LightMeasuredIn) Validate() error {
+	if err := v.payload.Validate(); err != nil {
+		return fmt.Errorf("payload: %w", err)
+	}
+	return nil
+}
+
+func (m *LightMeasuredIn) Payload() schemas.LightMeasuredPayload {
+	return m.payload
+}
+
+func (m *LightMeasuredIn) Headers() map[string]any {
+	return m.headers
+}
@@ -0,0 +1,80 @@
+// Code generated by go-asyncapi tool. DO NOT EDIT.
+
+package schemas
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"unicode/utf8"
+)
+
+type LightMeasuredPayload struct {
+	ID                   *int     `json:"id"`
+	Name                 *string  `json:"name"`
+	Mode                 *string  `json:"mode"`
+	Lumens               *int     `json:"lumens"`
+	Ratio                *float64 `json:"ratio"`
+	AdditionalProperties map[string]string
+}
+
+// Validate checks the LightMeasuredPayload value against the constraints from the jsonschema definition.
+func (v LightMeasuredPayload) Validate() error {
+	{
+		n := 0
+		if v.ID != nil {
+			n++
+		}
+		if v.Name != nil {
+			n++
+		}
+		if v.Mode != nil {
+			n++
+		}
+		if v.Lumens != nil {
+			n++
+		}
+		if v.Ratio != nil {
+			n++
+		}
+		n += len(v.AdditionalProperties)
+		if n < 2 {
+			return fmt.Errorf("must contain at least 2 properties, got %d", n)
+		}
+		if n > 4 {
+			return fmt.Errorf("must contain at most 4 properties, got %d", n)
+		}
+	}
+	if v.ID == nil {
+		return fmt.Errorf("id: required field is not set")
+	}
+	if v.ID != nil {
+		if float64((*v.ID)) < 0.0 {
+			return fmt.Errorf("id: must be >= 0, got %v", (*v.ID))
+		}
+	}
+	if v.Name != nil {
+		if utf8.RuneCountInString(string((*v.Name))) < 2 {
+			return fmt.Errorf("name: length must be >= 2, got %q", (*v.Name))
+		}
+	}
+	if v.Mode != nil {
+		if !slices.Contains([]string{"auto", "manual"}, string((*v.Mode))) {
+			return fmt.Errorf("mode: must be one of [auto manual], got %v", (*v.Mode))
+		}
+	}
+	if v.Lumens != nil {
+		if float64((*v.Lumens)) < 1.0 {
+			return fmt.Errorf("lumens: must be >= 1, got %v", (*v.Lumens))
+		}
+		if int64((*v.Lumens))%5 != 0 {
+			return fmt.Errorf("lumens: must be a multiple of 5, got %v", (*v.Lumens))
+		}
+	}
+	if v.Ratio != nil {
+		if q := float64((*v.Ratio)) / 0.1; math.Abs(q-math.Round(q)) > 1e-9 {
+			return fmt.Errorf("ratio: must be a multiple of 0.1, got %v", (*v.Ratio))
+		}
+	}
+	return nil
+}
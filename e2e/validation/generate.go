@@ -0,0 +1,4 @@
+// Package validation checks the Validate methods generated from jsonschema constraints.
+package validation
+
+//go:generate go -C ../.. run ./cmd/go-asyncapi code --disable-implementations -t e2e/validation/asyncapi -M github.com/bdragon300/go-asyncapi/e2e/validation/asyncapi e2e/validation/asyncapi.yaml
@@ -0,0 +1,69 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/bdragon300/go-asyncapi/e2e/validation/asyncapi/messages"
+	"github.com/bdragon300/go-asyncapi/e2e/validation/asyncapi/schemas"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   schemas.LightMeasuredPayload
+		wantErr bool
+	}{
+		{"optional fields are absent", schemas.LightMeasuredPayload{ID: ptr(1), Ratio: ptr(0.3)}, false},
+		{"all fields are valid", schemas.LightMeasuredPayload{ID: ptr(1), Name: ptr("lamp"), Mode: ptr("auto"), Lumens: ptr(10)}, false},
+		{"required field is absent", schemas.LightMeasuredPayload{Name: ptr("lamp")}, true},
+		{"minLength", schemas.LightMeasuredPayload{ID: ptr(1), Name: ptr("l")}, true},
+		{"enum", schemas.LightMeasuredPayload{ID: ptr(1), Mode: ptr("off")}, true},
+		{"minimum", schemas.LightMeasuredPayload{ID: ptr(1), Lumens: ptr(-5)}, true},
+		{"integer multipleOf", schemas.LightMeasuredPayload{ID: ptr(1), Lumens: ptr(7)}, true},
+		{"number multipleOf", schemas.LightMeasuredPayload{ID: ptr(1), Ratio: ptr(0.35)}, true},
+		{"minProperties", schemas.LightMeasuredPayload{ID: ptr(1)}, true},
+		{
+			"maxProperties with additionalProperties",
+			schemas.LightMeasuredPayload{ID: ptr(1), Name: ptr("lamp"), AdditionalProperties: map[string]string{"a": "1", "b": "2", "c": "3"}},
+			true,
+		},
+		// Zero values set explicitly are present and checked
+		{"zero value minimum", schemas.LightMeasuredPayload{ID: ptr(1), Lumens: ptr(0)}, true},
+		{"zero value minLength", schemas.LightMeasuredPayload{ID: ptr(1), Name: ptr("")}, true},
+		{"zero value enum", schemas.LightMeasuredPayload{ID: ptr(1), Mode: ptr("")}, true},
+		{"zero value multipleOf", schemas.LightMeasuredPayload{ID: ptr(1), Ratio: ptr(0.0)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.value.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   messages.LightMeasuredOut
+		wantErr bool
+	}{
+		{"valid payload", *new(messages.LightMeasuredOut).SetPayload(schemas.LightMeasuredPayload{ID: ptr(1), Name: ptr("lamp")}), false},
+		// Payload is always sent, so the zero payload is checked as well
+		{"zero payload", messages.LightMeasuredOut{}, true},
+		{"invalid payload", *new(messages.LightMeasuredOut).SetPayload(schemas.LightMeasuredPayload{ID: ptr(1), Lumens: ptr(0)}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.value.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
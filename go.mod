@@ -8,7 +8,10 @@ require (
 	github.com/bdragon300/go-asyncapi/run v0.0.0-20260111064117-e9ede27542aa
 	github.com/buger/jsonparser v1.1.1
 	github.com/charmbracelet/log v0.4.2
+	github.com/emicklei/proto v1.14.3
+	github.com/fsnotify/fsnotify v1.7.1-0.20240403050945-7086bea086b7
 	github.com/go-sprout/sprout v1.0.3
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/samber/lo v1.52.0
 	golang.org/x/exp v0.0.0-20251219203646-944ab1f22d93
 	golang.org/x/mod v0.32.0
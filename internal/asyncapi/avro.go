@@ -0,0 +1,511 @@
+package asyncapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bdragon300/go-asyncapi/internal/common"
+	"github.com/bdragon300/go-asyncapi/internal/compiler/compile"
+	"github.com/bdragon300/go-asyncapi/internal/render"
+	"github.com/bdragon300/go-asyncapi/internal/render/lang"
+	"github.com/bdragon300/go-asyncapi/internal/types"
+	"github.com/bdragon300/go-asyncapi/internal/utils"
+	"github.com/samber/lo"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// AvroSchema is the [Apache Avro] schema, that is set in the Multi Format Schema object. The Go types are produced
+// in the way the [hamba/avro] library maps the Avro types.
+//
+// [Apache Avro]: https://avro.apache.org/docs/1.11.1/specification/
+// [hamba/avro]: https://github.com/hamba/avro
+type AvroSchema struct {
+	// value is decoded schema: type name string, union list or complex type object
+	value any
+}
+
+func (a *AvroSchema) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.value); err != nil {
+		return err
+	}
+	return a.decodeStringSchema()
+}
+
+func (a *AvroSchema) UnmarshalYAML(value *yaml.Node) error {
+	if err := value.Decode(&a.value); err != nil {
+		return err
+	}
+	if err := a.decodeStringSchema(); err != nil {
+		return err
+	}
+	a.value = normalizeAvroYAMLType(a.value)
+	return nil
+}
+
+func (a AvroSchema) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.value)
+}
+
+func (a AvroSchema) MarshalYAML() (any, error) {
+	return a.value, nil
+}
+
+// decodeStringSchema decodes the schema if it has been set as JSON string, e.g. `schema: '{"type": "record", ...}'`
+func (a *AvroSchema) decodeStringSchema() error {
+	s, ok := a.value.(string)
+	if !ok {
+		return nil
+	}
+	if s = strings.TrimSpace(s); strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[") {
+		return json.Unmarshal([]byte(s), &a.value)
+	}
+	return nil
+}
+
+// normalizeAvroYAMLType replaces the unquoted YAML nulls in type definitions, e.g. `type: [null, string]`,
+// with the "null" type name.
+func normalizeAvroYAMLType(typ any) any {
+	switch v := typ.(type) {
+	case nil:
+		return "null"
+	case []any:
+		return lo.Map(v, func(item any, _ int) any { return normalizeAvroYAMLType(item) })
+	case map[string]any:
+		for _, k := range []string{"type", "items", "values"} {
+			if t, ok := v[k]; ok {
+				v[k] = normalizeAvroYAMLType(t)
+			}
+		}
+		if fields, ok := v["fields"].([]any); ok {
+			for _, f := range fields {
+				if fm, ok := f.(map[string]any); ok {
+					fm["type"] = normalizeAvroYAMLType(fm["type"])
+				}
+			}
+		}
+	}
+	return typ
+}
+
+func (a AvroSchema) Compile(ctx *compile.Context) error {
+	obj, err := a.build(ctx, ctx.Stack.Top().Flags)
+	if err != nil {
+		return err
+	}
+	ctx.PutArtifact(obj)
+	return nil
+}
+
+func (a AvroSchema) build(ctx *compile.Context, flags map[common.SchemaTag]string) (common.GolangType, error) {
+	_, isSelectable := flags[common.SchemaTagSelectable]
+	b := avroTypeBuilder{
+		ctx:          ctx,
+		isSelectable: isSelectable,
+		namedTypes:   make(map[string]common.GolangType),
+		contentTypesFunc: func() []string {
+			return []string{"avro"}
+		},
+	}
+
+	_, isDataModel := flags[common.SchemaTagDataModel]
+	if isDataModel {
+		ctx.Logger.Trace("Avro schema is data model")
+		messagesPrm := lang.NewListCbPromise[*render.Message](func(item common.Artifact) bool {
+			_, ok := item.(*render.Message)
+			return ok
+		}, nil)
+		ctx.PutListPromise(messagesPrm)
+		b.contentTypesFunc = func() []string {
+			return messagesTagNames(messagesPrm.T(), "avro")
+		}
+	}
+
+	return b.build(a.value, "", true)
+}
+
+// JSON returns the schema as JSON string, which is suitable to be parsed by Avro libraries.
+func (a AvroSchema) JSON() (string, error) {
+	b, err := json.Marshal(a.value)
+	return string(b), err
+}
+
+// avroTypeBuilder builds the Go types from Avro schema. It keeps track of named types (records, enums, fixed)
+// defined in the schema, so they can be referenced further by name.
+type avroTypeBuilder struct {
+	ctx              *compile.Context
+	isSelectable     bool
+	contentTypesFunc func() []string
+	// namedTypes contains the types by full name. Nil value means the type is being built yet.
+	namedTypes map[string]common.GolangType
+	// path is the path to the schema being built, relative to the schema root
+	path []string
+	// name is the name for the Go types that have no name in schema, such as unions
+	name string
+}
+
+// buildNested builds the nested schema located at the given path relative to the current one, setting the name
+// for Go types that have no name in schema.
+func (b *avroTypeBuilder) buildNested(schema any, namespace, name string, path ...string) (common.GolangType, error) {
+	prevPath, prevName := b.path, b.name
+	b.path, b.name = append(slices.Clone(b.path), path...), name
+	defer func() { b.path, b.name = prevPath, prevName }()
+
+	return b.build(schema, namespace, false)
+}
+
+func (b *avroTypeBuilder) build(schema any, namespace string, topLevel bool) (common.GolangType, error) {
+	switch v := schema.(type) {
+	case string:
+		return b.buildTypeName(v, namespace)
+	case []any:
+		return b.buildUnion(v, namespace)
+	case map[string]any:
+		return b.buildComplexType(v, namespace, topLevel)
+	}
+	return nil, b.error(fmt.Errorf("unexpected avro schema value %v", schema))
+}
+
+func (b *avroTypeBuilder) buildTypeName(name, namespace string) (common.GolangType, error) {
+	b.ctx.Logger.Trace("Avro type", "name", name)
+	switch name {
+	case "null":
+		return &lang.GoSimple{TypeName: "any", IsInterface: true}, nil
+	case "boolean":
+		return &lang.GoSimple{TypeName: "bool"}, nil
+	case "int":
+		return &lang.GoSimple{TypeName: "int32"}, nil
+	case "long":
+		return &lang.GoSimple{TypeName: "int64"}, nil
+	case "float":
+		return &lang.GoSimple{TypeName: "float32"}, nil
+	case "double":
+		return &lang.GoSimple{TypeName: "float64"}, nil
+	case "bytes":
+		return &lang.GoArray{ItemsType: &lang.GoSimple{TypeName: "byte"}}, nil
+	case "string":
+		return &lang.GoSimple{TypeName: "string"}, nil
+	}
+
+	// Reference to a named type
+	fullNames := []string{name}
+	if !strings.Contains(name, ".") && namespace != "" {
+		fullNames = []string{namespace + "." + name, name}
+	}
+	for _, n := range fullNames {
+		if t, ok := b.namedTypes[n]; ok {
+			if t == nil {
+				return nil, b.error(fmt.Errorf("recursive avro type %q is not supported", n))
+			}
+			return t, nil
+		}
+	}
+	return nil, b.error(fmt.Errorf("unknown avro type %q", name))
+}
+
+func (b *avroTypeBuilder) buildUnion(variants []any, namespace string) (common.GolangType, error) {
+	var nonNullVariants []any
+	var variantTypes []common.GolangType
+	for i, v := range variants {
+		if v == "null" {
+			continue
+		}
+		// Build all variants anyway, since they may define named types referenced further in schema
+		t, err := b.buildNested(v, namespace, b.name, strconv.Itoa(i))
+		if err != nil {
+			return nil, err
+		}
+		nonNullVariants = append(nonNullVariants, v)
+		variantTypes = append(variantTypes, t)
+	}
+	nullable := len(nonNullVariants) < len(variants)
+	b.ctx.Logger.Trace("Avro union", "variants", len(variants), "nullable", nullable)
+
+	switch {
+	case len(variantTypes) == 1 && nullable:
+		if _, ok := variantTypes[0].(*lang.GoPointer); ok {
+			return variantTypes[0], nil
+		}
+		return &lang.GoPointer{Type: variantTypes[0]}, nil
+	case len(variantTypes) == 1:
+		return variantTypes[0], nil
+	case len(variantTypes) == 0:
+		return &lang.GoSimple{TypeName: "any", IsInterface: true}, nil
+	}
+	if len(b.path) == 0 {
+		b.ctx.Logger.Warn("Avro union with several non-null types on the top level is represented as `any` type")
+		return &lang.GoSimple{TypeName: "any", IsInterface: true}, nil
+	}
+	res, err := b.buildUnionStruct(nonNullVariants, variantTypes)
+	if err != nil {
+		b.ctx.Logger.Warn("Avro union is represented as `any` type", "err", err, "ref", b.ctx.CurrentRefPointer(b.path...))
+		return &lang.GoSimple{TypeName: "any", IsInterface: true}, nil
+	}
+	b.ctx.PutArtifact(res, b.path...)
+	// hamba/avro requires the union type that implements avro.UnionConverter to be a pointer. Nil pointer is
+	// encoded as null, if union is nullable.
+	return &lang.GoPointer{Type: res}, nil
+}
+
+// buildUnionStruct returns the union struct for Avro union with several non-null types. Returns error if union
+// types can't be distinguished in Go code, so the union can't be represented as struct.
+func (b *avroTypeBuilder) buildUnionStruct(variants []any, variantTypes []common.GolangType) (*lang.UnionStruct, error) {
+	res := &lang.UnionStruct{
+		GoStruct: lang.GoStruct{
+			BaseType: lang.BaseType{
+				OriginalName:  lo.Ternary(b.name != "", b.name, b.ctx.GenerateObjName("", "Union")),
+				HasDefinition: true,
+				ArtifactKind:  common.ArtifactKindSchema,
+			},
+		},
+	}
+	decodedTypes := make(map[any]struct{})
+	for i, v := range variants {
+		typeName, avroVariant, decodedType, err := b.unionVariant(v, variantTypes[i])
+		if err != nil {
+			return nil, err
+		}
+		fieldName := utils.ToGolangName(typeName, true)
+		if _, ok := decodedTypes[decodedType]; ok || lo.ContainsBy(res.Fields, func(item lang.GoStructField) bool {
+			return item.OriginalName == fieldName
+		}) {
+			return nil, fmt.Errorf("type %q can't be distinguished from other union types", typeName)
+		}
+		decodedTypes[decodedType] = struct{}{}
+		res.Fields = append(res.Fields, lang.GoStructField{
+			OriginalName: fieldName,
+			Type:         &lang.GoPointer{Type: variantTypes[i]},
+		})
+		res.AvroVariants = append(res.AvroVariants, avroVariant)
+	}
+	return res, nil
+}
+
+// unionVariant returns the Avro union branch info for the given type. Also returns the type name and the key that
+// identifies the type the branch value is decoded to.
+func (b *avroTypeBuilder) unionVariant(schema any, typ common.GolangType) (typeName string, variant lang.AvroUnionVariant, decodedType any, err error) {
+	if m, ok := schema.(map[string]any); ok {
+		if logicalType, _ := m["logicalType"].(string); logicalType != "" {
+			// Only these logical types are pre-registered in hamba/avro
+			registered := []string{"date", "time-millis", "time-micros", "timestamp-millis", "timestamp-micros"}
+			if t, ok := typ.(*lang.GoSimple); ok && t.Import == "time" && lo.Contains(registered, logicalType) {
+				return logicalType, variant, t.Import + "." + t.TypeName, nil
+			}
+			return "", variant, nil, fmt.Errorf("logical type %q is not supported in union", logicalType)
+		}
+		schema = m["type"]
+	}
+	name, ok := schema.(string)
+	if !ok {
+		return "", variant, nil, fmt.Errorf("nested union %v is not supported", schema)
+	}
+
+	// Primitive types are decoded to the types pre-registered in hamba/avro
+	switch name {
+	case "int":
+		return name, lang.AvroUnionVariant{DecodedType: "int"}, name, nil
+	case "boolean", "long", "float", "double", "bytes", "string":
+		return name, variant, name, nil
+	}
+	// Records are decoded to the registered type, which is looked up by full name
+	fullName, ok := lo.FindKey(b.namedTypes, typ)
+	if _, isStruct := typ.(*lang.GoStruct); !ok || !isStruct {
+		return "", variant, nil, fmt.Errorf("avro type %q is not supported in union", name)
+	}
+	return lo.LastOrEmpty(strings.Split(fullName, ".")), lang.AvroUnionVariant{Name: fullName}, typ, nil
+}
+
+func (b *avroTypeBuilder) buildComplexType(schema map[string]any, namespace string, topLevel bool) (common.GolangType, error) {
+	if _, ok := schema["$ref"]; ok {
+		return nil, b.error(errors.New("$ref in avro schema is not supported"))
+	}
+
+	typ, ok := schema["type"].(string)
+	if !ok {
+		// Type is a nested schema, e.g. {"type": {"type": "array", "items": "string"}}
+		if topLevel {
+			return b.build(schema["type"], namespace, topLevel)
+		}
+		return b.buildNested(schema["type"], namespace, b.name, "type")
+	}
+	if t := b.buildLogicalType(typ, schema); t != nil {
+		return t, nil
+	}
+
+	switch typ {
+	case "record", "error":
+		return b.buildRecord(schema, namespace, topLevel)
+	case "enum":
+		return b.buildEnum(schema, namespace, topLevel)
+	case "fixed":
+		fullName, _, err := b.registerNamedType(schema, namespace)
+		if err != nil {
+			return nil, err
+		}
+		size, ok := schema["size"].(float64)
+		if !ok {
+			if s, ok2 := schema["size"].(int); ok2 {
+				size = float64(s)
+			}
+		}
+		if size <= 0 {
+			return nil, b.error(fmt.Errorf("avro fixed type %q has invalid size", fullName))
+		}
+		var res common.GolangType = &lang.GoArray{ItemsType: &lang.GoSimple{TypeName: "byte"}, Size: int(size)}
+		if schema["logicalType"] == "decimal" {
+			res = &lang.GoPointer{Type: &lang.GoSimple{TypeName: "Rat", Import: "math/big"}}
+		}
+		b.namedTypes[fullName] = res
+		return res, nil
+	case "array":
+		b.ctx.Logger.Trace("Avro array")
+		items, err := b.buildNested(schema["items"], namespace, b.name+"Item", "items")
+		if err != nil {
+			return nil, err
+		}
+		return &lang.GoArray{ItemsType: items}, nil
+	case "map":
+		b.ctx.Logger.Trace("Avro map")
+		values, err := b.buildNested(schema["values"], namespace, b.name+"Value", "values")
+		if err != nil {
+			return nil, err
+		}
+		return &lang.GoMap{KeyType: &lang.GoSimple{TypeName: "string"}, ValueType: values}, nil
+	}
+	return b.buildTypeName(typ, namespace)
+}
+
+// buildLogicalType returns the Go type for Avro logical type if it is set in schema and supported. Otherwise,
+// returns nil, so the underlying type is used.
+func (b *avroTypeBuilder) buildLogicalType(typ string, schema map[string]any) common.GolangType {
+	logicalType, _ := schema["logicalType"].(string)
+	if logicalType == "" {
+		return nil
+	}
+	b.ctx.Logger.Trace("Avro logical type", "type", typ, "logicalType", logicalType)
+
+	switch typ + "." + logicalType {
+	case "int.date", "long.timestamp-millis", "long.timestamp-micros",
+		"long.local-timestamp-millis", "long.local-timestamp-micros":
+		return &lang.GoSimple{TypeName: "Time", Import: "time"}
+	case "int.time-millis", "long.time-micros":
+		return &lang.GoSimple{TypeName: "Duration", Import: "time"}
+	case "bytes.decimal":
+		return &lang.GoPointer{Type: &lang.GoSimple{TypeName: "Rat", Import: "math/big"}}
+	}
+	return nil
+}
+
+func (b *avroTypeBuilder) buildRecord(schema map[string]any, namespace string, topLevel bool) (common.GolangType, error) {
+	fullName, namespace, err := b.registerNamedType(schema, namespace)
+	if err != nil {
+		return nil, err
+	}
+	b.ctx.Logger.Trace("Avro record", "name", fullName)
+
+	hasDefinition := topLevel && b.isSelectable
+	doc, _ := schema["doc"].(string)
+	res := &lang.GoStruct{
+		BaseType: lang.BaseType{
+			OriginalName:  b.ctx.GenerateObjName(lo.LastOrEmpty(strings.Split(fullName, ".")), ""),
+			Description:   doc,
+			HasDefinition: hasDefinition,
+			ArtifactKind:  lo.Ternary(hasDefinition, common.ArtifactKindSchema, common.ArtifactKindOther),
+		},
+	}
+
+	fields, ok := schema["fields"].([]any)
+	if !ok {
+		return nil, b.error(fmt.Errorf("avro record %q has no fields list", fullName))
+	}
+	b.ctx.Logger.NextCallLevel()
+	defer b.ctx.Logger.PrevCallLevel()
+	for i, item := range fields {
+		field, ok := item.(map[string]any)
+		if !ok {
+			return nil, b.error(fmt.Errorf("avro record %q has invalid field %v", fullName, item))
+		}
+		fieldName, _ := field["name"].(string)
+		if fieldName == "" {
+			return nil, b.error(fmt.Errorf("avro record %q has field without name", fullName))
+		}
+		b.ctx.Logger.Trace("Avro record field", "name", fieldName)
+		fieldType, err := b.buildNested(field["type"], namespace, res.OriginalName+utils.ToGolangName(fieldName, true), "fields", strconv.Itoa(i), "type")
+		if err != nil {
+			return nil, err
+		}
+		fieldDoc, _ := field["doc"].(string)
+		res.Fields = append(res.Fields, lang.GoStructField{
+			OriginalName:     utils.ToGolangName(fieldName, true),
+			MarshalName:      fieldName,
+			Description:      fieldDoc,
+			Type:             fieldType,
+			ContentTypesFunc: b.contentTypesFunc,
+		})
+	}
+
+	b.namedTypes[fullName] = res
+	return res, nil
+}
+
+func (b *avroTypeBuilder) buildEnum(schema map[string]any, namespace string, topLevel bool) (common.GolangType, error) {
+	fullName, _, err := b.registerNamedType(schema, namespace)
+	if err != nil {
+		return nil, err
+	}
+	b.ctx.Logger.Trace("Avro enum", "name", fullName)
+
+	symbols, ok := schema["symbols"].([]any)
+	if !ok || len(symbols) == 0 {
+		return nil, b.error(fmt.Errorf("avro enum %q has no symbols", fullName))
+	}
+	hasDefinition := topLevel && b.isSelectable
+	doc, _ := schema["doc"].(string)
+	res := &lang.GoTypeDefinition{
+		BaseType: lang.BaseType{
+			OriginalName:  b.ctx.GenerateObjName(lo.LastOrEmpty(strings.Split(fullName, ".")), ""),
+			Description:   doc,
+			HasDefinition: hasDefinition,
+			ArtifactKind:  lo.Ternary(hasDefinition, common.ArtifactKindSchema, common.ArtifactKindOther),
+			Constraints:   &lang.SchemaConstraints{Enum: symbols},
+		},
+		RedefinedType: &lang.GoSimple{TypeName: "string"},
+	}
+
+	b.namedTypes[fullName] = res
+	return res, nil
+}
+
+// registerNamedType registers the named type placeholder, returning its full name and namespace to be used for nested
+// types.
+func (b *avroTypeBuilder) registerNamedType(schema map[string]any, namespace string) (fullName, typeNamespace string, err error) {
+	name, _ := schema["name"].(string)
+	if name == "" {
+		return "", "", b.error(fmt.Errorf("avro %v type has no name", schema["type"]))
+	}
+
+	typeNamespace = namespace
+	if ns, ok := schema["namespace"].(string); ok {
+		typeNamespace = ns
+	}
+	fullName = name
+	switch {
+	case strings.Contains(name, "."):
+		typeNamespace = name[:strings.LastIndex(name, ".")]
+	case typeNamespace != "":
+		fullName = typeNamespace + "." + name
+	}
+
+	if _, ok := b.namedTypes[fullName]; ok {
+		return "", "", b.error(fmt.Errorf("avro type %q is defined more than once", fullName))
+	}
+	b.namedTypes[fullName] = nil
+	return
+}
+
+func (b *avroTypeBuilder) error(err error) error {
+	return types.CompileError{Err: err, Path: b.ctx.CurrentRefPointer(b.path...)}
+}
@@ -0,0 +1,285 @@
+package asyncapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bdragon300/go-asyncapi/internal/common"
+	"github.com/bdragon300/go-asyncapi/internal/compiler/compile"
+	"github.com/bdragon300/go-asyncapi/internal/jsonpointer"
+	"github.com/bdragon300/go-asyncapi/internal/render/lang"
+	"github.com/bdragon300/go-asyncapi/internal/types"
+	yaml "gopkg.in/yaml.v3"
+)
+
+func TestAvroSchemaCompile(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		// want is the Go types of record fields by field name
+		want map[string]string
+		// wantUnions is the union artifacts by their JSON pointer
+		wantUnions map[string]string
+	}{
+		{
+			name: "primitive types",
+			schema: `{type: record, name: Rec, fields: [
+				{name: b, type: boolean}, {name: i, type: int}, {name: l, type: long}, {name: f, type: float},
+				{name: d, type: double}, {name: by, type: bytes}, {name: s, type: string}, {name: n, type: null}]}`,
+			want: map[string]string{
+				"B": "bool", "I": "int32", "L": "int64", "F": "float32", "D": "float64", "By": "[]byte", "S": "string",
+				"N": "any",
+			},
+		},
+		{
+			name: "complex types",
+			schema: `{type: record, name: Rec, namespace: shop, fields: [
+				{name: a, type: {type: array, items: string}},
+				{name: m, type: {type: map, values: long}},
+				{name: e, type: {type: enum, name: Color, symbols: [RED, GREEN]}},
+				{name: fx, type: {type: fixed, name: Hash, size: 16}},
+				{name: r, type: {type: record, name: Inner, fields: [{name: x, type: int}]}},
+				{name: ref, type: Inner},
+				{name: fullRef, type: shop.Color}]}`,
+			want: map[string]string{
+				"A": "[]string", "M": "map[string]int64", "E": "type Color", "Fx": "[16]byte", "R": "struct Inner",
+				"Ref": "struct Inner", "FullRef": "type Color",
+			},
+		},
+		{
+			name: "logical types",
+			schema: `{type: record, name: Rec, fields: [
+				{name: date, type: {type: int, logicalType: date}},
+				{name: ts, type: {type: long, logicalType: timestamp-micros}},
+				{name: dur, type: {type: int, logicalType: time-millis}},
+				{name: dec, type: {type: bytes, logicalType: decimal, precision: 4}},
+				{name: unknown, type: {type: string, logicalType: uuid}}]}`,
+			want: map[string]string{
+				"Date": "time.Time", "Ts": "time.Time", "Dur": "time.Duration", "Dec": "*math/big.Rat", "Unknown": "string",
+			},
+		},
+		{
+			name: "nullable unions",
+			schema: `{type: record, name: Rec, fields: [
+				{name: s, type: [null, string]}, {name: dec, type: ["null", {type: bytes, logicalType: decimal}]},
+				{name: single, type: [int]}]}`,
+			want: map[string]string{"S": "*string", "Dec": "*math/big.Rat", "Single": "int32"},
+		},
+		{
+			name: "multi-type unions",
+			schema: `{type: record, name: Rec, namespace: shop, fields: [
+				{name: value, type: [int, string]},
+				{name: item, type: [null, {type: record, name: Book, fields: [{name: title, type: string}]}, long]},
+				{name: when, type: [long, {type: long, logicalType: timestamp-millis}]},
+				{name: list, type: {type: array, items: [float, boolean]}}]}`,
+			want: map[string]string{
+				"Value": "*union RecValue", "Item": "*union RecItem", "When": "*union RecWhen", "List": "[]*union RecListItem",
+			},
+			wantUnions: map[string]string{
+				"#/components/schemas/test/fields/0/type":       "RecValue Int:int32(decoded int) String:string",
+				"#/components/schemas/test/fields/1/type":       "RecItem Book:struct Book(shop.Book) Long:int64",
+				"#/components/schemas/test/fields/2/type":       "RecWhen Long:int64 TimestampMillis:time.Time",
+				"#/components/schemas/test/fields/3/type/items": "RecListItem Float:float32 Boolean:bool",
+			},
+		},
+		{
+			name: "indistinguishable unions",
+			schema: `{type: record, name: Rec, fields: [
+				{name: enum, type: [string, {type: enum, name: Color, symbols: [RED]}]},
+				{name: times, type: [{type: int, logicalType: date}, {type: long, logicalType: timestamp-millis}]},
+				{name: arrays, type: [string, {type: array, items: int}]},
+				{name: localTime, type: [string, {type: long, logicalType: local-timestamp-millis}]}]}`,
+			want: map[string]string{"Enum": "any", "Times": "any", "Arrays": "any", "LocalTime": "any"},
+		},
+		{
+			name:   "string schema",
+			schema: `'{"type": "record", "name": "Rec", "fields": [{"name": "s", "type": ["null", "string"]}]}'`,
+			want:   map[string]string{"S": "*string"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, storage := newTestCompileContext()
+			if err := unmarshalAvroSchema(t, tt.schema).Compile(ctx); err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+
+			rec, ok := storage.artifacts["#/components/schemas/test"].(*lang.GoStruct)
+			if !ok {
+				t.Fatalf("record artifact is not found, got %v", storage.artifacts)
+			}
+			if !rec.HasDefinition || rec.OriginalName != "Rec" {
+				t.Errorf("record = %q, has definition %v, want %q with definition", rec.OriginalName, rec.HasDefinition, "Rec")
+			}
+			got := make(map[string]string)
+			for _, f := range rec.Fields {
+				got[f.OriginalName] = describeGoType(f.Type)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+
+			gotUnions := make(map[string]string)
+			for ref, a := range storage.artifacts {
+				if u, ok := a.(*lang.UnionStruct); ok {
+					gotUnions[ref] = describeAvroUnion(u)
+				}
+			}
+			if fmt.Sprint(gotUnions) != fmt.Sprint(tt.wantUnions) {
+				t.Errorf("unions = %v, want %v", gotUnions, tt.wantUnions)
+			}
+		})
+	}
+}
+
+func TestAvroSchemaCompileError(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		wantErr  string
+		wantPath string
+	}{
+		{
+			name:     "unknown type",
+			schema:   `{type: record, name: Rec, fields: [{name: a, type: Unknown}]}`,
+			wantErr:  `unknown avro type "Unknown"`,
+			wantPath: "#/components/schemas/test/fields/0/type",
+		},
+		{
+			name:     "recursive type",
+			schema:   `{type: record, name: Rec, fields: [{name: next, type: [null, Rec]}]}`,
+			wantErr:  `recursive avro type "Rec" is not supported`,
+			wantPath: "#/components/schemas/test/fields/0/type/1",
+		},
+		{
+			name: "duplicate type",
+			schema: `{type: record, name: Rec, fields: [
+				{name: a, type: {type: enum, name: E, symbols: [A]}}, {name: b, type: {type: enum, name: E, symbols: [B]}}]}`,
+			wantErr:  `avro type "E" is defined more than once`,
+			wantPath: "#/components/schemas/test/fields/1/type",
+		},
+		{
+			name:     "no fields",
+			schema:   `{type: record, name: Rec}`,
+			wantErr:  `avro record "Rec" has no fields list`,
+			wantPath: "#/components/schemas/test",
+		},
+		{
+			name:     "invalid fixed size",
+			schema:   `{type: record, name: Rec, fields: [{name: a, type: {type: fixed, name: F, size: 0}}]}`,
+			wantErr:  `avro fixed type "F" has invalid size`,
+			wantPath: "#/components/schemas/test/fields/0/type",
+		},
+		{
+			name:     "ref",
+			schema:   `{$ref: "#/components/schemas/other"}`,
+			wantErr:  "$ref in avro schema is not supported",
+			wantPath: "#/components/schemas/test",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := newTestCompileContext()
+			err := unmarshalAvroSchema(t, tt.schema).Compile(ctx)
+			var compileErr types.CompileError
+			if !errors.As(err, &compileErr) {
+				t.Fatalf("Compile() error = %v, want CompileError", err)
+			}
+			if compileErr.Err.Error() != tt.wantErr || compileErr.Path != tt.wantPath {
+				t.Errorf("Compile() error = %q at %q, want %q at %q", compileErr.Err, compileErr.Path, tt.wantErr, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestAvroSchemaJSON(t *testing.T) {
+	// YAML nulls in types are replaced with "null" type name, so the schema is valid for Avro libraries
+	s := unmarshalAvroSchema(t, `{type: record, name: Rec, fields: [{name: a, type: [null, {type: array, items: [null, int]}]}]}`)
+	got, err := s.JSON()
+	if err != nil {
+		t.Fatalf("JSON() error = %v", err)
+	}
+	want := `{"fields":[{"name":"a","type":["null",{"items":["null","int"],"type":"array"}]}],"name":"Rec","type":"record"}`
+	if got != want {
+		t.Errorf("JSON() = %s, want %s", got, want)
+	}
+}
+
+type testArtifactStorage struct {
+	artifacts map[string]common.Artifact
+}
+
+func (s *testArtifactStorage) AddArtifact(obj common.Artifact) {
+	s.artifacts[obj.Pointer().String()] = obj
+}
+
+func (s *testArtifactStorage) AddExternalRef(_ *jsonpointer.JSONPointer) {}
+
+func (s *testArtifactStorage) AddPromise(_ common.ObjectPromise) {}
+
+func (s *testArtifactStorage) AddListPromise(_ common.ObjectListPromise) {}
+
+func (s *testArtifactStorage) DocumentURL() jsonpointer.JSONPointer {
+	return jsonpointer.JSONPointer{}
+}
+
+func newTestCompileContext() (*compile.Context, *testArtifactStorage) {
+	storage := &testArtifactStorage{artifacts: make(map[string]common.Artifact)}
+	ctx := compile.NewCompileContext(compile.CompilationOpts{})
+	ctx.Storage = storage
+	ctx.Stack.Push(compile.DocumentTreeItem{Key: "components"})
+	ctx.Stack.Push(compile.DocumentTreeItem{Key: "schemas"})
+	ctx.Stack.Push(compile.DocumentTreeItem{Key: "test", Flags: map[common.SchemaTag]string{common.SchemaTagSelectable: ""}})
+	return ctx, storage
+}
+
+func unmarshalAvroSchema(t *testing.T, schema string) AvroSchema {
+	t.Helper()
+	var res AvroSchema
+	if err := yaml.Unmarshal([]byte(schema), &res); err != nil {
+		t.Fatalf("unmarshal schema: %v", err)
+	}
+	return res
+}
+
+func describeGoType(typ common.GolangType) string {
+	switch v := typ.(type) {
+	case *lang.GoSimple:
+		if v.Import != "" {
+			return v.Import + "." + v.TypeName
+		}
+		return v.TypeName
+	case *lang.GoPointer:
+		return "*" + describeGoType(v.Type)
+	case *lang.GoArray:
+		if v.Size > 0 {
+			return fmt.Sprintf("[%d]%s", v.Size, describeGoType(v.ItemsType))
+		}
+		return "[]" + describeGoType(v.ItemsType)
+	case *lang.GoMap:
+		return fmt.Sprintf("map[%s]%s", describeGoType(v.KeyType), describeGoType(v.ValueType))
+	case *lang.UnionStruct:
+		return "union " + v.OriginalName
+	case *lang.GoStruct:
+		return "struct " + v.OriginalName
+	case *lang.GoTypeDefinition:
+		return "type " + v.OriginalName
+	}
+	return fmt.Sprintf("%T", typ)
+}
+
+func describeAvroUnion(u *lang.UnionStruct) string {
+	parts := []string{u.OriginalName}
+	for i, f := range u.Fields {
+		s := f.OriginalName + ":" + describeGoType(f.Type.(*lang.GoPointer).Type)
+		if v := u.AvroVariants[i]; v.Name != "" {
+			s += "(" + v.Name + ")"
+		} else if v.DecodedType != "" {
+			s += "(decoded " + v.DecodedType + ")"
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " ")
+}
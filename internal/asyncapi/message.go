@@ -3,6 +3,7 @@ package asyncapi
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/bdragon300/go-asyncapi/internal/compiler/compile"
 	"github.com/bdragon300/go-asyncapi/internal/render/lang"
@@ -16,19 +17,19 @@ import (
 )
 
 type Message struct {
-	Headers       *Object                `json:"headers,omitzero" yaml:"headers" cgen:"data_model"`
-	Payload       *Object                `json:"payload,omitzero" yaml:"payload" cgen:"data_model"`
-	CorrelationID *CorrelationID         `json:"correlationId,omitzero" yaml:"correlationId"`
-	ContentType   string                 `json:"contentType,omitzero" yaml:"contentType"`
-	Name          string                 `json:"name,omitzero" yaml:"name"`
-	Title         string                 `json:"title,omitzero" yaml:"title"`
-	Summary       string                 `json:"summary,omitzero" yaml:"summary"`
-	Description   string                 `json:"description,omitzero" yaml:"description"`
-	Tags          []Tag                  `json:"tags,omitzero" yaml:"tags"`
-	ExternalDocs  *ExternalDocumentation `json:"externalDocs,omitzero" yaml:"externalDocs"`
-	Bindings      *Bindings              `json:"bindings,omitzero" yaml:"bindings"`
-	Examples      []MessageExample       `json:"examples,omitzero" yaml:"examples"`
-	Traits        []MessageTrait         `json:"traits,omitzero" yaml:"traits"`
+	Headers       *Object                                  `json:"headers,omitzero" yaml:"headers" cgen:"data_model"`
+	Payload       *types.Union2[MultiFormatSchema, Object] `json:"payload,omitzero" yaml:"payload" cgen:"data_model"`
+	CorrelationID *CorrelationID                           `json:"correlationId,omitzero" yaml:"correlationId"`
+	ContentType   string                                   `json:"contentType,omitzero" yaml:"contentType"`
+	Name          string                                   `json:"name,omitzero" yaml:"name"`
+	Title         string                                   `json:"title,omitzero" yaml:"title"`
+	Summary       string                                   `json:"summary,omitzero" yaml:"summary"`
+	Description   string                                   `json:"description,omitzero" yaml:"description"`
+	Tags          []Tag                                    `json:"tags,omitzero" yaml:"tags"`
+	ExternalDocs  *ExternalDocumentation                   `json:"externalDocs,omitzero" yaml:"externalDocs"`
+	Bindings      *Bindings                                `json:"bindings,omitzero" yaml:"bindings"`
+	Examples      []MessageExample                         `json:"examples,omitzero" yaml:"examples"`
+	Traits        []MessageTrait                           `json:"traits,omitzero" yaml:"traits"`
 
 	XGoName string `json:"x-go-name,omitzero" yaml:"x-go-name"`
 	XIgnore bool   `json:"x-ignore,omitzero" yaml:"x-ignore"`
@@ -66,6 +67,7 @@ func (m Message) build(ctx *compile.Context, messageKey string, flags map[common
 	res := render.Message{
 		OriginalName: msgName,
 		ContentType:  m.ContentType,
+		MessageName:  m.Name,
 		IsSelectable: isSelectable,
 		IsPublisher:  ctx.CompileOpts.GeneratePublishers,
 		IsSubscriber: ctx.CompileOpts.GenerateSubscribers,
@@ -117,11 +119,44 @@ func (m Message) build(ctx *compile.Context, messageKey string, flags map[common
 	if m.Payload != nil {
 		ctx.Logger.Trace("Message payload")
 		ref := ctx.CurrentRefPointer("payload")
+		if m.Payload.Selector == 0 {
+			mf := m.Payload.V0
+			ctx.Logger.Trace("Message payload is multi-format schema", "schemaFormat", mf.SchemaFormat)
+			res.PayloadSchemaFormat = mf.SchemaFormat
+			switch mf.FormatKind() {
+			case schemaFormatKindAvro:
+				res.PayloadContentType = render.AvroContentType
+			case schemaFormatKindProtobuf:
+				res.PayloadContentType = render.ProtobufContentType
+				res.PayloadProtobuf = true
+				res.PayloadProtobufBound = mf.XGoType != nil
+			}
+			if mf.XGoType == nil {
+				ref = ctx.CurrentRefPointer("payload", "schema")
+			}
+			if mf.XGoType == nil && mf.Schema != nil && mf.Schema.Selector == 1 {
+				s, err := mf.Schema.V1.JSON()
+				if err != nil {
+					return nil, types.CompileError{Err: fmt.Errorf("avro schema: %w", err), Path: ctx.CurrentRefPointer("payload", "schema")}
+				}
+				res.PayloadAvroSchema = s
+			}
+		}
 		res.PayloadTypePromise = lang.NewGolangTypePromise(ref, nil)
 		ctx.PutPromise(res.PayloadTypePromise)
 	}
 	res.InType, res.OutType = m.buildInOutStructs(ctx, res, msgName)
 
+	// Examples
+	for i, ex := range m.Examples {
+		ctx.Logger.Trace("Message example", "index", i, "name", ex.Name)
+		v, err := ex.build()
+		if err != nil {
+			return nil, types.CompileError{Err: fmt.Errorf("example: %w", err), Path: ctx.CurrentRefPointer("examples", strconv.Itoa(i))}
+		}
+		res.Examples = append(res.Examples, v)
+	}
+
 	// Bindings
 	if m.Bindings != nil {
 		ctx.Logger.Trace("Message bindings")
@@ -157,8 +192,8 @@ func (m Message) buildInOutStructs(ctx *compile.Context, message render.Message,
 			HasDefinition: true,
 		},
 		Fields: []lang.GoStructField{
-			{OriginalName: utils.ToGolangName(string(lang.RuntimeExpressionStructFieldKindPayload), true), Type: payloadType},
-			{OriginalName: utils.ToGolangName(string(lang.RuntimeExpressionStructFieldKindHeaders), true), Type: headerType},
+			{OriginalName: utils.ToGolangName(string(lang.RuntimeExpressionStructFieldKindPayload), true), Type: payloadType, AlwaysValidate: true},
+			{OriginalName: utils.ToGolangName(string(lang.RuntimeExpressionStructFieldKindHeaders), true), Type: headerType, AlwaysValidate: true},
 		},
 	}
 	in = &lang.GoStruct{
@@ -168,8 +203,8 @@ func (m Message) buildInOutStructs(ctx *compile.Context, message render.Message,
 			HasDefinition: true,
 		},
 		Fields: []lang.GoStructField{
-			{OriginalName: utils.ToGolangName(string(lang.RuntimeExpressionStructFieldKindPayload), false), Type: payloadType},
-			{OriginalName: utils.ToGolangName(string(lang.RuntimeExpressionStructFieldKindHeaders), false), Type: headerType},
+			{OriginalName: utils.ToGolangName(string(lang.RuntimeExpressionStructFieldKindPayload), false), Type: payloadType, AlwaysValidate: true},
+			{OriginalName: utils.ToGolangName(string(lang.RuntimeExpressionStructFieldKindHeaders), false), Type: headerType, AlwaysValidate: true},
 		},
 	}
 
@@ -191,6 +226,37 @@ type MessageExample struct {
 	Summary string                                                             `json:"summary,omitzero" yaml:"summary"`
 }
 
+func (e MessageExample) build() (render.MessageExample, error) {
+	res := render.MessageExample{Name: e.Name, Summary: e.Summary}
+	if e.Payload != nil {
+		v, err := decodeSchemaValue(*e.Payload)
+		if err != nil {
+			return res, fmt.Errorf("payload: %w", err)
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return res, fmt.Errorf("payload: %w", err)
+		}
+		res.Payload = string(b)
+	}
+	if e.Headers.Len() > 0 {
+		headers := make(map[string]any, e.Headers.Len())
+		for k, item := range e.Headers.Entries() {
+			v, err := decodeSchemaValue(item)
+			if err != nil {
+				return res, fmt.Errorf("header %q: %w", k, err)
+			}
+			headers[k] = v
+		}
+		b, err := json.Marshal(headers)
+		if err != nil {
+			return res, fmt.Errorf("headers: %w", err)
+		}
+		res.Headers = string(b)
+	}
+	return res, nil
+}
+
 type MessageTrait struct {
 	Headers       *Object                `json:"headers,omitzero" yaml:"headers"`
 	CorrelationID *CorrelationID         `json:"correlationId,omitzero" yaml:"correlationId"`
@@ -0,0 +1,170 @@
+package asyncapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/bdragon300/go-asyncapi/internal/common"
+	"github.com/bdragon300/go-asyncapi/internal/compiler/compile"
+	"github.com/bdragon300/go-asyncapi/internal/render/lang"
+	"github.com/bdragon300/go-asyncapi/internal/types"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// schemaFormatKind is a kind of schema language, which is determined by the schemaFormat field of multi-format schema.
+type schemaFormatKind int
+
+const (
+	schemaFormatKindUnknown schemaFormatKind = iota
+	// schemaFormatKindJSONSchema is the AsyncAPI schema or JSON Schema
+	schemaFormatKindJSONSchema
+	// schemaFormatKindAvro is the Apache Avro schema
+	schemaFormatKindAvro
+	// schemaFormatKindProtobuf is the Protocol Buffers schema
+	schemaFormatKindProtobuf
+)
+
+var errNotMultiFormatSchema = errors.New("not a multi-format schema object")
+
+// MultiFormatSchema is the Multi Format Schema object, that allows to define the message payload schema in the
+// languages other than AsyncAPI schema (JSON Schema superset).
+//
+// The x-go-type extra field binds the payload to the existing Go type instead of generating it from schema. This is
+// useful, for example, for types generated by protoc.
+//
+// See: https://www.asyncapi.com/docs/reference/specification/v3.0.0#multiFormatSchemaObject
+type MultiFormatSchema struct {
+	SchemaFormat string                                            `json:"schemaFormat" yaml:"schemaFormat"`
+	Schema       *types.Union3[Object, AvroSchema, ProtobufSchema] `json:"schema" yaml:"schema"`
+
+	XGoType *types.Union2[string, xGoType] `json:"x-go-type,omitzero" yaml:"x-go-type"`
+}
+
+func (m MultiFormatSchema) Compile(ctx *compile.Context) error {
+	if m.XGoType != nil {
+		obj := m.buildXGoType()
+		ctx.Logger.Trace("Multi-format schema payload is bound to a type using x-go-type", "type", obj.String())
+		ctx.PutArtifact(obj)
+		return nil
+	}
+	if m.Schema == nil {
+		err := fmt.Errorf("unsupported schema format %q", m.SchemaFormat)
+		if m.FormatKind() != schemaFormatKindUnknown {
+			err = errors.New("schema is not set")
+		}
+		return types.CompileError{Err: err, Path: ctx.CurrentRefPointer()}
+	}
+	return nil
+}
+
+// buildXGoType builds a GolangType from x-go-type field value. Protobuf messages generated by protoc implement
+// proto.Message interface by pointer, so the type for protobuf schema format is always a pointer.
+func (m MultiFormatSchema) buildXGoType() common.GolangType {
+	t := &lang.GoSimple{}
+	pointer := m.FormatKind() == schemaFormatKindProtobuf
+	switch m.XGoType.Selector {
+	case 0:
+		t.TypeName = m.XGoType.V0
+	case 1:
+		t.TypeName = m.XGoType.V1.Type
+		t.Import = m.XGoType.V1.Import.Package
+		t.IsInterface = m.XGoType.V1.Hint.Kind == "interface"
+		pointer = pointer || m.XGoType.V1.Hint.Pointer
+	}
+	if pointer {
+		return &lang.GoPointer{Type: t}
+	}
+	return t
+}
+
+// FormatKind returns the schema language kind of this schema.
+func (m MultiFormatSchema) FormatKind() schemaFormatKind {
+	return getSchemaFormatKind(m.SchemaFormat)
+}
+
+func (m *MultiFormatSchema) UnmarshalJSON(data []byte) error {
+	var v struct {
+		SchemaFormat string                         `json:"schemaFormat"`
+		Schema       json.RawMessage                `json:"schema"`
+		XGoType      *types.Union2[string, xGoType] `json:"x-go-type"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.SchemaFormat == "" {
+		return errNotMultiFormatSchema
+	}
+
+	m.SchemaFormat = v.SchemaFormat
+	m.XGoType = v.XGoType
+	if len(v.Schema) == 0 {
+		return nil // Schema may be omitted if x-go-type is set, otherwise reported on compilation stage
+	}
+	switch m.FormatKind() {
+	case schemaFormatKindJSONSchema:
+		m.Schema = &types.Union3[Object, AvroSchema, ProtobufSchema]{Selector: 0}
+		return json.Unmarshal(v.Schema, &m.Schema.V0)
+	case schemaFormatKindAvro:
+		m.Schema = &types.Union3[Object, AvroSchema, ProtobufSchema]{Selector: 1}
+		return json.Unmarshal(v.Schema, &m.Schema.V1)
+	case schemaFormatKindProtobuf:
+		m.Schema = &types.Union3[Object, AvroSchema, ProtobufSchema]{Selector: 2}
+		return json.Unmarshal(v.Schema, &m.Schema.V2)
+	}
+	return nil // Unsupported format, reported on compilation stage
+}
+
+func (m *MultiFormatSchema) UnmarshalYAML(value *yaml.Node) error {
+	var v struct {
+		SchemaFormat string                         `yaml:"schemaFormat"`
+		Schema       yaml.Node                      `yaml:"schema"`
+		XGoType      *types.Union2[string, xGoType] `yaml:"x-go-type"`
+	}
+	if err := value.Decode(&v); err != nil {
+		return err
+	}
+	if v.SchemaFormat == "" {
+		return errNotMultiFormatSchema
+	}
+
+	m.SchemaFormat = v.SchemaFormat
+	m.XGoType = v.XGoType
+	if v.Schema.IsZero() {
+		return nil // Schema may be omitted if x-go-type is set, otherwise reported on compilation stage
+	}
+	switch m.FormatKind() {
+	case schemaFormatKindJSONSchema:
+		m.Schema = &types.Union3[Object, AvroSchema, ProtobufSchema]{Selector: 0}
+		return v.Schema.Decode(&m.Schema.V0)
+	case schemaFormatKindAvro:
+		m.Schema = &types.Union3[Object, AvroSchema, ProtobufSchema]{Selector: 1}
+		return v.Schema.Decode(&m.Schema.V1)
+	case schemaFormatKindProtobuf:
+		m.Schema = &types.Union3[Object, AvroSchema, ProtobufSchema]{Selector: 2}
+		return v.Schema.Decode(&m.Schema.V2)
+	}
+	return nil // Unsupported format, reported on compilation stage
+}
+
+// getSchemaFormatKind returns the schema language kind by schemaFormat value, e.g.
+// "application/vnd.aai.asyncapi+json;version=3.0.0", "application/vnd.apache.avro;version=1.9.0" or
+// "application/vnd.google.protobuf;version=3".
+func getSchemaFormatKind(schemaFormat string) schemaFormatKind {
+	mediaType, _, err := mime.ParseMediaType(schemaFormat)
+	if err != nil {
+		mediaType = strings.ToLower(schemaFormat)
+	}
+	switch mediaType {
+	case "application/vnd.aai.asyncapi", "application/vnd.aai.asyncapi+json", "application/vnd.aai.asyncapi+yaml",
+		"application/schema+json", "application/schema+yaml":
+		return schemaFormatKindJSONSchema
+	case "application/vnd.apache.avro", "application/vnd.apache.avro+json", "application/vnd.apache.avro+yaml":
+		return schemaFormatKindAvro
+	case "application/vnd.google.protobuf", "application/x-protobuf", "application/protobuf":
+		return schemaFormatKindProtobuf
+	}
+	return schemaFormatKindUnknown
+}
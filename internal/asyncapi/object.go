@@ -5,7 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
-	"slices"
+	"regexp"
 	"strconv"
 
 	"github.com/bdragon300/go-asyncapi/internal/compiler/compile"
@@ -223,6 +223,7 @@ func (o Object) buildGolangType(ctx *compile.Context, flags map[common.SchemaTag
 				Description:   o.Description,
 				HasDefinition: isSelectable,
 				ArtifactKind:  lo.Ternary(isSelectable, common.ArtifactKindSchema, common.ArtifactKindOther),
+				Constraints:   o.getSchemaConstraints(ctx),
 			},
 			RedefinedType: aliasedType,
 		}
@@ -255,6 +256,7 @@ func (o Object) buildLangStruct(ctx *compile.Context, flags map[common.SchemaTag
 			Description:   o.Description,
 			HasDefinition: isSelectable,
 			ArtifactKind:  lo.Ternary(isSelectable, common.ArtifactKindSchema, common.ArtifactKindOther),
+			Constraints:   o.getSchemaConstraints(ctx),
 		},
 		StructFieldRenderInfo: o.getStructFieldRenderInfo(ctx),
 	}
@@ -269,11 +271,7 @@ func (o Object) buildLangStruct(ctx *compile.Context, flags map[common.SchemaTag
 		}, nil)
 		ctx.PutListPromise(messagesPrm)
 		contentTypesFunc = func() []string {
-			tagNames := lo.Uniq(lo.Map(messagesPrm.T(), func(item *render.Message, _ int) string {
-				return guessTagByContentType(item.EffectiveContentType())
-			}))
-			slices.Sort(tagNames)
-			return tagNames
+			return messagesTagNames(messagesPrm.T())
 		}
 	}
 
@@ -285,7 +283,10 @@ func (o Object) buildLangStruct(ctx *compile.Context, flags map[common.SchemaTag
 		ctx.PutPromise(prm)
 
 		var langObj common.GolangType = prm
-		if lo.Contains(o.Required, k) {
+		required := lo.Contains(o.Required, k)
+		// Optional property with constraints is a pointer, so that its zero value is distinguished from absent one
+		// and is checked by Validate method.
+		if required || v.hasSchemaConstraints() {
 			langObj = &lang.GoPointer{Type: langObj}
 		}
 
@@ -295,6 +296,7 @@ func (o Object) buildLangStruct(ctx *compile.Context, flags map[common.SchemaTag
 			MarshalName:      k,
 			Description:      v.Description,
 			Type:             langObj,
+			Required:         required,
 			ContentTypesFunc: contentTypesFunc,
 		}
 		res.Fields = append(res.Fields, f)
@@ -365,6 +367,7 @@ func (o Object) buildLangArray(ctx *compile.Context, flags map[common.SchemaTag]
 			Description:   o.Description,
 			HasDefinition: isSelectable,
 			ArtifactKind:  lo.Ternary(isSelectable, common.ArtifactKindSchema, common.ArtifactKindOther),
+			Constraints:   o.getSchemaConstraints(ctx),
 		},
 		ItemsType:             nil,
 		StructFieldRenderInfo: o.getStructFieldRenderInfo(ctx),
@@ -486,3 +489,95 @@ func (o Object) getStructFieldRenderInfo(ctx *compile.Context) lang.StructFieldR
 
 	return res
 }
+
+// getSchemaConstraints returns the jsonschema validation keywords of the object. Returns nil if no constraints are set.
+func (o Object) getSchemaConstraints(ctx *compile.Context) *lang.SchemaConstraints {
+	toFloat := func(keyword string, n *json.Number) *float64 {
+		if n == nil {
+			return nil
+		}
+		v, err := n.Float64()
+		if err != nil {
+			ctx.Logger.Warn("Skip the constraint with invalid number", "keyword", keyword, "value", n.String(), "err", err)
+			return nil
+		}
+		return &v
+	}
+
+	res := lang.SchemaConstraints{
+		Minimum:       toFloat("minimum", o.Minimum),
+		Maximum:       toFloat("maximum", o.Maximum),
+		MultipleOf:    toFloat("multipleOf", o.MultipleOf),
+		MinLength:     o.MinLength,
+		MaxLength:     o.MaxLength,
+		MinItems:      o.MinItems,
+		MaxItems:      o.MaxItems,
+		UniqueItems:   lo.FromPtr(o.UniqueItems),
+		MinProperties: o.MinProperties,
+		MaxProperties: o.MaxProperties,
+	}
+
+	// Draft 4 defines exclusiveMinimum/exclusiveMaximum as booleans modifying minimum/maximum, later drafts as numbers
+	if o.ExclusiveMinimum != nil {
+		switch {
+		case o.ExclusiveMinimum.Selector == 1:
+			res.ExclusiveMinimum = toFloat("exclusiveMinimum", &o.ExclusiveMinimum.V1)
+		case o.ExclusiveMinimum.V0:
+			res.ExclusiveMinimum, res.Minimum = res.Minimum, nil
+		}
+	}
+	if o.ExclusiveMaximum != nil {
+		switch {
+		case o.ExclusiveMaximum.Selector == 1:
+			res.ExclusiveMaximum = toFloat("exclusiveMaximum", &o.ExclusiveMaximum.V1)
+		case o.ExclusiveMaximum.V0:
+			res.ExclusiveMaximum, res.Maximum = res.Maximum, nil
+		}
+	}
+
+	if o.Pattern != "" {
+		// JSON Schema uses ECMA 262 regex dialect, which is not fully compatible with Go's RE2 syntax
+		if _, err := regexp.Compile(o.Pattern); err != nil {
+			ctx.Logger.Warn("Skip the pattern constraint, the regular expression is not supported by Go", "pattern", o.Pattern, "err", err)
+		} else {
+			res.Pattern = o.Pattern
+		}
+	}
+
+	enum := o.Enum
+	if o.Const != nil {
+		enum = []types.Union2[json.RawMessage, yaml.Node]{*o.Const}
+	}
+	for _, item := range enum {
+		v, err := decodeSchemaValue(item)
+		if err != nil {
+			ctx.Logger.Warn("Skip the enum constraint, cannot decode the value", "err", err)
+			res.Enum = nil
+			break
+		}
+		if v == nil {
+			continue // null is allowed only for nullable types, they are checked for nil separately
+		}
+		if !isPrimitiveValue(v) {
+			ctx.Logger.Debug("Skip the enum constraint, only primitive values are supported", "value", v)
+			res.Enum = nil
+			break
+		}
+		res.Enum = append(res.Enum, v)
+	}
+
+	if res.IsEmpty() {
+		return nil
+	}
+	ctx.Logger.Trace("Object constraints", "value", res)
+	return &res
+}
+
+// hasSchemaConstraints returns true if any constraint keyword is set in the schema. The referenced schema is not
+// taken into account.
+func (o Object) hasSchemaConstraints() bool {
+	return o.Minimum != nil || o.Maximum != nil || o.ExclusiveMinimum != nil || o.ExclusiveMaximum != nil ||
+		o.MultipleOf != nil || o.MinLength != nil || o.MaxLength != nil || o.Pattern != "" ||
+		o.MinItems != nil || o.MaxItems != nil || lo.FromPtr(o.UniqueItems) ||
+		o.MinProperties != nil || o.MaxProperties != nil || len(o.Enum) > 0 || o.Const != nil
+}
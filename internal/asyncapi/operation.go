@@ -35,6 +35,8 @@ type Operation struct {
 	Reply        *OperationReply        `json:"reply,omitzero" yaml:"reply"`
 
 	XIgnore bool `json:"x-ignore,omitzero" yaml:"x-ignore"`
+	// XGoDeadLetterChannel is the channel, where the received messages that have failed handling are sent to
+	XGoDeadLetterChannel *StandaloneRef `json:"x-go-dead-letter-channel,omitzero" yaml:"x-go-dead-letter-channel"`
 
 	Ref string `json:"$ref,omitzero" yaml:"$ref"`
 }
@@ -78,6 +80,12 @@ func (o Operation) build(ctx *compile.Context, operationKey string, flags map[co
 	ctx.PutPromise(prm)
 	res.ChannelPromise = prm
 
+	if o.XGoDeadLetterChannel != nil {
+		ctx.Logger.Trace("Dead-letter channel", "ref", o.XGoDeadLetterChannel.Ref)
+		res.DeadLetterChannelPromise = lang.NewPromise[*render.Channel](o.XGoDeadLetterChannel.Ref, nil)
+		ctx.PutPromise(res.DeadLetterChannelPromise)
+	}
+
 	if o.Bindings != nil {
 		ctx.Logger.Trace("Found operation bindings")
 
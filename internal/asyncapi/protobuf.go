@@ -0,0 +1,401 @@
+package asyncapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bdragon300/go-asyncapi/internal/common"
+	"github.com/bdragon300/go-asyncapi/internal/compiler/compile"
+	"github.com/bdragon300/go-asyncapi/internal/render/lang"
+	"github.com/bdragon300/go-asyncapi/internal/types"
+	"github.com/bdragon300/go-asyncapi/internal/utils"
+	"github.com/emicklei/proto"
+	"github.com/samber/lo"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// ProtobufSchema is the [Protocol Buffers] schema, that is set in the Multi Format Schema object. It is either
+// the inline .proto definition, where the first top-level message describes the payload, or the $ref to a message
+// in the external .proto file, e.g. `$ref: "user.proto#/User"`.
+//
+// Messages are produced as Go structs with `protobuf` struct tags, which are encoded by the runtime protobuf codec.
+//
+// [Protocol Buffers]: https://protobuf.dev/programming-guides/proto3/
+type ProtobufSchema struct {
+	// source is inline .proto definition
+	source string
+
+	Ref string `json:"$ref,omitzero" yaml:"$ref"`
+}
+
+func (p *ProtobufSchema) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &p.source); err == nil {
+		return nil
+	}
+	var v struct {
+		Ref string `json:"$ref"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	p.Ref = v.Ref
+	return nil
+}
+
+func (p *ProtobufSchema) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		return value.Decode(&p.source)
+	}
+	var v struct {
+		Ref string `yaml:"$ref"`
+	}
+	if err := value.Decode(&v); err != nil {
+		return err
+	}
+	p.Ref = v.Ref
+	return nil
+}
+
+func (p ProtobufSchema) MarshalJSON() ([]byte, error) {
+	if p.Ref != "" {
+		return json.Marshal(map[string]string{"$ref": p.Ref})
+	}
+	return json.Marshal(p.source)
+}
+
+func (p ProtobufSchema) MarshalYAML() (any, error) {
+	if p.Ref != "" {
+		return map[string]string{"$ref": p.Ref}, nil
+	}
+	return p.source, nil
+}
+
+func (p ProtobufSchema) Compile(ctx *compile.Context) error {
+	obj, err := p.build(ctx, ctx.Stack.Top().Flags)
+	if err != nil {
+		return err
+	}
+	ctx.PutArtifact(obj)
+	return nil
+}
+
+func (p ProtobufSchema) build(ctx *compile.Context, flags map[common.SchemaTag]string) (common.Artifact, error) {
+	_, isSelectable := flags[common.SchemaTagSelectable]
+	if p.Ref != "" {
+		return registerRef(ctx, p.Ref, "", lo.Ternary(isSelectable, lo.ToPtr(true), nil)), nil
+	}
+
+	file, err := parseProtobuf(p.source)
+	if err != nil {
+		return nil, types.CompileError{Err: fmt.Errorf("parse protobuf schema: %w", err), Path: ctx.CurrentRefPointer()}
+	}
+	messages := protobufMessages(file.Elements)
+	if len(messages) == 0 {
+		return nil, types.CompileError{Err: errors.New("protobuf schema has no messages"), Path: ctx.CurrentRefPointer()}
+	}
+
+	b := newProtobufTypeBuilder(ctx, file)
+	if isSelectable {
+		b.definitions = []string{messages[0].Name}
+	}
+	return b.buildMessage(messages[0], messages[0].Name)
+}
+
+// ProtobufFile is the Protocol Buffers definition file (.proto), which messages are referenced from the Multi Format
+// Schema objects. Every top-level message in file produces a Go struct definition, that is available by JSON
+// pointer with message name, e.g. "user.proto#/User".
+type ProtobufFile struct {
+	source string
+	file   *proto.Proto
+}
+
+// UnmarshalProtobuf parses the .proto file contents.
+func (f *ProtobufFile) UnmarshalProtobuf(data []byte) (err error) {
+	f.source = string(data)
+	f.file, err = parseProtobuf(f.source)
+	return
+}
+
+func (f ProtobufFile) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.source)
+}
+
+func (f ProtobufFile) Compile(ctx *compile.Context) error {
+	messages := protobufMessages(f.file.Elements)
+	b := newProtobufTypeBuilder(ctx, f.file)
+	b.definitions = lo.Map(messages, func(item *proto.Message, _ int) string { return item.Name })
+
+	for _, msg := range messages {
+		ctx.Stack.Push(compile.DocumentTreeItem{Key: msg.Name})
+		ctx.Logger.Trace("Protobuf message", "name", msg.Name)
+		obj, err := b.buildMessage(msg, msg.Name)
+		if err != nil {
+			ctx.Stack.Pop()
+			return err
+		}
+		ctx.PutArtifact(obj)
+		ctx.Stack.Pop()
+	}
+	return nil
+}
+
+func parseProtobuf(source string) (*proto.Proto, error) {
+	return proto.NewParser(strings.NewReader(source)).Parse()
+}
+
+func protobufMessages(elements []proto.Visitee) []*proto.Message {
+	return lo.FilterMap(elements, func(item proto.Visitee, _ int) (*proto.Message, bool) {
+		m, ok := item.(*proto.Message)
+		return m, ok && !m.IsExtend
+	})
+}
+
+func newProtobufTypeBuilder(ctx *compile.Context, file *proto.Proto) *protobufTypeBuilder {
+	b := protobufTypeBuilder{
+		ctx:          ctx,
+		declarations: make(map[string]proto.Visitee),
+		builtTypes:   make(map[string]common.GolangType),
+	}
+	for _, el := range file.Elements {
+		if p, ok := el.(*proto.Package); ok {
+			b.pkg = p.Name
+		}
+	}
+	b.collectDeclarations(file.Elements, "")
+	return &b
+}
+
+// protobufTypeBuilder builds the Go types from the messages and enums declared in one .proto file.
+type protobufTypeBuilder struct {
+	ctx *compile.Context
+	// pkg is the protobuf package name
+	pkg string
+	// declarations are messages and enums by their full name without package, e.g. "User.Address"
+	declarations map[string]proto.Visitee
+	// builtTypes contains the types by full name. Nil value means the type is being built yet.
+	builtTypes map[string]common.GolangType
+	// definitions are names of messages, which are rendered as separate type definitions, other types are inlined
+	definitions []string
+}
+
+func (b *protobufTypeBuilder) collectDeclarations(elements []proto.Visitee, scope string) {
+	for _, el := range elements {
+		switch v := el.(type) {
+		case *proto.Message:
+			if v.IsExtend {
+				continue
+			}
+			fullName := joinProtobufName(scope, v.Name)
+			b.declarations[fullName] = v
+			b.collectDeclarations(v.Elements, fullName)
+		case *proto.Enum:
+			b.declarations[joinProtobufName(scope, v.Name)] = v
+		}
+	}
+}
+
+func (b *protobufTypeBuilder) buildMessage(msg *proto.Message, fullName string) (common.GolangType, error) {
+	if t, ok := b.builtTypes[fullName]; ok {
+		if t == nil {
+			return nil, b.error(fmt.Errorf("recursive protobuf message %q is not supported", fullName))
+		}
+		return t, nil
+	}
+	b.builtTypes[fullName] = nil
+	b.ctx.Logger.Trace("Protobuf message", "name", fullName)
+
+	hasDefinition := lo.Contains(b.definitions, fullName)
+	res := &lang.GoStruct{
+		BaseType: lang.BaseType{
+			OriginalName:  b.ctx.GenerateObjName(strings.ReplaceAll(fullName, ".", "_"), ""),
+			Description:   protobufComment(msg.Comment),
+			HasDefinition: hasDefinition,
+			ArtifactKind:  lo.Ternary(hasDefinition, common.ArtifactKindSchema, common.ArtifactKindOther),
+		},
+	}
+
+	b.ctx.Logger.NextCallLevel()
+	defer b.ctx.Logger.PrevCallLevel()
+	for _, el := range msg.Elements {
+		var fields []lang.GoStructField
+		var err error
+		switch v := el.(type) {
+		case *proto.NormalField:
+			var f lang.GoStructField
+			f, err = b.buildField(v.Field, fullName, v.Repeated, v.Optional)
+			fields = append(fields, f)
+		case *proto.MapField:
+			var f lang.GoStructField
+			f, err = b.buildMapField(v, fullName)
+			fields = append(fields, f)
+		case *proto.Oneof:
+			b.ctx.Logger.Trace("Protobuf oneof", "name", v.Name)
+			for _, item := range v.Elements {
+				if of, ok := item.(*proto.OneOfField); ok {
+					var f lang.GoStructField
+					if f, err = b.buildField(of.Field, fullName, false, true); err != nil {
+						break
+					}
+					f.Description = strings.TrimSpace(fmt.Sprintf("Oneof %s. %s", v.Name, f.Description))
+					fields = append(fields, f)
+				}
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		res.Fields = append(res.Fields, fields...)
+	}
+
+	b.builtTypes[fullName] = res
+	return res, nil
+}
+
+func (b *protobufTypeBuilder) buildField(field *proto.Field, scope string, repeated, optional bool) (lang.GoStructField, error) {
+	b.ctx.Logger.Trace("Protobuf field", "name", field.Name, "type", field.Type)
+	typ, kind, err := b.buildType(field.Type, scope)
+	if err != nil {
+		return lang.GoStructField{}, err
+	}
+	switch {
+	case repeated:
+		typ = &lang.GoArray{ItemsType: typ}
+	case optional || kind == "message":
+		typ = &lang.GoPointer{Type: typ}
+	}
+	return b.newField(field, typ, strconv.Itoa(field.Sequence)+","+kind), nil
+}
+
+func (b *protobufTypeBuilder) buildMapField(field *proto.MapField, scope string) (lang.GoStructField, error) {
+	b.ctx.Logger.Trace("Protobuf map field", "name", field.Name, "keyType", field.KeyType, "valueType", field.Type)
+	keyType, keyKind, err := b.buildType(field.KeyType, scope)
+	if err != nil {
+		return lang.GoStructField{}, err
+	}
+	valueType, valueKind, err := b.buildType(field.Type, scope)
+	if err != nil {
+		return lang.GoStructField{}, err
+	}
+	typ := &lang.GoMap{KeyType: keyType, ValueType: valueType}
+	return b.newField(field.Field, typ, fmt.Sprintf("%d,map,%s,%s", field.Sequence, keyKind, valueKind)), nil
+}
+
+func (b *protobufTypeBuilder) newField(field *proto.Field, typ common.GolangType, protobufTag string) lang.GoStructField {
+	res := lang.GoStructField{
+		OriginalName:     utils.ToGolangName(field.Name, true),
+		MarshalName:      field.Name,
+		Description:      protobufComment(field.Comment),
+		Type:             typ,
+		ContentTypesFunc: func() []string { return []string{"json"} },
+	}
+	res.Tags.Set("protobuf", protobufTag)
+	return res
+}
+
+// buildType returns the Go type and the protobuf kind (scalar type name, "enum" or "message") of the field type.
+func (b *protobufTypeBuilder) buildType(typeName, scope string) (common.GolangType, string, error) {
+	switch typeName {
+	case "double":
+		return &lang.GoSimple{TypeName: "float64"}, typeName, nil
+	case "float":
+		return &lang.GoSimple{TypeName: "float32"}, typeName, nil
+	case "int32", "sint32", "sfixed32":
+		return &lang.GoSimple{TypeName: "int32"}, typeName, nil
+	case "int64", "sint64", "sfixed64":
+		return &lang.GoSimple{TypeName: "int64"}, typeName, nil
+	case "uint32", "fixed32":
+		return &lang.GoSimple{TypeName: "uint32"}, typeName, nil
+	case "uint64", "fixed64":
+		return &lang.GoSimple{TypeName: "uint64"}, typeName, nil
+	case "bool":
+		return &lang.GoSimple{TypeName: "bool"}, typeName, nil
+	case "string":
+		return &lang.GoSimple{TypeName: "string"}, typeName, nil
+	case "bytes":
+		return &lang.GoArray{ItemsType: &lang.GoSimple{TypeName: "byte"}}, typeName, nil
+	}
+
+	fullName, decl, ok := b.resolve(typeName, scope)
+	if !ok {
+		return nil, "", b.error(fmt.Errorf(
+			"unknown protobuf type %q, imported types are not supported, consider using x-go-type to bind the payload to protoc-generated type",
+			typeName,
+		))
+	}
+	switch v := decl.(type) {
+	case *proto.Message:
+		t, err := b.buildMessage(v, fullName)
+		return t, "message", err
+	case *proto.Enum:
+		return b.buildEnum(v), "enum", nil
+	}
+	panic(fmt.Sprintf("unexpected declaration type %T", decl))
+}
+
+func (b *protobufTypeBuilder) buildEnum(enum *proto.Enum) common.GolangType {
+	b.ctx.Logger.Trace("Protobuf enum", "name", enum.Name)
+	var values []any
+	var names []string
+	for _, el := range enum.Elements {
+		if f, ok := el.(*proto.EnumField); ok {
+			values = append(values, f.Integer)
+			names = append(names, fmt.Sprintf("%s = %d", f.Name, f.Integer))
+		}
+	}
+	return &lang.GoTypeDefinition{
+		BaseType: lang.BaseType{
+			OriginalName: b.ctx.GenerateObjName(enum.Name, ""),
+			Description:  strings.TrimSpace(protobufComment(enum.Comment) + "\n" + strings.Join(names, "\n")),
+			ArtifactKind: common.ArtifactKindOther,
+			Constraints:  &lang.SchemaConstraints{Enum: values},
+		},
+		RedefinedType: &lang.GoSimple{TypeName: "int32"},
+	}
+}
+
+// resolve finds the message or enum declaration by type name according to protobuf scoping rules, i.e. searching from
+// the innermost scope to outermost.
+func (b *protobufTypeBuilder) resolve(typeName, scope string) (string, proto.Visitee, bool) {
+	var candidates []string
+	if strings.HasPrefix(typeName, ".") {
+		candidates = []string{typeName[1:]}
+	} else {
+		for s := scope; s != ""; s = s[:max(strings.LastIndex(s, "."), 0)] {
+			candidates = append(candidates, joinProtobufName(s, typeName))
+		}
+		candidates = append(candidates, typeName)
+	}
+
+	for _, c := range candidates {
+		if b.pkg != "" {
+			c = strings.TrimPrefix(c, b.pkg+".")
+		}
+		if d, ok := b.declarations[c]; ok {
+			return c, d, true
+		}
+	}
+	return "", nil, false
+}
+
+func (b *protobufTypeBuilder) error(err error) error {
+	return types.CompileError{Err: err, Path: b.ctx.CurrentRefPointer()}
+}
+
+func joinProtobufName(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+func protobufComment(c *proto.Comment) string {
+	if c == nil {
+		return ""
+	}
+	return strings.TrimSpace(strings.Join(lo.Map(c.Lines, func(item string, _ int) string {
+		return strings.TrimSpace(item)
+	}), "\n"))
+}
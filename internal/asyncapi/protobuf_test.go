@@ -0,0 +1,208 @@
+package asyncapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bdragon300/go-asyncapi/internal/common"
+	"github.com/bdragon300/go-asyncapi/internal/compiler/compile"
+	"github.com/bdragon300/go-asyncapi/internal/render/lang"
+	"github.com/bdragon300/go-asyncapi/internal/types"
+	yaml "gopkg.in/yaml.v3"
+)
+
+func TestProtobufSchemaCompile(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		// want is the Go types and protobuf tags of message fields by field name
+		want map[string]string
+	}{
+		{
+			name: "scalar types",
+			schema: `syntax = "proto3";
+				message Msg {
+					double d = 1; float f = 2; int32 i32 = 3; sint64 s64 = 4; uint32 u32 = 5; fixed64 f64 = 6;
+					sfixed32 sf32 = 7; bool b = 8; string s = 9; bytes by = 10;
+				}`,
+			want: map[string]string{
+				"D": "float64 1,double", "F": "float32 2,float", "I32": "int32 3,int32", "S64": "int64 4,sint64",
+				"U32": "uint32 5,uint32", "F64": "uint64 6,fixed64", "Sf32": "int32 7,sfixed32", "B": "bool 8,bool",
+				"S": "string 9,string", "By": "[]byte 10,bytes",
+			},
+		},
+		{
+			name: "repeated, optional and map fields",
+			schema: `syntax = "proto3";
+				message Msg {
+					repeated string tags = 1;
+					optional int64 count = 2;
+					map<string, int32> scores = 3;
+				}`,
+			want: map[string]string{
+				"Tags": "[]string 1,string", "Count": "*int64 2,int64", "Scores": "map[string]int32 3,map,string,int32",
+			},
+		},
+		{
+			name: "nested types and enums",
+			schema: `syntax = "proto3";
+				package shop.v1;
+				message Msg {
+					message Address { string city = 1; }
+					enum Kind { KIND_UNSPECIFIED = 0; KIND_ONLINE = 1; }
+					Address address = 1;
+					Kind kind = 2;
+					repeated Address history = 3;
+					map<string, Status> statuses = 4;
+					.shop.v1.Msg.Address full = 5;
+				}
+				enum Status { STATUS_UNKNOWN = 0; }`,
+			want: map[string]string{
+				"Address": "*struct MsgAddress 1,message", "Kind": "type Kind 2,enum",
+				"History": "[]struct MsgAddress 3,message", "Statuses": "map[string]type Status 4,map,string,enum",
+				"Full": "*struct MsgAddress 5,message",
+			},
+		},
+		{
+			name: "oneof",
+			schema: `syntax = "proto3";
+				message Msg {
+					oneof value {
+						string text = 1;
+						Msg2 other = 2;
+					}
+				}
+				message Msg2 { int32 x = 1; }`,
+			want: map[string]string{"Text": "*string 1,string", "Other": "*struct Msg2 2,message"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, storage := newTestCompileContext()
+			if err := unmarshalProtobufSchema(t, tt.schema).Compile(ctx); err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+
+			msg, ok := storage.artifacts["#/components/schemas/test"].(*lang.GoStruct)
+			if !ok {
+				t.Fatalf("message artifact is not found, got %v", storage.artifacts)
+			}
+			if !msg.HasDefinition || msg.OriginalName != "Msg" {
+				t.Errorf("message = %q, has definition %v, want %q with definition", msg.OriginalName, msg.HasDefinition, "Msg")
+			}
+			got := make(map[string]string)
+			for _, f := range msg.Fields {
+				got[f.OriginalName] = describeGoType(f.Type) + " " + f.Tags.MustGet("protobuf")
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProtobufSchemaCompileError(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr string
+	}{
+		{
+			name:    "recursive message",
+			schema:  `message Node { Node next = 1; }`,
+			wantErr: `recursive protobuf message "Node" is not supported`,
+		},
+		{
+			name:    "unknown type",
+			schema:  `import "google/protobuf/timestamp.proto"; message Msg { google.protobuf.Timestamp ts = 1; }`,
+			wantErr: `unknown protobuf type "google.protobuf.Timestamp"`,
+		},
+		{
+			name:    "no messages",
+			schema:  `syntax = "proto3"; enum Kind { KIND_UNSPECIFIED = 0; }`,
+			wantErr: "protobuf schema has no messages",
+		},
+		{
+			name:    "syntax error",
+			schema:  `message Msg {`,
+			wantErr: "parse protobuf schema",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := newTestCompileContext()
+			err := unmarshalProtobufSchema(t, tt.schema).Compile(ctx)
+			var compileErr types.CompileError
+			if !errors.As(err, &compileErr) {
+				t.Fatalf("Compile() error = %v, want CompileError", err)
+			}
+			if !strings.HasPrefix(compileErr.Err.Error(), tt.wantErr) || compileErr.Path != "#/components/schemas/test" {
+				t.Errorf("Compile() error = %q at %q, want %q at %q", compileErr.Err, compileErr.Path, tt.wantErr, "#/components/schemas/test")
+			}
+		})
+	}
+}
+
+func TestProtobufSchemaRef(t *testing.T) {
+	ctx, storage := newTestCompileContext()
+	var s ProtobufSchema
+	if err := yaml.Unmarshal([]byte(`{$ref: "user.proto#/User"}`), &s); err != nil {
+		t.Fatalf("unmarshal schema: %v", err)
+	}
+	if err := s.Compile(ctx); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	ref, ok := storage.artifacts["#/components/schemas/test"].(*lang.Ref)
+	if !ok {
+		t.Fatalf("ref artifact is not found, got %v", storage.artifacts)
+	}
+	if ref.Ref() != "user.proto#/User" {
+		t.Errorf("ref = %q, want %q", ref.Ref(), "user.proto#/User")
+	}
+}
+
+func TestProtobufFileCompile(t *testing.T) {
+	var f ProtobufFile
+	err := f.UnmarshalProtobuf([]byte(`syntax = "proto3";
+		// User is a user.
+		message User { Address address = 1; }
+		message Address { string city = 1; }
+		extend User { string extra = 100; }`))
+	if err != nil {
+		t.Fatalf("UnmarshalProtobuf() error = %v", err)
+	}
+	storage := &testArtifactStorage{artifacts: make(map[string]common.Artifact)}
+	ctx := compile.NewCompileContext(compile.CompilationOpts{})
+	ctx.Storage = storage
+	if err = f.Compile(ctx); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	got := make(map[string]string)
+	for ref, a := range storage.artifacts {
+		s := a.(*lang.GoStruct)
+		got[ref] = fmt.Sprintf("%s definition=%v description=%q", s.OriginalName, s.HasDefinition, s.Description)
+	}
+	want := map[string]string{
+		"#/User":    `User definition=true description="User is a user."`,
+		"#/Address": `Address definition=true description=""`,
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("artifacts = %v, want %v", got, want)
+	}
+	// The message fields must refer to the definitions, not to the inlined copies
+	if user := storage.artifacts["#/User"].(*lang.GoStruct); user.Fields[0].Type.(*lang.GoPointer).Type != storage.artifacts["#/Address"] {
+		t.Errorf("User.Address field does not refer to Address definition")
+	}
+}
+
+func unmarshalProtobufSchema(t *testing.T, schema string) ProtobufSchema {
+	t.Helper()
+	var res ProtobufSchema
+	if err := yaml.Unmarshal([]byte(fmt.Sprintf("%q", schema)), &res); err != nil {
+		t.Fatalf("unmarshal schema: %v", err)
+	}
+	return res
+}
@@ -1,13 +1,18 @@
 package asyncapi
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"unicode"
 
+	"github.com/bdragon300/go-asyncapi/internal/render"
 	"github.com/bdragon300/go-asyncapi/internal/render/lang"
+	"github.com/bdragon300/go-asyncapi/internal/types"
 	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
 )
 
 // guessTagByContentType guesses the struct tag name by the MIME type. It returns the last
@@ -23,6 +28,18 @@ func guessTagByContentType(contentType string) string {
 	return contentType
 }
 
+// messagesTagNames returns the sorted unique struct tag names guessed by the content types of given messages, plus
+// the extra names. The "protobuf" tag is skipped, since it has its own format and is set only on the types compiled
+// from .proto schemas.
+func messagesTagNames(messages []*render.Message, extra ...string) []string {
+	tagNames := lo.Map(messages, func(item *render.Message, _ int) string {
+		return guessTagByContentType(item.EffectiveContentType())
+	})
+	tagNames = lo.Without(lo.Uniq(append(tagNames, extra...)), "protobuf")
+	slices.Sort(tagNames)
+	return tagNames
+}
+
 // parseRuntimeExpression parses a runtime expression in the form of "$message.source#fragment" and returns
 // the struct field kind where the value is located and the fragment part, split by slashes.
 // See: https://github.com/asyncapi/spec/blob/master/spec/asyncapi.md#runtimeExpression
@@ -52,3 +69,24 @@ func parseRuntimeExpression(location string) (lang.RuntimeExpressionStructFieldK
 	locationPath := strings.Split(locationParts[1], "/")[1:]
 	return structField, locationPath, nil
 }
+
+// decodeSchemaValue decodes the arbitrary value from jsonschema (enum, const, default, examples, etc.) into Go value.
+func decodeSchemaValue(value types.Union2[json.RawMessage, yaml.Node]) (res any, err error) {
+	switch value.Selector {
+	case 0:
+		err = json.Unmarshal(value.V0, &res)
+	case 1:
+		err = value.V1.Decode(&res)
+	default:
+		panic(fmt.Errorf("invalid selector value %d, this is a bug", value.Selector))
+	}
+	return
+}
+
+func isPrimitiveValue(v any) bool {
+	switch v.(type) {
+	case bool, string, int, float64:
+		return true
+	}
+	return false
+}
@@ -1,6 +1,10 @@
 package common
 
 import (
+	"maps"
+	"slices"
+	"strings"
+
 	"github.com/bdragon300/go-asyncapi/internal/jsonpointer"
 )
 
@@ -71,3 +75,25 @@ func DerefArtifact(obj Artifact) Artifact {
 func CheckSameArtifacts(a, b Artifact) bool {
 	return DerefArtifact(a) == DerefArtifact(b)
 }
+
+// ArtifactStorage is a storage of compiled artifacts, e.g. compiled document.
+type ArtifactStorage interface {
+	Artifacts() []Artifact
+}
+
+// VisibleArtifacts returns the visible artifacts of type T from all documents, sorted by name.
+func VisibleArtifacts[T Artifact, S ArtifactStorage](documents map[string]S) []T {
+	var res []T
+	for _, docURL := range slices.Sorted(maps.Keys(documents)) {
+		for _, obj := range documents[docURL].Artifacts() {
+			if v, ok := obj.(T); ok && v.Visible() {
+				res = append(res, v)
+			}
+		}
+	}
+	// Sort by name to keep idempotency
+	slices.SortStableFunc(res, func(a, b T) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return res
+}
@@ -25,6 +25,10 @@ type (
 		RuntimeModule          string
 		ImportBase             string
 		PreambleTemplate       string
+		ValidateMessages       bool
+		Tracing                bool
+		Mocks                  bool
+		ExampleTests           bool
 		Layout                 []CodeLayoutItemOpts
 		UtilCodeOpts           UtilCodeOpts
 		ImplementationCodeOpts ImplementationCodeOpts
@@ -58,13 +58,16 @@ type Context struct {
 	CompileOpts CompilationOpts
 }
 
-// PutArtifact adds an artifact to the storage.
-func (c *Context) PutArtifact(obj common.Artifact) {
+// PutArtifact adds an artifact to the storage. The artifact is located at the current position in document, appending
+// the optional parts at the end. The parts are used for artifacts built from the nested parts of an object, that
+// are not compiled separately, e.g. the types defined inside an Avro schema.
+func (c *Context) PutArtifact(obj common.Artifact, extraParts ...string) {
 	type jsonPointerSetter interface {
 		SetPointer(pointer jsonpointer.JSONPointer)
 	}
 
 	u := c.CurrentRef()
+	u.Pointer = append(u.Pointer, extraParts...)
 	obj.(jsonPointerSetter).SetPointer(u) // Every artifact must have a SetPointer method
 	c.Logger.Debug(
 		"Built",
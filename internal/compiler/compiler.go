@@ -64,6 +64,8 @@ type Document struct {
 
 	kind        DocumentKind
 	objectsTree compiledObject
+	// upgraded is true if document was converted from AsyncAPI 2.x
+	upgraded bool
 
 	// Compilation results
 	externalRefs []*jsonpointer.JSONPointer
@@ -92,6 +94,11 @@ func (c *Document) DocumentURL() jsonpointer.JSONPointer {
 	return *c.url
 }
 
+// Upgraded returns true if the document was converted from AsyncAPI 2.x to AsyncAPI 3.0 on loading.
+func (c *Document) Upgraded() bool {
+	return c.upgraded
+}
+
 func (c *Document) Artifacts() []common.Artifact {
 	return c.artifacts
 }
@@ -125,10 +132,23 @@ func (c *Document) Load(locator documentLocator) error {
 	switch c.kind {
 	case DocumentKindAsyncapi:
 		c.objectsTree = new(asyncapi.AsyncAPI)
+	case DocumentKindProtobuf:
+		c.objectsTree = new(asyncapi.ProtobufFile)
 	default:
 		return fmt.Errorf("unsupported document kind: %s", c.kind)
 	}
 
+	if c.kind == DocumentKindAsyncapi {
+		c.upgraded, err = c.decodeUpgraded(buf)
+		if err != nil {
+			return fmt.Errorf("convert AsyncAPI 2.x document: %w", err)
+		}
+		if c.upgraded {
+			c.logger.Debug("Document decoded", "url", c.url, "kind", c.kind)
+			return nil
+		}
+	}
+
 	if err = newDecoder(bytes.NewReader(buf)).Decode(c.objectsTree); err != nil {
 		return fmt.Errorf("decode document: %w", err)
 	}
@@ -136,6 +156,36 @@ func (c *Document) Load(locator documentLocator) error {
 	return nil
 }
 
+// decodeUpgraded decodes the AsyncAPI 2.x document, converting it to the AsyncAPI 3.0 first. Returns false if the
+// document is not AsyncAPI 2.x.
+func (c *Document) decodeUpgraded(buf []byte) (bool, error) {
+	var node yaml.Node
+	var err error
+	// YAML is almost a superset of JSON, but YAML parser rejects some valid JSON, e.g. escaped slashes
+	if path.Ext(c.url.Location()) == ".json" {
+		err = parseJSONNode(buf, &node)
+	} else {
+		err = yaml.Unmarshal(buf, &node)
+	}
+	if err != nil {
+		return false, fmt.Errorf("parse document: %w", err)
+	}
+	upgraded, err := upgradeAsyncAPI2(&node)
+	if err != nil || !upgraded {
+		return false, err
+	}
+	c.logger.Info("Converted AsyncAPI 2.x document to AsyncAPI 3.0", "url", c.url)
+	if c.logger.GetLevel() == log.TraceLevel {
+		if out, err := yaml.Marshal(&node); err == nil {
+			c.logger.Trace("Converted document", "contents", string(out))
+		}
+	}
+	if err = node.Decode(c.objectsTree); err != nil {
+		return true, fmt.Errorf("decode document: %w", err)
+	}
+	return true, nil
+}
+
 // Compile runs the document compilation.
 func (c *Document) Compile(ctx *compile.Context) error {
 	ctx = ctx.WithResultsStore(c)
@@ -190,6 +240,9 @@ func ReadDocument(u *jsonpointer.JSONPointer, locator documentLocator, logger *l
 	case ".json":
 		logger.Debug("File is in JSON format", "name", u.Location())
 		return data, func(rd io.Reader) AnyDecoder { return json.NewDecoder(rd) }, nil
+	case ".proto":
+		logger.Debug("File is a Protocol Buffers definition", "name", u.Location())
+		return data, func(rd io.Reader) AnyDecoder { return protobufDecoder{rd: rd} }, nil
 	}
 	return data, nil, fmt.Errorf("cannot determine format by extension %s: %s", path.Ext(u.Location()), u.Location())
 }
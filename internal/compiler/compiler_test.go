@@ -0,0 +1,117 @@
+package compiler
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bdragon300/go-asyncapi/internal/jsonpointer"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDocumentLoad(t *testing.T) {
+	tests := []struct {
+		name         string
+		file         string
+		contents     string
+		wantUpgraded bool
+		wantErr      string
+	}{
+		{
+			name:     "v3",
+			file:     "asyncapi.yaml",
+			contents: "asyncapi: 3.0.0\ninfo: {title: Test, version: 1.0.0}\n",
+		},
+		{
+			name:         "v2",
+			file:         "asyncapi.yaml",
+			contents:     "asyncapi: 2.6.0\ninfo: {title: Test, version: 1.0.0}\nchannels: {}\n",
+			wantUpgraded: true,
+		},
+		{
+			name:         "v2 json",
+			file:         "asyncapi.json",
+			contents:     `{"asyncapi": "2.6.0", "info": {"title": "Test", "version": "1.0.0"}}`,
+			wantUpgraded: true,
+		},
+		{
+			// YAML parser rejects the escaped slashes
+			name:         "v2 json with escaped slash",
+			file:         "asyncapi.json",
+			contents:     `{"asyncapi": "2.6.0", "info": {"title": "Test", "version": "1.0.0"}, "defaultContentType": "application\/json"}`,
+			wantUpgraded: true,
+		},
+		{
+			name:     "invalid json",
+			file:     "asyncapi.json",
+			contents: `{"asyncapi": "2.6.0", "info": {"title": "Test", "version": "1.0.0"}} garbage`,
+			wantErr:  "parse document: unexpected data after JSON value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument(&jsonpointer.JSONPointer{FSPath: tt.file})
+			err := doc.Load(testLocator{tt.file: tt.contents})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if doc.Upgraded() != tt.wantUpgraded {
+				t.Errorf("Upgraded() = %v, want %v", doc.Upgraded(), tt.wantUpgraded)
+			}
+		})
+	}
+}
+
+func TestDocumentLoadUnknownKind(t *testing.T) {
+	// Parts of document in separate files must have `asyncapi` field as well
+	doc := NewDocument(&jsonpointer.JSONPointer{FSPath: "common.yaml"})
+	err := doc.Load(testLocator{"common.yaml": "components:\n  messages: {}\n"})
+	if !errors.Is(err, ErrUnknownDocumentKind) {
+		t.Errorf("Load() error = %v, want %v", err, ErrUnknownDocumentKind)
+	}
+}
+
+type testLocator map[string]string
+
+func (l testLocator) Locate(documentURL *jsonpointer.JSONPointer) (io.ReadCloser, error) {
+	contents, ok := l[documentURL.Location()]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return io.NopCloser(strings.NewReader(contents)), nil
+}
+
+func TestParseJSONNode(t *testing.T) {
+	data := `{"b": 1, "a": [1.5, -2e3, true, null, "s", {}], "c": {"z": false, "y": []}}`
+	var got, want yaml.Node
+	if err := parseJSONNode([]byte(data), &got); err != nil {
+		t.Fatalf("parseJSONNode() error = %v", err)
+	}
+	if err := yaml.Unmarshal([]byte(data), &want); err != nil {
+		t.Fatal(err)
+	}
+	// Keys order is kept
+	if keys := []string{got.Content[0].Content[0].Value, got.Content[0].Content[2].Value}; !slices.Equal(keys, []string{"b", "a"}) {
+		t.Errorf("parseJSONNode() keys = %v, want [b a]", keys)
+	}
+	// Scalar types must be the same as YAML parser produces
+	var gotValue, wantValue any
+	if err := got.Decode(&gotValue); err != nil {
+		t.Fatal(err)
+	}
+	if err := want.Decode(&wantValue); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("parseJSONNode() decoded = %v, want %v", gotValue, wantValue)
+	}
+}
@@ -1,13 +1,30 @@
 package compiler
 
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
+)
+
 type DocumentKind string
 
 const (
 	DocumentKindAsyncapi   DocumentKind = "asyncapi"
 	DocumentKindJsonschema DocumentKind = "jsonschema"
 	DocumentKindOpenapi    DocumentKind = "openapi"
+	DocumentKindProtobuf   DocumentKind = "protobuf"
 )
 
+// ErrUnknownDocumentKind is returned if the document kind can't be determined by its contents.
+var ErrUnknownDocumentKind = errors.New("unknown document kind, the document has no `asyncapi` field")
+
 type documentFormatTester struct {
 	Asyncapi string `json:"asyncapi" yaml:"asyncapi"`
 	Openapi  string `json:"openapi" yaml:"openapi"`
@@ -19,6 +36,9 @@ type AnyDecoder interface {
 
 // guessDocumentKind tries to guess the document kind by its contents.
 func guessDocumentKind(decoder AnyDecoder) (DocumentKind, error) {
+	if _, ok := decoder.(protobufDecoder); ok {
+		return DocumentKindProtobuf, nil
+	}
 	test := documentFormatTester{}
 
 	if err := decoder.Decode(&test); err != nil {
@@ -30,5 +50,82 @@ func guessDocumentKind(decoder AnyDecoder) (DocumentKind, error) {
 	case test.Openapi != "":
 		panic("openapi not implemented")
 	}
-	panic("jsonschema not implemented")
+	return "", ErrUnknownDocumentKind
+}
+
+type protobufUnmarshaler interface {
+	UnmarshalProtobuf(data []byte) error
+}
+
+// protobufDecoder is the decoder for Protocol Buffers definition files (.proto). It can decode only to the objects
+// that implement protobufUnmarshaler interface.
+type protobufDecoder struct {
+	rd io.Reader
+}
+
+func (d protobufDecoder) Decode(v any) error {
+	u, ok := v.(protobufUnmarshaler)
+	if !ok {
+		return fmt.Errorf("cannot decode protobuf definition to %T", v)
+	}
+	data, err := io.ReadAll(d.rd)
+	if err != nil {
+		return err
+	}
+	return u.UnmarshalProtobuf(data)
+}
+
+// parseJSONNode parses the JSON document to the YAML node tree, keeping the keys order.
+func parseJSONNode(data []byte, node *yaml.Node) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	root, err := decodeJSONNode(dec)
+	if err != nil {
+		return err
+	}
+	if _, err = dec.Token(); err != io.EOF {
+		return errors.New("unexpected data after JSON value")
+	}
+	*node = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}
+	return nil
+}
+
+func decodeJSONNode(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch v := tok.(type) {
+	case json.Delim:
+		res := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if v == '{' {
+			res = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		for dec.More() {
+			if res.Kind == yaml.MappingNode {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				res.Content = append(res.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.(string)})
+			}
+			item, err := decodeJSONNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			res.Content = append(res.Content, item)
+		}
+		if _, err = dec.Token(); err != nil { // Closing delimiter
+			return nil, err
+		}
+		return res, nil
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}, nil
+	case json.Number:
+		tag := lo.Ternary(strings.ContainsAny(v.String(), ".eE"), "!!float", "!!int")
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v)}, nil
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
 }
@@ -0,0 +1,412 @@
+package compiler
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bdragon300/go-asyncapi/internal/jsonpointer"
+	"gopkg.in/yaml.v3"
+)
+
+// upgradedAsyncAPIVersion is the version set to the AsyncAPI 2.x documents after conversion.
+const upgradedAsyncAPIVersion = "3.0.0"
+
+// upgradeAsyncAPI2 converts the AsyncAPI 2.x document to the AsyncAPI 3.0 object model in place. Returns false if
+// document is not AsyncAPI 2.x and was left untouched.
+//
+// The main differences are:
+//
+//   - publish/subscribe operations inside channels become the root operations with “receive”/“send” actions
+//     respectively. In 2.x the actions are described from the client's point of view, in 3.0 -- from the application's one.
+//   - channel messages are moved to the channel, operations refer to them by $ref
+//   - channel key becomes a channel id, and the channel address is set to the key
+//   - server url is split to host and pathname
+//   - channel parameters have no schema anymore, its enum, default and examples are moved to the parameter
+//   - security requirements become the $refs to the security schemes
+//   - message schemaFormat is moved to payload, making it the Multi Format Schema object
+//
+// The $refs to the parts of 2.x document, that do not exist anymore in 3.0 (e.g. “#/channels/foo/publish/message”),
+// are not converted.
+func upgradeAsyncAPI2(document *yaml.Node) (bool, error) {
+	root := document
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return false, nil
+	}
+	version := mappingGet(root, "asyncapi")
+	if version == nil || !strings.HasPrefix(version.Value, "2.") {
+		return false, nil
+	}
+	version.Value = upgradedAsyncAPIVersion
+
+	// Root tags and externalDocs are moved to info
+	if tags := mappingDelete(root, "tags"); tags != nil {
+		mappingSet(mappingGetOrCreate(root, "info"), "tags", tags)
+	}
+	if docs := mappingDelete(root, "externalDocs"); docs != nil {
+		mappingSet(mappingGetOrCreate(root, "info"), "externalDocs", docs)
+	}
+
+	if servers := mappingGet(root, "servers"); servers != nil {
+		for _, srv := range mappingValues(servers) {
+			upgradeServer(srv)
+		}
+	}
+
+	if channels := mappingGet(root, "channels"); channels != nil {
+		operations := mappingGetOrCreate(root, "operations")
+		for i := 0; i+1 < len(channels.Content); i += 2 {
+			if err := upgradeChannel(channels.Content[i].Value, channels.Content[i+1], operations); err != nil {
+				return true, fmt.Errorf("channel %q: %w", channels.Content[i].Value, err)
+			}
+		}
+		if len(operations.Content) == 0 {
+			mappingDelete(root, "operations")
+		}
+	}
+
+	if components := mappingGet(root, "components"); components != nil {
+		upgradeComponents(components)
+	}
+
+	return true, nil
+}
+
+func upgradeComponents(components *yaml.Node) {
+	if servers := mappingGet(components, "servers"); servers != nil {
+		for _, srv := range mappingValues(servers) {
+			upgradeServer(srv)
+		}
+	}
+	if messages := mappingGet(components, "messages"); messages != nil {
+		for _, msg := range mappingValues(messages) {
+			upgradeMessage(msg)
+		}
+	}
+	if traits := mappingGet(components, "messageTraits"); traits != nil {
+		for _, trait := range mappingValues(traits) {
+			upgradeMessage(trait)
+		}
+	}
+	if traits := mappingGet(components, "operationTraits"); traits != nil {
+		for _, trait := range mappingValues(traits) {
+			upgradeOperationTrait(trait)
+		}
+	}
+	if params := mappingGet(components, "parameters"); params != nil {
+		for _, param := range mappingValues(params) {
+			upgradeParameter(param)
+		}
+	}
+	if schemes := mappingGet(components, "securitySchemes"); schemes != nil {
+		for _, scheme := range mappingValues(schemes) {
+			upgradeSecurityScheme(scheme)
+		}
+	}
+}
+
+// upgradeServer splits the server url to host and pathname. The url scheme, if any, is dropped, since the protocol
+// is set in a separate field.
+func upgradeServer(server *yaml.Node) {
+	if isRef(server) {
+		return
+	}
+	if u := mappingDelete(server, "url"); u != nil {
+		address := u.Value
+		if _, after, found := strings.Cut(address, "://"); found {
+			address = after
+		}
+		host, pathname, found := strings.Cut(address, "/")
+		mappingSet(server, "host", scalarNode(host))
+		if found && pathname != "" {
+			mappingSet(server, "pathname", scalarNode("/"+pathname))
+		}
+	}
+	upgradeSecurityRequirements(server)
+}
+
+func upgradeChannel(channelKey string, channel, operations *yaml.Node) error {
+	if isRef(channel) {
+		return nil
+	}
+	if mappingGet(channel, "address") == nil {
+		mappingSet(channel, "address", scalarNode(channelKey))
+	}
+	if servers := mappingGet(channel, "servers"); servers != nil && servers.Kind == yaml.SequenceNode {
+		for i, srv := range servers.Content {
+			if srv.Kind == yaml.ScalarNode {
+				servers.Content[i] = refNode(jsonpointer.PointerString("servers", srv.Value))
+			}
+		}
+	}
+	if params := mappingGet(channel, "parameters"); params != nil {
+		for _, param := range mappingValues(params) {
+			upgradeParameter(param)
+		}
+	}
+
+	// In 2.x the operations are described from the client's point of view, so the actions are inverted
+	for _, action := range []struct{ v2, v3 string }{{"publish", "receive"}, {"subscribe", "send"}} {
+		op := mappingDelete(channel, action.v2)
+		if op == nil {
+			continue
+		}
+		opID := channelKey + "_" + action.v2
+		if v := mappingDelete(op, "operationId"); v != nil && v.Value != "" {
+			opID = v.Value
+		}
+		if mappingGet(operations, opID) != nil {
+			return fmt.Errorf("duplicated operation id %q", opID)
+		}
+
+		res := &yaml.Node{Kind: yaml.MappingNode}
+		mappingSet(res, "action", scalarNode(action.v3))
+		mappingSet(res, "channel", refNode(jsonpointer.PointerString("channels", channelKey)))
+		if msg := mappingDelete(op, "message"); msg != nil {
+			refs := upgradeOperationMessages(channelKey, opID, channel, msg)
+			mappingSet(res, "messages", &yaml.Node{Kind: yaml.SequenceNode, Content: refs})
+		}
+		if traits := mappingGet(op, "traits"); traits != nil {
+			for _, trait := range traits.Content {
+				upgradeOperationTrait(trait)
+			}
+		}
+		upgradeSecurityRequirements(op)
+		// The rest of fields (summary, description, bindings, extensions, etc.) are the same in both versions
+		res.Content = append(res.Content, op.Content...)
+
+		mappingSet(operations, opID, res)
+	}
+	return nil
+}
+
+// upgradeOperationMessages moves the operation message (or all messages in oneOf) to the channel messages and returns
+// the $refs to them.
+func upgradeOperationMessages(channelKey, opID string, channel, message *yaml.Node) []*yaml.Node {
+	messages := []*yaml.Node{message}
+	if oneOf := mappingGet(message, "oneOf"); oneOf != nil && !isRef(message) {
+		messages = oneOf.Content
+	}
+	channelMessages := mappingGetOrCreate(channel, "messages")
+
+	var res []*yaml.Node
+	for i, msg := range messages {
+		var key string
+		switch {
+		case isRef(msg):
+			ref := mappingGet(msg, "$ref").Value
+			if p, err := jsonpointer.Parse(ref); err == nil && len(p.Pointer) > 0 {
+				key = p.Pointer[len(p.Pointer)-1]
+			}
+		case mappingGet(msg, "name") != nil:
+			key = mappingGet(msg, "name").Value
+		case mappingGet(msg, "messageId") != nil:
+			key = mappingGet(msg, "messageId").Value
+		}
+		if key == "" {
+			key = opID + "Message"
+			if len(messages) > 1 {
+				key += fmt.Sprint(i)
+			}
+		}
+		if !isRef(msg) {
+			upgradeMessage(msg)
+		}
+
+		// The same message may be used in both publish and subscribe operations
+		key = uniqueMappingKey(channelMessages, key, msg)
+		if mappingGet(channelMessages, key) == nil {
+			mappingSet(channelMessages, key, msg)
+		}
+		res = append(res, refNode(jsonpointer.PointerString("channels", channelKey, "messages", key)))
+	}
+	return res
+}
+
+func upgradeMessage(message *yaml.Node) {
+	if isRef(message) {
+		return
+	}
+	mappingDelete(message, "messageId")
+	schemaFormat := mappingDelete(message, "schemaFormat")
+	if schemaFormat == nil || isJSONSchemaFormat(schemaFormat.Value) {
+		return
+	}
+	if payload := mappingGet(message, "payload"); payload != nil {
+		mfs := &yaml.Node{Kind: yaml.MappingNode}
+		mappingSet(mfs, "schemaFormat", schemaFormat)
+		mappingSet(mfs, "schema", payload)
+		mappingSet(message, "payload", mfs)
+	}
+}
+
+func upgradeOperationTrait(trait *yaml.Node) {
+	if isRef(trait) {
+		return
+	}
+	mappingDelete(trait, "operationId")
+	upgradeSecurityRequirements(trait)
+}
+
+// upgradeParameter moves the enum, default and examples from the parameter schema to the parameter itself. Values are
+// converted to strings, since 3.0 parameters are always strings.
+func upgradeParameter(param *yaml.Node) {
+	if isRef(param) {
+		return
+	}
+	schema := mappingDelete(param, "schema")
+	if schema == nil || isRef(schema) {
+		return
+	}
+	if v := mappingGet(schema, "enum"); v != nil && v.Kind == yaml.SequenceNode {
+		mappingSet(param, "enum", stringSequence(v))
+	}
+	if v := mappingGet(schema, "default"); v != nil && v.Kind == yaml.ScalarNode {
+		mappingSet(param, "default", scalarNode(v.Value))
+	}
+	if v := mappingGet(schema, "examples"); v != nil && v.Kind == yaml.SequenceNode {
+		mappingSet(param, "examples", stringSequence(v))
+	}
+	if v := mappingGet(schema, "description"); v != nil && mappingGet(param, "description") == nil {
+		mappingSet(param, "description", v)
+	}
+}
+
+// upgradeSecurityRequirements replaces the 2.x security requirements, like `[{petstoreAuth: [scope]}]`, with $refs
+// to the security schemes in components.
+func upgradeSecurityRequirements(obj *yaml.Node) {
+	security := mappingGet(obj, "security")
+	if security == nil || security.Kind != yaml.SequenceNode {
+		return
+	}
+	var res []*yaml.Node
+	for _, req := range security.Content {
+		if req.Kind != yaml.MappingNode || isRef(req) {
+			res = append(res, req)
+			continue
+		}
+		for i := 0; i < len(req.Content); i += 2 {
+			res = append(res, refNode(jsonpointer.PointerString("components", "securitySchemes", req.Content[i].Value)))
+		}
+	}
+	security.Content = res
+}
+
+func upgradeSecurityScheme(scheme *yaml.Node) {
+	if isRef(scheme) {
+		return
+	}
+	flows := mappingGet(scheme, "flows")
+	if flows == nil {
+		return
+	}
+	for _, flow := range mappingValues(flows) {
+		if scopes := mappingDelete(flow, "scopes"); scopes != nil {
+			mappingSet(flow, "availableScopes", scopes)
+		}
+	}
+}
+
+func isJSONSchemaFormat(format string) bool {
+	format = strings.ToLower(format)
+	return format == "" ||
+		strings.HasPrefix(format, "application/vnd.aai.asyncapi") ||
+		strings.HasPrefix(format, "application/schema+json") ||
+		strings.HasPrefix(format, "application/schema+yaml")
+}
+
+func isRef(node *yaml.Node) bool {
+	return node.Kind == yaml.MappingNode && mappingGet(node, "$ref") != nil
+}
+
+// uniqueMappingKey returns the key, that is not used in mapping yet or is used by the same $ref as value.
+func uniqueMappingKey(mapping *yaml.Node, key string, value *yaml.Node) string {
+	res := key
+	for i := 1; ; i++ {
+		existing := mappingGet(mapping, res)
+		if existing == nil || isRef(existing) && isRef(value) && mappingGet(existing, "$ref").Value == mappingGet(value, "$ref").Value {
+			return res
+		}
+		res = fmt.Sprintf("%s%d", key, i)
+	}
+}
+
+func mappingGet(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func mappingGetOrCreate(mapping *yaml.Node, key string) *yaml.Node {
+	if v := mappingGet(mapping, key); v != nil {
+		return v
+	}
+	v := &yaml.Node{Kind: yaml.MappingNode}
+	mappingSet(mapping, key, v)
+	return v
+}
+
+func mappingSet(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, scalarNode(key), value)
+}
+
+// mappingDelete removes the key from mapping and returns its value, or nil if there is no such key.
+func mappingDelete(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			v := mapping.Content[i+1]
+			mapping.Content = slices.Delete(mapping.Content, i, i+2)
+			return v
+		}
+	}
+	return nil
+}
+
+func mappingValues(mapping *yaml.Node) []*yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	var res []*yaml.Node
+	for i := 1; i < len(mapping.Content); i += 2 {
+		res = append(res, mapping.Content[i])
+	}
+	return res
+}
+
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+func refNode(ref string) *yaml.Node {
+	res := &yaml.Node{Kind: yaml.MappingNode}
+	mappingSet(res, "$ref", scalarNode(ref))
+	return res
+}
+
+func stringSequence(seq *yaml.Node) *yaml.Node {
+	res := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, item := range seq.Content {
+		if item.Kind == yaml.ScalarNode {
+			res.Content = append(res.Content, scalarNode(item.Value))
+		}
+	}
+	return res
+}
@@ -0,0 +1,76 @@
+package compiler
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestUpgradeAsyncAPI2(t *testing.T) {
+	tests := []struct {
+		name         string
+		wantUpgraded bool
+		wantErr      string
+	}{
+		{name: "operations", wantUpgraded: true},
+		{name: "oneof", wantUpgraded: true},
+		{name: "servers", wantUpgraded: true},
+		{name: "parameters", wantUpgraded: true},
+		{name: "security", wantUpgraded: true},
+		{name: "duplicated-operation-id", wantUpgraded: true, wantErr: `duplicated operation id "lights"`},
+		{name: "not-v2", wantUpgraded: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document := readYAMLFixture(t, tt.name+".v2.yaml")
+
+			upgraded, err := upgradeAsyncAPI2(document)
+			if upgraded != tt.wantUpgraded {
+				t.Errorf("upgradeAsyncAPI2() upgraded = %v, want %v", upgraded, tt.wantUpgraded)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("upgradeAsyncAPI2() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("upgradeAsyncAPI2() error = %v", err)
+			}
+
+			// Not upgraded document must be left untouched
+			wantFile := tt.name + ".v3.yaml"
+			if !tt.wantUpgraded {
+				wantFile = tt.name + ".v2.yaml"
+			}
+			var got, want any
+			if err = document.Decode(&got); err != nil {
+				t.Fatalf("decode result: %v", err)
+			}
+			if err = readYAMLFixture(t, wantFile).Decode(&want); err != nil {
+				t.Fatalf("decode %s: %v", wantFile, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				res, _ := yaml.Marshal(document)
+				t.Errorf("upgradeAsyncAPI2() result does not match %s:\n%s", wantFile, res)
+			}
+		})
+	}
+}
+
+func readYAMLFixture(t *testing.T, fileName string) *yaml.Node {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "upgrade", fileName))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	var res yaml.Node
+	if err = yaml.Unmarshal(data, &res); err != nil {
+		t.Fatalf("parse fixture %s: %v", fileName, err)
+	}
+	return &res
+}
@@ -0,0 +1,429 @@
+// Package differ compares two compiled and linked versions of the AsyncAPI document and reports the changes between
+// them, classifying every change as breaking or non-breaking.
+//
+// The change is considered breaking if the code generated from the old document version would not work with the
+// new one, or consumers may receive the data they don't expect. For example, removed channel, changed channel address,
+// new required property, narrowed enum, changed field type, etc.
+//
+// The objects in old and new documents are matched by names, so renaming the object appears as removal of the old
+// one and addition of the new one.
+package differ
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bdragon300/go-asyncapi/internal/common"
+	"github.com/bdragon300/go-asyncapi/internal/render"
+	"github.com/bdragon300/go-asyncapi/internal/render/lang"
+	"github.com/samber/lo"
+)
+
+// Kind is a change kind identifier.
+type Kind string
+
+const (
+	KindChannelRemoved          Kind = "channel-removed"
+	KindChannelAdded            Kind = "channel-added"
+	KindChannelAddressChanged   Kind = "channel-address-changed"
+	KindChannelParameterRemoved Kind = "channel-parameter-removed"
+	KindChannelParameterAdded   Kind = "channel-parameter-added"
+	KindChannelMessageRemoved   Kind = "channel-message-removed"
+	KindChannelMessageAdded     Kind = "channel-message-added"
+
+	KindOperationRemoved        Kind = "operation-removed"
+	KindOperationAdded          Kind = "operation-added"
+	KindOperationActionChanged  Kind = "operation-action-changed"
+	KindOperationChannelChanged Kind = "operation-channel-changed"
+	KindOperationMessageRemoved Kind = "operation-message-removed"
+	KindOperationMessageAdded   Kind = "operation-message-added"
+	KindOperationReplyRemoved   Kind = "operation-reply-removed"
+	KindOperationReplyAdded     Kind = "operation-reply-added"
+
+	KindMessageRemoved            Kind = "message-removed"
+	KindMessageAdded              Kind = "message-added"
+	KindMessageContentTypeChanged Kind = "message-content-type-changed"
+
+	KindSchemaRemoved    Kind = "schema-removed"
+	KindSchemaAdded      Kind = "schema-added"
+	KindTypeChanged      Kind = "type-changed"
+	KindPropertyRemoved  Kind = "property-removed"
+	KindPropertyAdded    Kind = "property-added"
+	KindPropertyRequired Kind = "property-required"
+	KindPropertyOptional Kind = "property-optional"
+	KindEnumNarrowed     Kind = "enum-narrowed"
+	KindEnumWidened      Kind = "enum-widened"
+)
+
+// Change is a single difference between the old and new document versions.
+type Change struct {
+	Kind     Kind   `json:"kind"`
+	Breaking bool   `json:"breaking"`
+	Message  string `json:"message"`
+	// OldPointer is the JSON Pointer to the changed object in the old document. Empty if the object was added.
+	OldPointer string `json:"oldPointer,omitempty"`
+	// NewPointer is the JSON Pointer to the changed object in the new document. Empty if the object was removed.
+	NewPointer string `json:"newPointer,omitempty"`
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s: %s [%s]", c.Severity(), c.Pointer(), c.Message, c.Kind)
+}
+
+// Severity returns "breaking" or "non-breaking" string.
+func (c Change) Severity() string {
+	return lo.Ternary(c.Breaking, "breaking", "non-breaking")
+}
+
+// Pointer returns the pointer to the changed object in the new document if it exists, otherwise in the old one.
+func (c Change) Pointer() string {
+	res, _ := lo.Coalesce(c.NewPointer, c.OldPointer)
+	return res
+}
+
+// Compare returns the changes between the old and new documents. Both documents sets must be linked successfully.
+func Compare[T common.ArtifactStorage](oldDocuments, newDocuments map[string]T) []Change {
+	c := comparator{visited: make(map[[2]common.GolangType]struct{})}
+
+	compareByName(
+		common.VisibleArtifacts[*render.Channel](oldDocuments), common.VisibleArtifacts[*render.Channel](newDocuments),
+		func(o *render.Channel) {
+			c.add(KindChannelRemoved, true, o, nil, "Channel %q removed", o.Name())
+		},
+		func(n *render.Channel) {
+			c.add(KindChannelAdded, false, nil, n, "Channel %q added", n.Name())
+		},
+		c.compareChannels,
+	)
+	compareByName(
+		common.VisibleArtifacts[*render.Operation](oldDocuments), common.VisibleArtifacts[*render.Operation](newDocuments),
+		func(o *render.Operation) {
+			c.add(KindOperationRemoved, true, o, nil, "Operation %q removed", o.Name())
+		},
+		func(n *render.Operation) {
+			c.add(KindOperationAdded, false, nil, n, "Operation %q added", n.Name())
+		},
+		c.compareOperations,
+	)
+	compareByName(
+		common.VisibleArtifacts[*render.Message](oldDocuments), common.VisibleArtifacts[*render.Message](newDocuments),
+		func(o *render.Message) {
+			c.add(KindMessageRemoved, true, o, nil, "Message %q removed", o.Name())
+		},
+		func(n *render.Message) {
+			c.add(KindMessageAdded, false, nil, n, "Message %q added", n.Name())
+		},
+		c.compareMessages,
+	)
+	compareByName(
+		schemaArtifacts(oldDocuments), schemaArtifacts(newDocuments),
+		func(o common.GolangType) {
+			c.add(KindSchemaRemoved, true, o, nil, "Schema %q removed", o.Name())
+		},
+		func(n common.GolangType) {
+			c.add(KindSchemaAdded, false, nil, n, "Schema %q added", n.Name())
+		},
+		func(o, n common.GolangType) {
+			c.compareTypes(fmt.Sprintf("Schema %q", n.Name()), o, n, o, n)
+		},
+	)
+
+	return c.changes
+}
+
+// HasBreaking returns true if any of changes is breaking.
+func HasBreaking(changes []Change) bool {
+	return lo.SomeBy(changes, func(c Change) bool { return c.Breaking })
+}
+
+type comparator struct {
+	changes []Change
+	// visited contains the pairs of old and new types that have been already compared. Prevents the infinite recursion
+	// on recursive types and the duplicated changes when the same type is reachable in several ways, e.g. from
+	// message payload and from components.
+	visited map[[2]common.GolangType]struct{}
+}
+
+func (c *comparator) add(kind Kind, breaking bool, oldObj, newObj common.Artifact, format string, args ...any) {
+	change := Change{Kind: kind, Breaking: breaking, Message: fmt.Sprintf(format, args...)}
+	if oldObj != nil {
+		change.OldPointer = oldObj.Pointer().String()
+	}
+	if newObj != nil {
+		change.NewPointer = newObj.Pointer().String()
+	}
+	if !lo.Contains(c.changes, change) {
+		c.changes = append(c.changes, change)
+	}
+}
+
+func (c *comparator) compareChannels(o, n *render.Channel) {
+	if o.Address != n.Address {
+		c.add(KindChannelAddressChanged, true, o, n, "Channel %q address changed from %q to %q", n.Name(), o.Address, n.Address)
+	}
+
+	oldParams, newParams := o.Parameters().Keys(), n.Parameters().Keys()
+	for _, p := range lo.Without(oldParams, newParams...) {
+		c.add(KindChannelParameterRemoved, true, o, n, "Channel %q parameter %q removed", n.Name(), p)
+	}
+	for _, p := range lo.Without(newParams, oldParams...) {
+		c.add(KindChannelParameterAdded, true, o, n, "Channel %q parameter %q added", n.Name(), p)
+	}
+
+	removed, added := diffNames(o.Messages(), n.Messages())
+	for _, m := range removed {
+		c.add(KindChannelMessageRemoved, true, o, n, "Message %q removed from channel %q", m, n.Name())
+	}
+	for _, m := range added {
+		c.add(KindChannelMessageAdded, false, o, n, "Message %q added to channel %q", m, n.Name())
+	}
+}
+
+func (c *comparator) compareOperations(o, n *render.Operation) {
+	oldAction, newAction := operationAction(o), operationAction(n)
+	if oldAction != newAction {
+		c.add(KindOperationActionChanged, true, o, n, "Operation %q action changed from %q to %q", n.Name(), oldAction, newAction)
+	}
+	if o.Channel().Name() != n.Channel().Name() {
+		c.add(
+			KindOperationChannelChanged, true, o, n,
+			"Operation %q channel changed from %q to %q", n.Name(), o.Channel().Name(), n.Channel().Name(),
+		)
+	}
+
+	removed, added := diffNames(o.BoundMessages(), n.BoundMessages())
+	for _, m := range removed {
+		c.add(KindOperationMessageRemoved, true, o, n, "Message %q removed from operation %q", m, n.Name())
+	}
+	for _, m := range added {
+		c.add(KindOperationMessageAdded, false, o, n, "Message %q added to operation %q", m, n.Name())
+	}
+
+	switch {
+	case o.OperationReply() != nil && n.OperationReply() == nil:
+		c.add(KindOperationReplyRemoved, true, o, n, "Operation %q reply removed", n.Name())
+	case o.OperationReply() == nil && n.OperationReply() != nil:
+		c.add(KindOperationReplyAdded, false, o, n, "Operation %q reply added", n.Name())
+	case o.OperationReply() != nil && n.OperationReply() != nil:
+		removed, added = diffNames(o.BoundReplyMessages(), n.BoundReplyMessages())
+		for _, m := range removed {
+			c.add(KindOperationMessageRemoved, true, o, n, "Message %q removed from operation %q reply", m, n.Name())
+		}
+		for _, m := range added {
+			c.add(KindOperationMessageAdded, false, o, n, "Message %q added to operation %q reply", m, n.Name())
+		}
+	}
+}
+
+func (c *comparator) compareMessages(o, n *render.Message) {
+	if o.EffectiveContentType() != n.EffectiveContentType() {
+		c.add(
+			KindMessageContentTypeChanged, true, o, n,
+			"Message %q content type changed from %q to %q", n.Name(), o.EffectiveContentType(), n.EffectiveContentType(),
+		)
+	}
+	c.compareTypes(fmt.Sprintf("Message %q payload", n.Name()), o.PayloadType(), n.PayloadType(), o, n)
+	c.compareTypes(fmt.Sprintf("Message %q headers", n.Name()), o.HeadersType(), n.HeadersType(), o, n)
+}
+
+// compareTypes compares two Go types recursively. The subject is the human-readable description of the place where
+// the types are located, e.g. `Message "foo" payload`. The oldObj and newObj are the closest objects that contain
+// the types, used to get the pointers of changes.
+func (c *comparator) compareTypes(subject string, oldType, newType common.GolangType, oldObj, newObj common.Artifact) {
+	oldType, oldConstraints := unwrapType(oldType)
+	newType, newConstraints := unwrapType(newType)
+	if oldType == nil || newType == nil {
+		return
+	}
+	key := [2]common.GolangType{oldType, newType}
+	if _, ok := c.visited[key]; ok {
+		return
+	}
+	c.visited[key] = struct{}{}
+
+	if oldEnum, newEnum := enumValues(oldConstraints), enumValues(newConstraints); len(oldEnum) > 0 || len(newEnum) > 0 {
+		switch {
+		case len(newEnum) == 0:
+			c.add(KindEnumWidened, false, oldObj, newObj, "%s: enum restriction removed", subject)
+		case len(oldEnum) == 0:
+			c.add(KindEnumNarrowed, true, oldObj, newObj, "%s: enum restriction added: %s", subject, strings.Join(newEnum, ", "))
+		default:
+			if removed := lo.Without(oldEnum, newEnum...); len(removed) > 0 {
+				c.add(KindEnumNarrowed, true, oldObj, newObj, "%s: enum values removed: %s", subject, strings.Join(removed, ", "))
+			}
+			if added := lo.Without(newEnum, oldEnum...); len(added) > 0 {
+				c.add(KindEnumWidened, false, oldObj, newObj, "%s: enum values added: %s", subject, strings.Join(added, ", "))
+			}
+		}
+	}
+
+	oldShape, newShape := typeShape(oldType), typeShape(newType)
+	if oldShape != newShape {
+		c.add(KindTypeChanged, true, oldObj, newObj, "%s: type changed from %s to %s", subject, oldShape, newShape)
+		return
+	}
+
+	switch o := oldType.(type) {
+	case *lang.GoArray:
+		n := newType.(*lang.GoArray)
+		c.compareTypes(subject+" items", o.ItemsType, n.ItemsType, oldObj, newObj)
+	case *lang.GoMap:
+		n := newType.(*lang.GoMap)
+		c.compareTypes(subject+" keys", o.KeyType, n.KeyType, oldObj, newObj)
+		c.compareTypes(subject+" values", o.ValueType, n.ValueType, oldObj, newObj)
+	case *lang.GoStruct:
+		n := newType.(*lang.GoStruct)
+		c.compareStructs(structSubject(subject, n), o, n)
+	case *lang.UnionStruct:
+		n := newType.(*lang.UnionStruct)
+		c.compareStructs(structSubject(subject, &n.GoStruct), &o.GoStruct, &n.GoStruct)
+	}
+}
+
+func (c *comparator) compareStructs(subject string, o, n *lang.GoStruct) {
+	oldFields := lo.SliceToMap(o.Fields, func(f lang.GoStructField) (string, lang.GoStructField) { return fieldKey(f), f })
+	newFields := lo.SliceToMap(n.Fields, func(f lang.GoStructField) (string, lang.GoStructField) { return fieldKey(f), f })
+
+	for _, f := range o.Fields {
+		if _, ok := newFields[fieldKey(f)]; !ok {
+			c.add(KindPropertyRemoved, true, o, n, "%s: property %q removed", subject, fieldKey(f))
+		}
+	}
+	for _, nf := range n.Fields {
+		of, ok := oldFields[fieldKey(nf)]
+		if !ok {
+			if nf.Required {
+				c.add(KindPropertyAdded, true, o, n, "%s: required property %q added", subject, fieldKey(nf))
+			} else {
+				c.add(KindPropertyAdded, false, o, n, "%s: optional property %q added", subject, fieldKey(nf))
+			}
+			continue
+		}
+		switch {
+		case !of.Required && nf.Required:
+			c.add(KindPropertyRequired, true, o, n, "%s: property %q became required", subject, fieldKey(nf))
+		case of.Required && !nf.Required:
+			c.add(KindPropertyOptional, true, o, n, "%s: property %q became optional", subject, fieldKey(nf))
+		}
+		c.compareTypes(fmt.Sprintf("%s: property %q", subject, fieldKey(nf)), of.Type, nf.Type, o, n)
+	}
+}
+
+// unwrapType dereferences the promises, pointers and type definitions, returning the underlying type and its
+// jsonschema constraints if any.
+func unwrapType(typ common.GolangType) (common.GolangType, *lang.SchemaConstraints) {
+	var constraints *lang.SchemaConstraints
+	for typ != nil {
+		switch v := typ.(type) {
+		case lang.GolangReferenceType:
+			typ = v.DerefGolangType()
+		case *lang.GoPointer:
+			typ = v.Type
+		case *lang.GoTypeDefinition:
+			if v.Constraints != nil {
+				constraints = v.Constraints
+			}
+			typ = v.RedefinedType
+		case *lang.GoStruct:
+			return v, lo.CoalesceOrEmpty(v.Constraints, constraints)
+		case *lang.GoArray:
+			return v, lo.CoalesceOrEmpty(v.Constraints, constraints)
+		case *lang.GoMap:
+			return v, lo.CoalesceOrEmpty(v.Constraints, constraints)
+		default:
+			return v, constraints
+		}
+	}
+	return nil, nil
+}
+
+// typeShape returns the short type description, that is used to detect the type changes. Only the top-level type
+// is described, the nested types are compared separately.
+func typeShape(typ common.GolangType) string {
+	switch v := typ.(type) {
+	case *lang.GoSimple:
+		if v.Import != "" {
+			return v.Import + "." + v.TypeName
+		}
+		return v.TypeName
+	case *lang.GoArray:
+		if v.Size > 0 {
+			return fmt.Sprintf("array[%d]", v.Size)
+		}
+		return "array"
+	case *lang.GoMap:
+		return "map"
+	case *lang.UnionStruct:
+		return "union"
+	case *lang.GoStruct:
+		if v.Import != "" {
+			return v.Import + "." + v.OriginalName
+		}
+		return "object"
+	}
+	return typ.String()
+}
+
+// structSubject returns the subject for the struct fields changes. Named schema may be reachable in several ways,
+// so the changes inside it are described by the schema name.
+func structSubject(subject string, s *lang.GoStruct) string {
+	if s.HasDefinition {
+		return fmt.Sprintf("Schema %q", s.Name())
+	}
+	return subject
+}
+
+func enumValues(c *lang.SchemaConstraints) []string {
+	if c == nil {
+		return nil
+	}
+	return lo.Map(c.Enum, func(item any, _ int) string { return fmt.Sprintf("%#v", item) })
+}
+
+func fieldKey(f lang.GoStructField) string {
+	res, _ := lo.Coalesce(f.MarshalName, f.OriginalName)
+	return res
+}
+
+func operationAction(o *render.Operation) string {
+	switch {
+	case o.IsPublisher:
+		return "send"
+	case o.IsSubscriber:
+		return "receive"
+	}
+	return ""
+}
+
+func diffNames[T common.Artifact](oldItems, newItems []T) (removed, added []string) {
+	oldNames := lo.Uniq(lo.Map(oldItems, func(item T, _ int) string { return item.Name() }))
+	newNames := lo.Uniq(lo.Map(newItems, func(item T, _ int) string { return item.Name() }))
+	return lo.Without(oldNames, newNames...), lo.Without(newNames, oldNames...)
+}
+
+// compareByName matches the old and new objects by names and calls the appropriate callback for every removed,
+// added or existing in both lists object.
+func compareByName[T common.Artifact](oldItems, newItems []T, removedCb, addedCb func(T), bothCb func(o, n T)) {
+	oldByName := lo.SliceToMap(oldItems, func(item T) (string, T) { return item.Name(), item })
+	newByName := lo.SliceToMap(newItems, func(item T) (string, T) { return item.Name(), item })
+	for _, o := range oldItems {
+		if n, ok := newByName[o.Name()]; ok {
+			bothCb(o, n)
+		} else {
+			removedCb(o)
+		}
+	}
+	for _, n := range newItems {
+		if _, ok := oldByName[n.Name()]; !ok {
+			addedCb(n)
+		}
+	}
+}
+
+// schemaArtifacts returns the Go types that are rendered as definitions, i.e. the named schemas.
+func schemaArtifacts[S common.ArtifactStorage](documents map[string]S) []common.GolangType {
+	return lo.Filter(common.VisibleArtifacts[common.GolangType](documents), func(item common.GolangType, _ int) bool {
+		_, isRef := item.(lang.GolangReferenceType)
+		return !isRef && item.Kind() == common.ArtifactKindSchema && item.Selectable()
+	})
+}
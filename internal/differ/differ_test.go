@@ -0,0 +1,203 @@
+package differ_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bdragon300/go-asyncapi/internal/compiler"
+	"github.com/bdragon300/go-asyncapi/internal/compiler/compile"
+	"github.com/bdragon300/go-asyncapi/internal/differ"
+	"github.com/bdragon300/go-asyncapi/internal/jsonpointer"
+	"github.com/bdragon300/go-asyncapi/internal/pipeline"
+)
+
+// documentTemplate is the document, which parts are changed by test cases. The placeholders are the channel address,
+// the operation action and the payload schema.
+const documentTemplate = `asyncapi: 3.0.0
+info:
+  title: Lights
+  version: 1.0.0
+servers:
+  production:
+    host: localhost:9092
+    protocol: kafka
+channels:
+  lights:
+    address: %s
+    messages:
+      lightMeasured:
+        $ref: '#/components/messages/lightMeasured'
+operations:
+  measureLight:
+    action: %s
+    channel:
+      $ref: '#/channels/lights'
+components:
+  messages:
+    lightMeasured:
+      payload:
+        $ref: '#/components/schemas/lightMeasuredPayload'
+  schemas:
+    lightMeasuredPayload:
+%s`
+
+type document struct {
+	address string
+	action  string
+	schema  string
+}
+
+func (d document) String() string {
+	var schema strings.Builder
+	for _, line := range strings.Split(strings.TrimSpace(d.schema), "\n") {
+		schema.WriteString("      " + line + "\n")
+	}
+	return fmt.Sprintf(documentTemplate, d.address, d.action, schema.String())
+}
+
+const baseSchema = `
+type: object
+required: [id]
+properties:
+  id:
+    type: integer
+  mode:
+    type: string
+    enum: [auto, manual, off]
+  lumens:
+    type: integer
+`
+
+var baseDocument = document{address: "lights.measured", action: "send", schema: baseSchema}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		new  document
+		want []string
+	}{
+		{
+			name: "no changes",
+			new:  baseDocument,
+			want: nil,
+		},
+		{
+			name: "enum narrowed",
+			new:  withSchema(strings.Replace(baseSchema, "[auto, manual, off]", "[auto, manual]", 1)),
+			want: []string{`breaking enum-narrowed: Schema "LightMeasuredPayload": property "mode": enum values removed: "off"`},
+		},
+		{
+			name: "enum widened",
+			new:  withSchema(strings.Replace(baseSchema, "[auto, manual, off]", "[auto, manual, off, eco]", 1)),
+			want: []string{`non-breaking enum-widened: Schema "LightMeasuredPayload": property "mode": enum values added: "eco"`},
+		},
+		{
+			name: "enum restriction added",
+			new:  withSchema(strings.Replace(baseSchema, "type: integer\n", "type: integer\n    enum: [1, 2]\n", 1)),
+			want: []string{`breaking enum-narrowed: Schema "LightMeasuredPayload": property "id": enum restriction added: 1, 2`},
+		},
+		{
+			name: "enum restriction removed",
+			new:  withSchema(strings.Replace(baseSchema, "    enum: [auto, manual, off]\n", "", 1)),
+			want: []string{`non-breaking enum-widened: Schema "LightMeasuredPayload": property "mode": enum restriction removed`},
+		},
+		{
+			name: "required property added",
+			new: withSchema(strings.Replace(baseSchema, "required: [id]", "required: [id, sensor]", 1) +
+				"  sensor:\n    type: string\n"),
+			want: []string{`breaking property-added: Schema "LightMeasuredPayload": required property "sensor" added`},
+		},
+		{
+			name: "optional property added",
+			new:  withSchema(baseSchema + "  sensor:\n    type: string\n"),
+			want: []string{`non-breaking property-added: Schema "LightMeasuredPayload": optional property "sensor" added`},
+		},
+		{
+			name: "property became required",
+			new:  withSchema(strings.Replace(baseSchema, "required: [id]", "required: [id, lumens]", 1)),
+			want: []string{`breaking property-required: Schema "LightMeasuredPayload": property "lumens" became required`},
+		},
+		{
+			name: "property removed",
+			new:  withSchema(strings.Replace(baseSchema, "  lumens:\n    type: integer\n", "", 1)),
+			want: []string{`breaking property-removed: Schema "LightMeasuredPayload": property "lumens" removed`},
+		},
+		{
+			name: "type changed",
+			new:  withSchema(strings.Replace(baseSchema, "  lumens:\n    type: integer\n", "  lumens:\n    type: string\n", 1)),
+			want: []string{`breaking type-changed: Schema "LightMeasuredPayload": property "lumens": type changed from int to string`},
+		},
+		{
+			name: "address changed",
+			new:  document{address: "lights.measured.v2", action: baseDocument.action, schema: baseSchema},
+			want: []string{`breaking channel-address-changed: Channel "lights" address changed from "lights.measured" to "lights.measured.v2"`},
+		},
+		{
+			name: "action changed",
+			new:  document{address: baseDocument.address, action: "receive", schema: baseSchema},
+			want: []string{`breaking operation-action-changed: Operation "measureLight" action changed from "send" to "receive"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldDocuments := compileDocument(t, baseDocument.String())
+			newDocuments := compileDocument(t, tt.new.String())
+
+			changes := differ.Compare(oldDocuments, newDocuments)
+			var got []string
+			for _, c := range changes {
+				got = append(got, fmt.Sprintf("%s %s: %s", c.Severity(), c.Kind, c.Message))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Compare() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+			if differ.HasBreaking(changes) != strings.HasPrefix(strings.Join(tt.want, ""), "breaking") {
+				t.Errorf("HasBreaking() = %v for %v", differ.HasBreaking(changes), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	changes := []differ.Change{
+		{Kind: differ.KindChannelRemoved, Breaking: true, Message: `Channel "a" removed`, OldPointer: "old.yaml#/channels/a"},
+		{Kind: differ.KindChannelAdded, Message: `Channel "b" added`, NewPointer: "new.yaml#/channels/b"},
+	}
+	var buf bytes.Buffer
+	if err := differ.WriteText(&buf, changes); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	want := `breaking: old.yaml#/channels/a: Channel "a" removed [channel-removed]` + "\n" +
+		`non-breaking: new.yaml#/channels/b: Channel "b" added [channel-added]` + "\n" +
+		"1 breaking change(s), 1 non-breaking change(s)\n"
+	if buf.String() != want {
+		t.Errorf("WriteText() = %q, want %q", buf.String(), want)
+	}
+}
+
+func withSchema(schema string) document {
+	return document{address: baseDocument.address, action: baseDocument.action, schema: schema}
+}
+
+func compileDocument(t *testing.T, contents string) map[string]*compiler.Document {
+	t.Helper()
+	fileName := filepath.Join(t.TempDir(), "asyncapi.yaml")
+	if err := os.WriteFile(fileName, []byte(contents), 0o644); err != nil {
+		t.Fatalf("write document: %v", err)
+	}
+	docURL, err := jsonpointer.Parse(fileName)
+	if err != nil {
+		t.Fatalf("parse URL: %v", err)
+	}
+	compileOpts := compile.CompilationOpts{GeneratePublishers: true, GenerateSubscribers: true}
+	documents, err := pipeline.CompileAndLink(pipeline.NewLocator(pipeline.ConfigLocator{}), docURL, compileOpts)
+	if err != nil {
+		t.Fatalf("CompileAndLink: %v", err)
+	}
+	return documents
+}
@@ -0,0 +1,28 @@
+package differ
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/samber/lo"
+)
+
+// WriteText writes the changes in human-readable form, one per line, followed by a summary.
+func WriteText(w io.Writer, changes []Change) error {
+	for _, c := range changes {
+		if _, err := fmt.Fprintln(w, c.String()); err != nil {
+			return err
+		}
+	}
+	breakingCount := lo.CountBy(changes, func(c Change) bool { return c.Breaking })
+	_, err := fmt.Fprintf(w, "%d breaking change(s), %d non-breaking change(s)\n", breakingCount, len(changes)-breakingCount)
+	return err
+}
+
+// WriteJSON writes the changes as JSON array.
+func WriteJSON(w io.Writer, changes []Change) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(lo.Ternary(changes == nil, []Change{}, changes))
+}
@@ -161,6 +161,10 @@ func resolvePromise(p common.ObjectPromise, docLocation string, sources map[stri
 		cb = func(item common.Artifact) bool { return ref.MatchPointer(item.Pointer().Pointer) }
 	}
 	found := lo.Filter(srcArtifacts, func(obj common.Artifact, _ int) bool { return cb(obj) })
+	if len(found) == 0 {
+		// Ref points to nowhere, leave it unresolved
+		return nil, false
+	}
 	if len(found) != 1 {
 		panic(fmt.Sprintf("Ref %q must point to one object, but %d objects found", p.Ref(), len(found)))
 	}
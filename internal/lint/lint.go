@@ -0,0 +1,299 @@
+// Package lint contains the structural checks of the compiled and linked documents.
+//
+// The checks look for the mistakes that are not errors from the AsyncAPI specification point of view, but typically
+// lead to incorrect or incomplete generated code, such as operations bound to channels without messages, server
+// variables without default values, messages with content type that has no encoder, unused components, etc.
+//
+// Every check produces a list of [Finding], that points to the problem location in the document by JSON Pointer.
+package lint
+
+import (
+	"fmt"
+	"maps"
+	"mime"
+	"slices"
+	"strings"
+
+	"github.com/bdragon300/go-asyncapi/internal/common"
+	"github.com/bdragon300/go-asyncapi/internal/jsonpointer"
+	"github.com/bdragon300/go-asyncapi/internal/render"
+	"github.com/samber/lo"
+)
+
+// Severity is a finding severity.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Rule is a check identifier.
+type Rule string
+
+const (
+	RuleCompilation                   Rule = "compilation"
+	RuleUnresolvedRef                 Rule = "unresolved-ref"
+	RuleNoServers                     Rule = "no-servers"
+	RuleDuplicateNames                Rule = "duplicate-names"
+	RuleOperationForeignMessages      Rule = "operation-foreign-messages"
+	RuleOperationReplyForeignMessages Rule = "operation-reply-foreign-messages"
+	RuleOperationChannelNoMessages    Rule = "operation-channel-no-messages"
+	RuleServerVariableNoDefault       Rule = "server-variable-no-default"
+	RuleContentTypeNoEncoder          Rule = "content-type-no-encoder"
+	RuleUnusedComponent               Rule = "unused-component"
+)
+
+// RuleDescriptions contains the short descriptions of all rules.
+var RuleDescriptions = map[Rule]string{
+	RuleCompilation:                   "Document cannot be compiled",
+	RuleUnresolvedRef:                 "$ref cannot be resolved",
+	RuleNoServers:                     "No active servers defined",
+	RuleDuplicateNames:                "Servers, channels or operations have the same names",
+	RuleOperationForeignMessages:      "Operation contains messages that are not listed in its channel",
+	RuleOperationReplyForeignMessages: "Operation reply contains messages that are not listed in its channel",
+	RuleOperationChannelNoMessages:    "Operation is bound to a channel without messages",
+	RuleServerVariableNoDefault:       "Server variable has no default value",
+	RuleContentTypeNoEncoder:          "Message content type has no encoder/decoder template",
+	RuleUnusedComponent:               "Component is not referenced anywhere",
+}
+
+// Finding is a single problem found in the document.
+type Finding struct {
+	Rule     Rule     `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	// Pointer is the JSON Pointer to the problem location, e.g. "asyncapi.yaml#/channels/foo".
+	Pointer string `json:"pointer"`
+	// Line is the line number of the problem location in the document file. Zero if unknown.
+	Line int `json:"line,omitempty"`
+	// Column is the column number of the problem location in the document file. Zero if unknown.
+	Column int `json:"column,omitempty"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s: %s [%s]", f.Severity, f.Pointer, f.Message, f.Rule)
+}
+
+// Options are the options for [Check].
+type Options struct {
+	// RootDocument is the location of the root document. Unused components are reported only for this document.
+	RootDocument string
+	// HasTemplate returns true if the template with the given name exists. If nil, the content type check is skipped.
+	HasTemplate func(name string) bool
+}
+
+type artifactStorage interface {
+	Artifacts() []common.Artifact
+	Promises() []common.ObjectPromise
+}
+
+// UnresolvedRefs returns the findings for $refs that the linker could not resolve.
+func UnresolvedRefs[T artifactStorage](documents map[string]T) (res []Finding) {
+	for _, docURL := range slices.Sorted(maps.Keys(documents)) {
+		for _, p := range documents[docURL].Promises() {
+			if p.Origin() != common.PromiseOriginRef || p.Assigned() {
+				continue
+			}
+			pointer := docURL
+			if a, ok := p.(common.Artifact); ok {
+				pointer = a.Pointer().String()
+			}
+			res = append(res, Finding{
+				Rule:     RuleUnresolvedRef,
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("$ref %q cannot be resolved", p.Ref()),
+				Pointer:  pointer,
+			})
+		}
+	}
+	return
+}
+
+// Check runs all checks on the compiled and linked documents. Documents must be linked successfully.
+func Check[T artifactStorage](documents map[string]T, opts Options) []Finding {
+	var res []Finding
+	res = append(res, UnresolvedRefs(documents)...)
+	res = append(res, CheckServers(documents, opts.RootDocument)...)
+	res = append(res, CheckNames(documents)...)
+	res = append(res, CheckOperations(documents)...)
+	if opts.HasTemplate != nil {
+		res = append(res, checkContentTypes(documents, opts.HasTemplate)...)
+	}
+	if doc, ok := documents[opts.RootDocument]; ok {
+		res = append(res, checkUnusedComponents(doc, documents)...)
+	}
+	return res
+}
+
+// CheckServers checks the active servers and their variables. The missing servers are reported in rootDocument.
+func CheckServers[T artifactStorage](documents map[string]T, rootDocument string) (res []Finding) {
+	// No active servers. This means no implementations, no protocol-specific channels/operations code, etc.
+	if len(common.VisibleArtifacts[*render.Server](documents)) == 0 {
+		res = append(res, Finding{
+			Rule:     RuleNoServers,
+			Severity: SeverityWarning,
+			Message:  "No active servers defined in root 'servers:' section. The generated code may lack of libraries and most of functionality",
+			Pointer:  rootDocument + "#/servers",
+		})
+	}
+
+	for _, v := range common.VisibleArtifacts[*render.ServerVariable](documents) {
+		if v.Default == "" {
+			res = append(res, Finding{
+				Rule:     RuleServerVariableNoDefault,
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("Server variable %q has no default value", v.Name()),
+				Pointer:  v.Pointer().String(),
+			})
+		}
+	}
+	return
+}
+
+// CheckNames checks that servers, channels and operations have the unique names, since they share the same
+// namespace in the generated code.
+func CheckNames[T artifactStorage](documents map[string]T) (res []Finding) {
+	artifacts := lo.Flatten([][]common.Artifact{
+		lo.Map(common.VisibleArtifacts[*render.Server](documents), func(v *render.Server, _ int) common.Artifact { return v }),
+		lo.Map(common.VisibleArtifacts[*render.Channel](documents), func(v *render.Channel, _ int) common.Artifact { return v }),
+		lo.Map(common.VisibleArtifacts[*render.Operation](documents), func(v *render.Operation, _ int) common.Artifact { return v }),
+	})
+	duplications := lo.FindDuplicates(lo.Map(artifacts, func(item common.Artifact, _ int) string { return item.Name() }))
+	for _, a := range artifacts {
+		if lo.Contains(duplications, a.Name()) {
+			res = append(res, Finding{
+				Rule:     RuleDuplicateNames,
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("Name %q is shared with another server, channel or operation. The generated code may contain errors", a.Name()),
+				Pointer:  a.Pointer().String(),
+			})
+		}
+	}
+	return
+}
+
+// CheckOperations checks the consistency of operations, their channels and messages.
+func CheckOperations[T artifactStorage](documents map[string]T) (res []Finding) {
+	for _, op := range common.VisibleArtifacts[*render.Operation](documents) {
+		ch := op.Channel()
+		if !ch.Visible() {
+			continue
+		}
+		if len(ch.Messages()) == 0 {
+			res = append(res, Finding{
+				Rule:     RuleOperationChannelNoMessages,
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("Operation %q is bound to channel %q that has no messages", op.Name(), ch.Name()),
+				Pointer:  op.Pointer().String(),
+			})
+		}
+		// Messages in operation is not a subset of channel messages
+		if !lo.Every(ch.Messages(), op.Messages()) {
+			res = append(res, Finding{
+				Rule:     RuleOperationForeignMessages,
+				Severity: SeverityWarning,
+				Message: fmt.Sprintf(
+					"Operation %q contains messages that are not listed in channel %q. The generated code may contain errors",
+					op.Name(), ch.Pointer(),
+				),
+				Pointer: op.Pointer().String(),
+			})
+		}
+
+		// Messages in operation reply is not a subset of its channel messages
+		reply := op.OperationReply()
+		if reply == nil {
+			continue
+		}
+		if reply.Channel() != nil {
+			ch = reply.Channel()
+		}
+		if !lo.Every(ch.Messages(), reply.Messages()) {
+			res = append(res, Finding{
+				Rule:     RuleOperationReplyForeignMessages,
+				Severity: SeverityWarning,
+				Message: fmt.Sprintf(
+					"Operation reply contains messages that are not listed in channel %q. The generated code may contain errors",
+					ch.Pointer(),
+				),
+				Pointer: reply.Pointer().String(),
+			})
+		}
+	}
+	return
+}
+
+// checkContentTypes checks that every message content type has the encoder and decoder templates. Otherwise, the
+// message is encoded by default JSON encoder, that is probably not what user expects.
+func checkContentTypes[T artifactStorage](documents map[string]T, hasTemplate func(name string) bool) (res []Finding) {
+	for _, msg := range common.VisibleArtifacts[*render.Message](documents) {
+		contentType := msg.EffectiveContentType()
+		if mediaType, _, err := mime.ParseMediaType(contentType); err == nil &&
+			(mediaType == render.DefaultContentType || strings.HasSuffix(mediaType, "+json")) {
+			continue // The default encoder is JSON
+		}
+		missing := lo.Filter([]string{"messageEncoder", "messageDecoder"}, func(item string, _ int) bool {
+			return !hasTemplate("code/proto/mime/" + item + "/" + contentType)
+		})
+		if len(missing) > 0 {
+			res = append(res, Finding{
+				Rule:     RuleContentTypeNoEncoder,
+				Severity: SeverityWarning,
+				Message: fmt.Sprintf(
+					"Message %q has content type %q, that has no %s templates. The default JSON encoding is used instead",
+					msg.Name(), contentType, strings.Join(missing, ", "),
+				),
+				Pointer: msg.Pointer().String(),
+			})
+		}
+	}
+	return
+}
+
+// checkUnusedComponents checks that every component in the document's `components` section is referenced by $ref
+// from any document.
+func checkUnusedComponents[T artifactStorage](doc T, documents map[string]T) (res []Finding) {
+	referenced := make(map[string]struct{})
+	for docURL, d := range documents {
+		for _, p := range d.Promises() {
+			if p.Origin() != common.PromiseOriginRef {
+				continue
+			}
+			ref, err := jsonpointer.Parse(p.Ref())
+			if err != nil {
+				continue
+			}
+			location := lo.Ternary(ref.Location() != "", ref.Location(), docURL)
+			referenced[location+ref.PointerString()] = struct{}{}
+		}
+	}
+
+	var reported []string
+	for _, a := range doc.Artifacts() {
+		ptr := a.Pointer()
+		if len(ptr.Pointer) != 3 || ptr.Pointer[0] != "components" {
+			continue // Not a component
+		}
+		if _, ok := a.(common.ObjectPromise); ok || !a.Visible() {
+			continue
+		}
+		key := ptr.Location() + ptr.PointerString()
+		if _, ok := referenced[key]; ok || lo.Contains(reported, key) {
+			continue
+		}
+		reported = append(reported, key)
+		res = append(res, Finding{
+			Rule:     RuleUnusedComponent,
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("Component %q in %q section is not referenced anywhere", ptr.Pointer[2], ptr.Pointer[1]),
+			Pointer:  ptr.String(),
+		})
+	}
+	return
+}
+
+// HasErrors returns true if any of findings has the error severity.
+func HasErrors(findings []Finding) bool {
+	return lo.SomeBy(findings, func(f Finding) bool { return f.Severity == SeverityError })
+}
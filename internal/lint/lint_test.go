@@ -0,0 +1,358 @@
+package lint_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/bdragon300/go-asyncapi/internal/compiler"
+	"github.com/bdragon300/go-asyncapi/internal/compiler/compile"
+	"github.com/bdragon300/go-asyncapi/internal/jsonpointer"
+	"github.com/bdragon300/go-asyncapi/internal/linker"
+	"github.com/bdragon300/go-asyncapi/internal/lint"
+	"github.com/bdragon300/go-asyncapi/internal/pipeline"
+	"github.com/samber/lo"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		file string
+		want []string
+	}{
+		{
+			file: "valid.yaml",
+			want: nil,
+		},
+		{
+			file: "no-servers.yaml",
+			want: []string{"no-servers no-servers.yaml#/servers"},
+		},
+		{
+			file: "servers.yaml",
+			want: []string{"server-variable-no-default servers.yaml#/servers/production/variables/port"},
+		},
+		{
+			file: "names.yaml",
+			want: []string{
+				"duplicate-names names.yaml#/servers/lights",
+				"duplicate-names names.yaml#/channels/lights",
+			},
+		},
+		{
+			file: "operations.yaml",
+			want: []string{
+				"operation-reply-foreign-messages operations.yaml#/operations/requestForeignReply/reply",
+				"operation-channel-no-messages operations.yaml#/operations/sendEmpty",
+				"operation-foreign-messages operations.yaml#/operations/sendForeign",
+			},
+		},
+		{
+			file: "content-types.yaml",
+			want: []string{
+				"content-type-no-encoder content-types.yaml#/channels/lights/messages/xml",
+				"content-type-no-encoder content-types.yaml#/channels/lights/messages/yaml",
+			},
+		},
+		{
+			// Unused components of documents other than root are not reported
+			file: "unused.yaml",
+			want: []string{"unused-component unused.yaml#/components/messages/lightOff"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			documents, err := compileDocument(t, tt.file)
+			if err != nil {
+				t.Fatalf("compile: %v", err)
+			}
+			findings := lint.Check(documents, lint.Options{
+				RootDocument: tt.file,
+				HasTemplate:  func(string) bool { return false },
+			})
+			if got := findingKeys(findings); !slices.Equal(got, tt.want) {
+				t.Errorf("Check() = %v, want %v", got, tt.want)
+			}
+			if lint.HasErrors(findings) {
+				t.Errorf("HasErrors() = true, want false")
+			}
+		})
+	}
+}
+
+func TestCheckContentTypes(t *testing.T) {
+	tests := []struct {
+		name        string
+		hasTemplate func(name string) bool
+		want        []string
+	}{
+		{
+			name:        "no template loader",
+			hasTemplate: nil,
+			want:        nil,
+		},
+		{
+			name:        "all templates exist",
+			hasTemplate: func(string) bool { return true },
+			want:        nil,
+		},
+		{
+			name: "decoder is missing",
+			hasTemplate: func(name string) bool {
+				return name == "code/proto/mime/messageEncoder/application/xml" ||
+					name == "code/proto/mime/messageEncoder/application/yaml" ||
+					name == "code/proto/mime/messageDecoder/application/yaml"
+			},
+			want: []string{`Message "xml" has content type "application/xml", that has no messageDecoder templates. The default JSON encoding is used instead`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			documents, err := compileDocument(t, "content-types.yaml")
+			if err != nil {
+				t.Fatalf("compile: %v", err)
+			}
+			findings := lint.Check(documents, lint.Options{RootDocument: "content-types.yaml", HasTemplate: tt.hasTemplate})
+			got := lo.Map(findings, func(f lint.Finding, _ int) string { return f.Message })
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Check() messages = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnresolvedRefs(t *testing.T) {
+	documents, err := compileDocument(t, "unresolved.yaml")
+	if err == nil {
+		t.Fatal("linking error expected, got nil")
+	}
+	findings := lint.UnresolvedRefs(documents)
+	want := []string{"unresolved-ref unresolved.yaml#/channels/lights/messages/lightMeasured"}
+	if got := findingKeys(findings); !slices.Equal(got, want) {
+		t.Errorf("UnresolvedRefs() = %v, want %v", got, want)
+	}
+	if !lint.HasErrors(findings) {
+		t.Errorf("HasErrors() = false, want true")
+	}
+}
+
+func TestResolvePositions(t *testing.T) {
+	const document = `asyncapi: 3.0.0
+channels:
+  lights:
+    address: lights
+operations:
+  send:
+    messages:
+      - $ref: '#/a'
+      - $ref: '#/b'
+`
+	readDocument := func(location string) ([]byte, error) {
+		if location == "asyncapi.yaml" {
+			return []byte(document), nil
+		}
+		return nil, errors.New("not found")
+	}
+
+	tests := []struct {
+		pointer    string
+		wantLine   int
+		wantColumn int
+	}{
+		{"asyncapi.yaml#/channels/lights", 3, 3},
+		{"asyncapi.yaml#/channels/lights/address", 4, 5},
+		{"asyncapi.yaml#/operations/send/messages/1", 9, 9},
+		// Position of the deepest existing node
+		{"asyncapi.yaml#/channels/unknown", 2, 1},
+		{"asyncapi.yaml#/operations/send/messages/5", 7, 5},
+		// Pointer without location belongs to the root document
+		{"#/operations/send", 6, 3},
+		{"other.yaml#/channels/lights", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pointer, func(t *testing.T) {
+			findings := []lint.Finding{{Pointer: tt.pointer}}
+			lint.ResolvePositions(findings, "asyncapi.yaml", readDocument)
+			if findings[0].Line != tt.wantLine || findings[0].Column != tt.wantColumn {
+				t.Errorf(
+					"ResolvePositions() = %d:%d, want %d:%d",
+					findings[0].Line, findings[0].Column, tt.wantLine, tt.wantColumn,
+				)
+			}
+		})
+	}
+}
+
+var reportFindings = []lint.Finding{
+	{
+		Rule:     lint.RuleUnresolvedRef,
+		Severity: lint.SeverityError,
+		Message:  "$ref cannot be resolved",
+		Pointer:  "common.yaml#/components/schemas/a",
+		Line:     10,
+		Column:   5,
+	},
+	{
+		Rule:     lint.RuleNoServers,
+		Severity: lint.SeverityWarning,
+		Message:  "No servers",
+		Pointer:  "#/servers",
+	},
+	{
+		Rule:     lint.RuleUnresolvedRef,
+		Severity: lint.SeverityError,
+		Message:  "$ref cannot be resolved",
+		Pointer:  "#/channels/b",
+	},
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := lint.WriteText(&buf, reportFindings); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	want := "error: common.yaml#/components/schemas/a (line 10): $ref cannot be resolved [unresolved-ref]\n" +
+		"warning: #/servers: No servers [no-servers]\n" +
+		"error: #/channels/b: $ref cannot be resolved [unresolved-ref]\n" +
+		"2 error(s), 1 warning(s)\n"
+	if buf.String() != want {
+		t.Errorf("WriteText() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		findings []lint.Finding
+		want     []lint.Finding
+	}{
+		{"findings", reportFindings, reportFindings},
+		{"no findings", nil, []lint.Finding{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := lint.WriteJSON(&buf, tt.findings); err != nil {
+				t.Fatalf("WriteJSON() error = %v", err)
+			}
+			var got []lint.Finding
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if got == nil || !slices.Equal(got, tt.want) {
+				t.Errorf("WriteJSON() = %s, want %v", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := lint.WriteSARIF(&buf, reportFindings, "asyncapi.yaml", "v1.0.0"); err != nil {
+		t.Fatalf("WriteSARIF() error = %v", err)
+	}
+
+	type sarifRule struct {
+		ID               string `json:"id"`
+		ShortDescription struct {
+			Text string `json:"text"`
+		} `json:"shortDescription"`
+	}
+	var report struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Version string      `json:"version"`
+					Rules   []sarifRule `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region *struct {
+							StartLine   int `json:"startLine"`
+							StartColumn int `json:"startColumn"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+					LogicalLocations []struct {
+						FullyQualifiedName string `json:"fullyQualifiedName"`
+					} `json:"logicalLocations"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if report.Version != "2.1.0" || len(report.Runs) != 1 {
+		t.Fatalf("unexpected report: %s", buf.String())
+	}
+	run := report.Runs[0]
+	if run.Tool.Driver.Version != "v1.0.0" {
+		t.Errorf("driver version = %q, want %q", run.Tool.Driver.Version, "v1.0.0")
+	}
+
+	// Every rule is listed once
+	rules := lo.Map(run.Tool.Driver.Rules, func(r sarifRule, _ int) string {
+		if r.ShortDescription.Text != lint.RuleDescriptions[lint.Rule(r.ID)] {
+			t.Errorf("rule %s description = %q, want %q", r.ID, r.ShortDescription.Text, lint.RuleDescriptions[lint.Rule(r.ID)])
+		}
+		return r.ID
+	})
+	if want := []string{"unresolved-ref", "no-servers"}; !slices.Equal(rules, want) {
+		t.Errorf("rules = %v, want %v", rules, want)
+	}
+
+	if len(run.Results) != len(reportFindings) {
+		t.Fatalf("results count = %d, want %d", len(run.Results), len(reportFindings))
+	}
+	wantLocations := []string{
+		"common.yaml #/components/schemas/a 10:5",
+		"asyncapi.yaml #/servers",
+		"asyncapi.yaml #/channels/b",
+	}
+	for i, res := range run.Results {
+		if res.RuleID != string(reportFindings[i].Rule) || res.Level != string(reportFindings[i].Severity) {
+			t.Errorf("result %d = %s/%s, want %s/%s", i, res.RuleID, res.Level, reportFindings[i].Rule, reportFindings[i].Severity)
+		}
+		loc := res.Locations[0]
+		got := loc.PhysicalLocation.ArtifactLocation.URI + " " + loc.LogicalLocations[0].FullyQualifiedName
+		if r := loc.PhysicalLocation.Region; r != nil {
+			got += fmt.Sprintf(" %d:%d", r.StartLine, r.StartColumn)
+		}
+		if got != wantLocations[i] {
+			t.Errorf("result %d location = %q, want %q", i, got, wantLocations[i])
+		}
+	}
+}
+
+// compileDocument compiles and links the document from testdata directory.
+func compileDocument(t *testing.T, fileName string) (map[string]*compiler.Document, error) {
+	t.Helper()
+	// Relative $refs to other documents are resolved against the working directory
+	t.Chdir("testdata")
+
+	docURL, err := jsonpointer.Parse(fileName)
+	if err != nil {
+		t.Fatalf("parse URL: %v", err)
+	}
+	compileOpts := compile.CompilationOpts{GeneratePublishers: true, GenerateSubscribers: true}
+	documents, err := pipeline.Compile(docURL, compile.NewCompileContext(compileOpts), pipeline.NewLocator(pipeline.ConfigLocator{}))
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	objSources := lo.MapValues(documents, func(value *compiler.Document, _ string) linker.ObjectSource { return value })
+	return documents, pipeline.Link(objSources)
+}
+
+func findingKeys(findings []lint.Finding) []string {
+	return lo.Map(findings, func(f lint.Finding, _ int) string { return string(f.Rule) + " " + f.Pointer })
+}
@@ -0,0 +1,181 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/bdragon300/go-asyncapi/internal/jsonpointer"
+	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
+)
+
+// ResolvePositions fills the line and column numbers of findings, looking up their JSON Pointers in the document
+// contents. YAML and JSON documents are supported. The readDocument function returns the document contents by
+// location. Findings without location in Pointer are considered to belong to the rootDocument.
+func ResolvePositions(findings []Finding, rootDocument string, readDocument func(location string) ([]byte, error)) {
+	nodes := make(map[string]*yaml.Node)
+	for i, f := range findings {
+		ptr, err := jsonpointer.Parse(f.Pointer)
+		if err != nil {
+			continue
+		}
+		location := lo.Ternary(ptr.Location() != "", ptr.Location(), rootDocument)
+		node, ok := nodes[location]
+		if !ok {
+			if data, err := readDocument(location); err == nil {
+				node = new(yaml.Node)
+				if err = yaml.Unmarshal(data, node); err != nil {
+					node = nil
+				}
+			}
+			nodes[location] = node
+		}
+		if node != nil {
+			findings[i].Line, findings[i].Column = findNodePosition(node, ptr.Pointer)
+		}
+	}
+}
+
+// findNodePosition returns the position of the node by the pointer. If the pointer cannot be followed to the end, returns
+// the position of the deepest found node. For mapping values the position of the key is returned.
+func findNodePosition(node *yaml.Node, pointer []string) (line, column int) {
+	line, column = node.Line, node.Column
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	for _, part := range pointer {
+		switch node.Kind {
+		case yaml.MappingNode:
+			found := false
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == part {
+					line, column = node.Content[i].Line, node.Content[i].Column
+					node = node.Content[i+1]
+					found = true
+					break
+				}
+			}
+			if !found {
+				return
+			}
+		case yaml.SequenceNode:
+			idx, err := strconv.Atoi(part)
+			if err != nil || idx < 0 || idx >= len(node.Content) {
+				return
+			}
+			node = node.Content[idx]
+			line, column = node.Line, node.Column
+		default:
+			return
+		}
+	}
+	return
+}
+
+// WriteText writes the findings in human-readable form, one per line, followed by a summary.
+func WriteText(w io.Writer, findings []Finding) error {
+	for _, f := range findings {
+		pos := f.Pointer
+		if f.Line > 0 {
+			pos += fmt.Sprintf(" (line %d)", f.Line)
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s: %s [%s]\n", f.Severity, pos, f.Message, f.Rule); err != nil {
+			return err
+		}
+	}
+	errorsCount := lo.CountBy(findings, func(f Finding) bool { return f.Severity == SeverityError })
+	_, err := fmt.Fprintf(w, "%d error(s), %d warning(s)\n", errorsCount, len(findings)-errorsCount)
+	return err
+}
+
+// WriteJSON writes the findings as JSON array.
+func WriteJSON(w io.Writer, findings []Finding) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(lo.Ternary(findings == nil, []Finding{}, findings))
+}
+
+// WriteSARIF writes the findings in [SARIF] 2.1.0 format, which is supported by code scanning tools, such as
+// GitHub code scanning. Findings without location in Pointer are considered to belong to the rootDocument.
+//
+// [SARIF]: https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+func WriteSARIF(w io.Writer, findings []Finding, rootDocument, toolVersion string) error {
+	type message struct {
+		Text string `json:"text"`
+	}
+	type region struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn,omitempty"`
+	}
+	type physicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region *region `json:"region,omitempty"`
+	}
+	type logicalLocation struct {
+		FullyQualifiedName string `json:"fullyQualifiedName"`
+	}
+	type location struct {
+		PhysicalLocation physicalLocation  `json:"physicalLocation"`
+		LogicalLocations []logicalLocation `json:"logicalLocations"`
+	}
+	type result struct {
+		RuleID    string     `json:"ruleId"`
+		Level     string     `json:"level"`
+		Message   message    `json:"message"`
+		Locations []location `json:"locations"`
+	}
+	type rule struct {
+		ID               string  `json:"id"`
+		ShortDescription message `json:"shortDescription"`
+	}
+
+	var rules []rule
+	results := make([]result, 0, len(findings))
+	for _, f := range findings {
+		if !lo.ContainsBy(rules, func(r rule) bool { return r.ID == string(f.Rule) }) {
+			rules = append(rules, rule{ID: string(f.Rule), ShortDescription: message{Text: RuleDescriptions[f.Rule]}})
+		}
+
+		var loc location
+		loc.PhysicalLocation.ArtifactLocation.URI = rootDocument
+		loc.LogicalLocations = []logicalLocation{{FullyQualifiedName: f.Pointer}}
+		if ptr, err := jsonpointer.Parse(f.Pointer); err == nil {
+			loc.PhysicalLocation.ArtifactLocation.URI = lo.Ternary(ptr.Location() != "", ptr.Location(), rootDocument)
+			loc.LogicalLocations[0].FullyQualifiedName = ptr.PointerString()
+		}
+		if f.Line > 0 {
+			loc.PhysicalLocation.Region = &region{StartLine: f.Line, StartColumn: f.Column}
+		}
+		results = append(results, result{
+			RuleID:    string(f.Rule),
+			Level:     string(f.Severity),
+			Message:   message{Text: f.Message},
+			Locations: []location{loc},
+		})
+	}
+
+	report := map[string]any{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []any{
+			map[string]any{
+				"tool": map[string]any{
+					"driver": map[string]any{
+						"name":           "go-asyncapi",
+						"informationUri": "https://github.com/bdragon300/go-asyncapi",
+						"version":        toolVersion,
+						"rules":          lo.Ternary(rules == nil, []rule{}, rules),
+					},
+				},
+				"results": results,
+			},
+		},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
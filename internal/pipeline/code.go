@@ -0,0 +1,326 @@
+package pipeline
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/bdragon300/go-asyncapi/internal/common"
+	"github.com/bdragon300/go-asyncapi/internal/compiler"
+	"github.com/bdragon300/go-asyncapi/internal/lint"
+	"github.com/bdragon300/go-asyncapi/internal/log"
+	"github.com/bdragon300/go-asyncapi/internal/render"
+	"github.com/bdragon300/go-asyncapi/internal/renderer"
+	"github.com/bdragon300/go-asyncapi/internal/selector"
+	"github.com/bdragon300/go-asyncapi/internal/tmpl"
+	"github.com/bdragon300/go-asyncapi/internal/tmpl/manager"
+	"github.com/bdragon300/go-asyncapi/internal/types"
+	"github.com/bdragon300/go-asyncapi/templates/client"
+	templates "github.com/bdragon300/go-asyncapi/templates/code"
+	"github.com/bdragon300/go-asyncapi/templates/codeextra"
+	"github.com/samber/lo"
+	"golang.org/x/mod/modfile"
+)
+
+// GenerateCode renders the code for compiled and linked documents and formats it. rootDocument is the location of
+// the root document. Returns the rendered files contents by file name, relative to the target directory. If clientApp
+// is true, the client application code is rendered as well.
+func GenerateCode(cfg Config, documents map[string]*compiler.Document, rootDocument string, clientApp bool) (map[string]*bytes.Buffer, error) {
+	logger := log.GetLogger("")
+
+	renderOpts, err := RenderOpts(cfg, cfg.Code.TargetDir, true)
+	if err != nil {
+		return nil, err
+	}
+	renderManager := manager.NewTemplateRenderManager(renderOpts)
+
+	activeProtocols := CollectAllProtocols(documents)
+	logger.Debug("Collected protocols", "value", activeProtocols)
+
+	CheckArtifacts(documents, rootDocument)
+
+	// Extra code: utils code
+	logger.Debug("Run util code rendering", "protocols", activeProtocols)
+	renderManager.TemplateLoader = tmpl.NewTemplateLoader("", codeextra.TemplateFS) // No main template there
+	if err := renderer.RenderUtilCode(activeProtocols, renderOpts, renderManager, codeextra.TemplateFS); err != nil {
+		return nil, fmt.Errorf("render util code: %w", err)
+	}
+	logger.Debug("Extra code rendering complete")
+
+	// Extra code: implementations code
+	activeProtocols = CollectActiveServersProtocols(documents)
+	logger.Debug("Collected active servers protocols", "value", activeProtocols)
+	if !renderOpts.ImplementationCodeOpts.Disable {
+		logger.Debug("Run implementations code rendering")
+		if err = renderer.RenderImplementationCode(activeProtocols, renderOpts, renderManager, codeextra.TemplateFS); err != nil {
+			return nil, fmt.Errorf("render implementation code: %w", err)
+		}
+		logger.Debug("Implementations rendering complete")
+	}
+
+	// Document objects
+	logger.Debug("Run objects rendering")
+	tplLoader, err := newTemplateLoader(cfg, renderManager, templates.TemplateFS)
+	if err != nil {
+		return nil, err
+	}
+	renderManager.TemplateLoader = tplLoader
+	allArtifacts := selector.GatherArtifacts(lo.Values(documents)...)
+	logger.Debug("Select artifacts")
+	renderQueue := SelectArtifacts(allArtifacts, renderOpts.Layout)
+	logger.Debug("Rendering the artifacts", "allArtifacts", len(allArtifacts), "selectedArtifacts", len(renderQueue))
+	if err = renderer.RenderArtifacts(renderQueue, renderManager); err != nil {
+		return nil, fmt.Errorf("render artifacts: %w", err)
+	}
+	logger.Debug("Objects rendering complete")
+
+	// Client app
+	if clientApp {
+		logger.Debug("Run client app rendering")
+		if tplLoader, err = newTemplateLoader(cfg, renderManager, templates.TemplateFS, client.TemplateFS); err != nil {
+			return nil, err
+		}
+		renderManager.TemplateLoader = tplLoader
+		if err = renderer.RenderClientApp(renderQueue, activeProtocols, cfg.Client.GoModTemplate, cfg.Client.OutputSourceFile, renderManager); err != nil {
+			return nil, fmt.Errorf("render client app: %w", err)
+		}
+		logger.Debug("Client app rendering complete")
+	}
+
+	// Render the final result: preamble, etc.
+	logger.Debug("Finish the files rendering")
+	files, err := renderer.FinishFiles(renderManager)
+	if err != nil {
+		return nil, fmt.Errorf("finish files: %w", err)
+	}
+	logger.Debug("Rendering finishing complete")
+
+	//
+	// Formatting
+	//
+	if !cfg.Code.DisableFormatting {
+		logger.Debug("Run postprocessing")
+		if err = FormatGoFiles(files); err != nil {
+			return nil, fmt.Errorf("formatting: %w", err)
+		}
+		logger.Debug("Postprocessing complete")
+	}
+
+	return files, nil
+}
+
+// CodeTemplateLoader returns the parsed code templates, including the user templates directory if it is set.
+func CodeTemplateLoader(cfg Config) (*tmpl.TemplateLoader, error) {
+	return newTemplateLoader(cfg, manager.NewTemplateRenderManager(common.RenderOpts{}), templates.TemplateFS)
+}
+
+// newTemplateLoader returns the template loader with given built-in template directories and the user templates
+// directory, and parses all templates in them.
+func newTemplateLoader(cfg Config, renderManager *manager.TemplateRenderManager, builtinDirs ...fs.FS) (*tmpl.TemplateLoader, error) {
+	logger := log.GetLogger("")
+
+	templateDirs := slices.Clone(builtinDirs)
+	if cfg.TemplatesDir != "" {
+		logger.Debug("Custom templates location", "directory", cfg.TemplatesDir)
+		templateDirs = append(templateDirs, os.DirFS(cfg.TemplatesDir))
+	}
+	tplLoader := tmpl.NewTemplateLoader(DefaultMainTemplateName, templateDirs...)
+	logger.Trace("Parse templates", "dirs", templateDirs)
+	if err := tplLoader.ParseRecursive(renderManager); err != nil {
+		return nil, fmt.Errorf("parse templates: %w", err)
+	}
+	return tplLoader, nil
+}
+
+// CollectActiveServersProtocols returns a list of protocols that are used in servers that are active and selectable, i.e.
+// those, which will appear in the generated code. Used to determine which implementations to generate.
+func CollectActiveServersProtocols(documents map[string]*compiler.Document) []string {
+	servers := common.VisibleArtifacts[*render.Server](documents)
+	r := lo.Uniq(lo.FilterMap(servers, func(obj *render.Server, _ int) (string, bool) {
+		return obj.Protocol, obj.Selectable()
+	}))
+	return r
+}
+
+// CollectAllProtocols returns a list of all protocols that are used both in bindings and active servers. Used to
+// determine which util code to generate.
+func CollectAllProtocols(documents map[string]*compiler.Document) []string {
+	bindingsArtifacts := common.VisibleArtifacts[*render.Bindings](documents)
+	bindingProtocols := lo.FlatMap(bindingsArtifacts, func(obj *render.Bindings, _ int) []string {
+		// Bindings are always non-selectable, so we don't check Selectable() here
+		return obj.Protocols()
+	})
+	r := lo.Uniq(append(bindingProtocols, CollectActiveServersProtocols(documents)...))
+	return r
+}
+
+// RenderOpts returns the render options from the configuration. If findProjectModule is true and project module is
+// not set, it is determined from go.mod file in the current working directory and targetDir.
+func RenderOpts(conf Config, targetDir string, findProjectModule bool) (common.RenderOpts, error) {
+	logger := log.GetLogger("")
+	res := common.RenderOpts{
+		RuntimeModule:    conf.RuntimeModule,
+		PreambleTemplate: conf.Code.PreambleTemplate,
+		ValidateMessages: conf.Code.ValidateMessages,
+		Tracing:          conf.Code.Tracing,
+		Mocks:            conf.Code.Mocks,
+		ExampleTests:     conf.Code.ExampleTests,
+		UtilCodeOpts: common.UtilCodeOpts{
+			Directory: conf.Code.Util.Directory,
+			Custom: lo.Map(conf.Code.Util.Custom, func(item ConfigCodeUtilProtocol, _ int) common.UtilCodeCustomOpts {
+				return common.UtilCodeCustomOpts{
+					Protocol:          item.Protocol,
+					TemplateDirectory: item.TemplateDirectory,
+				}
+			}),
+		},
+		ImplementationCodeOpts: common.ImplementationCodeOpts{
+			Directory: conf.Code.Implementation.Directory,
+			Disable:   conf.Code.Implementation.Disable,
+			Custom: lo.Map(conf.Code.Implementation.Custom, func(item ConfigImplementationProtocol, _ int) common.ImplementationCodeCustomOpts {
+				return common.ImplementationCodeCustomOpts{
+					Protocol:          item.Protocol,
+					Name:              item.Name,
+					Disable:           item.Disable,
+					TemplateDirectory: item.TemplateDirectory,
+					Package:           item.Package,
+				}
+			}),
+		},
+	}
+
+	// Layout
+	for _, item := range conf.Code.Layout {
+		l := common.CodeLayoutItemOpts{
+			Protocols:     item.Protocols,
+			ArtifactKinds: item.ArtifactKinds,
+			ModuleURLRe:   item.ModuleURLRe,
+			PathRe:        item.PathRe,
+			NameRe:        item.NameRe,
+			Not:           item.Not,
+			Render: common.CodeLayoutItemRenderOpts{
+				Template:  item.Render.Template,
+				File:      item.Render.File,
+				Package:   item.Render.Package,
+				Protocols: item.Render.Protocols,
+			},
+		}
+		logger.Debug("Use layout item", "value", l)
+		res.Layout = append(res.Layout, l)
+	}
+	if res.ExampleTests {
+		// Tests are rendered to the separate file next to the message file
+		l := common.CodeLayoutItemOpts{
+			ArtifactKinds: []string{string(common.ArtifactKindMessage)},
+			Render: common.CodeLayoutItemRenderOpts{
+				Template: exampleTestsTemplate,
+				File:     exampleTestsFile,
+			},
+		}
+		logger.Debug("Use example tests layout item", "value", l)
+		res.Layout = append(res.Layout, l)
+	}
+
+	// ImportBase
+	res.ImportBase = conf.ProjectModule
+	if res.ImportBase == "" && findProjectModule {
+		m, err := projectModule()
+		if err != nil {
+			return res, fmt.Errorf("determine the module name (use -M arg to override): %w", err)
+		}
+		logger.Debug("Determined project module", "value", m)
+		// Clean target directory path, removing empty, current and parent directories, leaving only the names.
+		// This is not the best solution, however, it should work for most cases. Moreover, user can always override it.
+		parts := lo.Filter(strings.Split(path.Clean(targetDir), string(os.PathSeparator)), func(s string, _ int) bool {
+			return !lo.Contains([]string{"", ".", ".."}, s)
+		})
+		res.ImportBase = path.Join(m, path.Join(parts...))
+	}
+	logger.Debug("Import base", "value", res.ImportBase)
+
+	return res, nil
+}
+
+// SelectArtifacts selects artifacts from the list of all artifacts based on the layout configuration.
+func SelectArtifacts(artifacts []common.Artifact, layout []common.CodeLayoutItemOpts) (res []renderer.RenderQueueItem) {
+	logger := log.GetLogger("")
+
+	for _, l := range layout {
+		logger.Trace("-> Process layout filters", "item", l)
+		selected := selector.ApplyFilters(artifacts, l)
+		for _, obj := range selected {
+			res = append(res, renderer.RenderQueueItem{LayoutItem: l, Object: obj})
+		}
+		logger.Debug("-> Selected", "artifacts", len(selected))
+	}
+	return
+}
+
+// projectModule returns the module name from the go.mod file in the current working directory.
+func projectModule() (string, error) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("get current working directory: %w", err)
+	}
+	fn := path.Join(pwd, "go.mod")
+	f, err := os.Open(fn)
+	if err != nil {
+		return "", fmt.Errorf("open %q: %w", fn, err)
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return "", fmt.Errorf("read %q file: %w", fn, err)
+	}
+	modpath := modfile.ModulePath(data)
+	if modpath == "" {
+		return "", fmt.Errorf("reading module name from %q", fn)
+	}
+	return modpath, nil
+}
+
+// CheckArtifacts briefly checks for the common mistakes in documents, that can lead to incorrect code generation or runtime errors.
+// The main purpose of this function is to inform the user about this.
+func CheckArtifacts(documents map[string]*compiler.Document, rootDocument string) {
+	logger := log.GetLogger(log.LoggerPrefixRendering)
+
+	var findings []lint.Finding
+	findings = append(findings, lint.CheckServers(documents, rootDocument)...)
+	findings = append(findings, lint.CheckNames(documents)...)
+	findings = append(findings, lint.CheckOperations(documents)...)
+	for _, f := range findings {
+		logger.Warn(f.Message, "pointer", f.Pointer)
+	}
+}
+
+// FormatGoFiles formats the file buffers in-place applying go fmt.
+func FormatGoFiles(files map[string]*bytes.Buffer) error {
+	logger := log.GetLogger(log.LoggerPrefixFormatting)
+
+	keys := lo.Keys(files)
+	slices.Sort(keys)
+	for _, fileName := range keys {
+		if !strings.HasSuffix(fileName, ".go") {
+			logger.Debug("Skip a file", "name", fileName)
+			continue
+		}
+		buf := files[fileName]
+		logger.Debug("File", "name", fileName, "bytes", buf.Len())
+		formatted, err := format.Source(buf.Bytes())
+		if err != nil {
+			return types.MultilineError{Err: err, Content: buf.Bytes()}
+		}
+		buf.Reset()
+		buf.Write(formatted)
+		logger.Debug("-> File formatted", "name", fileName, "bytes", buf.Len())
+	}
+
+	logger.Info("Formatting complete", "files", len(files))
+	return nil
+}
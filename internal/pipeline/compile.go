@@ -0,0 +1,177 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/bdragon300/go-asyncapi/internal/common"
+	"github.com/bdragon300/go-asyncapi/internal/compiler"
+	"github.com/bdragon300/go-asyncapi/internal/compiler/compile"
+	"github.com/bdragon300/go-asyncapi/internal/jsonpointer"
+	"github.com/bdragon300/go-asyncapi/internal/linker"
+	"github.com/bdragon300/go-asyncapi/internal/locator"
+	"github.com/bdragon300/go-asyncapi/internal/log"
+	"github.com/samber/lo"
+)
+
+// DocumentLocator reads the documents and resolves the $ref locations against the document they appear in.
+type DocumentLocator interface {
+	Locate(docURL *jsonpointer.JSONPointer) (io.ReadCloser, error)
+	ResolveURL(base, target *jsonpointer.JSONPointer) (*jsonpointer.JSONPointer, error)
+}
+
+// NewLocator returns the locator according to the configuration: the subprocess locator if the command is set,
+// and the built-in one otherwise.
+func NewLocator(conf ConfigLocator) DocumentLocator {
+	logger := log.GetLogger(log.LoggerPrefixLocating)
+	if conf.Command != "" {
+		return locator.Subprocess{
+			CommandLine:     conf.Command,
+			RunTimeout:      conf.Timeout,
+			ShutdownTimeout: defaultSubprocessLocatorShutdownTimeout,
+			RootDirectory:   conf.RootDirectory,
+			Logger:          logger,
+		}
+	}
+	res := locator.Default{
+		Client:        &http.Client{Timeout: conf.Timeout},
+		RootDirectory: conf.RootDirectory,
+		Logger:        logger,
+	}
+	return res
+}
+
+// CompileOpts returns the compilation options for code generation.
+func CompileOpts(cfg Config) compile.CompilationOpts {
+	// When both flags are not set, both pub and sub are enabled. If one of them is set, only that one is enabled.
+	// If both are set, both are enabled as well, but this is a weird case.
+	isPub := cfg.Code.OnlyPublish || !cfg.Code.OnlySubscribe
+	isSub := cfg.Code.OnlySubscribe || !cfg.Code.OnlyPublish
+	return compile.CompilationOpts{
+		AllowRemoteRefs:     cfg.Locator.AllowRemoteReferences,
+		GeneratePublishers:  isPub,
+		GenerateSubscribers: isSub,
+	}
+}
+
+// CompileAndLink compiles the document and all documents it refers to, and links them. On error,
+// the documents processed so far are returned along with the error.
+func CompileAndLink(
+	locator DocumentLocator,
+	docURL *jsonpointer.JSONPointer,
+	compileOpts compile.CompilationOpts,
+) (map[string]*compiler.Document, error) {
+	logger := log.GetLogger("")
+
+	logger.Debug("Run compilation")
+	compileContext := compile.NewCompileContext(compileOpts)
+	documents, err := Compile(docURL, compileContext, locator)
+	if err != nil {
+		return documents, err
+	}
+	logger.Debug("Compilation complete", "files", len(documents))
+	objSources := lo.MapValues(documents, func(value *compiler.Document, _ string) linker.ObjectSource { return value })
+
+	logger.Debug("Run linking")
+	if err = Link(objSources); err != nil {
+		return documents, fmt.Errorf("linking: %w", err)
+	}
+	logger.Debug("Linking complete")
+	return documents, nil
+}
+
+// Compile compiles the document and all documents it refers to. On error, the documents processed so far are
+// returned along with the error.
+func Compile(
+	docURL *jsonpointer.JSONPointer,
+	compileContext *compile.Context,
+	locator DocumentLocator,
+) (map[string]*compiler.Document, error) {
+	logger := log.GetLogger(log.LoggerPrefixCompilation)
+	compileQueue := []*jsonpointer.JSONPointer{docURL} // Queue of document urls to compile
+	documents := make(map[string]*compiler.Document)   // Documents by url
+	var rootUpgraded bool
+	for len(compileQueue) > 0 {
+		docURL, compileQueue = compileQueue[0], compileQueue[1:] // Pop an item from queue
+		if _, ok := documents[docURL.Location()]; ok {
+			continue // Skip if a document has been already compiled
+		}
+
+		logger.Info("Compile a document", "url", docURL)
+		document := compiler.NewDocument(docURL)
+		documents[docURL.Location()] = document
+
+		if !compileContext.CompileOpts.AllowRemoteRefs && docURL.URI != nil {
+			return documents, fmt.Errorf(
+				"%s: external requests are forbidden by default for security reasons, use --allow-remote-refs flag to allow them",
+				docURL,
+			)
+		}
+		logger.Debug("Loading a document", "url", docURL)
+		if err := document.Load(locator); err != nil {
+			if rootUpgraded && errors.Is(err, compiler.ErrUnknownDocumentKind) {
+				logger.Warn(
+					"Files with parts of AsyncAPI 2.x document must have `asyncapi` field to be converted to AsyncAPI 3.0",
+					"url", docURL,
+				)
+			}
+			return documents, fmt.Errorf("load a document: %w", err)
+		}
+		if len(documents) == 1 {
+			rootUpgraded = document.Upgraded()
+		}
+		logger.Debug("Compiling a document", "url", docURL)
+		if err := document.Compile(compileContext); err != nil {
+			return documents, fmt.Errorf("compilation a document: %w", err)
+		}
+		logger.Debugf("Compiler stats: %s", document.Stats())
+
+		// Resolve and add external URLs to the compile queue
+		var externalURLs []*jsonpointer.JSONPointer
+		for _, u := range document.ExternalURLs() {
+			joined, err := locator.ResolveURL(docURL, u)
+			if err != nil {
+				return documents, fmt.Errorf("join base %q and target %q: %w", docURL, u, err)
+			}
+			externalURLs = append(externalURLs, joined)
+			logger.Trace("Resolved external document location for $ref", "ref", u.String(), "url", joined.Location())
+		}
+		compileQueue = append(compileQueue, externalURLs...)
+	}
+
+	return documents, nil
+}
+
+// Link resolves the promises in all compiled documents.
+func Link(objSources map[string]linker.ObjectSource) error {
+	logger := log.GetLogger(log.LoggerPrefixLinking)
+
+	// Linking refs
+	linker.ResolvePromises(objSources)
+	unresolved := linker.UnresolvedPromises(objSources)
+	logger.Debugf("Linker stats: %s", linker.Stats(objSources))
+	if len(unresolved) > 0 {
+		logger.Error("Some refs remain dangling", "refs", unresolved)
+		return fmt.Errorf("cannot resolve all refs")
+	}
+
+	// Linking list promises
+	logger.Debug("Run linking the list promises")
+	linker.ResolveListPromises(objSources)
+	unresolvedCount := linker.UnresolvedPromisesCount(objSources)
+	logger.Debugf("Linker stats: %s", linker.Stats(objSources))
+	if unresolvedCount > 0 {
+		logger.Error("Cannot assign internal list promises", "promises", unresolvedCount)
+		return fmt.Errorf("cannot finish linking")
+	}
+
+	refsCount := lo.SumBy(lo.Values(objSources), func(item linker.ObjectSource) int {
+		return lo.CountBy(item.Promises(), func(p common.ObjectPromise) bool {
+			return p.Origin() == common.PromiseOriginRef
+		})
+	})
+	logger.Info("Linking complete", "refs", refsCount)
+	return nil
+}
@@ -1,4 +1,4 @@
-package main
+package pipeline
 
 import (
 	"encoding/json"
@@ -7,90 +7,116 @@ import (
 	"io/fs"
 	"time"
 
+	"github.com/bdragon300/go-asyncapi/assets"
 	"github.com/bdragon300/go-asyncapi/internal/common"
 	"github.com/bdragon300/go-asyncapi/internal/types"
+	"github.com/samber/lo"
 	"gopkg.in/yaml.v3"
 )
 
-type d2DiagramEngine string
+const (
+	// DefaultConfigFileName is the built-in configuration file name in [assets.AssetFS].
+	DefaultConfigFileName = "default_config.yaml"
+	// DefaultMainTemplateName is the root template name, that is called for every selected artifact.
+	DefaultMainTemplateName = "main.tmpl"
+
+	defaultSubprocessLocatorShutdownTimeout = 3 * time.Second
+
+	// exampleTestsTemplate and exampleTestsFile are the layout item render settings added if example tests are enabled
+	exampleTestsTemplate = "message_test.tmpl"
+	exampleTestsFile     = "{{.Object.Kind}}s/{{.Object | goID }}_test.go"
+)
+
+// Infra engines supported by infra command
+const (
+	InfraEngineDocker     = "docker"
+	InfraEngineKubernetes = "kubernetes"
+	InfraEngineHelm       = "helm"
+)
+
+type D2DiagramEngine string
 
 const (
-	D2DiagramEngineELK   d2DiagramEngine = "elk"
-	D2DiagramEngineDagre d2DiagramEngine = "dagre"
+	D2DiagramEngineELK   D2DiagramEngine = "elk"
+	D2DiagramEngineDagre D2DiagramEngine = "dagre"
 )
 
 // Structures, that represent the tool's configuration file
 type (
-	toolConfig struct {
+	Config struct {
 		ConfigVersion int    `yaml:"configVersion"`
 		ProjectModule string `yaml:"projectModule"`
 		RuntimeModule string `yaml:"runtimeModule"`
 		TemplatesDir  string `yaml:"templatesDir"`
 
-		Locator toolConfigLocator `yaml:"locator"`
+		Locator ConfigLocator `yaml:"locator"`
 
-		Code    toolConfigCode    `yaml:"code"`
-		Client  toolConfigClient  `yaml:"client"`
-		Infra   toolConfigInfra   `yaml:"infra"`
-		Diagram toolConfigDiagram `yaml:"diagram"`
-		UI      toolConfigUI      `yaml:"ui"`
+		Code    ConfigCode    `yaml:"code"`
+		Client  ConfigClient  `yaml:"client"`
+		Infra   ConfigInfra   `yaml:"infra"`
+		Diagram ConfigDiagram `yaml:"diagram"`
+		UI      ConfigUI      `yaml:"ui"`
 	}
 
-	toolConfigLocator struct {
+	ConfigLocator struct {
 		AllowRemoteReferences bool          `yaml:"allowRemoteReferences"`
 		RootDirectory         string        `yaml:"rootDirectory"`
 		Timeout               time.Duration `yaml:"timeout"`
 		Command               string        `yaml:"command"`
 	}
 
-	toolConfigCode struct {
+	ConfigCode struct {
 		OnlyPublish       bool   `yaml:"onlyPublish"`
 		OnlySubscribe     bool   `yaml:"onlySubscribe"`
 		DisableFormatting bool   `yaml:"disableFormatting"`
+		ValidateMessages  bool   `yaml:"validateMessages"`
+		Tracing           bool   `yaml:"tracing"`
+		Mocks             bool   `yaml:"mocks"`
+		ExampleTests      bool   `yaml:"exampleTests"`
 		TargetDir         string `yaml:"targetDir"`
 
-		Layout []toolConfigCodeLayout `yaml:"layout"`
+		Layout []ConfigCodeLayout `yaml:"layout"`
 
 		PreambleTemplate string `yaml:"preambleTemplate"`
 
-		Util           toolConfigCodeUtil           `yaml:"util"`
-		Implementation toolConfigCodeImplementation `yaml:"implementation"`
+		Util           ConfigCodeUtil           `yaml:"util"`
+		Implementation ConfigCodeImplementation `yaml:"implementation"`
 	}
 
-	toolConfigCodeLayout struct {
-		NameRe        string                 `yaml:"nameRe"`
-		ArtifactKinds []string               `yaml:"artifactKinds"`
-		ModuleURLRe   string                 `yaml:"moduleURLRe"` // TODO: rename to locationRe or smth like that
-		PathRe        string                 `yaml:"pathRe"`      // TODO: remove? almost duplicate of moduleURLRe
-		Protocols     []string               `yaml:"protocols"`
-		Not           bool                   `yaml:"not"` // Inverts the match, i.e. NOT operation
-		Render        toolConfigLayoutRender `yaml:"render"`
+	ConfigCodeLayout struct {
+		NameRe        string             `yaml:"nameRe"`
+		ArtifactKinds []string           `yaml:"artifactKinds"`
+		ModuleURLRe   string             `yaml:"moduleURLRe"` // TODO: rename to locationRe or smth like that
+		PathRe        string             `yaml:"pathRe"`      // TODO: remove? almost duplicate of moduleURLRe
+		Protocols     []string           `yaml:"protocols"`
+		Not           bool               `yaml:"not"` // Inverts the match, i.e. NOT operation
+		Render        ConfigLayoutRender `yaml:"render"`
 	}
 
-	toolConfigLayoutRender struct {
+	ConfigLayoutRender struct {
 		Protocols []string `yaml:"protocols"`
 		Template  string   `yaml:"template"`
 		File      string   `yaml:"file"`
 		Package   string   `yaml:"package"` // TODO: make it inline template
 	}
 
-	toolConfigCodeUtil struct {
-		Directory string                       `yaml:"directory"` // Template expression, relative to the target directory
-		Custom    []toolConfigCodeUtilProtocol `yaml:"custom"`
+	ConfigCodeUtil struct {
+		Directory string                   `yaml:"directory"` // Template expression, relative to the target directory
+		Custom    []ConfigCodeUtilProtocol `yaml:"custom"`
 	}
 
-	toolConfigCodeUtilProtocol struct {
+	ConfigCodeUtilProtocol struct {
 		Protocol          string `yaml:"protocol"`
 		TemplateDirectory string `yaml:"templateDirectory"`
 	}
 
-	toolConfigCodeImplementation struct {
-		Directory string                             `yaml:"directory"` // Template expression, relative to the target directory
-		Disable   bool                               `yaml:"disable"`
-		Custom    []toolConfigImplementationProtocol `yaml:"custom"`
+	ConfigCodeImplementation struct {
+		Directory string                         `yaml:"directory"` // Template expression, relative to the target directory
+		Disable   bool                           `yaml:"disable"`
+		Custom    []ConfigImplementationProtocol `yaml:"custom"`
 	}
 
-	toolConfigImplementationProtocol struct {
+	ConfigImplementationProtocol struct {
 		Protocol          string `yaml:"protocol"`
 		Name              string `yaml:"name"`
 		Disable           bool   `yaml:"disable"`
@@ -98,7 +124,7 @@ type (
 		Package           string `yaml:"package"`
 	}
 
-	toolConfigClient struct {
+	ConfigClient struct {
 		OutputFile       string `yaml:"outputFile"`
 		OutputSourceFile string `yaml:"outputSourceFile"`
 		KeepSource       bool   `yaml:"keepSource"`
@@ -106,18 +132,18 @@ type (
 		TempDir          string `yaml:"tempDir"`
 	}
 
-	toolConfigInfra struct {
-		ServerOpts []toolConfigInfraServerOpt `yaml:"serverOpts"`
-		Engine     string                     `yaml:"engine"`
-		OutputFile string                     `yaml:"outputFile"`
+	ConfigInfra struct {
+		ServerOpts []ConfigInfraServerOpt `yaml:"serverOpts"`
+		Engine     string                 `yaml:"engine"`
+		OutputFile string                 `yaml:"outputFile"`
 	}
 
-	toolConfigInfraServerOpt struct {
+	ConfigInfraServerOpt struct {
 		ServerName string                                                                             `yaml:"serverName"` // TODO: make required
 		Variables  types.Union2[types.OrderedMap[string, string], []types.OrderedMap[string, string]] `yaml:"variables"`
 	}
 
-	toolConfigDiagram struct {
+	ConfigDiagram struct {
 		Format common.DiagramOutputFormat `yaml:"format"`
 
 		OutputFile        string `yaml:"outputFile"`
@@ -129,23 +155,23 @@ type (
 		ServersCentric  bool `yaml:"serversCentric"`
 		DocumentBorders bool `yaml:"documentBorders"`
 
-		D2 toolConfigDiagramD2Opts `yaml:"d2"`
+		D2 ConfigDiagramD2Opts `yaml:"d2"`
 	}
 
-	toolConfigDiagramD2Opts struct {
-		Engine      d2DiagramEngine              `yaml:"engine"`
-		Direction   common.D2DiagramDirection    `yaml:"direction"`
-		ThemeID     *int64                       `yaml:"themeId"`
-		DarkThemeID *int64                       `yaml:"darkThemeId"`
-		Pad         *int64                       `yaml:"pad"`
-		Sketch      *bool                        `yaml:"sketch"`
-		Center      *bool                        `yaml:"center"`
-		Scale       *float64                     `yaml:"scale"`
-		ELK         toolConfigDiagramD2ELKOpts   `yaml:"elk"`
-		Dagre       toolConfigDiagramD2DagreOpts `yaml:"dagre"`
+	ConfigDiagramD2Opts struct {
+		Engine      D2DiagramEngine           `yaml:"engine"`
+		Direction   common.D2DiagramDirection `yaml:"direction"`
+		ThemeID     *int64                    `yaml:"themeId"`
+		DarkThemeID *int64                    `yaml:"darkThemeId"`
+		Pad         *int64                    `yaml:"pad"`
+		Sketch      *bool                     `yaml:"sketch"`
+		Center      *bool                     `yaml:"center"`
+		Scale       *float64                  `yaml:"scale"`
+		ELK         ConfigDiagramD2ELKOpts    `yaml:"elk"`
+		Dagre       ConfigDiagramD2DagreOpts  `yaml:"dagre"`
 	}
 
-	toolConfigDiagramD2ELKOpts struct {
+	ConfigDiagramD2ELKOpts struct {
 		Algorithm       string `yaml:"algorithm"`
 		NodeSpacing     int64  `yaml:"nodeSpacing"`
 		Padding         string `yaml:"padding"`
@@ -153,12 +179,12 @@ type (
 		SelfLoopSpacing int64  `yaml:"selfLoopSpacing"`
 	}
 
-	toolConfigDiagramD2DagreOpts struct {
+	ConfigDiagramD2DagreOpts struct {
 		NodeSep int64 `yaml:"nodeSep"`
 		EdgeSep int64 `yaml:"edgeSep"`
 	}
 
-	toolConfigUI struct {
+	ConfigUI struct {
 		OutputFile string `yaml:"outputFile"`
 
 		Listen        *bool  `yaml:"listen"`
@@ -171,7 +197,7 @@ type (
 
 // ToD2PluginOpts converts the config options to the JSON options of the d2 plugin.
 // For json tags see d2.d2layouts.d2elklayout.DefaultOpts.
-func (t toolConfigDiagramD2ELKOpts) ToD2PluginOpts() ([]byte, error) {
+func (t ConfigDiagramD2ELKOpts) ToD2PluginOpts() ([]byte, error) {
 	out := map[string]any{
 		"elk.algorithm":                 t.Algorithm,
 		"spacing.nodeNodeBetweenLayers": t.NodeSpacing,
@@ -184,7 +210,7 @@ func (t toolConfigDiagramD2ELKOpts) ToD2PluginOpts() ([]byte, error) {
 
 // ToD2PluginOpts converts the config options to the JSON options of the d2 plugin.
 // For json tags see d2.d2layouts.d2dagrelayout.DefaultOpts
-func (t toolConfigDiagramD2DagreOpts) ToD2PluginOpts() ([]byte, error) {
+func (t ConfigDiagramD2DagreOpts) ToD2PluginOpts() ([]byte, error) {
 	out := map[string]any{
 		"nodesep": t.NodeSep,
 		"edgesep": t.EdgeSep,
@@ -192,28 +218,38 @@ func (t toolConfigDiagramD2DagreOpts) ToD2PluginOpts() ([]byte, error) {
 	return json.Marshal(out)
 }
 
-// loadConfig loads and parses the configuration file with the given baseName from the given file system.
-func loadConfig(fileFS fs.FS, baseName string) (res toolConfig, err error) {
+// LoadConfig loads and parses the configuration file with the given baseName from the given file system.
+func LoadConfig(fileFS fs.FS, baseName string) (res Config, err error) {
 	f, err := fileFS.Open(baseName)
 	if err != nil {
-		return toolConfig{}, fmt.Errorf("open: %w", err)
+		return Config{}, fmt.Errorf("open: %w", err)
 	}
 	defer f.Close()
 
 	buf, err := io.ReadAll(f)
 	if err != nil {
-		return toolConfig{}, fmt.Errorf("read: %w", err)
+		return Config{}, fmt.Errorf("read: %w", err)
 	}
 
+	return ParseConfig(buf)
+}
+
+// ParseConfig parses the YAML configuration file contents.
+func ParseConfig(buf []byte) (res Config, err error) {
 	if err = yaml.Unmarshal(buf, &res); err != nil {
-		return toolConfig{}, fmt.Errorf("parse YAML: %w", err)
+		return Config{}, fmt.Errorf("parse YAML: %w", err)
 	}
 	return
 }
 
-// mergeConfig merges the default configuration with the user-provided one.
-func mergeConfig(defaultConf, userConf toolConfig) toolConfig {
-	var res toolConfig
+// DefaultConfig returns the built-in configuration, that contains the default values for all options.
+func DefaultConfig() (Config, error) {
+	return LoadConfig(assets.AssetFS, DefaultConfigFileName)
+}
+
+// MergeConfig merges the default configuration with the user-provided one.
+func MergeConfig(defaultConf, userConf Config) Config {
+	var res Config
 
 	res.ConfigVersion = coalesce(userConf.ConfigVersion, defaultConf.ConfigVersion)
 	res.ProjectModule = coalesce(userConf.ProjectModule, defaultConf.ProjectModule)
@@ -228,6 +264,10 @@ func mergeConfig(defaultConf, userConf toolConfig) toolConfig {
 	res.Code.OnlyPublish = coalesce(userConf.Code.OnlyPublish, defaultConf.Code.OnlyPublish)
 	res.Code.OnlySubscribe = coalesce(userConf.Code.OnlySubscribe, defaultConf.Code.OnlySubscribe)
 	res.Code.DisableFormatting = coalesce(userConf.Code.DisableFormatting, defaultConf.Code.DisableFormatting)
+	res.Code.ValidateMessages = coalesce(userConf.Code.ValidateMessages, defaultConf.Code.ValidateMessages)
+	res.Code.Tracing = coalesce(userConf.Code.Tracing, defaultConf.Code.Tracing)
+	res.Code.Mocks = coalesce(userConf.Code.Mocks, defaultConf.Code.Mocks)
+	res.Code.ExampleTests = coalesce(userConf.Code.ExampleTests, defaultConf.Code.ExampleTests)
 	res.Code.TargetDir = coalesce(userConf.Code.TargetDir, defaultConf.Code.TargetDir)
 	res.Code.PreambleTemplate = coalesce(userConf.Code.PreambleTemplate, defaultConf.Code.PreambleTemplate)
 
@@ -300,3 +340,9 @@ func mergeConfig(defaultConf, userConf toolConfig) toolConfig {
 
 	return res
 }
+
+// coalesce return the first non-zero value from the list of arguments.
+func coalesce[T comparable](vals ...T) T {
+	res, _ := lo.Coalesce(vals...)
+	return res
+}
@@ -0,0 +1,234 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/bdragon300/go-asyncapi/internal/common"
+	"github.com/bdragon300/go-asyncapi/internal/compiler"
+	"github.com/bdragon300/go-asyncapi/internal/log"
+	"github.com/bdragon300/go-asyncapi/internal/renderer"
+	"github.com/bdragon300/go-asyncapi/internal/selector"
+	"github.com/bdragon300/go-asyncapi/internal/tmpl/manager"
+	"github.com/bdragon300/go-asyncapi/internal/types"
+	"github.com/bdragon300/go-asyncapi/templates/diagram"
+	"github.com/samber/lo"
+	"oss.terrastruct.com/d2/d2format"
+	"oss.terrastruct.com/d2/d2graph"
+	"oss.terrastruct.com/d2/d2lib"
+	"oss.terrastruct.com/d2/d2plugin"
+	"oss.terrastruct.com/d2/d2renderers/d2svg"
+	"oss.terrastruct.com/d2/d2target"
+	"oss.terrastruct.com/d2/d2themes"
+	"oss.terrastruct.com/d2/d2themes/d2themescatalog"
+	d2log "oss.terrastruct.com/d2/lib/log"
+	"oss.terrastruct.com/d2/lib/textmeasure"
+)
+
+// GenerateDiagram renders the diagram files for compiled and linked documents, and converts or formats them according
+// to configuration. Returns the files contents by file name, relative to the target directory. rootDocument is used
+// to make the file name if the output file is not set.
+func GenerateDiagram(cfg Config, documents map[string]*compiler.Document, rootDocument string) (map[string]*bytes.Buffer, error) {
+	logger := log.GetLogger("")
+
+	logger.Debug("Run objects rendering")
+	renderManager := manager.NewTemplateRenderManager(common.RenderOpts{})
+	tplLoader, err := newTemplateLoader(cfg, renderManager, diagram.TemplateFS)
+	if err != nil {
+		return nil, err
+	}
+	renderManager.TemplateLoader = tplLoader
+
+	diagramConfig := DiagramRenderOpts(cfg.Diagram)
+	fileExtension := "." + strings.ToLower(string(cfg.Diagram.Format))
+	if cfg.Diagram.MultipleFiles {
+		visibleArtifactsByDoc := lo.MapValues(documents, func(d *compiler.Document, _ string) []common.Artifact {
+			return lo.Filter(selector.GatherArtifacts(d), func(a common.Artifact, _ int) bool {
+				return a.Visible()
+			})
+		})
+		logger.Debug("Render multiple diagram files", "count", len(visibleArtifactsByDoc))
+		if err = renderer.RenderDiagramMultipleFiles(visibleArtifactsByDoc, fileExtension, diagramConfig, renderManager); err != nil {
+			return nil, fmt.Errorf("render diagrams: %w", err)
+		}
+	} else {
+		allArtifacts := selector.GatherArtifacts(lo.Values(documents)...)
+		visibleArtifacts := lo.Filter(allArtifacts, func(a common.Artifact, _ int) bool {
+			return a.Visible()
+		})
+		logger.Debug("Rendering artifacts", "file", cfg.Diagram.OutputFile, "allArtifacts", len(allArtifacts), "visibleArtifacts", len(visibleArtifacts))
+		fileName := cfg.Diagram.OutputFile
+		if fileName == "" {
+			fileName = strings.TrimSuffix(path.Base(rootDocument), path.Ext(rootDocument)) + fileExtension
+		}
+		if err = renderer.RenderDiagramOneFile(visibleArtifacts, fileName, diagramConfig, renderManager); err != nil {
+			return nil, fmt.Errorf("render diagram: %w", err)
+		}
+	}
+
+	//
+	// Finishing d2 rendering
+	//
+	logger.Debug("Finish the files rendering")
+	buffers, err := renderer.FinishFiles(renderManager)
+	if err != nil {
+		return nil, fmt.Errorf("finish files: %w", err)
+	}
+	logger.Debug("Rendering finishing complete")
+
+	//
+	// Converting & formatting
+	//
+	needPostprocessing := cfg.Diagram.Format != common.DiagramOutputFormatD2 || !cfg.Diagram.DisableFormatting
+	if needPostprocessing {
+		logger.Debug("Run postprocessing", "files", len(buffers))
+		if buffers, err = postprocessD2Files(buffers, cfg.Diagram); err != nil {
+			return nil, fmt.Errorf("postprocessing: %w", err)
+		}
+		logger.Debug("Postprocessing complete", "filesCount", len(buffers))
+	}
+
+	return buffers, nil
+}
+
+// DiagramRenderOpts converts the diagram configuration to the diagram render options.
+func DiagramRenderOpts(conf ConfigDiagram) common.DiagramRenderOpts {
+	return common.DiagramRenderOpts{
+		ShowChannels:        !conf.ServersCentric,
+		ShowServers:         !conf.ChannelsCentric,
+		ShowDocumentBorders: conf.DocumentBorders,
+		D2DiagramDirection:  conf.D2.Direction,
+	}
+}
+
+func postprocessD2Files(buffers map[string]*bytes.Buffer, conf ConfigDiagram) (map[string]*bytes.Buffer, error) {
+	for fileName, srcBuf := range buffers {
+		log.GetLogger("").Debug("Compiling d2 file", "file", fileName, "size", srcBuf.Len(), "opts", conf.D2)
+		diagramObj, graphObj, err := compileD2(srcBuf.Bytes(), conf.D2)
+		if err != nil {
+			return nil, types.MultilineError{
+				Err:     fmt.Errorf("compile d2: %w", err),
+				Content: srcBuf.Bytes(),
+			}
+		}
+
+		switch {
+		case conf.Format == common.DiagramOutputFormatSVG:
+			log.GetLogger(log.LoggerPrefixRendering).Debug("-> Converting D2 diagram to SVG", "file", fileName)
+			renderOpts, err := getD2RenderOpts(conf.D2)
+			if err != nil {
+				return nil, fmt.Errorf("d2 render options: %w", err)
+			}
+			newBuf, err := d2svg.Render(diagramObj, &renderOpts)
+			if err != nil {
+				return nil, types.MultilineError{
+					Err:     fmt.Errorf("render svg: %w", err),
+					Content: srcBuf.Bytes(),
+				}
+			}
+			buffers[fileName].Reset()
+			buffers[fileName].Write(newBuf)
+
+		case !conf.DisableFormatting:
+			log.GetLogger(log.LoggerPrefixFormatting).Debug("-> Formatting D2 file", "file", fileName)
+			newBuf := []byte(d2format.Format(graphObj.BaseAST))
+			buffers[fileName] = bytes.NewBuffer(newBuf)
+		}
+	}
+	return buffers, nil
+}
+
+func compileD2(contents []byte, d2opts ConfigDiagramD2Opts) (*d2target.Diagram, *d2graph.Graph, error) {
+	engine := string(d2opts.Engine)
+
+	ctx := d2log.With(context.Background(), slog.Default())
+	ruler, err := textmeasure.NewRuler()
+	if err != nil {
+		return nil, nil, fmt.Errorf("create d2 text ruler: %w", err)
+	}
+	plugins, err := d2plugin.ListPlugins(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list plugins: %w", err)
+	}
+	compileOpts := &d2lib.CompileOptions{
+		Layout:         lo.ToPtr(engine),
+		LayoutResolver: layoutResolver(ctx, plugins, d2opts),
+		Ruler:          ruler,
+	}
+	renderOpts, err := getD2RenderOpts(d2opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("d2 render options: %w", err)
+	}
+
+	diagramObj, graphObj, err := d2lib.Compile(ctx, string(contents), compileOpts, &renderOpts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("d2 compile: %w", err)
+	}
+	return diagramObj, graphObj, nil
+}
+
+func layoutResolver(ctx context.Context, plugins []d2plugin.Plugin, d2opts ConfigDiagramD2Opts) func(engine string) (d2graph.LayoutGraph, error) {
+	cached := make(map[string]d2graph.LayoutGraph)
+	return func(engine string) (d2graph.LayoutGraph, error) {
+		if c, ok := cached[engine]; ok {
+			return c, nil
+		}
+
+		plugin, err := d2plugin.FindPlugin(ctx, plugins, engine)
+		if err != nil {
+			if errors.Is(err, exec.ErrNotFound) {
+				return nil, fmt.Errorf("layout engine %q not found", engine)
+			}
+			return nil, err
+		}
+
+		var engineOpts []byte
+		switch d2opts.Engine {
+		case D2DiagramEngineELK:
+			if engineOpts, err = d2opts.ELK.ToD2PluginOpts(); err != nil {
+				return nil, fmt.Errorf("to %s options: %w", engine, err)
+			}
+		case D2DiagramEngineDagre:
+			if engineOpts, err = d2opts.Dagre.ToD2PluginOpts(); err != nil {
+				return nil, fmt.Errorf("to %s options: %w", engine, err)
+			}
+		default:
+			return nil, fmt.Errorf("unknown D2 engine: %q", d2opts.Engine)
+		}
+		if err = plugin.HydrateOpts(engineOpts); err != nil {
+			return nil, fmt.Errorf("hydrate %q engine options: %w", engine, err)
+		}
+
+		cached[engine] = plugin.Layout
+		return plugin.Layout, nil
+	}
+}
+
+func getD2RenderOpts(d2opts ConfigDiagramD2Opts) (d2svg.RenderOpts, error) {
+	if d2opts.DarkThemeID != nil {
+		match := d2themescatalog.Find(*d2opts.DarkThemeID)
+		if match == (d2themes.Theme{}) {
+			return d2svg.RenderOpts{}, fmt.Errorf("dark theme not found. The available options are:\n%s", d2themescatalog.CLIString())
+		}
+	}
+	if d2opts.ThemeID != nil {
+		match := d2themescatalog.Find(*d2opts.ThemeID)
+		if match == (d2themes.Theme{}) {
+			return d2svg.RenderOpts{}, fmt.Errorf("theme not found. The available options are:\n%s", d2themescatalog.CLIString())
+		}
+	}
+	return d2svg.RenderOpts{
+		Pad:         d2opts.Pad,
+		Sketch:      d2opts.Sketch,
+		Center:      d2opts.Center,
+		ThemeID:     coalesce(d2opts.ThemeID, d2opts.DarkThemeID),
+		DarkThemeID: d2opts.DarkThemeID,
+		Scale:       d2opts.Scale,
+	}, nil
+}
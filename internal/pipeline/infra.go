@@ -0,0 +1,115 @@
+package pipeline
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+
+	"github.com/bdragon300/go-asyncapi/internal/common"
+	"github.com/bdragon300/go-asyncapi/internal/compiler"
+	"github.com/bdragon300/go-asyncapi/internal/log"
+	"github.com/bdragon300/go-asyncapi/internal/renderer"
+	"github.com/bdragon300/go-asyncapi/internal/selector"
+	"github.com/bdragon300/go-asyncapi/internal/tmpl/manager"
+	"github.com/bdragon300/go-asyncapi/templates/infra"
+	"github.com/samber/lo"
+)
+
+// GenerateInfra renders the infra setup files for compiled and linked documents. Returns the files contents by
+// file name. Most engines produce a single file named Config.Infra.OutputFile, the helm engine produces the chart
+// files in Config.Infra.OutputFile directory.
+func GenerateInfra(cfg Config, documents map[string]*compiler.Document) (map[string]*bytes.Buffer, error) {
+	logger := log.GetLogger("")
+
+	activeProtocols := CollectActiveServersProtocols(documents)
+	logger.Debug("Collected active servers protocols", "value", activeProtocols)
+
+	// TODO: refactor RenderOpts -- it almost not needed here, it's related to codegen.
+	//       Also consider to include add InfraServerOpts (replace RenderOpts to interface in manager?)
+	renderOpts, err := RenderOpts(cfg, cfg.Code.TargetDir, false)
+	if err != nil {
+		return nil, err
+	}
+	renderManager := manager.NewTemplateRenderManager(renderOpts)
+
+	// Document objects
+	logger.Debug("Run objects rendering")
+	tplLoader, err := newTemplateLoader(cfg, renderManager, infra.TemplateFS)
+	if err != nil {
+		return nil, err
+	}
+	renderManager.TemplateLoader = tplLoader
+	allArtifacts := selector.GatherArtifacts(lo.Values(documents)...)
+	visibleArtifacts := lo.Filter(allArtifacts, func(a common.Artifact, _ int) bool {
+		return a.Visible()
+	})
+	logger.Debug("Rendering the artifacts", "allArtifacts", len(allArtifacts), "visibleArtifacts", len(visibleArtifacts))
+
+	serverConfig := InfraServerOpts(cfg.Infra.ServerOpts)
+
+	outputFiles, err := InfraOutputFiles(cfg.Infra.Engine, cfg.Infra.OutputFile)
+	if err != nil {
+		return nil, err
+	}
+
+	err = renderer.RenderInfra(visibleArtifacts, activeProtocols, cfg.Infra.Engine, outputFiles, serverConfig, renderManager)
+	if err != nil {
+		return nil, fmt.Errorf("render infra: %w", err)
+	}
+
+	states := renderManager.CommittedStates()
+	return lo.MapValues(states, func(s manager.FileRenderState, _ string) *bytes.Buffer {
+		return s.Buffer
+	}), nil
+}
+
+// InfraOutputFiles returns the files that the engine produces mapped to the names of templates that render them.
+// The empty template name means the root template.
+func InfraOutputFiles(engine, outputFile string) (map[string]string, error) {
+	switch engine {
+	case InfraEngineDocker, InfraEngineKubernetes:
+		return map[string]string{outputFile: ""}, nil
+	case InfraEngineHelm:
+		return map[string]string{
+			path.Join(outputFile, "Chart.yaml"):             "infra/helm/chart",
+			path.Join(outputFile, "values.yaml"):            "infra/helm/values",
+			path.Join(outputFile, "templates/servers.yaml"): "",
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown infra engine: %q", engine)
+}
+
+// InfraServerOpts converts the server options from the configuration to the infra render options.
+func InfraServerOpts(opts []ConfigInfraServerOpt) []common.InfraServerOpts {
+	res := make([]common.InfraServerOpts, 0)
+
+	for _, opt := range opts {
+		switch opt.Variables.Selector {
+		case 0:
+			var varGroups [][]common.InfraServerVariableOpts
+			for k, v := range opt.Variables.V0.Entries() {
+				varGroups = append(varGroups, []common.InfraServerVariableOpts{
+					{Name: k, Value: v},
+				})
+			}
+			res = append(res, common.InfraServerOpts{
+				ServerName:     opt.ServerName,
+				VariableGroups: varGroups,
+			})
+		case 1:
+			var varGroups [][]common.InfraServerVariableOpts
+			for _, g := range opt.Variables.V1 {
+				for k, v := range g.Entries() {
+					varGroups = append(varGroups, []common.InfraServerVariableOpts{
+						{Name: k, Value: v},
+					})
+				}
+			}
+			res = append(res, common.InfraServerOpts{
+				ServerName:     opt.ServerName,
+				VariableGroups: varGroups,
+			})
+		}
+	}
+	return res
+}
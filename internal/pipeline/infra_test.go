@@ -0,0 +1,344 @@
+package pipeline
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bdragon300/go-asyncapi/internal/compiler"
+	"github.com/bdragon300/go-asyncapi/internal/compiler/compile"
+	"github.com/bdragon300/go-asyncapi/internal/jsonpointer"
+	"gopkg.in/yaml.v3"
+)
+
+// kubernetesSchemaFile contains the Kubernetes OpenAPI v3 schemas of objects that infra templates produce, taken from
+// api/openapi-spec/v3 in kubernetes repository. Descriptions and defaults are stripped to keep the file small.
+const kubernetesSchemaFile = "testdata/kubernetes-openapi-v1.31.json"
+
+func TestGenerateInfraKubernetes(t *testing.T) {
+	schemas := loadKubernetesSchemas(t)
+
+	tests := []struct {
+		engine    string
+		wantFiles []string
+	}{
+		{InfraEngineKubernetes, []string{"kubernetes.yaml"}},
+		{InfraEngineHelm, []string{"chart/Chart.yaml", "chart/templates/servers.yaml", "chart/values.yaml"}},
+	}
+	for _, test := range tests {
+		t.Run(test.engine, func(t *testing.T) {
+			cfg := infraTestConfig(t)
+			cfg.Infra.Engine = test.engine
+			cfg.Infra.OutputFile = strings.TrimSuffix(test.wantFiles[0], "/Chart.yaml")
+
+			files, err := GenerateInfra(cfg, compileInfraTestDocument(t, cfg))
+			if err != nil {
+				t.Fatalf("GenerateInfra: %v", err)
+			}
+			var fileNames []string
+			for k := range files {
+				fileNames = append(fileNames, k)
+			}
+			slices.Sort(fileNames)
+			if !slices.Equal(fileNames, test.wantFiles) {
+				t.Fatalf("GenerateInfra files = %v; expected %v", fileNames, test.wantFiles)
+			}
+
+			manifests := files[test.wantFiles[0]]
+			if test.engine == InfraEngineHelm {
+				manifests = renderHelmValues(t, files["chart/templates/servers.yaml"].Bytes(), files["chart/values.yaml"].Bytes())
+			}
+			kinds := make(map[string]int)
+			for i, obj := range decodeYAMLDocuments(t, manifests.Bytes()) {
+				apiVersion, _ := obj["apiVersion"].(string)
+				kind, _ := obj["kind"].(string)
+				kinds[kind]++
+				schema, ok := schemas.byGVK(apiVersion, kind)
+				if !ok {
+					t.Errorf("document %d: unknown object %s %s", i, apiVersion, kind)
+					continue
+				}
+				for _, err := range schemas.validate(obj, schema, kind) {
+					t.Errorf("document %d: %v", i, err)
+				}
+			}
+			for _, kind := range []string{"Service", "StatefulSet", "Deployment", "PersistentVolumeClaim", "ConfigMap"} {
+				if kinds[kind] == 0 {
+					t.Errorf("no %s objects generated", kind)
+				}
+			}
+			// varServer has two variable groups in config, so it must be rendered twice
+			if n := bytes.Count(manifests.Bytes(), []byte("# Server varServer:")); n != 2 {
+				t.Errorf("expected varServer to be rendered 2 times, got %d", n)
+			}
+			// Topics are created from channel bindings by the provisioning container
+			if !bytes.Contains(manifests.Bytes(), []byte("--topic 'orders' --partitions 3 --replication-factor 1")) {
+				t.Errorf("no provisioning of kafka topic from channel bindings")
+			}
+		})
+	}
+}
+
+func TestGenerateInfraNATS(t *testing.T) {
+	schemas := loadKubernetesSchemas(t)
+	// Overlapping subjects "orders.*.events" and "orders.created.events" are merged
+	const provision = "stream add 'NATS_SERVER' --subjects 'orders,orders.*.events'"
+
+	for _, engine := range []string{InfraEngineDocker, InfraEngineKubernetes} {
+		for _, jetstream := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s jetstream=%v", engine, jetstream), func(t *testing.T) {
+				cfg := infraTestConfig(t)
+				cfg.Infra.Engine = engine
+				cfg.Infra.OutputFile = "out.yaml"
+				if jetstream {
+					cfg.Code.Implementation.Custom = []ConfigImplementationProtocol{
+						{Protocol: "nats", Name: "github.com/nats-io/nats.go/jetstream"},
+					}
+				}
+
+				files, err := GenerateInfra(cfg, compileInfraTestDocument(t, cfg))
+				if err != nil {
+					t.Fatalf("GenerateInfra: %v", err)
+				}
+				out := files["out.yaml"].Bytes()
+				// JetStream is enabled and provisioned only if the generated code uses it
+				if got := bytes.Contains(out, []byte("--jetstream")); got != jetstream {
+					t.Errorf("JetStream enabled = %v; expected %v", got, jetstream)
+				}
+				if got := bytes.Contains(out, []byte(provision)); got != jetstream {
+					t.Errorf("stream provisioned = %v; expected %v", got, jetstream)
+				}
+				if engine != InfraEngineKubernetes {
+					return
+				}
+				for i, obj := range decodeYAMLDocuments(t, out) {
+					apiVersion, _ := obj["apiVersion"].(string)
+					kind, _ := obj["kind"].(string)
+					schema, ok := schemas.byGVK(apiVersion, kind)
+					if !ok {
+						t.Errorf("document %d: unknown object %s %s", i, apiVersion, kind)
+						continue
+					}
+					for _, err := range schemas.validate(obj, schema, kind) {
+						t.Errorf("document %d: %v", i, err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// helmValueRe matches the values references in the chart templates produced by "infra/kubernetes/value" template.
+var helmValueRe = regexp.MustCompile(`{{ index \.Values\.servers "([^"]+)" "([^"]+)" }}`)
+
+// renderHelmValues substitutes the values references in the chart template with values from values.yaml, like
+// "helm template" does. Fails if the template contains other actions or the referenced value is not set.
+func renderHelmValues(t *testing.T, template, valuesFile []byte) *bytes.Buffer {
+	t.Helper()
+	var values struct {
+		Servers map[string]map[string]any `yaml:"servers"`
+	}
+	if err := yaml.Unmarshal(valuesFile, &values); err != nil {
+		t.Fatalf("decode values: %v", err)
+	}
+	if !helmValueRe.Match(template) {
+		t.Fatalf("chart template has no values references")
+	}
+	res := helmValueRe.ReplaceAllFunc(template, func(ref []byte) []byte {
+		m := helmValueRe.FindSubmatch(ref)
+		v, ok := values.Servers[string(m[1])][string(m[2])]
+		if !ok {
+			t.Errorf("value %s.%s is not set in values.yaml", m[1], m[2])
+		}
+		return []byte(fmt.Sprint(v))
+	})
+	if bytes.Contains(res, []byte("{{")) {
+		t.Fatalf("chart template contains unexpected actions")
+	}
+	// Every server in values.yaml is rendered in the chart template
+	for name, v := range values.Servers {
+		if !bytes.Contains(template, []byte(`"`+name+`"`)) {
+			t.Errorf("values of server %q are not used", name)
+		}
+		if _, ok := v["image"]; !ok {
+			t.Errorf("no image value for server %q", name)
+		}
+	}
+	return bytes.NewBuffer(res)
+}
+
+func infraTestConfig(t *testing.T) Config {
+	t.Helper()
+	defaultConf, err := DefaultConfig()
+	if err != nil {
+		t.Fatalf("DefaultConfig: %v", err)
+	}
+	userConf, err := LoadConfig(os.DirFS("testdata"), "infra-config.yaml")
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	return MergeConfig(defaultConf, userConf)
+}
+
+func compileInfraTestDocument(t *testing.T, cfg Config) map[string]*compiler.Document {
+	t.Helper()
+	docURL, err := jsonpointer.Parse("testdata/infra.yml")
+	if err != nil {
+		t.Fatalf("parse URL: %v", err)
+	}
+	compileOpts := compile.CompilationOpts{GeneratePublishers: true, GenerateSubscribers: true}
+	documents, err := CompileAndLink(NewLocator(cfg.Locator), docURL, compileOpts)
+	if err != nil {
+		t.Fatalf("CompileAndLink: %v", err)
+	}
+	return documents
+}
+
+func decodeYAMLDocuments(t *testing.T, data []byte) []map[string]any {
+	t.Helper()
+	var res []map[string]any
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var obj map[string]any
+		err := dec.Decode(&obj)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("decode yaml: %v", err)
+		}
+		if obj != nil {
+			res = append(res, obj)
+		}
+	}
+	return res
+}
+
+type kubernetesSchemas map[string]map[string]any
+
+func loadKubernetesSchemas(t *testing.T) kubernetesSchemas {
+	t.Helper()
+	data, err := os.ReadFile(kubernetesSchemaFile)
+	if err != nil {
+		t.Fatalf("read schemas: %v", err)
+	}
+	var doc struct {
+		Components struct {
+			Schemas kubernetesSchemas `json:"schemas"`
+		} `json:"components"`
+	}
+	if err = json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("parse schemas: %v", err)
+	}
+	return doc.Components.Schemas
+}
+
+// byGVK returns the schema of the top-level object by its apiVersion and kind.
+func (s kubernetesSchemas) byGVK(apiVersion, kind string) (map[string]any, bool) {
+	group, version, found := strings.Cut(apiVersion, "/")
+	if !found {
+		group, version = "", apiVersion
+	}
+	for _, schema := range s {
+		gvks, _ := schema["x-kubernetes-group-version-kind"].([]any)
+		for _, v := range gvks {
+			gvk := v.(map[string]any)
+			if gvk["group"] == group && gvk["version"] == version && gvk["kind"] == kind {
+				return schema, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// validate checks the value against the schema strictly, i.e. the object properties not defined in schema are
+// considered as errors. Supports only the schema keywords that are used in Kubernetes OpenAPI.
+func (s kubernetesSchemas) validate(value any, schema map[string]any, path string) (errs []error) {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := ref[strings.LastIndex(ref, "/")+1:]
+		refSchema, ok := s[name]
+		if !ok {
+			return []error{fmt.Errorf("%s: unknown schema ref %q", path, ref)}
+		}
+		return s.validate(value, refSchema, path)
+	}
+	if allOf, ok := schema["allOf"].([]any); ok {
+		for _, sub := range allOf {
+			errs = append(errs, s.validate(value, sub.(map[string]any), path)...)
+		}
+	}
+	if oneOf, ok := schema["oneOf"].([]any); ok {
+		matched := 0
+		for _, sub := range oneOf {
+			if len(s.validate(value, sub.(map[string]any), path)) == 0 {
+				matched++
+			}
+		}
+		if matched != 1 {
+			errs = append(errs, fmt.Errorf("%s: value %v matches %d oneOf schemas", path, value, matched))
+		}
+	}
+
+	typ, _ := schema["type"].(string)
+	switch typ {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return append(errs, fmt.Errorf("%s: expected object, got %T", path, value))
+		}
+		props, _ := schema["properties"].(map[string]any)
+		additional, _ := schema["additionalProperties"].(map[string]any)
+		for k, v := range obj {
+			switch {
+			case props[k] != nil:
+				errs = append(errs, s.validate(v, props[k].(map[string]any), path+"."+k)...)
+			case additional != nil:
+				errs = append(errs, s.validate(v, additional, path+"."+k)...)
+			default:
+				errs = append(errs, fmt.Errorf("%s: unknown field %q", path, k))
+			}
+		}
+		required, _ := schema["required"].([]any)
+		for _, k := range required {
+			if _, ok := obj[k.(string)]; !ok {
+				errs = append(errs, fmt.Errorf("%s: missing required field %q", path, k))
+			}
+		}
+	case "array":
+		arr, ok := value.([]any)
+		if !ok {
+			return append(errs, fmt.Errorf("%s: expected array, got %T", path, value))
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, v := range arr {
+				errs = append(errs, s.validate(v, items, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			errs = append(errs, fmt.Errorf("%s: expected string, got %T", path, value))
+		}
+	case "integer":
+		if _, ok := value.(int); !ok {
+			errs = append(errs, fmt.Errorf("%s: expected integer, got %T", path, value))
+		}
+	case "number":
+		switch value.(type) {
+		case int, float64:
+		default:
+			errs = append(errs, fmt.Errorf("%s: expected number, got %T", path, value))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			errs = append(errs, fmt.Errorf("%s: expected boolean, got %T", path, value))
+		}
+	}
+	return errs
+}
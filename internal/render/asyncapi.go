@@ -9,6 +9,13 @@ import (
 // DefaultContentType is the default content type to use if none is set.
 const DefaultContentType = "application/json"
 
+// AvroContentType is the content type of messages with Avro payload schema, if no content type is set explicitly.
+const AvroContentType = "application/vnd.apache.avro"
+
+// ProtobufContentType is the content type of messages with Protocol Buffers payload schema, if no content type is set
+// explicitly.
+const ProtobufContentType = "application/vnd.google.protobuf"
+
 // AsyncAPI represents the root of the AsyncAPI document.
 type AsyncAPI struct {
 	lang.BaseJSONPointed
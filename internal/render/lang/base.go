@@ -35,6 +35,8 @@ type BaseType struct {
 	Import string
 	// ArtifactKind describes what kind of artifact this type represents.
 	ArtifactKind common.ArtifactKind
+	// Constraints are optional jsonschema validation keywords of this type.
+	Constraints *SchemaConstraints
 }
 
 func (b *BaseType) Name() string {
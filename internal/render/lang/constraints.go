@@ -0,0 +1,40 @@
+package lang
+
+import "reflect"
+
+// SchemaConstraints contains the jsonschema validation keywords of a type. It is used on the rendering stage to
+// generate the Validate method of the type.
+//
+// All fields are optional, nil or zero value means that the constraint is not set.
+type SchemaConstraints struct {
+	// Minimum, Maximum are the inclusive numeric bounds.
+	Minimum *float64
+	Maximum *float64
+	// ExclusiveMinimum, ExclusiveMaximum are the exclusive numeric bounds. Draft 4 boolean form is converted
+	// to these fields on the compilation stage.
+	ExclusiveMinimum *float64
+	ExclusiveMaximum *float64
+	MultipleOf       *float64
+
+	MinLength *int
+	MaxLength *int
+	// Pattern is a regular expression the string value must match. Only patterns compatible with Go regexp syntax
+	// are kept, the rest are skipped on the compilation stage.
+	Pattern string
+
+	MinItems    *int
+	MaxItems    *int
+	UniqueItems bool
+
+	MinProperties *int
+	MaxProperties *int
+
+	// Enum is a list of allowed primitive values (bool, string, int or float64). The "const" keyword is converted to
+	// one-element Enum.
+	Enum []any
+}
+
+// IsEmpty returns true if no constraints are set.
+func (c *SchemaConstraints) IsEmpty() bool {
+	return c == nil || reflect.ValueOf(*c).IsZero()
+}
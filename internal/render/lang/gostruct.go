@@ -51,8 +51,16 @@ type GoStructField struct {
 	Description string
 	// Type is the type of the field.
 	Type common.GolangType
+	// Required is true if the field is listed in jsonschema "required" keyword, i.e. it must not be nil.
+	Required bool
+	// AlwaysValidate is true if the field is validated even if it has a zero value. Otherwise, the zero value of
+	// an optional non-pointer field is considered absent. E.g. the message payload is always sent, so it's always checked.
+	AlwaysValidate bool
 	// ContentTypesFunc callback returns a list of content types associated with the struct. Used to compose a struct tag on the rendering stage.
 	ContentTypesFunc func() []string
+	// Tags are extra tags and their values specific for this field, e.g. `protobuf:"1,int64"`. Overwrite the tags
+	// with the same name.
+	Tags types.OrderedMap[string, string]
 }
 
 func (f *GoStructField) Name() string {
@@ -111,6 +119,9 @@ func (f *GoStructField) getTags(structRenderInfo StructFieldRenderInfo) types.Or
 	for k, v := range structRenderInfo.Tags.Entries() {
 		res.Set(k, v)
 	}
+	for k, v := range f.Tags.Entries() {
+		res.Set(k, v)
+	}
 	return res
 }
 
@@ -2,7 +2,6 @@ package lang
 
 import (
 	"fmt"
-	"reflect"
 
 	"github.com/bdragon300/go-asyncapi/internal/common"
 
@@ -12,11 +11,24 @@ import (
 // UnionStruct represents a union struct, a special case of Go struct.
 //
 // Union struct is a struct that can be one of the several types.
-// This struct is used to be generated the Go code from polymorphic jsonschema parts, such as $allOf, $oneOf, $anyOf.
-// So, the data that matches such schema can be unmarshalled to the union type and addressed from the user code and
-// be marshalled back.
+// This struct is used to be generated the Go code from polymorphic jsonschema parts, such as $allOf, $oneOf, $anyOf,
+// and from Avro unions. So, the data that matches such schema can be unmarshalled to the union type and addressed
+// from the user code and be marshalled back.
 type UnionStruct struct {
 	GoStruct
+	// AvroVariants contains the Avro union branches for every field in the same order. If set, the union gets
+	// the methods that hamba/avro library uses to encode and decode the union value. Empty for jsonschema unions.
+	AvroVariants []AvroUnionVariant
+}
+
+// AvroUnionVariant is an Avro union branch.
+type AvroUnionVariant struct {
+	// Name is the full name of Avro named type, that must be registered in hamba/avro to be resolved in union.
+	// Empty for primitive types.
+	Name string
+	// DecodedType is the Go type, that hamba/avro decodes the branch value to, if it differs from the field type.
+	// E.g. Avro int is decoded to "int", whereas the field type is int32.
+	DecodedType string
 }
 
 // UnionStruct return the Go code of union struct definition.
@@ -28,15 +40,14 @@ func (s *UnionStruct) UnionStruct() common.GolangType {
 		return &s.GoStruct
 	}
 
-	// Draw union with named fields and methods
+	// Draw union with named fields and methods. Fields that have no name are named after their types.
 	strct := s.GoStruct
 	strct.Fields = lo.Map(strct.Fields, func(item GoStructField, _ int) GoStructField {
-		item.OriginalName = item.Type.Name() // FIXME: check if this is will be correct
+		if item.OriginalName == "" {
+			item.OriginalName = item.Type.Name()
+		}
 		return item
 	})
-	if reflect.DeepEqual(strct.Fields, s.Fields) { // TODO: move this check to unit tests
-		panic("Must not happen")
-	}
 	return &strct
 }
 
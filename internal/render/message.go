@@ -17,6 +17,8 @@ type Message struct {
 	OriginalName string
 	// ContentType is the message's content type if set.
 	ContentType string
+	// MessageName is the machine-friendly message name from the "name" field if set.
+	MessageName string
 
 	// Dummy is true when message is ignored (x-ignore: true)
 	Dummy bool
@@ -42,6 +44,18 @@ type Message struct {
 	// PayloadTypeDefault is a type that is used for payload in message code when payload type is not set in the document.
 	// Typically, it's ``any''.
 	PayloadTypeDefault common.GolangType
+	// PayloadSchemaFormat is the schemaFormat of payload if it is set as Multi Format Schema object.
+	PayloadSchemaFormat string
+	// PayloadContentType is the content type implied by payload schema format, e.g. [AvroContentType] for Avro schema.
+	// Empty if the schema format does not imply any content type.
+	PayloadContentType string
+	// PayloadAvroSchema is the payload Avro schema as JSON string. Empty if payload schema is not Avro.
+	PayloadAvroSchema string
+	// PayloadProtobuf is true if payload schema is Protocol Buffers.
+	PayloadProtobuf bool
+	// PayloadProtobufBound is true if payload is bound to a protoc-generated type by x-go-type. Such payload
+	// is encoded by google.golang.org/protobuf package instead of runtime protobuf codec.
+	PayloadProtobufBound bool
 
 	// AllActiveChannelsPromise contains all active channels in the document. Used to find the channels that this message
 	// is bound to on the rendering stage.
@@ -58,6 +72,22 @@ type Message struct {
 
 	// AsyncAPIPromise is an AsyncAPI root object.
 	AsyncAPIPromise *lang.Promise[*AsyncAPI]
+
+	// Examples are the message examples declared in the document.
+	Examples []MessageExample
+}
+
+// MessageExample is a message example declared in the document. Its payload and headers are kept as JSON, so that
+// they can be decoded into the message Go types in the generated code.
+type MessageExample struct {
+	// Name is the machine-friendly example name if set.
+	Name string
+	// Summary is the short example description if set.
+	Summary string
+	// Payload is the example payload encoded as JSON. Empty if payload is not set in the example.
+	Payload string
+	// Headers is the example headers object encoded as JSON. Empty if headers are not set in the example.
+	Headers string
 }
 
 // HeadersType returns a Go type of headers defined for message in the document.
@@ -100,12 +130,13 @@ func (m *Message) AsyncAPI() *AsyncAPI {
 }
 
 // EffectiveContentType returns the message's content type for the message if set or the content type of the
-// document if set or [DefaultContentType].
+// document if set. If payload schema format implies a content type (e.g. [AvroContentType] for Avro schema), returns it.
+// Otherwise, returns [DefaultContentType].
 func (m *Message) EffectiveContentType() string {
 	if m.Dummy {
 		return ""
 	}
-	res, _ := lo.Coalesce(m.ContentType, m.AsyncAPIPromise.T().EffectiveDefaultContentType())
+	res, _ := lo.Coalesce(m.ContentType, m.AsyncAPIPromise.T().DefaultContentType, m.PayloadContentType, DefaultContentType)
 	return res
 }
 
@@ -44,6 +44,10 @@ type Operation struct {
 
 	// SecuritySchemePromises is a promises to the security scheme objects defined for this operation.
 	SecuritySchemePromises []*lang.Promise[*SecurityScheme]
+
+	// DeadLetterChannelPromise is a promise to the dead-letter channel set by x-go-dead-letter-channel extra field.
+	// Nil if not set.
+	DeadLetterChannelPromise *lang.Promise[*Channel]
 }
 
 // Channel returns the Channel that this operation is bound with.
@@ -73,6 +77,14 @@ func (o *Operation) OperationReply() *OperationReply {
 	return nil
 }
 
+// DeadLetterChannel returns the dead-letter channel or nil if it's not set.
+func (o *Operation) DeadLetterChannel() *Channel {
+	if o.DeadLetterChannelPromise != nil {
+		return o.DeadLetterChannelPromise.T()
+	}
+	return nil
+}
+
 // BoundOperationReplyChannel returns a Channel bound to Operation's OperationReply.
 // If OperationReply is not set, returns nil.
 func (o *Operation) BoundOperationReplyChannel() *Channel {
@@ -195,3 +207,17 @@ func (p *ProtoOperation) String() string {
 func (p *ProtoOperation) ProtoChannel() *ProtoChannel {
 	return p.Channel().ProtoChannel(p.Protocol)
 }
+
+// DeadLetterProtoChannel returns the ProtoChannel with the same Protocol in the dead-letter channel. Returns nil if
+// the operation doesn't subscribe or if the dead-letter channel is not set, is not suitable to publish to
+// (not visible, not available for this protocol, has parameters) or is the operation channel itself.
+func (p *ProtoOperation) DeadLetterProtoChannel() *ProtoChannel {
+	c := p.DeadLetterChannel()
+	if !p.IsSubscriber || c == nil || c == p.Channel() {
+		return nil
+	}
+	if !c.Visible() || !c.IsPublisher || c.Parameters().Len() > 0 || !lo.Contains(c.ActiveProtocols(), p.Protocol) {
+		return nil
+	}
+	return c.ProtoChannel(p.Protocol)
+}
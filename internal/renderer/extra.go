@@ -18,6 +18,7 @@ import (
 
 const (
 	utilCodeSrcDir        = "util"
+	mockCodeSrcDir        = "mock"
 	unknownProtocolSrcDir = "unknown"
 )
 
@@ -76,6 +77,25 @@ func RenderUtilCode(protocols []string, opts common.RenderOpts, mng *manager.Tem
 		if err = renderCodeExtraTemplates(templates, ctx, mng, nil); err != nil {
 			return fmt.Errorf("render templates for protocol %q: %w", protocol, err)
 		}
+
+		if opts.Mocks {
+			// Mocks are placed to the util code package, since they implement its interfaces
+			if userConfig.TemplateDirectory != "" {
+				logger.Debug("-> Custom util code templates are used, skip the mocks")
+				continue
+			}
+			mockDir := getMockSourceDir(found)
+			logger.Trace("-> Mocks source directory", "srcDir", mockDir)
+			ld := tmpl.NewTemplateLoader("", tplBase)
+			mng.TemplateLoader = ld
+
+			if templates, err = ld.ParseDir(mockDir, mng); err != nil {
+				return fmt.Errorf("parse mocks templates for protocol %q: %w", protocol, err)
+			}
+			if err = renderCodeExtraTemplates(templates, ctx, mng, nil); err != nil {
+				return fmt.Errorf("render mocks templates for protocol %q: %w", protocol, err)
+			}
+		}
 	}
 	return nil
 }
@@ -126,7 +146,7 @@ func RenderImplementationCode(protocols []string, opts common.RenderOpts, mng *m
 			mng.TemplateLoader = ld
 
 			man, found := lo.Find(manifests, func(item codeextra.ImplementationManifest) bool {
-				return item.Protocol == protocol && (userConfig.Name == "" || item.Name == userConfig.Name)
+				return item.Protocol == protocol && (userConfig.Name == "" && item.Default || item.Name == userConfig.Name)
 			})
 			if !found {
 				logger.Warn("-> No implementation found for protocol, skipping", "protocol", protocol, "name", lo.CoalesceOrEmpty(userConfig.Name, "<default>"))
@@ -139,6 +159,14 @@ func RenderImplementationCode(protocols []string, opts common.RenderOpts, mng *m
 			if err != nil {
 				return fmt.Errorf("parse templates from built-in implementation %q, this is a bug: %w", man.Name, err)
 			}
+			if len(man.Include) > 0 {
+				logger.Trace("-> Including templates", "files", man.Include)
+				included, err := ld.ParseFiles(mng, man.Include...)
+				if err != nil {
+					return fmt.Errorf("parse included templates of built-in implementation %q, this is a bug: %w", man.Name, err)
+				}
+				templates = append(templates, included...)
+			}
 		}
 
 		logger.Trace("-> Templates found", "files", templates)
@@ -187,6 +215,15 @@ func getUtilSourceDir(filesystem fs.FS, protocol string) (string, bool) {
 	return path.Join(unknownProtocolSrcDir, utilCodeSrcDir), false
 }
 
+// getMockSourceDir returns the directory with util code mocks sources. The mocks are shared by all known protocols,
+// the unknown protocol has its own mocks, since its util interfaces have no bindings.
+func getMockSourceDir(knownProtocol bool) string {
+	if knownProtocol {
+		return mockCodeSrcDir
+	}
+	return path.Join(unknownProtocolSrcDir, mockCodeSrcDir)
+}
+
 // LoadImplementationsManifests loads the built-in implementations manifests file.
 func LoadImplementationsManifests(tplFS fs.FS) (codeextra.ImplementationManifests, error) {
 	f, err := tplFS.Open("manifests.yaml")
@@ -2,6 +2,9 @@ package renderer
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"text/template"
 
 	"github.com/bdragon300/go-asyncapi/internal/common"
 	"github.com/bdragon300/go-asyncapi/internal/log"
@@ -9,7 +12,16 @@ import (
 	"github.com/bdragon300/go-asyncapi/internal/tmpl/manager"
 )
 
-func RenderInfra(objects []common.Artifact, activeProtocols []string, outputFileName string, serverConfig []common.InfraServerOpts, mng *manager.TemplateRenderManager) error {
+// RenderInfra renders the infra files for the given engine. outputFiles maps the file names to the names of
+// templates that render them, the empty template name means the root template.
+func RenderInfra(
+	objects []common.Artifact,
+	activeProtocols []string,
+	engine string,
+	outputFiles map[string]string,
+	serverConfig []common.InfraServerOpts,
+	mng *manager.TemplateRenderManager,
+) error {
 	logger := log.GetLogger(log.LoggerPrefixRendering)
 
 	logger.Debug("Objects selected", "count", len(objects))
@@ -17,21 +29,31 @@ func RenderInfra(objects []common.Artifact, activeProtocols []string, outputFile
 		ServerConfig:    serverConfig,
 		Objects:         objects,
 		ActiveProtocols: activeProtocols,
+		Engine:          engine,
 	}
 
-	logger.Trace("Loading root template")
-	tpl, err := mng.TemplateLoader.LoadRootTemplate()
-	if err != nil {
-		return fmt.Errorf("load root template: %w", err)
-	}
-	mng.BeginFile(outputFileName, "main")
-	logger.Debug("Render file", "name", outputFileName)
-	if err = tpl.Execute(mng.Buffer, ctx); err != nil {
-		return fmt.Errorf("root template: %w", err)
-	}
-	mng.Commit()
+	for _, fileName := range slices.Sorted(maps.Keys(outputFiles)) {
+		var tpl *template.Template
+		var err error
+		if name := outputFiles[fileName]; name == "" {
+			logger.Trace("Loading root template")
+			tpl, err = mng.TemplateLoader.LoadRootTemplate()
+		} else {
+			logger.Trace("Loading template", "name", name)
+			tpl, err = mng.TemplateLoader.LoadTemplate(name)
+		}
+		if err != nil {
+			return fmt.Errorf("load template: %w", err)
+		}
+		mng.BeginFile(fileName, "main")
+		logger.Debug("Render file", "name", fileName)
+		if err = tpl.Execute(mng.Buffer, ctx); err != nil {
+			return fmt.Errorf("template %q: %w", tpl.Name(), err)
+		}
+		mng.Commit()
 
-	logger.Info("Infra file rendered", "file", outputFileName)
+		logger.Info("Infra file rendered", "file", fileName)
+	}
 
 	return nil
 }
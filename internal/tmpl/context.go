@@ -65,6 +65,8 @@ type InfraTemplateContext struct {
 	Objects []common.Artifact
 	// ActiveProtocols is a list of supported protocols, that are used in AsyncAPI document.
 	ActiveProtocols []string
+	// Engine is the target infra engine name, e.g. "docker".
+	Engine string
 }
 
 // ServerVariableGroups returns the server variables that are set in tool's config filtered by the server name.
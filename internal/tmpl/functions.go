@@ -7,6 +7,7 @@ import (
 	"maps"
 	"path"
 	"reflect"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -267,6 +268,15 @@ func GetTemplateFunctions(renderManager *manager.TemplateRenderManager) template
 			}
 		},
 
+		"validation": func(typ common.GolangType) (*ValidationInfo, error) {
+			traceCall("validation", typ)
+			return generateValidationCode(renderManager, typ)
+		},
+		"renderOpts": func() common.RenderOpts {
+			traceCall("renderOpts")
+			return renderManager.RenderOpts
+		},
+
 		// Template execution
 		"tmpl": func(templateName string, ctx any) (string, error) {
 			traceCall("tmpl", templateName, ctx)
@@ -303,6 +313,19 @@ func GetTemplateFunctions(renderManager *manager.TemplateRenderManager) template
 			}
 			return "..." + s[len(s)-(maxlen-3):]
 		},
+		"mergeNATSSubjects": func(subjects []any) []string {
+			traceCall("mergeNATSSubjects", subjects)
+			return mergeNATSSubjects(lo.Map(subjects, func(item any, _ int) string { return fmt.Sprint(item) }))
+		},
+		"fail": func(msg string) (string, error) {
+			traceCall("fail", msg)
+			return "", errors.New(msg)
+		},
+		"warn": func(msg string, keyvals ...any) string {
+			traceCall("warn", msg, keyvals)
+			logger.Warn(msg, keyvals...)
+			return ""
+		},
 		"debug": func(args ...any) string {
 			for _, arg := range args {
 				logger.Debugf("debug: [%[1]p][%[1]T] %[1]v", arg)
@@ -692,9 +715,17 @@ func getExtraCodeFileByProtocol(protocol string, renderManager *manager.Template
 // imported object in the generated code.
 func importExternalPackage(mng *manager.TemplateRenderManager, parts []string) string {
 	_, pkgPath, pkgName := getImportPath(parts, false)
+	// Major version suffix is not a package name, e.g. "github.com/hamba/avro/v2" has package name "avro"
+	if majorVersionRe.MatchString(pkgName) {
+		if dir := path.Base(path.Dir(pkgPath)); dir != "." && dir != "/" {
+			pkgName = dir
+		}
+	}
 	return mng.ImportsManager.AddImport(pkgPath, pkgName)
 }
 
+var majorVersionRe = regexp.MustCompile(`^v[0-9]+$`)
+
 // importRuntimeSubpackage imports the given runtime subpackage and returns its alias or package name as prefix to prepend to the
 // imported object in the generated code.
 func importRuntimeSubpackage(mng *manager.TemplateRenderManager, parts []string) string {
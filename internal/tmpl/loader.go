@@ -55,6 +55,9 @@ func (l *TemplateLoader) ParseDir(dir string, renderManager *manager.TemplateRen
 		if err != nil {
 			return nil, fmt.Errorf("glob %q: %w", fileGlob, err)
 		}
+		if len(f) == 0 {
+			continue
+		}
 		files = append(files, f...)
 		if _, err = l.tpl.ParseFS(loc, f...); err != nil {
 			return nil, err
@@ -71,6 +74,39 @@ func (l *TemplateLoader) ParseDir(dir string, renderManager *manager.TemplateRen
 	return fileNames, nil
 }
 
+// ParseFiles parses the given template files in addition to the templates parsed by ParseDir. Every file is parsed
+// in the locations that contain it, and it's an error if no location does. The template names are the file names
+// without paths, so they must not clash with already parsed ones.
+//
+// Returns the list of parsed template file names without paths.
+func (l *TemplateLoader) ParseFiles(renderManager *manager.TemplateRenderManager, files ...string) ([]string, error) {
+	if l.tpl == nil {
+		l.tpl = template.New(l.rootName).Funcs(GetTemplateFunctions(renderManager))
+	}
+	for _, file := range files {
+		var found bool
+		for _, loc := range l.locations {
+			if _, err := fs.Stat(loc, file); errors.Is(err, fs.ErrNotExist) {
+				continue
+			} else if err != nil {
+				return nil, fmt.Errorf("stat %q: %w", file, err)
+			}
+			found = true
+			if _, err := l.tpl.ParseFS(loc, file); err != nil {
+				return nil, err
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, file)
+		}
+	}
+
+	fileNames := lo.Map(files, func(fileName string, _ int) string {
+		return path.Base(fileName)
+	})
+	return fileNames, nil
+}
+
 // LoadRootTemplate returns the template with the root name, that was set on creation.
 func (l *TemplateLoader) LoadRootTemplate() (*template.Template, error) {
 	return l.LoadTemplate(l.rootName)
@@ -0,0 +1,58 @@
+package tmpl
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestTemplateLoaderParseFiles(t *testing.T) {
+	builtin := fstest.MapFS{
+		"kafka/client.tmpl":   {Data: []byte(`{{define "client"}}builtin client{{end}}`)},
+		"util/types.tmpl":     {Data: []byte(`{{define "types"}}builtin types{{end}}`)},
+		"util/interface.tmpl": {Data: []byte(`{{define "interface"}}builtin interface{{end}}`)},
+	}
+	// User location overrides one of the templates and lacks the others
+	user := fstest.MapFS{
+		"util/types.tmpl": {Data: []byte(`{{define "types"}}user types{{end}}`)},
+	}
+
+	ld := NewTemplateLoader("root", builtin, user)
+	if _, err := ld.ParseDir("kafka", nil); err != nil {
+		t.Fatalf("ParseDir() error = %v", err)
+	}
+	got, err := ld.ParseFiles(nil, "util/types.tmpl", "util/interface.tmpl")
+	if err != nil {
+		t.Fatalf("ParseFiles() error = %v", err)
+	}
+	if want := []string{"types.tmpl", "interface.tmpl"}; !slices.Equal(got, want) {
+		t.Errorf("ParseFiles() = %v, want %v", got, want)
+	}
+
+	for name, want := range map[string]string{
+		"client":    "builtin client",
+		"types":     "user types",
+		"interface": "builtin interface",
+	} {
+		tpl, err := ld.LoadTemplate(name)
+		if err != nil {
+			t.Fatalf("LoadTemplate(%q) error = %v", name, err)
+		}
+		var b strings.Builder
+		if err = tpl.Execute(&b, nil); err != nil {
+			t.Fatalf("Execute(%q) error = %v", name, err)
+		}
+		if b.String() != want {
+			t.Errorf("template %q = %q, want %q", name, b.String(), want)
+		}
+	}
+}
+
+func TestTemplateLoaderParseFilesNotFound(t *testing.T) {
+	ld := NewTemplateLoader("root", fstest.MapFS{}, fstest.MapFS{})
+	if _, err := ld.ParseFiles(nil, "util/types.tmpl"); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("ParseFiles() error = %v, want %v", err, ErrTemplateNotFound)
+	}
+}
@@ -2,6 +2,7 @@ package tmpl
 
 import (
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -46,3 +47,59 @@ func unescapeJSONPointerFragmentPart(value string) (any, error) {
 	// RFC6901 JSON Pointer unescape: replace `~1` to `/` and `~0` to `~`
 	return strings.ReplaceAll(strings.ReplaceAll(value, "~1", "/"), "~0", "~"), nil
 }
+
+// mergeNATSSubjects returns the NATS subjects with the overlapping ones merged into a wildcard subject that covers
+// them, because JetStream rejects a stream with overlapping subjects. The order of subjects is preserved, the merged
+// subject takes the place of the last one. For example, "orders.*" and "orders.created" are merged into "orders.*",
+// "items.*.new" and "items.book.*" are merged into "items.*.*".
+func mergeNATSSubjects(subjects []string) []string {
+	var merged [][]string
+	for _, subject := range subjects {
+		tokens := strings.Split(subject, ".")
+		for i := 0; i < len(merged); {
+			if !natsSubjectsOverlap(merged[i], tokens) {
+				i++
+				continue
+			}
+			tokens = mergeNATSSubjectTokens(merged[i], tokens)
+			merged = slices.Delete(merged, i, i+1)
+			i = 0 // The wider subject may overlap with the ones checked before
+		}
+		merged = append(merged, tokens)
+	}
+
+	res := make([]string, 0, len(merged))
+	for _, tokens := range merged {
+		res = append(res, strings.Join(tokens, "."))
+	}
+	return res
+}
+
+// natsSubjectsOverlap returns true if there is a subject that matches both a and b.
+func natsSubjectsOverlap(a, b []string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		switch {
+		case a[i] == ">" || b[i] == ">":
+			return true
+		case a[i] != b[i] && a[i] != "*" && b[i] != "*":
+			return false
+		}
+	}
+	return len(a) == len(b)
+}
+
+// mergeNATSSubjectTokens returns the narrowest subject that covers both overlapping subjects a and b.
+func mergeNATSSubjectTokens(a, b []string) []string {
+	var res []string
+	for i := 0; i < len(a) || i < len(b); i++ {
+		switch {
+		case i >= len(a) || i >= len(b) || a[i] == ">" || b[i] == ">":
+			return append(res, ">")
+		case a[i] == b[i]:
+			res = append(res, a[i])
+		default:
+			res = append(res, "*")
+		}
+	}
+	return res
+}
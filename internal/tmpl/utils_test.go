@@ -0,0 +1,40 @@
+package tmpl
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMergeNATSSubjects(t *testing.T) {
+	tests := []struct {
+		name     string
+		subjects []string
+		want     []string
+	}{
+		{name: "no overlap", subjects: []string{"orders.created", "orders.deleted", "items"}, want: []string{"orders.created", "orders.deleted", "items"}},
+		{name: "duplicates", subjects: []string{"orders", "orders"}, want: []string{"orders"}},
+		{name: "wildcard covers subject", subjects: []string{"orders.*", "orders.created"}, want: []string{"orders.*"}},
+		{name: "subject covered by wildcard", subjects: []string{"orders.created", "orders.*"}, want: []string{"orders.*"}},
+		{name: "partial overlap", subjects: []string{"items.*.new", "items.book.*"}, want: []string{"items.*.*"}},
+		{name: "different length", subjects: []string{"orders.*", "orders.*.created"}, want: []string{"orders.*", "orders.*.created"}},
+		{name: "full wildcard", subjects: []string{"orders.>", "orders.a.b", "orders"}, want: []string{"orders.>", "orders"}},
+		{name: "full wildcard on shorter subject", subjects: []string{"a.b.c", "a.*.>"}, want: []string{"a.*.>"}},
+		{
+			name:     "merged subject overlaps with checked before",
+			subjects: []string{"a.x.c", "a.b.*", "a.b.c"},
+			want:     []string{"a.x.c", "a.b.*"},
+		},
+		{
+			name:     "chain of merges",
+			subjects: []string{"a.x.1", "a.b.*", "a.*.1"},
+			want:     []string{"a.*.*"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeNATSSubjects(tt.subjects); !slices.Equal(got, tt.want) {
+				t.Errorf("mergeNATSSubjects(%v) = %v, want %v", tt.subjects, got, tt.want)
+			}
+		})
+	}
+}
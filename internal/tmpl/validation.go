@@ -0,0 +1,566 @@
+package tmpl
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bdragon300/go-asyncapi/internal/common"
+	"github.com/bdragon300/go-asyncapi/internal/render/lang"
+	"github.com/bdragon300/go-asyncapi/internal/tmpl/manager"
+	"github.com/bdragon300/go-asyncapi/internal/utils"
+	"github.com/samber/lo"
+)
+
+var (
+	validationNumericTypes = []string{
+		"int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64",
+	}
+	validationStringTypes = []string{"string"}
+	validationBoolTypes   = []string{"bool"}
+)
+
+// ValidationInfo contains the generated code of Validate method for a type.
+//
+// For example, for the jsonschema:
+//
+//	type: object
+//	properties:
+//	  foo:
+//	    type: string
+//	    pattern: "^[a-z]+$"
+//	required: [foo]
+//
+// the Lines will contain the code like:
+//
+//	if v.Foo == nil {
+//	    return fmt.Errorf("foo: required field is not set")
+//	}
+//	if v.Foo != nil {
+//	    if !myStructPattern0.MatchString(string((*v.Foo))) {
+//	        return fmt.Errorf("foo: must match the pattern \"^[a-z]+$\", got %q", (*v.Foo))
+//	    }
+//	}
+type ValidationInfo struct {
+	// Type is the type, which the Validate method is generated for.
+	Type common.GolangType
+	// ReceiverVar is the receiver variable name in the Validate method.
+	ReceiverVar string
+	// Patterns are the regular expressions used in the Lines. They are expected to be rendered as package-level
+	// variables, to compile them once.
+	Patterns []ValidationPattern
+	// Lines contain the Go code of Validate method body. Every check returns an error if failed.
+	Lines []string
+}
+
+// ValidationPattern is a regular expression used in validation code.
+type ValidationPattern struct {
+	// VarName is a package-level variable name keeping the compiled regexp.
+	VarName string
+	// Pattern is a regular expression.
+	Pattern string
+}
+
+// generateValidationCode returns the code of Validate method of the given type. Returns nil if the type is not
+// defined in the generated code (e.g. it is an inline type or x-go-type), so it can't have methods.
+//
+// Types that are defined in the generated code are validated by calling their Validate method, the inline types
+// are validated in place. So the code is never generated recursively for the same type twice.
+func generateValidationCode(mng *manager.TemplateRenderManager, typ common.GolangType) (*ValidationInfo, error) {
+	t := derefGolangType(typ)
+	if !hasValidateMethod(t) {
+		return nil, nil
+	}
+
+	g := validationCodeGenerator{
+		mng:              mng,
+		patternVarPrefix: utils.ToGolangName(templateGoID(mng, typ, true)+"ValidationPattern", false),
+	}
+	res := ValidationInfo{Type: typ, ReceiverVar: "v"}
+	imports := mng.ImportsManager.Clone()
+	lines, err := g.generate(t, res.ReceiverVar, "", true)
+	if err != nil {
+		return nil, fmt.Errorf("generate validation code for %s: %w", typ, err)
+	}
+	if len(lines) == 0 {
+		// Drop the imports added for the code that turned out to be not needed, e.g. for items of array without checks
+		*mng.ImportsManager = *imports
+	}
+	res.Lines = lines
+	res.Patterns = g.patterns
+
+	return &res, nil
+}
+
+type validationCodeGenerator struct {
+	mng              *manager.TemplateRenderManager
+	patternVarPrefix string
+	patterns         []ValidationPattern
+	depth            int
+}
+
+// generate returns the validation code lines for the value of type typ, that is accessible by Go expression expr.
+// pathExpr is a Go string expression with path to the value in the data model, used in error messages. Empty
+// pathExpr means the root value.
+func (g *validationCodeGenerator) generate(typ common.GolangType, expr, pathExpr string, isRoot bool) ([]string, error) {
+	switch t := derefGolangType(typ).(type) {
+	case *lang.GoPointer:
+		if !t.Type.CanBeAddressed() { // The pointer to non-addressable type is rendered as the type itself
+			return g.generate(t.Type, expr, pathExpr, isRoot)
+		}
+		lines, err := g.generate(t.Type, "(*"+expr+")", pathExpr, isRoot)
+		if err != nil || len(lines) == 0 {
+			return nil, err
+		}
+		return wrapCodeBlock(fmt.Sprintf("if %s != nil {", expr), lines), nil
+	case nil:
+		return nil, nil
+	default:
+		if !isRoot && hasValidateMethod(t) {
+			return []string{fmt.Sprintf(
+				"if err := %s.Validate(); err != nil {\n\treturn %s\n}",
+				expr, g.errorExpr(pathExpr, "%w", "err"),
+			)}, nil
+		}
+	}
+
+	switch t := derefGolangType(typ).(type) {
+	case *lang.UnionStruct:
+		return g.generateStructFields(t.Fields, nil, expr, pathExpr, func(f lang.GoStructField) string { return cmp.Or(f.OriginalName, f.Type.Name()) })
+	case *lang.GoStruct:
+		return g.generateStructFields(t.Fields, t.Constraints, expr, pathExpr, func(f lang.GoStructField) string { return f.Name() })
+	case *lang.GoArray:
+		return g.generateArray(t, expr, pathExpr)
+	case *lang.GoMap:
+		return g.generateMap(t, expr, pathExpr)
+	case *lang.GoTypeDefinition:
+		return g.generateTypeDefinition(t, expr, pathExpr)
+	}
+
+	return nil, nil
+}
+
+func (g *validationCodeGenerator) generateStructFields(
+	fields []lang.GoStructField,
+	constraints *lang.SchemaConstraints,
+	expr, pathExpr string,
+	fieldNameFunc func(f lang.GoStructField) string,
+) (res []string, err error) {
+	if c := constraints; c != nil && (c.MinProperties != nil || c.MaxProperties != nil) {
+		res = append(res, g.generatePropertiesCount(fields, c, expr, pathExpr, fieldNameFunc)...)
+	}
+
+	for _, f := range fields {
+		name := fieldNameFunc(f)
+		if name == "" {
+			continue // Embedded types are validated by their own Validate method, which is promoted to the struct
+		}
+		fieldExpr := expr + "." + name
+		fieldPath := joinValidationPath(pathExpr, lo.CoalesceOrEmpty(f.MarshalName, name))
+
+		if f.Required && isNilableType(f.Type) {
+			res = append(res, fmt.Sprintf(
+				"if %s == nil {\n\treturn %s\n}",
+				fieldExpr, g.errorExpr(fieldPath, "required field is not set"),
+			))
+		}
+		lines, err := g.generate(f.Type, fieldExpr, fieldPath, false)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", name, err)
+		}
+		// Optional fields without own constraints are not pointers, so the absent field has a zero value and must
+		// not be checked. Pointers are already checked for nil by their validation code.
+		_, isPtr := derefGolangType(f.Type).(*lang.GoPointer)
+		if !f.Required && !f.AlwaysValidate && !isPtr && len(lines) > 0 {
+			lines = wrapCodeBlock(fmt.Sprintf("if %s {", g.presenceExpr(f.Type, fieldExpr)), lines)
+		}
+		res = append(res, lines...)
+	}
+	return
+}
+
+// generatePropertiesCount returns the minProperties and maxProperties checks of a struct. The fields with zero values
+// are considered absent, the additionalProperties items are counted one by one. The code is put in a separate block
+// to avoid the variable name conflicts with the checks of nested inline structs.
+func (g *validationCodeGenerator) generatePropertiesCount(
+	fields []lang.GoStructField,
+	c *lang.SchemaConstraints,
+	expr, pathExpr string,
+	fieldNameFunc func(f lang.GoStructField) string,
+) []string {
+	lines := []string{"n := 0"}
+	for _, f := range fields {
+		name := fieldNameFunc(f)
+		if name == "" {
+			continue
+		}
+		fieldExpr := expr + "." + name
+		if _, ok := derefGolangType(f.Type).(*lang.GoMap); ok && f.MarshalName == "" { // additionalProperties
+			lines = append(lines, fmt.Sprintf("n += len(%s)", fieldExpr))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("if %s {\n\tn++\n}", g.presenceExpr(f.Type, fieldExpr)))
+	}
+	if c.MinProperties != nil {
+		lines = append(lines, g.checkExpr(
+			fmt.Sprintf("n < %d", *c.MinProperties),
+			pathExpr, fmt.Sprintf("must contain at least %d properties, got %%d", *c.MinProperties), "n",
+		))
+	}
+	if c.MaxProperties != nil {
+		lines = append(lines, g.checkExpr(
+			fmt.Sprintf("n > %d", *c.MaxProperties),
+			pathExpr, fmt.Sprintf("must contain at most %d properties, got %%d", *c.MaxProperties), "n",
+		))
+	}
+	return wrapCodeBlock("{", lines)
+}
+
+// presenceExpr returns the Go condition, that is true if the value of expr is set, i.e. it is not nil or zero.
+func (g *validationCodeGenerator) presenceExpr(typ common.GolangType, expr string) string {
+	if isNilableType(typ) {
+		return expr + " != nil"
+	}
+	switch kind, _ := g.primitiveKind(typ); kind {
+	case "number":
+		return expr + " != 0"
+	case "string":
+		return expr + ` != ""`
+	case "bool":
+		return expr
+	}
+	return fmt.Sprintf("!%sValueOf(%s).IsZero()", g.pkg("reflect"), expr)
+}
+
+func (g *validationCodeGenerator) generateArray(t *lang.GoArray, expr, pathExpr string) (res []string, err error) {
+	if c := t.Constraints; c != nil {
+		if c.MinItems != nil {
+			res = append(res, g.checkExpr(
+				fmt.Sprintf("len(%s) < %d", expr, *c.MinItems),
+				pathExpr, fmt.Sprintf("must contain at least %d items, got %%d", *c.MinItems), "len("+expr+")",
+			))
+		}
+		if c.MaxItems != nil {
+			res = append(res, g.checkExpr(
+				fmt.Sprintf("len(%s) > %d", expr, *c.MaxItems),
+				pathExpr, fmt.Sprintf("must contain at most %d items, got %%d", *c.MaxItems), "len("+expr+")",
+			))
+		}
+		if c.UniqueItems {
+			lines, err := g.generateUniqueItems(t.ItemsType, expr, pathExpr)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, lines...)
+		}
+	}
+
+	g.depth++
+	defer func() { g.depth-- }()
+	idxVar, itemVar := fmt.Sprintf("i%d", g.depth), fmt.Sprintf("v%d", g.depth)
+	itemPath := g.indexValidationPath(pathExpr, "[%d]", idxVar)
+	lines, err := g.generate(t.ItemsType, itemVar, itemPath, false)
+	if err != nil {
+		return nil, fmt.Errorf("items: %w", err)
+	}
+	if len(lines) > 0 {
+		res = append(res, wrapCodeBlock(fmt.Sprintf("for %s, %s := range %s {", idxVar, itemVar, expr), lines)...)
+	}
+	return
+}
+
+func (g *validationCodeGenerator) generateUniqueItems(itemsType common.GolangType, expr, pathExpr string) ([]string, error) {
+	if _, ok := g.primitiveKind(itemsType); !ok {
+		return nil, nil // Checking for uniqueness is supported only for comparable primitive types
+	}
+	usage, err := templateGoUsage(g.mng, itemsType)
+	if err != nil {
+		return nil, err
+	}
+
+	g.depth++
+	defer func() { g.depth-- }()
+	seenVar, itemVar := fmt.Sprintf("seen%d", g.depth), fmt.Sprintf("v%d", g.depth)
+	lines := []string{
+		fmt.Sprintf("if _, ok := %s[%s]; ok {\n\treturn %s\n}", seenVar, itemVar, g.errorExpr(pathExpr, "items must be unique, got duplicate %v", itemVar)),
+		fmt.Sprintf("%s[%s] = struct{}{}", seenVar, itemVar),
+	}
+	return append(
+		[]string{fmt.Sprintf("%s := make(map[%s]struct{}, len(%s))", seenVar, usage, expr)},
+		wrapCodeBlock(fmt.Sprintf("for _, %s := range %s {", itemVar, expr), lines)...,
+	), nil
+}
+
+func (g *validationCodeGenerator) generateMap(t *lang.GoMap, expr, pathExpr string) (res []string, err error) {
+	// minProperties and maxProperties are checked on the struct level, because the map keeps only additionalProperties
+	g.depth++
+	defer func() { g.depth-- }()
+	keyVar, valueVar := fmt.Sprintf("k%d", g.depth), fmt.Sprintf("v%d", g.depth)
+	valuePath := g.indexValidationPath(pathExpr, "[%v]", keyVar)
+	lines, err := g.generate(t.ValueType, valueVar, valuePath, false)
+	if err != nil {
+		return nil, fmt.Errorf("values: %w", err)
+	}
+	if len(lines) > 0 {
+		res = append(res, wrapCodeBlock(fmt.Sprintf("for %s, %s := range %s {", keyVar, valueVar, expr), lines)...)
+	}
+	return
+}
+
+func (g *validationCodeGenerator) generateTypeDefinition(t *lang.GoTypeDefinition, expr, pathExpr string) (res []string, err error) {
+	c := t.Constraints
+	if c.IsEmpty() {
+		return nil, nil
+	}
+	kind, ok := g.primitiveKind(t.RedefinedType)
+	if !ok {
+		return nil, nil // Constraints are not applicable to non-primitive types, such as time.Time, net.IP, etc.
+	}
+
+	switch kind {
+	case "number":
+		numExpr := "float64(" + expr + ")"
+		bounds := []struct {
+			value *float64
+			op    string
+			msg   string
+		}{
+			{c.Minimum, "<", "must be >= %v"},
+			{c.ExclusiveMinimum, "<=", "must be > %v"},
+			{c.Maximum, ">", "must be <= %v"},
+			{c.ExclusiveMaximum, ">=", "must be < %v"},
+		}
+		for _, b := range bounds {
+			if b.value == nil {
+				continue
+			}
+			res = append(res, g.checkExpr(
+				fmt.Sprintf("%s %s %s", numExpr, b.op, toGoLiteral(*b.value)),
+				pathExpr, fmt.Sprintf(b.msg, *b.value)+", got %v", expr,
+			))
+		}
+		if c.MultipleOf != nil && *c.MultipleOf != 0 {
+			m := *c.MultipleOf
+			msg := fmt.Sprintf("must be a multiple of %v, got %%v", m)
+			if g.isInteger(t.RedefinedType) && m == math.Trunc(m) {
+				res = append(res, g.checkExpr(fmt.Sprintf("int64(%s)%%%d != 0", expr, int64(m)), pathExpr, msg, expr))
+			} else {
+				// Float division is inexact (e.g. 0.3 / 0.1 == 2.9999999999999996), so compare with a tolerance
+				res = append(res, fmt.Sprintf(
+					"if q := %s / %s; %sAbs(q-%sRound(q)) > 1e-9 {\n\treturn %s\n}",
+					numExpr, toGoLiteral(m), g.pkg("math"), g.pkg("math"), g.errorExpr(pathExpr, msg, expr),
+				))
+			}
+		}
+	case "string":
+		strExpr := "string(" + expr + ")"
+		if c.MinLength != nil || c.MaxLength != nil {
+			lenExpr := g.pkg("unicode/utf8") + "RuneCountInString(" + strExpr + ")"
+			if c.MinLength != nil {
+				res = append(res, g.checkExpr(
+					fmt.Sprintf("%s < %d", lenExpr, *c.MinLength),
+					pathExpr, fmt.Sprintf("length must be >= %d, got %%q", *c.MinLength), expr,
+				))
+			}
+			if c.MaxLength != nil {
+				res = append(res, g.checkExpr(
+					fmt.Sprintf("%s > %d", lenExpr, *c.MaxLength),
+					pathExpr, fmt.Sprintf("length must be <= %d, got %%q", *c.MaxLength), expr,
+				))
+			}
+		}
+		if c.Pattern != "" {
+			varName := fmt.Sprintf("%s%d", g.patternVarPrefix, len(g.patterns))
+			g.patterns = append(g.patterns, ValidationPattern{VarName: varName, Pattern: c.Pattern})
+			res = append(res, g.checkExpr(
+				fmt.Sprintf("!%s.MatchString(%s)", varName, strExpr),
+				pathExpr, fmt.Sprintf("must match the pattern %q, got %%q", escapeFormatVerbs(c.Pattern)), expr,
+			))
+		}
+	}
+
+	if lines := g.generateEnum(c.Enum, kind, expr, pathExpr); len(lines) > 0 {
+		res = append(res, lines...)
+	}
+	return
+}
+
+func (g *validationCodeGenerator) generateEnum(enum []any, kind, expr, pathExpr string) []string {
+	var values []string
+	var goType string
+	for _, v := range enum {
+		switch vv := v.(type) {
+		case int:
+			if kind == "number" {
+				values = append(values, toGoLiteral(float64(vv)))
+			}
+		case float64:
+			if kind == "number" {
+				values = append(values, toGoLiteral(vv))
+			}
+		case string:
+			if kind == "string" {
+				values = append(values, toGoLiteral(vv))
+			}
+		case bool:
+			if kind == "bool" {
+				values = append(values, toGoLiteral(vv))
+			}
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	goType = lo.Ternary(kind == "number", "float64", kind)
+	return []string{g.checkExpr(
+		fmt.Sprintf("!%sContains([]%s{%s}, %s(%s))", g.pkg("slices"), goType, strings.Join(values, ", "), goType, expr),
+		pathExpr, fmt.Sprintf("must be one of %v, got %%v", escapeFormatVerbs(fmt.Sprint(enum))), expr,
+	)}
+}
+
+// primitiveKind returns the kind of primitive type the given type is rendered to: "number", "string" or "bool".
+// Returns false if the type is not primitive (e.g. time.Time for "date-time" format).
+func (g *validationCodeGenerator) primitiveKind(typ common.GolangType) (string, bool) {
+	var simple *lang.GoSimple
+	switch t := derefGolangType(typ).(type) {
+	case *lang.GoSimple:
+		simple = t
+	case *lang.GoTypeDefinition:
+		s, ok := derefGolangType(t.RedefinedType).(*lang.GoSimple)
+		if !ok {
+			return "", false
+		}
+		simple = s
+	default:
+		return "", false
+	}
+	if simple.IsInterface || simple.Import != "" {
+		return "", false
+	}
+	// The type format may change the rendered type, so we check the rendered usage instead of type name
+	usage, err := templateGoUsage(g.mng, simple)
+	if err != nil {
+		return "", false
+	}
+	switch {
+	case slices.Contains(validationNumericTypes, usage):
+		return "number", true
+	case slices.Contains(validationStringTypes, usage):
+		return "string", true
+	case slices.Contains(validationBoolTypes, usage):
+		return "bool", true
+	}
+	return "", false
+}
+
+// isInteger returns true if the given type is rendered to a Go integer type.
+func (g *validationCodeGenerator) isInteger(typ common.GolangType) bool {
+	if t, ok := derefGolangType(typ).(*lang.GoTypeDefinition); ok {
+		typ = t.RedefinedType
+	}
+	usage, err := templateGoUsage(g.mng, derefGolangType(typ))
+	if err != nil {
+		return false
+	}
+	return slices.Contains(validationNumericTypes, usage) && !strings.HasPrefix(usage, "float")
+}
+
+// checkExpr returns the code, that returns an error if the condition is true.
+func (g *validationCodeGenerator) checkExpr(cond, pathExpr, msgFormat string, args ...string) string {
+	return fmt.Sprintf("if %s {\n\treturn %s\n}", cond, g.errorExpr(pathExpr, msgFormat, args...))
+}
+
+// errorExpr returns the fmt.Errorf call expression with path prefix in error message.
+func (g *validationCodeGenerator) errorExpr(pathExpr, msgFormat string, args ...string) string {
+	if s, err := strconv.Unquote(pathExpr); err == nil {
+		// Path is known on generation stage, so put it in the message as is
+		msgFormat = escapeFormatVerbs(s) + ": " + msgFormat
+	} else if pathExpr != "" {
+		msgFormat = "%s: " + msgFormat
+		args = append([]string{pathExpr}, args...)
+	}
+	return fmt.Sprintf("%sErrorf(%s)", g.pkg("fmt"), strings.Join(append([]string{strconv.Quote(msgFormat)}, args...), ", "))
+}
+
+// indexValidationPath returns the Go string expression, that appends the index or key to the parent path expression.
+func (g *validationCodeGenerator) indexValidationPath(parentExpr, indexFormat, indexVar string) string {
+	if s, err := strconv.Unquote(parentExpr); err == nil {
+		return fmt.Sprintf("%sSprintf(%s, %s)", g.pkg("fmt"), strconv.Quote(escapeFormatVerbs(s)+indexFormat), indexVar)
+	}
+	if parentExpr == "" {
+		return fmt.Sprintf("%sSprintf(%q, %s)", g.pkg("fmt"), indexFormat, indexVar)
+	}
+	return fmt.Sprintf("%sSprintf(%q, %s, %s)", g.pkg("fmt"), "%s"+indexFormat, parentExpr, indexVar)
+}
+
+func (g *validationCodeGenerator) pkg(pkgPath string) string {
+	return importExternalPackage(g.mng, []string{pkgPath}) + "."
+}
+
+// hasValidateMethod returns true if the Validate method is generated for the given type.
+func hasValidateMethod(typ common.GolangType) bool {
+	var base *lang.BaseType
+	switch t := typ.(type) {
+	case *lang.UnionStruct:
+		base = &t.BaseType
+	case *lang.GoStruct:
+		base = &t.BaseType
+	case *lang.GoArray:
+		base = &t.BaseType
+	case *lang.GoMap:
+		base = &t.BaseType
+	case *lang.GoTypeDefinition:
+		base = &t.BaseType
+	default:
+		return false
+	}
+	return base.HasDefinition && base.Import == ""
+}
+
+// isNilableType returns true if the value of the given type may be nil.
+func isNilableType(typ common.GolangType) bool {
+	switch t := derefGolangType(typ).(type) {
+	case *lang.GoPointer:
+		return t.Type.CanBeAddressed() || isNilableType(t.Type)
+	case *lang.GoMap:
+		return true
+	case *lang.GoArray:
+		return t.Size == 0
+	case *lang.GoSimple:
+		return t.IsInterface
+	}
+	return false
+}
+
+func derefGolangType(typ common.GolangType) common.GolangType {
+	if v, ok := typ.(golangReferenceType); ok {
+		return v.DerefGolangType()
+	}
+	return typ
+}
+
+// joinValidationPath returns the Go string expression, that joins the parent path expression and the field name.
+func joinValidationPath(parentExpr, fieldName string) string {
+	if parentExpr == "" {
+		return strconv.Quote(fieldName)
+	}
+	if s, err := strconv.Unquote(parentExpr); err == nil {
+		return strconv.Quote(s + "." + fieldName)
+	}
+	return parentExpr + " + " + strconv.Quote("."+fieldName)
+}
+
+// escapeFormatVerbs escapes the percent signs in s to use it in format string.
+func escapeFormatVerbs(s string) string {
+	return strings.ReplaceAll(s, "%", "%%")
+}
+
+func wrapCodeBlock(header string, lines []string) []string {
+	return append(append([]string{header}, lines...), "}")
+}
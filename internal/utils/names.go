@@ -32,7 +32,7 @@ var initialisms = []string{
 	"Qps", "Ram", "Rhs", "Rpc", "Sla", "Smtp", "Sql", "Ssh", "Tcp", "Tls", "Ttl", "Udp", "Ui", "Uid", "Uuid", "Uri",
 	"Url", "Utf8", "Vm", "Xml", "Xmpp", "Xsrf", "Xss",
 	// Additional initialisms used in the generated code
-	"Amqp", "Ip", "Mqtt",
+	"Amqp", "Ip", "Mqtt", "Sns", "Sqs", "Sse",
 }
 var initialismsTrie = ahocorasick.NewTrieBuilder().AddStrings(initialisms).Build()
 
@@ -0,0 +1,153 @@
+package writer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bdragon300/go-asyncapi/internal/log"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// FileDriftKind is the kind of difference between the generated file and the file on disk.
+type FileDriftKind string
+
+const (
+	// FileDriftAdded means the file is generated, but does not exist on disk.
+	FileDriftAdded FileDriftKind = "added"
+	// FileDriftRemoved means the generated Go file exists on disk, but it is not generated anymore.
+	FileDriftRemoved FileDriftKind = "removed"
+	// FileDriftChanged means the file contents on disk differ from the generated ones.
+	FileDriftChanged FileDriftKind = "changed"
+)
+
+// FileDrift is a difference between the generated file and the file on disk.
+type FileDrift struct {
+	// Name is file name relative to the base directory.
+	Name string
+	Kind FileDriftKind
+	// Diff is the unified diff of the file on disk against the generated contents.
+	Diff string
+}
+
+// CompareBuffersWithFiles compares the buffers by file name with the files in the baseDir directory, nothing is
+// written. Returns the differences sorted by file name.
+//
+// Besides the added and changed files, the Go files in baseDir that have the "Code generated ... DO NOT EDIT." comment,
+// but are not among the buffers, are reported as removed. Other files in baseDir are not considered.
+func CompareBuffersWithFiles(files map[string]*bytes.Buffer, baseDir string) ([]FileDrift, error) {
+	logger := log.GetLogger(log.LoggerPrefixWriting)
+
+	var res []FileDrift
+	for _, fileName := range slices.Sorted(maps.Keys(files)) {
+		fullPath := path.Join(baseDir, fileName)
+		contents, err := os.ReadFile(fullPath)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			logger.Debug("-> File is added", "name", fullPath)
+			d, err := unifiedDiff(nil, files[fileName].Bytes(), "/dev/null", fullPath)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, FileDrift{Name: fileName, Kind: FileDriftAdded, Diff: d})
+		case err != nil:
+			return nil, err
+		case !bytes.Equal(contents, files[fileName].Bytes()):
+			logger.Debug("-> File is changed", "name", fullPath)
+			d, err := unifiedDiff(contents, files[fileName].Bytes(), fullPath, fullPath)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, FileDrift{Name: fileName, Kind: FileDriftChanged, Diff: d})
+		default:
+			logger.Trace("-> File is unchanged", "name", fullPath)
+		}
+	}
+
+	stale, err := findStaleGeneratedFiles(files, baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("find stale files: %w", err)
+	}
+	for _, fileName := range stale {
+		fullPath := path.Join(baseDir, fileName)
+		logger.Debug("-> File is removed", "name", fullPath)
+		contents, err := os.ReadFile(fullPath)
+		if err != nil {
+			return nil, err
+		}
+		d, err := unifiedDiff(contents, nil, fullPath, "/dev/null")
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, FileDrift{Name: fileName, Kind: FileDriftRemoved, Diff: d})
+	}
+	slices.SortStableFunc(res, func(a, b FileDrift) int { return strings.Compare(a.Name, b.Name) })
+
+	logger.Info("Comparing complete", "files", len(files), "differences", len(res))
+	return res, nil
+}
+
+// findStaleGeneratedFiles returns the names of generated Go files in baseDir, that are not among the buffers.
+func findStaleGeneratedFiles(files map[string]*bytes.Buffer, baseDir string) ([]string, error) {
+	var res []string
+	err := filepath.WalkDir(baseDir, func(p string, d fs.DirEntry, err error) error {
+		switch {
+		case errors.Is(err, fs.ErrNotExist) && p == baseDir:
+			return fs.SkipAll
+		case err != nil:
+			return err
+		case d.IsDir() || !strings.HasSuffix(p, ".go"):
+			return nil
+		}
+		rel, err := filepath.Rel(baseDir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if _, ok := files[rel]; ok {
+			return nil
+		}
+		if isGeneratedGoFile(p) {
+			res = append(res, rel)
+		}
+		return nil
+	})
+	return res, err
+}
+
+// isGeneratedGoFile returns true if the Go file has the "Code generated ... DO NOT EDIT." comment according to
+// Go conventions. Returns false if the file could not be parsed.
+func isGeneratedGoFile(fileName string) bool {
+	f, err := parser.ParseFile(token.NewFileSet(), fileName, nil, parser.PackageClauseOnly|parser.ParseComments)
+	if err != nil {
+		return false
+	}
+	return ast.IsGenerated(f)
+}
+
+func unifiedDiff(a, b []byte, fromFile, toFile string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(a),
+		B:        splitLines(b),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+}
+
+func splitLines(b []byte) []string {
+	if len(b) == 0 {
+		return nil
+	}
+	return difflib.SplitLines(string(b))
+}
@@ -0,0 +1,129 @@
+package writer
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const generatedHeader = "// Code generated by go-asyncapi tool. DO NOT EDIT.\n\n"
+
+func TestCompareBuffersWithFiles(t *testing.T) {
+	tests := []struct {
+		name      string
+		onDisk    map[string]string
+		generated map[string]string
+		// noBaseDir removes the base directory before comparison
+		noBaseDir bool
+		want      []string
+		// wantDiff are the substrings expected in diffs by file name
+		wantDiff map[string][]string
+	}{
+		{
+			name:      "unchanged",
+			onDisk:    map[string]string{"a.go": generatedHeader + "package a\n", "sub/b.go": generatedHeader + "package sub\n"},
+			generated: map[string]string{"a.go": generatedHeader + "package a\n", "sub/b.go": generatedHeader + "package sub\n"},
+			want:      nil,
+		},
+		{
+			name:      "added",
+			onDisk:    map[string]string{},
+			generated: map[string]string{"sub/a.go": "package sub\n"},
+			want:      []string{"added sub/a.go"},
+			wantDiff:  map[string][]string{"sub/a.go": {"--- /dev/null", "+package sub"}},
+		},
+		{
+			name:      "base directory does not exist",
+			generated: map[string]string{"a.go": "package a\n", "b.yaml": "key: value\n"},
+			noBaseDir: true,
+			want:      []string{"added a.go", "added b.yaml"},
+		},
+		{
+			name:      "changed",
+			onDisk:    map[string]string{"a.go": "package a\n\nvar x = 1\n"},
+			generated: map[string]string{"a.go": "package a\n\nvar x = 2\n"},
+			want:      []string{"changed a.go"},
+			wantDiff:  map[string][]string{"a.go": {"-var x = 1", "+var x = 2", " package a"}},
+		},
+		{
+			name: "removed generated file",
+			onDisk: map[string]string{
+				"a.go":       generatedHeader + "package a\n",
+				"sub/old.go": generatedHeader + "package sub\n",
+			},
+			generated: map[string]string{"a.go": generatedHeader + "package a\n"},
+			want:      []string{"removed sub/old.go"},
+			wantDiff:  map[string][]string{"sub/old.go": {"+++ /dev/null", "-package sub"}},
+		},
+		{
+			name: "user files are not removed",
+			onDisk: map[string]string{
+				"user.go":         "package a\n",
+				"broken.go":       generatedHeader + "this is not Go\n",
+				"generated.txt":   generatedHeader,
+				"comment_late.go": "package a\n\n// Code generated by go-asyncapi tool. DO NOT EDIT.\n",
+			},
+			generated: map[string]string{},
+			want:      nil,
+		},
+		{
+			name:      "sorted by name",
+			onDisk:    map[string]string{"b.go": "package b\n", "c.go": generatedHeader + "package c\n"},
+			generated: map[string]string{"b.go": "package b2\n", "a.go": "package a\n"},
+			want:      []string{"added a.go", "changed b.go", "removed c.go"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseDir := filepath.Join(t.TempDir(), "out")
+			for name, contents := range tt.onDisk {
+				fullPath := filepath.Join(baseDir, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(fullPath, []byte(contents), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if !tt.noBaseDir {
+				if err := os.MkdirAll(baseDir, 0o755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			files := make(map[string]*bytes.Buffer)
+			for name, contents := range tt.generated {
+				files[name] = bytes.NewBufferString(contents)
+			}
+
+			drifts, err := CompareBuffersWithFiles(files, baseDir)
+			if err != nil {
+				t.Fatalf("CompareBuffersWithFiles() error = %v", err)
+			}
+			var got []string
+			for _, d := range drifts {
+				got = append(got, string(d.Kind)+" "+d.Name)
+				for _, want := range tt.wantDiff[d.Name] {
+					if !strings.Contains(d.Diff, want) {
+						t.Errorf("diff of %s has no %q:\n%s", d.Name, want, d.Diff)
+					}
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("CompareBuffersWithFiles() = %v, want %v", got, tt.want)
+			}
+
+			// Nothing is written
+			for name := range tt.generated {
+				if _, ok := tt.onDisk[name]; ok {
+					continue
+				}
+				if _, err = os.Stat(filepath.Join(baseDir, filepath.FromSlash(name))); !os.IsNotExist(err) {
+					t.Errorf("file %s is written, stat error = %v", name, err)
+				}
+			}
+		})
+	}
+}
@@ -3,8 +3,10 @@ package writer
 import (
 	"bytes"
 	"fmt"
+	"maps"
 	"os"
 	"path"
+	"slices"
 
 	"github.com/bdragon300/go-asyncapi/internal/log"
 )
@@ -37,6 +39,51 @@ func WriteBuffersToFiles(files map[string]*bytes.Buffer, baseDir string) error {
 	return nil
 }
 
+// WriteChangedBuffersToFiles is like [WriteBuffersToFiles], but writes only the files which contents differ from
+// the buffers. The unchanged files are left untouched, so their modification time remains the same. Returns the names
+// of the written files.
+func WriteChangedBuffersToFiles(files map[string]*bytes.Buffer, baseDir string) ([]string, error) {
+	logger := log.GetLogger(log.LoggerPrefixWriting)
+
+	if err := ensureDir(baseDir); err != nil {
+		return nil, err
+	}
+	var written []string
+	for _, fileName := range slices.Sorted(maps.Keys(files)) {
+		buf := files[fileName]
+		fullPath := path.Join(baseDir, fileName)
+		if contents, err := os.ReadFile(fullPath); err == nil && bytes.Equal(contents, buf.Bytes()) {
+			logger.Trace("-> File is unchanged, skip", "name", fullPath)
+			continue
+		}
+		if err := ensureDir(path.Dir(fullPath)); err != nil {
+			return written, err
+		}
+		if err := os.WriteFile(fullPath, buf.Bytes(), 0o644); err != nil {
+			return written, err
+		}
+		logger.Debug("-> File wrote", "name", fullPath, "bytes", buf.Len())
+		written = append(written, fileName)
+	}
+
+	logger.Info("Writing complete", "files", len(written), "unchanged", len(files)-len(written))
+	return written, nil
+}
+
+// RemoveFiles removes the files with given names in the baseDir directory. Files that don't exist are skipped.
+func RemoveFiles(fileNames []string, baseDir string) error {
+	logger := log.GetLogger(log.LoggerPrefixWriting)
+
+	for _, fileName := range fileNames {
+		fullPath := path.Join(baseDir, fileName)
+		if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		logger.Debug("-> File removed", "name", fullPath)
+	}
+	return nil
+}
+
 // ensureDir ensures that the directory at the given path exists. If not, creates it recursively.
 func ensureDir(path string) error {
 	if info, err := os.Stat(path); os.IsNotExist(err) {
@@ -0,0 +1,160 @@
+package writer
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWriteChangedBuffersToFiles(t *testing.T) {
+	tests := []struct {
+		name      string
+		onDisk    map[string]string
+		generated map[string]string
+		// noBaseDir removes the base directory before writing
+		noBaseDir bool
+		want      []string
+	}{
+		{
+			name:      "unchanged",
+			onDisk:    map[string]string{"a.go": "package a\n", "sub/b.go": "package sub\n"},
+			generated: map[string]string{"a.go": "package a\n", "sub/b.go": "package sub\n"},
+			want:      nil,
+		},
+		{
+			name:      "base directory does not exist",
+			generated: map[string]string{"sub/a.go": "package sub\n", "b.yaml": "key: value\n"},
+			noBaseDir: true,
+			want:      []string{"b.yaml", "sub/a.go"},
+		},
+		{
+			name:      "changed and added",
+			onDisk:    map[string]string{"a.go": "package a\n", "b.go": "package b\n"},
+			generated: map[string]string{"a.go": "package a\n", "b.go": "package b2\n", "new/c.go": "package c\n"},
+			want:      []string{"b.go", "new/c.go"},
+		},
+		{
+			name:      "other files are left",
+			onDisk:    map[string]string{"user.go": "package a\n"},
+			generated: map[string]string{"a.go": "package a\n"},
+			want:      []string{"a.go"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseDir := filepath.Join(t.TempDir(), "out")
+			writeTestFiles(t, baseDir, tt.onDisk)
+			if !tt.noBaseDir {
+				if err := os.MkdirAll(baseDir, 0o755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			// Set the modification time in the past to check that unchanged files are not touched
+			mtime := time.Now().Add(-time.Hour).Truncate(time.Second)
+			for name := range tt.onDisk {
+				if err := os.Chtimes(filepath.Join(baseDir, filepath.FromSlash(name)), mtime, mtime); err != nil {
+					t.Fatal(err)
+				}
+			}
+			files := make(map[string]*bytes.Buffer)
+			for name, contents := range tt.generated {
+				files[name] = bytes.NewBufferString(contents)
+			}
+
+			got, err := WriteChangedBuffersToFiles(files, baseDir)
+			if err != nil {
+				t.Fatalf("WriteChangedBuffersToFiles() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("WriteChangedBuffersToFiles() = %v, want %v", got, tt.want)
+			}
+
+			for name, contents := range tt.onDisk {
+				if _, ok := tt.generated[name]; !ok {
+					assertTestFile(t, baseDir, name, contents)
+				}
+			}
+			for name, contents := range tt.generated {
+				assertTestFile(t, baseDir, name, contents)
+				if slices.Contains(got, name) {
+					continue
+				}
+				info, err := os.Stat(filepath.Join(baseDir, filepath.FromSlash(name)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !info.ModTime().Equal(mtime) {
+					t.Errorf("unchanged file %s modification time = %v, want %v", name, info.ModTime(), mtime)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteChangedBuffersToFilesError(t *testing.T) {
+	baseDir := t.TempDir()
+	// File in place of directory
+	writeTestFiles(t, baseDir, map[string]string{"sub": "not a directory"})
+	files := map[string]*bytes.Buffer{
+		"a.go":     bytes.NewBufferString("package a\n"),
+		"sub/b.go": bytes.NewBufferString("package sub\n"),
+	}
+
+	got, err := WriteChangedBuffersToFiles(files, baseDir)
+	if err == nil {
+		t.Fatal("WriteChangedBuffersToFiles() error = nil, want error")
+	}
+	// Files written before the error are returned
+	if want := []string{"a.go"}; !slices.Equal(got, want) {
+		t.Errorf("WriteChangedBuffersToFiles() = %v, want %v", got, want)
+	}
+}
+
+func TestRemoveFiles(t *testing.T) {
+	baseDir := t.TempDir()
+	writeTestFiles(t, baseDir, map[string]string{"a.go": "package a\n", "sub/b.go": "package sub\n", "c.go": "package c\n"})
+
+	if err := RemoveFiles([]string{"a.go", "sub/b.go", "missing.go"}, baseDir); err != nil {
+		t.Fatalf("RemoveFiles() error = %v", err)
+	}
+	for _, name := range []string{"a.go", "sub/b.go"} {
+		if _, err := os.Stat(filepath.Join(baseDir, filepath.FromSlash(name))); !os.IsNotExist(err) {
+			t.Errorf("file %s is not removed, stat error = %v", name, err)
+		}
+	}
+	assertTestFile(t, baseDir, "c.go", "package c\n")
+
+	// Non-empty directory can't be removed
+	writeTestFiles(t, baseDir, map[string]string{"dir/d.go": "package dir\n"})
+	if err := RemoveFiles([]string{"dir"}, baseDir); err == nil {
+		t.Error("RemoveFiles() error = nil, want error")
+	}
+}
+
+func writeTestFiles(t *testing.T, baseDir string, files map[string]string) {
+	t.Helper()
+	for name, contents := range files {
+		fullPath := filepath.Join(baseDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func assertTestFile(t *testing.T, baseDir, name, want string) {
+	t.Helper()
+	got, err := os.ReadFile(filepath.Join(baseDir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Errorf("read file %s: %v", name, err)
+		return
+	}
+	if string(got) != want {
+		t.Errorf("file %s = %q, want %q", name, got, want)
+	}
+}
@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/bdragon300/go-asyncapi/internal/pipeline"
+)
+
+// Config is the generator configuration. It has the same structure and options as the go-asyncapi YAML configuration
+// file. See the configuration reference in docs for options description.
+type Config = pipeline.Config
+
+// Config sections. They are declared here only to be able to fill the [Config] in code.
+type (
+	LocatorConfig                = pipeline.ConfigLocator
+	CodeConfig                   = pipeline.ConfigCode
+	CodeLayoutConfig             = pipeline.ConfigCodeLayout
+	CodeLayoutRenderConfig       = pipeline.ConfigLayoutRender
+	CodeUtilConfig               = pipeline.ConfigCodeUtil
+	CodeUtilProtocolConfig       = pipeline.ConfigCodeUtilProtocol
+	CodeImplementationConfig     = pipeline.ConfigCodeImplementation
+	ImplementationProtocolConfig = pipeline.ConfigImplementationProtocol
+	ClientConfig                 = pipeline.ConfigClient
+	InfraConfig                  = pipeline.ConfigInfra
+	InfraServerOptConfig         = pipeline.ConfigInfraServerOpt
+	DiagramConfig                = pipeline.ConfigDiagram
+	DiagramD2Config              = pipeline.ConfigDiagramD2Opts
+	DiagramD2ELKConfig           = pipeline.ConfigDiagramD2ELKOpts
+	DiagramD2DagreConfig         = pipeline.ConfigDiagramD2DagreOpts
+	UIConfig                     = pipeline.ConfigUI
+)
+
+// DefaultConfig returns the built-in configuration, the same that go-asyncapi tool uses by default.
+func DefaultConfig() Config {
+	res, err := pipeline.DefaultConfig()
+	if err != nil {
+		panic(fmt.Sprintf("load built-in config, this is a bug: %v", err))
+	}
+	return res
+}
+
+// ReadConfig reads and parses the YAML configuration file contents from r. The result contains only the options
+// set in file, use [MergeConfig] to fill the rest from [DefaultConfig].
+func ReadConfig(r io.Reader) (Config, error) {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return Config{}, fmt.Errorf("read: %w", err)
+	}
+	return pipeline.ParseConfig(buf)
+}
+
+// LoadConfig loads and parses the YAML configuration file with the given name from fileFS.
+func LoadConfig(fileFS fs.FS, name string) (Config, error) {
+	return pipeline.LoadConfig(fileFS, name)
+}
+
+// MergeConfig returns the configuration, where the options not set in userConf are taken from defaultConf.
+// Lists, such as code layout, are replaced entirely.
+func MergeConfig(defaultConf, userConf Config) Config {
+	return pipeline.MergeConfig(defaultConf, userConf)
+}
@@ -0,0 +1,178 @@
+// Package generator is the go-asyncapi generator as a library. It runs the same pipeline as the go-asyncapi tool:
+// loads an AsyncAPI document with all documents it refers to, compiles and links them, and renders the Go code,
+// the diagrams or the infra setup files. Instead of writing files to disk, the result is returned in memory.
+//
+// Example:
+//
+//	g := generator.New(generator.DefaultConfig())
+//	g.Config.ProjectModule = "github.com/my/project/asyncapi"
+//	docs, err := g.Compile("asyncapi.yaml")
+//	if err != nil {
+//		return err
+//	}
+//	files, err := g.Code(docs) // map of file names to contents
+//
+// The generator logs through the default logger of [github.com/charmbracelet/log] package, configure it before the
+// first call to change the output or log level.
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/bdragon300/go-asyncapi/internal/common"
+	"github.com/bdragon300/go-asyncapi/internal/compiler"
+	"github.com/bdragon300/go-asyncapi/internal/jsonpointer"
+	"github.com/bdragon300/go-asyncapi/internal/pipeline"
+	"github.com/bdragon300/go-asyncapi/internal/selector"
+	"github.com/samber/lo"
+)
+
+// Generator runs the generation pipeline with the given configuration.
+type Generator struct {
+	// Config is the generator configuration. Typically, it is [DefaultConfig] merged with user options.
+	Config Config
+	// Locator reads the documents. If nil, the locator returned by [NewLocator] for Config.Locator is used.
+	Locator Locator
+}
+
+// New returns a new Generator with the given configuration and the built-in locator.
+func New(conf Config) *Generator {
+	return &Generator{Config: conf}
+}
+
+// Documents is the result of compilation and linking of an AsyncAPI document and all documents it refers to.
+type Documents struct {
+	root      string
+	documents map[string]*compiler.Document
+}
+
+// Root returns the root document location.
+func (d *Documents) Root() string {
+	return d.root
+}
+
+// Locations returns the sorted locations of all compiled documents.
+func (d *Documents) Locations() []string {
+	return slices.Sorted(maps.Keys(d.documents))
+}
+
+// Artifacts returns all artifacts found in compiled documents, i.e. the objects that are passed to templates.
+func (d *Documents) Artifacts() []Artifact {
+	artifacts := selector.GatherArtifacts(lo.Values(d.documents)...)
+	res := lo.Map(artifacts, func(a common.Artifact, _ int) Artifact {
+		p := a.Pointer()
+		return Artifact{
+			Name:       a.Name(),
+			Kind:       string(a.Kind()),
+			Location:   p.Location(),
+			Pointer:    p.String(),
+			Selectable: a.Selectable(),
+			Visible:    a.Visible(),
+		}
+	})
+	slices.SortStableFunc(res, func(a, b Artifact) int {
+		return strings.Compare(a.Pointer, b.Pointer)
+	})
+	return res
+}
+
+// Artifact describes a compiled object, such as channel, message, schema, server, etc.
+type Artifact struct {
+	// Name is the object name in document, or the x-go-name value.
+	Name string
+	// Kind is the artifact kind, the same as used in code layout rules, e.g. "channel", "message", "schema".
+	// Empty for utility objects, such as Go types of schema properties.
+	Kind string
+	// Location is the location of document, where the object is defined.
+	Location string
+	// Pointer is the full URL of object definition including the JSON Pointer, e.g. "asyncapi.yaml#/channels/foo".
+	Pointer string
+	// Selectable is true if object can be selected by code layout rules.
+	Selectable bool
+	// Visible is false if object is not rendered, e.g. it's marked with x-ignore.
+	Visible bool
+}
+
+// Compile locates, compiles and links the document at given location (filesystem path or URL) and all documents
+// it refers to.
+func (g *Generator) Compile(document string) (_ *Documents, err error) {
+	defer recoverError(&err)
+
+	docURL, err := jsonpointer.Parse(document)
+	if err != nil {
+		return nil, fmt.Errorf("parse URL: %w", err)
+	}
+	documents, err := pipeline.CompileAndLink(g.documentLocator(), docURL, pipeline.CompileOpts(g.Config))
+	if err != nil {
+		return nil, fmt.Errorf("compilation: %w", err)
+	}
+	return &Documents{root: docURL.Location(), documents: documents}, nil
+}
+
+// Code renders the Go code for compiled documents. Returns the files contents by file name, relative to the
+// Config.Code.TargetDir. If Config.ProjectModule is empty, the module is taken from go.mod file in the current
+// working directory.
+func (g *Generator) Code(docs *Documents) (_ map[string][]byte, err error) {
+	defer recoverError(&err)
+
+	files, err := pipeline.GenerateCode(g.Config, docs.documents, docs.root, false)
+	if err != nil {
+		return nil, err
+	}
+	return buffersToBytes(files), nil
+}
+
+// Diagram renders the diagram files for compiled documents in format set in Config.Diagram.Format. Returns the files
+// contents by file name, relative to the Config.Diagram.TargetDir.
+func (g *Generator) Diagram(docs *Documents) (_ map[string][]byte, err error) {
+	defer recoverError(&err)
+
+	files, err := pipeline.GenerateDiagram(g.Config, docs.documents, docs.root)
+	if err != nil {
+		return nil, err
+	}
+	return buffersToBytes(files), nil
+}
+
+// Infra renders the infra setup files for compiled documents. Returns the file contents by file name: the
+// Config.Infra.OutputFile for most engines, or the chart files in Config.Infra.OutputFile directory for helm engine.
+func (g *Generator) Infra(docs *Documents) (_ map[string][]byte, err error) {
+	defer recoverError(&err)
+
+	if g.Config.Infra.OutputFile == "" {
+		return nil, fmt.Errorf("infra output file is not set")
+	}
+	files, err := pipeline.GenerateInfra(g.Config, docs.documents)
+	if err != nil {
+		return nil, err
+	}
+	return buffersToBytes(files), nil
+}
+
+func (g *Generator) documentLocator() pipeline.DocumentLocator {
+	switch l := g.Locator.(type) {
+	case nil:
+		return pipeline.NewLocator(g.Config.Locator)
+	case internalLocator:
+		return l.locator
+	default:
+		return locatorAdapter{locator: l}
+	}
+}
+
+// recoverError turns a panic into an error, so that a document the pipeline does not expect never crashes the caller.
+func recoverError(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("internal error: %v", r)
+	}
+}
+
+func buffersToBytes(files map[string]*bytes.Buffer) map[string][]byte {
+	return lo.MapValues(files, func(buf *bytes.Buffer, _ string) []byte {
+		return buf.Bytes()
+	})
+}
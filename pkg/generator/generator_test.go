@@ -0,0 +1,191 @@
+package generator_test
+
+import (
+	"go/parser"
+	"go/token"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/bdragon300/go-asyncapi/pkg/generator"
+	"github.com/samber/lo"
+)
+
+const rootDocument = `asyncapi: 3.0.0
+info:
+  title: Lights
+  version: 1.0.0
+servers:
+  production:
+    host: localhost:9092
+    protocol: kafka
+channels:
+  lights:
+    address: lights.measured
+    messages:
+      lightMeasured:
+        $ref: 'schemas/common.yaml#/components/messages/lightMeasured'
+operations:
+  sendLightMeasured:
+    action: send
+    channel:
+      $ref: '#/channels/lights'
+`
+
+const commonDocument = `asyncapi: 3.0.0
+info:
+  title: Common components
+  version: 1.0.0
+components:
+  messages:
+    lightMeasured:
+      payload:
+        $ref: '#/components/schemas/lightMeasuredPayload'
+  schemas:
+    lightMeasuredPayload:
+      type: object
+      properties:
+        lumens:
+          type: integer
+`
+
+func newTestGenerator(files fstest.MapFS) *generator.Generator {
+	g := generator.New(generator.DefaultConfig())
+	g.Config.ProjectModule = "github.com/example/lights"
+	g.Locator = generator.NewFSLocator(files)
+	return g
+}
+
+func compileTestDocuments(t *testing.T) (*generator.Generator, *generator.Documents) {
+	t.Helper()
+	g := newTestGenerator(fstest.MapFS{
+		"asyncapi.yaml":       {Data: []byte(rootDocument)},
+		"schemas/common.yaml": {Data: []byte(commonDocument)},
+	})
+	docs, err := g.Compile("asyncapi.yaml")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	return g, docs
+}
+
+func TestCompile(t *testing.T) {
+	_, docs := compileTestDocuments(t)
+
+	if docs.Root() != "asyncapi.yaml" {
+		t.Errorf("Root() = %q, want %q", docs.Root(), "asyncapi.yaml")
+	}
+	if want := []string{"asyncapi.yaml", "schemas/common.yaml"}; !slices.Equal(docs.Locations(), want) {
+		t.Errorf("Locations() = %v, want %v", docs.Locations(), want)
+	}
+
+	artifacts := lo.Filter(docs.Artifacts(), func(a generator.Artifact, _ int) bool { return a.Visible })
+	got := lo.Map(artifacts, func(a generator.Artifact, _ int) string { return a.Kind + " " + a.Pointer })
+	for _, want := range []string{
+		"channel asyncapi.yaml#/channels/lights",
+		"operation asyncapi.yaml#/operations/sendLightMeasured",
+		"server asyncapi.yaml#/servers/production",
+		"message schemas/common.yaml#/components/messages/lightMeasured",
+		"schema schemas/common.yaml#/components/schemas/lightMeasuredPayload",
+	} {
+		if !lo.Contains(got, want) {
+			t.Errorf("Artifacts() has no %q, got %v", want, got)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{
+			name:  "document not found",
+			files: fstest.MapFS{},
+		},
+		{
+			name: "unresolved ref",
+			files: fstest.MapFS{
+				"asyncapi.yaml":       {Data: []byte(strings.Replace(rootDocument, "lightMeasured'", "unknown'", 1))},
+				"schemas/common.yaml": {Data: []byte(commonDocument)},
+			},
+		},
+		{
+			// The referred document has no asyncapi key, which is not supported by compiler
+			name: "unsupported document kind",
+			files: fstest.MapFS{
+				"asyncapi.yaml":       {Data: []byte(rootDocument)},
+				"schemas/common.yaml": {Data: []byte("components: {}\n")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docs, err := newTestGenerator(tt.files).Compile("asyncapi.yaml")
+			if err == nil {
+				t.Fatalf("Compile() = %v, error expected", docs.Locations())
+			}
+		})
+	}
+}
+
+func TestCode(t *testing.T) {
+	g, docs := compileTestDocuments(t)
+
+	files, err := g.Code(docs)
+	if err != nil {
+		t.Fatalf("Code() error = %v", err)
+	}
+	for _, want := range []string{"channels/lights.go", "messages/light_measured.go", "schemas/light_measured_payload.go"} {
+		if _, ok := files[want]; !ok {
+			t.Errorf("Code() has no file %q, got %v", want, lo.Keys(files))
+		}
+	}
+	for name, contents := range files {
+		if !strings.HasSuffix(name, ".go") {
+			continue
+		}
+		if _, err = parser.ParseFile(token.NewFileSet(), name, contents, parser.AllErrors); err != nil {
+			t.Errorf("file %s: %v", name, err)
+		}
+	}
+}
+
+func TestDiagram(t *testing.T) {
+	g, docs := compileTestDocuments(t)
+
+	files, err := g.Diagram(docs)
+	if err != nil {
+		t.Fatalf("Diagram() error = %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Diagram() files = %v, want one file", lo.Keys(files))
+	}
+	for name, contents := range files {
+		if !strings.HasSuffix(name, ".svg") || !strings.Contains(string(contents), "<svg") {
+			t.Errorf("Diagram() file %s is not svg", name)
+		}
+	}
+}
+
+func TestInfra(t *testing.T) {
+	g, docs := compileTestDocuments(t)
+
+	files, err := g.Infra(docs)
+	if err != nil {
+		t.Fatalf("Infra() error = %v", err)
+	}
+	compose, ok := files["./docker-compose.yaml"]
+	if !ok {
+		t.Fatalf("Infra() files = %v, want docker-compose.yaml", lo.Keys(files))
+	}
+	if !strings.Contains(string(compose), "production") {
+		t.Errorf("docker-compose.yaml has no production server service:\n%s", compose)
+	}
+
+	g.Config.Infra.OutputFile = ""
+	if _, err = g.Infra(docs); err == nil {
+		t.Error("Infra() with empty output file: error expected, got nil")
+	}
+}
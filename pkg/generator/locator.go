@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/bdragon300/go-asyncapi/internal/jsonpointer"
+	"github.com/bdragon300/go-asyncapi/internal/pipeline"
+)
+
+// Locator reads the AsyncAPI documents. The locations are filesystem paths or URLs without fragment, i.e. without
+// JSON Pointer part.
+type Locator interface {
+	// Locate returns the contents of document at the location.
+	Locate(location string) (io.ReadCloser, error)
+	// ResolveURL returns the location of document referred by $ref target from the base document. E.g. for base
+	// "/schemas/root.yaml" and target "common.yaml" it may return "/schemas/common.yaml".
+	ResolveURL(base, target string) (string, error)
+}
+
+// NewLocator returns the built-in locator, configured the same way as in go-asyncapi tool. It reads the local files
+// from filesystem and downloads the remote ones, or runs the locator command if it is set in configuration.
+func NewLocator(conf LocatorConfig) Locator {
+	return internalLocator{pipeline.NewLocator(conf)}
+}
+
+// NewFSLocator returns the locator that reads the documents from fileFS, e.g. [embed.FS] or [fstest.MapFS].
+// Absolute locations are treated as relative to fileFS root. Remote locations are not supported.
+func NewFSLocator(fileFS fs.FS) Locator {
+	return fsLocator{fileFS: fileFS}
+}
+
+type fsLocator struct {
+	fileFS fs.FS
+}
+
+func (f fsLocator) Locate(location string) (io.ReadCloser, error) {
+	u, err := jsonpointer.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("parse location: %w", err)
+	}
+	if u.URI != nil {
+		return nil, fmt.Errorf("%s: remote locations are not supported", location)
+	}
+	return f.fileFS.Open(strings.TrimPrefix(path.Clean(u.FSPath), "/"))
+}
+
+func (f fsLocator) ResolveURL(base, target string) (string, error) {
+	if path.IsAbs(target) || strings.Contains(target, "://") {
+		return target, nil
+	}
+	return path.Join(path.Dir(base), target), nil
+}
+
+// internalLocator adapts the internal locator to the public [Locator] interface.
+type internalLocator struct {
+	locator pipeline.DocumentLocator
+}
+
+func (l internalLocator) Locate(location string) (io.ReadCloser, error) {
+	u, err := jsonpointer.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("parse location: %w", err)
+	}
+	return l.locator.Locate(u)
+}
+
+func (l internalLocator) ResolveURL(base, target string) (string, error) {
+	baseURL, err := jsonpointer.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("parse base: %w", err)
+	}
+	targetURL, err := jsonpointer.Parse(target)
+	if err != nil {
+		return "", fmt.Errorf("parse target: %w", err)
+	}
+	res, err := l.locator.ResolveURL(baseURL, targetURL)
+	if err != nil {
+		return "", err
+	}
+	return res.Location(), nil
+}
+
+// locatorAdapter adapts the public [Locator] to the internal one, used by the compiler.
+type locatorAdapter struct {
+	locator Locator
+}
+
+func (l locatorAdapter) Locate(docURL *jsonpointer.JSONPointer) (io.ReadCloser, error) {
+	return l.locator.Locate(docURL.Location())
+}
+
+func (l locatorAdapter) ResolveURL(base, target *jsonpointer.JSONPointer) (*jsonpointer.JSONPointer, error) {
+	location, err := l.locator.ResolveURL(base.Location(), target.Location())
+	if err != nil {
+		return nil, err
+	}
+	res, err := jsonpointer.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("parse resolved location %q: %w", location, err)
+	}
+	res.Pointer = target.Pointer
+	return res, nil
+}
@@ -0,0 +1,35 @@
+package run
+
+import "context"
+
+type operationNameKey struct{}
+
+// WithOperationName returns a copy of ctx that carries the name of the AsyncAPI operation. The generated operation
+// code passes this context when it opens the channel, so the implementation may use the name for the server-side
+// objects that belong to the operation, e.g. durable consumers.
+func WithOperationName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, operationNameKey{}, name)
+}
+
+// OperationName returns the operation name set by [WithOperationName], or empty string if it is not set.
+func OperationName(ctx context.Context) string {
+	name, _ := ctx.Value(operationNameKey{}).(string)
+	return name
+}
+
+type subscribeReadyKey struct{}
+
+// WithSubscribeReady returns a copy of ctx that carries the ready function. The subscriber implementation calls it
+// by [NotifySubscribeReady] once the subscription is established.
+func WithSubscribeReady(ctx context.Context, ready func()) context.Context {
+	return context.WithValue(ctx, subscribeReadyKey{}, ready)
+}
+
+// NotifySubscribeReady tells that the subscription is established, so the messages sent after this call will be
+// received. The subscriber implementation calls it in Receive with the context passed to it. Does nothing if ctx
+// carries no ready function set by [WithSubscribeReady].
+func NotifySubscribeReady(ctx context.Context) {
+	if ready, ok := ctx.Value(subscribeReadyKey{}).(func()); ok {
+		ready()
+	}
+}
@@ -3,6 +3,7 @@ package run
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 // Headers is the key-value pairs that keeps the message headers.
@@ -30,3 +31,44 @@ func (h Headers) ToByteValues() map[string][]byte {
 
 	return res
 }
+
+// Get returns the header value as string or empty string if the header is not set. If the exact key is not found,
+// the key is matched case-insensitively.
+//
+// Together with Set and Keys methods, it makes Headers to satisfy the OpenTelemetry TextMapCarrier interface.
+func (h Headers) Get(key string) string {
+	v, ok := h[key]
+	if !ok {
+		for k, kv := range h {
+			if strings.EqualFold(k, key) {
+				v, ok = kv, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return ""
+	}
+	switch tv := v.(type) {
+	case string:
+		return tv
+	case []byte:
+		return string(tv)
+	default:
+		return fmt.Sprint(tv)
+	}
+}
+
+// Set sets the header value.
+func (h Headers) Set(key, value string) {
+	h[key] = value
+}
+
+// Keys returns the header keys.
+func (h Headers) Keys() []string {
+	res := make([]string, 0, len(h))
+	for k := range h {
+		res = append(res, k)
+	}
+	return res
+}
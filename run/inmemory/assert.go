@@ -0,0 +1,53 @@
+package inmemory
+
+import (
+	"context"
+	"time"
+)
+
+// TB is the subset of [testing.TB] methods, that the assertion helpers use.
+type TB interface {
+	Helper()
+	Fatalf(format string, args ...any)
+}
+
+// WaitMessages waits until at least n messages are published to the given address, and returns all messages
+// published to it so far. If address is empty, the messages published to any address are counted.
+// Returns the context error if ctx is done before.
+func (b *Broker) WaitMessages(ctx context.Context, address string, n int) ([]Message, error) {
+	for {
+		b.mu.Lock()
+		res := b.filterMessages(address)
+		changed := b.changed
+		b.mu.Unlock()
+
+		if len(res) >= n {
+			return res, nil
+		}
+		select {
+		case <-ctx.Done():
+			return res, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// AssertMessages waits until at least n messages are published to the given address during the timeout, and
+// returns them. Otherwise, it fails the test.
+//
+// Example:
+//
+//	broker := inmemory.Lookup(serverURL)
+//	// ... publish the messages by the code under test ...
+//	msgs := broker.AssertMessages(t, "orders", 2, time.Second)
+func (b *Broker) AssertMessages(t TB, address string, n int, timeout time.Duration) []Message {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	res, err := b.WaitMessages(ctx, address, n)
+	if err != nil {
+		t.Fatalf("expected %d messages published to %q within %s, got %d", n, address, timeout, len(res))
+	}
+	return res
+}
@@ -0,0 +1,235 @@
+// Package inmemory is an in-process message broker, that the generated "inmemory" implementations use instead of
+// a real broker connection.
+//
+// It is intended for unit tests of code that uses the generated channels and operations. Messages are routed by
+// the channel address in memory without any network, and the broker records all published messages to make
+// assertions on them, see [Broker.WaitMessages] and [Broker.AssertMessages].
+//
+// The implementations connect to the broker registered for the server URL, see [Lookup]. So, the producer and
+// consumer connected to the same server share the same broker. The registry is process-global, therefore the tests
+// connected to the same server URL share the broker as well. To isolate a test, pass the broker created by
+// [NewBroker] to the generated NewBrokerClient function, or connect to the server URL unique for the test.
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"sync"
+
+	"github.com/bdragon300/go-asyncapi/run"
+)
+
+// ErrClosed is returned when receiving from the closed subscription.
+var ErrClosed = errors.New("subscription closed")
+
+// Message is a message published to the broker.
+type Message struct {
+	// Address is the address the message is published to, i.e. the topic, subject, routing key, etc.
+	Address string
+	// Payload is the message payload.
+	Payload []byte
+	// Headers contains the message headers.
+	Headers run.Headers
+	// ContentType is the message content type.
+	ContentType string
+	// Properties contains the protocol-specific properties of message, e.g. "exchange" and "replyTo" for AMQP.
+	Properties map[string]any
+}
+
+// Filter reports whether the subscription receives the message.
+type Filter func(msg Message) bool
+
+// NewBroker returns a new empty Broker.
+func NewBroker() *Broker {
+	return &Broker{
+		changed:       make(chan struct{}),
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+}
+
+// Broker routes the published messages to the subscriptions and records them.
+//
+// Messages are delivered only to subscriptions that exist at the moment of publishing, as in pub/sub systems without
+// persistence. Every matching subscription gets its own copy of message.
+type Broker struct {
+	mu            sync.Mutex
+	changed       chan struct{} // Closed and replaced on every publish to wake up waiters
+	messages      []Message
+	subscriptions map[*Subscription]struct{}
+}
+
+// Publish records the message and delivers it to all subscriptions which filters match it.
+func (b *Broker) Publish(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.messages = append(b.messages, msg)
+	for s := range b.subscriptions {
+		if s.filter(msg) {
+			s.push(msg)
+		}
+	}
+	close(b.changed)
+	b.changed = make(chan struct{})
+	return nil
+}
+
+// Subscribe returns a new subscription that receives the messages matching the filter, which are published after
+// this call.
+func (b *Broker) Subscribe(filter Filter) *Subscription {
+	s := &Subscription{
+		broker: b,
+		filter: filter,
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions[s] = struct{}{}
+	return s
+}
+
+// Messages returns the recorded messages published to the given address in order of publishing. If address is
+// empty, returns all recorded messages.
+func (b *Broker) Messages(address string) []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.filterMessages(address)
+}
+
+// Reset forgets all recorded messages. Subscriptions are kept.
+func (b *Broker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.messages = nil
+}
+
+func (b *Broker) filterMessages(address string) []Message {
+	if address == "" {
+		return slices.Clone(b.messages)
+	}
+	var res []Message
+	for _, m := range b.messages {
+		if m.Address == address {
+			res = append(res, m)
+		}
+	}
+	return res
+}
+
+func (b *Broker) unsubscribe(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.subscriptions, s)
+}
+
+// Subscription is a queue of messages delivered from the broker.
+type Subscription struct {
+	broker *Broker
+	filter Filter
+
+	mu        sync.Mutex
+	queue     []Message
+	notify    chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Next returns the next message from the queue, blocking until a message is delivered, ctx is done or
+// the subscription is closed. In the latter case ErrClosed is returned.
+func (s *Subscription) Next(ctx context.Context) (Message, error) {
+	for {
+		s.mu.Lock()
+		if len(s.queue) > 0 {
+			msg := s.queue[0]
+			s.queue = s.queue[1:]
+			s.mu.Unlock()
+			return msg, nil
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return Message{}, ctx.Err()
+		case <-s.done:
+			return Message{}, ErrClosed
+		case <-s.notify:
+		}
+	}
+}
+
+// Requeue puts the message back to the end of the queue, so it will be received again.
+func (s *Subscription) Requeue(msg Message) {
+	s.push(msg)
+}
+
+// Close removes the subscription from the broker. Messages left in the queue are dropped.
+func (s *Subscription) Close() error {
+	s.closeOnce.Do(func() {
+		s.broker.unsubscribe(s)
+		close(s.done)
+	})
+	return nil
+}
+
+func (s *Subscription) push(msg Message) {
+	s.mu.Lock()
+	s.queue = append(s.queue, msg)
+	s.mu.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = make(map[string]*Broker)
+)
+
+// Lookup returns the broker registered for the server URL. If no broker is registered, the new one is created and
+// registered. Brokers are identified by the scheme and host of URL, the rest of URL is ignored.
+//
+// The registry is shared by the whole process, so all code connected to the same scheme and host gets the same
+// broker, including the tests running in parallel.
+func Lookup(serverURL *url.URL) *Broker {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	key := registryKey(serverURL)
+	b, ok := registry[key]
+	if !ok {
+		b = NewBroker()
+		registry[key] = b
+	}
+	return b
+}
+
+// Register registers the broker for the server URL, replacing the previous one. If b is nil, the broker is
+// unregistered, so the next Lookup call creates a new one.
+//
+// The registered broker is returned to all callers in the process, so registering a broker per test isolates
+// only the tests that don't run in parallel with others using the same server URL. Parallel tests should rather
+// use the server URL unique for the test, or bypass the registry with the generated NewBrokerClient function.
+func Register(serverURL *url.URL, b *Broker) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if b == nil {
+		delete(registry, registryKey(serverURL))
+		return
+	}
+	registry[registryKey(serverURL)] = b
+}
+
+func registryKey(u *url.URL) string {
+	return u.Scheme + "://" + u.Host
+}
@@ -0,0 +1,232 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBrokerPublish(t *testing.T) {
+	ctx := context.Background()
+	b := NewBroker()
+	orders := b.Subscribe(MatchAddress("orders"))
+	defer orders.Close()
+	all := b.Subscribe(func(Message) bool { return true })
+	defer all.Close()
+
+	for _, addr := range []string{"orders", "payments", "orders"} {
+		if err := b.Publish(ctx, Message{Address: addr, Payload: []byte(addr)}); err != nil {
+			t.Fatalf("Publish(%q) error = %v", addr, err)
+		}
+	}
+	// Messages published before subscription are not delivered to it
+	late := b.Subscribe(MatchAddress("orders"))
+	defer late.Close()
+
+	if got := receiveAddresses(t, orders, 2); !slices.Equal(got, []string{"orders", "orders"}) {
+		t.Errorf("orders subscription got %v", got)
+	}
+	if got := receiveAddresses(t, all, 3); !slices.Equal(got, []string{"orders", "payments", "orders"}) {
+		t.Errorf("catch-all subscription got %v", got)
+	}
+	assertEmpty(t, late)
+
+	if got := addresses(b.Messages("")); !slices.Equal(got, []string{"orders", "payments", "orders"}) {
+		t.Errorf("Messages(\"\") = %v", got)
+	}
+	if got := addresses(b.Messages("payments")); !slices.Equal(got, []string{"payments"}) {
+		t.Errorf("Messages(\"payments\") = %v", got)
+	}
+	b.Reset()
+	if got := b.Messages(""); len(got) != 0 {
+		t.Errorf("Messages() after Reset = %v, want none", got)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := b.Publish(cancelled, Message{Address: "orders"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Publish() with done ctx error = %v, want %v", err, context.Canceled)
+	}
+	if got := b.Messages(""); len(got) != 0 {
+		t.Errorf("message is recorded after failed Publish: %v", got)
+	}
+}
+
+func TestSubscription(t *testing.T) {
+	ctx := context.Background()
+	b := NewBroker()
+	s := b.Subscribe(MatchAddress("orders"))
+
+	for i := range 2 {
+		if err := b.Publish(ctx, Message{Address: "orders", Payload: []byte{byte(i)}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	msg, err := s.Next(ctx)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	// Requeued message goes to the end of the queue
+	s.Requeue(msg)
+	var got []byte
+	for range 2 {
+		m, err := s.Next(ctx)
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		got = append(got, m.Payload...)
+	}
+	if want := []byte{1, 0}; !slices.Equal(got, want) {
+		t.Errorf("payloads after Requeue = %v, want %v", got, want)
+	}
+
+	// Next blocks until a message is delivered
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_ = b.Publish(ctx, Message{Address: "orders", Payload: []byte{2}})
+	}()
+	if m, err := s.Next(ctx); err != nil || !slices.Equal(m.Payload, []byte{2}) {
+		t.Errorf("Next() = %v, %v, want payload [2]", m.Payload, err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err = s.Next(timeoutCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Next() on empty queue error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if err = s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err = s.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+	if _, err = s.Next(ctx); !errors.Is(err, ErrClosed) {
+		t.Errorf("Next() after Close error = %v, want %v", err, ErrClosed)
+	}
+	// Closed subscription is removed from the broker
+	if err = b.Publish(ctx, Message{Address: "orders"}); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(b.subscriptions); n != 0 {
+		t.Errorf("broker has %d subscriptions after Close, want 0", n)
+	}
+}
+
+func TestWaitMessages(t *testing.T) {
+	ctx := context.Background()
+	b := NewBroker()
+	go func() {
+		for _, addr := range []string{"payments", "orders", "orders", "orders"} {
+			time.Sleep(time.Millisecond)
+			_ = b.Publish(ctx, Message{Address: addr})
+		}
+	}()
+
+	got, err := b.WaitMessages(ctx, "orders", 3)
+	if err != nil {
+		t.Fatalf("WaitMessages() error = %v", err)
+	}
+	if len(got) != 3 {
+		t.Errorf("WaitMessages() returned %d messages, want 3", len(got))
+	}
+	// Empty address counts all messages
+	if got = b.AssertMessages(t, "", 4, time.Second); len(got) != 4 {
+		t.Errorf("AssertMessages() returned %d messages, want 4", len(got))
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	got, err = b.WaitMessages(timeoutCtx, "payments", 2)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitMessages() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if len(got) != 1 {
+		t.Errorf("WaitMessages() returned %d messages on timeout, want 1 published so far", len(got))
+	}
+
+	ft := &fakeTB{}
+	b.AssertMessages(ft, "payments", 2, 10*time.Millisecond)
+	if want := `expected 2 messages published to "payments" within 10ms, got 1`; ft.failure != want {
+		t.Errorf("AssertMessages() failure = %q, want %q", ft.failure, want)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	u1 := &url.URL{Scheme: "kafka", Host: t.Name() + "-1", Path: "/a"}
+	u2 := &url.URL{Scheme: "kafka", Host: t.Name() + "-2"}
+	t.Cleanup(func() {
+		Register(u1, nil)
+		Register(u2, nil)
+	})
+
+	b := Lookup(u1)
+	// Path and other URL parts are ignored
+	if got := Lookup(&url.URL{Scheme: "kafka", Host: u1.Host, Path: "/b", RawQuery: "x=1"}); got != b {
+		t.Error("Lookup() with another path returned another broker")
+	}
+	if got := Lookup(&url.URL{Scheme: "amqp", Host: u1.Host}); got == b {
+		t.Error("Lookup() with another scheme returned the same broker")
+	}
+	if got := Lookup(u2); got == b {
+		t.Error("Lookup() with another host returned the same broker")
+	}
+
+	own := NewBroker()
+	Register(u1, own)
+	if got := Lookup(u1); got != own {
+		t.Error("Lookup() did not return the registered broker")
+	}
+	Register(u1, nil)
+	if got := Lookup(u1); got == own || got == b {
+		t.Error("Lookup() after unregistering did not create a new broker")
+	}
+}
+
+func receiveAddresses(t *testing.T, s *Subscription, n int) []string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	var res []string
+	for range n {
+		msg, err := s.Next(ctx)
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		res = append(res, msg.Address)
+	}
+	assertEmpty(t, s)
+	return res
+}
+
+func assertEmpty(t *testing.T, s *Subscription) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if msg, err := s.Next(ctx); err == nil {
+		t.Errorf("unexpected message received: %+v", msg)
+	}
+}
+
+func addresses(msgs []Message) []string {
+	var res []string
+	for _, m := range msgs {
+		res = append(res, m.Address)
+	}
+	return res
+}
+
+// fakeTB records the test failure instead of stopping the test.
+type fakeTB struct {
+	failure string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Fatalf(format string, args ...any) {
+	f.failure = fmt.Sprintf(format, args...)
+}
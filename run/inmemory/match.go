@@ -0,0 +1,73 @@
+package inmemory
+
+import "strings"
+
+// MatchAddress returns a filter that matches the messages published exactly to the given address.
+func MatchAddress(address string) Filter {
+	return func(msg Message) bool {
+		return msg.Address == address
+	}
+}
+
+// MatchNATSSubject returns a filter that matches the message address against the NATS subject. Subject tokens are
+// separated by ".", the "*" wildcard matches a single token, ">" at the end matches one or more tokens.
+func MatchNATSSubject(subject string) Filter {
+	pattern := strings.Split(subject, ".")
+	return func(msg Message) bool {
+		return matchTokens(pattern, strings.Split(msg.Address, "."), "*", ">", false)
+	}
+}
+
+// MatchMQTTTopic returns a filter that matches the message address against the MQTT topic filter. Topic levels are
+// separated by "/", the "+" wildcard matches a single level, "#" at the end matches the parent level and any
+// number of child levels.
+func MatchMQTTTopic(topicFilter string) Filter {
+	pattern := strings.Split(topicFilter, "/")
+	return func(msg Message) bool {
+		return matchTokens(pattern, strings.Split(msg.Address, "/"), "+", "#", true)
+	}
+}
+
+// MatchAMQPTopic returns a filter that matches the message address, i.e. the routing key, against the binding key
+// of AMQP topic exchange. Words are separated by ".", the "*" wildcard matches a single word, "#" matches zero or
+// more words.
+func MatchAMQPTopic(bindingKey string) Filter {
+	pattern := strings.Split(bindingKey, ".")
+	return func(msg Message) bool {
+		return matchAMQPWords(pattern, strings.Split(msg.Address, "."))
+	}
+}
+
+// matchTokens matches the address tokens against the pattern, where the single wildcard matches one token,
+// and the tail wildcard is allowed only at the end of pattern. If tailMatchesEmpty is true, the tail wildcard
+// also matches the absence of tokens.
+func matchTokens(pattern, tokens []string, single, tail string, tailMatchesEmpty bool) bool {
+	for i, p := range pattern {
+		if p == tail && i == len(pattern)-1 {
+			return len(tokens) > i || tailMatchesEmpty && len(tokens) == i
+		}
+		if i >= len(tokens) || p != single && p != tokens[i] {
+			return false
+		}
+	}
+	return len(pattern) == len(tokens)
+}
+
+func matchAMQPWords(pattern, words []string) bool {
+	if len(pattern) == 0 {
+		return len(words) == 0
+	}
+	switch pattern[0] {
+	case "#":
+		for i := 0; i <= len(words); i++ {
+			if matchAMQPWords(pattern[1:], words[i:]) {
+				return true
+			}
+		}
+		return false
+	case "*":
+		return len(words) > 0 && matchAMQPWords(pattern[1:], words[1:])
+	default:
+		return len(words) > 0 && pattern[0] == words[0] && matchAMQPWords(pattern[1:], words[1:])
+	}
+}
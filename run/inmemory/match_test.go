@@ -0,0 +1,43 @@
+package inmemory
+
+import "testing"
+
+func TestFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  Filter
+		address string
+		want    bool
+	}{
+		{"exact", MatchAddress("orders"), "orders", true},
+		{"exact mismatch", MatchAddress("orders"), "orders.new", false},
+
+		{"nats literal", MatchNATSSubject("orders.new"), "orders.new", true},
+		{"nats single", MatchNATSSubject("orders.*.new"), "orders.eu.new", true},
+		{"nats single too many tokens", MatchNATSSubject("orders.*"), "orders.eu.new", false},
+		{"nats tail", MatchNATSSubject("orders.>"), "orders.eu.new", true},
+		{"nats tail requires a token", MatchNATSSubject("orders.>"), "orders", false},
+
+		{"mqtt literal", MatchMQTTTopic("sensors/1/temp"), "sensors/1/temp", true},
+		{"mqtt single", MatchMQTTTopic("sensors/+/temp"), "sensors/1/temp", true},
+		{"mqtt single mismatch", MatchMQTTTopic("sensors/+/temp"), "sensors/1/humidity", false},
+		{"mqtt tail", MatchMQTTTopic("sensors/#"), "sensors/1/temp", true},
+		{"mqtt tail matches parent", MatchMQTTTopic("sensors/#"), "sensors", true},
+		{"mqtt tail only", MatchMQTTTopic("#"), "sensors/1", true},
+
+		{"amqp literal", MatchAMQPTopic("orders.new"), "orders.new", true},
+		{"amqp single", MatchAMQPTopic("orders.*"), "orders.new", true},
+		{"amqp single requires a word", MatchAMQPTopic("orders.*"), "orders", false},
+		{"amqp hash in the middle", MatchAMQPTopic("orders.#.new"), "orders.eu.de.new", true},
+		{"amqp hash matches zero words", MatchAMQPTopic("orders.#"), "orders", true},
+		{"amqp hash only", MatchAMQPTopic("#"), "anything.here", true},
+		{"amqp mismatch", MatchAMQPTopic("orders.#.new"), "orders.eu.old", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.filter(Message{Address: test.address}); got != test.want {
+				t.Errorf("filter(%q) = %v; expected %v", test.address, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package run
+
+import "time"
+
+// MetricLabels are the labels of the metrics reported by generated operations.
+type MetricLabels struct {
+	// Server is the name of the server the operation channel is opened on, as returned by its Name method, i.e.
+	// the Go name of the generated server type. Empty if the server doesn't have the Name method.
+	Server string
+	// Channel is the name of the channel as it was defined in the AsyncAPI document.
+	Channel string
+	// Operation is the name of the operation as it was defined in the AsyncAPI document.
+	Operation string
+	// Message is the name of the message as it was defined in the AsyncAPI document. Empty if the message is unknown.
+	Message string
+	// Protocol is the protocol of the operation channel, e.g. "kafka".
+	Protocol string
+}
+
+// Metrics receives the metrics reported by generated operations. Implementation must be safe for concurrent use.
+//
+// Metrics are enabled by passing WithMetrics option to the generated Open* function of an operation. Channels
+// opened directly don't report metrics.
+type Metrics interface {
+	// MessagePublished is called after the sealed message has been sent or failed to send. Size is the message
+	// payload size in bytes, latency is the time spent on sending, err is the sending error if any.
+	MessagePublished(labels MetricLabels, size int, latency time.Duration, err error)
+	// MessageReceived is called after the received message has been handled. Size is the number of payload bytes
+	// read while unsealing, err is the handling error if any.
+	MessageReceived(labels MetricLabels, size int, err error)
+	// MarshalFailed is called if the message could not be sealed (marshalled) to the envelope.
+	MarshalFailed(labels MetricLabels, err error)
+	// UnmarshalFailed is called if the envelope could not be unsealed (unmarshalled) to the message,
+	// i.e. the handling error wraps ErrUnsealEnvelope. MessageReceived is called as well.
+	UnmarshalFailed(labels MetricLabels, err error)
+}
+
+// WithMetrics returns an option that enables reporting the metrics to m.
+func WithMetrics(m Metrics) MiddlewareOption {
+	return func(mw *Middlewares) {
+		mw.metrics = m
+	}
+}
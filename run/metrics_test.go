@@ -0,0 +1,36 @@
+package run
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithMetrics(t *testing.T) {
+	first, second := &testMetrics{}, &testMetrics{}
+	tests := []struct {
+		name string
+		opts []MiddlewareOption
+		want Metrics
+	}{
+		{name: "no option"},
+		{name: "set", opts: []MiddlewareOption{WithMetrics(first)}, want: first},
+		{name: "last wins", opts: []MiddlewareOption{WithMetrics(first), WithMetrics(second)}, want: second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewMiddlewares(tt.opts...).Metrics(); got != tt.want {
+				t.Errorf("Metrics() = %p, want %p", got, tt.want)
+			}
+		})
+	}
+}
+
+type testMetrics struct {
+	// Non-zero size, so that pointers to different values are not equal
+	_ int
+}
+
+func (*testMetrics) MessagePublished(MetricLabels, int, time.Duration, error) {}
+func (*testMetrics) MessageReceived(MetricLabels, int, error)                 {}
+func (*testMetrics) MarshalFailed(MetricLabels, error)                        {}
+func (*testMetrics) UnmarshalFailed(MetricLabels, error)                      {}
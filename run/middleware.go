@@ -0,0 +1,93 @@
+package run
+
+import "context"
+
+// PublishHandler sends the envelope. The message is the typed message that has been sealed to the envelope.
+type PublishHandler[E any] func(ctx context.Context, envelope E, message any) error
+
+// PublishMiddleware wraps the PublishHandler. Middleware is called with already sealed envelope right before
+// sending it. It may modify the envelope, return an error or not call the next handler at all to cancel sending.
+//
+// Type parameter E is the protocol's EnvelopeWriter interface, e.g. kafka.EnvelopeWriter.
+type PublishMiddleware[E any] func(next PublishHandler[E]) PublishHandler[E]
+
+// SubscribeHandler handles the received envelope. The message is a pointer to the typed message, that the
+// envelope will be unsealed to.
+type SubscribeHandler[E any] func(ctx context.Context, envelope E, message any) error
+
+// SubscribeMiddleware wraps the SubscribeHandler. Middleware is called with just received envelope before
+// unsealing it to the message, so the message is filled only after the next handler returns. It may replace
+// the envelope, return an error to stop the subscription or not call the next handler at all to drop the envelope.
+//
+// Type parameter E is the protocol's EnvelopeReader interface, e.g. kafka.EnvelopeReader.
+type SubscribeMiddleware[E any] func(next SubscribeHandler[E]) SubscribeHandler[E]
+
+// MiddlewareOption is an option for generated channels and operations that adds middlewares.
+type MiddlewareOption func(m *Middlewares)
+
+// WithPublishMiddleware returns an option that adds the publish middlewares. Middlewares are called in order they
+// are added, i.e. the first middleware is the outermost one.
+func WithPublishMiddleware[E any](middlewares ...PublishMiddleware[E]) MiddlewareOption {
+	return func(m *Middlewares) {
+		for _, mw := range middlewares {
+			m.publish = append(m.publish, mw)
+		}
+	}
+}
+
+// WithSubscribeMiddleware returns an option that adds the subscribe middlewares. Middlewares are called in order
+// they are added, i.e. the first middleware is the outermost one.
+func WithSubscribeMiddleware[E any](middlewares ...SubscribeMiddleware[E]) MiddlewareOption {
+	return func(m *Middlewares) {
+		for _, mw := range middlewares {
+			m.subscribe = append(m.subscribe, mw)
+		}
+	}
+}
+
+// NewMiddlewares returns a new Middlewares with applied options.
+func NewMiddlewares(opts ...MiddlewareOption) Middlewares {
+	var res Middlewares
+	for _, opt := range opts {
+		opt(&res)
+	}
+	return res
+}
+
+// Middlewares is the list of publish and subscribe middlewares of a channel.
+//
+// Middlewares may be added for different envelope types, so the same options may be passed to channels of
+// different protocols. Every channel uses only the middlewares for its protocol's envelope type, others are skipped.
+type Middlewares struct {
+	publish   []any
+	subscribe []any
+	metrics   Metrics
+
+	retry      *RetryPolicy
+	deadLetter []any
+}
+
+// Metrics returns the Metrics set by WithMetrics option or nil.
+func (m Middlewares) Metrics() Metrics {
+	return m.metrics
+}
+
+// PublishChain returns the handler wrapped in the publish middlewares for envelope type E.
+func PublishChain[E any](m Middlewares, handler PublishHandler[E]) PublishHandler[E] {
+	for i := len(m.publish) - 1; i >= 0; i-- {
+		if mw, ok := m.publish[i].(PublishMiddleware[E]); ok {
+			handler = mw(handler)
+		}
+	}
+	return handler
+}
+
+// SubscribeChain returns the handler wrapped in the subscribe middlewares for envelope type E.
+func SubscribeChain[E any](m Middlewares, handler SubscribeHandler[E]) SubscribeHandler[E] {
+	for i := len(m.subscribe) - 1; i >= 0; i-- {
+		if mw, ok := m.subscribe[i].(SubscribeMiddleware[E]); ok {
+			handler = mw(handler)
+		}
+	}
+	return handler
+}
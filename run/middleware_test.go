@@ -0,0 +1,230 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPublishChain(t *testing.T) {
+	errCancel := errors.New("cancelled")
+	var calls []string
+	record := func(name string) PublishMiddleware[*strings.Builder] {
+		return func(next PublishHandler[*strings.Builder]) PublishHandler[*strings.Builder] {
+			return func(ctx context.Context, envelope *strings.Builder, message any) error {
+				calls = append(calls, name)
+				envelope.WriteString(name)
+				err := next(ctx, envelope, message)
+				calls = append(calls, name+" done")
+				return err
+			}
+		}
+	}
+	otherEnvelope := func(next PublishHandler[*bytes.Buffer]) PublishHandler[*bytes.Buffer] {
+		return func(ctx context.Context, envelope *bytes.Buffer, message any) error {
+			calls = append(calls, "other")
+			return next(ctx, envelope, message)
+		}
+	}
+	cancel := func(PublishHandler[*strings.Builder]) PublishHandler[*strings.Builder] {
+		return func(context.Context, *strings.Builder, any) error {
+			calls = append(calls, "cancel")
+			return errCancel
+		}
+	}
+
+	tests := []struct {
+		name         string
+		opts         []MiddlewareOption
+		wantCalls    []string
+		wantEnvelope string
+		wantErr      error
+	}{
+		{
+			name:         "no middlewares",
+			wantCalls:    []string{"handler"},
+			wantEnvelope: "handler",
+		},
+		{
+			name: "first middleware is outermost",
+			opts: []MiddlewareOption{
+				WithPublishMiddleware(record("a"), record("b")),
+				WithPublishMiddleware(record("c")),
+			},
+			wantCalls:    []string{"a", "b", "c", "handler", "c done", "b done", "a done"},
+			wantEnvelope: "abchandler",
+		},
+		{
+			name: "other envelope types are skipped",
+			opts: []MiddlewareOption{
+				WithPublishMiddleware(record("a")),
+				WithPublishMiddleware[*bytes.Buffer](otherEnvelope),
+				WithSubscribeMiddleware(func(next SubscribeHandler[*strings.Builder]) SubscribeHandler[*strings.Builder] {
+					calls = append(calls, "subscribe")
+					return next
+				}),
+				WithPublishMiddleware(record("b")),
+			},
+			wantCalls:    []string{"a", "b", "handler", "b done", "a done"},
+			wantEnvelope: "abhandler",
+		},
+		{
+			name: "short circuit",
+			opts: []MiddlewareOption{
+				WithPublishMiddleware(record("a"), cancel, record("b")),
+			},
+			wantCalls:    []string{"a", "cancel", "a done"},
+			wantEnvelope: "a",
+			wantErr:      errCancel,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			handler := PublishChain(NewMiddlewares(tt.opts...), func(_ context.Context, envelope *strings.Builder, message any) error {
+				if message != "msg" {
+					t.Errorf("message = %v, want %q", message, "msg")
+				}
+				calls = append(calls, "handler")
+				envelope.WriteString("handler")
+				return nil
+			})
+
+			var envelope strings.Builder
+			if err := handler(context.Background(), &envelope, "msg"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("handler() error = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", calls, tt.wantCalls)
+			}
+			if envelope.String() != tt.wantEnvelope {
+				t.Errorf("envelope = %q, want %q", envelope.String(), tt.wantEnvelope)
+			}
+		})
+	}
+}
+
+func TestSubscribeChain(t *testing.T) {
+	errStop := errors.New("stop")
+	var calls []string
+	record := func(name string) SubscribeMiddleware[*strings.Reader] {
+		return func(next SubscribeHandler[*strings.Reader]) SubscribeHandler[*strings.Reader] {
+			return func(ctx context.Context, envelope *strings.Reader, message any) error {
+				calls = append(calls, name)
+				err := next(ctx, envelope, message)
+				calls = append(calls, name+" done")
+				return err
+			}
+		}
+	}
+	replace := func(next SubscribeHandler[*strings.Reader]) SubscribeHandler[*strings.Reader] {
+		return func(ctx context.Context, _ *strings.Reader, message any) error {
+			calls = append(calls, "replace")
+			return next(ctx, strings.NewReader("replaced"), message)
+		}
+	}
+	drop := func(SubscribeHandler[*strings.Reader]) SubscribeHandler[*strings.Reader] {
+		return func(context.Context, *strings.Reader, any) error {
+			calls = append(calls, "drop")
+			return nil
+		}
+	}
+	stop := func(SubscribeHandler[*strings.Reader]) SubscribeHandler[*strings.Reader] {
+		return func(context.Context, *strings.Reader, any) error {
+			calls = append(calls, "stop")
+			return errStop
+		}
+	}
+
+	tests := []struct {
+		name         string
+		opts         []MiddlewareOption
+		wantCalls    []string
+		wantEnvelope string
+		wantErr      error
+	}{
+		{
+			name:         "no middlewares",
+			wantCalls:    []string{"handler"},
+			wantEnvelope: "received",
+		},
+		{
+			name: "first middleware is outermost",
+			opts: []MiddlewareOption{
+				WithSubscribeMiddleware(record("a"), record("b")),
+				WithSubscribeMiddleware(record("c")),
+			},
+			wantCalls:    []string{"a", "b", "c", "handler", "c done", "b done", "a done"},
+			wantEnvelope: "received",
+		},
+		{
+			name: "other envelope types are skipped",
+			opts: []MiddlewareOption{
+				WithSubscribeMiddleware(record("a")),
+				WithSubscribeMiddleware(func(next SubscribeHandler[*bytes.Reader]) SubscribeHandler[*bytes.Reader] {
+					calls = append(calls, "other")
+					return next
+				}),
+				WithPublishMiddleware(func(next PublishHandler[*strings.Reader]) PublishHandler[*strings.Reader] {
+					calls = append(calls, "publish")
+					return next
+				}),
+				WithSubscribeMiddleware(record("b")),
+			},
+			wantCalls:    []string{"a", "b", "handler", "b done", "a done"},
+			wantEnvelope: "received",
+		},
+		{
+			name: "replace envelope",
+			opts: []MiddlewareOption{
+				WithSubscribeMiddleware(record("a"), replace),
+			},
+			wantCalls:    []string{"a", "replace", "handler", "a done"},
+			wantEnvelope: "replaced",
+		},
+		{
+			name: "drop envelope",
+			opts: []MiddlewareOption{
+				WithSubscribeMiddleware(record("a"), drop, record("b")),
+			},
+			wantCalls: []string{"a", "drop", "a done"},
+		},
+		{
+			name: "stop with error",
+			opts: []MiddlewareOption{
+				WithSubscribeMiddleware(stop, record("a")),
+			},
+			wantCalls: []string{"stop"},
+			wantErr:   errStop,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			var gotEnvelope string
+			handler := SubscribeChain(NewMiddlewares(tt.opts...), func(_ context.Context, envelope *strings.Reader, message any) error {
+				if message != "msg" {
+					t.Errorf("message = %v, want %q", message, "msg")
+				}
+				calls = append(calls, "handler")
+				var b strings.Builder
+				_, _ = envelope.WriteTo(&b)
+				gotEnvelope = b.String()
+				return nil
+			})
+
+			if err := handler(context.Background(), strings.NewReader("received"), "msg"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("handler() error = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", calls, tt.wantCalls)
+			}
+			if gotEnvelope != tt.wantEnvelope {
+				t.Errorf("envelope = %q, want %q", gotEnvelope, tt.wantEnvelope)
+			}
+		})
+	}
+}
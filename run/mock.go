@@ -0,0 +1,52 @@
+package run
+
+import "sync"
+
+// MockCall is a recorded call of the mock method.
+type MockCall struct {
+	// Method is the name of the called method.
+	Method string
+	// Args are the method arguments in order. Variadic arguments are recorded as a single slice.
+	Args []any
+}
+
+// MockRecorder records the calls of the generated mocks. It is embedded in every mock and is safe for concurrent use.
+type MockRecorder struct {
+	mu    sync.Mutex
+	calls []MockCall
+}
+
+// Record records the call of method with args.
+func (r *MockRecorder) Record(method string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, MockCall{Method: method, Args: args})
+}
+
+// Calls returns the recorded calls of the method in order they were made. If method is empty, returns all calls.
+func (r *MockRecorder) Calls(method string) []MockCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var res []MockCall
+	for _, c := range r.calls {
+		if method == "" || c.Method == method {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// CallCount returns the number of recorded calls of the method. If method is empty, returns the number of all calls.
+func (r *MockRecorder) CallCount(method string) int {
+	return len(r.Calls(method))
+}
+
+// ResetCalls forgets all recorded calls.
+func (r *MockRecorder) ResetCalls() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+}
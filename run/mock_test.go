@@ -0,0 +1,45 @@
+package run
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestMockRecorder(t *testing.T) {
+	var r MockRecorder
+	r.Record("Send", "a", 1)
+	r.Record("Close")
+	r.Record("Send", "b", 2)
+
+	if n := r.CallCount(""); n != 3 {
+		t.Errorf("CallCount(\"\") = %d, want 3", n)
+	}
+	calls := r.Calls("Send")
+	if len(calls) != 2 || calls[0].Args[0] != "a" || calls[1].Args[0] != "b" || calls[1].Args[1] != 2 {
+		t.Errorf("Calls(\"Send\") = %+v", calls)
+	}
+	if calls = r.Calls("Close"); len(calls) != 1 || len(calls[0].Args) != 0 {
+		t.Errorf("Calls(\"Close\") = %+v, want one call without args", calls)
+	}
+	if n := r.CallCount("Receive"); n != 0 {
+		t.Errorf("CallCount(\"Receive\") = %d, want 0", n)
+	}
+
+	r.ResetCalls()
+	if n := r.CallCount(""); n != 0 {
+		t.Errorf("CallCount() after ResetCalls = %d, want 0", n)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.Record("Send", i)
+		}()
+	}
+	wg.Wait()
+	if n := r.CallCount("Send"); n != 10 {
+		t.Errorf("CallCount() after concurrent calls = %d, want 10", n)
+	}
+}
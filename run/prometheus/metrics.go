@@ -0,0 +1,166 @@
+// Package prometheus is the adapter of run.Metrics for the Prometheus client library.
+//
+// Usage:
+//
+//	metrics := prometheus.NewMetrics("myapp")
+//	prometheusClient.MustRegister(metrics)
+//	operation, err := server.OpenMyOperationKafka(ctx, run.WithMetrics(metrics))
+package prometheus
+
+import (
+	"time"
+
+	"github.com/bdragon300/go-asyncapi/run"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var labelNames = []string{"server", "channel", "operation", "message", "protocol"}
+
+// Option is an option for NewMetrics.
+type Option func(m *Metrics)
+
+// WithLatencyBuckets sets the buckets of the send latency histogram, in seconds.
+// Default is prometheus.DefBuckets.
+func WithLatencyBuckets(buckets []float64) Option {
+	return func(m *Metrics) {
+		m.latencyBuckets = buckets
+	}
+}
+
+// WithSizeBuckets sets the buckets of the payload size histograms, in bytes.
+// Default is exponential buckets from 64 bytes to 4 MiB.
+func WithSizeBuckets(buckets []float64) Option {
+	return func(m *Metrics) {
+		m.sizeBuckets = buckets
+	}
+}
+
+// NewMetrics returns a new Metrics with all metric names prefixed by namespace (if not empty).
+// The returned Metrics must be registered in the Prometheus registry.
+func NewMetrics(namespace string, opts ...Option) *Metrics {
+	res := &Metrics{
+		latencyBuckets: prometheus.DefBuckets,
+		sizeBuckets:    prometheus.ExponentialBuckets(64, 4, 10),
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+
+	res.published = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_published_total",
+		Help:      "Number of messages sent successfully.",
+	}, labelNames)
+	res.publishFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_publish_failures_total",
+		Help:      "Number of messages failed to send.",
+	}, labelNames)
+	res.received = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_received_total",
+		Help:      "Number of messages received.",
+	}, labelNames)
+	res.receiveFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_receive_failures_total",
+		Help:      "Number of received messages failed to handle, including the unmarshal failures.",
+	}, labelNames)
+	res.marshalFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_marshal_failures_total",
+		Help:      "Number of messages failed to marshal.",
+	}, labelNames)
+	res.unmarshalFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_unmarshal_failures_total",
+		Help:      "Number of received messages failed to unmarshal.",
+	}, labelNames)
+	res.sendLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "message_send_duration_seconds",
+		Help:      "Time spent on sending a message.",
+		Buckets:   res.latencyBuckets,
+	}, labelNames)
+	res.publishedSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "message_published_payload_bytes",
+		Help:      "Payload size of sent messages.",
+		Buckets:   res.sizeBuckets,
+	}, labelNames)
+	res.receivedSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "message_received_payload_bytes",
+		Help:      "Payload size of received messages.",
+		Buckets:   res.sizeBuckets,
+	}, labelNames)
+
+	return res
+}
+
+// Metrics implements run.Metrics and prometheus.Collector.
+type Metrics struct {
+	latencyBuckets []float64
+	sizeBuckets    []float64
+
+	published         *prometheus.CounterVec
+	publishFailures   *prometheus.CounterVec
+	received          *prometheus.CounterVec
+	receiveFailures   *prometheus.CounterVec
+	marshalFailures   *prometheus.CounterVec
+	unmarshalFailures *prometheus.CounterVec
+	sendLatency       *prometheus.HistogramVec
+	publishedSize     *prometheus.HistogramVec
+	receivedSize      *prometheus.HistogramVec
+}
+
+func (m *Metrics) MessagePublished(labels run.MetricLabels, size int, latency time.Duration, err error) {
+	values := labelValues(labels)
+	m.sendLatency.WithLabelValues(values...).Observe(latency.Seconds())
+	if err != nil {
+		m.publishFailures.WithLabelValues(values...).Inc()
+		return
+	}
+	m.published.WithLabelValues(values...).Inc()
+	m.publishedSize.WithLabelValues(values...).Observe(float64(size))
+}
+
+func (m *Metrics) MessageReceived(labels run.MetricLabels, size int, err error) {
+	values := labelValues(labels)
+	m.received.WithLabelValues(values...).Inc()
+	m.receivedSize.WithLabelValues(values...).Observe(float64(size))
+	if err != nil {
+		m.receiveFailures.WithLabelValues(values...).Inc()
+	}
+}
+
+func (m *Metrics) MarshalFailed(labels run.MetricLabels, _ error) {
+	m.marshalFailures.WithLabelValues(labelValues(labels)...).Inc()
+}
+
+func (m *Metrics) UnmarshalFailed(labels run.MetricLabels, _ error) {
+	m.unmarshalFailures.WithLabelValues(labelValues(labels)...).Inc()
+}
+
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range m.collectors() {
+		c.Describe(ch)
+	}
+}
+
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range m.collectors() {
+		c.Collect(ch)
+	}
+}
+
+func (m *Metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.published, m.publishFailures, m.received, m.receiveFailures, m.marshalFailures, m.unmarshalFailures,
+		m.sendLatency, m.publishedSize, m.receivedSize,
+	}
+}
+
+func labelValues(labels run.MetricLabels) []string {
+	return []string{labels.Server, labels.Channel, labels.Operation, labels.Message, labels.Protocol}
+}
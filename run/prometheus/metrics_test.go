@@ -0,0 +1,103 @@
+package prometheus
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bdragon300/go-asyncapi/run"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var (
+	sendLabels    = run.MetricLabels{Server: "main", Channel: "orders", Operation: "send", Message: "order", Protocol: "kafka"}
+	receiveLabels = run.MetricLabels{Server: "main", Channel: "orders", Operation: "receive", Message: "order", Protocol: "kafka"}
+)
+
+func TestMetrics(t *testing.T) {
+	m := NewMetrics("app", WithLatencyBuckets([]float64{1}), WithSizeBuckets([]float64{64, 256}))
+	errFailed := errors.New("failed")
+
+	m.MessagePublished(sendLabels, 100, 250*time.Millisecond, nil)
+	m.MessagePublished(sendLabels, 10, 1500*time.Millisecond, errFailed)
+	m.MarshalFailed(sendLabels, errFailed)
+	m.MessageReceived(receiveLabels, 100, nil)
+	// Generated code reports the unmarshal failure as received message as well
+	m.UnmarshalFailed(receiveLabels, errFailed)
+	m.MessageReceived(receiveLabels, 10, errFailed)
+
+	const (
+		send    = `{channel="orders",message="order",operation="send",protocol="kafka",server="main"`
+		receive = `{channel="orders",message="order",operation="receive",protocol="kafka",server="main"`
+	)
+	want := `
+# HELP app_messages_published_total Number of messages sent successfully.
+# TYPE app_messages_published_total counter
+app_messages_published_total` + send + `} 1
+# HELP app_messages_publish_failures_total Number of messages failed to send.
+# TYPE app_messages_publish_failures_total counter
+app_messages_publish_failures_total` + send + `} 1
+# HELP app_messages_marshal_failures_total Number of messages failed to marshal.
+# TYPE app_messages_marshal_failures_total counter
+app_messages_marshal_failures_total` + send + `} 1
+# HELP app_messages_received_total Number of messages received.
+# TYPE app_messages_received_total counter
+app_messages_received_total` + receive + `} 2
+# HELP app_messages_receive_failures_total Number of received messages failed to handle, including the unmarshal failures.
+# TYPE app_messages_receive_failures_total counter
+app_messages_receive_failures_total` + receive + `} 1
+# HELP app_messages_unmarshal_failures_total Number of received messages failed to unmarshal.
+# TYPE app_messages_unmarshal_failures_total counter
+app_messages_unmarshal_failures_total` + receive + `} 1
+# HELP app_message_send_duration_seconds Time spent on sending a message.
+# TYPE app_message_send_duration_seconds histogram
+app_message_send_duration_seconds_bucket` + send + `,le="1"} 1
+app_message_send_duration_seconds_bucket` + send + `,le="+Inf"} 2
+app_message_send_duration_seconds_sum` + send + `} 1.75
+app_message_send_duration_seconds_count` + send + `} 2
+# HELP app_message_published_payload_bytes Payload size of sent messages.
+# TYPE app_message_published_payload_bytes histogram
+app_message_published_payload_bytes_bucket` + send + `,le="64"} 0
+app_message_published_payload_bytes_bucket` + send + `,le="256"} 1
+app_message_published_payload_bytes_bucket` + send + `,le="+Inf"} 1
+app_message_published_payload_bytes_sum` + send + `} 100
+app_message_published_payload_bytes_count` + send + `} 1
+# HELP app_message_received_payload_bytes Payload size of received messages.
+# TYPE app_message_received_payload_bytes histogram
+app_message_received_payload_bytes_bucket` + receive + `,le="64"} 1
+app_message_received_payload_bytes_bucket` + receive + `,le="256"} 2
+app_message_received_payload_bytes_bucket` + receive + `,le="+Inf"} 2
+app_message_received_payload_bytes_sum` + receive + `} 110
+app_message_received_payload_bytes_count` + receive + `} 2
+`
+	if err := testutil.CollectAndCompare(m, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMetricsDefaults(t *testing.T) {
+	m := NewMetrics("")
+	m.MessagePublished(sendLabels, 100, time.Millisecond, nil)
+	m.MessageReceived(receiveLabels, 100, nil)
+
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(m); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	// Metrics without namespace, all 9 are described even without samples
+	if n, err := testutil.GatherAndCount(reg); err != nil || n != 5 {
+		t.Errorf("GatherAndCount() = %d, %v, want 5 series", n, err)
+	}
+	if n := testutil.CollectAndCount(m, "message_send_duration_seconds"); n != 1 {
+		t.Errorf("message_send_duration_seconds series = %d, want 1", n)
+	}
+	problems, err := testutil.CollectAndLint(m)
+	if err != nil {
+		t.Fatalf("CollectAndLint() error = %v", err)
+	}
+	for _, p := range problems {
+		t.Errorf("lint %s: %s", p.Metric, p.Text)
+	}
+}
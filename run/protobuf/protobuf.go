@@ -0,0 +1,469 @@
+// Package protobuf is a minimal Protocol Buffers binary format codec for the message payload structs, that
+// go-asyncapi generates from the .proto message definitions.
+//
+// The struct fields are mapped to the protobuf fields by "protobuf" struct tag. The tag format is
+// `protobuf:"<field number>,<type>"`, where type is a protobuf scalar type name, "enum" or "message". For map fields
+// the tag format is `protobuf:"<field number>,map,<key type>,<value type>"`. Slices (except []byte) are treated as
+// repeated fields, pointers are treated as fields with explicit presence. Fields without a tag are ignored.
+//
+// The generated structs have no protoc descriptors, so google.golang.org/protobuf can't encode them. This codec
+// encodes them by reflection, so the generated code doesn't depend on protoc and on the protobuf library. The wire
+// format compatibility with google.golang.org/protobuf is checked by the tests in e2e module.
+package protobuf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Wire types
+const (
+	wireVarint = 0
+	wireI64    = 1
+	wireLen    = 2
+	wireSGroup = 3
+	wireEGroup = 4
+	wireI32    = 5
+)
+
+var errTruncated = errors.New("unexpected end of data")
+
+// Marshal encodes v to the protobuf binary format. v must be a struct or a pointer to struct.
+func Marshal(v any) ([]byte, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("protobuf: cannot marshal %T, struct expected", v)
+	}
+	return appendStruct(nil, rv)
+}
+
+// Unmarshal decodes the protobuf binary data into v. v must be a non-nil pointer to struct.
+func Unmarshal(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("protobuf: cannot unmarshal to %T, non-nil pointer to struct expected", v)
+	}
+	return decodeStruct(data, rv.Elem())
+}
+
+type fieldInfo struct {
+	index   int
+	number  uint64
+	typ     string
+	keyType string // map key type
+	valType string // map value type
+}
+
+var fieldsCache sync.Map // map[reflect.Type][]fieldInfo
+
+func structFields(t reflect.Type) ([]fieldInfo, error) {
+	if v, ok := fieldsCache.Load(t); ok {
+		return v.([]fieldInfo), nil
+	}
+
+	var res []fieldInfo
+	for i := 0; i < t.NumField(); i++ {
+		tag, ok := t.Field(i).Tag.Lookup("protobuf")
+		if !ok || !t.Field(i).IsExported() {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("protobuf: field %s.%s: invalid tag %q", t.Name(), t.Field(i).Name, tag)
+		}
+		num, err := strconv.ParseUint(parts[0], 10, 32)
+		if err != nil || num == 0 {
+			return nil, fmt.Errorf("protobuf: field %s.%s: invalid field number in tag %q", t.Name(), t.Field(i).Name, tag)
+		}
+		fi := fieldInfo{index: i, number: num, typ: parts[1]}
+		if fi.typ == "map" {
+			if len(parts) < 4 {
+				return nil, fmt.Errorf("protobuf: field %s.%s: map key and value types are required in tag %q", t.Name(), t.Field(i).Name, tag)
+			}
+			fi.keyType, fi.valType = parts[2], parts[3]
+		}
+		res = append(res, fi)
+	}
+
+	fieldsCache.Store(t, res)
+	return res, nil
+}
+
+//
+// Encoding
+//
+
+func appendStruct(b []byte, rv reflect.Value) ([]byte, error) {
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range fields {
+		fv := rv.Field(f.index)
+		switch {
+		case f.typ == "map":
+			b, err = appendMap(b, f, fv)
+		case fv.Kind() == reflect.Slice && f.typ != "bytes":
+			b, err = appendRepeated(b, f, fv)
+		case fv.Kind() == reflect.Pointer:
+			// Explicit presence, the value is encoded even if it is zero
+			if !fv.IsNil() {
+				b, err = appendField(b, f.number, f.typ, fv.Elem())
+			}
+		default:
+			// Implicit presence, zero values are not encoded
+			if !fv.IsZero() {
+				b, err = appendField(b, f.number, f.typ, fv)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("field %d: %w", f.number, err)
+		}
+	}
+	return b, nil
+}
+
+func appendMap(b []byte, f fieldInfo, fv reflect.Value) ([]byte, error) {
+	iter := fv.MapRange()
+	for iter.Next() {
+		entry, err := appendField(nil, 1, f.keyType, iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		if entry, err = appendField(entry, 2, f.valType, reflect.Indirect(iter.Value())); err != nil {
+			return nil, err
+		}
+		b = appendTag(b, f.number, wireLen)
+		b = binary.AppendUvarint(b, uint64(len(entry)))
+		b = append(b, entry...)
+	}
+	return b, nil
+}
+
+func appendRepeated(b []byte, f fieldInfo, fv reflect.Value) ([]byte, error) {
+	if fv.Len() == 0 {
+		return b, nil
+	}
+
+	var err error
+	if isPackable(f.typ) {
+		var packed []byte
+		for i := 0; i < fv.Len(); i++ {
+			if packed, err = appendScalar(packed, f.typ, fv.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		b = appendTag(b, f.number, wireLen)
+		b = binary.AppendUvarint(b, uint64(len(packed)))
+		return append(b, packed...), nil
+	}
+
+	for i := 0; i < fv.Len(); i++ {
+		if b, err = appendField(b, f.number, f.typ, reflect.Indirect(fv.Index(i))); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func appendField(b []byte, number uint64, typ string, v reflect.Value) ([]byte, error) {
+	wt, err := wireType(typ)
+	if err != nil {
+		return nil, err
+	}
+	b = appendTag(b, number, wt)
+	return appendScalar(b, typ, v)
+}
+
+func appendScalar(b []byte, typ string, v reflect.Value) ([]byte, error) {
+	switch typ {
+	case "int32", "int64", "uint32", "uint64", "bool", "enum":
+		return binary.AppendUvarint(b, toUint64(v)), nil
+	case "sint32", "sint64":
+		x := toInt64(v)
+		return binary.AppendUvarint(b, uint64(x<<1)^uint64(x>>63)), nil
+	case "fixed32", "sfixed32":
+		return binary.LittleEndian.AppendUint32(b, uint32(toUint64(v))), nil
+	case "fixed64", "sfixed64":
+		return binary.LittleEndian.AppendUint64(b, toUint64(v)), nil
+	case "float":
+		return binary.LittleEndian.AppendUint32(b, math.Float32bits(float32(v.Float()))), nil
+	case "double":
+		return binary.LittleEndian.AppendUint64(b, math.Float64bits(v.Float())), nil
+	case "string":
+		b = binary.AppendUvarint(b, uint64(v.Len()))
+		return append(b, v.String()...), nil
+	case "bytes":
+		b = binary.AppendUvarint(b, uint64(v.Len()))
+		return append(b, v.Bytes()...), nil
+	case "message":
+		msg, err := appendStruct(nil, v)
+		if err != nil {
+			return nil, err
+		}
+		b = binary.AppendUvarint(b, uint64(len(msg)))
+		return append(b, msg...), nil
+	}
+	return nil, fmt.Errorf("unknown type %q", typ)
+}
+
+func appendTag(b []byte, number uint64, wt int) []byte {
+	return binary.AppendUvarint(b, number<<3|uint64(wt))
+}
+
+func toUint64(v reflect.Value) uint64 {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return 1
+		}
+		return 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// Negative int32 values are sign-extended to 64 bits
+		return uint64(v.Int())
+	default:
+		return v.Uint()
+	}
+}
+
+func toInt64(v reflect.Value) int64 {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	default:
+		return int64(v.Uint())
+	}
+}
+
+func wireType(typ string) (int, error) {
+	switch typ {
+	case "int32", "int64", "uint32", "uint64", "sint32", "sint64", "bool", "enum":
+		return wireVarint, nil
+	case "fixed64", "sfixed64", "double":
+		return wireI64, nil
+	case "fixed32", "sfixed32", "float":
+		return wireI32, nil
+	case "string", "bytes", "message":
+		return wireLen, nil
+	}
+	return 0, fmt.Errorf("unknown type %q", typ)
+}
+
+func isPackable(typ string) bool {
+	wt, err := wireType(typ)
+	return err == nil && wt != wireLen
+}
+
+//
+// Decoding
+//
+
+func decodeStruct(data []byte, rv reflect.Value) error {
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errTruncated
+		}
+		data = data[n:]
+		number, wt := tag>>3, int(tag&7)
+
+		var raw []byte
+		if raw, data, err = consumeValue(data, wt); err != nil {
+			return fmt.Errorf("field %d: %w", number, err)
+		}
+
+		f, ok := findField(fields, number)
+		if !ok {
+			continue // Unknown field
+		}
+		if err = decodeField(raw, wt, f, rv.Field(f.index)); err != nil {
+			return fmt.Errorf("field %d: %w", number, err)
+		}
+	}
+	return nil
+}
+
+func findField(fields []fieldInfo, number uint64) (fieldInfo, bool) {
+	for _, f := range fields {
+		if f.number == number {
+			return f, true
+		}
+	}
+	return fieldInfo{}, false
+}
+
+// consumeValue returns the value bytes of the given wire type and the rest of data. For LEN values, the length prefix
+// is stripped.
+func consumeValue(data []byte, wt int) (value, rest []byte, err error) {
+	switch wt {
+	case wireVarint:
+		_, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, nil, errTruncated
+		}
+		return data[:n], data[n:], nil
+	case wireI64:
+		if len(data) < 8 {
+			return nil, nil, errTruncated
+		}
+		return data[:8], data[8:], nil
+	case wireI32:
+		if len(data) < 4 {
+			return nil, nil, errTruncated
+		}
+		return data[:4], data[4:], nil
+	case wireLen:
+		l, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < l {
+			return nil, nil, errTruncated
+		}
+		return data[n : n+int(l)], data[n+int(l):], nil
+	case wireSGroup:
+		// Deprecated groups are skipped entirely
+		for {
+			tag, n := binary.Uvarint(data)
+			if n <= 0 {
+				return nil, nil, errTruncated
+			}
+			data = data[n:]
+			if int(tag&7) == wireEGroup {
+				return nil, data, nil
+			}
+			if _, data, err = consumeValue(data, int(tag&7)); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("unsupported wire type %d", wt)
+}
+
+func decodeField(raw []byte, wt int, f fieldInfo, fv reflect.Value) error {
+	switch {
+	case f.typ == "map":
+		return decodeMapEntry(raw, f, fv)
+	case fv.Kind() == reflect.Slice && f.typ != "bytes":
+		if wt == wireLen && isPackable(f.typ) {
+			return decodePacked(raw, f, fv)
+		}
+		elem := reflect.New(fv.Type().Elem()).Elem()
+		if err := decodeValue(raw, f.typ, elem); err != nil {
+			return err
+		}
+		fv.Set(reflect.Append(fv, elem))
+		return nil
+	}
+	return decodeValue(raw, f.typ, fv)
+}
+
+func decodePacked(raw []byte, f fieldInfo, fv reflect.Value) error {
+	wt, _ := wireType(f.typ)
+	for len(raw) > 0 {
+		var item []byte
+		var err error
+		if item, raw, err = consumeValue(raw, wt); err != nil {
+			return err
+		}
+		elem := reflect.New(fv.Type().Elem()).Elem()
+		if err = decodeValue(item, f.typ, elem); err != nil {
+			return err
+		}
+		fv.Set(reflect.Append(fv, elem))
+	}
+	return nil
+}
+
+func decodeMapEntry(raw []byte, f fieldInfo, fv reflect.Value) error {
+	if fv.IsNil() {
+		fv.Set(reflect.MakeMap(fv.Type()))
+	}
+	key := reflect.New(fv.Type().Key()).Elem()
+	val := reflect.New(fv.Type().Elem()).Elem()
+	for len(raw) > 0 {
+		tag, n := binary.Uvarint(raw)
+		if n <= 0 {
+			return errTruncated
+		}
+		raw = raw[n:]
+		var item []byte
+		var err error
+		if item, raw, err = consumeValue(raw, int(tag&7)); err != nil {
+			return err
+		}
+		switch tag >> 3 {
+		case 1:
+			err = decodeValue(item, f.keyType, key)
+		case 2:
+			err = decodeValue(item, f.valType, val)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	fv.SetMapIndex(key, val)
+	return nil
+}
+
+func decodeValue(raw []byte, typ string, v reflect.Value) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	switch typ {
+	case "int32", "int64", "uint32", "uint64", "bool", "enum":
+		x, _ := binary.Uvarint(raw)
+		return setNumber(v, x)
+	case "sint32", "sint64":
+		x, _ := binary.Uvarint(raw)
+		return setNumber(v, uint64(int64(x>>1)^-int64(x&1)))
+	case "fixed32", "sfixed32":
+		x := binary.LittleEndian.Uint32(raw)
+		if typ == "sfixed32" {
+			return setNumber(v, uint64(int64(int32(x))))
+		}
+		return setNumber(v, uint64(x))
+	case "fixed64", "sfixed64":
+		return setNumber(v, binary.LittleEndian.Uint64(raw))
+	case "float":
+		v.SetFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(raw))))
+	case "double":
+		v.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(raw)))
+	case "string":
+		v.SetString(string(raw))
+	case "bytes":
+		v.SetBytes(append([]byte(nil), raw...))
+	case "message":
+		return decodeStruct(raw, v)
+	default:
+		return fmt.Errorf("unknown type %q", typ)
+	}
+	return nil
+}
+
+func setNumber(v reflect.Value, x uint64) error {
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(x != 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(x))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(x)
+	default:
+		return fmt.Errorf("cannot set a number to %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+package protobuf
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+type testInner struct {
+	Name  string `protobuf:"1,string"`
+	Value *int32 `protobuf:"2,int32"`
+}
+
+type testMessage struct {
+	Int32    int32                `protobuf:"1,int32"`
+	Int64    int64                `protobuf:"2,int64"`
+	Uint32   uint32               `protobuf:"3,uint32"`
+	Uint64   uint64               `protobuf:"4,uint64"`
+	Sint32   int32                `protobuf:"5,sint32"`
+	Sint64   int64                `protobuf:"6,sint64"`
+	Fixed32  uint32               `protobuf:"7,fixed32"`
+	Fixed64  uint64               `protobuf:"8,fixed64"`
+	Sfixed32 int32                `protobuf:"9,sfixed32"`
+	Sfixed64 int64                `protobuf:"10,sfixed64"`
+	Float    float32              `protobuf:"11,float"`
+	Double   float64              `protobuf:"12,double"`
+	Bool     bool                 `protobuf:"13,bool"`
+	String   string               `protobuf:"14,string"`
+	Bytes    []byte               `protobuf:"15,bytes"`
+	Color    int32                `protobuf:"16,enum"`
+	Sints    []int32              `protobuf:"17,sint32"`
+	Doubles  []float64            `protobuf:"18,double"`
+	Strings  []string             `protobuf:"19,string"`
+	Inner    *testInner           `protobuf:"20,message"`
+	Inners   []testInner          `protobuf:"21,message"`
+	Counts   map[string]int64     `protobuf:"22,map,string,int64"`
+	ByID     map[int32]*testInner `protobuf:"23,map,int32,message"`
+	Optional *int64               `protobuf:"24,int64"`
+	Ignored  string
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// roundTripTests are the testMessage values covering every field kind. The same values are checked against
+// google.golang.org/protobuf library in e2e tests.
+var roundTripTests = []struct {
+	name  string
+	value testMessage
+}{
+	{
+		name:  "empty",
+		value: testMessage{},
+	},
+	{
+		name: "varint",
+		value: testMessage{
+			Int32: -1, Int64: -1 << 40, Uint32: 1<<32 - 1, Uint64: 1<<64 - 1, Bool: true, Color: 2,
+		},
+	},
+	{
+		name:  "zigzag",
+		value: testMessage{Sint32: -2147483648, Sint64: 9223372036854775807},
+	},
+	{
+		name:  "fixed",
+		value: testMessage{Fixed32: 7, Fixed64: 1 << 63, Sfixed32: -7, Sfixed64: -1 << 63, Float: 1.5, Double: -0.25},
+	},
+	{
+		name:  "length delimited",
+		value: testMessage{String: "héllo", Bytes: []byte{0, 1, 2}},
+	},
+	{
+		name:  "packed repeated",
+		value: testMessage{Sints: []int32{0, -1, 1, -300}, Doubles: []float64{1, 2.5}},
+	},
+	{
+		name:  "repeated strings",
+		value: testMessage{Strings: []string{"a", "", "c"}},
+	},
+	{
+		name: "nested messages",
+		value: testMessage{
+			Inner:  &testInner{Name: "x", Value: ptr[int32](0)},
+			Inners: []testInner{{Name: "a"}, {Value: ptr[int32](-5)}},
+		},
+	},
+	{
+		name: "maps",
+		value: testMessage{
+			Counts: map[string]int64{"a": 1, "b": -2, "": 0},
+			ByID:   map[int32]*testInner{1: {Name: "one"}, -1: {Value: ptr[int32](3)}},
+		},
+	},
+	{
+		name:  "explicit presence",
+		value: testMessage{Optional: ptr[int64](0)},
+	},
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, tt := range roundTripTests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Marshal(&tt.value)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			var got testMessage
+			if err = Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.value) {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.value)
+			}
+		})
+	}
+}
+
+func TestMarshalWire(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  []byte
+	}{
+		{
+			name:  "zigzag",
+			value: testMessage{Sint32: -1, Sint64: 1},
+			want:  []byte{0x28, 0x01, 0x30, 0x02},
+		},
+		{
+			name:  "negative int32 is sign-extended",
+			value: testMessage{Int32: -1},
+			want:  []byte{0x08, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01},
+		},
+		{
+			name:  "packed repeated",
+			value: testMessage{Sints: []int32{1, -1}},
+			want:  []byte{0x8a, 0x01, 0x02, 0x02, 0x01},
+		},
+		{
+			name:  "repeated strings are not packed",
+			value: testMessage{Strings: []string{"a", "b"}},
+			want:  []byte{0x9a, 0x01, 0x01, 'a', 0x9a, 0x01, 0x01, 'b'},
+		},
+		{
+			name:  "map entry",
+			value: testMessage{Counts: map[string]int64{"a": 1}},
+			want:  []byte{0xb2, 0x01, 0x05, 0x0a, 0x01, 'a', 0x10, 0x01},
+		},
+		{
+			name:  "fixed",
+			value: testMessage{Fixed32: 7, Sfixed64: -1},
+			want:  []byte{0x3d, 0x07, 0, 0, 0, 0x51, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		},
+		{
+			name:  "floating point",
+			value: testMessage{Float: 1.5, Double: -0.25},
+			want:  []byte{0x5d, 0, 0, 0xc0, 0x3f, 0x61, 0, 0, 0, 0, 0, 0, 0xd0, 0xbf},
+		},
+		{
+			name:  "bool, bytes and enum",
+			value: testMessage{Bool: true, Bytes: []byte{0, 1}, Color: 2},
+			want:  []byte{0x68, 0x01, 0x7a, 0x02, 0, 0x01, 0x80, 0x01, 0x02},
+		},
+		{
+			name:  "message and explicit presence",
+			value: testMessage{Inner: &testInner{Name: "x"}, Optional: ptr[int64](0)},
+			want:  []byte{0xa2, 0x01, 0x03, 0x0a, 0x01, 'x', 0xc0, 0x01, 0x00},
+		},
+		{
+			name:  "untagged fields are ignored",
+			value: testMessage{Ignored: "x"},
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.value)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("Marshal() = % x, want % x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalWire(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    testMessage
+		wantErr bool
+	}{
+		{
+			name: "unpacked repeated",
+			data: []byte{0x88, 0x01, 0x01, 0x88, 0x01, 0x04},
+			want: testMessage{Sints: []int32{-1, 2}},
+		},
+		{
+			name: "unknown fields are skipped",
+			data: []byte{
+				0xa0, 0x06, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x02, // 100: varint 1<<50
+				0xa9, 0x06, 0x01, 0, 0, 0, 0, 0, 0, 0, // 101: fixed64
+				0x72, 0x05, 'k', 'n', 'o', 'w', 'n', // 14: "known"
+				0xb2, 0x06, 0x07, 'u', 'n', 'k', 'n', 'o', 'w', 'n', // 102: bytes
+				0xbd, 0x06, 0x01, 0, 0, 0, // 103: fixed32
+				0xc3, 0x06, 0x08, 0x01, 0xc4, 0x06, // 104: group
+				0x08, 0x2a, // 1: 42
+			},
+			want: testMessage{String: "known", Int32: 42},
+		},
+		{
+			name: "last value wins",
+			data: []byte{0x08, 0x01, 0x08, 0x02},
+			want: testMessage{Int32: 2},
+		},
+		{
+			name:    "truncated varint",
+			data:    []byte{0x08, 0xff},
+			wantErr: true,
+		},
+		{
+			name:    "truncated length",
+			data:    []byte{0x72, 0x05, 'a'},
+			wantErr: true,
+		},
+		{
+			name:    "truncated fixed64",
+			data:    []byte{0x41, 0x01, 0x02},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported wire type",
+			data:    []byte{0x0e},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got testMessage
+			err := Unmarshal(tt.data, &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvalidArguments(t *testing.T) {
+	type badNumber struct {
+		A int32 `protobuf:"0,int32"`
+	}
+	type badMap struct {
+		A map[string]string `protobuf:"1,map,string"`
+	}
+	type badType struct {
+		A int32 `protobuf:"1,int128"`
+	}
+
+	tests := []struct {
+		name string
+		fn   func() error
+	}{
+		{"marshal non-struct", func() error { _, err := Marshal(1); return err }},
+		{"unmarshal non-pointer", func() error { return Unmarshal(nil, testMessage{}) }},
+		{"unmarshal nil pointer", func() error { return Unmarshal(nil, (*testMessage)(nil)) }},
+		{"zero field number", func() error { _, err := Marshal(badNumber{A: 1}); return err }},
+		{"map without value type", func() error { _, err := Marshal(badMap{}); return err }},
+		{"unknown type", func() error { _, err := Marshal(badType{A: 1}); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.fn(); err == nil {
+				t.Error("error expected, got nil")
+			}
+		})
+	}
+}
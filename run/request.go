@@ -0,0 +1,168 @@
+package run
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrRequestsClosed is returned from [Requests.Do] if the [Requests] has been closed while waiting for a reply.
+var ErrRequestsClosed = errors.New("requests closed")
+
+// NewCorrelationID returns a new random correlation ID.
+func NewCorrelationID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("cannot generate correlation id: %v", err))
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// NewRequests returns a new empty Requests.
+func NewRequests[K comparable, V any]() *Requests[K, V] {
+	return &Requests[K, V]{
+		mu:      &sync.Mutex{},
+		pending: make(map[K]chan V),
+	}
+}
+
+// Requests is the thread-safe table of requests that are waiting for replies. Replies are matched to requests by
+// correlation ID.
+//
+// All requests share the single reply subscription, that is started on the first request. If the subscription
+// fails, all pending requests return the error, and the next request starts a new subscription. Replies that don't
+// match any pending request are ignored.
+type Requests[K comparable, V any] struct {
+	mu      *sync.Mutex
+	pending map[K]chan V
+	sub     *requestsSubscription
+	closed  bool
+}
+
+type requestsSubscription struct {
+	cancel context.CancelFunc
+	ready  chan struct{}
+	done   chan struct{}
+	err    error
+}
+
+// Do registers the request with correlation ID id, starts the reply subscription if it is not running by calling
+// subscribe in a separate goroutine, waits until the subscription is ready, then calls send and waits for the reply
+// with the same correlation ID.
+//
+// The subscribe function must block until the subscription context is done or error occurs, calling resolve
+// for every received reply. The subscription is ready when [NotifySubscribeReady] is called with the subscription
+// context, so the reply can't be sent before the subscription is established.
+func (r *Requests[K, V]) Do(
+	ctx context.Context,
+	id K,
+	subscribe func(ctx context.Context, resolve func(id K, reply V)) error,
+	send func(ctx context.Context) error,
+) (V, error) {
+	var zero V
+
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return zero, ErrRequestsClosed
+	}
+	if _, ok := r.pending[id]; ok {
+		r.mu.Unlock()
+		return zero, fmt.Errorf("request with correlation id %v is already pending", id)
+	}
+	ch := make(chan V, 1)
+	r.pending[id] = ch
+	sub := r.ensureSubscription(subscribe)
+	r.mu.Unlock()
+
+	defer func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.pending, id)
+	}()
+
+	select {
+	case <-sub.ready:
+	case <-sub.done:
+		return zero, sub.err
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+
+	if err := send(ctx); err != nil {
+		return zero, err
+	}
+
+	select {
+	case reply := <-ch:
+		return reply, nil
+	case <-sub.done:
+		// Reply could arrive right before the subscription has finished
+		select {
+		case reply := <-ch:
+			return reply, nil
+		default:
+		}
+		return zero, sub.err
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// Close stops the reply subscription. Pending requests return [ErrRequestsClosed].
+func (r *Requests[K, V]) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+	if r.sub != nil {
+		r.sub.cancel()
+	}
+	return nil
+}
+
+// ensureSubscription starts the reply subscription if it is not running. Must be called with the lock held.
+func (r *Requests[K, V]) ensureSubscription(subscribe func(ctx context.Context, resolve func(id K, reply V)) error) *requestsSubscription {
+	if r.sub != nil {
+		return r.sub
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := &requestsSubscription{cancel: cancel, ready: make(chan struct{}), done: make(chan struct{})}
+	r.sub = sub
+	var readyOnce sync.Once
+	ctx = WithSubscribeReady(ctx, func() {
+		readyOnce.Do(func() { close(sub.ready) })
+	})
+	go func() {
+		err := subscribe(ctx, r.resolve)
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		switch {
+		case r.closed:
+			sub.err = ErrRequestsClosed
+		case err == nil || errors.Is(err, context.Canceled):
+			sub.err = errors.New("reply subscription has finished")
+		default:
+			sub.err = fmt.Errorf("reply subscription: %w", err)
+		}
+		r.sub = nil
+		cancel()
+		close(sub.done)
+	}()
+	return sub
+}
+
+func (r *Requests[K, V]) resolve(id K, reply V) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if ch, ok := r.pending[id]; ok {
+		delete(r.pending, id)
+		ch <- reply
+	}
+}
@@ -0,0 +1,99 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRequestsDo(t *testing.T) {
+	errSubscribe := errors.New("subscribe failed")
+	tests := []struct {
+		name      string
+		subscribe func(ctx context.Context, ready <-chan struct{}, sent <-chan string, resolve func(id, reply string)) error
+		timeout   time.Duration
+		want      string
+		wantErr   error
+		wantSent  bool
+	}{
+		{
+			name: "reply",
+			subscribe: func(ctx context.Context, ready <-chan struct{}, sent <-chan string, resolve func(id, reply string)) error {
+				<-ready
+				NotifySubscribeReady(ctx)
+				for {
+					select {
+					case id := <-sent:
+						resolve(id, "pong")
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+			},
+			timeout:  5 * time.Second,
+			want:     "pong",
+			wantSent: true,
+		},
+		{
+			name: "subscription fails before ready",
+			subscribe: func(_ context.Context, ready <-chan struct{}, _ <-chan string, _ func(id, reply string)) error {
+				<-ready
+				return errSubscribe
+			},
+			timeout: 5 * time.Second,
+			wantErr: errSubscribe,
+		},
+		{
+			name: "never ready",
+			subscribe: func(ctx context.Context, _ <-chan struct{}, _ <-chan string, _ func(id, reply string)) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			timeout: 50 * time.Millisecond,
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := NewRequests[string, string]()
+			defer requests.Close()
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+
+			// Subscription is established after a delay, the request must not be sent before it
+			ready := make(chan struct{})
+			time.AfterFunc(10*time.Millisecond, func() { close(ready) })
+			sent := make(chan string, 1)
+			var sentBeforeReady, isSent atomic.Bool
+			got, err := requests.Do(ctx, "1",
+				func(ctx context.Context, resolve func(id, reply string)) error {
+					return tt.subscribe(ctx, ready, sent, resolve)
+				},
+				func(_ context.Context) error {
+					select {
+					case <-ready:
+					default:
+						sentBeforeReady.Store(true)
+					}
+					isSent.Store(true)
+					sent <- "1"
+					return nil
+				},
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Do() = %q, want %q", got, tt.want)
+			}
+			if isSent.Load() != tt.wantSent {
+				t.Errorf("request sent = %v, want %v", isSent.Load(), tt.wantSent)
+			}
+			if sentBeforeReady.Load() {
+				t.Error("request is sent before the subscription is ready")
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// RetryPolicy is a policy of handling the received message again if the subscriber callback returns an error.
+// The interval between attempts grows exponentially.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts to handle a message, including the first one. If 0 or 1,
+	// the failed message is not retried and goes straight to the dead-letter handler.
+	MaxAttempts int
+	// InitialBackoff is the interval before the second attempt. Default is 100ms.
+	InitialBackoff time.Duration
+	// MaxBackoff limits the interval between attempts. No limit if 0.
+	MaxBackoff time.Duration
+	// Multiplier is the factor the interval is multiplied by after each attempt. Default is 2.
+	Multiplier float64
+}
+
+// Backoff returns the interval to wait after the given failed attempt (starting from 1).
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = 100 * time.Millisecond
+	}
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	res := float64(initial) * math.Pow(multiplier, float64(max(attempt-1, 0)))
+	if p.MaxBackoff > 0 && res > float64(p.MaxBackoff) {
+		return p.MaxBackoff
+	}
+	return time.Duration(res)
+}
+
+// DeadLetterHandler gets the received message that has failed all handling attempts. Payload is the raw message
+// payload, err is the last error returned from handling. If the handler returns an error, the subscription stops.
+//
+// Type parameter E is the protocol's EnvelopeReader interface, e.g. kafka.EnvelopeReader.
+type DeadLetterHandler[E any] func(ctx context.Context, envelope E, payload []byte, err error) error
+
+// Redeliverer is implemented by the received envelopes of protocols, that can redeliver a message natively,
+// e.g. AMQP (nack with requeue) or NATS JetStream (nak with delay). Such envelopes are retried by the broker
+// instead of retrying in-process.
+type Redeliverer interface {
+	// DeliveryAttempt returns the delivery attempt number of the message, starting from 1. Returns false if
+	// the number is unknown, then the message is retried in-process.
+	DeliveryAttempt() (attempt int, ok bool)
+	// Redeliver asks the broker to deliver the message again after the delay.
+	Redeliver(delay time.Duration) error
+}
+
+// DeadLetterer is implemented by the received envelopes of protocols, that can reject a message natively without
+// redelivery, e.g. AMQP (nack without requeue, the message goes to the queue's dead-letter exchange if any).
+// It is used if there is no DeadLetterHandler.
+type DeadLetterer interface {
+	DeadLetter() error
+}
+
+// WithRetry returns an option that sets the retry policy for the received messages.
+func WithRetry(policy RetryPolicy) MiddlewareOption {
+	return func(m *Middlewares) {
+		m.retry = &policy
+	}
+}
+
+// WithDeadLetter returns an option that sets the handler of messages that have failed all handling attempts.
+// If the option is passed several times for the same envelope type, the last handler is used.
+func WithDeadLetter[E any](handler DeadLetterHandler[E]) MiddlewareOption {
+	return func(m *Middlewares) {
+		m.deadLetter = append(m.deadLetter, handler)
+	}
+}
+
+// HandleEnvelope calls the handler for the received envelope, applying the retry policy and the dead-letter handler
+// from m.
+//
+// If m has neither a retry policy nor a dead-letter handler for envelope type E, the handler is called once, and
+// its error is returned as is. Otherwise, the envelope payload is read into memory, and the handler gets a new
+// envelope made by rewind function on every attempt. If the envelope implements Redeliverer, the retries are
+// performed by the broker. Messages that could not be unsealed are not retried.
+//
+// The message that has failed all attempts is passed to the dead-letter handler, if any, or rejected natively if
+// the envelope implements DeadLetterer. Otherwise, the last error is returned.
+func HandleEnvelope[E io.Reader](
+	ctx context.Context,
+	m Middlewares,
+	envelope E,
+	rewind func(payload []byte) E,
+	handler func(ctx context.Context, envelope E) error,
+) error {
+	var deadLetter DeadLetterHandler[E]
+	for i := len(m.deadLetter) - 1; i >= 0 && deadLetter == nil; i-- {
+		deadLetter, _ = m.deadLetter[i].(DeadLetterHandler[E])
+	}
+	if m.retry == nil && deadLetter == nil {
+		return handler(ctx, envelope)
+	}
+
+	policy := RetryPolicy{MaxAttempts: 1}
+	if m.retry != nil {
+		policy = *m.retry
+	}
+	payload, err := io.ReadAll(envelope)
+	if err != nil {
+		return fmt.Errorf("read envelope: %w", err)
+	}
+
+	if v, ok := any(envelope).(Redeliverer); ok {
+		if attempt, ok := v.DeliveryAttempt(); ok {
+			if err = handler(ctx, rewind(payload)); err == nil {
+				return nil
+			}
+			if attempt < policy.MaxAttempts && !errors.Is(err, ErrUnsealEnvelope) {
+				return v.Redeliver(policy.Backoff(attempt))
+			}
+			return handleDeadLetter(ctx, envelope, payload, err, deadLetter)
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		if err = handler(ctx, rewind(payload)); err == nil {
+			return nil
+		}
+		if attempt >= policy.MaxAttempts || errors.Is(err, ErrUnsealEnvelope) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(policy.Backoff(attempt)):
+		}
+	}
+	return handleDeadLetter(ctx, envelope, payload, err, deadLetter)
+}
+
+func handleDeadLetter[E any](ctx context.Context, envelope E, payload []byte, err error, handler DeadLetterHandler[E]) error {
+	if handler != nil {
+		if e := handler(ctx, envelope, payload, err); e != nil {
+			return fmt.Errorf("dead letter: %w", e)
+		}
+		return nil
+	}
+	if v, ok := any(envelope).(DeadLetterer); ok {
+		return v.DeadLetter()
+	}
+	return err
+}
@@ -0,0 +1,381 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		want    time.Duration
+	}{
+		{name: "defaults first attempt", attempt: 1, want: 100 * time.Millisecond},
+		{name: "defaults third attempt", attempt: 3, want: 400 * time.Millisecond},
+		{name: "zero attempt", attempt: 0, want: 100 * time.Millisecond},
+		{
+			name:    "custom multiplier",
+			policy:  RetryPolicy{InitialBackoff: time.Second, Multiplier: 3},
+			attempt: 3,
+			want:    9 * time.Second,
+		},
+		{
+			name:    "max backoff",
+			policy:  RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second},
+			attempt: 4,
+			want:    5 * time.Second,
+		},
+		{
+			name:    "below max backoff",
+			policy:  RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second},
+			attempt: 2,
+			want:    2 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Backoff(tt.attempt); got != tt.want {
+				t.Errorf("Backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleEnvelope(t *testing.T) {
+	errHandle := errors.New("handle failed")
+	errUnseal := fmt.Errorf("%w: bad payload", ErrUnsealEnvelope)
+	errDeadLetter := errors.New("dead letter failed")
+	fastRetry := func(attempts int) MiddlewareOption {
+		return WithRetry(RetryPolicy{MaxAttempts: attempts, InitialBackoff: time.Millisecond})
+	}
+
+	tests := []struct {
+		name string
+		opts []MiddlewareOption
+		// errs are the errors returned by the handler on every call, the last one is repeated
+		errs []error
+		// deadLetterErr is returned by the dead-letter handler
+		deadLetterErr error
+		// deadLetterer makes the envelope implement DeadLetterer
+		deadLetterer   bool
+		wantCalls      int
+		wantErr        error
+		wantDeadLetter error
+		wantRejected   bool
+	}{
+		{
+			name:      "no options, success",
+			errs:      []error{nil},
+			wantCalls: 1,
+		},
+		{
+			name:      "no options, error is returned as is",
+			errs:      []error{errHandle},
+			wantCalls: 1,
+			wantErr:   errHandle,
+		},
+		{
+			name:      "succeeds after retries",
+			opts:      []MiddlewareOption{fastRetry(3)},
+			errs:      []error{errHandle, errHandle, nil},
+			wantCalls: 3,
+		},
+		{
+			name:      "max attempts, no dead-letter handler",
+			opts:      []MiddlewareOption{fastRetry(3)},
+			errs:      []error{errHandle},
+			wantCalls: 3,
+			wantErr:   errHandle,
+		},
+		{
+			name:           "max attempts, dead-letter handler",
+			opts:           []MiddlewareOption{fastRetry(3), WithDeadLetter(testDeadLetter)},
+			errs:           []error{errHandle},
+			wantCalls:      3,
+			wantDeadLetter: errHandle,
+		},
+		{
+			name:           "dead-letter handler only, no retries",
+			opts:           []MiddlewareOption{WithDeadLetter(testDeadLetter)},
+			errs:           []error{errHandle},
+			wantCalls:      1,
+			wantDeadLetter: errHandle,
+		},
+		{
+			name:           "dead-letter handler error stops the subscription",
+			opts:           []MiddlewareOption{WithDeadLetter(testDeadLetter)},
+			errs:           []error{errHandle},
+			deadLetterErr:  errDeadLetter,
+			wantCalls:      1,
+			wantErr:        errDeadLetter,
+			wantDeadLetter: errHandle,
+		},
+		{
+			name:           "unseal error is not retried",
+			opts:           []MiddlewareOption{fastRetry(3), WithDeadLetter(testDeadLetter)},
+			errs:           []error{errUnseal},
+			wantCalls:      1,
+			wantDeadLetter: ErrUnsealEnvelope,
+		},
+		{
+			name: "dead-letter handler for other envelope type is skipped",
+			opts: []MiddlewareOption{
+				fastRetry(2),
+				WithDeadLetter(func(context.Context, *bytes.Reader, []byte, error) error {
+					t.Error("dead-letter handler for other envelope type is called")
+					return nil
+				}),
+			},
+			errs:      []error{errHandle},
+			wantCalls: 2,
+			wantErr:   errHandle,
+		},
+		{
+			name: "last dead-letter handler is used",
+			opts: []MiddlewareOption{
+				WithDeadLetter(func(context.Context, io.Reader, []byte, error) error {
+					t.Error("overridden dead-letter handler is called")
+					return nil
+				}),
+				WithDeadLetter(testDeadLetter),
+			},
+			errs:           []error{errHandle},
+			wantCalls:      1,
+			wantDeadLetter: errHandle,
+		},
+		{
+			name:         "rejected natively without dead-letter handler",
+			opts:         []MiddlewareOption{fastRetry(2)},
+			errs:         []error{errHandle},
+			deadLetterer: true,
+			wantCalls:    2,
+			wantRejected: true,
+		},
+		{
+			name:           "dead-letter handler takes precedence over native rejection",
+			opts:           []MiddlewareOption{WithDeadLetter(testDeadLetter)},
+			errs:           []error{errHandle},
+			deadLetterer:   true,
+			wantCalls:      1,
+			wantDeadLetter: errHandle,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), testDeadLetterKey{}, &testDeadLetterResult{err: tt.deadLetterErr})
+			var envelope io.Reader = bytes.NewReader([]byte("payload"))
+			if tt.deadLetterer {
+				envelope = &testNativeEnvelope{Reader: bytes.NewReader([]byte("payload"))}
+			}
+
+			calls := 0
+			err := HandleEnvelope(ctx, NewMiddlewares(tt.opts...), envelope, testRewind, func(_ context.Context, envelope io.Reader) error {
+				// Every attempt gets the whole payload
+				if b, _ := io.ReadAll(envelope); string(b) != "payload" {
+					t.Errorf("attempt %d payload = %q, want %q", calls+1, b, "payload")
+				}
+				calls++
+				return tt.errs[min(calls, len(tt.errs))-1]
+			})
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("HandleEnvelope() error = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("handler calls = %d, want %d", calls, tt.wantCalls)
+			}
+
+			dl := ctx.Value(testDeadLetterKey{}).(*testDeadLetterResult)
+			if !errors.Is(dl.got, tt.wantDeadLetter) || (tt.wantDeadLetter == nil && dl.called) {
+				t.Errorf("dead-letter handler error = %v (called %v), want %v", dl.got, dl.called, tt.wantDeadLetter)
+			}
+			if dl.called && string(dl.payload) != "payload" {
+				t.Errorf("dead-letter handler payload = %q, want %q", dl.payload, "payload")
+			}
+			if v, ok := envelope.(*testNativeEnvelope); ok && v.rejected != tt.wantRejected {
+				t.Errorf("rejected = %v, want %v", v.rejected, tt.wantRejected)
+			}
+		})
+	}
+}
+
+func TestHandleEnvelopeRedeliverer(t *testing.T) {
+	errHandle := errors.New("handle failed")
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Second}
+
+	tests := []struct {
+		name           string
+		opts           []MiddlewareOption
+		attempt        int
+		unknownAttempt bool
+		err            error
+		wantCalls      int
+		wantRedeliver  []time.Duration
+		wantRejected   bool
+		wantDeadLetter bool
+		wantErr        error
+	}{
+		{
+			name:      "success",
+			opts:      []MiddlewareOption{WithRetry(policy)},
+			attempt:   1,
+			wantCalls: 1,
+		},
+		{
+			name:          "first attempt is redelivered by the broker",
+			opts:          []MiddlewareOption{WithRetry(policy)},
+			attempt:       1,
+			err:           errHandle,
+			wantCalls:     1,
+			wantRedeliver: []time.Duration{time.Second},
+		},
+		{
+			name:          "backoff grows with attempt number",
+			opts:          []MiddlewareOption{WithRetry(policy)},
+			attempt:       2,
+			err:           errHandle,
+			wantCalls:     1,
+			wantRedeliver: []time.Duration{2 * time.Second},
+		},
+		{
+			name:         "last attempt is rejected natively",
+			opts:         []MiddlewareOption{WithRetry(policy)},
+			attempt:      3,
+			err:          errHandle,
+			wantCalls:    1,
+			wantRejected: true,
+		},
+		{
+			name:           "last attempt goes to dead-letter handler",
+			opts:           []MiddlewareOption{WithRetry(policy), WithDeadLetter(testDeadLetter)},
+			attempt:        3,
+			err:            errHandle,
+			wantCalls:      1,
+			wantDeadLetter: true,
+		},
+		{
+			name:           "unseal error is not redelivered",
+			opts:           []MiddlewareOption{WithRetry(policy), WithDeadLetter(testDeadLetter)},
+			attempt:        1,
+			err:            fmt.Errorf("%w: bad payload", ErrUnsealEnvelope),
+			wantCalls:      1,
+			wantDeadLetter: true,
+		},
+		{
+			name: "unknown attempt is retried in-process",
+			opts: []MiddlewareOption{
+				WithRetry(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+			},
+			unknownAttempt: true,
+			err:            errHandle,
+			wantCalls:      3,
+			wantRejected:   true,
+		},
+		{
+			name:      "no options, redelivery is not used",
+			attempt:   1,
+			err:       errHandle,
+			wantCalls: 1,
+			wantErr:   errHandle,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), testDeadLetterKey{}, &testDeadLetterResult{})
+			envelope := &testNativeEnvelope{Reader: bytes.NewReader([]byte("payload")), attempt: tt.attempt, known: !tt.unknownAttempt}
+
+			calls := 0
+			err := HandleEnvelope[io.Reader](ctx, NewMiddlewares(tt.opts...), envelope, testRewind, func(context.Context, io.Reader) error {
+				calls++
+				return tt.err
+			})
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("HandleEnvelope() error = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("handler calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if !slices.Equal(envelope.redelivered, tt.wantRedeliver) {
+				t.Errorf("redelivered = %v, want %v", envelope.redelivered, tt.wantRedeliver)
+			}
+			if envelope.rejected != tt.wantRejected {
+				t.Errorf("rejected = %v, want %v", envelope.rejected, tt.wantRejected)
+			}
+			if dl := ctx.Value(testDeadLetterKey{}).(*testDeadLetterResult); dl.called != tt.wantDeadLetter {
+				t.Errorf("dead-letter handler called = %v, want %v", dl.called, tt.wantDeadLetter)
+			}
+		})
+	}
+}
+
+func TestHandleEnvelopeCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewMiddlewares(WithRetry(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour}))
+
+	calls := 0
+	err := HandleEnvelope[io.Reader](ctx, m, bytes.NewReader(nil), testRewind, func(context.Context, io.Reader) error {
+		calls++
+		cancel()
+		return errors.New("handle failed")
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("HandleEnvelope() error = %v, want %v", err, context.Canceled)
+	}
+	if calls != 1 {
+		t.Errorf("handler calls = %d, want 1", calls)
+	}
+}
+
+func testRewind(payload []byte) io.Reader {
+	return bytes.NewReader(payload)
+}
+
+type testDeadLetterKey struct{}
+
+// testDeadLetterResult records the dead-letter handler call. It's passed in context, since the handler is
+// a plain function.
+type testDeadLetterResult struct {
+	err     error
+	called  bool
+	got     error
+	payload []byte
+}
+
+func testDeadLetter(ctx context.Context, _ io.Reader, payload []byte, err error) error {
+	res := ctx.Value(testDeadLetterKey{}).(*testDeadLetterResult)
+	res.called = true
+	res.got = err
+	res.payload = payload
+	return res.err
+}
+
+// testNativeEnvelope implements Redeliverer and DeadLetterer.
+type testNativeEnvelope struct {
+	io.Reader
+	attempt     int
+	known       bool
+	redelivered []time.Duration
+	rejected    bool
+}
+
+func (e *testNativeEnvelope) DeliveryAttempt() (int, bool) {
+	return e.attempt, e.known
+}
+
+func (e *testNativeEnvelope) Redeliver(delay time.Duration) error {
+	e.redelivered = append(e.redelivered, delay)
+	return nil
+}
+
+func (e *testNativeEnvelope) DeadLetter() error {
+	e.rejected = true
+	return nil
+}
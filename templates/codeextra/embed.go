@@ -13,6 +13,9 @@ type ImplementationManifest struct {
 	URL      string `yaml:"url"`
 	Dir      string `yaml:"dir"`
 	Default  bool   `yaml:"default"`
+	// Include are the template files from other directories, that are rendered to the implementation package along
+	// with templates from Dir. Used to share the code between implementations.
+	Include []string `yaml:"include"`
 }
 
 type ImplementationManifests []ImplementationManifest
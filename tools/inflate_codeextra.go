@@ -151,13 +151,20 @@ func render(dirs []string, renderManager *manager.TemplateRenderManager) error {
 		if err != nil {
 			return fmt.Errorf("parse templates in dir %q: %w", dir, err)
 		}
+		man := findImpl(dir)
+		if man != nil && len(man.Include) > 0 {
+			included, err := tplLoader.ParseFiles(renderManager, man.Include...)
+			if err != nil {
+				return fmt.Errorf("parse included templates in dir %q: %w", dir, err)
+			}
+			templates = append(templates, included...)
+		}
 
 		for _, templateName := range templates {
 			ctx := tmpl.CodeExtraTemplateContext{
 				RenderOpts:  renderManager.RenderOpts,
 				PackageName: utils.ToGolangName(path.Base(dir), false),
 			}
-			man := findImpl(dir)
 			if man != nil {
 				ctx.Protocol = man.Protocol
 				ctx.Manifest = man